@@ -0,0 +1,102 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// AccessListReply is reply for AccessList.Get and AccessList.Set requests.
+type AccessListReply struct {
+	Allow []string
+	Deny  []string
+}
+
+// AccessListArgs is arguments that AccessList.Set accepts.
+type AccessListArgs struct {
+	AdminAuth
+	// Allow is list of CIDRs, IPs and node references of peers that are allowed to connect, empty list allows all
+	Allow []string
+	// Deny is list of CIDRs, IPs and node references of peers whose packets are dropped
+	Deny []string
+}
+
+// AccessListService is a service that manages allow and deny lists of network peers.
+type AccessListService struct {
+	runner *Runner
+}
+
+// NewAccessListService creates new AccessList service instance.
+func NewAccessListService(runner *Runner) *AccessListService {
+	return &AccessListService{runner: runner}
+}
+
+// Get returns rules of allow and deny lists.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "access.Get",
+//	  "id": str|int|null
+//	}
+func (s *AccessListService) Get(r *http.Request, args *interface{}, reply *AccessListReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ AccessListService.Get ] Incoming request: %s", r.RequestURI)
+
+	reply.Allow, reply.Deny = s.runner.HostAccessList.GetAccessRules()
+	return nil
+}
+
+// Set replaces rules of allow and deny lists without restart of node. Lists are not changed if any rule is invalid.
+// Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "access.Set",
+//	  "params": {
+//	    "Allow": []str, // CIDRs, IPs and node references
+//	    "Deny": []str,
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *AccessListService) Set(r *http.Request, args *AccessListArgs, reply *AccessListReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ AccessListService.Set ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "access.Set", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ AccessListService.Set ]")
+	}
+	if err := s.runner.HostAccessList.SetAccessRules(args.Allow, args.Deny); err != nil {
+		return errors.Wrap(err, "[ AccessListService.Set ] Failed to update access lists")
+	}
+	inslogger.FromContext(ctx).Warnf("[ AccessListService.Set ] Access lists are updated: allow %v, deny %v", args.Allow, args.Deny)
+
+	reply.Allow, reply.Deny = s.runner.HostAccessList.GetAccessRules()
+	return nil
+}
@@ -0,0 +1,291 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// AdminAuth is signature of admin action by operator key registered in certificate. It is embedded into params
+// of admin actions.
+type AdminAuth struct {
+	// Operator is PEM encoded public key of operator
+	Operator string
+	// Seed is seed got with seed.Get, it can be used only once
+	Seed []byte
+	// Signature is signature of AdminActionBytes made with operator key
+	Signature []byte
+}
+
+// adminAuthFields are JSON fields of AdminAuth, they are not covered by signature.
+var adminAuthFields = []string{"Operator", "Seed", "Signature"}
+
+// adminParams returns canonical JSON of action params without AdminAuth fields: keys are sorted and values
+// are encoded as they are decoded by API.
+func adminParams(params interface{}) ([]byte, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal params")
+	}
+	fields := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal params")
+	}
+	for _, field := range adminAuthFields {
+		delete(fields, field)
+	}
+	return json.Marshal(fields)
+}
+
+// AdminActionBytes returns bytes operator signs to authorize admin action with params.
+func AdminActionBytes(action string, params interface{}, seed []byte) ([]byte, error) {
+	data, err := adminParams(params)
+	if err != nil {
+		return nil, err
+	}
+	return core.MarshalArgs(action, data, seed)
+}
+
+// isOperator checks if key is registered in certificate as operator key.
+func (ar *Runner) isOperator(key []byte) bool {
+	kp := platformpolicy.NewKeyProcessor()
+	for _, operator := range ar.CertificateManager.GetCertificate().GetOperatorPublicKeys() {
+		operatorKey, err := kp.ExportPublicKeyPEM(operator)
+		if err == nil && bytes.Equal(operatorKey, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeAdmin checks that action is signed by operator and records it to admin log. Action must not be
+// executed if error is returned.
+func (ar *Runner) authorizeAdmin(ctx context.Context, action string, auth AdminAuth, params interface{}) error {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.ImportPublicKeyPEM([]byte(auth.Operator))
+	if err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ] Failed to parse operator key")
+	}
+	// operator key is compared in canonical form, PEM of request may differ in formatting
+	canonical, err := kp.ExportPublicKeyPEM(key)
+	if err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ] Failed to export operator key")
+	}
+	if !ar.isOperator(canonical) {
+		return errors.New("[ authorizeAdmin ] Key is not registered as operator key in certificate")
+	}
+
+	data, err := adminParams(params)
+	if err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ]")
+	}
+	signed, err := core.MarshalArgs(action, data, auth.Seed)
+	if err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ] Can't marshal action for verify signature")
+	}
+	if !scheme.Verifier(key).Verify(core.SignatureFromBytes(auth.Signature), signed) {
+		return errors.New("[ authorizeAdmin ] Incorrect signature")
+	}
+	if err := ar.checkSeed(ctx, auth.Seed); err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ]")
+	}
+
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ] Can't get current pulse")
+	}
+	entry, err := ar.AdminLog.AppendAdminAction(ctx, core.AdminLogEntry{
+		Time:      time.Now().UTC(),
+		Pulse:     pulse.PulseNumber,
+		Operator:  string(canonical),
+		Action:    action,
+		Params:    data,
+		Seed:      auth.Seed,
+		Signature: auth.Signature,
+	})
+	if err != nil {
+		return errors.Wrap(err, "[ authorizeAdmin ] Failed to record action to admin log")
+	}
+	inslogger.FromContext(ctx).Warnf("[ authorizeAdmin ] Admin action %s #%d is authorized: %s", action, entry.Index, data)
+	return nil
+}
+
+// AdminLogArgs is arguments that Admin.Log accepts.
+type AdminLogArgs struct {
+	PageArgs
+	// From is index of first entry, entries are indexed from 1. It is ignored if Cursor is set
+	From uint64
+}
+
+// AdminLogEntry is signed admin action recorded to log.
+type AdminLogEntry struct {
+	Index     uint64
+	Time      string
+	Pulse     core.PulseNumber
+	Operator  string
+	Action    string
+	Params    json.RawMessage
+	Seed      []byte
+	Signature []byte
+	PrevHash  []byte
+	Hash      []byte
+}
+
+func (e AdminLogEntry) pagePosition() Cursor {
+	return Cursor{Pulse: e.Pulse, Position: e.Index}
+}
+
+func (e AdminLogEntry) pageField(name string) (interface{}, bool) {
+	switch name {
+	case "Index":
+		return e.Index, true
+	case "Time":
+		return e.Time, true
+	case "Pulse":
+		return uint64(e.Pulse), true
+	case "Operator":
+		return e.Operator, true
+	case "Action":
+		return e.Action, true
+	}
+	return nil, false
+}
+
+// AdminLogReply is reply for Admin.Log requests.
+type AdminLogReply struct {
+	PageInfo
+	Entries []AdminLogEntry
+}
+
+const (
+	// defaultAdminLogLimit is count of entries returned by Admin.Log if limit is not set.
+	defaultAdminLogLimit = 100
+	// maxAdminLogLimit is max count of entries returned by Admin.Log.
+	maxAdminLogLimit = 1000
+)
+
+// AdminService is a service that provides log of admin actions.
+type AdminService struct {
+	runner *Runner
+}
+
+// NewAdminService creates new Admin service instance.
+func NewAdminService(runner *Runner) *AdminService {
+	return &AdminService{runner: runner}
+}
+
+// Log returns admin actions recorded by node. Every entry contains hash of previous one, so entries can't be
+// removed or changed unnoticed.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "admin.Log",
+//	  "params": {
+//	    "From": int, // index of first entry, optional
+//	    "Cursor": str, // NextCursor of previous page, optional
+//	    "Limit": int, // max count of entries, 100 by default
+//	    "Filter": {"Action": str, "Operator": str, "Pulse": str}, // optional
+//	    "Sort": str // Index, Time, Pulse, Operator or Action, "-" prefix for descending order, optional
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *AdminService) Log(r *http.Request, args *AdminLogArgs, reply *AdminLogReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ AdminService.Log ] Incoming request: %s", r.RequestURI)
+
+	if !args.simple() {
+		entries, err := s.entries(ctx, args.From, 0)
+		if err != nil {
+			return errors.Wrap(err, "[ AdminService.Log ] Failed to get admin log")
+		}
+		items := make([]pageItem, len(entries))
+		for i, e := range entries {
+			items[i] = e
+		}
+		page, info, err := args.page(items, defaultAdminLogLimit, maxAdminLogLimit)
+		if err != nil {
+			return errors.Wrap(err, "[ AdminService.Log ]")
+		}
+		reply.PageInfo = info
+		reply.Entries = make([]AdminLogEntry, len(page))
+		for i, item := range page {
+			reply.Entries[i] = item.(AdminLogEntry)
+		}
+		return nil
+	}
+
+	// without filter and sort entries are read from log starting right after cursor
+	from := args.From
+	if args.Cursor != "" {
+		cursor, err := decodeCursor(args.Cursor, "")
+		if err != nil {
+			return errors.Wrap(err, "[ AdminService.Log ]")
+		}
+		from = cursor.Position + 1
+	}
+	limit := args.limit(defaultAdminLogLimit, maxAdminLogLimit)
+	entries, err := s.entries(ctx, from, limit+1)
+	if err != nil {
+		return errors.Wrap(err, "[ AdminService.Log ] Failed to get admin log")
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		reply.NextCursor = encodeCursor(entries[limit-1].pagePosition(), "")
+	}
+	reply.Entries = entries
+	return nil
+}
+
+// entries reads up to limit entries of admin log starting from index, all entries are read if limit is zero.
+func (s *AdminService) entries(ctx context.Context, from uint64, limit int) ([]AdminLogEntry, error) {
+	entries, err := s.runner.AdminLog.GetAdminActions(ctx, from, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]AdminLogEntry, len(entries))
+	for i, e := range entries {
+		result[i] = AdminLogEntry{
+			Index:     e.Index,
+			Time:      e.Time.Format(time.RFC3339),
+			Pulse:     e.Pulse,
+			Operator:  e.Operator,
+			Action:    e.Action,
+			Params:    e.Params,
+			Seed:      e.Seed,
+			Signature: e.Signature,
+			PrevHash:  e.PrevHash,
+			Hash:      e.Hash,
+		}
+	}
+	return result, nil
+}
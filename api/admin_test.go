@@ -0,0 +1,177 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"testing"
+
+	"github.com/insolar/insolar/api/seedmanager"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func adminRunner(t *testing.T, operators ...crypto.PublicKey) (*Runner, *[]core.AdminLogEntry) {
+	cert := testutils.NewCertificateMock(t)
+	cert.GetOperatorPublicKeysMock.Return(operators)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: 100, NextPulseNumber: 110}, nil)
+
+	var entries []core.AdminLogEntry
+	log := testutils.NewAdminLogMock(t)
+	log.AppendAdminActionFunc = func(_ context.Context, entry core.AdminLogEntry) (*core.AdminLogEntry, error) {
+		entry.Index = uint64(len(entries) + 1)
+		entries = append(entries, entry)
+		return &entry, nil
+	}
+	log.GetAdminActionsFunc = func(_ context.Context, from uint64, limit int) ([]core.AdminLogEntry, error) {
+		var result []core.AdminLogEntry
+		for _, e := range entries {
+			if e.Index >= from && (limit == 0 || len(result) < limit) {
+				result = append(result, e)
+			}
+		}
+		return result, nil
+	}
+
+	return &Runner{
+		CertificateManager: cm,
+		PulseStorage:       ps,
+		AdminLog:           log,
+		SeedManager:        seedmanager.New(),
+	}, &entries
+}
+
+func signAdminAction(t *testing.T, runner *Runner, key crypto.PrivateKey, action string, params interface{}) AdminAuth {
+	kp := platformpolicy.NewKeyProcessor()
+	operator, err := kp.ExportPublicKeyPEM(kp.ExtractPublicKey(key))
+	require.NoError(t, err)
+
+	seed, err := runner.SeedGenerator.Next()
+	require.NoError(t, err)
+	runner.SeedManager.Add(*seed)
+
+	data, err := AdminActionBytes(action, params, seed[:])
+	require.NoError(t, err)
+	signature, err := scheme.Signer(key).Sign(data)
+	require.NoError(t, err)
+	return AdminAuth{Operator: string(operator), Seed: seed[:], Signature: signature.Bytes()}
+}
+
+func TestRunner_authorizeAdmin(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	operatorKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	strangerKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	runner, entries := adminRunner(t, kp.ExtractPublicKey(operatorKey))
+
+	args := &UnbanArgs{Address: "127.0.0.1"}
+	args.AdminAuth = signAdminAction(t, runner, operatorKey, "banlist.Unban", args)
+	require.NoError(t, runner.authorizeAdmin(ctx, "banlist.Unban", args.AdminAuth, args))
+	require.Len(t, *entries, 1)
+	entry := (*entries)[0]
+	assert.Equal(t, "banlist.Unban", entry.Action)
+	assert.Equal(t, `{"Address":"127.0.0.1"}`, string(entry.Params))
+	assert.Equal(t, core.PulseNumber(100), entry.Pulse)
+	assert.Equal(t, args.Operator, entry.Operator)
+
+	// seed can't be used twice
+	require.Error(t, runner.authorizeAdmin(ctx, "banlist.Unban", args.AdminAuth, args))
+
+	// params are covered by signature
+	args.AdminAuth = signAdminAction(t, runner, operatorKey, "banlist.Unban", args)
+	changed := *args
+	changed.Address = "127.0.0.2"
+	require.Error(t, runner.authorizeAdmin(ctx, "banlist.Unban", changed.AdminAuth, &changed))
+
+	// action is covered by signature
+	require.Error(t, runner.authorizeAdmin(ctx, "access.Set", args.AdminAuth, args))
+
+	// key must be registered in certificate
+	args.AdminAuth = signAdminAction(t, runner, strangerKey, "banlist.Unban", args)
+	require.Error(t, runner.authorizeAdmin(ctx, "banlist.Unban", args.AdminAuth, args))
+
+	require.Len(t, *entries, 1)
+}
+
+func TestAdminService_Log(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	operatorKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	runner, _ := adminRunner(t, kp.ExtractPublicKey(operatorKey))
+	auth := signAdminAction(t, runner, operatorKey, "drill.Stop", &AdminAuth{})
+	require.NoError(t, runner.authorizeAdmin(ctx, "drill.Stop", auth, &auth))
+
+	reply := &AdminLogReply{}
+	err = NewAdminService(runner).Log(&http.Request{}, &AdminLogArgs{}, reply)
+	require.NoError(t, err)
+	require.Len(t, reply.Entries, 1)
+	assert.Equal(t, uint64(1), reply.Entries[0].Index)
+	assert.Equal(t, "drill.Stop", reply.Entries[0].Action)
+	assert.Equal(t, "{}", string(reply.Entries[0].Params))
+}
+
+func TestAdminService_LogPages(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	operatorKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	runner, _ := adminRunner(t, kp.ExtractPublicKey(operatorKey))
+	for _, action := range []string{"drill.Start", "drill.Stop", "drill.Start"} {
+		auth := signAdminAction(t, runner, operatorKey, action, &AdminAuth{})
+		require.NoError(t, runner.authorizeAdmin(ctx, action, auth, &auth))
+	}
+	service := NewAdminService(runner)
+
+	args := &AdminLogArgs{PageArgs: PageArgs{Limit: 2}}
+	reply := &AdminLogReply{}
+	require.NoError(t, service.Log(&http.Request{}, args, reply))
+	require.Len(t, reply.Entries, 2)
+	require.NotEmpty(t, reply.NextCursor)
+
+	args.Cursor = reply.NextCursor
+	reply = &AdminLogReply{}
+	require.NoError(t, service.Log(&http.Request{}, args, reply))
+	require.Len(t, reply.Entries, 1)
+	assert.Equal(t, uint64(3), reply.Entries[0].Index)
+	assert.Empty(t, reply.NextCursor)
+
+	args = &AdminLogArgs{PageArgs: PageArgs{Filter: map[string]string{"Action": "drill.Start"}, Sort: "-Index"}}
+	reply = &AdminLogReply{}
+	require.NoError(t, service.Log(&http.Request{}, args, reply))
+	require.Len(t, reply.Entries, 2)
+	assert.Equal(t, uint64(3), reply.Entries[0].Index)
+	assert.Equal(t, uint64(1), reply.Entries[1].Index)
+
+	args = &AdminLogArgs{PageArgs: PageArgs{Sort: "Unknown"}}
+	require.Error(t, service.Log(&http.Request{}, args, &AdminLogReply{}))
+}
@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/pkg/errors"
+)
+
+// resolveReference returns reference in base58 form, if reference is not a valid base58 reference,
+// it's treated as alias and resolved by alias registry of RootDomain
+func (ar *Runner) resolveReference(ctx context.Context, reference string) (string, error) {
+	if _, err := core.NewRefFromBase58(reference); err == nil {
+		return reference, nil
+	}
+
+	res, err := ar.ContractRequester.SendRequest(
+		ctx,
+		ar.CertificateManager.GetCertificate().GetRootDomainReference(),
+		"ResolveAlias",
+		[]interface{}{reference},
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "[ resolveReference ] Can't send request")
+	}
+
+	resolved, err := extractor.AliasResponse(res.(*reply.CallMethod).Result)
+	if err != nil {
+		return "", errors.Wrap(err, "[ resolveReference ] Can't resolve alias")
+	}
+	return resolved, nil
+}
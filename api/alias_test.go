@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_resolveReference(t *testing.T) {
+	ctx := context.Background()
+	rootDomain := testutils.RandomRef()
+	aliased := testutils.RandomRef()
+
+	cert := testutils.NewCertificateMock(t)
+	cert.GetRootDomainReferenceMock.Return(&rootDomain)
+	cm := testutils.NewCertificateManagerMock(t)
+	cm.GetCertificateMock.Return(cert)
+
+	cr := testutils.NewContractRequesterMock(t)
+	cr.SendRequestFunc = func(_ context.Context, ref *core.RecordRef, method string, args []interface{}) (core.Reply, error) {
+		require.Equal(t, rootDomain, *ref)
+		require.Equal(t, "ResolveAlias", method)
+
+		var result string
+		var contractErr *foundation.Error
+		if args[0] == "feepool" {
+			result = aliased.String()
+		} else {
+			contractErr = &foundation.Error{S: "Alias not found"}
+		}
+		data, _ := core.MarshalArgs(result, contractErr)
+		return &reply.CallMethod{Result: data}, nil
+	}
+
+	ar := &Runner{CertificateManager: cm, ContractRequester: cr}
+
+	res, err := ar.resolveReference(ctx, aliased.String())
+	require.NoError(t, err)
+	require.Equal(t, aliased.String(), res)
+	require.Equal(t, uint64(0), cr.SendRequestCounter)
+
+	res, err = ar.resolveReference(ctx, "feepool")
+	require.NoError(t, err)
+	require.Equal(t, aliased.String(), res)
+
+	_, err = ar.resolveReference(ctx, "unknown")
+	require.Contains(t, err.Error(), "Alias not found")
+}
@@ -0,0 +1,166 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// BannedHost is a host banned by network for misbehaviour.
+type BannedHost struct {
+	Address string
+	Score   int
+	Until   string
+}
+
+func (h BannedHost) pagePosition() Cursor {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(h.Address))
+	return Cursor{Position: hash.Sum64()}
+}
+
+func (h BannedHost) pageField(name string) (interface{}, bool) {
+	switch name {
+	case "Address":
+		return h.Address, true
+	case "Score":
+		return int64(h.Score), true
+	case "Until":
+		return h.Until, true
+	}
+	return nil, false
+}
+
+// BanListArgs is arguments that BanList.List accepts.
+type BanListArgs struct {
+	PageArgs
+}
+
+// BanListReply is reply for BanList.List requests.
+type BanListReply struct {
+	PageInfo
+	Hosts []BannedHost
+}
+
+const (
+	// defaultBanListLimit is count of hosts returned by BanList.List if limit is not set.
+	defaultBanListLimit = 100
+	// maxBanListLimit is max count of hosts returned by BanList.List.
+	maxBanListLimit = 1000
+)
+
+// UnbanArgs is arguments that BanList.Unban accepts.
+type UnbanArgs struct {
+	AdminAuth
+	// Address is IP of banned host
+	Address string
+}
+
+// UnbanReply is reply for BanList.Unban requests.
+type UnbanReply struct {
+	Unbanned bool
+}
+
+// BanListService is a service that manages hosts banned by network.
+type BanListService struct {
+	runner *Runner
+}
+
+// NewBanListService creates new BanList service instance.
+func NewBanListService(runner *Runner) *BanListService {
+	return &BanListService{runner: runner}
+}
+
+// List returns hosts banned for timeouts, malformed packets or failed authorization ordered by address.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "banlist.List",
+//	  "params": {
+//	    "Cursor": str, // NextCursor of previous page, optional
+//	    "Limit": int, // max count of hosts, 100 by default
+//	    "Filter": {"Address": str, "Score": str}, // optional
+//	    "Sort": str // Address, Score or Until, "-" prefix for descending order, optional
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *BanListService) List(r *http.Request, args *BanListArgs, reply *BanListReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ BanListService.List ] Incoming request: %s", r.RequestURI)
+
+	hosts := s.runner.HostBanList.GetBannedHosts()
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Address < hosts[j].Address
+	})
+	items := make([]pageItem, len(hosts))
+	for i, h := range hosts {
+		items[i] = BannedHost{
+			Address: h.Address,
+			Score:   h.Score,
+			Until:   h.Until.UTC().Format(time.RFC3339),
+		}
+	}
+	page, info, err := args.page(items, defaultBanListLimit, maxBanListLimit)
+	if err != nil {
+		return errors.Wrap(err, "[ BanListService.List ]")
+	}
+	reply.PageInfo = info
+	reply.Hosts = make([]BannedHost, len(page))
+	for i, item := range page {
+		reply.Hosts[i] = item.(BannedHost)
+	}
+	return nil
+}
+
+// Unban removes host from ban list and resets its score. Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "banlist.Unban",
+//	  "params": {
+//	    "Address": str, // IP of banned host
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *BanListService) Unban(r *http.Request, args *UnbanArgs, reply *UnbanReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ BanListService.Unban ] Incoming request: %s", r.RequestURI)
+
+	if args.Address == "" {
+		return errors.New("[ BanListService.Unban ] Address must not be empty")
+	}
+	if err := s.runner.authorizeAdmin(ctx, "banlist.Unban", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ BanListService.Unban ]")
+	}
+	reply.Unbanned = s.runner.HostBanList.UnbanHost(args.Address)
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/insolar/insolar/api/requester"
 	"github.com/insolar/insolar/api/seedmanager"
 	"github.com/insolar/insolar/application/extractor"
 	"github.com/insolar/insolar/core"
@@ -45,12 +46,37 @@ type Request struct {
 	Params    []byte `json:"params"`
 	Seed      []byte `json:"seed"`
 	Signature []byte `json:"signature"`
+	// Timeout is optional timeout of request in seconds, it can't exceed MaxTimeout from api config
+	Timeout uint32 `json:"timeout,omitempty"`
+	// Trace requests steps of contract calls to be returned with answer, execution trace must be enabled in config
+	Trace bool `json:"trace,omitempty"`
 }
 
+// Codes of errors, that api returns in code field of answer.
+const (
+	// TimeoutErrorCode means that request was not completed in time and was aborted
+	TimeoutErrorCode = "timeout"
+	// CanceledErrorCode means that client has gone before request was completed
+	CanceledErrorCode = "canceled"
+	// CommitRequiredErrorCode means that method is executed in fair ordering mode and request wasn't committed properly
+	CommitRequiredErrorCode = "commit_required"
+)
+
 type answer struct {
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
 	Result  interface{} `json:"result,omitempty"`
 	TraceID string      `json:"traceID,omitempty"`
+
+	Trace []core.ExecutionTraceEvent `json:"trace,omitempty"`
+
+	Receipt *requester.Receipt `json:"receipt,omitempty"`
+}
+
+// callResult is result of contract method with reference of request registered on ledger.
+type callResult struct {
+	request core.RecordRef
+	result  interface{}
 }
 
 // UnmarshalRequest unmarshals request to api
@@ -99,20 +125,39 @@ func (ar *Runner) verifySignature(ctx context.Context, params Request) error {
 	return nil
 }
 
-func (ar *Runner) checkSeed(paramsSeed []byte) error {
+// requestHash returns hash of request fields covered by signature.
+func requestHash(params Request) ([]byte, error) {
+	ref, err := core.NewRefFromBase58(params.Reference)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ requestHash ] failed to parse params.Reference")
+	}
+	args, err := core.MarshalArgs(*ref, params.Method, params.Params, params.Seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ requestHash ] Can't marshal request")
+	}
+	return scheme.IntegrityHasher().Hash(args), nil
+}
+
+func (ar *Runner) checkSeed(ctx context.Context, paramsSeed []byte) error {
 	seed := seedmanager.SeedFromBytes(paramsSeed)
 	if seed == nil {
 		return errors.New("[ checkSeed ] Bad seed param")
 	}
 
-	if !ar.SeedManager.Exists(*seed) {
-		return errors.New("[ checkSeed ] Incorrect seed")
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ checkSeed ] Can't get current pulse")
+	}
+
+	err = ar.SeedManager.Consume(*seed, pulse.PulseNumber)
+	if err != nil {
+		return errors.Wrap(err, "[ checkSeed ] Incorrect seed")
 	}
 
 	return nil
 }
 
-func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, error) {
+func (ar *Runner) makeCall(ctx context.Context, params Request) (*callResult, error) {
 	ctx, span := instracer.StartSpan(ctx, "SendRequest "+params.Method)
 	defer span.End()
 
@@ -132,7 +177,8 @@ func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, er
 		return nil, errors.Wrap(err, "[ makeCall ] Can't send request")
 	}
 
-	result, contractErr, err := extractor.CallResponse(res.(*reply.CallMethod).Result)
+	callReply := res.(*reply.CallMethod)
+	result, contractErr, err := extractor.CallResponse(callReply.Result)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "[ makeCall ] Can't extract response")
@@ -142,7 +188,37 @@ func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, er
 		return nil, errors.Wrap(errors.New(contractErr.S), "[ makeCall ] Error in called method")
 	}
 
-	return result, nil
+	return &callResult{request: callReply.Request, result: result}, nil
+}
+
+// execute makes call of contract method, methods designated for fair ordering wait for their turn in the batch.
+func (ar *Runner) execute(ctx context.Context, params Request) (*callResult, error) {
+	if !ar.orderer.designated(params.Method) {
+		return ar.makeCall(ctx, params)
+	}
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ execute ] Can't get current pulse")
+	}
+	hash, err := requestHash(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ execute ] Can't calculate request hash")
+	}
+	res, err := ar.orderer.reveal(ctx, pulse, hash, func(ctx context.Context) (interface{}, error) {
+		return ar.makeCall(ctx, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*callResult), nil
+}
+
+// processOrderingError sets dedicated error code if request of fair ordering method was not committed properly.
+func processOrderingError(err error, resp *answer) {
+	switch errors.Cause(err) {
+	case ErrNotCommitted, ErrRevealTooEarly:
+		resp.Code = CommitRequiredErrorCode
+	}
 }
 
 func processError(err error, extraMsg string, resp *answer, insLog core.Logger) {
@@ -150,10 +226,38 @@ func processError(err error, extraMsg string, resp *answer, insLog core.Logger)
 	insLog.Error(errors.Wrapf(err, "[ CallHandler ] %s", extraMsg))
 }
 
+// processContextError fills answer with dedicated error code if request was aborted by its context.
+func processContextError(err error, resp *answer, insLog core.Logger) bool {
+	switch errors.Cause(err) {
+	case context.DeadlineExceeded:
+		resp.Error = "Messagebus timeout exceeded"
+		resp.Code = TimeoutErrorCode
+	case context.Canceled:
+		resp.Error = "Request canceled"
+		resp.Code = CanceledErrorCode
+	default:
+		return false
+	}
+	insLog.Warn(errors.Wrap(err, "[ CallHandler ] Request aborted"))
+	return true
+}
+
+// requestTimeout returns timeout requested by client, limited by MaxTimeout, or default timeout from config.
+func (ar *Runner) requestTimeout(requested uint32) time.Duration {
+	timeout := ar.cfg.Timeout
+	if requested != 0 {
+		timeout = requested
+	}
+	if ar.cfg.MaxTimeout != 0 && timeout > ar.cfg.MaxTimeout {
+		timeout = ar.cfg.MaxTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
 func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 	return func(response http.ResponseWriter, req *http.Request) {
 		traceID := utils.RandTraceID()
-		ctx, insLog := inslogger.WithTraceField(context.Background(), traceID)
+		ctx, insLog := inslogger.WithTraceField(req.Context(), traceID)
 
 		ctx, span := instracer.StartSpan(ctx, "callHandler")
 		defer span.End()
@@ -166,8 +270,23 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 			success := "success"
 			if resp.Error != "" {
 				success = "fail"
+				code := resp.Code
+				if code == "" {
+					code = "error"
+				}
+				metrics.APIErrorsTotal.WithLabelValues("call", code).Inc()
 			}
 			metrics.APIContractExecutionTime.WithLabelValues(params.Method, success).Observe(time.Since(startTime).Seconds())
+			if ar.explorer != nil {
+				ar.explorer.recordRequest(ExplorerRequest{
+					TraceID:   traceID,
+					Reference: params.Reference,
+					Method:    params.Method,
+					Time:      startTime,
+					Duration:  time.Since(startTime),
+					Error:     resp.Error,
+				})
+			}
 		}()
 
 		resp.TraceID = traceID
@@ -192,44 +311,73 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 			return
 		}
 
-		err = ar.checkSeed(params.Seed)
+		ctx, cancel := context.WithTimeout(ctx, ar.requestTimeout(params.Timeout))
+		defer cancel()
+
+		err = ar.checkSeed(ctx, params.Seed)
 		if err != nil {
 			processError(err, "Can't checkSeed", &resp, insLog)
 			return
 		}
 
+		params.Reference, err = ar.resolveReference(ctx, params.Reference)
+		if err != nil {
+			if !processContextError(err, &resp, insLog) {
+				processError(err, "Can't resolve reference", &resp, insLog)
+			}
+			return
+		}
+
 		err = ar.verifySignature(ctx, params)
 		if err != nil {
-			processError(err, "Can't verify signature", &resp, insLog)
+			if !processContextError(err, &resp, insLog) {
+				processError(err, "Can't verify signature", &resp, insLog)
+			}
 			return
 		}
 
-		var result interface{}
+		if params.Trace {
+			err = ar.ExecutionTracer.StartTrace(traceID)
+			if err != nil {
+				processError(err, "Can't start execution trace", &resp, insLog)
+				return
+			}
+			defer func() {
+				resp.Trace = ar.ExecutionTracer.FinishTrace(traceID)
+			}()
+		}
+
+		var result *callResult
 		ch := make(chan interface{}, 1)
 		go func() {
-			result, err = ar.makeCall(ctx, params)
+			result, err = ar.execute(ctx, params)
 			ch <- nil
 		}()
 		select {
 
 		case <-ch:
 			if err != nil {
-				processError(err, "Can't makeCall", &resp, insLog)
+				if !processContextError(err, &resp, insLog) {
+					processError(err, "Can't makeCall", &resp, insLog)
+					processOrderingError(err, &resp)
+				}
 				return
 			}
-			resp.Result = result
+			if params.Method == sendMessageMethod {
+				ar.notifyInbox(ctx, params, result.result)
+			}
+			resp.Result = result.result
+			if ar.cfg.Receipts != 0 {
+				resp.Receipt, err = ar.issueReceipt(result.request, result.result)
+				if err != nil {
+					insLog.Warn(errors.Wrap(err, "[ CallHandler ] Can't issue receipt"))
+				}
+			}
 
-		case <-time.After(time.Duration(ar.cfg.Timeout) * time.Second):
-			resp.Error = "Messagebus timeout exceeded"
+		case <-ctx.Done():
+			processContextError(ctx.Err(), &resp, insLog)
 			return
 
 		}
-
-		if err != nil {
-			processError(err, "Can't makeCall", &resp, insLog)
-			return
-		}
-
-		resp.Result = result
 	}
 }
@@ -18,15 +18,18 @@ package api
 
 import (
 	"context"
+	"crypto"
 	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/api/seedmanager"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/platformpolicy"
@@ -36,18 +39,22 @@ import (
 )
 
 const CallUrl = "http://localhost:19192/api/call"
+const APIUrl = "http://localhost:19192/api"
 
 type TimeoutSuite struct {
 	suite.Suite
-	ctx   context.Context
-	api   *Runner
-	user  *requester.UserConfigJSON
-	delay bool
+	ctx     context.Context
+	api     *Runner
+	user    *requester.UserConfigJSON
+	nodeKey crypto.PublicKey
+	delay   bool
 }
 
 type APIresp struct {
-	Result string
-	Error  string
+	Result  string
+	Error   string
+	Code    string
+	Receipt *requester.Receipt
 }
 
 func (suite *TimeoutSuite) TestRunner_callHandler() {
@@ -69,6 +76,14 @@ func (suite *TimeoutSuite) TestRunner_callHandler() {
 	suite.NoError(err)
 	suite.Equal("", result.Error)
 	suite.Equal("OK", result.Result)
+
+	suite.Require().NotNil(result.Receipt)
+	suite.NoError(requester.VerifyReceipt(result.Receipt, result.Result, suite.nodeKey))
+	suite.Error(requester.VerifyReceipt(result.Receipt, "NOT OK", suite.nodeKey))
+
+	receipt, err := requester.GetReceipt(APIUrl, result.Receipt.Request)
+	suite.NoError(err)
+	suite.Equal(result.Receipt, receipt)
 }
 
 func (suite *TimeoutSuite) TestRunner_callHandlerTimeout() {
@@ -90,9 +105,42 @@ func (suite *TimeoutSuite) TestRunner_callHandlerTimeout() {
 	err = json.Unmarshal(resp, &result)
 	suite.NoError(err)
 	suite.Equal("Messagebus timeout exceeded", result.Error)
+	suite.Equal(TimeoutErrorCode, result.Code)
 	suite.Equal("", result.Result)
 }
 
+func (suite *TimeoutSuite) TestRunner_callHandlerReplay() {
+	seed, err := suite.api.SeedGenerator.Next()
+	suite.NoError(err)
+	suite.api.SeedManager.Add(*seed)
+
+	var result APIresp
+	resp, err := requester.SendWithSeed(suite.ctx, CallUrl, suite.user, &requester.RequestConfigJSON{}, seed[:])
+	suite.NoError(err)
+	err = json.Unmarshal(resp, &result)
+	suite.NoError(err)
+	suite.Equal("", result.Error)
+
+	// seed is issued again, but it's already consumed in current pulse
+	suite.api.SeedManager.Add(*seed)
+	resp, err = requester.SendWithSeed(suite.ctx, CallUrl, suite.user, &requester.RequestConfigJSON{}, seed[:])
+	suite.NoError(err)
+	err = json.Unmarshal(resp, &result)
+	suite.NoError(err)
+	suite.Contains(result.Error, seedmanager.ErrSeedConsumed.Error())
+}
+
+func TestRunner_requestTimeout(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	cfg.Timeout = 15
+	cfg.MaxTimeout = 60
+	ar := &Runner{cfg: &cfg}
+
+	require.Equal(t, 15*time.Second, ar.requestTimeout(0))
+	require.Equal(t, 5*time.Second, ar.requestTimeout(5))
+	require.Equal(t, 60*time.Second, ar.requestTimeout(100))
+}
+
 func TestTimeoutSuite(t *testing.T) {
 	timeoutSuite := new(TimeoutSuite)
 	timeoutSuite.ctx, _ = inslogger.WithTraceField(context.Background(), "APItests")
@@ -106,6 +154,10 @@ func TestTimeoutSuite(t *testing.T) {
 	pKeyString, err := ks.ExportPublicKeyPEM(pKey)
 	require.NoError(t, err)
 
+	nodeKey, err := ks.GeneratePrivateKey()
+	require.NoError(t, err)
+	timeoutSuite.nodeKey = ks.ExtractPublicKey(nodeKey)
+
 	userRef := testutils.RandomRef().String()
 	timeoutSuite.user, err = requester.CreateUserConfig(userRef, string(sKeyString))
 
@@ -120,6 +172,8 @@ func TestTimeoutSuite(t *testing.T) {
 		ref := testutils.RandomRef()
 		return &ref
 	}
+	nodeRef := testutils.RandomRef()
+	cert.GetNodeRefMock.Return(&nodeRef)
 
 	cm := testutils.NewCertificateManagerMock(t)
 	cm.GetCertificateFunc = func() (r core.Certificate) {
@@ -144,13 +198,19 @@ func TestTimeoutSuite(t *testing.T) {
 			var contractErr *foundation.Error
 			data, _ := core.MarshalArgs(result, contractErr)
 			return &reply.CallMethod{
-				Result: data,
+				Request: testutils.RandomRef(),
+				Result:  data,
 			}, nil
 		}
 	}
 
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(core.GenesisPulse, nil)
+
 	timeoutSuite.api.ContractRequester = cr
+	timeoutSuite.api.PulseStorage = ps
 	timeoutSuite.api.CertificateManager = cm
+	timeoutSuite.api.CryptographyService = cryptography.NewKeyBoundCryptographyService(nodeKey)
 	timeoutSuite.api.Start(timeoutSuite.ctx)
 
 	requester.SetTimeout(25)
@@ -0,0 +1,234 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// CaptureStartArgs is arguments that Capture.Start accepts.
+type CaptureStartArgs struct {
+	AdminAuth
+	// PerPeer is count of the last packets kept for each peer
+	PerPeer int
+	// PayloadSize is max size of captured payload of packet
+	PayloadSize int
+}
+
+// CapturePacketsArgs is arguments that Capture.Packets accepts.
+type CapturePacketsArgs struct {
+	AdminAuth
+	PageArgs
+	// Peer is address or reference of node, packets of all peers are returned if empty
+	Peer string
+}
+
+// CapturedPacket is packet captured by network.
+type CapturedPacket struct {
+	Time       string
+	Outgoing   bool
+	Address    string
+	Sender     string
+	Receiver   string
+	Type       string
+	RequestID  uint64
+	TraceID    string
+	IsResponse bool
+	Payload    string
+	Truncated  bool
+
+	time time.Time
+}
+
+func (p CapturedPacket) pagePosition() Cursor {
+	return Cursor{Position: uint64(p.time.UnixNano())}
+}
+
+func (p CapturedPacket) pageField(name string) (interface{}, bool) {
+	switch name {
+	case "Time":
+		return p.time, true
+	case "Outgoing":
+		return strconv.FormatBool(p.Outgoing), true
+	case "Address":
+		return p.Address, true
+	case "Sender":
+		return p.Sender, true
+	case "Receiver":
+		return p.Receiver, true
+	case "Type":
+		return p.Type, true
+	case "TraceID":
+		return p.TraceID, true
+	}
+	return nil, false
+}
+
+// CapturePacketsReply is reply for Capture.Packets requests.
+type CapturePacketsReply struct {
+	PageInfo
+	Packets []CapturedPacket
+}
+
+// CaptureReply is reply for Capture.Start and Capture.Stop requests.
+type CaptureReply struct {
+	Capturing bool
+}
+
+const (
+	// defaultCapturedPacketsLimit is count of packets returned by Capture.Packets if limit is not set.
+	defaultCapturedPacketsLimit = 100
+	// maxCapturedPacketsLimit is max count of packets returned by Capture.Packets.
+	maxCapturedPacketsLimit = 1000
+)
+
+// CaptureService is a service that captures the last packets of network peers for debugging.
+type CaptureService struct {
+	runner *Runner
+}
+
+// NewCaptureService creates new Capture service instance.
+func NewCaptureService(runner *Runner) *CaptureService {
+	return &CaptureService{runner: runner}
+}
+
+// Start starts capturing the last packets of each peer, packets captured before are discarded. Action must be
+// signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "capture.Start",
+//	  "params": {
+//	    "PerPeer": int, // count of the last packets kept for each peer
+//	    "PayloadSize": int, // max size of captured payload
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *CaptureService) Start(r *http.Request, args *CaptureStartArgs, reply *CaptureReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ CaptureService.Start ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "capture.Start", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ CaptureService.Start ]")
+	}
+	if err := s.runner.PacketCapture.StartPacketCapture(args.PerPeer, args.PayloadSize); err != nil {
+		return errors.Wrap(err, "[ CaptureService.Start ]")
+	}
+	reply.Capturing = true
+	return nil
+}
+
+// Stop stops capturing packets, captured packets are kept until next start. Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "capture.Stop",
+//	  "params": {
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *CaptureService) Stop(r *http.Request, args *AdminAuth, reply *CaptureReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ CaptureService.Stop ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "capture.Stop", *args, args); err != nil {
+		return errors.Wrap(err, "[ CaptureService.Stop ]")
+	}
+	s.runner.PacketCapture.StopPacketCapture()
+	reply.Capturing = false
+	return nil
+}
+
+// Packets returns captured packets ordered by time. Payloads may contain request data, so action must be signed
+// by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "capture.Packets",
+//	  "params": {
+//	    "Peer": str, // address or reference of node, optional
+//	    "Cursor": str, // NextCursor of previous page, optional
+//	    "Limit": int, // max count of packets, 100 by default
+//	    "Filter": {"Type": str, "Outgoing": str, "TraceID": str}, // optional
+//	    "Sort": str, // Time, Address, Sender, Receiver or Type, "-" prefix for descending order, optional
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *CaptureService) Packets(r *http.Request, args *CapturePacketsArgs, reply *CapturePacketsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ CaptureService.Packets ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "capture.Packets", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ CaptureService.Packets ]")
+	}
+	packets := s.runner.PacketCapture.GetCapturedPackets(args.Peer)
+	items := make([]pageItem, len(packets))
+	for i, p := range packets {
+		items[i] = newCapturedPacket(p)
+	}
+	page, info, err := args.page(items, defaultCapturedPacketsLimit, maxCapturedPacketsLimit)
+	if err != nil {
+		return errors.Wrap(err, "[ CaptureService.Packets ]")
+	}
+	reply.PageInfo = info
+	reply.Packets = make([]CapturedPacket, len(page))
+	for i, item := range page {
+		reply.Packets[i] = item.(CapturedPacket)
+	}
+	return nil
+}
+
+func newCapturedPacket(p core.CapturedPacket) CapturedPacket {
+	return CapturedPacket{
+		Time:       p.Time.UTC().Format(time.RFC3339Nano),
+		Outgoing:   p.Outgoing,
+		Address:    p.Address,
+		Sender:     p.Sender.String(),
+		Receiver:   p.Receiver.String(),
+		Type:       p.Type,
+		RequestID:  p.RequestID,
+		TraceID:    p.TraceID,
+		IsResponse: p.IsResponse,
+		Payload:    p.Payload,
+		Truncated:  p.Truncated,
+		time:       p.Time,
+	}
+}
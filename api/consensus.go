@@ -0,0 +1,245 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// ConsensusTimelineArgs is arguments that Consensus.Timeline accepts.
+type ConsensusTimelineArgs struct {
+	// Pulse is number of pulse, current pulse if zero
+	Pulse core.PulseNumber
+}
+
+// ConsensusPhaseReply is timeline record of consensus phase.
+type ConsensusPhaseReply struct {
+	Phase           string
+	Started         string
+	DurationMs      int64
+	PacketsSent     int
+	PacketsReceived int
+	ClaimQueue      int
+	ProofFailures   int
+	Error           string
+}
+
+// ConsensusTimelineReply is reply for Consensus service requests.
+type ConsensusTimelineReply struct {
+	Pulse  core.PulseNumber
+	Phases []ConsensusPhaseReply
+}
+
+// ConsensusService is a service that provides timelines of consensus phases and history of cloud hashes.
+type ConsensusService struct {
+	runner *Runner
+}
+
+// NewConsensusService creates new Consensus service instance.
+func NewConsensusService(runner *Runner) *ConsensusService {
+	return &ConsensusService{runner: runner}
+}
+
+// Timeline returns duration, exchanged packets, claim queue depth and proof failures of consensus phases
+// executed on pulse. Timelines are kept for recent pulses only.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "consensus.Timeline",
+//	  "params": {
+//	    "Pulse": int // pulse number, current pulse if omitted
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *ConsensusService) Timeline(r *http.Request, args *ConsensusTimelineArgs, reply *ConsensusTimelineReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ConsensusService.Timeline ] Incoming request: %s", r.RequestURI)
+
+	pulse := args.Pulse
+	if pulse == 0 {
+		current, err := s.runner.PulseStorage.Current(ctx)
+		if err != nil {
+			return errors.Wrap(err, "[ ConsensusService.Timeline ] failed to get current pulse")
+		}
+		pulse = current.PulseNumber
+	}
+
+	timeline := s.runner.ConsensusTimelines.GetConsensusTimeline(pulse)
+	if timeline == nil {
+		return errors.Errorf("[ ConsensusService.Timeline ] no consensus timeline for pulse %d", pulse)
+	}
+
+	reply.Pulse = timeline.Pulse
+	reply.Phases = make([]ConsensusPhaseReply, len(timeline.Phases))
+	for i, phase := range timeline.Phases {
+		reply.Phases[i] = ConsensusPhaseReply{
+			Phase:           phase.Phase,
+			Started:         phase.Started.UTC().Format(time.RFC3339Nano),
+			DurationMs:      int64(phase.Duration / time.Millisecond),
+			PacketsSent:     phase.PacketsSent,
+			PacketsReceived: phase.PacketsReceived,
+			ClaimQueue:      phase.ClaimQueue,
+			ProofFailures:   phase.ProofFailures,
+			Error:           phase.Error,
+		}
+	}
+	return nil
+}
+
+const (
+	defaultCloudHashesLimit = 100
+	maxCloudHashesLimit     = 1000
+)
+
+// CloudHashArgs is arguments that Consensus.CloudHash accepts.
+type CloudHashArgs struct {
+	Pulse core.PulseNumber
+}
+
+// CloudHashesArgs is arguments that Consensus.CloudHashes accepts.
+type CloudHashesArgs struct {
+	// From and To are the first and the last pulse of range, To is the current pulse if zero
+	From  core.PulseNumber
+	To    core.PulseNumber
+	Limit int
+}
+
+// GlobuleProofReply is proof of globule signed by node.
+type GlobuleProofReply struct {
+	Node          string
+	GlobuleID     uint32
+	NodeCount     uint32
+	NodeRoot      []byte
+	PrevCloudHash []byte
+	Signature     []byte
+}
+
+// CloudHashReply is cloud hash of pulse with globule proofs it was calculated from.
+type CloudHashReply struct {
+	Pulse         core.PulseNumber
+	CloudHash     []byte
+	PrevCloudHash []byte
+	GlobuleProofs []GlobuleProofReply
+}
+
+// CloudHashesReply is reply for Consensus.CloudHashes requests.
+type CloudHashesReply struct {
+	CloudHashes []CloudHashReply
+}
+
+func cloudHashReply(record core.CloudHashRecord) CloudHashReply {
+	reply := CloudHashReply{
+		Pulse:         record.Pulse,
+		CloudHash:     record.CloudHash,
+		PrevCloudHash: record.PrevCloudHash,
+		GlobuleProofs: make([]GlobuleProofReply, len(record.GlobuleProofs)),
+	}
+	for i, proof := range record.GlobuleProofs {
+		reply.GlobuleProofs[i] = GlobuleProofReply{
+			Node:          proof.Node.String(),
+			GlobuleID:     uint32(proof.GlobuleID),
+			NodeCount:     proof.NodeCount,
+			NodeRoot:      proof.NodeRoot,
+			PrevCloudHash: proof.PrevCloudHash,
+			Signature:     proof.Signature,
+		}
+	}
+	return reply
+}
+
+// CloudHash returns cloud hash calculated on pulse with globule proofs it was calculated from.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "consensus.CloudHash",
+//	  "params": {
+//	    "Pulse": int // pulse number
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *ConsensusService) CloudHash(r *http.Request, args *CloudHashArgs, reply *CloudHashReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ConsensusService.CloudHash ] Incoming request: %s", r.RequestURI)
+
+	record, err := s.runner.CloudHashStorage.GetCloudHash(ctx, args.Pulse)
+	if err != nil {
+		return errors.Wrapf(err, "[ ConsensusService.CloudHash ] failed to get cloud hash of pulse %d", args.Pulse)
+	}
+	*reply = cloudHashReply(*record)
+	return nil
+}
+
+// CloudHashes returns chain of cloud hashes calculated on pulses in range ordered by pulse, so light clients
+// can check that every hash refers to the previous one.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "consensus.CloudHashes",
+//	  "params": {
+//	    "From": int, // first pulse of range
+//	    "To": int, // last pulse of range, current pulse if omitted
+//	    "Limit": int // max number of hashes, 100 if omitted, 1000 at most
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *ConsensusService) CloudHashes(r *http.Request, args *CloudHashesArgs, reply *CloudHashesReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ConsensusService.CloudHashes ] Incoming request: %s", r.RequestURI)
+
+	to := args.To
+	if to == 0 {
+		current, err := s.runner.PulseStorage.Current(ctx)
+		if err != nil {
+			return errors.Wrap(err, "[ ConsensusService.CloudHashes ] failed to get current pulse")
+		}
+		to = current.PulseNumber
+	}
+	if args.From > to {
+		return errors.Errorf("[ ConsensusService.CloudHashes ] range from %d to %d is empty", args.From, to)
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultCloudHashesLimit
+	}
+	if limit > maxCloudHashesLimit {
+		limit = maxCloudHashesLimit
+	}
+
+	records, err := s.runner.CloudHashStorage.GetCloudHashes(ctx, args.From, to, limit)
+	if err != nil {
+		return errors.Wrap(err, "[ ConsensusService.CloudHashes ] failed to get cloud hashes")
+	}
+	reply.CloudHashes = make([]CloudHashReply, len(records))
+	for i, record := range records {
+		reply.CloudHashes[i] = cloudHashReply(record)
+	}
+	return nil
+}
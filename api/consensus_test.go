@@ -0,0 +1,60 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusService_CloudHashes(t *testing.T) {
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: 100}, nil)
+
+	node := testutils.RandomRef()
+	record := core.CloudHashRecord{
+		Pulse:         90,
+		CloudHash:     []byte{2},
+		PrevCloudHash: []byte{1},
+		GlobuleProofs: []core.GlobuleProofRecord{{Node: node, NodeCount: 1, Signature: []byte{3}}},
+	}
+	storage := testutils.NewCloudHashStorageMock(t)
+	storage.GetCloudHashesFunc = func(_ context.Context, from, to core.PulseNumber, limit int) ([]core.CloudHashRecord, error) {
+		assert.Equal(t, core.PulseNumber(80), from)
+		assert.Equal(t, core.PulseNumber(100), to)
+		assert.Equal(t, maxCloudHashesLimit, limit)
+		return []core.CloudHashRecord{record}, nil
+	}
+
+	s := NewConsensusService(&Runner{PulseStorage: ps, CloudHashStorage: storage})
+	r := &http.Request{}
+
+	var reply CloudHashesReply
+	require.NoError(t, s.CloudHashes(r, &CloudHashesArgs{From: 80, Limit: 5000}, &reply))
+	require.Len(t, reply.CloudHashes, 1)
+	assert.Equal(t, core.PulseNumber(90), reply.CloudHashes[0].Pulse)
+	assert.Equal(t, []byte{1}, reply.CloudHashes[0].PrevCloudHash)
+	assert.Equal(t, node.String(), reply.CloudHashes[0].GlobuleProofs[0].Node)
+
+	assert.Error(t, s.CloudHashes(r, &CloudHashesArgs{From: 120}, &reply))
+}
@@ -0,0 +1,87 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	// proxies register their descriptors on init
+	_ "github.com/insolar/insolar/application/proxy/allowance"
+	_ "github.com/insolar/insolar/application/proxy/bucket"
+	_ "github.com/insolar/insolar/application/proxy/member"
+	_ "github.com/insolar/insolar/application/proxy/message"
+	_ "github.com/insolar/insolar/application/proxy/nodedomain"
+	_ "github.com/insolar/insolar/application/proxy/noderecord"
+	_ "github.com/insolar/insolar/application/proxy/rootdomain"
+	_ "github.com/insolar/insolar/application/proxy/wallet"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+)
+
+// PrototypeInfo describes callable prototype and signatures of its methods
+type PrototypeInfo struct {
+	Name         string                      `json:"name"`
+	Reference    string                      `json:"reference"`
+	Methods      []proxyctx.MethodDescriptor `json:"methods"`
+	Constructors []proxyctx.MethodDescriptor `json:"constructors"`
+}
+
+// ContractsResponse is a response of contracts discovery endpoint
+type ContractsResponse struct {
+	Prototypes []PrototypeInfo `json:"prototypes"`
+}
+
+func contractsResponse() ContractsResponse {
+	descriptors := proxyctx.Prototypes()
+	res := ContractsResponse{Prototypes: make([]PrototypeInfo, 0, len(descriptors))}
+	for _, desc := range descriptors {
+		res.Prototypes = append(res.Prototypes, PrototypeInfo{
+			Name:         desc.Name,
+			Reference:    desc.Reference.String(),
+			Methods:      desc.Methods,
+			Constructors: desc.Constructors,
+		})
+	}
+	return res
+}
+
+// contractsHandler returns list of callable prototypes with signatures of their methods,
+// derived from descriptors registered by generated proxies
+func (ar *Runner) contractsHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		insLog := inslogger.FromContext(req.Context())
+
+		if req.Method != http.MethodGet {
+			response.Header().Add("Allow", http.MethodGet)
+			response.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		data, err := json.MarshalIndent(contractsResponse(), "", "    ")
+		if err != nil {
+			insLog.Error("[ contractsHandler ] Can't marshal response: ", err)
+			response.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		response.Header().Add("Content-Type", "application/json")
+		_, err = response.Write(data)
+		if err != nil {
+			insLog.Error("[ contractsHandler ] Can't write response: ", err)
+		}
+	}
+}
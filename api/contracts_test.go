@@ -0,0 +1,58 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/insolar/insolar/application/proxy/rootdomain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_contractsHandler(t *testing.T) {
+	ar := &Runner{}
+	handler := http.HandlerFunc(ar.contractsHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/contracts", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp ContractsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	var found *PrototypeInfo
+	for i := range resp.Prototypes {
+		if resp.Prototypes[i].Name == "RootDomain" {
+			found = &resp.Prototypes[i]
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, rootdomain.PrototypeReference.String(), found.Reference)
+
+	methods := make(map[string]bool)
+	for _, m := range found.Methods {
+		methods[m.Name] = true
+	}
+	require.True(t, methods["CreateMember"])
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/contracts", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
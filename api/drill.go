@@ -0,0 +1,178 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// DrillStartArgs is arguments that Drill.Start accepts.
+type DrillStartArgs struct {
+	AdminAuth
+	// Peers are references of nodes to isolate from
+	Peers []string
+	// Pulses is count of pulses isolation lasts
+	Pulses int
+}
+
+// DrillReply is reply for Drill service requests.
+type DrillReply struct {
+	Peers           []string
+	Pulses          int
+	Status          string
+	Started         string
+	Finished        string
+	StartPulse      core.PulseNumber
+	HealPulse       core.PulseNumber
+	SafeModeEntered bool
+	SafeModePulse   core.PulseNumber
+	Recovered       bool
+	RecoveryPulse   core.PulseNumber
+}
+
+// DrillService is a service that runs network partition drills.
+type DrillService struct {
+	runner *Runner
+}
+
+// NewDrillService creates new Drill service instance.
+func NewDrillService(runner *Runner) *DrillService {
+	return &DrillService{runner: runner}
+}
+
+// Start isolates node from peers for count of pulses. Packets to and from peers are dropped by transports,
+// node is expected to enter safe mode and to recover after isolation is healed. Drills must be enabled
+// with Service.PartitionDrill option. Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "drill.Start",
+//	  "params": {
+//	    "Peers": []str, // references of nodes to isolate from
+//	    "Pulses": int, // count of pulses isolation lasts
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *DrillService) Start(r *http.Request, args *DrillStartArgs, reply *DrillReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ DrillService.Start ] Incoming request: %s", r.RequestURI)
+
+	peers := make([]core.RecordRef, 0, len(args.Peers))
+	for _, peer := range args.Peers {
+		ref, err := core.NewRefFromBase58(peer)
+		if err != nil {
+			return errors.Wrapf(err, "[ DrillService.Start ] failed to parse peer reference %s", peer)
+		}
+		peers = append(peers, *ref)
+	}
+	if len(peers) == 0 {
+		return errors.New("[ DrillService.Start ] Peers must not be empty")
+	}
+	if err := s.runner.authorizeAdmin(ctx, "drill.Start", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ DrillService.Start ]")
+	}
+
+	err := s.runner.PartitionDrill.StartPartitionDrill(ctx, peers, args.Pulses)
+	if err != nil {
+		return errors.Wrap(err, "[ DrillService.Start ]")
+	}
+	fillDrillReply(s.runner.PartitionDrill.GetPartitionDrillReport(), reply)
+	return nil
+}
+
+// Stop heals isolation and aborts running drill. Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "drill.Stop",
+//	  "params": {
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *DrillService) Stop(r *http.Request, args *AdminAuth, reply *DrillReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ DrillService.Stop ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "drill.Stop", *args, args); err != nil {
+		return errors.Wrap(err, "[ DrillService.Stop ]")
+	}
+
+	err := s.runner.PartitionDrill.StopPartitionDrill(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ DrillService.Stop ]")
+	}
+	fillDrillReply(s.runner.PartitionDrill.GetPartitionDrillReport(), reply)
+	return nil
+}
+
+// Report returns report of the last drill: whether node entered safe mode while isolated and recovered after healing.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "drill.Report",
+//	  "id": str|int|null
+//	}
+func (s *DrillService) Report(r *http.Request, args *interface{}, reply *DrillReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ DrillService.Report ] Incoming request: %s", r.RequestURI)
+
+	report := s.runner.PartitionDrill.GetPartitionDrillReport()
+	if report == nil {
+		return errors.New("[ DrillService.Report ] no partition drills were run")
+	}
+	fillDrillReply(report, reply)
+	return nil
+}
+
+func fillDrillReply(report *core.PartitionDrillReport, reply *DrillReply) {
+	reply.Peers = make([]string, len(report.Peers))
+	for i, peer := range report.Peers {
+		reply.Peers[i] = peer.String()
+	}
+	reply.Pulses = report.Pulses
+	reply.Status = report.Status
+	reply.Started = report.Started.UTC().Format(time.RFC3339)
+	if !report.Finished.IsZero() {
+		reply.Finished = report.Finished.UTC().Format(time.RFC3339)
+	}
+	reply.StartPulse = report.StartPulse
+	reply.HealPulse = report.HealPulse
+	reply.SafeModeEntered = report.SafeModeEntered
+	reply.SafeModePulse = report.SafeModePulse
+	reply.Recovered = report.Recovered
+	reply.RecoveryPulse = report.RecoveryPulse
+}
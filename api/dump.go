@@ -0,0 +1,170 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	dumpUsersMethod   = "DumpAllUsers"
+	dumpUsersPageSize = 100
+)
+
+// dumpUsersPage requests page of users on behalf of member which signed DumpAllUsers request,
+// RootDomain accepts it only from root member
+func (ar *Runner) dumpUsersPage(ctx context.Context, params Request, cursor string, limit uint) ([]json.RawMessage, string, error) {
+	reference, err := core.NewRefFromBase58(params.Reference)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ dumpUsersPage ] failed to parse params.Reference")
+	}
+
+	res, err := ar.ContractRequester.SendRequest(
+		ctx,
+		reference,
+		"DumpUsersPage",
+		[]interface{}{*ar.CertificateManager.GetCertificate().GetRootDomainReference(), params.Params, params.Seed, params.Signature, cursor, limit},
+	)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ dumpUsersPage ] Can't send request")
+	}
+
+	users, next, err := extractor.UsersPageResponse(res.(*reply.CallMethod).Result)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ dumpUsersPage ] Can't extract response")
+	}
+	return users, next, nil
+}
+
+func (ar *Runner) checkRootMember(ctx context.Context, reference string) error {
+	rootMember, err := ar.GenesisDataProvider.GetRootMember(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ checkRootMember ] Can't get root member")
+	}
+	if rootMember.String() != reference {
+		return errors.New("[ checkRootMember ] Only root can call this method")
+	}
+	return nil
+}
+
+func writeAnswer(response http.ResponseWriter, status int, resp answer, insLog core.Logger) {
+	res, err := json.MarshalIndent(resp, "", "    ")
+	if err != nil {
+		res = []byte(`{"error": "can't marshal answer to json'"}`)
+	}
+	response.Header().Add("Content-Type", "application/json")
+	response.WriteHeader(status)
+	_, err = response.Write(res)
+	if err != nil {
+		insLog.Errorf("Can't write response\n")
+	}
+}
+
+// dumpUsersHandler streams all users as newline-delimited json. Request is the same as
+// for api call of DumpAllUsers method, users are fetched from RootDomain page by page,
+// so neither node nor client buffers the whole dump. If dump fails in the middle, the last
+// line is an object with error field.
+func (ar *Runner) dumpUsersHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		traceID := utils.RandTraceID()
+		ctx, insLog := inslogger.WithTraceField(req.Context(), traceID)
+
+		params := Request{}
+		resp := answer{TraceID: traceID}
+
+		_, err := UnmarshalRequest(req, &params)
+		if err != nil {
+			processError(err, "Can't unmarshal request", &resp, insLog)
+			writeAnswer(response, http.StatusBadRequest, resp, insLog)
+			return
+		}
+		if params.Method != dumpUsersMethod {
+			processError(errors.New("[ dumpUsersHandler ] Only DumpAllUsers method can be streamed"), "Wrong method", &resp, insLog)
+			writeAnswer(response, http.StatusBadRequest, resp, insLog)
+			return
+		}
+
+		err = ar.checkSeed(ctx, params.Seed)
+		if err != nil {
+			processError(err, "Can't checkSeed", &resp, insLog)
+			writeAnswer(response, http.StatusUnauthorized, resp, insLog)
+			return
+		}
+
+		params.Reference, err = ar.resolveReference(ctx, params.Reference)
+		if err != nil {
+			processError(err, "Can't resolve reference", &resp, insLog)
+			writeAnswer(response, http.StatusBadRequest, resp, insLog)
+			return
+		}
+
+		err = ar.verifySignature(ctx, params)
+		if err != nil {
+			processError(err, "Can't verify signature", &resp, insLog)
+			writeAnswer(response, http.StatusUnauthorized, resp, insLog)
+			return
+		}
+
+		err = ar.checkRootMember(ctx, params.Reference)
+		if err != nil {
+			processError(err, "Not a root member", &resp, insLog)
+			writeAnswer(response, http.StatusForbidden, resp, insLog)
+			return
+		}
+
+		response.Header().Add("Content-Type", "application/x-ndjson")
+		response.Header().Add("Trace-Id", traceID)
+		flusher, _ := response.(http.Flusher)
+		encoder := json.NewEncoder(response)
+
+		var cursor string
+		for {
+			pageCtx, cancel := context.WithTimeout(ctx, ar.requestTimeout(params.Timeout))
+			users, next, err := ar.dumpUsersPage(pageCtx, params, cursor, dumpUsersPageSize)
+			cancel()
+			if err != nil {
+				if !processContextError(err, &resp, insLog) {
+					processError(err, "Can't get users page", &resp, insLog)
+				}
+				_ = encoder.Encode(resp)
+				return
+			}
+			for _, user := range users {
+				if err := encoder.Encode(user); err != nil {
+					insLog.Error(errors.Wrap(err, "[ dumpUsersHandler ] Can't write user"))
+					return
+				}
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			if next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+}
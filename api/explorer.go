@@ -0,0 +1,352 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// explorerHistorySize is count of recent pulses and requests kept by explorer
+	explorerHistorySize = 100
+	// explorerPulseCheckInterval is interval of checking current pulse by explorer
+	explorerPulseCheckInterval = 500 * time.Millisecond
+)
+
+// ExplorerPulse is a pulse applied by the node.
+type ExplorerPulse struct {
+	PulseNumber uint32    `json:"pulseNumber"`
+	Entropy     []byte    `json:"entropy"`
+	Applied     time.Time `json:"applied"`
+}
+
+// ExplorerRequest is a contract call processed by the node.
+type ExplorerRequest struct {
+	TraceID   string        `json:"traceID"`
+	Reference string        `json:"reference"`
+	Method    string        `json:"method"`
+	Time      time.Time     `json:"time"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+
+	// seq is sequence number of request in explorer history
+	seq uint64
+}
+
+// ExplorerObject describes object state.
+type ExplorerObject struct {
+	Reference   string `json:"reference"`
+	State       string `json:"state,omitempty"`
+	Prototype   string `json:"prototype,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	IsPrototype bool   `json:"isPrototype"`
+	MemorySize  int    `json:"memorySize"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ExplorerNode is an active node of the network.
+type ExplorerNode struct {
+	Reference string `json:"reference"`
+	ShortID   uint32 `json:"shortID"`
+	Role      string `json:"role"`
+	Address   string `json:"address"`
+	Version   string `json:"version"`
+	Origin    bool   `json:"origin"`
+}
+
+func (p ExplorerPulse) pagePosition() Cursor {
+	return Cursor{Pulse: core.PulseNumber(p.PulseNumber)}
+}
+
+func (p ExplorerPulse) pageField(name string) (interface{}, bool) {
+	switch name {
+	case "pulseNumber":
+		return uint64(p.PulseNumber), true
+	case "applied":
+		return p.Applied, true
+	}
+	return nil, false
+}
+
+func (r ExplorerRequest) pagePosition() Cursor {
+	return Cursor{Position: r.seq}
+}
+
+func (r ExplorerRequest) pageField(name string) (interface{}, bool) {
+	switch name {
+	case "traceID":
+		return r.TraceID, true
+	case "reference":
+		return r.Reference, true
+	case "method":
+		return r.Method, true
+	case "time":
+		return r.Time, true
+	case "duration":
+		return int64(r.Duration), true
+	case "error":
+		return r.Error, true
+	}
+	return nil, false
+}
+
+// explorer keeps history of recent pulses and requests of the node for explorer UI.
+type explorer struct {
+	lock     sync.RWMutex
+	pulses   []ExplorerPulse
+	requests []ExplorerRequest
+	seq      uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newExplorer() *explorer {
+	return &explorer{stop: make(chan struct{})}
+}
+
+func (e *explorer) recordPulse(pulse *core.Pulse) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(e.pulses) > 0 && e.pulses[len(e.pulses)-1].PulseNumber == uint32(pulse.PulseNumber) {
+		return
+	}
+	if len(e.pulses) == explorerHistorySize {
+		e.pulses = e.pulses[1:]
+	}
+	e.pulses = append(e.pulses, ExplorerPulse{
+		PulseNumber: uint32(pulse.PulseNumber),
+		Entropy:     pulse.Entropy[:],
+		Applied:     time.Now(),
+	})
+}
+
+func (e *explorer) recordRequest(request ExplorerRequest) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(e.requests) == explorerHistorySize {
+		e.requests = e.requests[1:]
+	}
+	e.seq++
+	request.seq = e.seq
+	e.requests = append(e.requests, request)
+}
+
+// recentPulses returns recorded pulses, newest first.
+func (e *explorer) recentPulses() []ExplorerPulse {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	result := make([]ExplorerPulse, 0, len(e.pulses))
+	for i := len(e.pulses) - 1; i >= 0; i-- {
+		result = append(result, e.pulses[i])
+	}
+	return result
+}
+
+// recentRequests returns recorded requests, newest first.
+func (e *explorer) recentRequests() []ExplorerRequest {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	result := make([]ExplorerRequest, 0, len(e.requests))
+	for i := len(e.requests) - 1; i >= 0; i-- {
+		result = append(result, e.requests[i])
+	}
+	return result
+}
+
+// recentObjects returns distinct references of objects called by recent requests, newest first.
+func (e *explorer) recentObjects() []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	for _, r := range e.recentRequests() {
+		if r.Reference == "" || seen[r.Reference] {
+			continue
+		}
+		seen[r.Reference] = true
+		result = append(result, r.Reference)
+	}
+	return result
+}
+
+// watch records pulses applied by the node until explorer is stopped.
+func (e *explorer) watch(ctx context.Context, storage core.PulseStorage) {
+	ticker := time.NewTicker(explorerPulseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			pulse, err := storage.Current(ctx)
+			if err != nil {
+				continue
+			}
+			e.recordPulse(pulse)
+		}
+	}
+}
+
+func (e *explorer) close() {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+	})
+}
+
+func (ar *Runner) explorerObject(ctx context.Context, reference string) ExplorerObject {
+	result := ExplorerObject{Reference: reference}
+	ref, err := core.NewRefFromBase58(reference)
+	if err != nil {
+		result.Error = errors.Wrap(err, "can't parse reference").Error()
+		return result
+	}
+	desc, err := ar.ArtifactManager.GetObject(ctx, *ref, nil, false)
+	if err != nil {
+		result.Error = errors.Wrap(err, "can't get object").Error()
+		return result
+	}
+	result.State = desc.StateID().String()
+	if prototype, err := desc.Prototype(); err == nil && prototype != nil {
+		result.Prototype = prototype.String()
+	}
+	if parent := desc.Parent(); parent != nil && !parent.IsEmpty() {
+		result.Parent = parent.String()
+	}
+	result.IsPrototype = desc.IsPrototype()
+	result.MemorySize = len(desc.Memory())
+	return result
+}
+
+func (ar *Runner) explorerNodes() []ExplorerNode {
+	var origin core.RecordRef
+	if node := ar.NodeNetwork.GetOrigin(); node != nil {
+		origin = node.ID()
+	}
+	nodes := ar.NodeNetwork.GetActiveNodes()
+	result := make([]ExplorerNode, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, ExplorerNode{
+			Reference: n.ID().String(),
+			ShortID:   uint32(n.ShortID()),
+			Role:      n.Role().String(),
+			Address:   n.PhysicalAddress(),
+			Version:   n.Version(),
+			Origin:    n.ID() == origin,
+		})
+	}
+	return result
+}
+
+// explorerPage selects page of items requested by query parameters, cursor of next page is returned in
+// X-Next-Cursor header.
+func explorerPage(response http.ResponseWriter, req *http.Request, items []pageItem) ([]pageItem, error) {
+	args, err := pageArgsFromQuery(req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	page, info, err := args.page(items, explorerHistorySize, explorerHistorySize)
+	if err != nil {
+		return nil, err
+	}
+	if info.NextCursor != "" {
+		response.Header().Add("X-Next-Cursor", info.NextCursor)
+	}
+	return page, nil
+}
+
+// explorerHandler serves explorer page and JSON endpoints with recent pulses, requests, objects and nodes.
+func (ar *Runner) explorerHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		insLog := inslogger.FromContext(ctx)
+
+		if req.Method != http.MethodGet {
+			response.Header().Add("Allow", http.MethodGet)
+			response.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var result interface{}
+		var err error
+		switch strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(ar.cfg.Explorer, "/")) {
+		case "", "/":
+			response.Header().Add("Content-Type", "text/html; charset=utf-8")
+			if _, err := response.Write([]byte(explorerPage)); err != nil {
+				insLog.Error("[ explorerHandler ] Can't write response: ", err)
+			}
+			return
+		case "/api/pulses":
+			pulses := ar.explorer.recentPulses()
+			items := make([]pageItem, len(pulses))
+			for i, p := range pulses {
+				items[i] = p
+			}
+			result, err = explorerPage(response, req, items)
+		case "/api/requests":
+			requests := ar.explorer.recentRequests()
+			items := make([]pageItem, len(requests))
+			for i, r := range requests {
+				items[i] = r
+			}
+			result, err = explorerPage(response, req, items)
+		case "/api/objects":
+			if ref := req.URL.Query().Get("ref"); ref != "" {
+				result = ar.explorerObject(ctx, ref)
+				break
+			}
+			objects := make([]ExplorerObject, 0)
+			for _, ref := range ar.explorer.recentObjects() {
+				objects = append(objects, ar.explorerObject(ctx, ref))
+			}
+			result = objects
+		case "/api/nodes":
+			result = ar.explorerNodes()
+		default:
+			http.NotFound(response, req)
+			return
+		}
+		if err != nil {
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			insLog.Error("[ explorerHandler ] Can't marshal response: ", err)
+			response.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		response.Header().Add("Content-Type", "application/json")
+		_, err = response.Write(data)
+		if err != nil {
+			insLog.Error("[ explorerHandler ] Can't write response: ", err)
+		}
+	}
+}
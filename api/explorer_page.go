@@ -0,0 +1,104 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+// explorerPage is a single page explorer UI, it polls JSON endpoints relative to its own path.
+const explorerPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Insolar explorer</title>
+<style>
+body { font-family: sans-serif; margin: 20px; color: #222; }
+h2 { margin-top: 28px; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: left; font-family: monospace; }
+th { background: #f4f4f4; font-family: sans-serif; }
+.error { color: #b00; }
+form { margin-bottom: 8px; }
+input { width: 480px; font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>Insolar explorer</h1>
+
+<h2>Nodes</h2>
+<table id="nodes"></table>
+
+<h2>Pulses</h2>
+<table id="pulses"></table>
+
+<h2>Requests</h2>
+<table id="requests"></table>
+
+<h2>Objects</h2>
+<form id="lookup"><input id="ref" placeholder="object reference"> <button>Show</button></form>
+<table id="object"></table>
+<table id="objects"></table>
+
+<script>
+var base = window.location.pathname.replace(/\/$/, '');
+
+function escape(value) {
+	return String(value === undefined || value === null ? '' : value)
+		.replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;');
+}
+
+function render(id, columns, rows) {
+	var html = '<tr>' + columns.map(function (c) { return '<th>' + c + '</th>'; }).join('') + '</tr>';
+	(rows || []).forEach(function (row) {
+		html += '<tr' + (row.error ? ' class="error"' : '') + '>' + columns.map(function (c) {
+			return '<td>' + escape(row[c]) + '</td>';
+		}).join('') + '</tr>';
+	});
+	document.getElementById(id).innerHTML = html;
+}
+
+function load(path, callback) {
+	fetch(base + path).then(function (r) { return r.json(); }).then(callback);
+}
+
+function refresh() {
+	load('/api/nodes', function (rows) {
+		render('nodes', ['reference', 'shortID', 'role', 'address', 'version', 'origin'], rows);
+	});
+	load('/api/pulses', function (rows) {
+		render('pulses', ['pulseNumber', 'applied'], rows);
+	});
+	load('/api/requests', function (rows) {
+		rows.forEach(function (r) { r.duration = (r.duration / 1e6).toFixed(1) + 'ms'; });
+		render('requests', ['time', 'reference', 'method', 'duration', 'traceID', 'error'], rows);
+	});
+	load('/api/objects', function (rows) {
+		render('objects', ['reference', 'prototype', 'parent', 'state', 'isPrototype', 'memorySize', 'error'], rows);
+	});
+}
+
+document.getElementById('lookup').onsubmit = function (event) {
+	event.preventDefault();
+	load('/api/objects?ref=' + encodeURIComponent(document.getElementById('ref').value), function (row) {
+		render('object', ['reference', 'prototype', 'parent', 'state', 'isPrototype', 'memorySize', 'error'], [row]);
+	});
+};
+
+refresh();
+setInterval(refresh, 2000);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,123 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplorer_History(t *testing.T) {
+	e := newExplorer()
+	for i := 0; i < explorerHistorySize+10; i++ {
+		e.recordPulse(&core.Pulse{PulseNumber: core.PulseNumber(i)})
+		e.recordPulse(&core.Pulse{PulseNumber: core.PulseNumber(i)})
+	}
+	pulses := e.recentPulses()
+	require.Len(t, pulses, explorerHistorySize)
+	require.Equal(t, uint32(explorerHistorySize+9), pulses[0].PulseNumber)
+
+	e.recordRequest(ExplorerRequest{Reference: "a", Method: "Transfer"})
+	e.recordRequest(ExplorerRequest{Reference: "b", Method: "Transfer"})
+	e.recordRequest(ExplorerRequest{Reference: "a", Method: "GetBalance"})
+	require.Equal(t, "GetBalance", e.recentRequests()[0].Method)
+	require.Equal(t, []string{"a", "b"}, e.recentObjects())
+}
+
+func TestRunner_explorerHandler(t *testing.T) {
+	node := network.NewNodeMock(t)
+	node.IDMock.Return(testutils.RandomRef())
+	node.ShortIDMock.Return(core.ShortNodeID(1))
+	node.RoleMock.Return(core.StaticRoleVirtual)
+	node.PhysicalAddressMock.Return("127.0.0.1:13831")
+	node.VersionMock.Return("v1")
+	nn := network.NewNodeNetworkMock(t)
+	nn.GetOriginMock.Return(node)
+	nn.GetActiveNodesMock.Return([]core.Node{node})
+
+	cfg := configuration.NewAPIRunner()
+	cfg.Explorer = "/explorer"
+	ar := &Runner{cfg: &cfg, NodeNetwork: nn, explorer: newExplorer()}
+	ar.explorer.recordRequest(ExplorerRequest{Reference: "ref", Method: "Transfer"})
+	handler := http.HandlerFunc(ar.explorerHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Insolar explorer")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/api/nodes", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var nodes []ExplorerNode
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &nodes))
+	require.Len(t, nodes, 1)
+	require.True(t, nodes[0].Origin)
+	require.Equal(t, "virtual", nodes[0].Role)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/api/requests", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var requests []ExplorerRequest
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &requests))
+	require.Equal(t, "Transfer", requests[0].Method)
+
+	ar.explorer.recordRequest(ExplorerRequest{Reference: "ref", Method: "GetBalance"})
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/api/requests?limit=1&sort=method", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &requests))
+	require.Len(t, requests, 1)
+	require.Equal(t, "GetBalance", requests[0].Method)
+	cursor := rec.Header().Get("X-Next-Cursor")
+	require.NotEmpty(t, cursor)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/api/requests?limit=1&sort=method&cursor="+cursor, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &requests))
+	require.Equal(t, "Transfer", requests[0].Method)
+	require.Empty(t, rec.Header().Get("X-Next-Cursor"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/api/requests?filter.unknown=1", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/api/objects?ref=invalid", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var object ExplorerObject
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &object))
+	require.NotEmpty(t, object.Error)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/explorer/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/explorer/api/nodes", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,410 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/insolar/insolar/api/graphql"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	graphMaxDepth      = 5
+	graphChildrenLimit = 10
+	graphChildrenMax   = 100
+	// graphCallBudget limits ledger calls single query can make, fields over the budget resolve to null.
+	graphCallBudget = 1000
+)
+
+// GraphQLRequest is a representation of request struct to graphql endpoint
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLAnswer struct {
+	Data   interface{}    `json:"data"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+// graphObject is a json object which keeps field order of the query
+type graphObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newGraphObject() *graphObject {
+	return &graphObject{values: map[string]interface{}{}}
+}
+
+func (o *graphObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON implements json.Marshaler
+func (o *graphObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// objectGraph resolves graphql queries over ledger objects.
+//
+// Schema:
+//
+//	type Query { object(ref: String!): Object }
+//	type Object {
+//	  reference: String
+//	  state: String
+//	  prototype: String
+//	  code: String
+//	  isPrototype: Boolean
+//	  memory: String # base64
+//	  parent: Object
+//	  children(prototype: String, offset: Int, limit: Int): [Object]
+//	  delegate(prototype: String!): Object
+//	}
+//
+// So member -> organizations -> bprocesses is a chain of children/delegate fields filtered by prototypes.
+// Every object, delegate, children list and child fetched counts against the call budget of the query.
+type objectGraph struct {
+	ctx       context.Context
+	am        core.ArtifactManager
+	variables map[string]interface{}
+	errors    []graphQLError
+	// calls is count of ledger calls left for the query.
+	calls int
+}
+
+func (g *objectGraph) addError(path string, err error) {
+	g.errors = append(g.errors, graphQLError{Message: fmt.Sprintf("%s: %s", path, err.Error())})
+}
+
+// call takes ledger call from budget of the query, error is added once budget is exhausted.
+func (g *objectGraph) call(path string) bool {
+	if g.calls == 0 {
+		g.addError(path, errors.Errorf("query exceeds budget of %d ledger calls", graphCallBudget))
+	}
+	if g.calls <= 0 {
+		g.calls = -1
+		return false
+	}
+	g.calls--
+	return true
+}
+
+func (g *objectGraph) argument(field *graphql.Field, name string) interface{} {
+	value := field.Arguments[name]
+	if v, ok := value.(graphql.Variable); ok {
+		return g.variables[string(v)]
+	}
+	return value
+}
+
+func (g *objectGraph) stringArgument(field *graphql.Field, name string) (string, error) {
+	switch v := g.argument(field, name).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", errors.Errorf("argument %s must be String", name)
+}
+
+func (g *objectGraph) intArgument(field *graphql.Field, name string, def int) (int, error) {
+	switch v := g.argument(field, name).(type) {
+	case nil:
+		return def, nil
+	case int64:
+		return int(v), nil
+	case float64: // from json variables
+		return int(v), nil
+	}
+	return 0, errors.Errorf("argument %s must be Int", name)
+}
+
+func (g *objectGraph) refArgument(field *graphql.Field, name string) (*core.RecordRef, error) {
+	str, err := g.stringArgument(field, name)
+	if err != nil {
+		return nil, err
+	}
+	if str == "" {
+		return nil, nil
+	}
+	ref, err := core.NewRefFromBase58(str)
+	if err != nil {
+		return nil, errors.Wrapf(err, "argument %s is not a reference", name)
+	}
+	return ref, nil
+}
+
+func (g *objectGraph) resolveQuery(op *graphql.Operation) *graphObject {
+	res := newGraphObject()
+	for _, field := range op.Selections {
+		switch field.Name {
+		case "__typename":
+			res.set(field.Key(), "Query")
+		case "object":
+			ref, err := g.refArgument(field, "ref")
+			if err == nil && ref == nil {
+				err = errors.New("argument ref is required")
+			}
+			if err != nil {
+				g.addError(field.Key(), err)
+				res.set(field.Key(), nil)
+				continue
+			}
+			res.set(field.Key(), g.resolveObject(field.Key(), *ref, field, 1))
+		default:
+			g.addError(field.Key(), errors.Errorf("unknown field %s of Query", field.Name))
+			res.set(field.Key(), nil)
+		}
+	}
+	return res
+}
+
+func refString(ref *core.RecordRef, err error) interface{} {
+	if err != nil || ref == nil {
+		return nil
+	}
+	return ref.String()
+}
+
+func (g *objectGraph) resolveObject(path string, ref core.RecordRef, object *graphql.Field, depth int) interface{} {
+	if len(object.Selections) == 0 {
+		g.addError(path, errors.New("selection set of Object must not be empty"))
+		return nil
+	}
+	if depth > graphMaxDepth {
+		g.addError(path, errors.Errorf("query is deeper than %d levels", graphMaxDepth))
+		return nil
+	}
+	if !g.call(path) {
+		return nil
+	}
+
+	desc, err := g.am.GetObject(g.ctx, ref, nil, false)
+	if err != nil {
+		g.addError(path, errors.Wrap(err, "can't get object"))
+		return nil
+	}
+
+	res := newGraphObject()
+	for _, field := range object.Selections {
+		fieldPath := path + "." + field.Key()
+		switch field.Name {
+		case "__typename":
+			res.set(field.Key(), "Object")
+		case "reference":
+			res.set(field.Key(), ref.String())
+		case "state":
+			res.set(field.Key(), desc.StateID().String())
+		case "prototype":
+			res.set(field.Key(), refString(desc.Prototype()))
+		case "code":
+			res.set(field.Key(), refString(desc.Code()))
+		case "isPrototype":
+			res.set(field.Key(), desc.IsPrototype())
+		case "memory":
+			res.set(field.Key(), desc.Memory())
+		case "parent":
+			parent := desc.Parent()
+			if parent == nil || parent.IsEmpty() {
+				res.set(field.Key(), nil)
+				continue
+			}
+			res.set(field.Key(), g.resolveObject(fieldPath, *parent, field, depth+1))
+		case "children":
+			res.set(field.Key(), g.resolveChildren(fieldPath, ref, field, depth+1))
+		case "delegate":
+			res.set(field.Key(), g.resolveDelegate(fieldPath, ref, field, depth+1))
+		default:
+			g.addError(fieldPath, errors.Errorf("unknown field %s of Object", field.Name))
+			res.set(field.Key(), nil)
+		}
+	}
+	return res
+}
+
+func (g *objectGraph) resolveDelegate(path string, ref core.RecordRef, field *graphql.Field, depth int) interface{} {
+	prototype, err := g.refArgument(field, "prototype")
+	if err == nil && prototype == nil {
+		err = errors.New("argument prototype is required")
+	}
+	if err != nil {
+		g.addError(path, err)
+		return nil
+	}
+
+	if !g.call(path) {
+		return nil
+	}
+	delegate, err := g.am.GetDelegate(g.ctx, ref, *prototype)
+	if err != nil {
+		g.addError(path, errors.Wrap(err, "can't get delegate"))
+		return nil
+	}
+	if delegate == nil {
+		return nil
+	}
+	return g.resolveObject(path, *delegate, field, depth)
+}
+
+func (g *objectGraph) resolveChildren(path string, ref core.RecordRef, field *graphql.Field, depth int) interface{} {
+	prototype, err := g.refArgument(field, "prototype")
+	if err != nil {
+		g.addError(path, err)
+		return nil
+	}
+	offset, err := g.intArgument(field, "offset", 0)
+	if err != nil {
+		g.addError(path, err)
+		return nil
+	}
+	limit, err := g.intArgument(field, "limit", graphChildrenLimit)
+	if err != nil {
+		g.addError(path, err)
+		return nil
+	}
+	if limit <= 0 || limit > graphChildrenMax {
+		limit = graphChildrenMax
+	}
+
+	if !g.call(path) {
+		return nil
+	}
+	iterator, err := g.am.GetChildren(g.ctx, ref, nil)
+	if err != nil {
+		g.addError(path, errors.Wrap(err, "can't get children"))
+		return nil
+	}
+
+	res := []interface{}{}
+	for iterator.HasNext() && len(res) < limit {
+		if !g.call(path) {
+			break
+		}
+		child, err := iterator.Next()
+		if err != nil {
+			g.addError(path, errors.Wrap(err, "can't get next child"))
+			break
+		}
+
+		if prototype != nil {
+			if !g.call(path) {
+				break
+			}
+			desc, err := g.am.GetObject(g.ctx, *child, nil, false)
+			if err != nil {
+				g.addError(path, errors.Wrap(err, "can't get child"))
+				continue
+			}
+			childPrototype, err := desc.Prototype()
+			if err != nil || childPrototype == nil || *childPrototype != *prototype {
+				continue
+			}
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+		res = append(res, g.resolveObject(fmt.Sprintf("%s.%d", path, len(res)), *child, field, depth))
+	}
+	return res
+}
+
+// executeGraphQL parses and resolves query
+func (ar *Runner) executeGraphQL(ctx context.Context, req GraphQLRequest) graphQLAnswer {
+	op, err := graphql.Parse(req.Query)
+	if err != nil {
+		return graphQLAnswer{Errors: []graphQLError{{Message: err.Error()}}}
+	}
+
+	g := &objectGraph{
+		ctx:       ctx,
+		am:        ar.ArtifactManager,
+		variables: req.Variables,
+		calls:     graphCallBudget,
+	}
+	data := g.resolveQuery(op)
+	return graphQLAnswer{Data: data, Errors: g.errors}
+}
+
+func (ar *Runner) graphQLHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		traceID := utils.RandTraceID()
+		ctx, insLog := inslogger.WithTraceField(context.Background(), traceID)
+
+		var resp graphQLAnswer
+		params := GraphQLRequest{}
+		switch req.Method {
+		case http.MethodGet:
+			params.Query = req.URL.Query().Get("query")
+		default:
+			_, err := UnmarshalRequest(req, &params)
+			if err != nil {
+				insLog.Error(errors.Wrap(err, "[ graphQLHandler ] Can't unmarshal request"))
+				resp.Errors = []graphQLError{{Message: err.Error()}}
+			}
+		}
+		if resp.Errors == nil {
+			resp = ar.executeGraphQL(ctx, params)
+		}
+
+		res, err := json.MarshalIndent(resp, "", "    ")
+		if err != nil {
+			res = []byte(`{"errors": [{"message": "can't marshal answer to json"}]}`)
+		}
+		response.Header().Add("Content-Type", "application/json")
+		_, err = response.Write(res)
+		if err != nil {
+			insLog.Errorf("Can't write response\n")
+		}
+	}
+}
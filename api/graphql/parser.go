@@ -0,0 +1,384 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package graphql implements parser for the subset of GraphQL query language
+// used by api to traverse the object graph: operations, fields with aliases,
+// arguments with scalar or variable values and nested selection sets.
+// Fragments, directives and mutations are not supported.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Variable is an argument value referring to query variable
+type Variable string
+
+// Field is a single field of a selection set
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]interface{}
+	Selections []*Field
+}
+
+// Key returns name of the field in response
+func (f *Field) Key() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// Operation is a parsed query
+type Operation struct {
+	Name       string
+	Selections []*Field
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenString
+	tokenInt
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	src   []rune
+	pos   int
+	token token
+}
+
+// Parse parses query document with a single query operation
+func Parse(query string) (*Operation, error) {
+	p := &parser{src: []rune(query)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	op, err := p.parseOperation()
+	if err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenEOF {
+		return nil, p.errorf("unexpected %q after operation", p.token.value)
+	}
+	return op, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("graphql: syntax error at %d: %s", p.token.pos, fmt.Sprintf(format, args...))
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isNameRune(r rune) bool {
+	return isNameStart(r) || (r >= '0' && r <= '9')
+}
+
+func (p *parser) skipIgnored() {
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		switch {
+		case r == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case r == ',' || unicode.IsSpace(r) || r == '\uFEFF':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) next() error {
+	p.skipIgnored()
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.token = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	r := p.src[p.pos]
+	switch {
+	case strings.ContainsRune("{}():!$=", r):
+		p.pos++
+		p.token = token{kind: tokenPunct, value: string(r), pos: start}
+	case isNameStart(r):
+		for p.pos < len(p.src) && isNameRune(p.src[p.pos]) {
+			p.pos++
+		}
+		p.token = token{kind: tokenName, value: string(p.src[start:p.pos]), pos: start}
+	case r == '-' || (r >= '0' && r <= '9'):
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		p.token = token{kind: tokenInt, value: string(p.src[start:p.pos]), pos: start}
+	case r == '"':
+		return p.readString()
+	default:
+		p.token = token{pos: start}
+		return p.errorf("unexpected character %q", r)
+	}
+	return nil
+}
+
+func (p *parser) readString() error {
+	start := p.pos
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		p.pos++
+		switch r {
+		case '"':
+			p.token = token{kind: tokenString, value: sb.String(), pos: start}
+			return nil
+		case '\n':
+			p.token = token{pos: start}
+			return p.errorf("unterminated string")
+		case '\\':
+			if p.pos >= len(p.src) {
+				break
+			}
+			esc := p.src[p.pos]
+			p.pos++
+			switch esc {
+			case '"', '\\', '/':
+				sb.WriteRune(esc)
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case 'b':
+				sb.WriteRune('\b')
+			case 'f':
+				sb.WriteRune('\f')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					p.token = token{pos: start}
+					return p.errorf("bad unicode escape")
+				}
+				code, err := strconv.ParseUint(string(p.src[p.pos:p.pos+4]), 16, 32)
+				if err != nil {
+					p.token = token{pos: start}
+					return p.errorf("bad unicode escape")
+				}
+				sb.WriteRune(rune(code))
+				p.pos += 4
+			default:
+				p.token = token{pos: start}
+				return p.errorf("bad escape sequence \\%c", esc)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	p.token = token{pos: start}
+	return p.errorf("unterminated string")
+}
+
+func (p *parser) isPunct(value string) bool {
+	return p.token.kind == tokenPunct && p.token.value == value
+}
+
+func (p *parser) expectPunct(value string) error {
+	if !p.isPunct(value) {
+		return p.errorf("expected %q, got %q", value, p.token.value)
+	}
+	return p.next()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.errorf("expected name, got %q", p.token.value)
+	}
+	name := p.token.value
+	return name, p.next()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{}
+	if p.token.kind == tokenName {
+		if p.token.value != "query" {
+			return nil, p.errorf("unsupported operation %q", p.token.value)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.token.kind == tokenName {
+			op.Name = p.token.value
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.isPunct("(") {
+			if err := p.skipVariableDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = selections
+	return op, nil
+}
+
+// skipVariableDefinitions skips variable types and defaults, values are taken from request as is
+func (p *parser) skipVariableDefinitions() error {
+	depth := 0
+	for {
+		switch {
+		case p.token.kind == tokenEOF:
+			return p.errorf("unterminated variable definitions")
+		case p.isPunct("("):
+			depth++
+		case p.isPunct(")"):
+			depth--
+			if depth == 0 {
+				return p.next()
+			}
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseSelectionSet() ([]*Field, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var fields []*Field
+	for !p.isPunct("}") {
+		if p.token.kind == tokenEOF {
+			return nil, p.errorf("unterminated selection set")
+		}
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+	return fields, p.next()
+}
+
+func (p *parser) parseField() (*Field, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	field := &Field{Name: name}
+	if p.isPunct(":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		field.Alias = name
+		if field.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunct("(") {
+		if field.Arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunct("{") {
+		if field.Selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) parseArguments() (map[string]interface{}, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	args := map[string]interface{}{}
+	for !p.isPunct(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+	}
+	return args, p.next()
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	tok := p.token
+	switch {
+	case tok.kind == tokenString:
+		return tok.value, p.next()
+	case tok.kind == tokenInt:
+		value, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, p.errorf("bad int value %q", tok.value)
+		}
+		return value, p.next()
+	case tok.kind == tokenName:
+		switch tok.value {
+		case "true":
+			return true, p.next()
+		case "false":
+			return false, p.next()
+		case "null":
+			return nil, p.next()
+		}
+		// enum values are passed as strings
+		return tok.value, p.next()
+	case p.isPunct("$"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return Variable(name), err
+	}
+	return nil, p.errorf("unsupported value %q", tok.value)
+}
@@ -0,0 +1,75 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	op, err := Parse(`
+		query Inbox($ref: String!) {
+			# comment
+			member: object(ref: $ref) {
+				reference
+				children(prototype: "proto", limit: 10, deep: true) {
+					reference, __typename
+				}
+			}
+		}`)
+	require.NoError(t, err)
+	require.Equal(t, "Inbox", op.Name)
+	require.Len(t, op.Selections, 1)
+
+	member := op.Selections[0]
+	require.Equal(t, "member", member.Key())
+	require.Equal(t, "object", member.Name)
+	require.Equal(t, Variable("ref"), member.Arguments["ref"])
+	require.Len(t, member.Selections, 2)
+
+	children := member.Selections[1]
+	require.Equal(t, "children", children.Key())
+	require.Equal(t, "proto", children.Arguments["prototype"])
+	require.Equal(t, int64(10), children.Arguments["limit"])
+	require.Equal(t, true, children.Arguments["deep"])
+	require.Len(t, children.Selections, 2)
+	require.Equal(t, "__typename", children.Selections[1].Name)
+}
+
+func TestParse_Shorthand(t *testing.T) {
+	op, err := Parse(`{ object(ref: "a\"bA") { reference } }`)
+	require.NoError(t, err)
+	require.Equal(t, "a\"bA", op.Selections[0].Arguments["ref"])
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, query := range []string{
+		``,
+		`{}`,
+		`{ object`,
+		`{ object(ref: ) { reference } }`,
+		`{ object(ref: "abc) { reference } }`,
+		`mutation { object }`,
+		`{ object } }`,
+		`{ ...fragment }`,
+	} {
+		_, err := Parse(query)
+		require.Error(t, err, query)
+	}
+}
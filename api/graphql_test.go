@@ -0,0 +1,149 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testObject struct {
+	core.ObjectDescriptor
+	head      core.RecordRef
+	prototype core.RecordRef
+	parent    core.RecordRef
+	children  []core.RecordRef
+}
+
+func (o *testObject) Prototype() (*core.RecordRef, error) {
+	return &o.prototype, nil
+}
+
+func (o *testObject) Parent() *core.RecordRef {
+	return &o.parent
+}
+
+type testRefIterator struct {
+	refs []core.RecordRef
+}
+
+func (i *testRefIterator) HasNext() bool {
+	return len(i.refs) > 0
+}
+
+func (i *testRefIterator) Next() (*core.RecordRef, error) {
+	ref := i.refs[0]
+	i.refs = i.refs[1:]
+	return &ref, nil
+}
+
+func graphTestRunner(t *testing.T, objects ...*testObject) *Runner {
+	am := testutils.NewArtifactManagerMock(t)
+	find := func(ref core.RecordRef) *testObject {
+		for _, o := range objects {
+			if o.head == ref {
+				return o
+			}
+		}
+		return nil
+	}
+	am.GetObjectFunc = func(ctx context.Context, head core.RecordRef, state *core.RecordID, approved bool) (core.ObjectDescriptor, error) {
+		if o := find(head); o != nil {
+			return o, nil
+		}
+		return nil, errors.New("object not found")
+	}
+	am.GetChildrenFunc = func(ctx context.Context, parent core.RecordRef, pulse *core.PulseNumber) (core.RefIterator, error) {
+		return &testRefIterator{refs: find(parent).children}, nil
+	}
+	am.GetDelegateFunc = func(ctx context.Context, head core.RecordRef, asType core.RecordRef) (*core.RecordRef, error) {
+		for _, o := range objects {
+			if o.parent == head && o.prototype == asType {
+				return &o.head, nil
+			}
+		}
+		return nil, errors.New("delegate not found")
+	}
+	return &Runner{ArtifactManager: am}
+}
+
+func TestRunner_executeGraphQL(t *testing.T) {
+	memberProto, orgProto, walletProto := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	member := &testObject{head: testutils.RandomRef(), prototype: memberProto}
+	org1 := &testObject{head: testutils.RandomRef(), prototype: orgProto, parent: member.head}
+	org2 := &testObject{head: testutils.RandomRef(), prototype: orgProto, parent: member.head}
+	wallet := &testObject{head: testutils.RandomRef(), prototype: walletProto, parent: member.head}
+	member.children = []core.RecordRef{org1.head, wallet.head, org2.head}
+
+	ar := graphTestRunner(t, member, org1, org2, wallet)
+	answer := ar.executeGraphQL(context.Background(), GraphQLRequest{
+		Query: `query ($ref: String!, $org: String!) {
+			member: object(ref: $ref) {
+				reference
+				orgs: children(prototype: $org, offset: 1) { reference parent { reference } }
+				delegate(prototype: "` + walletProto.String() + `") { prototype }
+			}
+		}`,
+		Variables: map[string]interface{}{"ref": member.head.String(), "org": orgProto.String()},
+	})
+	require.Empty(t, answer.Errors)
+
+	res, err := json.Marshal(answer.Data)
+	require.NoError(t, err)
+	expected := `{"member":{"reference":"` + member.head.String() + `",` +
+		`"orgs":[{"reference":"` + org2.head.String() + `","parent":{"reference":"` + member.head.String() + `"}}],` +
+		`"delegate":{"prototype":"` + walletProto.String() + `"}}}`
+	require.Equal(t, expected, string(res))
+}
+
+func TestRunner_executeGraphQL_Errors(t *testing.T) {
+	ar := graphTestRunner(t)
+
+	answer := ar.executeGraphQL(context.Background(), GraphQLRequest{Query: `{ object(ref: "` + testutils.RandomRef().String() + `") { reference } }`})
+	require.Len(t, answer.Errors, 1)
+	require.Contains(t, answer.Errors[0].Message, "object not found")
+
+	answer = ar.executeGraphQL(context.Background(), GraphQLRequest{Query: `{ unknown }`})
+	require.Len(t, answer.Errors, 1)
+	require.Contains(t, answer.Errors[0].Message, "unknown field unknown of Query")
+
+	answer = ar.executeGraphQL(context.Background(), GraphQLRequest{Query: `{ object`})
+	require.Nil(t, answer.Data)
+	require.Len(t, answer.Errors, 1)
+}
+
+func TestRunner_executeGraphQL_CallBudget(t *testing.T) {
+	root := &testObject{head: testutils.RandomRef()}
+	for i := 0; i < graphChildrenMax; i++ {
+		root.children = append(root.children, root.head)
+	}
+
+	ar := graphTestRunner(t, root)
+	answer := ar.executeGraphQL(context.Background(), GraphQLRequest{
+		Query: `{ object(ref: "` + root.head.String() + `") {
+			children(limit: 100) { children(limit: 100) { children(limit: 100) { reference } } }
+		} }`,
+	})
+	require.Len(t, answer.Errors, 1)
+	require.Contains(t, answer.Errors[0].Message, "query exceeds budget")
+}
@@ -0,0 +1,203 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	sendMessageMethod    = "SendMessage"
+	subscribeInboxMethod = "SubscribeInbox"
+
+	inboxQueueSize = 64
+)
+
+// InboxEvent is pushed to the recipient's subscribers when a message is delivered through this node
+type InboxEvent struct {
+	Reference string `json:"reference"`
+	Sender    string `json:"sender"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// inboxHub keeps websocket subscriptions of members for their inboxes
+type inboxHub struct {
+	lock        sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func newInboxHub() *inboxHub {
+	return &inboxHub{
+		subscribers: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+func (h *inboxHub) subscribe(member string) chan []byte {
+	ch := make(chan []byte, inboxQueueSize)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.subscribers[member]; !ok {
+		h.subscribers[member] = make(map[chan []byte]struct{})
+	}
+	h.subscribers[member][ch] = struct{}{}
+	return ch
+}
+
+func (h *inboxHub) unsubscribe(member string, ch chan []byte) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.subscribers[member], ch)
+	if len(h.subscribers[member]) == 0 {
+		delete(h.subscribers, member)
+	}
+}
+
+// publish sends event to every subscriber of member, slow subscribers lose events
+func (h *inboxHub) publish(member string, event []byte) int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	delivered := 0
+	for ch := range h.subscribers[member] {
+		select {
+		case ch <- event:
+			delivered++
+		default:
+		}
+	}
+	return delivered
+}
+
+// notifyInbox pushes successfully sent message to the recipient's subscribers
+func (ar *Runner) notifyInbox(ctx context.Context, params Request, result interface{}) {
+	var to string
+	var payload []byte
+	var signature []byte
+	if err := core.Deserialize(params.Params, []interface{}{&to, &payload, &signature}); err != nil {
+		inslogger.FromContext(ctx).Warn("[ notifyInbox ] Can't unmarshal params: ", err)
+		return
+	}
+	msgRef, _ := result.(string)
+
+	event, err := json.Marshal(InboxEvent{
+		Reference: msgRef,
+		Sender:    params.Reference,
+		Payload:   payload,
+		Signature: signature,
+	})
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("[ notifyInbox ] Can't marshal event: ", err)
+		return
+	}
+	ar.inbox.publish(to, event)
+}
+
+func (ar *Runner) parseSubscribeRequest(req *http.Request) (Request, error) {
+	query := req.URL.Query()
+	params := Request{
+		Reference: query.Get("reference"),
+		Method:    subscribeInboxMethod,
+	}
+	var err error
+	params.Seed, err = base64.StdEncoding.DecodeString(query.Get("seed"))
+	if err != nil {
+		return params, errors.Wrap(err, "[ parseSubscribeRequest ] Can't decode seed")
+	}
+	params.Signature, err = base64.StdEncoding.DecodeString(query.Get("signature"))
+	if err != nil {
+		return params, errors.Wrap(err, "[ parseSubscribeRequest ] Can't decode signature")
+	}
+	return params, nil
+}
+
+// inboxHandler upgrades connection to websocket and pushes member's incoming messages.
+// Member authenticates the same way as for api call: with seed and signature of
+// (reference, "SubscribeInbox", empty params, seed) passed as query parameters.
+func (ar *Runner) inboxHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		traceID := utils.RandTraceID()
+		ctx, insLog := inslogger.WithTraceField(context.Background(), traceID)
+
+		params, err := ar.parseSubscribeRequest(req)
+		if err != nil {
+			insLog.Error(err)
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = ar.checkSeed(ctx, params.Seed)
+		if err != nil {
+			insLog.Error(errors.Wrap(err, "[ inboxHandler ] Can't checkSeed"))
+			http.Error(response, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		params.Reference, err = ar.resolveReference(ctx, params.Reference)
+		if err != nil {
+			insLog.Error(errors.Wrap(err, "[ inboxHandler ] Can't resolve reference"))
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = ar.verifySignature(ctx, params)
+		if err != nil {
+			insLog.Error(errors.Wrap(err, "[ inboxHandler ] Can't verify signature"))
+			http.Error(response, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := wsUpgrade(response, req)
+		if err != nil {
+			insLog.Error(err)
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer conn.Close() //nolint: errcheck
+
+		events := ar.inbox.subscribe(params.Reference)
+		defer ar.inbox.unsubscribe(params.Reference, events)
+
+		closed := make(chan struct{})
+		go func() {
+			conn.serveControl()
+			close(closed)
+		}()
+
+		insLog.Infof("[ inboxHandler ] Member %s subscribed to inbox", params.Reference)
+		for {
+			select {
+			case event := <-events:
+				if err := conn.WriteText(event); err != nil {
+					insLog.Warn("[ inboxHandler ] Can't push event: ", err)
+					return
+				}
+			case <-closed:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboxHub(t *testing.T) {
+	hub := newInboxHub()
+	ch := hub.subscribe("member")
+
+	require.Equal(t, 1, hub.publish("member", []byte("event")))
+	require.Equal(t, []byte("event"), <-ch)
+	require.Equal(t, 0, hub.publish("other", []byte("event")))
+
+	hub.unsubscribe("member", ch)
+	require.Equal(t, 0, hub.publish("member", []byte("event")))
+	require.Empty(t, hub.subscribers)
+}
+
+func TestInboxHub_SlowSubscriber(t *testing.T) {
+	hub := newInboxHub()
+	hub.subscribe("member")
+
+	for i := 0; i < inboxQueueSize; i++ {
+		require.Equal(t, 1, hub.publish("member", []byte("event")))
+	}
+	require.Equal(t, 0, hub.publish("member", []byte("event")))
+}
+
+func TestWsAcceptKey(t *testing.T) {
+	// example from RFC 6455
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", wsAcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestWsUpgrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, req *http.Request) {
+		conn, err := wsUpgrade(response, req)
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.WriteText([]byte("hello")))
+		conn.serveControl()
+	}))
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\n" +
+		"Host: localhost\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	frame := make([]byte, 7)
+	_, err = io.ReadFull(reader, frame)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x80|wsOpText), frame[0])
+	require.Equal(t, byte(5), frame[1])
+	require.Equal(t, "hello", string(frame[2:]))
+
+	// masked close frame with empty payload
+	closeFrame := []byte{0x80 | wsOpClose, 0x80, 0, 0, 0, 0}
+	_, err = conn.Write(closeFrame)
+	require.NoError(t, err)
+
+	head := make([]byte, 2)
+	_, err = io.ReadFull(reader, head)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x80|wsOpClose), head[0])
+}
+
+func TestWsUpgrade_NotWebsocket(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/inbox", nil)
+	_, err := wsUpgrade(httptest.NewRecorder(), req)
+	require.Error(t, err)
+}
+
+func TestWsConn_writeFrameLength(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := &wsConn{conn: server}
+
+	payload := make([]byte, 300)
+	go func() {
+		conn.WriteText(payload)
+		server.Close()
+	}()
+
+	head := make([]byte, 4)
+	_, err := io.ReadFull(client, head)
+	require.NoError(t, err)
+	require.Equal(t, byte(126), head[1])
+	require.Equal(t, uint16(300), binary.BigEndian.Uint16(head[2:]))
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -48,11 +49,29 @@ type Runner struct {
 	NetworkSwitcher     core.NetworkSwitcher     `inject:""`
 	NodeNetwork         core.NodeNetwork         `inject:""`
 	PulseStorage        core.PulseStorage        `inject:""`
+	ArtifactManager     core.ArtifactManager     `inject:""`
+	HostBanList         core.HostBanList         `inject:""`
+	HostAccessList      core.HostAccessList      `inject:""`
+	AdminLog            core.AdminLog            `inject:""`
+	CryptographyService core.CryptographyService `inject:""`
+	DiscoveryStandby    core.DiscoveryStandby    `inject:""`
+	ExecutionTracer     core.ExecutionTracer     `inject:""`
+	PartitionDrill      core.PartitionDrill      `inject:""`
+	PacketCapture       core.PacketCapture       `inject:""`
+	ConsensusTimelines  core.ConsensusTimelines  `inject:""`
+	CloudHashStorage    core.CloudHashStorage    `inject:""`
+	RoleInspector       core.RoleInspector       `inject:""`
+	SnapshotMaker       core.SnapshotMaker       `inject:""`
+	StorageInspector    core.StorageInspector    `inject:""`
 	server              *http.Server
 	rpcServer           *rpc.Server
 	cfg                 *configuration.APIRunner
 	keyCache            map[string]crypto.PublicKey
 	cacheLock           *sync.RWMutex
+	inbox               *inboxHub
+	orderer             *fairOrderer
+	explorer            *explorer
+	receipts            *receiptStore
 	SeedManager         *seedmanager.SeedManager
 	SeedGenerator       seedmanager.SeedGenerator
 }
@@ -103,6 +122,81 @@ func (ar *Runner) registerServices(rpcServer *rpc.Server) error {
 		return errors.New("[ registerServices ] Can't RegisterService: cert")
 	}
 
+	err = rpcServer.RegisterService(NewUpgradeService(ar), "upgrade")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: upgrade")
+	}
+
+	err = rpcServer.RegisterService(NewBanListService(ar), "banlist")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: banlist")
+	}
+
+	err = rpcServer.RegisterService(NewAccessListService(ar), "access")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: access")
+	}
+
+	err = rpcServer.RegisterService(NewOrderingService(ar), "ordering")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: ordering")
+	}
+
+	err = rpcServer.RegisterService(NewStandbyService(ar), "standby")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: standby")
+	}
+
+	err = rpcServer.RegisterService(NewDrillService(ar), "drill")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: drill")
+	}
+
+	err = rpcServer.RegisterService(NewConsensusService(ar), "consensus")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: consensus")
+	}
+
+	err = rpcServer.RegisterService(NewAdminService(ar), "admin")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: admin")
+	}
+
+	err = rpcServer.RegisterService(NewReceiptService(ar), "receipt")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: receipt")
+	}
+
+	err = rpcServer.RegisterService(NewCaptureService(ar), "capture")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: capture")
+	}
+
+	err = rpcServer.RegisterService(NewProofService(ar), "proof")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: proof")
+	}
+
+	err = rpcServer.RegisterService(NewRolesService(ar), "roles")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: roles")
+	}
+
+	err = rpcServer.RegisterService(NewSnapshotService(ar), "snapshot")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: snapshot")
+	}
+
+	err = rpcServer.RegisterService(NewObjectsService(ar), "objects")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: objects")
+	}
+
+	err = rpcServer.RegisterService(NewStorageService(ar), "storage")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: storage")
+	}
+
 	return nil
 }
 
@@ -121,6 +215,13 @@ func NewRunner(cfg *configuration.APIRunner) (*Runner, error) {
 		cfg:       cfg,
 		keyCache:  make(map[string]crypto.PublicKey),
 		cacheLock: &sync.RWMutex{},
+		inbox:     newInboxHub(),
+		orderer:   newFairOrderer(cfg.FairOrderingMethods, time.Duration(cfg.FairOrderingWindow)*time.Millisecond),
+		receipts:  newReceiptStore(int(cfg.Receipts)),
+	}
+
+	if cfg.Explorer != "" {
+		ar.explorer = newExplorer()
 	}
 
 	rpcServer.RegisterCodec(jsonrpc.NewCodec(), "application/json")
@@ -140,8 +241,33 @@ func (ar *Runner) IsAPIRunner() bool {
 // Start runs api server
 func (ar *Runner) Start(ctx context.Context) error {
 	ar.SeedManager = seedmanager.New()
-	http.HandleFunc(ar.cfg.Call, ar.callHandler())
-	http.Handle(ar.cfg.RPC, ar.rpcServer)
+	http.Handle(ar.cfg.Call, withMetrics("call", http.HandlerFunc(ar.callHandler())))
+	http.Handle(ar.cfg.RPC, withMetrics("rpc", ar.rpcServer))
+	if ar.cfg.Inbox != "" {
+		http.Handle(ar.cfg.Inbox, withMetrics("inbox", http.HandlerFunc(ar.inboxHandler())))
+	}
+	if ar.cfg.GraphQL != "" {
+		http.Handle(ar.cfg.GraphQL, withMetrics("graphql", http.HandlerFunc(ar.graphQLHandler())))
+	}
+	if ar.cfg.DumpUsers != "" {
+		http.Handle(ar.cfg.DumpUsers, withMetrics("dumpusers", http.HandlerFunc(ar.dumpUsersHandler())))
+	}
+	if ar.cfg.Contracts != "" {
+		http.Handle(ar.cfg.Contracts, withMetrics("contracts", http.HandlerFunc(ar.contractsHandler())))
+	}
+	if ar.cfg.WaitForPulse != "" {
+		http.Handle(ar.cfg.WaitForPulse, withMetrics("waitforpulse", http.HandlerFunc(ar.waitForPulseHandler())))
+	}
+	if ar.cfg.PreCheck != "" {
+		http.Handle(ar.cfg.PreCheck, withMetrics("precheck", http.HandlerFunc(ar.preCheckHandler())))
+	}
+	if ar.explorer != nil {
+		handler := withMetrics("explorer", http.HandlerFunc(ar.explorerHandler()))
+		path := strings.TrimSuffix(ar.cfg.Explorer, "/")
+		http.Handle(path, handler)
+		http.Handle(path+"/", handler)
+		go ar.explorer.watch(ctx, ar.PulseStorage)
+	}
 	inslog := inslogger.FromContext(ctx)
 	inslog.Info("Starting ApiRunner ...")
 	inslog.Info("Config: ", ar.cfg)
@@ -161,6 +287,10 @@ func (ar *Runner) Start(ctx context.Context) error {
 func (ar *Runner) Stop(ctx context.Context) error {
 	const timeOut = 5
 
+	if ar.explorer != nil {
+		ar.explorer.close()
+	}
+
 	inslogger.FromContext(ctx).Infof("Shutting down server gracefully ...(waiting for %d seconds)", timeOut)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeOut)*time.Second)
 	defer cancel()
@@ -0,0 +1,63 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/insolar/insolar/metrics"
+)
+
+// statusRecorder remembers status code written by handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush lets streaming handlers flush through the recorder
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// withMetrics wraps handler with request counter, latency histogram, in-flight gauge
+// and counter of http error codes, all labeled by queryType
+func withMetrics(queryType string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, req *http.Request) {
+		metrics.APIRequestsTotal.WithLabelValues(queryType).Inc()
+		inFlight := metrics.APIRequestsInFlight.WithLabelValues(queryType)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		recorder := &statusRecorder{ResponseWriter: response, status: http.StatusOK}
+		startTime := time.Now()
+		handler.ServeHTTP(recorder, req)
+		metrics.APIRequestTime.WithLabelValues(queryType).Observe(time.Since(startTime).Seconds())
+
+		if recorder.status >= http.StatusBadRequest {
+			metrics.APIErrorsTotal.WithLabelValues(queryType, strconv.Itoa(recorder.status)).Inc()
+		}
+	})
+}
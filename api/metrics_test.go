@@ -0,0 +1,62 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/insolar/insolar/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func metricValue(t *testing.T, m prometheus.Metric) *dto.Metric {
+	res := &dto.Metric{}
+	require.NoError(t, m.Write(res))
+	return res
+}
+
+func TestWithMetrics(t *testing.T) {
+	queryType := "test_with_metrics"
+	handler := withMetrics(queryType, http.HandlerFunc(func(response http.ResponseWriter, req *http.Request) {
+		inFlight := metricValue(t, metrics.APIRequestsInFlight.WithLabelValues(queryType))
+		require.Equal(t, float64(1), inFlight.GetGauge().GetValue())
+		if req.URL.Path == "/bad" {
+			response.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	for _, path := range []string{"/good", "/bad", "/good"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	total := metricValue(t, metrics.APIRequestsTotal.WithLabelValues(queryType))
+	require.Equal(t, float64(3), total.GetCounter().GetValue())
+
+	inFlight := metricValue(t, metrics.APIRequestsInFlight.WithLabelValues(queryType))
+	require.Equal(t, float64(0), inFlight.GetGauge().GetValue())
+
+	errs := metricValue(t, metrics.APIErrorsTotal.WithLabelValues(queryType, "400"))
+	require.Equal(t, float64(1), errs.GetCounter().GetValue())
+
+	latency := &dto.Metric{}
+	require.NoError(t, metrics.APIRequestTime.WithLabelValues(queryType).(prometheus.Histogram).Write(latency))
+	require.Equal(t, uint64(3), latency.GetHistogram().GetSampleCount())
+}
@@ -0,0 +1,128 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultObjectsLimit is count of objects returned by Objects.ByPrototype if limit is not set.
+	defaultObjectsLimit = 100
+	// maxObjectsLimit is max count of objects returned by Objects.ByPrototype.
+	maxObjectsLimit = 1000
+)
+
+// ObjectsByPrototypeArgs is arguments that Objects.ByPrototype accepts.
+type ObjectsByPrototypeArgs struct {
+	PageArgs
+	Prototype string
+}
+
+// ObjectsByPrototypeReply is reply for Objects.ByPrototype.
+type ObjectsByPrototypeReply struct {
+	PageInfo
+	Objects []string
+}
+
+// ObjectsService is a service that provides queries of objects.
+type ObjectsService struct {
+	runner *Runner
+}
+
+// NewObjectsService creates new Objects service instance.
+func NewObjectsService(runner *Runner) *ObjectsService {
+	return &ObjectsService{runner: runner}
+}
+
+// ByPrototype returns references of objects of prototype ordered by reference. Objects are taken from index of
+// heavy material node, so objects activated in recent pulses which are not replicated yet are not returned.
+// Filter and sort are not supported.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "objects.ByPrototype",
+//	  "params": {
+//	    "Prototype": str, // prototype reference
+//	    "Cursor": str, // NextCursor of previous page, optional
+//	    "Limit": int // max count of objects, 100 by default
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *ObjectsService) ByPrototype(r *http.Request, args *ObjectsByPrototypeArgs, reply *ObjectsByPrototypeReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ObjectsService.ByPrototype ] Incoming request: %s", r.RequestURI)
+
+	if !args.simple() {
+		return errors.New("[ ObjectsService.ByPrototype ] filter and sort are not supported")
+	}
+	prototype, err := core.NewRefFromBase58(args.Prototype)
+	if err != nil {
+		return errors.Wrap(err, "[ ObjectsService.ByPrototype ] failed to parse prototype reference")
+	}
+	var from *core.RecordRef
+	if args.Cursor != "" {
+		from, err = decodeRefCursor(args.Cursor)
+		if err != nil {
+			return errors.Wrap(err, "[ ObjectsService.ByPrototype ]")
+		}
+	}
+
+	refs, next, err := s.runner.ArtifactManager.GetObjectsByPrototype(
+		ctx, *prototype, from, args.limit(defaultObjectsLimit, maxObjectsLimit),
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ ObjectsService.ByPrototype ] failed to get objects")
+	}
+
+	reply.Objects = make([]string, len(refs))
+	for i, ref := range refs {
+		reply.Objects[i] = ref.String()
+	}
+	if next != nil {
+		reply.NextCursor = encodeRefCursor(*next)
+	}
+	return nil
+}
+
+// encodeRefCursor returns opaque cursor token of list ordered by reference.
+func encodeRefCursor(ref core.RecordRef) string {
+	return base64.RawURLEncoding.EncodeToString(ref[:])
+}
+
+// decodeRefCursor parses cursor token got with encodeRefCursor.
+func decodeRefCursor(token string) (*core.RecordRef, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed cursor")
+	}
+	if len(buf) != core.RecordRefSize {
+		return nil, errors.New("malformed cursor")
+	}
+	var ref core.RecordRef
+	copy(ref[:], buf)
+	return &ref, nil
+}
@@ -0,0 +1,225 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// Fair ordering is submit-then-reveal mode for designated methods. Client commits hash of request in pulse N
+// and sends request itself in pulse N+1. Revealed requests are collected during reveal window and are executed
+// in order derived from entropy of pulse N+1, that is unknown at the moment of commit.
+
+// maxCommits limits count of commits waiting for reveal
+const maxCommits = 100000
+
+var (
+	// ErrTooManyCommits is returned when node has too many commits waiting for reveal
+	ErrTooManyCommits = errors.New("too many committed requests, try later")
+	// ErrNotCommitted is returned when request of designated method was not committed before
+	ErrNotCommitted = errors.New("request must be committed in previous pulse")
+	// ErrRevealTooEarly is returned when request is revealed in the same pulse it was committed
+	ErrRevealTooEarly = errors.New("request must be revealed in the pulse following commit")
+)
+
+type revealResult struct {
+	result interface{}
+	err    error
+}
+
+type revealedRequest struct {
+	ctx   context.Context
+	hash  []byte
+	order []byte
+	exec  func(ctx context.Context) (interface{}, error)
+	done  chan revealResult
+}
+
+type fairOrderer struct {
+	methods map[string]bool
+	window  time.Duration
+
+	lock    sync.Mutex
+	commits map[string]core.PulseNumber
+	batches map[core.PulseNumber][]*revealedRequest
+}
+
+func newFairOrderer(methods []string, window time.Duration) *fairOrderer {
+	o := &fairOrderer{
+		methods: make(map[string]bool),
+		window:  window,
+		commits: make(map[string]core.PulseNumber),
+		batches: make(map[core.PulseNumber][]*revealedRequest),
+	}
+	for _, m := range methods {
+		o.methods[m] = true
+	}
+	return o
+}
+
+// designated returns true if method is executed in fair ordering mode.
+func (o *fairOrderer) designated(method string) bool {
+	return o.methods[method]
+}
+
+// commit remembers hash of request committed in pulse.
+func (o *fairOrderer) commit(pulse *core.Pulse, hash []byte) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.forgetStale(pulse)
+	if len(o.commits) >= maxCommits {
+		return ErrTooManyCommits
+	}
+	o.commits[hex.EncodeToString(hash)] = pulse.PulseNumber
+	return nil
+}
+
+// forgetStale drops commits that can't be revealed anymore. Must be called under lock.
+func (o *fairOrderer) forgetStale(pulse *core.Pulse) {
+	for hash, pn := range o.commits {
+		if pn < pulse.PrevPulseNumber {
+			delete(o.commits, hash)
+		}
+	}
+}
+
+// reveal queues committed request to batch of current pulse and waits for its execution.
+func (o *fairOrderer) reveal(
+	ctx context.Context, pulse *core.Pulse, hash []byte, exec func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	key := hex.EncodeToString(hash)
+
+	o.lock.Lock()
+	o.forgetStale(pulse)
+	committed, ok := o.commits[key]
+	if !ok {
+		o.lock.Unlock()
+		return nil, ErrNotCommitted
+	}
+	if committed >= pulse.PulseNumber {
+		o.lock.Unlock()
+		return nil, ErrRevealTooEarly
+	}
+	delete(o.commits, key)
+
+	request := &revealedRequest{
+		ctx:   ctx,
+		hash:  hash,
+		order: scheme.IntegrityHasher().Hash(append(pulse.Entropy[:], hash...)),
+		exec:  exec,
+		done:  make(chan revealResult, 1),
+	}
+	batch, started := o.batches[pulse.PulseNumber]
+	o.batches[pulse.PulseNumber] = append(batch, request)
+	o.lock.Unlock()
+
+	if !started {
+		time.AfterFunc(o.window, func() { o.execute(pulse.PulseNumber) })
+	}
+
+	select {
+	case res := <-request.done:
+		return res.result, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// execute runs requests of batch one by one in entropy-derived order.
+func (o *fairOrderer) execute(pulse core.PulseNumber) {
+	o.lock.Lock()
+	batch := o.batches[pulse]
+	delete(o.batches, pulse)
+	o.lock.Unlock()
+
+	sort.Slice(batch, func(i, j int) bool {
+		return bytes.Compare(batch[i].order, batch[j].order) < 0
+	})
+	for _, request := range batch {
+		if request.ctx.Err() != nil {
+			request.done <- revealResult{err: request.ctx.Err()}
+			continue
+		}
+		result, err := request.exec(request.ctx)
+		request.done <- revealResult{result: result, err: err}
+	}
+}
+
+// OrderingService is a service that accepts commits of requests executed in fair ordering mode.
+type OrderingService struct {
+	runner *Runner
+}
+
+// NewOrderingService creates new Ordering service instance.
+func NewOrderingService(runner *Runner) *OrderingService {
+	return &OrderingService{runner: runner}
+}
+
+// CommitArgs is arguments that Ordering.Commit accepts.
+type CommitArgs struct {
+	// Hash is hash of request fields covered by signature
+	Hash []byte
+}
+
+// CommitReply is reply for Ordering.Commit requests.
+type CommitReply struct {
+	// PulseNumber is number of pulse request is committed in, request must be sent in the next pulse
+	PulseNumber core.PulseNumber
+}
+
+// Commit commits hash of request of method executed in fair ordering mode.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "ordering.Commit",
+//	  "params": {
+//	    "Hash": str // base64 encoded hash of request
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *OrderingService) Commit(r *http.Request, args *CommitArgs, reply *CommitReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ OrderingService.Commit ] Incoming request: %s", r.RequestURI)
+
+	if len(args.Hash) == 0 {
+		return errors.New("[ OrderingService.Commit ] Hash must not be empty")
+	}
+	pulse, err := s.runner.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ OrderingService.Commit ] Can't get current pulse")
+	}
+	err = s.runner.orderer.commit(pulse, args.Hash)
+	if err != nil {
+		return errors.Wrap(err, "[ OrderingService.Commit ] Can't commit request")
+	}
+	reply.PulseNumber = pulse.PulseNumber
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairOrderer_Reveal(t *testing.T) {
+	ctx := context.Background()
+	o := newFairOrderer([]string{"Transfer"}, 50*time.Millisecond)
+	require.True(t, o.designated("Transfer"))
+	require.False(t, o.designated("GetBalance"))
+
+	commitPulse := &core.Pulse{PulseNumber: 10, PrevPulseNumber: 0}
+	revealPulse := &core.Pulse{PulseNumber: 20, PrevPulseNumber: 10, Entropy: core.Entropy{1, 2, 3}}
+	exec := func(ctx context.Context) (interface{}, error) { return "ok", nil }
+
+	_, err := o.reveal(ctx, revealPulse, []byte("unknown"), exec)
+	require.Equal(t, ErrNotCommitted, err)
+
+	require.NoError(t, o.commit(commitPulse, []byte("early")))
+	_, err = o.reveal(ctx, commitPulse, []byte("early"), exec)
+	require.Equal(t, ErrRevealTooEarly, err)
+
+	hashes := [][]byte{[]byte("first"), []byte("second"), []byte("third")}
+	for _, hash := range hashes {
+		require.NoError(t, o.commit(commitPulse, hash))
+	}
+
+	var lock sync.Mutex
+	var executed [][]byte
+	wg := sync.WaitGroup{}
+	wg.Add(len(hashes))
+	for _, hash := range hashes {
+		go func(hash []byte) {
+			defer wg.Done()
+			res, err := o.reveal(ctx, revealPulse, hash, func(ctx context.Context) (interface{}, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				executed = append(executed, hash)
+				return "ok", nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, "ok", res)
+		}(hash)
+	}
+	wg.Wait()
+
+	require.Len(t, executed, len(hashes))
+	for i := 1; i < len(executed); i++ {
+		prev := scheme.IntegrityHasher().Hash(append(revealPulse.Entropy[:], executed[i-1]...))
+		next := scheme.IntegrityHasher().Hash(append(revealPulse.Entropy[:], executed[i]...))
+		require.True(t, bytes.Compare(prev, next) < 0)
+	}
+
+	// commit can be revealed only once
+	_, err = o.reveal(ctx, revealPulse, hashes[0], exec)
+	require.Equal(t, ErrNotCommitted, err)
+}
@@ -0,0 +1,246 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// PageArgs is pagination, filter and sort arguments of list methods. It is embedded into args of methods
+// returning lists, so every list is paged the same way.
+type PageArgs struct {
+	// Cursor is NextCursor of previous page, first page is returned if empty
+	Cursor string
+	// Limit is max count of items on page, method default is used if not set
+	Limit int
+	// Filter is map of item field to value, only items with all fields equal to values are returned
+	Filter map[string]string
+	// Sort is item field to sort by, "-" prefix means descending order. List order is used if empty
+	Sort string
+}
+
+// PageInfo is pagination part of list methods reply.
+type PageInfo struct {
+	// NextCursor is cursor of next page, empty if page is the last one
+	NextCursor string
+}
+
+// Cursor is position of list item: pulse of record and position of record in pulse.
+type Cursor struct {
+	Pulse    core.PulseNumber
+	Position uint64
+}
+
+// cursorHeaderSize is size of pulse and position in encoded cursor.
+const cursorHeaderSize = 12
+
+// encodeCursor returns opaque cursor token. Token is bound to sort order, so it can't be used with another one.
+func encodeCursor(cursor Cursor, order string) string {
+	buf := make([]byte, cursorHeaderSize+len(order))
+	binary.BigEndian.PutUint32(buf, uint32(cursor.Pulse))
+	binary.BigEndian.PutUint64(buf[4:], cursor.Position)
+	copy(buf[cursorHeaderSize:], order)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodeCursor parses cursor token got with encodeCursor for the same sort order.
+func decodeCursor(token string, order string) (*Cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed cursor")
+	}
+	if len(buf) < cursorHeaderSize {
+		return nil, errors.New("malformed cursor")
+	}
+	if string(buf[cursorHeaderSize:]) != order {
+		return nil, errors.New("cursor doesn't match sort order")
+	}
+	return &Cursor{
+		Pulse:    core.PulseNumber(binary.BigEndian.Uint32(buf)),
+		Position: binary.BigEndian.Uint64(buf[4:]),
+	}, nil
+}
+
+// pageItem is item of list that can be paged.
+type pageItem interface {
+	// pagePosition returns unique position of item in list
+	pagePosition() Cursor
+	// pageField returns value of item field for filter and sort. Value is string, int64, uint64 or time.Time.
+	pageField(name string) (interface{}, bool)
+}
+
+// filterQueryPrefix is prefix of query parameters with filter values, e.g. filter.method=Transfer.
+const filterQueryPrefix = "filter."
+
+// pageArgsFromQuery parses pagination arguments of HTTP endpoints from cursor, limit, sort and filter.<field>
+// query parameters.
+func pageArgsFromQuery(query url.Values) (PageArgs, error) {
+	args := PageArgs{
+		Cursor: query.Get("cursor"),
+		Sort:   query.Get("sort"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		args.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return PageArgs{}, errors.Wrap(err, "malformed limit")
+		}
+	}
+	for key := range query {
+		if !strings.HasPrefix(key, filterQueryPrefix) {
+			continue
+		}
+		if args.Filter == nil {
+			args.Filter = make(map[string]string)
+		}
+		args.Filter[strings.TrimPrefix(key, filterQueryPrefix)] = query.Get(key)
+	}
+	return args, nil
+}
+
+// limit returns count of items on page.
+func (args PageArgs) limit(def, max int) int {
+	if args.Limit <= 0 {
+		return def
+	}
+	if args.Limit > max {
+		return max
+	}
+	return args.Limit
+}
+
+// simple checks if list can be paged by cursor position only, without filter and sort.
+func (args PageArgs) simple() bool {
+	return len(args.Filter) == 0 && args.Sort == ""
+}
+
+// page selects items of requested page from items in list order.
+func (args PageArgs) page(items []pageItem, def, max int) ([]pageItem, PageInfo, error) {
+	matched := make([]pageItem, 0, len(items))
+	for _, item := range items {
+		ok, err := args.match(item)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+	if err := args.sort(matched); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	start := 0
+	if args.Cursor != "" {
+		cursor, err := decodeCursor(args.Cursor, args.Sort)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		start = -1
+		for i, item := range matched {
+			if item.pagePosition() == *cursor {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, PageInfo{}, errors.New("cursor is expired")
+		}
+	}
+
+	var info PageInfo
+	end := start + args.limit(def, max)
+	if end < len(matched) {
+		info.NextCursor = encodeCursor(matched[end-1].pagePosition(), args.Sort)
+	} else {
+		end = len(matched)
+	}
+	return matched[start:end], info, nil
+}
+
+func (args PageArgs) match(item pageItem) (bool, error) {
+	for name, expected := range args.Filter {
+		value, ok := item.pageField(name)
+		if !ok {
+			return false, errors.Errorf("unknown filter field %s", name)
+		}
+		if formatPageValue(value) != expected {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (args PageArgs) sort(items []pageItem) error {
+	if args.Sort == "" {
+		return nil
+	}
+	field := strings.TrimPrefix(args.Sort, "-")
+	desc := field != args.Sort
+	for _, item := range items {
+		if _, ok := item.pageField(field); !ok {
+			return errors.Errorf("unknown sort field %s", field)
+		}
+	}
+	// stable sort keeps list order of items with equal values, so pages don't overlap
+	sort.SliceStable(items, func(i, j int) bool {
+		a, _ := items[i].pageField(field)
+		b, _ := items[j].pageField(field)
+		if desc {
+			return lessPageValue(b, a)
+		}
+		return lessPageValue(a, b)
+	})
+	return nil
+}
+
+func formatPageValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func lessPageValue(a, b interface{}) bool {
+	switch a := a.(type) {
+	case int64:
+		return a < b.(int64)
+	case uint64:
+		return a < b.(uint64)
+	case time.Time:
+		return a.Before(b.(time.Time))
+	default:
+		return formatPageValue(a) < formatPageValue(b)
+	}
+}
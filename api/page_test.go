@@ -0,0 +1,128 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/require"
+)
+
+type testPageItem struct {
+	pulse core.PulseNumber
+	name  string
+	size  int64
+}
+
+func (i testPageItem) pagePosition() Cursor {
+	return Cursor{Pulse: i.pulse}
+}
+
+func (i testPageItem) pageField(name string) (interface{}, bool) {
+	switch name {
+	case "name":
+		return i.name, true
+	case "size":
+		return i.size, true
+	}
+	return nil, false
+}
+
+func testPageItems() []pageItem {
+	return []pageItem{
+		testPageItem{pulse: 1, name: "a", size: 30},
+		testPageItem{pulse: 2, name: "b", size: 10},
+		testPageItem{pulse: 3, name: "a", size: 20},
+		testPageItem{pulse: 4, name: "c", size: 10},
+	}
+}
+
+func pulses(items []pageItem) []core.PulseNumber {
+	result := make([]core.PulseNumber, len(items))
+	for i, item := range items {
+		result[i] = item.pagePosition().Pulse
+	}
+	return result
+}
+
+func TestCursor_Encoding(t *testing.T) {
+	cursor := Cursor{Pulse: core.FirstPulseNumber, Position: 42}
+	decoded, err := decodeCursor(encodeCursor(cursor, "-size"), "-size")
+	require.NoError(t, err)
+	require.Equal(t, cursor, *decoded)
+
+	_, err = decodeCursor(encodeCursor(cursor, "-size"), "size")
+	require.Error(t, err)
+	_, err = decodeCursor("not a cursor", "")
+	require.Error(t, err)
+	_, err = decodeCursor("AAAA", "")
+	require.Error(t, err)
+}
+
+func TestPageArgs_Page(t *testing.T) {
+	args := PageArgs{Limit: 3}
+	page, info, err := args.page(testPageItems(), 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, []core.PulseNumber{1, 2, 3}, pulses(page))
+
+	args.Cursor = info.NextCursor
+	page, info, err = args.page(testPageItems(), 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, []core.PulseNumber{4}, pulses(page))
+	require.Empty(t, info.NextCursor)
+
+	// equal values keep list order
+	args = PageArgs{Limit: 2, Sort: "size"}
+	page, info, err = args.page(testPageItems(), 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, []core.PulseNumber{2, 4}, pulses(page))
+	args.Cursor = info.NextCursor
+	page, _, err = args.page(testPageItems(), 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, []core.PulseNumber{3, 1}, pulses(page))
+
+	args = PageArgs{Filter: map[string]string{"name": "a", "size": "20"}, Sort: "-size"}
+	page, _, err = args.page(testPageItems(), 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, []core.PulseNumber{3}, pulses(page))
+
+	// limit is bounded by max
+	page, _, err = PageArgs{Limit: 100}.page(testPageItems(), 1, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+
+	_, _, err = PageArgs{Sort: "unknown"}.page(testPageItems(), 10, 10)
+	require.Error(t, err)
+	_, _, err = PageArgs{Filter: map[string]string{"unknown": ""}}.page(testPageItems(), 10, 10)
+	require.Error(t, err)
+	_, _, err = PageArgs{Cursor: encodeCursor(Cursor{Pulse: 5}, "")}.page(testPageItems(), 10, 10)
+	require.Error(t, err)
+}
+
+func TestPageArgsFromQuery(t *testing.T) {
+	query, err := url.ParseQuery("cursor=abc&limit=5&sort=-name&filter.name=a&other=1")
+	require.NoError(t, err)
+	args, err := pageArgsFromQuery(query)
+	require.NoError(t, err)
+	require.Equal(t, PageArgs{Cursor: "abc", Limit: 5, Sort: "-name", Filter: map[string]string{"name": "a"}}, args)
+
+	_, err = pageArgsFromQuery(url.Values{"limit": {"many"}})
+	require.Error(t, err)
+}
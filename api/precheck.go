@@ -0,0 +1,216 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/insolar/insolar/application/contract/member/signer"
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/application/proxy/wallet"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const transferMethod = "Transfer"
+
+// Names of checks performed by pre-check.
+const (
+	PreCheckReference = "reference"
+	PreCheckSignature = "signature"
+	PreCheckRecipient = "recipient"
+	PreCheckBalance   = "balance"
+)
+
+// PreCheckResult is a result of single check of prospective request.
+type PreCheckResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PreCheck is an artifact of request pre-validation signed by node.
+// Signature covers JSON representation of artifact with empty signature field.
+type PreCheck struct {
+	Reference   string           `json:"reference"`
+	Method      string           `json:"method"`
+	RequestHash []byte           `json:"requestHash"`
+	Checks      []PreCheckResult `json:"checks"`
+	Valid       bool             `json:"valid"`
+	// PulseNumber and ValidUntil are bounds of pulses in which artifact is valid
+	PulseNumber core.PulseNumber `json:"pulseNumber"`
+	ValidUntil  core.PulseNumber `json:"validUntil"`
+	Signature   []byte           `json:"signature,omitempty"`
+}
+
+func (pc *PreCheck) addCheck(name string, err error) bool {
+	result := PreCheckResult{Name: name, Passed: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	pc.Checks = append(pc.Checks, result)
+	return result.Passed
+}
+
+// preCheck validates request without executing it. Seed is not consumed, so the same request can be sent to call
+// endpoint after that.
+func (ar *Runner) preCheck(ctx context.Context, params Request) (*PreCheck, error) {
+	pulse, err := ar.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ preCheck ] Can't get current pulse")
+	}
+
+	result := &PreCheck{
+		Reference:   params.Reference,
+		Method:      params.Method,
+		PulseNumber: pulse.PulseNumber,
+		ValidUntil:  pulse.PulseNumber + core.PulseNumber(ar.cfg.PreCheckPulses)*(pulse.NextPulseNumber-pulse.PulseNumber),
+	}
+
+	ref, err := core.NewRefFromBase58(params.Reference)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ preCheck ] failed to parse params.Reference")
+	}
+	result.RequestHash, err = requestHash(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ preCheck ] Can't calculate request hash")
+	}
+
+	result.Valid = ar.preCheckRequest(ctx, params, ref, result)
+	if ctx.Err() != nil {
+		return nil, errors.Wrap(ctx.Err(), "[ preCheck ] Request aborted")
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ preCheck ] Can't marshal artifact")
+	}
+	signature, err := ar.CryptographyService.Sign(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ preCheck ] Can't sign artifact")
+	}
+	result.Signature = signature.Bytes()
+	return result, nil
+}
+
+// preCheckRequest adds results of checks to artifact, stops on the first failed check.
+func (ar *Runner) preCheckRequest(ctx context.Context, params Request, ref *core.RecordRef, result *PreCheck) bool {
+	_, err := ar.getMemberPubKey(ctx, params.Reference)
+	if !result.addCheck(PreCheckReference, err) {
+		return false
+	}
+	if !result.addCheck(PreCheckSignature, ar.verifySignature(ctx, params)) {
+		return false
+	}
+	if params.Method != transferMethod {
+		return true
+	}
+
+	var amount uint
+	var to string
+	err = signer.UnmarshalParams(params.Params, &amount, &to)
+	if err == nil {
+		err = ar.checkObjectExists(ctx, to)
+	}
+	if !result.addCheck(PreCheckRecipient, err) {
+		return false
+	}
+
+	balance, err := ar.getBalance(ctx, *ref)
+	if err == nil && balance < amount {
+		err = errors.Errorf("insufficient balance: %d, required %d", balance, amount)
+	}
+	return result.addCheck(PreCheckBalance, err)
+}
+
+func (ar *Runner) checkObjectExists(ctx context.Context, ref string) error {
+	reference, err := core.NewRefFromBase58(ref)
+	if err != nil {
+		return errors.Wrap(err, "[ checkObjectExists ] Can't parse ref")
+	}
+	_, err = ar.ArtifactManager.GetObject(ctx, *reference, nil, false)
+	if err != nil {
+		return errors.Wrap(err, "[ checkObjectExists ] Object not found")
+	}
+	return nil
+}
+
+// getBalance makes dry-run of balance request to member's wallet.
+func (ar *Runner) getBalance(ctx context.Context, member core.RecordRef) (uint, error) {
+	walletRef, err := ar.ArtifactManager.GetDelegate(ctx, member, *wallet.PrototypeReference)
+	if err != nil {
+		return 0, errors.Wrap(err, "[ getBalance ] Can't get wallet")
+	}
+	res, err := ar.ContractRequester.SendRequest(ctx, walletRef, "GetBalance", []interface{}{})
+	if err != nil {
+		return 0, errors.Wrap(err, "[ getBalance ] Can't get balance")
+	}
+	return extractor.BalanceResponse(res.(*reply.CallMethod).Result)
+}
+
+func (ar *Runner) preCheckHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		traceID := utils.RandTraceID()
+		ctx, insLog := inslogger.WithTraceField(req.Context(), traceID)
+
+		params := Request{}
+		resp := answer{TraceID: traceID}
+
+		defer func() {
+			res, err := json.MarshalIndent(resp, "", "    ")
+			if err != nil {
+				res = []byte(`{"error": "can't marshal answer to json'"}`)
+			}
+			response.Header().Add("Content-Type", "application/json")
+			_, err = response.Write(res)
+			if err != nil {
+				insLog.Errorf("Can't write response\n")
+			}
+		}()
+
+		_, err := UnmarshalRequest(req, &params)
+		if err != nil {
+			processError(err, "Can't unmarshal request", &resp, insLog)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, ar.requestTimeout(params.Timeout))
+		defer cancel()
+
+		params.Reference, err = ar.resolveReference(ctx, params.Reference)
+		if err != nil {
+			if !processContextError(err, &resp, insLog) {
+				processError(err, "Can't resolve reference", &resp, insLog)
+			}
+			return
+		}
+
+		result, err := ar.preCheck(ctx, params)
+		if err != nil {
+			if !processContextError(err, &resp, insLog) {
+				processError(err, "Can't pre-check request", &resp, insLog)
+			}
+			return
+		}
+		resp.Result = result
+	}
+}
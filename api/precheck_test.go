@@ -0,0 +1,115 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"crypto"
+	"sync"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func preCheckRunner(t *testing.T, member core.RecordRef, key crypto.PublicKey, balance uint) *Runner {
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: 100, NextPulseNumber: 110}, nil)
+
+	walletRef := testutils.RandomRef()
+	am := testutils.NewArtifactManagerMock(t)
+	am.GetObjectMock.Return(nil, nil)
+	am.GetDelegateFunc = func(_ context.Context, head core.RecordRef, _ core.RecordRef) (*core.RecordRef, error) {
+		require.Equal(t, member, head)
+		return &walletRef, nil
+	}
+
+	cr := testutils.NewContractRequesterMock(t)
+	cr.SendRequestFunc = func(_ context.Context, ref *core.RecordRef, method string, _ []interface{}) (core.Reply, error) {
+		require.Equal(t, walletRef, *ref)
+		require.Equal(t, "GetBalance", method)
+		data, _ := core.MarshalArgs(balance, nil)
+		return &reply.CallMethod{Result: data}, nil
+	}
+
+	cs := testutils.NewCryptographyServiceMock(t)
+	cs.SignMock.Return(&core.Signature{}, nil)
+
+	cfg := configuration.NewAPIRunner()
+	return &Runner{
+		cfg:                 &cfg,
+		PulseStorage:        ps,
+		ArtifactManager:     am,
+		ContractRequester:   cr,
+		CryptographyService: cs,
+		keyCache:            map[string]crypto.PublicKey{member.String(): key},
+		cacheLock:           &sync.RWMutex{},
+	}
+}
+
+func signedTransfer(t *testing.T, member core.RecordRef, key crypto.PrivateKey, amount uint) Request {
+	params, err := core.MarshalArgs(amount, testutils.RandomRef().String())
+	require.NoError(t, err)
+	request := Request{
+		Reference: member.String(),
+		Method:    transferMethod,
+		Params:    params,
+		Seed:      []byte("seed"),
+	}
+	args, err := core.MarshalArgs(member, request.Method, request.Params, request.Seed)
+	require.NoError(t, err)
+	signature, err := scheme.Signer(key).Sign(args)
+	require.NoError(t, err)
+	request.Signature = signature.Bytes()
+	return request
+}
+
+func TestRunner_preCheck(t *testing.T) {
+	ctx := context.Background()
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	member := testutils.RandomRef()
+	ar := preCheckRunner(t, member, kp.ExtractPublicKey(key), 100)
+
+	result, err := ar.preCheck(ctx, signedTransfer(t, member, key, 50))
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Len(t, result.Checks, 4)
+	assert.Equal(t, core.PulseNumber(100), result.PulseNumber)
+	assert.Equal(t, core.PulseNumber(120), result.ValidUntil)
+	assert.NotNil(t, result.RequestHash)
+
+	result, err = ar.preCheck(ctx, signedTransfer(t, member, key, 150))
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, PreCheckBalance, result.Checks[3].Name)
+	assert.Contains(t, result.Checks[3].Error, "insufficient balance")
+
+	request := signedTransfer(t, member, key, 50)
+	request.Seed = []byte("other")
+	result, err = ar.preCheck(ctx, request)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Checks, 2)
+	assert.False(t, result.Checks[1].Passed)
+}
@@ -0,0 +1,144 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/pkg/errors"
+)
+
+// ObjectStateArgs is arguments that Proof.ObjectState accepts.
+type ObjectStateArgs struct {
+	Reference string
+	Pulse     core.PulseNumber
+}
+
+// PathStepReply is a step of merkle path, the next hash is hash of concatenation of Hash and the current hash
+// if Left is true, and of the current hash and Hash otherwise.
+type PathStepReply struct {
+	Left bool
+	Hash []byte
+}
+
+// ObjectStateReply is object state with merkle path from state hash of node to the signed cloud hash of pulse.
+// State hash of node is not calculated from object states yet, so the path proves only that state hash of node is
+// included in cloud hash, the object state itself is unverified.
+type ObjectStateReply struct {
+	// Verified is true if object state is bound to state hash of node, it is always false for now
+	Verified bool
+
+	Reference  string
+	State      string
+	StatePulse core.PulseNumber
+	Memory     []byte
+	MemoryHash []byte
+
+	Pulse core.PulseNumber
+	// Node is reference of node which state hash is the leaf of path
+	Node      string
+	StateHash []byte
+	Path      []PathStepReply
+	CloudHash []byte
+	// GlobuleProofs are signatures of globule proofs the cloud hash was calculated from
+	GlobuleProofs []GlobuleProofReply
+}
+
+// ProofService is a service that provides proofs for light clients.
+type ProofService struct {
+	runner *Runner
+}
+
+// NewProofService creates new Proof service instance.
+func NewProofService(runner *Runner) *ProofService {
+	return &ProofService{runner: runner}
+}
+
+// ObjectState returns state of object as of pulse and merkle path from state hash of the node that serves
+// request up to the cloud hash of pulse. State is returned only if the latest state of object was created on or
+// before pulse.
+//
+// State hash of node doesn't cover object states yet, so the reply is marked as unverified: light clients can check
+// that the node is a part of signed cloud hash, but have to trust the node for the object state itself.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "proof.ObjectState",
+//	  "params": {
+//	    "Reference": str, // object reference
+//	    "Pulse": int // pulse number
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *ProofService) ObjectState(r *http.Request, args *ObjectStateArgs, reply *ObjectStateReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ProofService.ObjectState ] Incoming request: %s", r.RequestURI)
+
+	ref, err := core.NewRefFromBase58(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ ProofService.ObjectState ] failed to parse reference")
+	}
+	desc, err := s.runner.ArtifactManager.GetObject(ctx, *ref, nil, false)
+	if err != nil {
+		return errors.Wrap(err, "[ ProofService.ObjectState ] failed to get object")
+	}
+	state := desc.StateID()
+	if state.Pulse() > args.Pulse {
+		return errors.Errorf(
+			"[ ProofService.ObjectState ] state of object on pulse %d is unknown, latest state is of pulse %d",
+			args.Pulse, state.Pulse(),
+		)
+	}
+
+	record, err := s.runner.CloudHashStorage.GetCloudHash(ctx, args.Pulse)
+	if err != nil {
+		return errors.Wrapf(err, "[ ProofService.ObjectState ] failed to get cloud hash of pulse %d", args.Pulse)
+	}
+	node := s.runner.NodeNetwork.GetOrigin().ID()
+	path, err := merkle.CloudPath(scheme, *record, node)
+	if err != nil {
+		return errors.Wrap(err, "[ ProofService.ObjectState ] failed to build merkle path")
+	}
+	for _, proof := range record.NodeProofs {
+		if proof.Node == node {
+			reply.StateHash = proof.StateHash
+		}
+	}
+
+	reply.Verified = false
+	reply.Reference = args.Reference
+	reply.State = state.String()
+	reply.StatePulse = state.Pulse()
+	reply.Memory = desc.Memory()
+	reply.MemoryHash = scheme.IntegrityHasher().Hash(reply.Memory)
+	reply.Pulse = record.Pulse
+	reply.Node = node.String()
+	reply.Path = make([]PathStepReply, len(path))
+	for i, step := range path {
+		reply.Path[i] = PathStepReply{Left: step.Left, Hash: step.Hash}
+	}
+	reply.CloudHash = record.CloudHash
+	reply.GlobuleProofs = cloudHashReply(*record).GlobuleProofs
+	return nil
+}
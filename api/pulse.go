@@ -0,0 +1,109 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// pulseCheckInterval is interval of checking current pulse while waiting for the next one
+const pulseCheckInterval = 50 * time.Millisecond
+
+// WaitForPulseResponse is a response of wait for pulse endpoint
+type WaitForPulseResponse struct {
+	PulseNumber uint32 `json:"pulseNumber"`
+	Entropy     []byte `json:"entropy"`
+	// TimedOut is true if no pulse newer than requested was applied before timeout, current pulse is returned then
+	TimedOut bool `json:"timedOut"`
+}
+
+// waitForPulseHandler blocks until pulse newer than "after" query parameter is applied locally or timeout expires.
+// Timeout is "timeout" query parameter in seconds, limited by MaxTimeout, or default timeout from config.
+func (ar *Runner) waitForPulseHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		insLog := inslogger.FromContext(ctx)
+
+		if req.Method != http.MethodGet {
+			response.Header().Add("Allow", http.MethodGet)
+			response.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		after, err := strconv.ParseUint(req.URL.Query().Get("after"), 10, 32)
+		if err != nil {
+			http.Error(response, "after must be a pulse number", http.StatusBadRequest)
+			return
+		}
+		var requested uint64
+		if t := req.URL.Query().Get("timeout"); t != "" {
+			requested, err = strconv.ParseUint(t, 10, 32)
+			if err != nil {
+				http.Error(response, "timeout must be a number of seconds", http.StatusBadRequest)
+				return
+			}
+		}
+
+		deadline := time.NewTimer(ar.requestTimeout(uint32(requested)))
+		defer deadline.Stop()
+		ticker := time.NewTicker(pulseCheckInterval)
+		defer ticker.Stop()
+
+		var resp WaitForPulseResponse
+		for {
+			pulse, err := ar.PulseStorage.Current(ctx)
+			if err != nil {
+				insLog.Error("[ waitForPulseHandler ] Can't get current pulse: ", err)
+				response.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			resp.PulseNumber = uint32(pulse.PulseNumber)
+			resp.Entropy = pulse.Entropy[:]
+			if pulse.PulseNumber > core.PulseNumber(after) {
+				break
+			}
+
+			select {
+			case <-ticker.C:
+				continue
+			case <-deadline.C:
+				resp.TimedOut = true
+			case <-ctx.Done():
+				return
+			}
+			break
+		}
+
+		data, err := json.Marshal(resp)
+		if err != nil {
+			insLog.Error("[ waitForPulseHandler ] Can't marshal response: ", err)
+			response.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		response.Header().Add("Content-Type", "application/json")
+		_, err = response.Write(data)
+		if err != nil {
+			insLog.Error("[ waitForPulseHandler ] Can't write response: ", err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// receiptStore keeps recent receipts to be re-fetched by request reference.
+type receiptStore struct {
+	lock     sync.RWMutex
+	limit    int
+	order    []core.RecordRef
+	receipts map[core.RecordRef]*requester.Receipt
+}
+
+func newReceiptStore(limit int) *receiptStore {
+	return &receiptStore{
+		limit:    limit,
+		receipts: make(map[core.RecordRef]*requester.Receipt),
+	}
+}
+
+func (s *receiptStore) add(request core.RecordRef, receipt *requester.Receipt) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.receipts[request]; !ok {
+		if len(s.order) == s.limit {
+			delete(s.receipts, s.order[0])
+			s.order = s.order[1:]
+		}
+		s.order = append(s.order, request)
+	}
+	s.receipts[request] = receipt
+}
+
+func (s *receiptStore) get(request core.RecordRef) *requester.Receipt {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.receipts[request]
+}
+
+// issueReceipt signs receipt of executed request with node key and stores it for re-fetching.
+func (ar *Runner) issueReceipt(request core.RecordRef, result interface{}) (*requester.Receipt, error) {
+	hash, err := requester.HashResult(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ issueReceipt ] Can't hash result")
+	}
+	receipt := &requester.Receipt{
+		Request:    request.String(),
+		Pulse:      request.Record().Pulse(),
+		ResultHash: hash,
+		Node:       ar.CertificateManager.GetCertificate().GetNodeRef().String(),
+	}
+	data, err := receipt.SignedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ issueReceipt ]")
+	}
+	signature, err := ar.CryptographyService.Sign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ issueReceipt ] Can't sign receipt")
+	}
+	receipt.Signature = signature.Bytes()
+
+	ar.receipts.add(request, receipt)
+	return receipt, nil
+}
+
+// ReceiptArgs is arguments that Receipt service accepts.
+type ReceiptArgs struct {
+	Request string
+}
+
+// ReceiptReply is reply for Receipt service requests.
+type ReceiptReply struct {
+	Receipt *requester.Receipt `json:"receipt"`
+}
+
+// ReceiptService is a service that provides receipts of executed requests.
+type ReceiptService struct {
+	runner *Runner
+}
+
+// NewReceiptService creates new Receipt service instance.
+func NewReceiptService(runner *Runner) *ReceiptService {
+	return &ReceiptService{runner: runner}
+}
+
+// Get returns receipt of request with given reference. Receipts are kept for recent requests only.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "receipt.Get",
+//	  "params": {
+//	    "Request": str // reference of request from receipt returned by call
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *ReceiptService) Get(r *http.Request, args *ReceiptArgs, reply *ReceiptReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ReceiptService.Get ] Incoming request: %s", r.RequestURI)
+
+	request, err := core.NewRefFromBase58(args.Request)
+	if err != nil {
+		return errors.Wrap(err, "[ ReceiptService.Get ] failed to parse args.Request")
+	}
+	receipt := s.runner.receipts.get(*request)
+	if receipt == nil {
+		return errors.Errorf("[ ReceiptService.Get ] no receipt for request %s", args.Request)
+	}
+
+	reply.Receipt = receipt
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package requester
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// Receipt is node signed confirmation that request was registered and executed with result of given hash.
+type Receipt struct {
+	Request    string           `json:"request"`
+	Pulse      core.PulseNumber `json:"pulse"`
+	ResultHash []byte           `json:"resultHash"`
+	Node       string           `json:"node"`
+	Signature  []byte           `json:"signature"`
+}
+
+type rpcReceiptResponse struct {
+	rpcResponse
+	Result struct {
+		Receipt *Receipt `json:"receipt"`
+	} `json:"result"`
+}
+
+// SignedData returns data that is covered by receipt signature.
+func (r *Receipt) SignedData() ([]byte, error) {
+	request, err := core.NewRefFromBase58(r.Request)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SignedData ] failed to parse request reference")
+	}
+	node, err := core.NewRefFromBase58(r.Node)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SignedData ] failed to parse node reference")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(request[:])
+	buf.Write(r.Pulse.Bytes())
+	buf.Write(r.ResultHash)
+	buf.Write(node[:])
+	return buf.Bytes(), nil
+}
+
+// HashResult returns hash of call result as client sees it in JSON answer of api.
+func HashResult(result interface{}) ([]byte, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ HashResult ] can't marshal result")
+	}
+	// result is decoded and encoded again, so the hash does not depend on Go types on the node side
+	var decoded interface{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ HashResult ] can't unmarshal result")
+	}
+	data, err = json.Marshal(decoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ HashResult ] can't marshal result")
+	}
+	return platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher().Hash(data), nil
+}
+
+// VerifyReceipt checks that receipt is signed with key of node and confirms given result.
+func VerifyReceipt(receipt *Receipt, result interface{}, nodeKey crypto.PublicKey) error {
+	if receipt == nil {
+		return errors.New("[ VerifyReceipt ] receipt is nil")
+	}
+	hash, err := HashResult(result)
+	if err != nil {
+		return errors.Wrap(err, "[ VerifyReceipt ]")
+	}
+	if !bytes.Equal(hash, receipt.ResultHash) {
+		return errors.New("[ VerifyReceipt ] result does not match receipt")
+	}
+	data, err := receipt.SignedData()
+	if err != nil {
+		return errors.Wrap(err, "[ VerifyReceipt ]")
+	}
+	verifier := platformpolicy.NewPlatformCryptographyScheme().Verifier(nodeKey)
+	if !verifier.Verify(core.SignatureFromBytes(receipt.Signature), data) {
+		return errors.New("[ VerifyReceipt ] incorrect signature")
+	}
+	return nil
+}
+
+// GetReceipt makes rpc request to receipt.Get method and extracts receipt of request
+func GetReceipt(url string, request string) (*Receipt, error) {
+	params := getDefaultRPCParams("receipt.Get")
+	params["params"] = map[string]string{"Request": request}
+
+	body, err := GetResponseBody(url+"/rpc", params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetReceipt ]")
+	}
+
+	receiptResp := rpcReceiptResponse{}
+
+	err = json.Unmarshal(body, &receiptResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetReceipt ] Can't unmarshal")
+	}
+	if receiptResp.Error != nil {
+		return nil, errors.New("[ GetReceipt ] Field 'error' is not nil: " + fmt.Sprint(receiptResp.Error))
+	}
+	if receiptResp.Result.Receipt == nil {
+		return nil, errors.New("[ GetReceipt ] Field 'result' is nil")
+	}
+
+	return receiptResp.Result.Receipt, nil
+}
@@ -0,0 +1,75 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package requester
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashResult(t *testing.T) {
+	type balance struct {
+		Member string
+		Amount uint64
+	}
+
+	// node hashes Go value, client hashes value decoded from JSON answer
+	hash, err := HashResult(balance{Member: "a", Amount: 100})
+	require.NoError(t, err)
+	decoded, err := HashResult(map[string]interface{}{"Amount": float64(100), "Member": "a"})
+	require.NoError(t, err)
+	require.Equal(t, hash, decoded)
+
+	other, err := HashResult(balance{Member: "a", Amount: 101})
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other)
+}
+
+func TestVerifyReceipt(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	hash, err := HashResult("OK")
+	require.NoError(t, err)
+	request := testutils.RandomRef()
+	receipt := &Receipt{
+		Request:    request.String(),
+		Pulse:      request.Record().Pulse(),
+		ResultHash: hash,
+		Node:       testutils.RandomRef().String(),
+	}
+	data, err := receipt.SignedData()
+	require.NoError(t, err)
+	signature, err := cryptography.NewKeyBoundCryptographyService(key).Sign(data)
+	require.NoError(t, err)
+	receipt.Signature = signature.Bytes()
+
+	require.NoError(t, VerifyReceipt(receipt, "OK", kp.ExtractPublicKey(key)))
+	require.Error(t, VerifyReceipt(receipt, "NOT OK", kp.ExtractPublicKey(key)))
+	require.Error(t, VerifyReceipt(receipt, "OK", kp.ExtractPublicKey(otherKey)))
+	require.Error(t, VerifyReceipt(nil, "OK", kp.ExtractPublicKey(key)))
+
+	receipt.Pulse++
+	require.Error(t, VerifyReceipt(receipt, "OK", kp.ExtractPublicKey(key)))
+}
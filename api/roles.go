@@ -0,0 +1,152 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// RolesInspectArgs is arguments that Roles.Inspect accepts.
+type RolesInspectArgs struct {
+	// Reference is object reference, Jet is jet ID, exactly one should be set
+	Reference string
+	Jet       string
+	// Pulse is number of pulse, current pulse if zero
+	Pulse core.PulseNumber
+}
+
+// RoleSelectionReply is selection of nodes for static role with inputs it was calculated from.
+type RoleSelectionReply struct {
+	Role       string
+	Pulse      core.PulseNumber
+	Object     string
+	Jet        string
+	Strategy   string
+	Entropy    []byte
+	Seed       []byte
+	Candidates []string
+	Executors  []string
+	Validators []string
+}
+
+// RolesInspectReply is reply for Roles.Inspect requests.
+type RolesInspectReply struct {
+	Selections []RoleSelectionReply
+}
+
+// RolesService is a service that explains selection of executors and validators.
+type RolesService struct {
+	runner *Runner
+}
+
+// NewRolesService creates new Roles service instance.
+func NewRolesService(runner *Runner) *RolesService {
+	return &RolesService{runner: runner}
+}
+
+// Inspect returns executors and validators selected for object or jet on pulse together with entropy, seed
+// and sorted candidate nodes they were selected from. For object virtual and light material selections are
+// returned, for jet only light material one.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "roles.Inspect",
+//	  "params": {
+//	    "Reference": str, // object reference
+//	    "Jet": str, // jet ID, if Reference is omitted
+//	    "Pulse": int // pulse number, current pulse if omitted
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *RolesService) Inspect(r *http.Request, args *RolesInspectArgs, reply *RolesInspectReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ RolesService.Inspect ] Incoming request: %s", r.RequestURI)
+
+	var id core.RecordID
+	switch {
+	case args.Reference != "" && args.Jet != "":
+		return errors.New("[ RolesService.Inspect ] only one of reference and jet should be set")
+	case args.Reference != "":
+		ref, err := core.NewRefFromBase58(args.Reference)
+		if err != nil {
+			return errors.Wrap(err, "[ RolesService.Inspect ] failed to parse reference")
+		}
+		id = *ref.Record()
+	case args.Jet != "":
+		jetID, err := core.NewIDFromBase58(args.Jet)
+		if err != nil {
+			return errors.Wrap(err, "[ RolesService.Inspect ] failed to parse jet")
+		}
+		if jetID.Pulse() != core.PulseNumberJet {
+			return errors.Errorf("[ RolesService.Inspect ] %s is not a jet", args.Jet)
+		}
+		id = *jetID
+	default:
+		return errors.New("[ RolesService.Inspect ] reference or jet should be set")
+	}
+
+	pulse := args.Pulse
+	if pulse == 0 {
+		current, err := s.runner.PulseStorage.Current(ctx)
+		if err != nil {
+			return errors.Wrap(err, "[ RolesService.Inspect ] failed to get current pulse")
+		}
+		pulse = current.PulseNumber
+	}
+
+	selections, err := s.runner.RoleInspector.InspectRoles(ctx, id, pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ RolesService.Inspect ] failed to inspect roles")
+	}
+
+	reply.Selections = make([]RoleSelectionReply, len(selections))
+	for i, selection := range selections {
+		reply.Selections[i] = RoleSelectionReply{
+			Role:       selection.Role.String(),
+			Pulse:      selection.Pulse,
+			Strategy:   selection.Strategy,
+			Entropy:    selection.Entropy[:],
+			Seed:       selection.Seed,
+			Candidates: refStrings(selection.Candidates),
+			Executors:  refStrings(selection.Executors),
+			Validators: refStrings(selection.Validators),
+		}
+		if !selection.Object.Equal(&core.RecordID{}) {
+			reply.Selections[i].Object = selection.Object.String()
+		}
+		if !selection.Jet.Equal(&core.RecordID{}) {
+			reply.Selections[i].Jet = selection.Jet.String()
+		}
+	}
+	return nil
+}
+
+func refStrings(refs []core.RecordRef) []string {
+	res := make([]string, len(refs))
+	for i, ref := range refs {
+		res[i] = ref.String()
+	}
+	return res
+}
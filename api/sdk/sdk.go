@@ -209,3 +209,22 @@ func (sdk *SDK) GetBalance(m *Member) (uint64, error) {
 	// TODO FIXME don't transfer money in floats!
 	return uint64(response.Result.(float64)), nil
 }
+
+// GetReceipt re-fetches signed receipt of request from api.
+func (sdk *SDK) GetReceipt(request string) (*requester.Receipt, error) {
+	receipt, err := requester.GetReceipt(sdk.apiURLs.next(), request)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetReceipt ] can't get receipt")
+	}
+	return receipt, nil
+}
+
+// VerifyReceipt checks that receipt is signed by node with given public key in PEM format and confirms result
+// returned by api.
+func VerifyReceipt(receipt *requester.Receipt, result interface{}, nodePublicKey string) error {
+	key, err := platformpolicy.NewKeyProcessor().ImportPublicKeyPEM([]byte(nodePublicKey))
+	if err != nil {
+		return errors.Wrap(err, "[ VerifyReceipt ] can't import node public key")
+	}
+	return requester.VerifyReceipt(receipt, result, key)
+}
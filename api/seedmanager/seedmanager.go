@@ -19,6 +19,9 @@ package seedmanager
 import (
 	"sync"
 	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
 )
 
 // Expiration represents time of expiration
@@ -30,12 +33,25 @@ const DefaultTTL = time.Duration(1 * time.Second)
 // DefaultCleanPeriod default time period for launching cleaning goroutine
 const DefaultCleanPeriod = time.Duration(1 * time.Second)
 
+// ConsumedPulsesDepth is count of last pulses for which consumed seeds are remembered
+const ConsumedPulsesDepth = 2
+
+var (
+	// ErrSeedNotFound is returned when seed was never issued or is expired
+	ErrSeedNotFound = errors.New("seed is unknown or expired")
+	// ErrSeedConsumed is returned when seed was already used by another request
+	ErrSeedConsumed = errors.New("seed has already been used")
+)
+
 // SeedManager manages working with seed pool
 // It's thread safe
 type SeedManager struct {
 	mutex    sync.RWMutex
 	seedPool map[Seed]Expiration
 	ttl      time.Duration
+
+	// consumed holds seeds used by requests grouped by pulse they were used in
+	consumed map[core.PulseNumber]map[Seed]struct{}
 }
 
 // New creates new seed manager with default params
@@ -45,7 +61,11 @@ func New() *SeedManager {
 
 // NewSpecified creates new seed manager with custom params
 func NewSpecified(TTL time.Duration, cleanPeriod time.Duration) *SeedManager {
-	sm := SeedManager{seedPool: make(map[Seed]Expiration), ttl: TTL}
+	sm := SeedManager{
+		seedPool: make(map[Seed]Expiration),
+		ttl:      TTL,
+		consumed: make(map[core.PulseNumber]map[Seed]struct{}),
+	}
 	go func() {
 		for range time.Tick(cleanPeriod) {
 			sm.deleteExpired()
@@ -69,20 +89,63 @@ func (sm *SeedManager) isExpired(expTime Expiration) bool {
 	return expTime < time.Now().UnixNano()
 }
 
-// Exists checks whether seed in the pool
+// Exists checks whether seed in the pool and removes it from the pool
 func (sm *SeedManager) Exists(seed Seed) bool {
-	sm.mutex.RLock()
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	return sm.take(seed)
+}
+
+// take removes seed from the pool, returns false if seed is absent or expired. Must be called under write lock.
+func (sm *SeedManager) take(seed Seed) bool {
 	expTime, ok := sm.seedPool[seed]
-	sm.mutex.RUnlock()
+	if !ok {
+		return false
+	}
+	delete(sm.seedPool, seed)
+	return !sm.isExpired(expTime)
+}
+
+// Consume checks that seed was issued and was not used before, and marks it as used in provided pulse.
+// Check and mark are atomic, so concurrent requests with the same seed can't both pass.
+func (sm *SeedManager) Consume(seed Seed, pulse core.PulseNumber) error {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
 
-	isSeedOk := ok && !sm.isExpired(expTime)
-	if isSeedOk {
-		sm.mutex.Lock()
-		delete(sm.seedPool, seed)
-		sm.mutex.Unlock()
+	for _, seeds := range sm.consumed {
+		if _, ok := seeds[seed]; ok {
+			return ErrSeedConsumed
+		}
+	}
+	if !sm.take(seed) {
+		return ErrSeedNotFound
 	}
 
-	return isSeedOk
+	seeds, ok := sm.consumed[pulse]
+	if !ok {
+		seeds = make(map[Seed]struct{})
+		sm.consumed[pulse] = seeds
+		sm.forgetOldPulses()
+	}
+	seeds[seed] = struct{}{}
+	return nil
+}
+
+// forgetOldPulses drops consumed seeds of pulses older than ConsumedPulsesDepth last ones.
+// Seeds of that pulses are expired anyway. Must be called under write lock.
+func (sm *SeedManager) forgetOldPulses() {
+	for len(sm.consumed) > ConsumedPulsesDepth {
+		var oldest core.PulseNumber
+		first := true
+		for pn := range sm.consumed {
+			if first || pn < oldest {
+				oldest = pn
+				first = false
+			}
+		}
+		delete(sm.consumed, oldest)
+	}
 }
 
 func (sm *SeedManager) deleteExpired() {
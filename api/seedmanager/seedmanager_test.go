@@ -18,9 +18,11 @@ package seedmanager
 
 import (
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/insolar/insolar/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -87,6 +89,59 @@ func TestSeedManager_ExpiredSeedAfterCleaning(t *testing.T) {
 	require.False(t, sm.Exists(seed))
 }
 
+func TestSeedManager_Consume(t *testing.T) {
+	sm := New()
+	seed := getSeed(t)
+
+	require.Equal(t, ErrSeedNotFound, sm.Consume(seed, core.FirstPulseNumber))
+
+	sm.Add(seed)
+	require.NoError(t, sm.Consume(seed, core.FirstPulseNumber))
+
+	sm.Add(seed)
+	require.Equal(t, ErrSeedConsumed, sm.Consume(seed, core.FirstPulseNumber))
+	require.Equal(t, ErrSeedConsumed, sm.Consume(seed, core.FirstPulseNumber+1))
+}
+
+func TestSeedManager_ConsumeForgetsOldPulses(t *testing.T) {
+	sm := New()
+	seed := getSeed(t)
+	sm.Add(seed)
+	require.NoError(t, sm.Consume(seed, core.FirstPulseNumber))
+
+	for i := 1; i <= ConsumedPulsesDepth; i++ {
+		other := getSeed(t)
+		sm.Add(other)
+		require.NoError(t, sm.Consume(other, core.FirstPulseNumber+core.PulseNumber(i)))
+	}
+	require.Len(t, sm.consumed, ConsumedPulsesDepth)
+
+	sm.Add(seed)
+	require.NoError(t, sm.Consume(seed, core.FirstPulseNumber+ConsumedPulsesDepth+1))
+}
+
+func TestSeedManager_ConsumeConcurrent(t *testing.T) {
+	const numConcurrent = 20
+
+	sm := New()
+	seed := getSeed(t)
+	sm.Add(seed)
+
+	var passed int32
+	wg := sync.WaitGroup{}
+	wg.Add(numConcurrent)
+	for i := 0; i < numConcurrent; i++ {
+		go func() {
+			defer wg.Done()
+			if sm.Consume(seed, core.FirstPulseNumber) == nil {
+				atomic.AddInt32(&passed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), passed)
+}
+
 func TestRace(t *testing.T) {
 	const numConcurrent = 15
 
@@ -0,0 +1,89 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// SnapshotCreateArgs is arguments that Snapshot.Create accepts.
+type SnapshotCreateArgs struct {
+	AdminAuth
+	// Name is file name of snapshot in snapshot directory of ledger
+	Name string
+}
+
+// SnapshotCreateReply is reply for Snapshot.Create requests.
+type SnapshotCreateReply struct {
+	Path  string
+	Pulse core.PulseNumber
+	Keys  int
+	Size  int64
+}
+
+// SnapshotService is a service that makes snapshots of ledger storage.
+type SnapshotService struct {
+	runner *Runner
+}
+
+// NewSnapshotService creates new Snapshot service instance.
+func NewSnapshotService(runner *Runner) *SnapshotService {
+	return &SnapshotService{runner: runner}
+}
+
+// Create saves snapshot of ledger storage taken at pulse boundary into snapshot directory of node. Snapshot can
+// seed storage of fresh node instead of genesis. Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "snapshot.Create",
+//	  "params": {
+//	    "Name": str, // file name of snapshot
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *SnapshotService) Create(r *http.Request, args *SnapshotCreateArgs, reply *SnapshotCreateReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ SnapshotService.Create ] Incoming request: %s", r.RequestURI)
+
+	if args.Name == "" {
+		return errors.New("[ SnapshotService.Create ] Name must not be empty")
+	}
+	if err := s.runner.authorizeAdmin(ctx, "snapshot.Create", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ SnapshotService.Create ]")
+	}
+	info, err := s.runner.SnapshotMaker.MakeSnapshot(ctx, args.Name)
+	if err != nil {
+		return errors.Wrap(err, "[ SnapshotService.Create ]")
+	}
+	reply.Path = info.Path
+	reply.Pulse = info.Pulse
+	reply.Keys = info.Keys
+	reply.Size = info.Size
+	return nil
+}
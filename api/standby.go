@@ -0,0 +1,91 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// StandbyReply is reply for Standby service requests.
+type StandbyReply struct {
+	Promoted bool
+}
+
+// StandbyService is a service that manages standby discovery node.
+type StandbyService struct {
+	runner *Runner
+}
+
+// NewStandbyService creates new Standby service instance.
+func NewStandbyService(runner *Runner) *StandbyService {
+	return &StandbyService{runner: runner}
+}
+
+// Promote makes standby node serve joining nodes on behalf of its failed primary discovery node.
+// Standby restores replicated handshake sessions and join claims and signs discovery challenges with primary keys.
+// Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "standby.Promote",
+//	  "params": {
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *StandbyService) Promote(r *http.Request, args *AdminAuth, reply *StandbyReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ StandbyService.Promote ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "standby.Promote", *args, args); err != nil {
+		return errors.Wrap(err, "[ StandbyService.Promote ]")
+	}
+
+	err := s.runner.DiscoveryStandby.PromoteStandby(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ StandbyService.Promote ] failed to promote standby")
+	}
+	reply.Promoted = true
+	return nil
+}
+
+// Status returns true if standby node was promoted.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "standby.Status",
+//	  "id": str|int|null
+//	}
+func (s *StandbyService) Status(r *http.Request, args *interface{}, reply *StandbyReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ StandbyService.Status ] Incoming request: %s", r.RequestURI)
+
+	reply.Promoted = s.runner.DiscoveryStandby.IsStandbyPromoted()
+	return nil
+}
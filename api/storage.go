@@ -0,0 +1,72 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// StorageStatsReply is reply for Storage.Stats requests.
+type StorageStatsReply struct {
+	Scopes []core.StorageScopeStats
+	// PulseBytesWritten is size of data written since the latest pulse
+	PulseBytesWritten int64
+	// PrevPulseBytesWritten is size of data written during previous pulse
+	PrevPulseBytesWritten int64
+}
+
+// StorageService is a service that provides statistics of ledger storage.
+type StorageService struct {
+	runner *Runner
+}
+
+// NewStorageService creates new Storage service instance.
+func NewStorageService(runner *Runner) *StorageService {
+	return &StorageService{runner: runner}
+}
+
+// Stats returns count and size of keys in scopes of ledger storage (records, indexes, blobs, drops etc.) and
+// size of data written during the latest pulses. Keys are counted on request, so it takes time proportional to
+// storage size.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "storage.Stats",
+//	  "params": {},
+//	  "id": str|int|null
+//	}
+func (s *StorageService) Stats(r *http.Request, args *interface{}, reply *StorageStatsReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ StorageService.Stats ] Incoming request: %s", r.RequestURI)
+
+	stats, err := s.runner.StorageInspector.StorageStats(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ StorageService.Stats ] failed to get storage stats")
+	}
+	reply.Scopes = stats.Scopes
+	reply.PulseBytesWritten = stats.PulseBytesWritten
+	reply.PrevPulseBytesWritten = stats.PrevPulseBytesWritten
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// UpgradeStartArgs is arguments that Upgrade.Start accepts.
+type UpgradeStartArgs struct {
+	AdminAuth
+	// Nodes are references of nodes to upgrade in order of upgrade
+	Nodes []string
+	// Version is version of software nodes should rejoin with, optional
+	Version string
+	// Parallel is max count of nodes upgraded at the same time, 1 by default
+	Parallel int
+}
+
+// UpgradeNode is progress of node upgrade.
+type UpgradeNode struct {
+	Reference string
+	Role      string
+	State     string
+	Pulse     uint32
+}
+
+// UpgradeReply is reply for Upgrade service requests.
+type UpgradeReply struct {
+	Version  string
+	Parallel int
+	Nodes    []UpgradeNode
+	Done     bool
+}
+
+// UpgradeService is a service that orchestrates rolling upgrade of nodes.
+type UpgradeService struct {
+	runner *Runner
+}
+
+// NewUpgradeService creates new Upgrade service instance.
+func NewUpgradeService(runner *Runner) *UpgradeService {
+	return &UpgradeService{runner: runner}
+}
+
+// Start starts rolling upgrade of nodes. Nodes are switched to draining state while the rest of network satisfies
+// majority rule and min roles. Operator should poll upgrade.Status, restart draining nodes with new version and
+// wait until they are done. Action must be signed by operator.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "upgrade.Start",
+//	  "params": {
+//	    "Nodes": [str, ...], // references of nodes
+//	    "Version": str, // version nodes should rejoin with, optional
+//	    "Parallel": int, // max count of nodes upgraded at the same time, optional
+//	    "Operator": str, // PEM encoded operator public key
+//	    "Seed": str, // seed got with seed.Get
+//	    "Signature": str // signature of AdminActionBytes
+//	  },
+//	  "id": str|int|null
+//	}
+func (s *UpgradeService) Start(r *http.Request, args *UpgradeStartArgs, reply *UpgradeReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ UpgradeService.Start ] Incoming request: %s", r.RequestURI)
+
+	nodes := make([]core.RecordRef, len(args.Nodes))
+	for i, ref := range args.Nodes {
+		nodeRef, err := core.NewRefFromBase58(ref)
+		if err != nil {
+			return errors.Wrapf(err, "[ UpgradeService.Start ] failed to parse node reference %s", ref)
+		}
+		nodes[i] = *nodeRef
+	}
+	if err := s.runner.authorizeAdmin(ctx, "upgrade.Start", args.AdminAuth, args); err != nil {
+		return errors.Wrap(err, "[ UpgradeService.Start ]")
+	}
+
+	status, err := s.runner.NetworkCoordinator.StartUpgrade(ctx, nodes, args.Version, args.Parallel)
+	if err != nil {
+		return errors.Wrap(err, "[ UpgradeService.Start ]")
+	}
+	fillUpgradeReply(status, reply)
+	return nil
+}
+
+// Status returns progress of rolling upgrade. Nodes with "draining" state can be stopped for upgrade.
+//
+//	Request structure:
+//	{
+//	  "jsonrpc": "2.0",
+//	  "method": "upgrade.Status",
+//	  "id": str|int|null
+//	}
+func (s *UpgradeService) Status(r *http.Request, args *interface{}, reply *UpgradeReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ UpgradeService.Status ] Incoming request: %s", r.RequestURI)
+
+	status, err := s.runner.NetworkCoordinator.GetUpgradeStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ UpgradeService.Status ]")
+	}
+	fillUpgradeReply(status, reply)
+	return nil
+}
+
+// Cancel stops rolling upgrade. Action must be signed by operator, params are AdminAuth fields.
+func (s *UpgradeService) Cancel(r *http.Request, args *AdminAuth, reply *UpgradeReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ UpgradeService.Cancel ] Incoming request: %s", r.RequestURI)
+
+	if err := s.runner.authorizeAdmin(ctx, "upgrade.Cancel", *args, args); err != nil {
+		return errors.Wrap(err, "[ UpgradeService.Cancel ]")
+	}
+
+	return errors.Wrap(s.runner.NetworkCoordinator.CancelUpgrade(ctx), "[ UpgradeService.Cancel ]")
+}
+
+func fillUpgradeReply(status *core.UpgradeStatus, reply *UpgradeReply) {
+	reply.Version = status.Version
+	reply.Parallel = status.Parallel
+	reply.Done = status.Done
+	reply.Nodes = make([]UpgradeNode, len(status.Nodes))
+	for i, n := range status.Nodes {
+		reply.Nodes[i] = UpgradeNode{
+			Reference: n.Node.String(),
+			Role:      n.Role.String(),
+			State:     string(n.State),
+			Pulse:     uint32(n.Pulse),
+		}
+	}
+}
@@ -0,0 +1,204 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Minimal server side of RFC 6455, enough to push text frames to subscribers.
+
+const (
+	wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xA
+
+	wsMaxControlPayload = 125
+)
+
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	lock   sync.Mutex
+}
+
+func wsAcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func headerContains(header http.Header, name string, value string) bool {
+	for _, v := range header[http.CanonicalHeaderKey(name)] {
+		for _, token := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wsUpgrade performs websocket handshake and takes over the connection
+func wsUpgrade(response http.ResponseWriter, req *http.Request) (*wsConn, error) {
+	if req.Method != http.MethodGet {
+		return nil, errors.New("[ wsUpgrade ] Method must be GET")
+	}
+	if !headerContains(req.Header, "Connection", "upgrade") || !headerContains(req.Header, "Upgrade", "websocket") {
+		return nil, errors.New("[ wsUpgrade ] Not a websocket handshake")
+	}
+	if req.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, errors.New("[ wsUpgrade ] Unsupported websocket version")
+	}
+	key := req.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, errors.New("[ wsUpgrade ] Sec-WebSocket-Key is empty")
+	}
+
+	hijacker, ok := response.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("[ wsUpgrade ] Response doesn't support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ wsUpgrade ] Can't hijack connection")
+	}
+
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAcceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "[ wsUpgrade ] Can't write handshake")
+	}
+
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode, 0}
+	length := len(payload)
+	switch {
+	case length <= wsMaxControlPayload:
+		header[1] = byte(length)
+	case length <= 0xFFFF:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return errors.Wrap(err, "[ writeFrame ] Can't write frame")
+	}
+	return nil
+}
+
+// WriteText sends text frame to the client
+func (c *wsConn) WriteText(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+// readFrame reads single frame sent by the client. Client frames are always masked.
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, head); err != nil {
+		return 0, nil, err
+	}
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, ext); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, ext); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext)
+	}
+	if opcode >= wsOpClose && length > wsMaxControlPayload {
+		return 0, nil, errors.New("[ readFrame ] Control frame is too big")
+	}
+	if !masked {
+		return 0, nil, errors.New("[ readFrame ] Client frame is not masked")
+	}
+
+	mask := make([]byte, 4)
+	if _, err := io.ReadFull(c.reader, mask); err != nil {
+		return 0, nil, err
+	}
+	// subscribers are not expected to send anything but control frames
+	if length > wsMaxControlPayload {
+		return 0, nil, errors.New("[ readFrame ] Frame is too big")
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+// serveControl answers pings and returns when the client closes connection
+func (c *wsConn) serveControl() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case wsOpPing:
+			if c.writeFrame(wsOpPong, payload) != nil {
+				return
+			}
+		case wsOpClose:
+			c.writeFrame(wsOpClose, nil)
+			return
+		}
+	}
+}
+
+// Close closes underlying connection
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,77 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package bucket
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// Bucket holds part of entries of foundation.Map, it is a child of the object owning the map
+type Bucket struct {
+	foundation.BaseContract
+
+	Items map[string]string
+}
+
+// New creates new empty Bucket
+func New() (*Bucket, error) {
+	return &Bucket{
+		Items: make(map[string]string),
+	}, nil
+}
+
+func (b *Bucket) checkOwner() error {
+	ctx := b.GetContext()
+	if ctx.Parent == nil || ctx.Caller == nil || *ctx.Caller != *ctx.Parent {
+		return fmt.Errorf("only owner of bucket can modify it")
+	}
+	return nil
+}
+
+// Get returns value stored by key and whether it exists
+func (b *Bucket) Get(key string) (string, bool, error) {
+	value, ok := b.Items[key]
+	return value, ok, nil
+}
+
+// Set stores value by key
+func (b *Bucket) Set(key string, value string) error {
+	if err := b.checkOwner(); err != nil {
+		return fmt.Errorf("[ Set ] %s", err.Error())
+	}
+	if b.Items == nil {
+		b.Items = make(map[string]string)
+	}
+	b.Items[key] = value
+	return nil
+}
+
+// Delete removes key from bucket
+func (b *Bucket) Delete(key string) error {
+	if err := b.checkOwner(); err != nil {
+		return fmt.Errorf("[ Delete ] %s", err.Error())
+	}
+	delete(b.Items, key)
+	return nil
+}
+
+// Entries returns all entries of bucket
+func (b *Bucket) Entries() (map[string]string, error) {
+	return b.Items, nil
+}
@@ -17,9 +17,11 @@
 package member
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/insolar/insolar/application/contract/member/signer"
+	"github.com/insolar/insolar/application/proxy/message"
 	"github.com/insolar/insolar/application/proxy/nodedomain"
 	"github.com/insolar/insolar/application/proxy/rootdomain"
 	"github.com/insolar/insolar/application/proxy/wallet"
@@ -98,10 +100,33 @@ func (m *Member) Call(rootDomain core.RecordRef, method string, params []byte, s
 		return m.registerNodeCall(rootDomain, params)
 	case "GetNodeRef":
 		return m.getNodeRefCall(rootDomain, params)
+	case "SendMessage":
+		return m.sendMessageCall(params)
+	case "GetInbox":
+		return m.getInboxCall(params)
+	case "SetAlias":
+		return m.setAliasCall(rootDomain, params)
+	case "RemoveAlias":
+		return m.removeAliasCall(rootDomain, params)
+	case "ResolveAlias":
+		return m.resolveAliasCall(rootDomain, params)
+	case "GetAliasHistory":
+		return m.getAliasHistoryCall(rootDomain, params)
 	}
 	return nil, &foundation.Error{S: "Unknown method"}
 }
 
+var INSATTR_DumpUsersPage_API = true
+
+// DumpUsersPage returns one page of users dump, request is authorized by signature of DumpAllUsers call,
+// so api can stream dump page by page on behalf of root member
+func (m *Member) DumpUsersPage(rootDomain core.RecordRef, params []byte, seed []byte, sign []byte, cursor string, limit uint) ([]byte, error) {
+	if err := m.verifySig("DumpAllUsers", params, seed, sign); err != nil {
+		return nil, fmt.Errorf("[ DumpUsersPage ]: %s", err.Error())
+	}
+	return rootdomain.GetObject(rootDomain).DumpUsersPage(cursor, limit)
+}
+
 func (m *Member) createMemberCall(ref core.RecordRef, params []byte) (interface{}, error) {
 	rootDomain := rootdomain.GetObject(ref)
 	var name string
@@ -215,3 +240,102 @@ func (m *Member) getNodeRefCall(ref core.RecordRef, params []byte) (interface{},
 
 	return nodeRef, nil
 }
+
+func (m *Member) setAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	var reference string
+	if err := signer.UnmarshalParams(params, &alias, &reference); err != nil {
+		return nil, fmt.Errorf("[ setAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	return nil, rootdomain.GetObject(ref).SetAlias(alias, reference)
+}
+
+func (m *Member) removeAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	if err := signer.UnmarshalParams(params, &alias); err != nil {
+		return nil, fmt.Errorf("[ removeAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	return nil, rootdomain.GetObject(ref).RemoveAlias(alias)
+}
+
+func (m *Member) resolveAliasCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	if err := signer.UnmarshalParams(params, &alias); err != nil {
+		return nil, fmt.Errorf("[ resolveAliasCall ] Can't unmarshal params: %s", err.Error())
+	}
+	return rootdomain.GetObject(ref).ResolveAlias(alias)
+}
+
+func (m *Member) getAliasHistoryCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var alias string
+	if err := signer.UnmarshalParams(params, &alias); err != nil {
+		return nil, fmt.Errorf("[ getAliasHistoryCall ] Can't unmarshal params: %s", err.Error())
+	}
+	return rootdomain.GetObject(ref).GetAliasHistory(alias)
+}
+
+func (m *Member) sendMessageCall(params []byte) (interface{}, error) {
+	var toStr string
+	var payload []byte
+	var signature []byte
+	if err := signer.UnmarshalParams(params, &toStr, &payload, &signature); err != nil {
+		return nil, fmt.Errorf("[ sendMessageCall ] Can't unmarshal params: %s", err.Error())
+	}
+	to, err := core.NewRefFromBase58(toStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ sendMessageCall ] Failed to parse 'to' param: %s", err.Error())
+	}
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("[ sendMessageCall ] Message payload is empty")
+	}
+
+	publicKey, err := foundation.ImportPublicKey(m.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("[ sendMessageCall ] Invalid public key")
+	}
+	if !foundation.Verify(payload, signature, publicKey) {
+		return nil, fmt.Errorf("[ sendMessageCall ] Incorrect message signature")
+	}
+
+	msgHolder := message.New(m.GetReference(), payload, signature, m.GetContext().Time.Unix())
+	msg, err := msgHolder.AsChild(*to)
+	if err != nil {
+		return nil, fmt.Errorf("[ sendMessageCall ] Can't save as child: %s", err.Error())
+	}
+
+	return msg.GetReference().String(), nil
+}
+
+func (m *Member) getInboxCall(params []byte) (interface{}, error) {
+	var offset uint
+	var limit uint
+	if err := signer.UnmarshalParams(params, &offset, &limit); err != nil {
+		return nil, fmt.Errorf("[ getInboxCall ] Can't unmarshal params: %s", err.Error())
+	}
+
+	iterator, err := m.NewChildrenTypedIterator(message.GetPrototype())
+	if err != nil {
+		return nil, fmt.Errorf("[ getInboxCall ] Can't get children: %s", err.Error())
+	}
+
+	res := []json.RawMessage{}
+	var skipped uint
+	for iterator.HasNext() && (limit == 0 || uint(len(res)) < limit) {
+		cref, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("[ getInboxCall ] Can't get next child: %s", err.Error())
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+
+		msgJSON, err := message.GetObject(cref).Dump()
+		if err != nil {
+			return nil, fmt.Errorf("[ getInboxCall ] Can't dump message: %s", err.Error())
+		}
+		res = append(res, msgJSON)
+	}
+
+	return json.Marshal(res)
+}
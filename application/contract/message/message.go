@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// Message is a member-to-member message. Payload is encrypted by the sender
+// to the recipient's public key, so it is opaque to the network and is stored as is.
+type Message struct {
+	foundation.BaseContract
+	Sender    core.RecordRef
+	Payload   []byte
+	Signature []byte
+	Created   int64
+}
+
+// New creates new message
+func New(sender core.RecordRef, payload []byte, signature []byte, created int64) (*Message, error) {
+	return &Message{
+		Sender:    sender,
+		Payload:   payload,
+		Signature: signature,
+		Created:   created,
+	}, nil
+}
+
+// GetSender returns reference of the member who sent the message
+func (msg *Message) GetSender() (core.RecordRef, error) {
+	return msg.Sender, nil
+}
+
+// GetPayload returns encrypted message body
+func (msg *Message) GetPayload() ([]byte, error) {
+	return msg.Payload, nil
+}
+
+// GetSignature returns sender's signature of the encrypted message body
+func (msg *Message) GetSignature() ([]byte, error) {
+	return msg.Signature, nil
+}
+
+// GetCreated returns unix time of message creation
+func (msg *Message) GetCreated() (int64, error) {
+	return msg.Created, nil
+}
+
+// Dump returns message as json
+func (msg *Message) Dump() ([]byte, error) {
+	res := map[string]interface{}{
+		"reference": msg.GetReference().String(),
+		"sender":    msg.Sender.String(),
+		"payload":   msg.Payload,
+		"signature": msg.Signature,
+		"created":   msg.Created,
+	}
+	resJSON, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("[ Dump ] Can't marshal res: %s", err.Error())
+	}
+	return resJSON, nil
+}
@@ -19,6 +19,7 @@ package rootdomain
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"github.com/insolar/insolar/application/proxy/member"
 	"github.com/insolar/insolar/application/proxy/wallet"
@@ -31,8 +32,29 @@ type RootDomain struct {
 	foundation.BaseContract
 	RootMember    core.RecordRef
 	NodeDomainRef core.RecordRef
+
+	Aliases      map[string]string
+	AliasChanges []AliasChange
+}
+
+// AliasChange is an entry of append-only log of alias registry changes
+type AliasChange struct {
+	Alias     string           `json:"alias"`
+	Reference string           `json:"reference"`
+	Previous  string           `json:"previous"`
+	Changer   string           `json:"changer"`
+	Pulse     core.PulseNumber `json:"pulse"`
 }
 
+// Builtin aliases can't be changed and always resolve to root domain objects
+const (
+	rootDomainAlias = "rootdomain"
+	nodeDomainAlias = "nodedomain"
+	rootMemberAlias = "rootmember"
+)
+
+var aliasPattern = regexp.MustCompile(`^[a-z][a-z0-9_\-]{0,63}$`)
+
 // CreateMember processes create member request
 func (rd *RootDomain) CreateMember(name string, key string) (string, error) {
 	if *rd.GetContext().Caller != rd.RootMember {
@@ -104,30 +126,74 @@ func (rd *RootDomain) DumpAllUsers() ([]byte, error) {
 	if *rd.GetContext().Caller != rd.RootMember {
 		return nil, fmt.Errorf("[ DumpAllUsers ] Only root can call this method")
 	}
-	res := []map[string]interface{}{}
-	iterator, err := rd.NewChildrenTypedIterator(member.GetPrototype())
+	members := []core.RecordRef{}
+	err := rd.NewChildrenList(member.GetPrototype()).ForEach(func(ref core.RecordRef) (bool, error) {
+		members = append(members, ref)
+		return true, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("[ DumpAllUsers ] Can't get children: %s", err.Error())
 	}
+	res, err := rd.dumpUsers(members)
+	if err != nil {
+		return nil, fmt.Errorf("[ DumpAllUsers ] %s", err.Error())
+	}
+	resJSON, _ := json.Marshal(res)
+	return resJSON, nil
+}
 
-	for iterator.HasNext() {
-		cref, err := iterator.Next()
+// DumpUsersPage returns json with users found among limit children starting from child record cursor
+// and cursor of the next page. Empty cursor requests the first page and marks the last one in response.
+// Api streams all users page by page, so memory usage doesn't depend on number of users.
+func (rd *RootDomain) DumpUsersPage(cursor string, limit uint) ([]byte, error) {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return nil, fmt.Errorf("[ DumpUsersPage ] Only root can call this method")
+	}
+	if limit == 0 {
+		return nil, fmt.Errorf("[ DumpUsersPage ] Limit must be positive")
+	}
+	var from *core.RecordID
+	if cursor != "" {
+		id, err := core.NewIDFromBase58(cursor)
 		if err != nil {
-			return nil, fmt.Errorf("[ DumpAllUsers ] Can't get next child: %s", err.Error())
+			return nil, fmt.Errorf("[ DumpUsersPage ] Failed to parse cursor: %s", err.Error())
 		}
+		from = id
+	}
+	members, next, err := rd.NewChildrenList(member.GetPrototype()).Page(from, int(limit))
+	if err != nil {
+		return nil, fmt.Errorf("[ DumpUsersPage ] Can't get children: %s", err.Error())
+	}
+	users, err := rd.dumpUsers(members)
+	if err != nil {
+		return nil, fmt.Errorf("[ DumpUsersPage ] %s", err.Error())
+	}
+	res := map[string]interface{}{
+		"users": users,
+		"next":  "",
+	}
+	if next != nil {
+		res["next"] = next.String()
+	}
+	resJSON, _ := json.Marshal(res)
+	return resJSON, nil
+}
 
+// dumpUsers collects info of members except root member
+func (rd *RootDomain) dumpUsers(members []core.RecordRef) ([]map[string]interface{}, error) {
+	res := make([]map[string]interface{}, 0, len(members))
+	for _, cref := range members {
 		if cref == rd.RootMember {
 			continue
 		}
 		m := member.GetObject(cref)
 		userInfo, err := rd.getUserInfoMap(m)
 		if err != nil {
-			return nil, fmt.Errorf("[ DumpAllUsers ] Problem with making request: %s", err.Error())
+			return nil, fmt.Errorf("Problem with making request: %s", err.Error())
 		}
 		res = append(res, userInfo)
 	}
-	resJSON, _ := json.Marshal(res)
-	return resJSON, nil
+	return res, nil
 }
 
 var INSATTR_Info_API = true
@@ -150,7 +216,105 @@ func (rd *RootDomain) GetNodeDomainRef() (core.RecordRef, error) {
 	return rd.NodeDomainRef, nil
 }
 
+func (rd *RootDomain) builtinAlias(alias string) (core.RecordRef, bool) {
+	switch alias {
+	case rootDomainAlias:
+		return rd.GetReference(), true
+	case nodeDomainAlias:
+		return rd.NodeDomainRef, true
+	case rootMemberAlias:
+		return rd.RootMember, true
+	}
+	return core.RecordRef{}, false
+}
+
+func (rd *RootDomain) changeAlias(alias string, reference string) error {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return fmt.Errorf("only root member can change aliases")
+	}
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("invalid alias: %s", alias)
+	}
+	if _, ok := rd.builtinAlias(alias); ok {
+		return fmt.Errorf("alias %s is builtin", alias)
+	}
+
+	if rd.Aliases == nil {
+		rd.Aliases = make(map[string]string)
+	}
+	previous := rd.Aliases[alias]
+	if reference == "" {
+		delete(rd.Aliases, alias)
+	} else {
+		rd.Aliases[alias] = reference
+	}
+	rd.AliasChanges = append(rd.AliasChanges, AliasChange{
+		Alias:     alias,
+		Reference: reference,
+		Previous:  previous,
+		Changer:   rd.GetContext().Caller.String(),
+		Pulse:     rd.GetContext().Pulse.PulseNumber,
+	})
+	return nil
+}
+
+// SetAlias binds human-readable alias to reference, only root member can do it
+func (rd *RootDomain) SetAlias(alias string, reference string) error {
+	ref, err := core.NewRefFromBase58(reference)
+	if err != nil {
+		return fmt.Errorf("[ SetAlias ] Failed to parse reference: %s", err.Error())
+	}
+	if err := rd.changeAlias(alias, ref.String()); err != nil {
+		return fmt.Errorf("[ SetAlias ] %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveAlias removes alias from registry, only root member can do it
+func (rd *RootDomain) RemoveAlias(alias string) error {
+	if _, ok := rd.Aliases[alias]; !ok {
+		return fmt.Errorf("[ RemoveAlias ] Alias not found: %s", alias)
+	}
+	if err := rd.changeAlias(alias, ""); err != nil {
+		return fmt.Errorf("[ RemoveAlias ] %s", err.Error())
+	}
+	return nil
+}
+
+var INSATTR_ResolveAlias_API = true
+
+// ResolveAlias returns reference bound to alias
+func (rd *RootDomain) ResolveAlias(alias string) (string, error) {
+	if ref, ok := rd.builtinAlias(alias); ok {
+		return ref.String(), nil
+	}
+	ref, ok := rd.Aliases[alias]
+	if !ok {
+		return "", fmt.Errorf("[ ResolveAlias ] Alias not found: %s", alias)
+	}
+	return ref, nil
+}
+
+var INSATTR_GetAliasHistory_API = true
+
+// GetAliasHistory returns json array of changes of alias, empty alias means changes of all aliases
+func (rd *RootDomain) GetAliasHistory(alias string) ([]byte, error) {
+	res := []AliasChange{}
+	for _, change := range rd.AliasChanges {
+		if alias == "" || change.Alias == alias {
+			res = append(res, change)
+		}
+	}
+	resJSON, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("[ GetAliasHistory ] Can't marshal res: %s", err.Error())
+	}
+	return resJSON, nil
+}
+
 // NewRootDomain creates new RootDomain
 func NewRootDomain() (*RootDomain, error) {
-	return &RootDomain{}, nil
+	return &RootDomain{
+		Aliases: make(map[string]string),
+	}, nil
 }
@@ -52,3 +52,35 @@ func InfoResponse(data []byte) (*Info, error) {
 
 	return &info, nil
 }
+
+// UsersPage is a page of users dump with cursor of the next page, cursor is empty on the last page
+type UsersPage struct {
+	Users []json.RawMessage `json:"users"`
+	Next  string            `json:"next"`
+}
+
+// UsersPageResponse returns users and cursor of the next page from response of DumpUsersPage() method of Member contract
+func UsersPageResponse(data []byte) ([]json.RawMessage, string, error) {
+	var pageJSON []byte
+	var contractErr *foundation.Error
+	_, err := core.UnMarshalResponse(data, []interface{}{&pageJSON, &contractErr})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ UsersPageResponse ] Can't unmarshal")
+	}
+	if contractErr != nil {
+		return nil, "", errors.Wrap(contractErr, "[ UsersPageResponse ] Has error in response")
+	}
+
+	var page UsersPage
+	err = json.Unmarshal(pageJSON, &page)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "[ UsersPageResponse ] Can't unmarshal response ")
+	}
+
+	return page.Users, page.Next, nil
+}
+
+// AliasResponse returns reference from response of ResolveAlias() method of RootDomain contract
+func AliasResponse(data []byte) (string, error) {
+	return stringResponse(data)
+}
@@ -70,3 +70,48 @@ func TestInfoResponse_UnmarshalError(t *testing.T) {
 	require.Contains(t, err.Error(), "Can't unmarshal")
 	require.Nil(t, info)
 }
+
+func TestUsersPageResponse(t *testing.T) {
+	testValue, _ := json.Marshal(map[string]interface{}{
+		"users": []map[string]interface{}{
+			{"member": "first", "wallet": 1},
+			{"member": "second", "wallet": 2},
+		},
+		"next": "cursor",
+	})
+
+	data, err := core.Serialize([]interface{}{testValue, nil})
+	require.NoError(t, err)
+
+	users, next, err := UsersPageResponse(data)
+
+	require.NoError(t, err)
+	require.Equal(t, "cursor", next)
+	require.Len(t, users, 2)
+	require.JSONEq(t, `{"member": "second", "wallet": 2}`, string(users[1]))
+}
+
+func TestUsersPageResponse_ErrorResponse(t *testing.T) {
+	contractErr := &foundation.Error{S: "Custom test error"}
+
+	data, err := core.Serialize([]interface{}{nil, contractErr})
+	require.NoError(t, err)
+
+	users, _, err := UsersPageResponse(data)
+
+	require.Contains(t, err.Error(), "Has error in response")
+	require.Contains(t, err.Error(), "Custom test error")
+	require.Nil(t, users)
+}
+
+func TestAliasResponse(t *testing.T) {
+	testValue := "11111111111111111111111111111111.11111111111111111111111111111111"
+
+	data, err := core.Serialize([]interface{}{testValue, nil})
+	require.NoError(t, err)
+
+	ref, err := AliasResponse(data)
+
+	require.NoError(t, err)
+	require.Equal(t, testValue, ref)
+}
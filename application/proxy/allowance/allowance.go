@@ -18,6 +18,7 @@ package allowance
 
 import (
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
@@ -311,3 +312,34 @@ func (r *Allowance) GetExpiredBalanceNoWait() error {
 
 	return nil
 }
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "Allowance",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{
+				Name:      "TakeAmount",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"uint", "error"},
+			},
+			{
+				Name:      "GetBalanceForOwner",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"uint", "error"},
+			},
+			{
+				Name:      "GetExpiredBalance",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"uint", "error"},
+			},
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{
+				Name:      "New",
+				Arguments: []argschema.Argument{{Name: "to", Type: "*core.RecordRef"}, {Name: "amount", Type: "uint"}, {Name: "expire", Type: "int64"}},
+				Results:   []string{"*Allowance", "error"},
+			},
+		},
+	})
+}
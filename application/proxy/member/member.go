@@ -18,13 +18,14 @@ package member
 
 import (
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
 
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("1111DojgftvVq6ZYswwJEcjpBU6kgR9Cqq4hKMrgWr.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("111137a6wPL33iunrSVwMArYv9SaWXcovPBmLnbtiMg.11111111111111111111111111111111")
 
 // Member holds proxy type
 type Member struct {
@@ -320,3 +321,103 @@ func (r *Member) CallNoWait(rootDomain core.RecordRef, method string, params []b
 
 	return nil
 }
+
+// DumpUsersPage is proxy generated method
+func (r *Member) DumpUsersPage(rootDomain core.RecordRef, params []byte, seed []byte, sign []byte, cursor string, limit uint) ([]byte, error) {
+	var args [6]interface{}
+	args[0] = rootDomain
+	args[1] = params
+	args[2] = seed
+	args[3] = sign
+	args[4] = cursor
+	args[5] = limit
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "DumpUsersPage", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// DumpUsersPageNoWait is proxy generated method
+func (r *Member) DumpUsersPageNoWait(rootDomain core.RecordRef, params []byte, seed []byte, sign []byte, cursor string, limit uint) error {
+	var args [6]interface{}
+	args[0] = rootDomain
+	args[1] = params
+	args[2] = seed
+	args[3] = sign
+	args[4] = cursor
+	args[5] = limit
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "DumpUsersPage", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "Member",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{
+				Name:      "GetName",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "GetPublicKey",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "Call",
+				Arguments: []argschema.Argument{{Name: "rootDomain", Type: "core.RecordRef"}, {Name: "method", Type: "string"}, {Name: "params", Type: "[]byte"}, {Name: "seed", Type: "[]byte"}, {Name: "sign", Type: "[]byte"}},
+				Results:   []string{"interface{}", "error"},
+			},
+			{
+				Name:      "DumpUsersPage",
+				Arguments: []argschema.Argument{{Name: "rootDomain", Type: "core.RecordRef"}, {Name: "params", Type: "[]byte"}, {Name: "seed", Type: "[]byte"}, {Name: "sign", Type: "[]byte"}, {Name: "cursor", Type: "string"}, {Name: "limit", Type: "uint"}},
+				Results:   []string{"[]byte", "error"},
+			},
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{
+				Name:      "New",
+				Arguments: []argschema.Argument{{Name: "name", Type: "string"}, {Name: "key", Type: "string"}},
+				Results:   []string{"*Member", "error"},
+			},
+		},
+	})
+}
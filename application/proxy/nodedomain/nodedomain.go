@@ -18,6 +18,7 @@ package nodedomain
 
 import (
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
@@ -314,3 +315,34 @@ func (r *NodeDomain) RemoveNodeNoWait(nodeRef core.RecordRef) error {
 
 	return nil
 }
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "NodeDomain",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{
+				Name:      "RegisterNode",
+				Arguments: []argschema.Argument{{Name: "publicKey", Type: "string"}, {Name: "role", Type: "string"}},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "GetNodeRefByPK",
+				Arguments: []argschema.Argument{{Name: "publicKey", Type: "string"}},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "RemoveNode",
+				Arguments: []argschema.Argument{{Name: "nodeRef", Type: "core.RecordRef"}},
+				Results:   []string{"error"},
+			},
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{
+				Name:      "NewNodeDomain",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"*NodeDomain", "error"},
+			},
+		},
+	})
+}
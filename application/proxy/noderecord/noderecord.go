@@ -18,6 +18,7 @@ package noderecord
 
 import (
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
@@ -365,3 +366,39 @@ func (r *NodeRecord) DestroyNoWait() error {
 
 	return nil
 }
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "NodeRecord",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{
+				Name:      "GetNodeInfo",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"RecordInfo", "error"},
+			},
+			{
+				Name:      "GetPublicKey",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "GetRole",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"core.StaticRole", "error"},
+			},
+			{
+				Name:      "Destroy",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"error"},
+			},
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{
+				Name:      "NewNodeRecord",
+				Arguments: []argschema.Argument{{Name: "publicKey", Type: "string"}, {Name: "roleStr", Type: "string"}},
+				Results:   []string{"*NodeRecord", "error"},
+			},
+		},
+	})
+}
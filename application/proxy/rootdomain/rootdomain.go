@@ -18,13 +18,22 @@ package rootdomain
 
 import (
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
 
+type AliasChange struct {
+	Alias     string           `json:"alias"`
+	Reference string           `json:"reference"`
+	Previous  string           `json:"previous"`
+	Changer   string           `json:"changer"`
+	Pulse     core.PulseNumber `json:"pulse"`
+}
+
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("1111C3jrgHyV9RYvRQjnZDcZb58a5ymPrgKt6o7N89.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("1111MiiWMTJaVe6CY9CKtDerYusiJCYrqmz6Y7VS4A.11111111111111111111111111111111")
 
 // RootDomain holds proxy type
 type RootDomain struct {
@@ -367,6 +376,62 @@ func (r *RootDomain) DumpAllUsersNoWait() error {
 	return nil
 }
 
+// DumpUsersPage is proxy generated method
+func (r *RootDomain) DumpUsersPage(cursor string, limit uint) ([]byte, error) {
+	var args [2]interface{}
+	args[0] = cursor
+	args[1] = limit
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "DumpUsersPage", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// DumpUsersPageNoWait is proxy generated method
+func (r *RootDomain) DumpUsersPageNoWait(cursor string, limit uint) error {
+	var args [2]interface{}
+	args[0] = cursor
+	args[1] = limit
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "DumpUsersPage", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Info is proxy generated method
 func (r *RootDomain) Info() (interface{}, error) {
 	var args [0]interface{}
@@ -470,3 +535,288 @@ func (r *RootDomain) GetNodeDomainRefNoWait() error {
 
 	return nil
 }
+
+// SetAlias is proxy generated method
+func (r *RootDomain) SetAlias(alias string, reference string) error {
+	var args [2]interface{}
+	args[0] = alias
+	args[1] = reference
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "SetAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return err
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// SetAliasNoWait is proxy generated method
+func (r *RootDomain) SetAliasNoWait(alias string, reference string) error {
+	var args [2]interface{}
+	args[0] = alias
+	args[1] = reference
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "SetAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveAlias is proxy generated method
+func (r *RootDomain) RemoveAlias(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "RemoveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return err
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// RemoveAliasNoWait is proxy generated method
+func (r *RootDomain) RemoveAliasNoWait(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "RemoveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResolveAlias is proxy generated method
+func (r *RootDomain) ResolveAlias(alias string) (string, error) {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "ResolveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// ResolveAliasNoWait is proxy generated method
+func (r *RootDomain) ResolveAliasNoWait(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "ResolveAlias", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAliasHistory is proxy generated method
+func (r *RootDomain) GetAliasHistory(alias string) ([]byte, error) {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetAliasHistory", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetAliasHistoryNoWait is proxy generated method
+func (r *RootDomain) GetAliasHistoryNoWait(alias string) error {
+	var args [1]interface{}
+	args[0] = alias
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetAliasHistory", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "RootDomain",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{
+				Name:      "CreateMember",
+				Arguments: []argschema.Argument{{Name: "name", Type: "string"}, {Name: "key", Type: "string"}},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "GetRootMemberRef",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"*core.RecordRef", "error"},
+			},
+			{
+				Name:      "DumpUserInfo",
+				Arguments: []argschema.Argument{{Name: "reference", Type: "string"}},
+				Results:   []string{"[]byte", "error"},
+			},
+			{
+				Name:      "DumpAllUsers",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"[]byte", "error"},
+			},
+			{
+				Name:      "DumpUsersPage",
+				Arguments: []argschema.Argument{{Name: "cursor", Type: "string"}, {Name: "limit", Type: "uint"}},
+				Results:   []string{"[]byte", "error"},
+			},
+			{
+				Name:      "Info",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"interface{}", "error"},
+			},
+			{
+				Name:      "GetNodeDomainRef",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"core.RecordRef", "error"},
+			},
+			{
+				Name:      "SetAlias",
+				Arguments: []argschema.Argument{{Name: "alias", Type: "string"}, {Name: "reference", Type: "string"}},
+				Results:   []string{"error"},
+			},
+			{
+				Name:      "RemoveAlias",
+				Arguments: []argschema.Argument{{Name: "alias", Type: "string"}},
+				Results:   []string{"error"},
+			},
+			{
+				Name:      "ResolveAlias",
+				Arguments: []argschema.Argument{{Name: "alias", Type: "string"}},
+				Results:   []string{"string", "error"},
+			},
+			{
+				Name:      "GetAliasHistory",
+				Arguments: []argschema.Argument{{Name: "alias", Type: "string"}},
+				Results:   []string{"[]byte", "error"},
+			},
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{
+				Name:      "NewRootDomain",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"*RootDomain", "error"},
+			},
+		},
+	})
+}
@@ -18,6 +18,7 @@ package wallet
 
 import (
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
@@ -311,3 +312,34 @@ func (r *Wallet) GetBalanceNoWait() error {
 
 	return nil
 }
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "Wallet",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{
+				Name:      "Transfer",
+				Arguments: []argschema.Argument{{Name: "amount", Type: "uint"}, {Name: "to", Type: "*core.RecordRef"}},
+				Results:   []string{"error"},
+			},
+			{
+				Name:      "Accept",
+				Arguments: []argschema.Argument{{Name: "aRef", Type: "*core.RecordRef"}},
+				Results:   []string{"error"},
+			},
+			{
+				Name:      "GetBalance",
+				Arguments: []argschema.Argument{},
+				Results:   []string{"uint", "error"},
+			},
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{
+				Name:      "New",
+				Arguments: []argschema.Argument{{Name: "balance", Type: "uint"}},
+				Results:   []string{"*Wallet", "error"},
+			},
+		},
+	})
+}
@@ -0,0 +1,162 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package canary periodically executes small end-to-end transaction through local api
+// (create temporary members, transfer, read balance back) and reports results to metrics.
+package canary
+
+import (
+	"context"
+	"time"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/metrics"
+	"github.com/pkg/errors"
+)
+
+// client is part of api sdk used by canary.
+type client interface {
+	CreateMember() (*sdk.Member, string, error)
+	Transfer(amount uint, from *sdk.Member, to *sdk.Member) (string, error)
+	GetBalance(m *sdk.Member) (uint64, error)
+}
+
+// Canary is component executing canary transactions.
+type Canary struct {
+	cfg       configuration.Canary
+	newClient func() (client, error)
+	client    client
+
+	failures int
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// New creates new Canary, transactions are sent to api on apiCfg.Address.
+func New(cfg configuration.Canary, apiCfg configuration.APIRunner) *Canary {
+	url := "http://" + apiCfg.Address + "/api"
+	return &Canary{
+		cfg: cfg,
+		newClient: func() (client, error) {
+			return sdk.NewSDK([]string{url}, cfg.RootMemberKeys)
+		},
+	}
+}
+
+// Start starts canary if it's enabled in configuration.
+func (c *Canary) Start(ctx context.Context) error {
+	if !c.cfg.Enabled {
+		return nil
+	}
+	if c.cfg.Interval <= 0 {
+		return errors.New("[ Canary.Start ] interval should be positive")
+	}
+
+	c.stop = make(chan struct{})
+	c.stopped = make(chan struct{})
+	go c.loop(ctx)
+	return nil
+}
+
+// Stop stops canary.
+func (c *Canary) Stop(ctx context.Context) error {
+	if c.stop == nil {
+		return nil
+	}
+	close(c.stop)
+	<-c.stopped
+	return nil
+}
+
+func (c *Canary) loop(ctx context.Context) {
+	defer close(c.stopped)
+
+	ticker := time.NewTicker(time.Duration(c.cfg.Interval) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check executes one transaction and updates metrics.
+func (c *Canary) check(ctx context.Context) {
+	inslog := inslogger.FromContext(ctx)
+
+	start := time.Now()
+	err := c.transaction()
+	if err == nil {
+		metrics.CanaryTransactionsTotal.WithLabelValues("success").Inc()
+		metrics.CanaryTransactionTime.Observe(time.Since(start).Seconds())
+		if c.failures >= c.cfg.FailureThreshold {
+			inslog.Info("[ Canary ] transactions are successful again")
+		}
+		c.failures = 0
+		metrics.CanaryConsecutiveFailures.Set(0)
+		metrics.CanaryAlert.Set(0)
+		return
+	}
+
+	metrics.CanaryTransactionsTotal.WithLabelValues("failure").Inc()
+	c.failures++
+	metrics.CanaryConsecutiveFailures.Set(float64(c.failures))
+	inslog.Warn("[ Canary ] transaction failed: ", err)
+	if c.failures == c.cfg.FailureThreshold {
+		inslog.Errorf("[ Canary ] ALERT: %d transactions failed in a row, last error: %s", c.failures, err)
+		metrics.CanaryAlert.Set(1)
+	}
+}
+
+// transaction creates two temporary members, transfers amount between them and checks balance of receiver.
+func (c *Canary) transaction() error {
+	if c.client == nil {
+		cl, err := c.newClient()
+		if err != nil {
+			return errors.Wrap(err, "[ transaction ] failed to create api client")
+		}
+		c.client = cl
+	}
+
+	from, _, err := c.client.CreateMember()
+	if err != nil {
+		return errors.Wrap(err, "[ transaction ] failed to create member")
+	}
+	to, _, err := c.client.CreateMember()
+	if err != nil {
+		return errors.Wrap(err, "[ transaction ] failed to create member")
+	}
+	before, err := c.client.GetBalance(to)
+	if err != nil {
+		return errors.Wrap(err, "[ transaction ] failed to get balance")
+	}
+	if _, err := c.client.Transfer(c.cfg.Amount, from, to); err != nil {
+		return errors.Wrap(err, "[ transaction ] failed to transfer")
+	}
+	after, err := c.client.GetBalance(to)
+	if err != nil {
+		return errors.Wrap(err, "[ transaction ] failed to get balance")
+	}
+	if after != before+uint64(c.cfg.Amount) {
+		return errors.Errorf("[ transaction ] balance is %d after transfer of %d, expected %d", after, c.cfg.Amount, before+uint64(c.cfg.Amount))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package canary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/metrics"
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+type testClient struct {
+	balances map[string]uint64
+	members  int
+	fail     bool
+}
+
+func (tc *testClient) CreateMember() (*sdk.Member, string, error) {
+	if tc.fail {
+		return nil, "", errors.New("api is down")
+	}
+	tc.members++
+	m := sdk.NewMember(string(rune('a'+tc.members)), "")
+	tc.balances[m.Reference] = 1000
+	return m, "", nil
+}
+
+func (tc *testClient) Transfer(amount uint, from *sdk.Member, to *sdk.Member) (string, error) {
+	tc.balances[from.Reference] -= uint64(amount)
+	tc.balances[to.Reference] += uint64(amount)
+	return "", nil
+}
+
+func (tc *testClient) GetBalance(m *sdk.Member) (uint64, error) {
+	return tc.balances[m.Reference], nil
+}
+
+func gaugeValue(t *testing.T, g interface{ Write(*dto.Metric) error }) float64 {
+	m := &dto.Metric{}
+	require.NoError(t, g.Write(m))
+	return m.GetGauge().GetValue()
+}
+
+func TestCanary_Check(t *testing.T) {
+	cfg := configuration.NewCanary()
+	cfg.FailureThreshold = 2
+	cl := &testClient{balances: map[string]uint64{}}
+	c := New(cfg, configuration.NewAPIRunner())
+	c.newClient = func() (client, error) {
+		return cl, nil
+	}
+	ctx := context.Background()
+
+	c.check(ctx)
+	require.Equal(t, 0, c.failures)
+	require.Equal(t, 2, cl.members)
+
+	cl.fail = true
+	c.check(ctx)
+	require.Equal(t, 1, c.failures)
+	require.Equal(t, float64(0), gaugeValue(t, metrics.CanaryAlert))
+	c.check(ctx)
+	require.Equal(t, float64(2), gaugeValue(t, metrics.CanaryConsecutiveFailures))
+	require.Equal(t, float64(1), gaugeValue(t, metrics.CanaryAlert))
+
+	cl.fail = false
+	c.check(ctx)
+	require.Equal(t, 0, c.failures)
+	require.Equal(t, float64(0), gaugeValue(t, metrics.CanaryAlert))
+}
+
+func TestCanary_StartDisabled(t *testing.T) {
+	c := New(configuration.NewCanary(), configuration.NewAPIRunner())
+	ctx := context.Background()
+	require.NoError(t, c.Start(ctx))
+	require.NoError(t, c.Stop(ctx))
+}
@@ -93,9 +93,12 @@ type Certificate struct {
 	PulsarPublicKeys    []string        `json:"pulsar_public_keys"`
 	RootDomainReference string          `json:"root_domain_ref"`
 	BootstrapNodes      []BootstrapNode `json:"bootstrap_nodes"`
+	// OperatorPublicKeys are keys of operators allowed to sign admin API actions
+	OperatorPublicKeys []string `json:"operator_public_keys,omitempty"`
 
 	// preprocessed fields
-	pulsarPublicKey []crypto.PublicKey
+	pulsarPublicKey   []crypto.PublicKey
+	operatorPublicKey []crypto.PublicKey
 }
 
 func newCertificate(publicKey crypto.PublicKey, keyProcessor core.KeyProcessor, data []byte) (*Certificate, error) {
@@ -140,6 +143,9 @@ func (cert *Certificate) SerializeNetworkPart() []byte {
 	}
 	sort.Strings(nodes)
 	out += strings.Join(nodes, "")
+	operators := append([]string(nil), cert.OperatorPublicKeys...)
+	sort.Strings(operators)
+	out += strings.Join(operators, "")
 
 	return []byte(out)
 }
@@ -169,6 +175,14 @@ func (cert *Certificate) fillExtraFields(keyProcessor core.KeyProcessor) error {
 		cert.pulsarPublicKey = append(cert.pulsarPublicKey, importedPulsarPubKey)
 	}
 
+	for _, operatorKey := range cert.OperatorPublicKeys {
+		importedOperatorPubKey, err := keyProcessor.ImportPublicKeyPEM([]byte(operatorKey))
+		if err != nil {
+			return errors.Wrapf(err, "[ fillExtraFields ] Bad operatorKey: %s", operatorKey)
+		}
+		cert.operatorPublicKey = append(cert.operatorPublicKey, importedOperatorPubKey)
+	}
+
 	for i := 0; i < len(cert.BootstrapNodes); i++ {
 		currentNode := &cert.BootstrapNodes[i]
 		importedBNodePubKey, err := keyProcessor.ImportPublicKeyPEM([]byte(currentNode.PublicKey))
@@ -201,6 +215,11 @@ func (cert *Certificate) GetDiscoveryNodes() []core.DiscoveryNode {
 	return result
 }
 
+// GetOperatorPublicKeys returns keys of operators allowed to sign admin API actions
+func (cert *Certificate) GetOperatorPublicKeys() []crypto.PublicKey {
+	return cert.operatorPublicKey
+}
+
 // Dump returns all info about certificate in json format
 func (cert *Certificate) Dump() (string, error) {
 	result, err := json.MarshalIndent(cert, "", "    ")
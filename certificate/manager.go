@@ -17,8 +17,11 @@
 package certificate
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/sha256"
 	"io"
+	"sync"
 
 	"github.com/insolar/insolar/core"
 	"github.com/pkg/errors"
@@ -28,11 +31,21 @@ import (
 type CertificateManager struct {
 	CS          core.CryptographyService `inject:""`
 	certificate core.Certificate
+
+	// verified holds digests of authorization certificates whose discovery signatures
+	// were already checked, so the same chain is not re-verified on every pulse.
+	verified     map[core.RecordRef][]byte
+	revoked      map[core.RecordRef]struct{}
+	verifiedLock sync.RWMutex
 }
 
 // NewCertificateManager returns new CertificateManager instance
 func NewCertificateManager(cert core.Certificate) *CertificateManager {
-	return &CertificateManager{certificate: cert}
+	return &CertificateManager{
+		certificate: cert,
+		verified:    make(map[core.RecordRef][]byte),
+		revoked:     make(map[core.RecordRef]struct{}),
+	}
 }
 
 // GetCertificate returns current node certificate
@@ -40,21 +53,94 @@ func (m *CertificateManager) GetCertificate() core.Certificate {
 	return m.certificate
 }
 
-// VerifyAuthorizationCertificate verifies certificate from some node
+// VerifyAuthorizationCertificate verifies certificate from some node.
+// Successful results are cached by node reference until the certificate changes or gets revoked.
 func (m *CertificateManager) VerifyAuthorizationCertificate(authCert core.AuthorizationCertificate) (bool, error) {
 	discoveryNodes := m.certificate.GetDiscoveryNodes()
-	if len(discoveryNodes) != len(authCert.GetDiscoverySigns()) {
+	signs := authCert.GetDiscoverySigns()
+	if len(discoveryNodes) != len(signs) {
 		return false, nil
 	}
 	data := authCert.SerializeNodePart()
+
+	ref := authCert.GetNodeRef()
+	if ref == nil {
+		return m.verifySigns(discoveryNodes, signs, data), nil
+	}
+
+	digest := certificateDigest(discoveryNodes, signs, data)
+	m.verifiedLock.RLock()
+	_, isRevoked := m.revoked[*ref]
+	cached, ok := m.verified[*ref]
+	m.verifiedLock.RUnlock()
+	if isRevoked {
+		return false, nil
+	}
+	if ok && bytes.Equal(cached, digest) {
+		return true, nil
+	}
+
+	if !m.verifySigns(discoveryNodes, signs, data) {
+		return false, nil
+	}
+
+	m.verifiedLock.Lock()
+	defer m.verifiedLock.Unlock()
+	if _, isRevoked := m.revoked[*ref]; isRevoked {
+		return false, nil
+	}
+	if m.verified == nil {
+		m.verified = make(map[core.RecordRef][]byte)
+	}
+	m.verified[*ref] = digest
+	return true, nil
+}
+
+// UpdateRevocationList replaces the list of revoked node certificates
+// and drops cached verification results for every revoked node.
+func (m *CertificateManager) UpdateRevocationList(refs []core.RecordRef) {
+	revoked := make(map[core.RecordRef]struct{}, len(refs))
+	for _, ref := range refs {
+		revoked[ref] = struct{}{}
+	}
+
+	m.verifiedLock.Lock()
+	defer m.verifiedLock.Unlock()
+	m.revoked = revoked
+	for ref := range revoked {
+		delete(m.verified, ref)
+	}
+}
+
+// InvalidateCertificate drops cached verification result for node certificate.
+func (m *CertificateManager) InvalidateCertificate(ref core.RecordRef) {
+	m.verifiedLock.Lock()
+	defer m.verifiedLock.Unlock()
+	delete(m.verified, ref)
+}
+
+func (m *CertificateManager) verifySigns(discoveryNodes []core.DiscoveryNode, signs map[*core.RecordRef][]byte, data []byte) bool {
 	for _, node := range discoveryNodes {
-		sign := authCert.GetDiscoverySigns()[node.GetNodeRef()]
+		sign := signs[node.GetNodeRef()]
 		ok := m.CS.Verify(node.GetPublicKey(), core.SignatureFromBytes(sign), data)
 		if !ok {
-			return false, nil
+			return false
 		}
 	}
-	return true, nil
+	return true
+}
+
+// certificateDigest identifies node part of certificate together with discovery signs it is checked against.
+func certificateDigest(discoveryNodes []core.DiscoveryNode, signs map[*core.RecordRef][]byte, data []byte) []byte {
+	h := sha256.New()
+	h.Write(data)
+	for _, node := range discoveryNodes {
+		if ref := node.GetNodeRef(); ref != nil {
+			h.Write(ref[:])
+		}
+		h.Write(signs[node.GetNodeRef()])
+	}
+	return h.Sum(nil)
 }
 
 // NewUnsignedCertificate returns new certificate
@@ -0,0 +1,103 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package certificate
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachingTestManager(t *testing.T) (*CertificateManager, *testutils.CryptographyServiceMock, *AuthorizationCertificate) {
+	discoveryRef := testutils.RandomRef()
+	discovery := testutils.NewDiscoveryNodeMock(t)
+	discovery.GetNodeRefMock.Return(&discoveryRef)
+	discovery.GetPublicKeyMock.Return(nil)
+
+	cert := testutils.NewCertificateMock(t)
+	cert.GetDiscoveryNodesMock.Return([]core.DiscoveryNode{discovery})
+
+	cs := testutils.NewCryptographyServiceMock(t)
+	cs.VerifyFunc = func(crypto.PublicKey, core.Signature, []byte) bool {
+		return true
+	}
+
+	nodeRef := testutils.RandomRef()
+	authCert := &AuthorizationCertificate{
+		PublicKey:      "public key",
+		Reference:      nodeRef.String(),
+		Role:           "virtual",
+		DiscoverySigns: map[*core.RecordRef][]byte{&discoveryRef: []byte("sign")},
+	}
+
+	manager := NewCertificateManager(cert)
+	manager.CS = cs
+	return manager, cs, authCert
+}
+
+func TestCertificateManager_VerifyAuthorizationCertificate_Cached(t *testing.T) {
+	manager, cs, authCert := newCachingTestManager(t)
+
+	for i := 0; i < 3; i++ {
+		ok, err := manager.VerifyAuthorizationCertificate(authCert)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	require.Equal(t, uint64(1), cs.VerifyCounter)
+
+	authCert.Role = "heavy_material"
+	ok, err := manager.VerifyAuthorizationCertificate(authCert)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), cs.VerifyCounter)
+}
+
+func TestCertificateManager_VerifyAuthorizationCertificate_NotCachedOnFailure(t *testing.T) {
+	manager, cs, authCert := newCachingTestManager(t)
+	cs.VerifyFunc = func(crypto.PublicKey, core.Signature, []byte) bool {
+		return false
+	}
+
+	for i := 0; i < 2; i++ {
+		ok, err := manager.VerifyAuthorizationCertificate(authCert)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	require.Equal(t, uint64(2), cs.VerifyCounter)
+}
+
+func TestCertificateManager_UpdateRevocationList(t *testing.T) {
+	manager, cs, authCert := newCachingTestManager(t)
+
+	ok, err := manager.VerifyAuthorizationCertificate(authCert)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	manager.UpdateRevocationList([]core.RecordRef{*authCert.GetNodeRef()})
+	ok, err = manager.VerifyAuthorizationCertificate(authCert)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	manager.UpdateRevocationList(nil)
+	ok, err = manager.VerifyAuthorizationCertificate(authCert)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), cs.VerifyCounter)
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
 
@@ -97,7 +98,7 @@ var (
 func parseInputParams() {
 	var rootCmd = &cobra.Command{}
 	rootCmd.Flags().StringVarP(&cmd, "cmd", "c", "",
-		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs")
+		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs | partition_drill | drill_report | consensus_timeline")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "be verbose (default false)")
 	rootCmd.Flags().StringVarP(&output, "output", "o", defaultStdoutPath, "output file (use - for STDOUT)")
 	rootCmd.Flags().StringVarP(&sendUrls, "url", "u", defaultURL, "api url")
@@ -218,6 +219,56 @@ func genSendConfigs(out io.Writer) {
 	writeToOutput(out, string(userConf)+"\n")
 }
 
+func partitionDrill(out io.Writer) {
+	pPath := paramsPath
+	if len(pPath) == 0 {
+		pPath = configPath
+	}
+	rawParams, err := ioutil.ReadFile(pPath)
+	check("[ partitionDrill ] Can't read params:", err)
+	params := map[string]interface{}{}
+	err = json.Unmarshal(rawParams, &params)
+	check("[ partitionDrill ] Can't unmarshal params:", err)
+
+	body, err := requester.GetResponseBody(sendUrls+"/rpc", requester.PostParams{
+		"jsonrpc": "2.0",
+		"id":      "",
+		"method":  "drill.Start",
+		"params":  params,
+	})
+	check("[ partitionDrill ]", err)
+	writeToOutput(out, string(body)+"\n")
+}
+
+func drillReport(out io.Writer) {
+	body, err := requester.GetResponseBody(sendUrls+"/rpc", requester.PostParams{
+		"jsonrpc": "2.0",
+		"id":      "",
+		"method":  "drill.Report",
+	})
+	check("[ drillReport ]", err)
+	writeToOutput(out, string(body)+"\n")
+}
+
+func consensusTimeline(out io.Writer) {
+	params := map[string]interface{}{}
+	if len(paramsPath) > 0 {
+		rawParams, err := ioutil.ReadFile(paramsPath)
+		check("[ consensusTimeline ] Can't read params:", err)
+		err = json.Unmarshal(rawParams, &params)
+		check("[ consensusTimeline ] Can't unmarshal params:", err)
+	}
+
+	body, err := requester.GetResponseBody(sendUrls+"/rpc", requester.PostParams{
+		"jsonrpc": "2.0",
+		"id":      "",
+		"method":  "consensus.Timeline",
+		"params":  params,
+	})
+	check("[ consensusTimeline ]", err)
+	writeToOutput(out, string(body)+"\n")
+}
+
 func main() {
 	parseInputParams()
 	out, err := chooseOutput(output)
@@ -238,5 +289,11 @@ func main() {
 		sendRequest(out)
 	case "gen_send_configs":
 		genSendConfigs(out)
+	case "partition_drill":
+		partitionDrill(out)
+	case "drill_report":
+		drillReport(out)
+	case "consensus_timeline":
+		consensusTimeline(out)
 	}
 }
@@ -0,0 +1,152 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// verifyBatchSize is max size of transaction snapshot is restored with.
+const verifyBatchSize = 4 << 20
+
+func newBackupCommand() *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "ledger backup tools",
+	}
+
+	var snapshotPath, walDir, tmpDir string
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "verify that snapshot can be restored, WAL replayed on it and its jet drops are consistent",
+		Run: func(cmd *cobra.Command, args []string) {
+			report, err := verifyBackup(context.Background(), snapshotPath, walDir, tmpDir)
+			if err != nil {
+				fmt.Println("FAIL:", err)
+				os.Exit(2)
+			}
+			for _, m := range report.Mismatches {
+				fmt.Printf("mismatch: jet %x, pulse %v: recorded %x, computed %x\n", m.JetPrefix, m.Pulse, m.Recorded, m.Computed)
+			}
+			if !report.Passed() {
+				fmt.Printf("FAIL: pulse %v, %d of %d drops mismatched\n", report.Pulse, len(report.Mismatches), report.Drops)
+				os.Exit(1)
+			}
+			fmt.Printf("PASS: pulse %v, %d drops verified, %d WAL commits replayed\n",
+				report.Pulse, report.Drops, report.WALReplayed)
+			os.Exit(0)
+		},
+	}
+	verifyCmd.Flags().StringVarP(&snapshotPath, "snapshot", "s", "", "path to snapshot file")
+	verifyCmd.Flags().StringVarP(&walDir, "wal", "", "", "directory of WAL segments replayed after snapshot is restored")
+	verifyCmd.Flags().StringVarP(&tmpDir, "tmpdir", "", "", "directory for temporary storage (system temp dir by default)")
+	backupCmd.AddCommand(verifyCmd)
+
+	return backupCmd
+}
+
+// verifyBackup restores snapshot into temporary storage, replays WAL segments of walDir on it if provided and
+// recomputes hashes of jet drops up to the snapshot pulse, so drops recorded at the snapshot pulse are compared with
+// the storage as it would be used on recovery.
+func verifyBackup(ctx context.Context, snapshotPath, walDir, tmpDir string) (*storage.BackupReport, error) {
+	if snapshotPath == "" {
+		return nil, errors.New("snapshot path is required")
+	}
+	snapshot, err := os.Open(snapshotPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't open snapshot")
+	}
+	defer snapshot.Close() //nolint: errcheck
+
+	dir, err := ioutil.TempDir(tmpDir, "insolard-backup-verify")
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create temporary storage directory")
+	}
+	defer os.RemoveAll(dir) //nolint: errcheck
+
+	conf := configuration.NewLedger()
+	conf.Storage.DataDirectory = dir
+	db, err := storage.NewDB(conf, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create temporary storage")
+	}
+	header, _, err := storage.RestoreSnapshot(ctx, db, snapshot, verifyBatchSize)
+	closeErr := db.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, errors.Wrap(closeErr, "can't close restored storage")
+	}
+
+	if walDir != "" {
+		if err := copyWAL(walDir, filepath.Join(dir, "wal")); err != nil {
+			return nil, err
+		}
+		// log segments are replayed on open of storage with enabled WAL
+		conf.Storage.WAL.Enabled = true
+	}
+	db, err = storage.NewDB(conf, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't reopen restored storage")
+	}
+	defer db.Close() //nolint: errcheck
+
+	report, err := storage.VerifyDrops(ctx, db, platformpolicy.NewPlatformCryptographyScheme(), header.Pulse)
+	if err != nil {
+		return nil, err
+	}
+	if replayed, ok := db.(interface{ WALReplayed() int }); ok {
+		report.WALReplayed = replayed.WALReplayed()
+	}
+	return report, nil
+}
+
+// copyWAL copies log segments to WAL directory of storage, segments are modified on replay so originals are kept.
+func copyWAL(from, to string) error {
+	files, err := ioutil.ReadDir(from)
+	if err != nil {
+		return errors.Wrap(err, "can't list WAL directory")
+	}
+	if err := os.MkdirAll(to, 0700); err != nil {
+		return errors.Wrap(err, "can't create WAL directory")
+	}
+	for _, file := range files {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(from, file.Name()))
+		if err != nil {
+			return errors.Wrap(err, "can't read WAL segment")
+		}
+		if err := ioutil.WriteFile(filepath.Join(to, file.Name()), data, 0600); err != nil {
+			return errors.Wrap(err, "can't copy WAL segment")
+		}
+	}
+	return nil
+}
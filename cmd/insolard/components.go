@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/insolar/insolar/api"
+	"github.com/insolar/insolar/canary"
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
@@ -30,6 +31,7 @@ import (
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/genesis"
 	"github.com/insolar/insolar/genesisdataprovider"
+	"github.com/insolar/insolar/instrumentation/watchdog"
 	"github.com/insolar/insolar/keystore"
 	"github.com/insolar/insolar/ledger"
 	"github.com/insolar/insolar/logicrunner"
@@ -191,6 +193,8 @@ func initComponents(
 		networkCoordinator,
 		phases.NewPhaseManager(),
 		cryptographyService,
+		canary.New(cfg.Canary, cfg.APIRunner),
+		watchdog.NewWatchdog(cfg.Watchdog),
 	}...)
 
 	cm.Inject(components...)
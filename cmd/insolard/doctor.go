@@ -0,0 +1,310 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package main
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/keystore"
+	"github.com/insolar/insolar/ledger/recovery"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+const (
+	// doctorDialTimeout is timeout of connection to bootstrap host
+	doctorDialTimeout = 3 * time.Second
+	// doctorFsyncWarnLatency is fsync latency of storage directory that is reported as warning
+	doctorFsyncWarnLatency = 50 * time.Millisecond
+)
+
+// Statuses of doctor checks.
+const (
+	checkPassed  = "PASS"
+	checkWarning = "WARN"
+	checkFailed  = "FAIL"
+)
+
+// doctorCheck is result of one prerequisite check.
+type doctorCheck struct {
+	Name     string
+	Status   string
+	Detail   string
+	Duration time.Duration
+}
+
+// doctorReport is result of all prerequisite checks.
+type doctorReport struct {
+	Checks []doctorCheck
+}
+
+// Passed checks if none of checks failed, warnings are allowed.
+func (r *doctorReport) Passed() bool {
+	for _, c := range r.Checks {
+		if c.Status == checkFailed {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *doctorReport) add(name string, f func() (string, error)) {
+	start := time.Now()
+	detail, err := f()
+	check := doctorCheck{Name: name, Status: checkPassed, Detail: detail, Duration: time.Since(start)}
+	if err != nil {
+		check.Status = checkFailed
+		check.Detail = err.Error()
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+func (r *doctorReport) warn(name string, detail string) {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: checkWarning, Detail: detail})
+}
+
+func newDoctorCommand() *cobra.Command {
+	var configPath string
+	var asJSON bool
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "check keys, storage, ports and bootstrap hosts before joining the network",
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadDoctorConfig(configPath)
+			if err != nil {
+				fmt.Println("FAIL:", err)
+				os.Exit(2)
+			}
+			report := runDoctor(context.Background(), cfg)
+			if asJSON {
+				out, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					fmt.Println("FAIL:", err)
+					os.Exit(2)
+				}
+				fmt.Println(string(out))
+			} else {
+				for _, c := range report.Checks {
+					fmt.Printf("%s %s: %s (%v)\n", c.Status, c.Name, c.Detail, c.Duration)
+				}
+			}
+			if !report.Passed() {
+				os.Exit(1)
+			}
+			os.Exit(0)
+		},
+	}
+	doctorCmd.Flags().StringVarP(&configPath, "config", "c", "", "path to config file")
+	doctorCmd.Flags().BoolVarP(&asJSON, "json", "", false, "print report as JSON")
+
+	return doctorCmd
+}
+
+func loadDoctorConfig(path string) (configuration.Configuration, error) {
+	cfgHolder := configuration.NewHolder()
+	var err error
+	if len(path) != 0 {
+		err = cfgHolder.LoadFromFile(path)
+	} else {
+		err = cfgHolder.Load()
+	}
+	if err != nil && len(path) != 0 {
+		return cfgHolder.Configuration, errors.Wrap(err, "failed to load configuration from file")
+	}
+	err = cfgHolder.LoadEnv()
+	if err != nil {
+		return cfgHolder.Configuration, errors.Wrap(err, "failed to load configuration from env")
+	}
+	return cfgHolder.Configuration, nil
+}
+
+// runDoctor checks prerequisites of node start in order, checks that depend on failed ones are skipped.
+func runDoctor(ctx context.Context, cfg configuration.Configuration) *doctorReport {
+	report := &doctorReport{}
+
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	var privateKey crypto.PrivateKey
+	report.add("keys", func() (string, error) {
+		keyStore, err := keystore.NewKeyStore(cfg.KeysPath)
+		if err != nil {
+			return "", errors.Wrapf(err, "can't read keys from %s", cfg.KeysPath)
+		}
+		privateKey, err = keyStore.GetPrivateKey("")
+		if err != nil {
+			return "", errors.Wrap(err, "can't get private key")
+		}
+		return cfg.KeysPath, nil
+	})
+	if privateKey != nil {
+		report.add("signature", func() (string, error) {
+			return checkSignature(privateKey)
+		})
+	}
+
+	var fsyncLatency time.Duration
+	report.add("storage", func() (string, error) {
+		var err error
+		fsyncLatency, err = checkStorage(cfg.Ledger.Storage.DataDirectory)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s is writable, fsync took %v", cfg.Ledger.Storage.DataDirectory, fsyncLatency), nil
+	})
+	if fsyncLatency > doctorFsyncWarnLatency {
+		report.warn("storage latency", fmt.Sprintf("fsync took %v, ledger writes may be slow", fsyncLatency))
+	}
+	checkRecovery(report, cfg.Ledger.Storage.DataDirectory)
+
+	report.add("transport port", func() (string, error) {
+		return checkPort(cfg.Host.Transport.Address)
+	})
+	report.add("api port", func() (string, error) {
+		return checkPort(cfg.APIRunner.Address)
+	})
+
+	if privateKey == nil {
+		return report
+	}
+	cert, err := certificate.ReadCertificate(keyProcessor.ExtractPublicKey(privateKey), keyProcessor, cfg.CertificatePath)
+	if err != nil {
+		report.add("certificate", func() (string, error) {
+			return "", errors.Wrapf(err, "can't read certificate from %s", cfg.CertificatePath)
+		})
+		return report
+	}
+	for _, discovery := range cert.GetDiscoveryNodes() {
+		address := discovery.GetHost()
+		report.add("bootstrap host "+address, func() (string, error) {
+			return checkReachable(ctx, address)
+		})
+	}
+	return report
+}
+
+// checkSignature signs random data with private key and verifies it with extracted public key.
+func checkSignature(privateKey crypto.PrivateKey) (string, error) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	publicKey := platformpolicy.NewKeyProcessor().ExtractPublicKey(privateKey)
+	data := []byte(time.Now().String())
+	signature, err := scheme.Signer(privateKey).Sign(data)
+	if err != nil {
+		return "", errors.Wrap(err, "can't sign data")
+	}
+	if !scheme.Verifier(publicKey).Verify(*signature, data) {
+		return "", errors.New("signature made with private key is not verified with public key")
+	}
+	return "sign and verify roundtrip succeeded", nil
+}
+
+// checkStorage writes and fsyncs file in storage directory, returns fsync latency.
+func checkStorage(dir string) (time.Duration, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return 0, errors.Wrapf(err, "can't create storage directory %s", dir)
+	}
+	f, err := ioutil.TempFile(dir, "insolard-doctor")
+	if err != nil {
+		return 0, errors.Wrapf(err, "storage directory %s is not writable", dir)
+	}
+	defer os.Remove(filepath.Clean(f.Name())) //nolint: errcheck
+	defer f.Close()                           //nolint: errcheck
+
+	if _, err := f.Write(make([]byte, 4096)); err != nil {
+		return 0, errors.Wrap(err, "can't write to storage directory")
+	}
+	start := time.Now()
+	if err := f.Sync(); err != nil {
+		return 0, errors.Wrap(err, "can't fsync file in storage directory")
+	}
+	return time.Since(start), nil
+}
+
+// checkRecovery reports recovery report and last known good marker saved by previous run of node.
+func checkRecovery(report *doctorReport, dir string) {
+	marker, err := recovery.ReadMarker(dir)
+	switch {
+	case os.IsNotExist(err):
+		report.warn("last known good", "no marker, node has never completed a pulse")
+	default:
+		report.add("last known good", func() (string, error) {
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("pulse %d at %v", marker.Pulse, marker.Time.Format(time.RFC3339)), nil
+		})
+	}
+
+	recovered, err := recovery.ReadReport(dir)
+	if os.IsNotExist(err) {
+		return
+	}
+	report.add("recovery", func() (string, error) {
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			"started at %v from pulse %d, WAL replayed in %v, %d jets with gaps, %d pulses pending replication",
+			recovered.Time.Format(time.RFC3339), recovered.LastPulse, recovered.WAL.ReplayDuration,
+			len(recovered.JetsWithGaps), recovered.PendingOutbox,
+		), nil
+	})
+	if err != nil {
+		return
+	}
+	for _, e := range recovered.Errors {
+		report.warn("recovery", e)
+	}
+	if marker != nil && marker.Time.Before(recovered.Time) {
+		report.warn("last known good", "node hasn't completed a pulse since the last start")
+	}
+}
+
+// checkPort checks that address can be listened.
+func checkPort(address string) (string, error) {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", errors.Wrapf(err, "can't listen %s", address)
+	}
+	if err := l.Close(); err != nil {
+		return "", errors.Wrapf(err, "can't close listener on %s", address)
+	}
+	return address + " is free", nil
+}
+
+// checkReachable checks that TCP connection to address can be established.
+func checkReachable(ctx context.Context, address string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, doctorDialTimeout)
+	defer cancel()
+	start := time.Now()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s is unreachable", address)
+	}
+	defer conn.Close() //nolint: errcheck
+	return fmt.Sprintf("%s is reachable, connected in %v", address, time.Since(start)), nil
+}
@@ -0,0 +1,96 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/recovery"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctor")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = checkStorage(dir)
+	require.NoError(t, err)
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestCheckPort(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	_, err = checkPort(l.Addr().String())
+	require.Error(t, err)
+	_, err = checkPort("127.0.0.1:0")
+	require.NoError(t, err)
+}
+
+func TestDoctorReport_Passed(t *testing.T) {
+	report := &doctorReport{}
+	report.add("ok", func() (string, error) { return "", nil })
+	report.warn("slow", "")
+	require.True(t, report.Passed())
+	report.add("broken", func() (string, error) { return "", errors.New("broken") })
+	require.False(t, report.Passed())
+	require.Equal(t, checkFailed, report.Checks[2].Status)
+}
+
+func TestCheckRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doctor")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	report := &doctorReport{}
+	checkRecovery(report, dir)
+	require.Len(t, report.Checks, 1)
+	require.Equal(t, checkWarning, report.Checks[0].Status)
+
+	reporter := recovery.NewReporter(configuration.Ledger{Storage: configuration.Storage{DataDirectory: dir}})
+	db := storage.NewDBContextMock(t)
+	db.GetBadgerDBMock.Return(nil)
+	reporter.DB = db
+	pt := storage.NewPulseTrackerMock(t)
+	pt.GetLatestPulseMock.Return(&storage.Pulse{Pulse: core.Pulse{PulseNumber: core.FirstPulseNumber}}, nil)
+	reporter.PulseTracker = pt
+	rs := storage.NewReplicaStorageMock(t)
+	rs.GetAllNonEmptySyncClientJetsMock.Return(nil, nil)
+	reporter.ReplicaStorage = rs
+	require.NoError(t, reporter.Start(context.Background()))
+	require.NoError(t, reporter.MarkLastKnownGood(context.Background(), core.FirstPulseNumber+1))
+
+	report = &doctorReport{}
+	checkRecovery(report, dir)
+	require.Len(t, report.Checks, 2)
+	for _, c := range report.Checks {
+		require.Equal(t, checkPassed, c.Status, c.Detail)
+	}
+}
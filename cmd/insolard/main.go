@@ -51,6 +51,9 @@ func parseInputParams() inputParams {
 	rootCmd.Flags().StringVarP(&result.genesisConfigPath, "genesis", "g", "", "path to genesis config file")
 	rootCmd.Flags().StringVarP(&result.genesisKeyOut, "keyout", "", ".", "genesis certificates path")
 	rootCmd.Flags().BoolVarP(&result.traceEnabled, "trace", "t", false, "enable tracing")
+	rootCmd.AddCommand(newBackupCommand())
+	rootCmd.AddCommand(newDoctorCommand())
+	rootCmd.AddCommand(newStorageCommand())
 	err := rootCmd.Execute()
 	if err != nil {
 		log.Fatal("Wrong input params:", err)
@@ -0,0 +1,224 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/ledger/storage"
+)
+
+func newStorageCommand() *cobra.Command {
+	storageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "ledger storage tools",
+	}
+
+	var from, to configuration.Storage
+	var batchSize int
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "copy ledger storage to storage of another backend, node should be stopped",
+		Run: func(cmd *cobra.Command, args []string) {
+			count, err := migrateStorage(context.Background(), from, to, batchSize)
+			if err != nil {
+				fmt.Println("FAIL:", err)
+				os.Exit(1)
+			}
+			fmt.Printf("OK: %d keys copied from %s storage to %s storage\n", count, from.Backend, to.Backend)
+		},
+	}
+	migrateCmd.Flags().StringVarP(&from.Backend, "from-backend", "", storage.BackendBadger, "backend of source storage")
+	migrateCmd.Flags().StringVarP(&from.DataDirectory, "from", "", "", "data directory of source storage")
+	migrateCmd.Flags().StringVarP(&to.Backend, "to-backend", "", storage.BackendBolt, "backend of target storage")
+	migrateCmd.Flags().StringVarP(&to.DataDirectory, "to", "", "", "data directory of target storage")
+	migrateCmd.Flags().IntVarP(&batchSize, "batch", "", 4<<20, "max size of target transaction in bytes")
+	storageCmd.AddCommand(migrateCmd)
+
+	var snapshotConf configuration.Storage
+	var snapshotPath string
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "save snapshot of ledger storage, node should be stopped",
+		Run: func(cmd *cobra.Command, args []string) {
+			header, count, err := snapshotStorage(context.Background(), snapshotConf, snapshotPath)
+			if err != nil {
+				fmt.Println("FAIL:", err)
+				os.Exit(1)
+			}
+			fmt.Printf("OK: snapshot of pulse %d saved, %d keys\n", header.Pulse, count)
+		},
+	}
+	snapshotCmd.Flags().StringVarP(&snapshotConf.Backend, "backend", "", storage.BackendBadger, "storage backend")
+	snapshotCmd.Flags().StringVarP(&snapshotConf.DataDirectory, "data", "", "", "data directory of storage")
+	snapshotCmd.Flags().StringVarP(&snapshotPath, "snapshot", "s", "", "path to snapshot file")
+	storageCmd.AddCommand(snapshotCmd)
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "seed empty ledger storage with snapshot",
+		Run: func(cmd *cobra.Command, args []string) {
+			header, count, err := restoreStorage(context.Background(), snapshotConf, snapshotPath, batchSize)
+			if err != nil {
+				fmt.Println("FAIL:", err)
+				os.Exit(1)
+			}
+			fmt.Printf("OK: snapshot of pulse %d restored, %d keys\n", header.Pulse, count)
+		},
+	}
+	restoreCmd.Flags().StringVarP(&snapshotConf.Backend, "backend", "", storage.BackendBadger, "storage backend")
+	restoreCmd.Flags().StringVarP(&snapshotConf.DataDirectory, "data", "", "", "data directory of storage")
+	restoreCmd.Flags().StringVarP(&snapshotPath, "snapshot", "s", "", "path to snapshot file")
+	restoreCmd.Flags().IntVarP(&batchSize, "batch", "", 4<<20, "max size of transaction in bytes")
+	storageCmd.AddCommand(restoreCmd)
+
+	var rebuildConf configuration.Storage
+	rebuildOpts := storage.RebuildOptions{ProgressInterval: 10 * time.Second}
+	rebuildCmd := &cobra.Command{
+		Use:   "rebuild-indexes",
+		Short: "rebuild object indexes from records of ledger storage, node should be stopped",
+		Run: func(cmd *cobra.Command, args []string) {
+			stat, err := rebuildIndexes(context.Background(), rebuildConf, rebuildOpts)
+			if err != nil {
+				fmt.Println("FAIL:", err)
+				os.Exit(1)
+			}
+			fmt.Printf("OK: %d indexes of %d objects written, %d records of %d jets scanned\n",
+				stat.Written, stat.Objects, stat.Records, stat.Jets)
+		},
+	}
+	rebuildCmd.Flags().StringVarP(&rebuildConf.Backend, "backend", "", storage.BackendBadger, "storage backend")
+	rebuildCmd.Flags().StringVarP(&rebuildConf.DataDirectory, "data", "", "", "data directory of storage")
+	rebuildCmd.Flags().IntVarP(&rebuildOpts.Workers, "workers", "w", 4, "count of jets processed concurrently")
+	rebuildCmd.Flags().BoolVarP(&rebuildOpts.Force, "force", "", false, "rewrite all indexes, not only missing and corrupt ones")
+	storageCmd.AddCommand(rebuildCmd)
+
+	return storageCmd
+}
+
+// migrateStorage copies all keys of source storage into target storage. Target storage is expected to be empty.
+func migrateStorage(ctx context.Context, from, to configuration.Storage, batchSize int) (int, error) {
+	if from.DataDirectory == "" || to.DataDirectory == "" {
+		return 0, errors.New("source and target data directories are required")
+	}
+	if from.DataDirectory == to.DataDirectory {
+		return 0, errors.New("source and target data directories should differ")
+	}
+
+	src, err := storage.NewKV(from, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't open source storage")
+	}
+	defer src.Close() //nolint: errcheck
+
+	dst, err := storage.NewKV(to, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't open target storage")
+	}
+	count, err := storage.MigrateKV(ctx, src, dst, batchSize)
+	closeErr := dst.Close()
+	if err != nil {
+		return count, err
+	}
+	return count, errors.Wrap(closeErr, "can't close target storage")
+}
+
+// openStorage opens ledger storage in data directory.
+func openStorage(conf configuration.Storage) (storage.DBContext, error) {
+	if conf.DataDirectory == "" {
+		return nil, errors.New("data directory is required")
+	}
+	ledgerConf := configuration.NewLedger()
+	ledgerConf.Storage.Backend = conf.Backend
+	ledgerConf.Storage.DataDirectory = conf.DataDirectory
+	db, err := storage.NewDB(ledgerConf, nil)
+	return db, errors.Wrap(err, "can't open storage")
+}
+
+// snapshotStorage writes snapshot of storage to file.
+func snapshotStorage(
+	ctx context.Context, conf configuration.Storage, path string,
+) (*storage.SnapshotHeader, int, error) {
+	if path == "" {
+		return nil, 0, errors.New("snapshot path is required")
+	}
+	db, err := openStorage(conf)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer db.Close() //nolint: errcheck
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "can't create snapshot file")
+	}
+	header, count, err := storage.WriteSnapshot(ctx, db, file)
+	closeErr := file.Close()
+	if err != nil {
+		os.Remove(path) //nolint: errcheck
+		return nil, 0, err
+	}
+	return header, count, errors.Wrap(closeErr, "can't close snapshot file")
+}
+
+// restoreStorage loads snapshot into empty storage.
+func restoreStorage(
+	ctx context.Context, conf configuration.Storage, path string, batchSize int,
+) (*storage.SnapshotHeader, int, error) {
+	if path == "" {
+		return nil, 0, errors.New("snapshot path is required")
+	}
+	snapshot, err := os.Open(path)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "can't open snapshot")
+	}
+	defer snapshot.Close() //nolint: errcheck
+
+	db, err := openStorage(conf)
+	if err != nil {
+		return nil, 0, err
+	}
+	header, count, err := storage.RestoreSnapshot(ctx, db, snapshot, batchSize)
+	closeErr := db.Close()
+	if err != nil {
+		return nil, count, err
+	}
+	return header, count, errors.Wrap(closeErr, "can't close storage")
+}
+
+// rebuildIndexes rebuilds object indexes of storage from its records.
+func rebuildIndexes(
+	ctx context.Context, conf configuration.Storage, opts storage.RebuildOptions,
+) (*storage.RebuildStat, error) {
+	db, err := openStorage(conf)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := storage.RebuildIndexes(ctx, db, opts)
+	closeErr := db.Close()
+	if err != nil {
+		return stat, err
+	}
+	return stat, errors.Wrap(closeErr, "can't close storage")
+}
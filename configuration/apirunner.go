@@ -22,23 +22,52 @@ import (
 
 // APIRunner holds configuration for api
 type APIRunner struct {
-	Address string
-	Call    string
-	RPC     string
-	Timeout uint32
+	Address      string
+	Call         string
+	RPC          string
+	Inbox        string
+	GraphQL      string
+	DumpUsers    string
+	Contracts    string
+	WaitForPulse string
+	PreCheck     string
+	Explorer     string // path of explorer UI for local development, empty disables it
+	Timeout      uint32 // default timeout of request, seconds
+	MaxTimeout   uint32 // max timeout of request, that client can request, seconds
+
+	PreCheckPulses uint32 // count of pulses pre-check artifact stays valid
+
+	FairOrderingMethods []string // methods executed in submit-then-reveal mode
+	FairOrderingWindow  uint32   // time of collecting revealed requests before execution, ms
+
+	Receipts uint32 // count of recent signed receipts kept for re-fetching by request reference, 0 disables receipts
 }
 
 // NewAPIRunner creates new api config
 func NewAPIRunner() APIRunner {
 	return APIRunner{
-		Address: "localhost:19101",
-		Call:    "/api/call",
-		RPC:     "/api/rpc",
-		Timeout: 15,
+		Address:      "localhost:19101",
+		Call:         "/api/call",
+		RPC:          "/api/rpc",
+		Inbox:        "/api/inbox",
+		GraphQL:      "/api/graphql",
+		DumpUsers:    "/api/dumpusers",
+		Contracts:    "/api/v1/contracts",
+		WaitForPulse: "/api/wait_for_pulse",
+		PreCheck:     "/api/precheck",
+		Timeout:      15,
+		MaxTimeout:   60,
+
+		PreCheckPulses: 2,
+
+		FairOrderingMethods: []string{},
+		FairOrderingWindow:  1000,
+
+		Receipts: 10000,
 	}
 }
 
 func (ar *APIRunner) String() string {
-	res := fmt.Sprintln("Addr ->", ar.Address, ", Call ->", ar.Call, ", RPC ->", ar.RPC)
+	res := fmt.Sprintln("Addr ->", ar.Address, ", Call ->", ar.Call, ", RPC ->", ar.RPC, ", Inbox ->", ar.Inbox, ", GraphQL ->", ar.GraphQL, ", DumpUsers ->", ar.DumpUsers, ", Contracts ->", ar.Contracts, ", WaitForPulse ->", ar.WaitForPulse, ", PreCheck ->", ar.PreCheck, ", Explorer ->", ar.Explorer, ", Timeout ->", ar.Timeout, ", MaxTimeout ->", ar.MaxTimeout)
 	return res
 }
@@ -0,0 +1,41 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+// Canary holds configuration for canary which periodically executes end-to-end transaction through local api.
+type Canary struct {
+	Enabled bool
+	// Interval between transactions, ms
+	Interval int
+	// RootMemberKeys is path to root member keys used for creating temporary members
+	RootMemberKeys string
+	// Amount transferred between temporary members
+	Amount uint
+	// FailureThreshold is count of consecutive failed transactions which raises alert
+	FailureThreshold int
+}
+
+// NewCanary creates new default configuration for canary.
+func NewCanary() Canary {
+	return Canary{
+		Enabled:          false,
+		Interval:         60000,
+		RootMemberKeys:   "",
+		Amount:           1,
+		FailureThreshold: 3,
+	}
+}
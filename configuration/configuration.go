@@ -40,6 +40,8 @@ type Configuration struct {
 	KeysPath        string
 	CertificatePath string
 	Tracer          Tracer
+	Canary          Canary
+	Watchdog        Watchdog
 }
 
 // Holder provides methods to manage configuration
@@ -63,6 +65,8 @@ func NewConfiguration() Configuration {
 		KeysPath:        "./",
 		CertificatePath: "",
 		Tracer:          NewTracer(),
+		Canary:          NewCanary(),
+		Watchdog:        NewWatchdog(),
 	}
 
 	return cfg
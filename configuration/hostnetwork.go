@@ -18,12 +18,67 @@ package configuration
 
 // Transport holds transport protocol configuration for HostNetwork
 type Transport struct {
-	// protocol type
+	// protocol type: TCP, PURE_UDP, QUIC or MEMORY (in-memory network for tests)
 	Protocol string
 	// Address to listen
 	Address string
 	// if true transport will use network traversal technique(like STUN) to get PublicAddress
 	BehindNAT bool
+	// comma separated list of STUN servers which are asked for PublicAddress in order if BehindNAT is true,
+	// empty list means default public STUN server. If none of servers responds listen address is used
+	STUNServers string
+	// if true transport will try to map its port on NAT gateway with UPnP or NAT-PMP and use gateway address as
+	// PublicAddress, falls back to BehindNAT resolving if no gateway found
+	PortMapping bool
+	// comma separated list of compression codecs (flate, snappy, zstd) in order of preference, which large packet
+	// bodies are compressed with. TCP transport offers them on connection handshake, other transports learn codecs
+	// accepted by peer from packet headers. Empty list disables compression
+	Compression string
+	// comma separated list of packet formats (gob, protobuf) in order of preference, formats are negotiated with
+	// each peer in packet headers. Gob is always accepted and used if list is empty
+	PacketFormats string
+	// if true TCP connections are encrypted with TLS 1.3 using certificate made of node keys, QUIC is always
+	// encrypted and uses certificate made of node keys if true, otherwise generated one
+	TLS bool
+	// if true packets are signed with node key (TCP and QUIC), signatures of incoming packets are verified and
+	// unsigned packets from peers that are not active nodes are dropped
+	SignPackets bool
+	// peers are banned when their score falls to BanThreshold, score is lowered on request timeouts (1 point),
+	// malformed packets (10 points) and failed authorization (25 points) and recovers by 1 point a minute
+	BanThreshold int
+	// ms, duration of peer ban, 0 disables banning. Disabled by default, because peers are scored by IP and
+	// nodes sharing an address, e.g. on loopback, would be banned together
+	BanDuration int32
+	// comma separated list of CIDRs, IPs and node references of peers that are allowed to connect, empty list
+	// allows all peers. Addresses are checked before packets are deserialized, references after
+	AllowList string
+	// comma separated list of CIDRs, IPs and node references of peers whose packets are dropped
+	DenyList string
+	// ms, requests are remembered by sender and request id for one to two windows to drop duplicated and replayed
+	// packets, should be about pulse length. 0 disables duplicate suppression
+	ReplayWindow int32
+	// max count of TCP connections opened to one peer, extra connection is opened when all are busy writing
+	MaxConnectionsPerPeer int
+	// ms, outgoing TCP connection unused for this duration is closed, 0 keeps connections open
+	ConnectionIdleTimeout int32
+	// ms, period of TCP keep-alive probes of outgoing connections, 0 means system default
+	KeepAlivePeriod int32
+	// if true and Address host is empty or 0.0.0.0 transport listens on both IPv4 and IPv6 interfaces
+	DualStack bool
+	// name of in-memory network registered with transport.RegisterMemoryNetwork, used if Protocol is MEMORY
+	MemoryNetwork string
+	// count of workers sending queued packets, packets of priority classes (consensus, pulse, rpc, replication)
+	// are taken from queue by weighted round robin. Consensus transport sends packets through the same queue.
+	// 0 disables send queue, packets are sent by callers
+	SendWorkers int
+	// max count of queued packets of each priority class
+	SendQueueSize int
+	// comma separated list of class=weight pairs, weights of classes not listed are consensus=8, pulse=4, rpc=2,
+	// replication=1
+	SendQueueWeights string
+	// comma separated list of class=policy pairs, policy is newest (new packet is dropped when queue of class is
+	// full) or oldest (the oldest queued packet is dropped). Consensus and pulse drop oldest, others newest by default
+	SendQueueDropPolicies string
 }
 
 // HostNetwork holds configuration for HostNetwork
@@ -36,12 +91,70 @@ type HostNetwork struct {
 	TimeoutMult         int   // bootstrap timout multiplier
 	SignMessages        bool  // signing a messages if true
 	HandshakeSessionTTL int32 // ms
+
+	BootstrapJitter        float64 // fraction of bootstrap retry timeout that is randomized, from 0 to 1
+	BootstrapMaxAttempts   int     // max count of bootstrap attempts to one host if InfinityBootstrap, 0 for no limit
+	BootstrapAbortOnCancel bool    // stop bootstrap retries when bootstrap is canceled
+
+	// if true all discovery nodes are pinged before bootstrap and the one with least weighted RTT is tried first
+	BootstrapProbeRTT bool
+	// RTT multiplier of discovery nodes in the same /24 (IPv4) or /64 (IPv6) subnet as origin
+	BootstrapSameSubnetWeight float64
+	// RTT multiplier of discovery nodes in BootstrapRegionSubnets
+	BootstrapSameRegionWeight float64
+	// comma separated list of CIDRs of discovery nodes in the same region as origin
+	BootstrapRegionSubnets string
+
+	// max count of joiners discovery node accepts during one pulse, others are redirected to the least loaded
+	// discovery node, 0 for no limit
+	BootstrapJoinerQuota int
+	// max count of redirects joiner follows before bootstrap to discovery node fails
+	BootstrapMaxRedirects int
+
+	PeerExchangeInterval   int32 // ms, 0 disables peer exchange
+	PeerExchangeSampleSize int   // max count of nodes in one peer exchange packet
+	PeerExchangeFanout     int   // count of random peers to share sample with
+
+	// file where active nodes are saved on shutdown and routing table is seeded from on startup, empty disables
+	RoutingTablePath string
+	// comma separated list of references of nodes that forward requests of current globe to nodes of other globes
+	GlobeGateways string
+	// max count of hosts of other globes cached in routing table, 0 for no limit
+	RemoteHostsCacheSize int
+	// comma separated list of addresses node is also reachable at (internal, external, relay) in order of
+	// preference, they are advertised after public address of transport and tried when it is unreachable
+	AdvertisedAddresses string
+
+	// reference of standby node that is allowed to replicate state of this discovery node, empty disables
+	StandbyNode string
+	// address of primary discovery node, if set node runs as standby and replicates its state
+	StandbyPrimary string
+	// ms, period of standby state replication
+	StandbySyncInterval int32
+	// keys file of primary discovery node, loaded on promotion to sign discovery challenges on behalf of primary
+	StandbyPrimaryKeysPath string
+
+	GossipInterval int32 // ms, 0 disables gossip of active node digests
+	GossipFanout   int   // count of random peers to exchange digest with in one round
+	GossipQuorum   int   // count of peers that must confirm active list before it is applied
+
+	AntiEntropyPulses int // count of pulses between reconciliations of active list with random peer, 0 disables
+
+	CascadeAckTimeout int32 // ms, time to wait for delivery acknowledgement per cascade layer
+	CascadeRetries    int   // count of re-sends to substitute nodes when cascade delivery is not confirmed
+
+	CascadeMinReplicationFactor uint    // replication factor of cascades in small networks
+	CascadeMaxReplicationFactor uint    // upper bound of replication factor chosen for large networks and high loss rate
+	CascadeMaxDepth             int     // count of cascade layers replication factor is raised to keep within
+	CascadeLossThreshold        float64 // loss rate of cascade delivery above which replication factor is raised
 }
 
 // NewHostNetwork creates new default HostNetwork configuration
 func NewHostNetwork() HostNetwork {
 	// IP address should not be 0.0.0.0!!!
-	transport := Transport{Protocol: "TCP", Address: "127.0.0.1:0", BehindNAT: false}
+	transport := Transport{Protocol: "TCP", Address: "127.0.0.1:0", BehindNAT: false, BanThreshold: -100, BanDuration: 0,
+		ReplayWindow: 10000, MaxConnectionsPerPeer: 4, ConnectionIdleTimeout: 120000, KeepAlivePeriod: 15000,
+		PacketFormats: "protobuf", SendQueueSize: 1024}
 
 	return HostNetwork{
 		Transport:           transport,
@@ -52,5 +165,36 @@ func NewHostNetwork() HostNetwork {
 		InfinityBootstrap:   false,
 		SignMessages:        false,
 		HandshakeSessionTTL: 5000,
+
+		BootstrapJitter:        0.5,
+		BootstrapAbortOnCancel: true,
+
+		BootstrapProbeRTT:         true,
+		BootstrapSameSubnetWeight: 0.5,
+		BootstrapSameRegionWeight: 0.7,
+
+		BootstrapMaxRedirects: 3,
+
+		PeerExchangeInterval:   5000,
+		PeerExchangeSampleSize: 16,
+		PeerExchangeFanout:     2,
+
+		StandbySyncInterval: 1000,
+
+		RemoteHostsCacheSize: 1024,
+
+		GossipInterval: 5000,
+		GossipFanout:   3,
+		GossipQuorum:   2,
+
+		AntiEntropyPulses: 10,
+
+		CascadeAckTimeout: 3000,
+		CascadeRetries:    1,
+
+		CascadeMinReplicationFactor: 2,
+		CascadeMaxReplicationFactor: 8,
+		CascadeMaxDepth:             4,
+		CascadeLossThreshold:        0.05,
 	}
 }
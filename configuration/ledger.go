@@ -22,11 +22,72 @@ import (
 
 // Storage configures Ledger's storage.
 type Storage struct {
+	// Backend is key-value store storage is built on: "badger" (tuned for write throughput) or "bolt" (every
+	// commit is synced to disk). Use "insolard storage migrate" to switch backend of existing storage.
+	Backend string
 	// DataDirectory is a directory where database's files live.
 	DataDirectory string
 	// TxRetriesOnConflict defines how many retries on transaction conflicts
 	// storage update methods should do.
 	TxRetriesOnConflict int
+	// WriteBatch configures grouping of concurrent writes into shared transactions.
+	WriteBatch WriteBatch
+	// WAL configures write-ahead log of storage commits.
+	WAL WAL
+	// Compression configures compression of stored records.
+	Compression Compression
+	// StatsInterval is interval of counting keys of storage scopes for metrics, zero disables counting.
+	StatsInterval time.Duration
+	// RebuildIndexes configures rebuilding of object indexes from records on start.
+	RebuildIndexes RebuildIndexes
+}
+
+// RebuildIndexes configures rebuilding of object lifeline indexes from records. Jets are scanned by parallel
+// workers, indexes of objects activated on other nodes are left as is.
+type RebuildIndexes struct {
+	// OnStart enables rebuilding on node start.
+	OnStart bool
+	// Workers is count of jets processed concurrently.
+	Workers int
+	// Force rewrites all indexes, otherwise only missing and corrupt indexes are written.
+	Force bool
+	// ProgressInterval is interval of progress logging, zero disables it.
+	ProgressInterval time.Duration
+}
+
+// Compression configures zstd compression of records in ledger storage. Dictionaries are trained per record
+// type on the first records node stores. Compressed records stay readable when compression is disabled.
+type Compression struct {
+	// Enabled turns on compression of new records.
+	Enabled bool
+	// Level is zstd compression level.
+	Level int
+	// DictionarySize is max size of dictionary in bytes, zero disables dictionaries.
+	DictionarySize int
+	// TrainSamples is count of records of type dictionary is trained on.
+	TrainSamples int
+	// MinSize is min size of serialized record in bytes to compress.
+	MinSize int
+}
+
+// WAL configures write-ahead log of ledger storage. Commits are appended to the log and synced before they are
+// applied to KV store, and replayed on start if KV store lost them on unclean shutdown, e.g. when BadgerDB runs
+// without synchronous writes. Write batching is not used when WAL is enabled.
+type WAL struct {
+	Enabled bool
+	// KeepPulses is count of the latest pulses which log segments are kept, must be at least 1.
+	KeepPulses int
+}
+
+// WriteBatch configures adaptive batching of storage writes.
+type WriteBatch struct {
+	// Enabled turns on batching, otherwise every commit gets its own transaction.
+	Enabled bool
+	// MaxSize is a soft limit of batch size in bytes, reaching it flushes the batch.
+	MaxSize int64
+	// MinDelay and MaxDelay bound the time a batch waits for more writes.
+	// The actual delay follows observed commit latency.
+	MinDelay, MaxDelay time.Duration
 }
 
 // PulseManager holds configuration for PulseManager.
@@ -37,8 +98,29 @@ type PulseManager struct {
 	HeavySyncMessageLimit int
 	// Backoff configures retry backoff algorithm for Heavy Sync
 	HeavyBackoff Backoff
+	// HeavySyncMaxInFlight limits payloads light sends to heavy concurrently over all jets.
+	HeavySyncMaxInFlight int
+	// HeavySyncMaxConcurrentStores limits payloads heavy stores concurrently, extra payloads are rejected
+	// with retryable error. Zero means no limit.
+	HeavySyncMaxConcurrentStores int
 	// SplitThreshold is a drop size threshold in bytes to perform split.
 	SplitThreshold uint64
+	// LightKeepPulses is count of the latest pulses light material keeps data of. Older data is removed once it
+	// is replicated to heavy, objects and pending requests of recent storage are kept. Values less than
+	// LightChainLimit are raised to it, zero means LightChainLimit.
+	LightKeepPulses int
+	// RecordTTL configures how long light material keeps records of completed calls.
+	RecordTTL RecordTTL
+}
+
+// RecordTTL holds count of the latest pulses light material keeps records of a class. Records are removed only
+// after replication to heavy, pending requests are kept. Values less than LightChainLimit are raised to it, zero
+// means LightKeepPulses.
+type RecordTTL struct {
+	// Requests is TTL of request records.
+	Requests int
+	// Results is TTL of result records.
+	Results int
 }
 
 // Backoff configures retry backoff algorithm
@@ -54,6 +136,9 @@ type Backoff struct {
 type RecentStorage struct {
 	// Default TTL is a value of default ttl for redirects
 	DefaultTTL int
+	// MaxObjects is max count of objects in recent storage of jet, least recently used objects without pending
+	// requests are evicted. Zero means no limit.
+	MaxObjects int
 }
 
 // Exporter holds configuration of Exporter
@@ -62,6 +147,46 @@ type Exporter struct {
 	ExportLag uint32
 }
 
+// StateDigest configures comparison of jet states among light material nodes.
+type StateDigest struct {
+	// Enabled turns on sending of jet state digests to validators at the end of pulse.
+	Enabled bool
+	// DisputeThreshold is count of consecutive pulses digests diverge before jet is disputed and resynced from heavy.
+	DisputeThreshold int
+}
+
+// JetCoordinator configures selection of nodes for dynamic roles.
+//
+// IMPORTANT: It should be the same on ALL nodes.
+type JetCoordinator struct {
+	// Strategy is node selection strategy: "entropy", "rendezvous" or "stake".
+	Strategy string
+	// VirtualExecutorCount and MaterialExecutorCount are counts of selected executors, the first one executes.
+	VirtualExecutorCount  int
+	MaterialExecutorCount int
+	// VirtualValidatorCount and MaterialValidatorCount are counts of selected validators.
+	VirtualValidatorCount  int
+	MaterialValidatorCount int
+	// Stakes are weights of nodes by reference for "stake" strategy, nodes without stake have weight 1.
+	Stakes map[string]uint64
+	// RoleCacheSize is max count of cached selections of nodes for roles, 0 disables cache.
+	RoleCacheSize int
+	// HeavyReplicaCount is count of heavy material nodes selected for pulse in failover order, the first one
+	// that is active in network executes.
+	HeavyReplicaCount int
+}
+
+// Snapshot configures snapshots of ledger storage.
+type Snapshot struct {
+	// Directory is where snapshots made through admin API are saved.
+	Directory string
+	// Restore is path to snapshot which seeds empty storage on start instead of genesis. It is ignored if storage
+	// has data, so node can be restarted with the same configuration.
+	Restore string
+	// BatchSize is max size in bytes of keys restored in one transaction.
+	BatchSize int
+}
+
 // Ledger holds configuration for ledger.
 type Ledger struct {
 	// Storage defines storage configuration.
@@ -84,14 +209,61 @@ type Ledger struct {
 
 	// Exporter holds configuration of Exporter
 	Exporter Exporter
+
+	// MaxConcurrentReads limits read requests handled concurrently, others wait in queue. High priority reads
+	// (e.g. issued by validators) bypass the queue. Zero means no limit.
+	MaxConcurrentReads int
+
+	// StateDigest configures comparison of jet states among light material nodes.
+	StateDigest StateDigest
+
+	// CheckJetTreeInvariants enables check of jet tree invariants on every change of tree, change that breaks them
+	// fails instead of being saved. It walks the whole tree, so it is meant for tests and debugging.
+	CheckJetTreeInvariants bool
+
+	// MaxStateSize is maximum size of serialized object state in bytes. Updates with bigger state are rejected
+	// with core.ErrStateTooBig instead of producing huge amend records. Zero means no limit.
+	//
+	// IMPORTANT: It should be the same on ALL nodes.
+	MaxStateSize int
+
+	// JetCoordinator configures selection of nodes for dynamic roles.
+	JetCoordinator JetCoordinator
+
+	// Snapshot configures snapshots of storage.
+	Snapshot Snapshot
 }
 
 // NewLedger creates new default Ledger configuration.
 func NewLedger() Ledger {
 	return Ledger{
 		Storage: Storage{
+			Backend:             "badger",
 			DataDirectory:       "./data",
 			TxRetriesOnConflict: 3,
+			WriteBatch: WriteBatch{
+				Enabled:  true,
+				MaxSize:  4 << 20, // 4Mb
+				MinDelay: 0,
+				MaxDelay: 5 * time.Millisecond,
+			},
+			WAL: WAL{
+				Enabled:    false,
+				KeepPulses: 2,
+			},
+			Compression: Compression{
+				Enabled:        false,
+				Level:          3,
+				DictionarySize: 16 << 10, // 16Kb
+				TrainSamples:   1000,
+				MinSize:        64,
+			},
+			StatsInterval: time.Minute,
+			RebuildIndexes: RebuildIndexes{
+				OnStart:          false,
+				Workers:          4,
+				ProgressInterval: 10 * time.Second,
+			},
 		},
 
 		PulseManager: PulseManager{
@@ -103,11 +275,14 @@ func NewLedger() Ledger {
 				Max:    2 * time.Second,
 				Factor: 2,
 			},
-			SplitThreshold: 10 * 100, // 10 megabytes.
+			HeavySyncMaxInFlight:         4,
+			HeavySyncMaxConcurrentStores: 8,
+			SplitThreshold:               10 * 100, // 10 megabytes.
 		},
 
 		RecentStorage: RecentStorage{
 			DefaultTTL: 10,
+			MaxObjects: 100 * 1000,
 		},
 
 		LightChainLimit: 5, // 5 pulses
@@ -117,5 +292,29 @@ func NewLedger() Ledger {
 		Exporter: Exporter{
 			ExportLag: 40, // 40 seconds
 		},
+
+		MaxConcurrentReads: 256,
+
+		StateDigest: StateDigest{
+			Enabled:          true,
+			DisputeThreshold: 3,
+		},
+
+		MaxStateSize: 1 << 20, // 1Mb
+
+		JetCoordinator: JetCoordinator{
+			Strategy:               "entropy",
+			VirtualExecutorCount:   1,
+			MaterialExecutorCount:  1,
+			VirtualValidatorCount:  3,
+			MaterialValidatorCount: 3,
+			RoleCacheSize:          10000,
+			HeavyReplicaCount:      1,
+		},
+
+		Snapshot: Snapshot{
+			Directory: "./snapshots",
+			BatchSize: 4 << 20, // 4Mb
+		},
 	}
 }
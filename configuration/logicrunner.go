@@ -27,6 +27,9 @@ type LogicRunner struct {
 	BuiltIn *BuiltIn
 	// GoPlugin - configuration of executor based on Go plugins
 	GoPlugin *GoPlugin
+	// ExecutionTrace - allows api requests to record steps of contract calls,
+	// intended for dev mode when all calls are executed by one node
+	ExecutionTrace bool
 }
 
 // BuiltIn configuration, no options at the moment
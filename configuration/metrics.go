@@ -28,6 +28,23 @@ type Metrics struct {
 	// ReportingPeriod defines exporter reporting period
 	// if zero, exporter uses default value (1s)
 	ReportingPeriod time.Duration
+
+	// Providers lists telemetry backends, "prometheus" and "otlp" are supported
+	// if empty, only prometheus endpoint is served
+	Providers []string
+	OTLP      OTLP
+}
+
+// OTLP holds configuration for export of metrics and traces to OpenTelemetry collector.
+type OTLP struct {
+	// Endpoint is base url of collector OTLP/HTTP receiver
+	Endpoint string
+	// Headers are added to every export request, e.g. for authentication
+	Headers map[string]string
+	// ExportInterval defines how often metrics and traces are pushed
+	ExportInterval time.Duration
+	// Traces enables export of sampled spans
+	Traces bool
 }
 
 // NewMetrics creates new default configuration for metrics publishing.
@@ -36,5 +53,11 @@ func NewMetrics() Metrics {
 		ListenAddress: "0.0.0.0:9090",
 		Namespace:     "insolar",
 		ZpagesEnabled: true,
+		Providers:     []string{"prometheus"},
+		OTLP: OTLP{
+			Endpoint:       "http://localhost:4318",
+			ExportInterval: 10 * time.Second,
+			Traces:         true,
+		},
 	}
 }
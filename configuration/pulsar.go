@@ -44,6 +44,17 @@ type Pulsar struct {
 
 	DistributionTransport Transport
 	PulseDistributor      PulseDistributor
+
+	// ContractLimits are distributed with pulses to all nodes
+	ContractLimits ContractLimits
+}
+
+// ContractLimits holds limits applied to smart contracts
+type ContractLimits struct {
+	MaxStateSize       uint32 // bytes
+	MaxChildrenPerCall uint32
+	MaxEventPayload    uint32 // bytes
+	MeteringBudget     uint64
 }
 
 type PulseDistributor struct {
@@ -88,5 +99,11 @@ func NewPulsar() Pulsar {
 			PulseRequestTimeout:       1000,
 			RandomNodesCount:          5,
 		},
+		ContractLimits: ContractLimits{
+			MaxStateSize:       1 << 20,
+			MaxChildrenPerCall: 100,
+			MaxEventPayload:    64 << 10,
+			MeteringBudget:     1000000,
+		},
 	}
 }
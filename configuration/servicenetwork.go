@@ -19,11 +19,19 @@ package configuration
 // ServiceNetwork is configuration for ServiceNetwork.
 type ServiceNetwork struct {
 	Skip int // magic number that indicates what delta after last ignored pulse we should wait
+
+	Rejoin             bool // rejoin network automatically when node is expelled from active list
+	RejoinMissedPulses int  // count of pulses without node in active list after which it is considered expelled
+
+	PartitionDrill bool // allow partition drills isolating node from peers, enable on staging networks only
 }
 
 // NewServiceNetwork creates a new ServiceNetwork configuration.
 func NewServiceNetwork() ServiceNetwork {
 	return ServiceNetwork{
 		Skip: 10,
+
+		Rejoin:             true,
+		RejoinMissedPulses: 2,
 	}
 }
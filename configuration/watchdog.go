@@ -0,0 +1,37 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package configuration
+
+import (
+	"time"
+)
+
+// Watchdog configures detection of stalled worker pools and channels.
+type Watchdog struct {
+	// Interval defines how often probes are sampled, zero disables watchdog
+	Interval time.Duration
+	// StallTimeout is time without progress of probe with pending items after which probe is reported as stalled
+	StallTimeout time.Duration
+}
+
+// NewWatchdog creates new default Watchdog configuration.
+func NewWatchdog() Watchdog {
+	return Watchdog{
+		Interval:     5 * time.Second,
+		StallTimeout: 30 * time.Second,
+	}
+}
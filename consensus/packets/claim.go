@@ -18,7 +18,10 @@
 package packets
 
 import (
+	"bytes"
+
 	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
 )
 
 type ClaimType uint8
@@ -31,6 +34,14 @@ const (
 	TypeNodeBroadcast
 	TypeNodeLeaveClaim
 	TypeChangeNetworkClaim
+	TypeNodeAddressClaim
+)
+
+const (
+	// MaxNodeAddresses is max count of physical addresses node can advertise.
+	MaxNodeAddresses = 3
+	// NodeAddressLength is max length of physical address, it fits IPv6 address with brackets and port.
+	NodeAddressLength = 48
 )
 
 // ChangeNetworkClaim uses to change network state.
@@ -105,6 +116,46 @@ func (nac *NodeAnnounceClaim) Type() ClaimType {
 	return TypeNodeAnnounceClaim
 }
 
+// NodeAddressClaim advertises physical addresses of node in order of preference, addresses are zero padded.
+// Type 8, len == 208.
+type NodeAddressClaim struct {
+	NodeRef   core.RecordRef
+	Addresses [MaxNodeAddresses][NodeAddressLength]byte
+}
+
+// NewNodeAddressClaim creates claim with addresses of node.
+func NewNodeAddressClaim(ref core.RecordRef, addresses []string) (*NodeAddressClaim, error) {
+	if len(addresses) > MaxNodeAddresses {
+		return nil, errors.Errorf("too many addresses: %d, max is %d", len(addresses), MaxNodeAddresses)
+	}
+	claim := &NodeAddressClaim{NodeRef: ref}
+	for i, address := range addresses {
+		if len(address) > NodeAddressLength {
+			return nil, errors.Errorf("address %s is longer than %d bytes", address, NodeAddressLength)
+		}
+		copy(claim.Addresses[i][:], address)
+	}
+	return claim, nil
+}
+
+// GetAddresses returns addresses of node in order of preference.
+func (nadc *NodeAddressClaim) GetAddresses() []string {
+	result := make([]string, 0, MaxNodeAddresses)
+	for _, address := range nadc.Addresses {
+		if n := bytes.IndexByte(address[:], 0); n != 0 {
+			if n < 0 {
+				n = len(address)
+			}
+			result = append(result, string(address[:n]))
+		}
+	}
+	return result
+}
+
+func (nadc *NodeAddressClaim) Type() ClaimType {
+	return TypeNodeAddressClaim
+}
+
 // NodeLeaveClaim can be the only be issued by the node itself and must be the only claim record.
 // Should be executed with the next pulse. Type 1, len == 0.
 type NodeLeaveClaim struct {
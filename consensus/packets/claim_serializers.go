@@ -266,6 +266,33 @@ func (nac *NodeAnnounceClaim) Deserialize(data io.Reader) error {
 	return nil
 }
 
+// Serialize implements interface method
+func (nadc *NodeAddressClaim) Serialize() ([]byte, error) {
+	result := allocateBuffer(256)
+	err := binary.Write(result, defaultByteOrder, nadc.NodeRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeAddressClaim.Serialize ] Can't write NodeRef")
+	}
+	err = binary.Write(result, defaultByteOrder, nadc.Addresses)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeAddressClaim.Serialize ] Can't write Addresses")
+	}
+	return result.Bytes(), nil
+}
+
+// Deserialize implements interface method
+func (nadc *NodeAddressClaim) Deserialize(data io.Reader) error {
+	err := binary.Read(data, defaultByteOrder, &nadc.NodeRef)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeAddressClaim.Deserialize ] Can't read NodeRef")
+	}
+	err = binary.Read(data, defaultByteOrder, &nadc.Addresses)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeAddressClaim.Deserialize ] Can't read Addresses")
+	}
+	return nil
+}
+
 // Deserialize implements interface method
 func (nlc *NodeLeaveClaim) Deserialize(data io.Reader) error {
 	return nil
@@ -331,6 +358,8 @@ func parseReferendumClaim(data []byte) ([]ReferendumClaim, error) {
 			refClaim = &NodeBroadcast{}
 		case TypeNodeLeaveClaim:
 			refClaim = &NodeLeaveClaim{}
+		case TypeNodeAddressClaim:
+			refClaim = &NodeAddressClaim{}
 		default:
 			return nil, errors.Wrap(err, "[ PacketHeader.parseReferendumClaim ] Unsupported claim type.")
 		}
@@ -18,10 +18,12 @@
 package packets
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
 )
 
 func makeNodeBroadCast() *NodeBroadcast {
@@ -101,3 +103,32 @@ func makeNodeAnnounceClaim() *NodeAnnounceClaim {
 func TestNodeAnnounceClaim(t *testing.T) {
 	checkSerializationDeserialization(t, makeNodeAnnounceClaim())
 }
+
+func makeNodeAddressClaim(t *testing.T) *NodeAddressClaim {
+	claim, err := NewNodeAddressClaim(testutils.RandomRef(), []string{"10.0.0.1:13831", "[2001:db8::1]:13831"})
+	require.NoError(t, err)
+	return claim
+}
+
+func TestNodeAddressClaim(t *testing.T) {
+	claim := makeNodeAddressClaim(t)
+	checkSerializationDeserialization(t, claim)
+	require.Equal(t, []string{"10.0.0.1:13831", "[2001:db8::1]:13831"}, claim.GetAddresses())
+
+	data, err := claim.Serialize()
+	require.NoError(t, err)
+	require.Len(t, data, int(getClaimSize(claim)))
+}
+
+func TestNewNodeAddressClaim_Limits(t *testing.T) {
+	_, err := NewNodeAddressClaim(testutils.RandomRef(), []string{"a", "b", "c", "d"})
+	require.Error(t, err)
+	_, err = NewNodeAddressClaim(testutils.RandomRef(), []string{strings.Repeat("a", NodeAddressLength+1)})
+	require.Error(t, err)
+
+	// address of max length is not zero terminated
+	address := strings.Repeat("a", NodeAddressLength)
+	claim, err := NewNodeAddressClaim(testutils.RandomRef(), []string{address})
+	require.NoError(t, err)
+	require.Equal(t, []string{address}, claim.GetAddresses())
+}
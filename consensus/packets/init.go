@@ -52,6 +52,7 @@ func init() {
 	claimSizeMap[TypeNodeBroadcast] = sizeOf(&NodeBroadcast{})
 	claimSizeMap[TypeNodeLeaveClaim] = sizeOf(&NodeLeaveClaim{})
 	claimSizeMap[TypeChangeNetworkClaim] = sizeOf(&NodeLeaveClaim{})
+	claimSizeMap[TypeNodeAddressClaim] = sizeOf(&NodeAddressClaim{})
 
 	voteSizeMap = make(map[VoteType]uint16)
 	voteSizeMap[TypeNodeJoinSupplementaryVote] = sizeOf(&NodeJoinSupplementaryVote{})
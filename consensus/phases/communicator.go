@@ -134,7 +134,9 @@ func (nc *NaiveCommunicator) ExchangePhase1(
 	requestBuilder := nc.ConsensusNetwork.NewRequestBuilder()
 	request := requestBuilder.Type(types.Phase1).Data(packetBuffer).Build()
 
+	record := phaseRecord(ctx)
 	nc.sendRequestToNodes(participants, request)
+	record.PacketsSent += len(participants)
 
 	inslogger.FromContext(ctx).Infof("result len %d", len(result))
 	for {
@@ -150,7 +152,9 @@ func (nc *NaiveCommunicator) ExchangePhase1(
 				if err != nil {
 					log.Errorln(err.Error())
 				}
+				record.PacketsSent++
 			}
+			record.PacketsReceived++
 			result[res.id] = res.packet
 			addresses[res.id] = res.address.String()
 
@@ -177,7 +181,9 @@ func (nc *NaiveCommunicator) ExchangePhase2(ctx context.Context, participants []
 	requestBuilder := nc.ConsensusNetwork.NewRequestBuilder()
 	request := requestBuilder.Type(types.Phase2).Data(packetBuffer).Build()
 
+	record := phaseRecord(ctx)
 	nc.sendRequestToNodes(participants, request)
+	record.PacketsSent += len(participants)
 
 	inslogger.FromContext(ctx).Infof("result len %d", len(result))
 	for {
@@ -193,7 +199,9 @@ func (nc *NaiveCommunicator) ExchangePhase2(ctx context.Context, participants []
 				if err != nil {
 					log.Errorln(err.Error())
 				}
+				record.PacketsSent++
 			}
+			record.PacketsReceived++
 			result[res.id] = res.packet
 
 			if len(result) == len(participants) {
@@ -222,7 +230,9 @@ func (nc *NaiveCommunicator) ExchangePhase3(ctx context.Context, participants []
 	requestBuilder := nc.ConsensusNetwork.NewRequestBuilder()
 	request := requestBuilder.Type(types.Phase3).Data(packetBuffer).Build()
 
+	record := phaseRecord(ctx)
 	nc.sendRequestToNodes(participants, request)
+	record.PacketsSent += len(participants)
 
 	inslogger.FromContext(ctx).Infof("result len %d", len(result))
 	for {
@@ -234,7 +244,9 @@ func (nc *NaiveCommunicator) ExchangePhase3(ctx context.Context, participants []
 				if err != nil {
 					log.Errorln(err.Error())
 				}
+				record.PacketsSent++
 			}
+			record.PacketsReceived++
 			result[res.id] = res.packet
 
 			if len(result) == len(participants) {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/merkle"
@@ -32,6 +33,17 @@ import (
 
 const ConsensusAtPercents = 2.0 / 3.0
 
+// consensusParticipants filters out nodes which don't vote in consensus.
+func consensusParticipants(nodes []core.Node) []core.Node {
+	result := make([]core.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Role().IsConsensusParticipant() {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
 func consensusReached(resultLen, participanstLen int) bool {
 	minParticipants := int(math.Floor(ConsensusAtPercents*float64(participanstLen))) + 1
 
@@ -51,6 +63,7 @@ type firstPhase struct {
 	Communicator Communicator             `inject:""`
 	Cryptography core.CryptographyService `inject:""`
 	NodeKeeper   network.NodeKeeper       `inject:""`
+	Hooks        PhaseHooks               `inject:"subcomponent"`
 	State        *FirstPhaseState
 	UnsyncList   network.UnsyncList
 }
@@ -73,6 +86,9 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 		return nil, errors.Wrap(err, "[ Execute ] Failed to set pulse proof in Phase1Packet.")
 	}
 
+	record := phaseRecord(ctx)
+	record.ClaimQueue = fp.NodeKeeper.GetClaimQueue().Length()
+
 	var success bool
 	if fp.NodeKeeper.NodesJoinedDuringPreviousPulse() {
 		originClaim, err := fp.NodeKeeper.GetOriginClaim()
@@ -91,6 +107,14 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 		}
 		_ = fp.NodeKeeper.GetClaimQueue().Pop()
 	}
+	if fp.Hooks != nil {
+		for _, claim := range fp.Hooks.HookClaims(ctx, pulse) {
+			if !packet.AddClaim(claim) {
+				log.Warn("[ Execute ] Phase1Packet is full, skipping claims of phase hooks")
+				break
+			}
+		}
+	}
 
 	activeNodes := fp.NodeKeeper.GetActiveNodes()
 	err = fp.signPhase1Packet(&packet)
@@ -111,6 +135,9 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 		} else if !signIsCorrect {
 			log.Warn("recieved a bad sign packet: ", err.Error())
 		}
+		if err != nil || !signIsCorrect {
+			record.ProofFailures++
+		}
 		rawProof := packet.GetPulseProof()
 		proofSet[ref] = &merkle.PulseProof{
 			BaseProof: merkle.BaseProof{
@@ -131,7 +158,8 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 
 	fp.UnsyncList.AddClaims(claimMap, addressMap)
 
-	valid, fault := fp.validateProofs(pulseHash, proofSet)
+	valid, fault := fp.validateProofs(ctx, pulse.PulseNumber, pulseHash, proofSet)
+	record.ProofFailures += len(fault)
 
 	return &FirstPhaseState{
 		PulseEntry:  entry,
@@ -167,6 +195,8 @@ func (fp *firstPhase) isSignPhase1PacketRight(packet *packets.Phase1Packet, reco
 }
 
 func (fp *firstPhase) validateProofs(
+	ctx context.Context,
+	pulseNumber core.PulseNumber,
 	pulseHash merkle.OriginHash,
 	proofs map[core.RecordRef]*merkle.PulseProof,
 ) (valid map[core.Node]*merkle.PulseProof, fault map[core.RecordRef]*merkle.PulseProof) {
@@ -174,16 +204,35 @@ func (fp *firstPhase) validateProofs(
 	validProofs := make(map[core.Node]*merkle.PulseProof)
 	faultProofs := make(map[core.RecordRef]*merkle.PulseProof)
 	for nodeID, proof := range proofs {
+		if node := fp.UnsyncList.GetActiveNode(nodeID); node != nil && !node.Role().IsConsensusParticipant() {
+			// followers receive pulses, but their proofs are not part of the globule
+			continue
+		}
 		valid := fp.validateProof(pulseHash, nodeID, proof)
 		if valid {
 			validProofs[fp.UnsyncList.GetActiveNode(nodeID)] = proof
 		} else {
 			faultProofs[nodeID] = proof
+			fp.recordFault(ctx, pulseNumber, nodeID, proof)
 		}
 	}
 	return validProofs, faultProofs
 }
 
+func (fp *firstPhase) recordFault(ctx context.Context, pulseNumber core.PulseNumber, nodeID core.RecordRef, proof *merkle.PulseProof) {
+	evidence := core.FaultEvidence{
+		Node:      nodeID,
+		Pulse:     pulseNumber,
+		Reason:    "invalid pulse proof",
+		StateHash: proof.StateHash,
+		Signature: proof.Signature.Bytes(),
+	}
+	err := fp.NodeKeeper.AddFaultEvidence(ctx, evidence)
+	if err != nil {
+		inslogger.FromContext(ctx).Warnf("[ validateProofs ] failed to record fault of node %s: %s", nodeID, err)
+	}
+}
+
 func (fp *firstPhase) validateProof(pulseHash merkle.OriginHash, nodeID core.RecordRef, proof *merkle.PulseProof) bool {
 	node := fp.UnsyncList.GetActiveNode(nodeID)
 	if node == nil {
@@ -18,11 +18,14 @@
 package phases
 
 import (
+	"bytes"
 	"crypto"
 	"testing"
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	networkMerkle "github.com/insolar/insolar/network/merkle"
 	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/merkle"
@@ -61,6 +64,46 @@ func TestFirstPhase_HandlePulse(t *testing.T) {
 	assert.Equal(t, 1, len(activeNodes))
 }
 
+func TestFirstPhase_validateProofs(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	honest := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "", "")
+	deviant := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "", "")
+	nodes := map[core.RecordRef]core.Node{honest.ID(): honest, deviant.ID(): deviant}
+
+	unsyncList := network.NewUnsyncListMock(t)
+	unsyncList.GetActiveNodeMock.Set(func(p core.RecordRef) core.Node {
+		return nodes[p]
+	})
+	calculator := merkle.NewCalculatorMock(t)
+	calculator.IsValidMock.Set(func(p networkMerkle.Proof, p1 networkMerkle.OriginHash, p2 crypto.PublicKey) bool {
+		return bytes.Equal(p.(*networkMerkle.PulseProof).StateHash, []byte("valid"))
+	})
+	nodeKeeper := network.NewNodeKeeperMock(t)
+	nodeKeeper.AddFaultEvidenceMock.ExpectOnce(ctx, core.FaultEvidence{
+		Node:      deviant.ID(),
+		Pulse:     core.PulseNumber(100),
+		Reason:    "invalid pulse proof",
+		StateHash: []byte("forged"),
+		Signature: []byte("signature"),
+	}).Return(nil)
+
+	fp := &firstPhase{Calculator: calculator, NodeKeeper: nodeKeeper, UnsyncList: unsyncList}
+	proofs := map[core.RecordRef]*networkMerkle.PulseProof{
+		honest.ID(): {StateHash: []byte("valid")},
+		deviant.ID(): {
+			BaseProof: networkMerkle.BaseProof{Signature: core.SignatureFromBytes([]byte("signature"))},
+			StateHash: []byte("forged"),
+		},
+	}
+	valid, fault := fp.validateProofs(ctx, core.PulseNumber(100), nil, proofs)
+
+	assert.Len(t, valid, 1)
+	assert.Contains(t, valid, honest)
+	assert.Len(t, fault, 1)
+	assert.Contains(t, fault, deviant.ID())
+	nodeKeeper.MinimockFinish()
+}
+
 func Test_consensusReached(t *testing.T) {
 	assert.True(t, consensusReached(5, 6))
 	assert.False(t, consensusReached(4, 6))
@@ -0,0 +1,206 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package phases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/log"
+	"github.com/pkg/errors"
+)
+
+// ClaimHook contributes custom claims to phase 1 packet.
+type ClaimHook interface {
+	Claims(ctx context.Context, pulse *core.Pulse) ([]packets.ReferendumClaim, error)
+}
+
+// FirstPhaseHook validates results of phase 1, error fails the phase.
+type FirstPhaseHook interface {
+	ValidateFirstPhase(ctx context.Context, state *FirstPhaseState) error
+}
+
+// SecondPhaseHook validates results of phase 2, error fails the phase.
+type SecondPhaseHook interface {
+	ValidateSecondPhase(ctx context.Context, state *SecondPhaseState) error
+}
+
+// ThirdPhaseHook is notified when phase 3 is finished.
+type ThirdPhaseHook interface {
+	AfterThirdPhase(ctx context.Context, state *SecondPhaseState) error
+}
+
+// PhaseHooks is a registry of extensions of consensus phases contributed by optional components.
+type PhaseHooks interface {
+	// RegisterHook registers hook implementing one or more of ClaimHook, FirstPhaseHook, SecondPhaseHook and
+	// ThirdPhaseHook. Each call of hook is limited by budget, hooks that don't return in time are skipped.
+	RegisterHook(name string, hook interface{}, budget time.Duration) error
+	// HookClaims returns claims of registered claim hooks.
+	HookClaims(ctx context.Context, pulse *core.Pulse) []packets.ReferendumClaim
+}
+
+type registeredHook struct {
+	name   string
+	hook   interface{}
+	budget time.Duration
+}
+
+type hookRegistry struct {
+	lock  sync.RWMutex
+	hooks []registeredHook
+}
+
+func newHookRegistry() *hookRegistry {
+	return &hookRegistry{}
+}
+
+func (r *hookRegistry) RegisterHook(name string, hook interface{}, budget time.Duration) error {
+	switch hook.(type) {
+	case ClaimHook, FirstPhaseHook, SecondPhaseHook, ThirdPhaseHook:
+	default:
+		return errors.Errorf("[ RegisterHook ] hook %s doesn't implement any of phase hook interfaces", name)
+	}
+	if budget <= 0 {
+		return errors.Errorf("[ RegisterHook ] hook %s must have positive time budget", name)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, h := range r.hooks {
+		if h.name == name {
+			return errors.Errorf("[ RegisterHook ] hook %s is already registered", name)
+		}
+	}
+	r.hooks = append(r.hooks, registeredHook{name: name, hook: hook, budget: budget})
+	return nil
+}
+
+func (r *hookRegistry) registered() []registeredHook {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.hooks
+}
+
+// callHooks calls f for every registered hook concurrently, each call is limited by hook budget.
+// Results of hooks that exceeded budget are dropped.
+func (r *hookRegistry) callHooks(ctx context.Context, f func(ctx context.Context, hook interface{}) (bool, error)) []hookResult {
+	hooks := r.registered()
+	results := make([]hookResult, len(hooks))
+	wg := sync.WaitGroup{}
+	wg.Add(len(hooks))
+	for i, h := range hooks {
+		go func(i int, h registeredHook) {
+			defer wg.Done()
+			results[i] = callWithBudget(ctx, h, f)
+		}(i, h)
+	}
+	wg.Wait()
+	return results
+}
+
+type hookResult struct {
+	name   string
+	called bool
+	err    error
+}
+
+func callWithBudget(ctx context.Context, h registeredHook, f func(ctx context.Context, hook interface{}) (bool, error)) hookResult {
+	ctx, cancel := context.WithTimeout(ctx, h.budget)
+	defer cancel()
+
+	done := make(chan hookResult, 1)
+	go func() {
+		called, err := f(ctx, h.hook)
+		done <- hookResult{name: h.name, called: called, err: err}
+	}()
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		log.Warnf("[ callHooks ] hook %s exceeded time budget %v, skipped", h.name, h.budget)
+		return hookResult{name: h.name}
+	}
+}
+
+// HookClaims returns claims of registered claim hooks, claims of failed hooks are skipped.
+func (r *hookRegistry) HookClaims(ctx context.Context, pulse *core.Pulse) []packets.ReferendumClaim {
+	lock := sync.Mutex{}
+	claims := make([]packets.ReferendumClaim, 0)
+	results := r.callHooks(ctx, func(ctx context.Context, hook interface{}) (bool, error) {
+		claimHook, ok := hook.(ClaimHook)
+		if !ok {
+			return false, nil
+		}
+		hookClaims, err := claimHook.Claims(ctx, pulse)
+		if err != nil {
+			return true, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		claims = append(claims, hookClaims...)
+		return true, nil
+	})
+	for _, result := range results {
+		if result.err != nil {
+			log.Warnf("[ HookClaims ] hook %s failed to make claims: %s", result.name, result.err)
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	return claims
+}
+
+// validate calls validation hooks of phase, returns error of the first failed hook.
+func (r *hookRegistry) validate(ctx context.Context, f func(ctx context.Context, hook interface{}) (bool, error)) error {
+	for _, result := range r.callHooks(ctx, f) {
+		if result.err != nil {
+			return errors.Wrapf(result.err, "hook %s failed", result.name)
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) validateFirstPhase(ctx context.Context, state *FirstPhaseState) error {
+	return r.validate(ctx, func(ctx context.Context, hook interface{}) (bool, error) {
+		if h, ok := hook.(FirstPhaseHook); ok {
+			return true, h.ValidateFirstPhase(ctx, state)
+		}
+		return false, nil
+	})
+}
+
+func (r *hookRegistry) validateSecondPhase(ctx context.Context, state *SecondPhaseState) error {
+	return r.validate(ctx, func(ctx context.Context, hook interface{}) (bool, error) {
+		if h, ok := hook.(SecondPhaseHook); ok {
+			return true, h.ValidateSecondPhase(ctx, state)
+		}
+		return false, nil
+	})
+}
+
+func (r *hookRegistry) afterThirdPhase(ctx context.Context, state *SecondPhaseState) error {
+	return r.validate(ctx, func(ctx context.Context, hook interface{}) (bool, error) {
+		if h, ok := hook.(ThirdPhaseHook); ok {
+			return true, h.AfterThirdPhase(ctx, state)
+		}
+		return false, nil
+	})
+}
@@ -0,0 +1,93 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package phases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClaimHook struct {
+	claims []packets.ReferendumClaim
+	delay  time.Duration
+}
+
+func (h *testClaimHook) Claims(ctx context.Context, pulse *core.Pulse) ([]packets.ReferendumClaim, error) {
+	select {
+	case <-time.After(h.delay):
+		return h.claims, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type testValidationHook struct {
+	err error
+}
+
+func (h *testValidationHook) ValidateFirstPhase(ctx context.Context, state *FirstPhaseState) error {
+	return h.err
+}
+
+func TestHookRegistry_RegisterHook(t *testing.T) {
+	registry := newHookRegistry()
+
+	err := registry.RegisterHook("empty", struct{}{}, time.Second)
+	assert.Error(t, err)
+
+	err = registry.RegisterHook("nobudget", &testValidationHook{}, 0)
+	assert.Error(t, err)
+
+	err = registry.RegisterHook("validation", &testValidationHook{}, time.Second)
+	require.NoError(t, err)
+
+	err = registry.RegisterHook("validation", &testValidationHook{}, time.Second)
+	assert.Error(t, err)
+}
+
+func TestHookRegistry_HookClaims(t *testing.T) {
+	registry := newHookRegistry()
+	claim := &packets.NodeLeaveClaim{}
+
+	require.NoError(t, registry.RegisterHook("fast", &testClaimHook{claims: []packets.ReferendumClaim{claim}}, time.Second))
+	require.NoError(t, registry.RegisterHook("slow", &testClaimHook{
+		claims: []packets.ReferendumClaim{claim},
+		delay:  time.Second,
+	}, 10*time.Millisecond))
+	require.NoError(t, registry.RegisterHook("validation", &testValidationHook{}, time.Second))
+
+	start := time.Now()
+	claims := registry.HookClaims(context.Background(), &core.Pulse{})
+	assert.Len(t, claims, 1)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestHookRegistry_ValidateFirstPhase(t *testing.T) {
+	registry := newHookRegistry()
+	require.NoError(t, registry.RegisterHook("ok", &testValidationHook{}, time.Second))
+	assert.NoError(t, registry.validateFirstPhase(context.Background(), &FirstPhaseState{}))
+
+	require.NoError(t, registry.RegisterHook("fail", &testValidationHook{err: errors.New("invalid")}, time.Second))
+	assert.Error(t, registry.validateFirstPhase(context.Background(), &FirstPhaseState{}))
+}
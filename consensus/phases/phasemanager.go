@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
@@ -30,6 +31,9 @@ import (
 
 type PhaseManager interface {
 	OnPulse(ctx context.Context, pulse *core.Pulse) error
+	// Timeline returns timeline of consensus phases executed on pulse, nil if pulse is not among recent ones.
+	Timeline(pulse core.PulseNumber) *core.ConsensusTimeline
+	PhaseHooks
 }
 
 type Phases struct {
@@ -39,11 +43,29 @@ type Phases struct {
 
 	PulseManager core.PulseManager  `inject:""`
 	NodeKeeper   network.NodeKeeper `inject:""`
+
+	hooks    *hookRegistry
+	timeline *timeline
 }
 
 // NewPhaseManager creates and returns a new phase manager.
 func NewPhaseManager() PhaseManager {
-	return &Phases{}
+	return &Phases{hooks: newHookRegistry(), timeline: newTimeline()}
+}
+
+// RegisterHook registers extension of consensus phases.
+func (pm *Phases) RegisterHook(name string, hook interface{}, budget time.Duration) error {
+	return pm.hooks.RegisterHook(name, hook, budget)
+}
+
+// HookClaims returns claims contributed by registered hooks.
+func (pm *Phases) HookClaims(ctx context.Context, pulse *core.Pulse) []packets.ReferendumClaim {
+	return pm.hooks.HookClaims(ctx, pulse)
+}
+
+// Timeline returns timeline of consensus phases executed on pulse.
+func (pm *Phases) Timeline(pulse core.PulseNumber) *core.ConsensusTimeline {
+	return pm.timeline.get(pulse)
 }
 
 // Start starts calculate args on phases.
@@ -61,18 +83,38 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *core.Pulse) error {
 	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.2)
 	defer cancel()
 
-	firstPhaseState, err := pm.FirstPhase.Execute(tctx, pulse)
-	checkError(err)
+	rctx, record := pm.timeline.begin(tctx, phaseFirst)
+	firstPhaseState, err := pm.FirstPhase.Execute(rctx, pulse)
+	pm.timeline.finish(pulse.PulseNumber, record, err)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] Failed to execute first phase")
+	}
+	err = pm.hooks.validateFirstPhase(tctx, firstPhaseState)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] First phase validation failed")
+	}
 
 	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.2)
 	defer cancel()
 
-	secondPhaseState, err := pm.SecondPhase.Execute(tctx, firstPhaseState)
-	checkError(err)
+	rctx, record = pm.timeline.begin(tctx, phaseSecond)
+	secondPhaseState, err := pm.SecondPhase.Execute(rctx, firstPhaseState)
+	pm.timeline.finish(pulse.PulseNumber, record, err)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] Failed to execute second phase")
+	}
+	err = pm.hooks.validateSecondPhase(tctx, secondPhaseState)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] Second phase validation failed")
+	}
 
 	fmt.Println(secondPhaseState) // TODO: remove after use
 
-	checkError(pm.ThirdPhase.Execute(ctx, secondPhaseState))
+	rctx, record = pm.timeline.begin(ctx, phaseThird)
+	err = pm.ThirdPhase.Execute(rctx, secondPhaseState)
+	pm.timeline.finish(pulse.PulseNumber, record, err)
+	checkError(err)
+	checkError(pm.hooks.afterThirdPhase(ctx, secondPhaseState))
 
 	return nil
 }
@@ -79,6 +79,7 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 		return nil, errors.Wrap(err, "[ Execute ] Failed to exchange results.")
 	}
 
+	record := phaseRecord(ctx)
 	nodeProofs := make(map[core.Node]*merkle.GlobuleProof)
 
 	for ref, packet := range packets {
@@ -88,7 +89,13 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 		} else if !signIsCorrect {
 			log.Warn("recieved a bad sign packet: ", err.Error())
 		}
+		if err != nil || !signIsCorrect {
+			record.ProofFailures++
+		}
 		node := state.UnsyncList.GetActiveNode(ref)
+		if !node.Role().IsConsensusParticipant() {
+			continue
+		}
 		proof := &merkle.GlobuleProof{
 			BaseProof: merkle.BaseProof{
 				Signature: core.SignatureFromBytes(packet.GetGlobuleHashSignature()),
@@ -100,12 +107,13 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 		}
 
 		if !sp.Calculator.IsValid(proof, globuleHash, node.PublicKey()) {
+			record.ProofFailures++
 			nodeProofs[node] = proof
 		}
 	}
 
 	// TODO: check
-	if !consensusReached(len(nodeProofs), len(activeNodes)) {
+	if !consensusReached(len(nodeProofs), len(consensusParticipants(activeNodes))) {
 		return nil, errors.New("[ Execute ] Consensus not reached")
 	}
 
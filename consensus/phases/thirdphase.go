@@ -19,10 +19,12 @@ package phases
 
 import (
 	"context"
+	"sort"
 
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/merkle"
 	"github.com/pkg/errors"
 )
 
@@ -35,9 +37,11 @@ func NewThirdPhase() ThirdPhase {
 }
 
 type thirdPhase struct {
-	Cryptography core.CryptographyService `inject:""`
-	Communicator Communicator             `inject:""`
-	NodeKeeper   network.NodeKeeper       `inject:""`
+	Cryptography     core.CryptographyService `inject:""`
+	Communicator     Communicator             `inject:""`
+	NodeKeeper       network.NodeKeeper       `inject:""`
+	Calculator       merkle.Calculator        `inject:""`
+	CloudHashStorage core.CloudHashStorage    `inject:""`
 
 	newActiveNodeList []core.Node
 	// TODO: insert it from somewhere
@@ -63,6 +67,9 @@ func (tp *thirdPhase) Execute(ctx context.Context, state *SecondPhaseState) erro
 
 	for ref, packet := range answers {
 		signed, err := tp.isSignPhase3PacketRight(packet, ref)
+		if err != nil || !signed {
+			phaseRecord(ctx).ProofFailures++
+		}
 		if err != nil {
 			return errors.Wrap(err, "[ Execute ] failed to check a packet sign")
 		} else if !signed {
@@ -83,9 +90,66 @@ func (tp *thirdPhase) Execute(ctx context.Context, state *SecondPhaseState) erro
 		}
 	}
 
+	return tp.saveCloudHash(ctx, state)
+}
+
+// saveCloudHash calculates cloud hash of pulse, makes it previous cloud hash of the next pulse and persists it
+// with globule proofs, so the chain of network state hashes can be queried later.
+func (tp *thirdPhase) saveCloudHash(ctx context.Context, state *SecondPhaseState) error {
+	entry := &merkle.CloudEntry{
+		ProofSet:      []*merkle.GlobuleProof{state.GlobuleProof},
+		PrevCloudHash: state.GlobuleEntry.PrevCloudHash,
+	}
+	cloudHash, _, err := tp.Calculator.GetCloudProof(entry)
+	if err != nil {
+		return errors.Wrap(err, "[ Execute ] failed to calculate cloud hash")
+	}
+	tp.NodeKeeper.SetCloudHash(cloudHash)
+
+	record := core.CloudHashRecord{
+		Pulse:         state.PulseEntry.Pulse.PulseNumber,
+		CloudHash:     cloudHash,
+		PrevCloudHash: entry.PrevCloudHash,
+		GlobuleProofs: []core.GlobuleProofRecord{globuleProofRecord(tp.NodeKeeper.GetOrigin().ID(), state.GlobuleProof)},
+		Entropy:       state.PulseEntry.Pulse.Entropy,
+		GlobuleID:     state.GlobuleEntry.GlobuleID,
+	}
+	for node, proof := range state.GlobuleProofSet {
+		record.GlobuleProofs = append(record.GlobuleProofs, globuleProofRecord(node.ID(), proof))
+	}
+	sort.Slice(record.GlobuleProofs, func(i, j int) bool {
+		return record.GlobuleProofs[i].Node.Compare(record.GlobuleProofs[j].Node) < 0
+	})
+	for node, proof := range state.GlobuleEntry.ProofSet {
+		record.NodeProofs = append(record.NodeProofs, core.PulseProofRecord{
+			Node:      node.ID(),
+			Role:      node.Role(),
+			StateHash: proof.StateHash,
+			Signature: proof.Signature.Bytes(),
+		})
+	}
+	sort.Slice(record.NodeProofs, func(i, j int) bool {
+		return record.NodeProofs[i].Node.Compare(record.NodeProofs[j].Node) < 0
+	})
+
+	err = tp.CloudHashStorage.SaveCloudHash(ctx, record)
+	if err != nil {
+		return errors.Wrap(err, "[ Execute ] failed to save cloud hash")
+	}
 	return nil
 }
 
+func globuleProofRecord(node core.RecordRef, proof *merkle.GlobuleProof) core.GlobuleProofRecord {
+	return core.GlobuleProofRecord{
+		Node:          node,
+		GlobuleID:     proof.GlobuleID,
+		NodeCount:     proof.NodeCount,
+		NodeRoot:      proof.NodeRoot,
+		PrevCloudHash: proof.PrevCloudHash,
+		Signature:     proof.Signature.Bytes(),
+	}
+}
+
 func getNode(ref core.RecordRef, nodes []core.Node) (core.Node, error) {
 	for _, node := range nodes {
 		if ref == node.ID() {
@@ -0,0 +1,106 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/metrics"
+)
+
+const (
+	phaseFirst  = "first"
+	phaseSecond = "second"
+	phaseThird  = "third"
+
+	// timelineDepth is count of recent pulses consensus timelines are kept for
+	timelineDepth = 100
+)
+
+type phaseRecordKey struct{}
+
+// phaseRecord returns record of phase being executed, phases called outside of phase manager get a throwaway record.
+func phaseRecord(ctx context.Context) *core.ConsensusPhaseRecord {
+	if record, ok := ctx.Value(phaseRecordKey{}).(*core.ConsensusPhaseRecord); ok {
+		return record
+	}
+	return &core.ConsensusPhaseRecord{}
+}
+
+// timeline keeps consensus timelines of recent pulses.
+type timeline struct {
+	lock      sync.RWMutex
+	timelines []*core.ConsensusTimeline
+}
+
+func newTimeline() *timeline {
+	return &timeline{}
+}
+
+// begin starts record of phase, the record is filled by phase and communicator through returned context.
+func (t *timeline) begin(ctx context.Context, phase string) (context.Context, *core.ConsensusPhaseRecord) {
+	record := &core.ConsensusPhaseRecord{Phase: phase, Started: time.Now()}
+	return context.WithValue(ctx, phaseRecordKey{}, record), record
+}
+
+// finish completes record of phase, exports it to metrics and appends it to timeline of pulse.
+func (t *timeline) finish(pulse core.PulseNumber, record *core.ConsensusPhaseRecord, err error) {
+	record.Duration = time.Since(record.Started)
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	metrics.ConsensusPhaseTime.WithLabelValues(record.Phase).Observe(record.Duration.Seconds())
+	metrics.ConsensusPacketsSentTotal.WithLabelValues(record.Phase).Add(float64(record.PacketsSent))
+	metrics.ConsensusPacketsReceivedTotal.WithLabelValues(record.Phase).Add(float64(record.PacketsReceived))
+	metrics.ConsensusProofFailuresTotal.WithLabelValues(record.Phase).Add(float64(record.ProofFailures))
+	if record.Phase == phaseFirst {
+		metrics.ConsensusClaimQueue.Set(float64(record.ClaimQueue))
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	last := len(t.timelines) - 1
+	if last < 0 || t.timelines[last].Pulse != pulse {
+		t.timelines = append(t.timelines, &core.ConsensusTimeline{Pulse: pulse})
+		if len(t.timelines) > timelineDepth {
+			t.timelines = t.timelines[1:]
+		}
+		last = len(t.timelines) - 1
+	}
+	t.timelines[last].Phases = append(t.timelines[last].Phases, *record)
+}
+
+// get returns copy of timeline of pulse, nil if pulse is not among recent ones.
+func (t *timeline) get(pulse core.PulseNumber) *core.ConsensusTimeline {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for _, tl := range t.timelines {
+		if tl.Pulse == pulse {
+			result := &core.ConsensusTimeline{Pulse: pulse, Phases: make([]core.ConsensusPhaseRecord, len(tl.Phases))}
+			copy(result.Phases, tl.Phases)
+			return result
+		}
+	}
+	return nil
+}
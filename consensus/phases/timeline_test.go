@@ -0,0 +1,64 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	tl := newTimeline()
+
+	ctx, record := tl.begin(context.Background(), phaseFirst)
+	phaseRecord(ctx).PacketsSent = 3
+	phaseRecord(ctx).ProofFailures++
+	tl.finish(core.FirstPulseNumber, record, nil)
+
+	_, record = tl.begin(context.Background(), phaseSecond)
+	tl.finish(core.FirstPulseNumber, record, errors.New("consensus not reached"))
+
+	timeline := tl.get(core.FirstPulseNumber)
+	require.NotNil(t, timeline)
+	require.Len(t, timeline.Phases, 2)
+	assert.Equal(t, phaseFirst, timeline.Phases[0].Phase)
+	assert.Equal(t, 3, timeline.Phases[0].PacketsSent)
+	assert.Equal(t, 1, timeline.Phases[0].ProofFailures)
+	assert.Empty(t, timeline.Phases[0].Error)
+	assert.Equal(t, "consensus not reached", timeline.Phases[1].Error)
+
+	assert.Nil(t, tl.get(core.FirstPulseNumber+1))
+	// record outside of phase manager is not tracked
+	phaseRecord(context.Background()).PacketsSent++
+	assert.Len(t, tl.get(core.FirstPulseNumber).Phases, 2)
+}
+
+func TestTimeline_Depth(t *testing.T) {
+	tl := newTimeline()
+	for i := 0; i <= timelineDepth; i++ {
+		_, record := tl.begin(context.Background(), phaseFirst)
+		tl.finish(core.PulseNumber(core.FirstPulseNumber+i), record, nil)
+	}
+	assert.Nil(t, tl.get(core.FirstPulseNumber))
+	assert.NotNil(t, tl.get(core.PulseNumber(core.FirstPulseNumber+timelineDepth)))
+}
@@ -61,6 +61,16 @@ func randomUint64() uint64 {
 	return binary.LittleEndian.Uint64(buf)
 }
 
+// forgetResult removes waiter of results with provided sequence.
+func (cr *ContractRequester) forgetResult(seq uint64) {
+	if seq == 0 {
+		return
+	}
+	cr.ResultMutex.Lock()
+	delete(cr.ResultMap, seq)
+	cr.ResultMutex.Unlock()
+}
+
 // SendRequest makes synchronously call to method of contract by its ref without additional information
 func (cr *ContractRequester) SendRequest(ctx context.Context, ref *core.RecordRef, method string, argsIn []interface{}) (core.Reply, error) {
 	ctx, span := instracer.StartSpan(ctx, "SendRequest "+method)
@@ -131,8 +141,8 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 	}
 
 	res, err := mb.Send(ctx, msg, nil)
-
 	if err != nil {
+		cr.forgetResult(seq)
 		return nil, errors.Wrap(err, "couldn't dispatch event")
 	}
 
@@ -164,10 +174,8 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base core.Message,
 			Result:  retReply.Result,
 		}
 	case <-ctx.Done():
-		cr.ResultMutex.Lock()
-		delete(cr.ResultMap, seq)
-		cr.ResultMutex.Unlock()
-		return nil, errors.New("canceled")
+		cr.forgetResult(seq)
+		return nil, errors.Wrap(ctx.Err(), "canceled")
 	}
 
 	return result, nil
@@ -211,6 +219,7 @@ func (cr *ContractRequester) CallConstructor(ctx context.Context, base core.Mess
 
 	res, err := mb.Send(ctx, msg, nil)
 	if err != nil {
+		cr.forgetResult(seq)
 		return nil, errors.Wrap(err, "couldn't save new object as delegate")
 	}
 
@@ -233,12 +242,8 @@ func (cr *ContractRequester) CallConstructor(ctx context.Context, base core.Mess
 		}
 		return &r.Request, nil
 	case <-ctx.Done():
-
-		cr.ResultMutex.Lock()
-		delete(cr.ResultMap, seq)
-		cr.ResultMutex.Unlock()
-
-		return nil, errors.New("canceled")
+		cr.forgetResult(seq)
+		return nil, errors.Wrap(ctx.Err(), "canceled")
 	}
 }
 
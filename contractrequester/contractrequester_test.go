@@ -20,6 +20,7 @@ import (
 	"context"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/insolar/insolar/core/message"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -134,3 +136,56 @@ func TestContractRequester_SendRequest_RouteError(t *testing.T) {
 	result, err := cReq.SendRequest(ctx, &ref, "TestMethod", []interface{}{})
 	require.Nil(t, result)
 }
+
+func TestContractRequester_SendRequest_Timeout(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	ref := testutils.RandomRef()
+
+	mbm := mockMessageBus(t, &reply.RegisterRequest{})
+	cReq, err := New()
+	assert.NoError(t, err)
+	cReq.MessageBus = mbm
+
+	mbm.MustRegisterMock.Return()
+	cReq.Start(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	result, err := cReq.SendRequest(ctx, &ref, "TestMethod", []interface{}{})
+	require.Nil(t, result)
+	require.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+
+	cReq.ResultMutex.Lock()
+	defer cReq.ResultMutex.Unlock()
+	require.Empty(t, cReq.ResultMap)
+}
+
+func TestContractRequester_SendRequest_CanceledSend(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	ref := testutils.RandomRef()
+
+	mbm := testutils.NewMessageBusMock(t)
+	mbm.SendFunc = func(c context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+		select {
+		case <-c.Done():
+			return nil, c.Err()
+		case <-time.After(time.Second):
+			return &reply.RegisterRequest{}, nil
+		}
+	}
+	cReq, err := New()
+	assert.NoError(t, err)
+	cReq.MessageBus = mbm
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	result, err := cReq.SendRequest(ctx, &ref, "TestMethod", []interface{}{})
+	require.Nil(t, result)
+	require.Equal(t, context.Canceled, errors.Cause(err))
+
+	cReq.ResultMutex.Lock()
+	defer cReq.ResultMutex.Unlock()
+	require.Empty(t, cReq.ResultMap)
+}
@@ -16,7 +16,41 @@
 
 package core
 
+import (
+	"context"
+	"time"
+)
+
 // APIRunner
 type APIRunner interface {
 	IsAPIRunner() bool
 }
+
+// AdminLogEntry is record of admin API action signed by operator.
+type AdminLogEntry struct {
+	// Index is position of entry in log starting from 1, it is set by log
+	Index uint64
+	Time  time.Time
+	Pulse PulseNumber
+	// Operator is PEM encoded public key of operator who signed action
+	Operator string
+	// Action is API method, e.g. "banlist.Unban"
+	Action string
+	// Params is JSON of action params covered by signature
+	Params    []byte
+	Seed      []byte
+	Signature []byte
+	// PrevHash is hash of previous entry and Hash is hash of this one, they are set by log. Entries form a chain,
+	// so removed or changed entry breaks hashes of all entries after it.
+	PrevHash []byte
+	Hash     []byte
+}
+
+// AdminLog is append-only persistent log of admin API actions.
+//go:generate minimock -i github.com/insolar/insolar/core.AdminLog -o ../testutils -s _mock.go
+type AdminLog interface {
+	// AppendAdminAction adds entry to the end of log and returns it with index and hashes set.
+	AppendAdminAction(ctx context.Context, entry AdminLogEntry) (*AdminLogEntry, error)
+	// GetAdminActions returns up to limit entries starting from index from.
+	GetAdminActions(ctx context.Context, from uint64, limit int) ([]AdminLogEntry, error)
+}
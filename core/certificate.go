@@ -32,6 +32,8 @@ type Certificate interface {
 
 	GetRootDomainReference() *RecordRef
 	GetDiscoveryNodes() []DiscoveryNode
+	// GetOperatorPublicKeys returns keys of operators allowed to sign admin API actions.
+	GetOperatorPublicKeys() []crypto.PublicKey
 }
 
 //go:generate minimock -i github.com/insolar/insolar/core.DiscoveryNode -o ../testutils -s _mock.go
@@ -18,21 +18,22 @@ package dns
 
 import (
 	"net"
-	"strings"
 )
 
-// GetIPFromDomain returns IP address string from domain.
+// GetIPFromDomain returns IP address string from domain. Domain may contain port, IPv6 address is returned
+// in brackets then.
 func GetIPFromDomain(domain string) (string, error) {
-	woPort := strings.Split(domain, ":")
-	address := woPort[0]
-	var port string
-	if len(woPort) > 1 {
-		port = ":" + woPort[1]
+	address, port, err := net.SplitHostPort(domain)
+	if err != nil {
+		address, port = domain, ""
 	}
 
 	ips, err := net.LookupIP(address)
 	if err != nil {
 		return "", err
 	}
-	return ips[0].String() + port, nil
+	if port == "" {
+		return ips[0].String(), nil
+	}
+	return net.JoinHostPort(ips[0].String(), port), nil
 }
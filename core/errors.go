@@ -27,4 +27,6 @@ var (
 	ErrStateNotAvailable = errors.New("object state is not available")
 	// ErrHotDataTimeout returned when no hot data received for a specific jet
 	ErrHotDataTimeout = errors.New("requests were abandoned due to hot-data timeout")
+	// ErrStateTooBig returned when serialized object state exceeds maximum size.
+	ErrStateTooBig = errors.New("object state is too big")
 )
@@ -27,4 +27,6 @@ type HeavySync interface {
 	Store(ctx context.Context, jet RecordID, pn PulseNumber, kvs []KV) error
 	Stop(ctx context.Context, jet RecordID, pn PulseNumber) error
 	Reset(ctx context.Context, jet RecordID, pn PulseNumber) error
+	Resume(ctx context.Context, jet RecordID, pn PulseNumber) ([]byte, error)
+	Checkpoint(ctx context.Context, jet RecordID, pn PulseNumber, cursor []byte) error
 }
@@ -109,6 +109,69 @@ type JetCoordinator interface {
 	Heavy(ctx context.Context, pulse PulseNumber) (*RecordRef, error)
 }
 
+// RoleSelection describes selection of executors and validators of static role for object or jet on pulse.
+type RoleSelection struct {
+	Role  StaticRole
+	Pulse PulseNumber
+	// Object is empty if selection was requested for jet. Jet is empty for virtual role.
+	Object RecordID
+	Jet    RecordID
+	// Strategy is name of node selection strategy
+	Strategy string
+	Entropy  Entropy
+	// Seed is input of strategy made of pulse entropy and object or jet
+	Seed []byte
+	// Candidates are active nodes of role sorted by reference
+	Candidates []RecordRef
+	Executors  []RecordRef
+	Validators []RecordRef
+}
+
+// RoleInspector explains which nodes handle messages of object or jet, it is meant for debugging.
+type RoleInspector interface {
+	// InspectRoles returns virtual and light material selections for object, or light material selection for
+	// jet, on pulse.
+	InspectRoles(ctx context.Context, id RecordID, pulse PulseNumber) ([]RoleSelection, error)
+}
+
+// SnapshotInfo describes snapshot of ledger storage.
+type SnapshotInfo struct {
+	Path string
+	// Pulse is the latest pulse of snapshot
+	Pulse PulseNumber
+	Keys  int
+	Size  int64
+}
+
+// SnapshotMaker makes snapshots of ledger storage which can seed storage of fresh node.
+type SnapshotMaker interface {
+	// MakeSnapshot saves snapshot with name into snapshot directory of ledger. Snapshot is taken between pulses.
+	MakeSnapshot(ctx context.Context, name string) (*SnapshotInfo, error)
+}
+
+// StorageScopeStats is count and size of keys in scope of ledger storage, e.g. records or blobs.
+type StorageScopeStats struct {
+	Scope string
+	Keys  int64
+	// Size is total size of keys and values in bytes
+	Size int64
+}
+
+// StorageStats is snapshot of ledger storage statistics.
+type StorageStats struct {
+	Scopes []StorageScopeStats
+	// PulseBytesWritten is size of data written since the latest pulse, PrevPulseBytesWritten is size of data
+	// written during previous pulse.
+	PulseBytesWritten     int64
+	PrevPulseBytesWritten int64
+}
+
+// StorageInspector returns statistics of ledger storage.
+type StorageInspector interface {
+	// StorageStats counts keys of storage scopes, so it takes time proportional to storage size.
+	StorageStats(ctx context.Context) (*StorageStats, error)
+}
+
 // ArtifactManager is a high level storage interface.
 //go:generate minimock -i github.com/insolar/insolar/core.ArtifactManager -o ../testutils -s _mock.go
 type ArtifactManager interface {
@@ -153,6 +216,20 @@ type ArtifactManager interface {
 	// During iteration children refs will be fetched from remote source (parent object).
 	GetChildren(ctx context.Context, parent RecordRef, pulse *PulseNumber) (RefIterator, error)
 
+	// GetChildrenPage returns one page of object children starting from provided child record.
+	//
+	// Children are fetched from the latest one if from is nil. Child record to start the next page from is returned
+	// if there are more children, so large lists can be paged without holding an iterator between calls.
+	GetChildrenPage(ctx context.Context, parent RecordRef, from *RecordID, amount int) ([]RecordRef, *RecordID, error)
+
+	// GetObjectsByPrototype returns page of objects of provided prototype ordered by reference.
+	//
+	// Objects are fetched from heavy material node index. Page starts after from reference if it is not nil. Next
+	// page reference is returned if there are more objects.
+	GetObjectsByPrototype(
+		ctx context.Context, prototype RecordRef, from *RecordRef, amount int,
+	) ([]RecordRef, *RecordRef, error)
+
 	// DeclareType creates new type record in storage.
 	//
 	// Type is a contract interface. It contains one method signature.
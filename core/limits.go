@@ -0,0 +1,54 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package core
+
+// ContractLimitsVersion is a version of ContractLimits structure.
+const ContractLimitsVersion = 1
+
+// ContractLimits holds limits applied to smart contracts. Limits are set by pulsars and are distributed with pulses,
+// so they can be changed network-wide without rebuilding contracts.
+type ContractLimits struct {
+	Version uint32
+
+	// MaxStateSize is max size of contract memory in bytes
+	MaxStateSize uint32
+	// MaxChildrenPerCall is max count of objects that can be created by single call
+	MaxChildrenPerCall uint32
+	// MaxEventPayload is max size of payload of contract event in bytes
+	MaxEventPayload uint32
+	// MeteringBudget is default budget of single call
+	MeteringBudget uint64
+}
+
+// DefaultContractLimits returns limits used when pulse carries no limits.
+func DefaultContractLimits() ContractLimits {
+	return ContractLimits{
+		Version:            ContractLimitsVersion,
+		MaxStateSize:       1 << 20,
+		MaxChildrenPerCall: 100,
+		MaxEventPayload:    64 << 10,
+		MeteringBudget:     1000000,
+	}
+}
+
+// GetContractLimits returns contract limits distributed with pulse or default limits.
+func (p *Pulse) GetContractLimits() ContractLimits {
+	if p.ContractLimits == nil {
+		return DefaultContractLimits()
+	}
+	return *p.ContractLimits
+}
@@ -26,6 +26,8 @@ type HeavyPayload struct {
 	JetID    core.RecordID
 	PulseNum core.PulseNumber
 	Records  []core.KV
+	// Cursor is position of light replica iterator after Records, heavy saves it as replication checkpoint.
+	Cursor []byte
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -58,6 +60,8 @@ type HeavyStartStop struct {
 	JetID    core.RecordID
 	PulseNum core.PulseNumber
 	Finished bool
+	// Resume asks heavy to continue replication of pulse from the last saved checkpoint.
+	Resume bool
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -373,6 +373,36 @@ func (*ValidationCheck) Type() core.MessageType {
 	return core.TypeValidationCheck
 }
 
+// JetStateDigest carries digest of latest object states in jet, calculated by light executor at the end of pulse.
+type JetStateDigest struct {
+	ledgerMessage
+
+	JetID       core.RecordID
+	PulseNumber core.PulseNumber
+	Digest      []byte
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *JetStateDigest) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	// This check is not needed, because JetStateDigest sender is explicitly checked in handler.
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*JetStateDigest) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleLightValidator
+}
+
+// DefaultTarget returns of target of this event.
+func (m *JetStateDigest) DefaultTarget() *core.RecordRef {
+	return core.NewRecordRef(core.RecordID{}, m.JetID)
+}
+
+// Type implementation of Message interface.
+func (*JetStateDigest) Type() core.MessageType {
+	return core.TypeJetStateDigest
+}
+
 // HotData contains hot-data
 type HotData struct {
 	ledgerMessage
@@ -519,3 +549,32 @@ func (*GetRequest) DefaultRole() core.DynamicRole {
 func (m *GetRequest) DefaultTarget() *core.RecordRef {
 	return core.NewRecordRef(core.DomainID, m.Request)
 }
+
+// GetObjectsByPrototype fetches objects of prototype from heavy material node index.
+type GetObjectsByPrototype struct {
+	ledgerMessage
+
+	Prototype core.RecordRef
+	From      *core.RecordRef
+	Amount    int
+}
+
+// Type implementation of Message interface.
+func (*GetObjectsByPrototype) Type() core.MessageType {
+	return core.TypeGetObjectsByPrototype
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *GetObjectsByPrototype) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*GetObjectsByPrototype) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleHeavyExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *GetObjectsByPrototype) DefaultTarget() *core.RecordRef {
+	return &m.Prototype
+}
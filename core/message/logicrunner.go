@@ -274,6 +274,8 @@ type ExecutorResults struct {
 	Queue                 []ExecutionQueueElement
 	LedgerHasMoreRequests bool
 	Pending               PendingState
+	// ExecutionOrder is a list of requests executed on the object in this pulse, in order of execution
+	ExecutionOrder []core.RecordRef
 }
 
 type ExecutionQueueElement struct {
@@ -312,10 +314,11 @@ func (er *ExecutorResults) GetReference() core.RecordRef {
 }
 
 type ValidateCaseBind struct {
-	Caller    core.RecordRef
-	RecordRef core.RecordRef
-	Requests  []CaseBindRequest
-	Pulse     core.Pulse
+	Caller         core.RecordRef
+	RecordRef      core.RecordRef
+	Requests       []CaseBindRequest
+	Pulse          core.Pulse
+	ExecutionOrder []core.RecordRef
 }
 
 type CaseBindRequest struct {
@@ -456,3 +459,31 @@ func (se *StillExecuting) DefaultTarget() *core.RecordRef {
 func (se *StillExecuting) Type() core.MessageType {
 	return core.TypeStillExecuting
 }
+
+// StateMigration is recorded on ledger as a request when object state was migrated to a new schema version
+type StateMigration struct {
+	Object    core.RecordRef
+	Prototype core.RecordRef
+	From      uint
+	To        uint
+}
+
+func (sm *StateMigration) GetCaller() *core.RecordRef {
+	return &sm.Object
+}
+
+func (sm *StateMigration) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, 0
+}
+
+func (sm *StateMigration) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleVirtualExecutor
+}
+
+func (sm *StateMigration) DefaultTarget() *core.RecordRef {
+	return &sm.Object
+}
+
+func (sm *StateMigration) Type() core.MessageType {
+	return core.TypeStateMigration
+}
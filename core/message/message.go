@@ -51,6 +51,8 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &PendingFinished{}, nil
 	case core.TypeStillExecuting:
 		return &StillExecuting{}, nil
+	case core.TypeStateMigration:
+		return &StateMigration{}, nil
 
 	// Ledger
 	case core.TypeGetCode:
@@ -73,6 +75,8 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &GetObjectIndex{}, nil
 	case core.TypeValidationCheck:
 		return &ValidationCheck{}, nil
+	case core.TypeJetStateDigest:
+		return &JetStateDigest{}, nil
 	case core.TypeGetPendingRequests:
 		return &GetPendingRequests{}, nil
 	case core.TypeGetJet:
@@ -81,6 +85,8 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &AbandonedRequestsNotification{}, nil
 	case core.TypeGetRequest:
 		return &GetRequest{}, nil
+	case core.TypeGetObjectsByPrototype:
+		return &GetObjectsByPrototype{}, nil
 
 	// heavy sync
 	case core.TypeHeavyStartStop:
@@ -201,6 +207,7 @@ func init() {
 	gob.Register(&ValidationResults{})
 	gob.Register(&PendingFinished{})
 	gob.Register(&StillExecuting{})
+	gob.Register(&StateMigration{})
 
 	// Ledger
 	gob.Register(&GetCode{})
@@ -214,6 +221,7 @@ func init() {
 	gob.Register(&SetBlob{})
 	gob.Register(&ValidateRecord{})
 	gob.Register(&ValidationCheck{})
+	gob.Register(&JetStateDigest{})
 	gob.Register(&GetPendingRequests{})
 	gob.Register(&GetJet{})
 	gob.Register(&AbandonedRequestsNotification{})
@@ -40,6 +40,7 @@ type Parcel struct {
 	TraceSpanData []byte
 	Token         core.DelegationToken
 	PulseNumber   core.PulseNumber
+	Priority      core.MessagePriority
 }
 
 // AllowedSenderObjectAndRole implements interface method
@@ -70,6 +71,9 @@ func (sm *Parcel) Message() core.Message {
 // Context returns initialized context with propagated data with ctx as parent.
 func (sm *Parcel) Context(ctx context.Context) context.Context {
 	ctx = inslogger.ContextWithTrace(ctx, sm.LogTraceID)
+	if sm.Priority != core.PriorityNormal {
+		ctx = core.ContextWithPriority(ctx, sm.Priority)
+	}
 	parentspan := instracer.MustDeserialize(sm.TraceSpanData)
 	return instracer.WithParentSpan(ctx, parentspan)
 }
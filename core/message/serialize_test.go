@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 )
@@ -82,3 +83,23 @@ func TestSerializeSignedWithContext(t *testing.T) {
 	require.Equal(t, inslogger.TraceID(ctxIn), inslogger.TraceID(ctxOut))
 	require.Equal(t, instracer.GetBaggage(ctxIn), instracer.GetBaggage(ctxOut))
 }
+
+func TestSerializeSignedWithPriority(t *testing.T) {
+	msg := &GetObject{}
+	spanData := instracer.MustSerialize(context.Background())
+
+	signMsgIn := &Parcel{
+		Msg:           msg,
+		TraceSpanData: spanData,
+		Priority:      core.PriorityHigh,
+	}
+
+	signMsgOut, err := DeserializeParcel(bytes.NewBuffer(ParcelToBytes(signMsgIn)))
+	require.NoError(t, err)
+
+	ctxOut := signMsgOut.Context(context.Background())
+	require.Equal(t, core.PriorityHigh, core.PriorityFromContext(ctxOut))
+
+	ctxOut = (&Parcel{Msg: msg, TraceSpanData: spanData}).Context(context.Background())
+	require.Equal(t, core.PriorityNormal, core.PriorityFromContext(ctxOut))
+}
@@ -198,6 +198,30 @@ func ContextWithMessageBus(ctx context.Context, bus MessageBus) context.Context
 	return context.WithValue(ctx, messageBusKey{}, bus)
 }
 
+// MessagePriority defines how urgently receiver should handle message.
+type MessagePriority uint8
+
+const (
+	// PriorityNormal is priority of regular messages.
+	PriorityNormal MessagePriority = iota
+	// PriorityHigh is priority of messages which must be handled within current pulse, e.g. validator reads.
+	PriorityHigh
+)
+
+type messagePriorityKey struct{}
+
+// ContextWithPriority returns new context with priority of messages sent within it. Priority is propagated
+// with parcels, so handlers of high priority messages inherit it for messages they send.
+func ContextWithPriority(ctx context.Context, priority MessagePriority) context.Context {
+	return context.WithValue(ctx, messagePriorityKey{}, priority)
+}
+
+// PriorityFromContext returns priority of messages sent within context.
+func PriorityFromContext(ctx context.Context) MessagePriority {
+	priority, _ := ctx.Value(messagePriorityKey{}).(MessagePriority)
+	return priority
+}
+
 // MessageHandler is a function for message handling. It should be registered via Register method.
 type MessageHandler func(context.Context, Parcel) (Reply, error)
 
@@ -222,6 +246,8 @@ const (
 	// TypeStillExecuting is sent by an old executor on pulse switch if it wants to continue executing
 	// to the current executor
 	TypeStillExecuting
+	// TypeStateMigration records migration of object state to a new schema version
+	TypeStateMigration
 
 	// Ledger
 
@@ -257,9 +283,13 @@ const (
 	TypeAbandonedRequestsNotification
 	// TypeGetRequest fetches request from ledger.
 	TypeGetRequest
+	// TypeGetObjectsByPrototype fetches objects of prototype from heavy material node.
+	TypeGetObjectsByPrototype
 
 	// TypeValidationCheck checks if validation of a particular record can be performed.
 	TypeValidationCheck
+	// TypeJetStateDigest carries digest of jet state to validators for comparison.
+	TypeJetStateDigest
 
 	// Heavy replication
 
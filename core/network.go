@@ -18,6 +18,7 @@ package core
 
 import (
 	"context"
+	"time"
 )
 
 // Cascade contains routing data for cascade sending
@@ -26,7 +27,8 @@ type Cascade struct {
 	NodeIds []RecordRef
 	// GeneratedEntropy is used for pseudorandom cascade building
 	Entropy Entropy
-	// Replication factor is the number of children nodes of the each node of the cascade
+	// Replication factor is the number of children nodes of the each node of the cascade,
+	// zero means that factor is chosen by network from count of nodes and measured loss rate
 	ReplicationFactor uint
 }
 
@@ -36,7 +38,7 @@ type RemoteProcedure func(ctx context.Context, args [][]byte) ([]byte, error)
 // Network is interface for network modules facade.
 type Network interface {
 	// SendParcel sends a message.
-	SendMessage(nodeID RecordRef, method string, msg Parcel) ([]byte, error)
+	SendMessage(ctx context.Context, nodeID RecordRef, method string, msg Parcel) ([]byte, error)
 	// SendCascadeMessage sends a message.
 	SendCascadeMessage(data Cascade, method string, msg Parcel) error
 	// RemoteProcedureRegister is remote procedure register func.
@@ -49,3 +51,201 @@ type PulseDistributor interface {
 	// Distribute distributes a pulse across the network.
 	Distribute(context.Context, Pulse)
 }
+
+// BannedHost is a peer host temporarily banned by network for misbehaviour.
+type BannedHost struct {
+	// Address is IP of banned host
+	Address string
+	// Score is reputation score of host when it was banned
+	Score int
+	// Until is time ban expires
+	Until time.Time
+}
+
+// HostBanList is interface for management of hosts banned by network.
+type HostBanList interface {
+	// GetBannedHosts returns hosts banned now.
+	GetBannedHosts() []BannedHost
+	// UnbanHost removes host with address from ban list, returns false if host was not banned.
+	UnbanHost(address string) bool
+}
+
+// HostAccessList is interface for management of allow and deny lists of network peers.
+type HostAccessList interface {
+	// GetAccessRules returns rules of allow and deny lists.
+	GetAccessRules() (allow []string, deny []string)
+	// SetAccessRules replaces rules of allow and deny lists, rule is CIDR, IP or node reference.
+	SetAccessRules(allow []string, deny []string) error
+}
+
+// DiscoveryStandby is interface for management of standby discovery node.
+type DiscoveryStandby interface {
+	// PromoteStandby makes standby serve joining nodes on behalf of its failed primary discovery node.
+	PromoteStandby(ctx context.Context) error
+	// IsStandbyPromoted returns true if standby was promoted.
+	IsStandbyPromoted() bool
+}
+
+// PartitionDrillReport is a report of network partition drill.
+type PartitionDrillReport struct {
+	// Peers are nodes the node was isolated from
+	Peers []RecordRef
+	// Pulses is count of pulses isolation lasted
+	Pulses int
+	// Status is one of "isolated", "recovering", "finished" or "aborted"
+	Status string
+	// Started and Finished are times drill was started and finished
+	Started  time.Time
+	Finished time.Time
+	// StartPulse and HealPulse are pulses isolation was started and healed at
+	StartPulse PulseNumber
+	HealPulse  PulseNumber
+	// SafeModeEntered is true if node worked in safe mode during the drill, SafeModePulse is the first such pulse
+	SafeModeEntered bool
+	SafeModePulse   PulseNumber
+	// Recovered is true if node left safe mode after isolation was healed, RecoveryPulse is the first normal pulse
+	Recovered     bool
+	RecoveryPulse PulseNumber
+}
+
+// PartitionDrill is interface for network partition drills that rehearse failure handling on staging networks.
+type PartitionDrill interface {
+	// StartPartitionDrill isolates node from peers for count of pulses and watches safe mode entry and recovery.
+	StartPartitionDrill(ctx context.Context, peers []RecordRef, pulses int) error
+	// StopPartitionDrill heals isolation and aborts running drill.
+	StopPartitionDrill(ctx context.Context) error
+	// GetPartitionDrillReport returns report of the last drill, nil if there were no drills.
+	GetPartitionDrillReport() *PartitionDrillReport
+}
+
+// CapturedPacket is header and truncated payload of packet captured by network for debugging.
+type CapturedPacket struct {
+	// Time is time packet was sent or received
+	Time time.Time
+	// Outgoing is true for sent packets
+	Outgoing bool
+	// Address is address of peer
+	Address string
+	// Sender and Receiver are references of nodes
+	Sender   RecordRef
+	Receiver RecordRef
+	// Type is packet type
+	Type      string
+	RequestID uint64
+	TraceID   string
+	// IsResponse is true for responses
+	IsResponse bool
+	// Payload is text representation of packet data truncated to capture payload size
+	Payload string
+	// Truncated is true if payload was truncated
+	Truncated bool
+}
+
+// PacketCapture is interface for capturing the last packets of each peer to ring buffers, it is used to diagnose
+// misbehaving peers on production hosts.
+type PacketCapture interface {
+	// StartPacketCapture starts capturing up to perPeer last packets of each peer, payloads are truncated to
+	// payloadSize bytes. Packets captured before are discarded.
+	StartPacketCapture(perPeer int, payloadSize int) error
+	// StopPacketCapture stops capturing, captured packets are kept until next start.
+	StopPacketCapture()
+	// GetCapturedPackets returns captured packets of peer with address or node reference ordered by time,
+	// packets of all peers if peer is empty.
+	GetCapturedPackets(peer string) []CapturedPacket
+}
+
+// ConsensusPhaseRecord is a timeline record of consensus phase executed on pulse.
+type ConsensusPhaseRecord struct {
+	// Phase is one of "first", "second" or "third"
+	Phase    string
+	Started  time.Time
+	Duration time.Duration
+	// PacketsSent and PacketsReceived are counts of packets exchanged with participants
+	PacketsSent     int
+	PacketsReceived int
+	// ClaimQueue is depth of claim queue at the beginning of phase
+	ClaimQueue int
+	// ProofFailures is count of proofs and packet signatures that failed validation
+	ProofFailures int
+	// Error is set if phase failed
+	Error string
+}
+
+// ConsensusTimeline is a timeline of consensus phases executed on pulse.
+type ConsensusTimeline struct {
+	Pulse  PulseNumber
+	Phases []ConsensusPhaseRecord
+}
+
+// ConsensusTimelines provides timelines of consensus executed on recent pulses.
+type ConsensusTimelines interface {
+	// GetConsensusTimeline returns timeline of consensus on pulse, nil if pulse is not among recent ones.
+	GetConsensusTimeline(pulse PulseNumber) *ConsensusTimeline
+}
+
+// FaultEvidence is an evidence of node misbehaviour detected during consensus.
+type FaultEvidence struct {
+	// Node is reference of misbehaving node
+	Node RecordRef
+	// Pulse is pulse misbehaviour was detected on
+	Pulse PulseNumber
+	// Reason describes the fault
+	Reason string
+	// StateHash and Signature are the invalid proof sent by node
+	StateHash []byte
+	Signature []byte
+}
+
+// FaultEvidenceStorage is a persistent storage of evidences of node misbehaviour.
+//go:generate minimock -i github.com/insolar/insolar/core.FaultEvidenceStorage -o ../testutils -s _mock.go
+type FaultEvidenceStorage interface {
+	// AddFaultEvidence saves evidence, evidence of the same node on the same pulse is overridden.
+	AddFaultEvidence(ctx context.Context, evidence FaultEvidence) error
+	// GetFaultEvidence returns evidences of node ordered by pulse.
+	GetFaultEvidence(ctx context.Context, node RecordRef) ([]FaultEvidence, error)
+}
+
+// GlobuleProofRecord is a persisted proof of globule signed by node during consensus.
+type GlobuleProofRecord struct {
+	// Node is reference of node that signed the proof
+	Node          RecordRef
+	GlobuleID     GlobuleID
+	NodeCount     uint32
+	NodeRoot      []byte
+	PrevCloudHash []byte
+	Signature     []byte
+}
+
+// PulseProofRecord is a persisted proof of pulse signed by node during consensus.
+type PulseProofRecord struct {
+	Node      RecordRef
+	Role      StaticRole
+	StateHash []byte
+	Signature []byte
+}
+
+// CloudHashRecord is a hash of network state calculated on pulse with globule proofs it was calculated from.
+type CloudHashRecord struct {
+	Pulse         PulseNumber
+	CloudHash     []byte
+	PrevCloudHash []byte
+	// GlobuleProofs are ordered by node reference
+	GlobuleProofs []GlobuleProofRecord
+
+	// Entropy, GlobuleID and NodeProofs are the globule entry of local globule, they allow to build merkle path
+	// from state hash of node to the cloud hash. NodeProofs are ordered by node reference.
+	Entropy    Entropy
+	GlobuleID  GlobuleID
+	NodeProofs []PulseProofRecord
+}
+
+// CloudHashStorage is a persistent storage of the chain of cloud hashes.
+//go:generate minimock -i github.com/insolar/insolar/core.CloudHashStorage -o ../testutils -s _mock.go
+type CloudHashStorage interface {
+	// SaveCloudHash saves cloud hash of pulse, hash of the same pulse is overridden.
+	SaveCloudHash(ctx context.Context, record CloudHashRecord) error
+	// GetCloudHash returns cloud hash of pulse.
+	GetCloudHash(ctx context.Context, pulse PulseNumber) (*CloudHashRecord, error)
+	// GetCloudHashes returns up to limit cloud hashes of pulses in range [from, to] ordered by pulse.
+	GetCloudHashes(ctx context.Context, from, to PulseNumber, limit int) ([]CloudHashRecord, error)
+}
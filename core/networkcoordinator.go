@@ -34,4 +34,45 @@ type NetworkCoordinator interface {
 
 	// IsStarted returns true if component was started and false in other way
 	IsStarted() bool
+
+	// StartUpgrade starts rolling upgrade of nodes, see UpgradeStatus for its progress
+	StartUpgrade(ctx context.Context, nodes []RecordRef, version string, parallel int) (*UpgradeStatus, error)
+	// GetUpgradeStatus returns progress of current rolling upgrade
+	GetUpgradeStatus(ctx context.Context) (*UpgradeStatus, error)
+	// CancelUpgrade stops current rolling upgrade, nodes that are already draining should be rejoined by operator
+	CancelUpgrade(ctx context.Context) error
+}
+
+// UpgradeNodeState is state of node in rolling upgrade.
+type UpgradeNodeState string
+
+const (
+	// UpgradePending means node waits until it can leave network without breaking majority rule and min roles
+	UpgradePending = UpgradeNodeState("pending")
+	// UpgradeDraining means operator can stop node now
+	UpgradeDraining = UpgradeNodeState("draining")
+	// UpgradeOffline means node left active list
+	UpgradeOffline = UpgradeNodeState("offline")
+	// UpgradeDone means node rejoined network
+	UpgradeDone = UpgradeNodeState("done")
+)
+
+// UpgradeNodeStatus is progress of single node in rolling upgrade.
+type UpgradeNodeStatus struct {
+	Node  RecordRef
+	Role  StaticRole
+	State UpgradeNodeState
+	// Pulse is pulse number when node switched to its State
+	Pulse PulseNumber
+}
+
+// UpgradeStatus is progress of rolling upgrade.
+type UpgradeStatus struct {
+	// Version is version of software nodes should rejoin with, empty means any version
+	Version string
+	// Parallel is max count of nodes upgraded at the same time
+	Parallel int
+	Nodes    []UpgradeNodeStatus
+	// Done is true when all nodes are rejoined
+	Done bool
 }
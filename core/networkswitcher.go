@@ -37,11 +37,44 @@ const (
 	CompleteNetworkState
 )
 
+// QuorumStatus is a classification of active node list against majority rule and min roles from certificate
+type QuorumStatus int
+
+//go:generate stringer -type=QuorumStatus
+const (
+	// QuorumUnknown status means that rules were not evaluated yet
+	QuorumUnknown QuorumStatus = iota
+	// QuorumMet status means that active node list satisfies majority rule and min roles
+	QuorumMet
+	// QuorumBelowMajority status means that count of active nodes is less than majority rule
+	QuorumBelowMajority
+	// QuorumBelowMinRoles status means that count of active nodes of some role is less than min roles rule
+	QuorumBelowMinRoles
+	// QuorumSplitGlobes status means that active nodes belong to several globes and none of them satisfies majority rule
+	QuorumSplitGlobes
+)
+
+// QuorumResult is a result of quorum evaluation with details of failure.
+type QuorumResult struct {
+	Status QuorumStatus
+	// Active is count of nodes that were checked against the rule, nodes of largest globe for QuorumSplitGlobes
+	// and nodes of Role for QuorumBelowMinRoles.
+	Active int
+	// Required is count of nodes required by the failed rule.
+	Required int
+	// Role is the role that does not satisfy min roles rule.
+	Role StaticRole
+	// Globes is count of globes active nodes belong to.
+	Globes int
+}
+
 // NetworkSwitcher is a network FSM using for bootstrapping
 //go:generate minimock -i github.com/insolar/insolar/core.NetworkSwitcher -o ../testutils -s _mock.go
 type NetworkSwitcher interface {
 	// GetState method returns current network state
 	GetState() NetworkState
+	// GetQuorum method returns result of last majority rule evaluation
+	GetQuorum() QuorumResult
 	// OnPulse method checks current state and finds out reasons to update this state
 	OnPulse(context.Context, Pulse) error
 }
@@ -38,6 +38,9 @@ type Node interface {
 	PublicKey() crypto.PublicKey
 	// PhysicalAddress is the network address of the node
 	PhysicalAddress() string
+	// PhysicalAddresses are all network addresses the node advertises (internal, external, relay) in order of
+	// preference, the first one is PhysicalAddress
+	PhysicalAddresses() []string
 	// GetGlobuleID returns node current globule id
 	GetGlobuleID() GlobuleID
 	// Version of node software
@@ -24,6 +24,9 @@ const (
 	StaticRoleVirtual
 	StaticRoleHeavyMaterial
 	StaticRoleLightMaterial
+	// StaticRoleFollower is a read-only observer: it keeps a copy of the ledger,
+	// but never takes part in consensus and is never selected for dynamic roles.
+	StaticRoleFollower
 )
 
 // AllStaticRoles is an array of all possible StaticRoles.
@@ -31,6 +34,7 @@ var AllStaticRoles = []StaticRole{
 	StaticRoleVirtual,
 	StaticRoleLightMaterial,
 	StaticRoleHeavyMaterial,
+	StaticRoleFollower,
 }
 
 // GetStaticRoleFromString converts role from string to StaticRole.
@@ -42,6 +46,8 @@ func GetStaticRoleFromString(role string) StaticRole {
 		return StaticRoleHeavyMaterial
 	case "light_material":
 		return StaticRoleLightMaterial
+	case "follower":
+		return StaticRoleFollower
 	}
 
 	return StaticRoleUnknown
@@ -55,7 +61,14 @@ func (nr StaticRole) String() string {
 		return "heavy_material"
 	case StaticRoleLightMaterial:
 		return "light_material"
+	case StaticRoleFollower:
+		return "follower"
 	}
 
 	return "unknown"
 }
+
+// IsConsensusParticipant checks if node with the role votes in consensus.
+func (nr StaticRole) IsConsensusParticipant() bool {
+	return nr != StaticRoleFollower
+}
@@ -0,0 +1,37 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticRole_String(t *testing.T) {
+	for _, role := range AllStaticRoles {
+		require.Equal(t, role, GetStaticRoleFromString(role.String()))
+	}
+	require.Equal(t, StaticRoleUnknown, GetStaticRoleFromString("observer"))
+}
+
+func TestStaticRole_IsConsensusParticipant(t *testing.T) {
+	require.True(t, StaticRoleVirtual.IsConsensusParticipant())
+	require.True(t, StaticRoleLightMaterial.IsConsensusParticipant())
+	require.True(t, StaticRoleHeavyMaterial.IsConsensusParticipant())
+	require.False(t, StaticRoleFollower.IsConsensusParticipant())
+}
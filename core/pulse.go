@@ -74,6 +74,9 @@ type Pulse struct {
 
 	Entropy Entropy
 	Signs   map[string]PulseSenderConfirmation
+
+	// ContractLimits are limits applied to contracts in this pulse, nil means default limits
+	ContractLimits *ContractLimits
 }
 
 // PulseSenderConfirmation contains confirmations of the pulse from other pulsars
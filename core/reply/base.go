@@ -76,6 +76,12 @@ const (
 	TypeHeavyError
 
 	TypeNodeSign
+
+	// TypeHeavyCheckpoint carries heavy replication checkpoint.
+	TypeHeavyCheckpoint
+
+	// TypeObjectsByPrototype contains page of objects of prototype.
+	TypeObjectsByPrototype
 )
 
 // ErrType is used to determine and compare reply errors.
@@ -86,6 +92,7 @@ const (
 	ErrDeactivated = iota + 1
 	ErrStateNotAvailable
 	ErrHotDataTimeout
+	ErrStateTooBig
 )
 
 func getEmptyReply(t core.ReplyType) (core.Reply, error) {
@@ -131,6 +138,10 @@ func getEmptyReply(t core.ReplyType) (core.Reply, error) {
 
 	case TypeNodeSign:
 		return &NodeSign{}, nil
+	case TypeHeavyCheckpoint:
+		return &HeavyCheckpoint{}, nil
+	case TypeObjectsByPrototype:
+		return &ObjectsByPrototype{}, nil
 
 	default:
 		return nil, errors.Errorf("unimplemented reply type: '%d'", t)
@@ -196,4 +207,6 @@ func init() {
 	gob.Register(&NodeSign{})
 	gob.Register(&HasPendingRequests{})
 	gob.Register(&Request{})
+	gob.Register(&HeavyCheckpoint{})
+	gob.Register(&ObjectsByPrototype{})
 }
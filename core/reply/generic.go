@@ -55,6 +55,8 @@ func (e *Error) Error() error {
 		return core.ErrStateNotAvailable
 	case ErrHotDataTimeout:
 		return core.ErrHotDataTimeout
+	case ErrStateTooBig:
+		return core.ErrStateTooBig
 	}
 
 	return core.ErrUnknown
@@ -25,6 +25,8 @@ import (
 // ErrHeavySyncInProgress returned when heavy sync in progress.
 const (
 	ErrHeavySyncInProgress ErrType = iota + 1
+	// ErrHeavyBusy returned when heavy has too many payloads in store.
+	ErrHeavyBusy
 )
 
 // HeavyError carries heavy sync error information.
@@ -52,5 +54,18 @@ func (e *HeavyError) Error() string {
 
 // IsRetryable returns true if retry could be performed.
 func (e *HeavyError) IsRetryable() bool {
-	return e.SubType == ErrHeavySyncInProgress
+	return e.SubType == ErrHeavySyncInProgress || e.SubType == ErrHeavyBusy
+}
+
+// HeavyCheckpoint is returned on resumed heavy sync start and carries position replication continues from.
+type HeavyCheckpoint struct {
+	JetID    core.RecordID
+	PulseNum core.PulseNumber
+	// Cursor is position of light replica iterator saved on heavy, nil if replication starts from the beginning.
+	Cursor []byte
+}
+
+// Type implementation of Reply interface.
+func (*HeavyCheckpoint) Type() core.ReplyType {
+	return TypeHeavyCheckpoint
 }
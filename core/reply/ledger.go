@@ -129,3 +129,14 @@ type Request struct {
 func (r *Request) Type() core.ReplyType {
 	return TypeRequest
 }
+
+// ObjectsByPrototype contains page of objects of prototype.
+type ObjectsByPrototype struct {
+	Refs     []core.RecordRef
+	NextFrom *core.RecordRef
+}
+
+// Type implementation of Reply interface.
+func (r *ObjectsByPrototype) Type() core.ReplyType {
+	return TypeObjectsByPrototype
+}
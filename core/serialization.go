@@ -17,22 +17,19 @@
 package core
 
 import (
+	"github.com/insolar/insolar/utils/pool"
 	"github.com/pkg/errors"
-	"github.com/ugorji/go/codec"
 )
 
 // Serialize serializes interface
 func Serialize(o interface{}) ([]byte, error) {
-	ch := new(codec.CborHandle)
-	var data []byte
-	err := codec.NewEncoderBytes(&data, ch).Encode(o)
+	data, err := pool.MarshalCBOR(nil, o)
 	return data, errors.Wrap(err, "[ Serialize ]")
 }
 
 // Deserialize deserializes data to specific interface
 func Deserialize(data []byte, to interface{}) error {
-	ch := new(codec.CborHandle)
-	err := codec.NewDecoderBytes(data, ch).Decode(&to)
+	err := pool.UnmarshalCBOR(data, &to)
 	return errors.Wrap(err, "[ Deserialize ]")
 }
 
@@ -62,6 +62,38 @@ type LogicRunner interface {
 	OnPulse(context.Context, Pulse) error
 }
 
+// Types of execution trace events
+const (
+	TraceEventCall        = "call"
+	TraceEventConstructor = "constructor"
+	TraceEventRouteCall   = "route_call"
+	TraceEventSaveAs      = "save_as"
+	TraceEventRead        = "read"
+	TraceEventAmend       = "amend"
+	TraceEventActivate    = "activate"
+	TraceEventDeactivate  = "deactivate"
+)
+
+// ExecutionTraceEvent is a step of contract call recorded by ExecutionTracer.
+type ExecutionTraceEvent struct {
+	Type   string    `json:"type"`
+	Caller string    `json:"caller,omitempty"`
+	Object string    `json:"object,omitempty"`
+	Method string    `json:"method,omitempty"`
+	State  string    `json:"state,omitempty"`
+	Time   time.Time `json:"time"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// ExecutionTracer records steps of contract calls made on behalf of traced requests, it's intended for debugging
+// contracts in dev mode, when all calls of request are executed by one node.
+type ExecutionTracer interface {
+	// StartTrace starts recording steps of calls with traceID.
+	StartTrace(traceID string) error
+	// FinishTrace stops recording and returns steps recorded for traceID.
+	FinishTrace(traceID string) []ExecutionTraceEvent
+}
+
 // LogicCallContext is a context of contract execution
 type LogicCallContext struct {
 	Mode            string     // either "execution" or "validation"
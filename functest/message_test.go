@@ -0,0 +1,71 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/require"
+)
+
+func signPayload(t *testing.T, sender *user, payload []byte) []byte {
+	ks := platformpolicy.NewKeyProcessor()
+	privateKey, err := ks.ImportPrivateKeyPEM([]byte(sender.privKey))
+	require.NoError(t, err)
+	signature, err := platformpolicy.NewPlatformCryptographyScheme().Signer(privateKey).Sign(payload)
+	require.NoError(t, err)
+	return signature.Bytes()
+}
+
+func TestSendMessage(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+
+	// payload is opaque for the network, so any bytes fit here
+	payload := []byte("ciphertext")
+	_, err := signedRequest(sender, "SendMessage", recipient.ref, payload, signPayload(t, sender, payload))
+	require.NoError(t, err)
+
+	resp, err := signedRequest(recipient, "GetInbox", 0, 10)
+	require.NoError(t, err)
+	data, err := base64.StdEncoding.DecodeString(resp.(string))
+	require.NoError(t, err)
+
+	var inbox []struct {
+		Sender  string
+		Payload []byte
+	}
+	err = json.Unmarshal(data, &inbox)
+	require.NoError(t, err)
+	require.Len(t, inbox, 1)
+	require.Equal(t, sender.ref, inbox[0].Sender)
+	require.Equal(t, payload, inbox[0].Payload)
+}
+
+func TestSendMessageWrongSignature(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+
+	payload := []byte("ciphertext")
+	_, err := signedRequest(sender, "SendMessage", recipient.ref, payload, signPayload(t, recipient, payload))
+	require.Contains(t, err.Error(), "[ sendMessageCall ] Incorrect message signature")
+}
@@ -183,6 +183,12 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 		log.Debugf("Deployed code %q for contract %q in %q", codeRef.String(), name, cb.root)
 		cb.Codes[name] = codeRef
 
+		// prototype memory holds schema of arguments to validate calls before plugin is loaded
+		schema, err := contracts[name].ArgumentSchema().Serialize()
+		if err != nil {
+			return errors.Wrap(err, "[ Build ] Can't serialize argument schema")
+		}
+
 		// FIXME: It's a temporary fix and should not be here. Ii will NOT work properly on production. Remove it ASAP!
 		_, err = cb.ArtifactManager.ActivatePrototype(
 			ctx,
@@ -190,7 +196,7 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 			*cb.Prototypes[name],
 			*cb.ArtifactManager.GenesisRef(), // FIXME: Only bootstrap can do this!
 			*codeRef,
-			nil,
+			schema,
 		)
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't ActivatePrototype")
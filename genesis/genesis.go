@@ -45,9 +45,11 @@ const (
 	walletContract    = "wallet"
 	memberContract    = "member"
 	allowanceContract = "allowance"
+	messageContract   = "message"
+	bucketContract    = "bucket"
 )
 
-var contractNames = []string{walletContract, memberContract, allowanceContract, rootDomain, nodeDomain, nodeRecord}
+var contractNames = []string{walletContract, memberContract, allowanceContract, rootDomain, nodeDomain, nodeRecord, messageContract, bucketContract}
 
 type messageBusLocker interface {
 	Lock(ctx context.Context)
@@ -0,0 +1,31 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+/*
+Package watchdog detects stalled worker pools and channels.
+
+Components count items of their pools and channels with Counter. Watchdog samples registered counters and reports
+counter as stalled when it has pending items and makes no progress for configured timeout. Stacks of goroutines
+of owning package are dumped to log and stall metrics are exported.
+
+Usage:
+
+	var queue = watchdog.NewCounter("messagebus.deliver", "github.com/insolar/insolar/messagebus")
+
+	queue.Begin()
+	defer queue.Done()
+*/
+package watchdog
@@ -0,0 +1,196 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/metrics"
+)
+
+// Counter tracks items of worker pool or channel, it's safe for concurrent use.
+type Counter struct {
+	name  string
+	owner string
+
+	pending  int64
+	progress uint64
+}
+
+var registry = struct {
+	sync.RWMutex
+	counters map[string]*Counter
+}{counters: make(map[string]*Counter)}
+
+// NewCounter creates counter and registers it in watchdog. Owner is import path of package which goroutines
+// process items, their stacks are dumped when counter is stalled. Counters with the same name are shared.
+func NewCounter(name, owner string) *Counter {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if c, ok := registry.counters[name]; ok {
+		return c
+	}
+	c := &Counter{name: name, owner: owner}
+	registry.counters[name] = c
+	return c
+}
+
+// Begin is called when item is queued or its processing is started.
+func (c *Counter) Begin() {
+	atomic.AddInt64(&c.pending, 1)
+}
+
+// Done is called when item is processed.
+func (c *Counter) Done() {
+	atomic.AddInt64(&c.pending, -1)
+	atomic.AddUint64(&c.progress, 1)
+}
+
+// Pending returns count of items that are not processed yet.
+func (c *Counter) Pending() int64 {
+	return atomic.LoadInt64(&c.pending)
+}
+
+// Progress returns count of processed items.
+func (c *Counter) Progress() uint64 {
+	return atomic.LoadUint64(&c.progress)
+}
+
+type sample struct {
+	progress uint64
+	changed  time.Time
+	stalled  bool
+}
+
+// Watchdog periodically samples registered counters and reports stalled ones.
+type Watchdog struct {
+	cfg     configuration.Watchdog
+	samples map[string]*sample
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWatchdog creates new watchdog.
+func NewWatchdog(cfg configuration.Watchdog) *Watchdog {
+	return &Watchdog{
+		cfg:     cfg,
+		samples: make(map[string]*sample),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start starts sampling of counters, it does nothing if sampling interval is not set.
+func (w *Watchdog) Start(ctx context.Context) error {
+	if w.cfg.Interval <= 0 {
+		return nil
+	}
+	go w.loop(ctx)
+	return nil
+}
+
+// Stop stops sampling of counters.
+func (w *Watchdog) Stop(ctx context.Context) error {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	return nil
+}
+
+func (w *Watchdog) loop(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			w.check(ctx, now)
+		}
+	}
+}
+
+// check samples counters and returns names of counters that became stalled.
+func (w *Watchdog) check(ctx context.Context, now time.Time) []string {
+	registry.RLock()
+	counters := make([]*Counter, 0, len(registry.counters))
+	for _, c := range registry.counters {
+		counters = append(counters, c)
+	}
+	registry.RUnlock()
+	sort.Slice(counters, func(i, j int) bool { return counters[i].name < counters[j].name })
+
+	logger := inslogger.FromContext(ctx)
+	var stalled []string
+	for _, c := range counters {
+		pending, progress := c.Pending(), c.Progress()
+		metrics.WatchdogPending.WithLabelValues(c.name).Set(float64(pending))
+
+		s, ok := w.samples[c.name]
+		if !ok || s.progress != progress || pending <= 0 {
+			if ok && s.stalled {
+				logger.Infof("[ Watchdog ] %s is not stalled anymore", c.name)
+			}
+			w.samples[c.name] = &sample{progress: progress, changed: now}
+			metrics.WatchdogStalled.WithLabelValues(c.name).Set(0)
+			continue
+		}
+		if s.stalled || now.Sub(s.changed) < w.cfg.StallTimeout {
+			continue
+		}
+
+		s.stalled = true
+		stalled = append(stalled, c.name)
+		metrics.WatchdogStalled.WithLabelValues(c.name).Set(1)
+		metrics.WatchdogStallsTotal.WithLabelValues(c.name).Inc()
+		logger.Errorf("[ Watchdog ] %s made no progress for %s with %d pending items, goroutines of %s:\n%s",
+			c.name, now.Sub(s.changed), pending, c.owner, stacks(c.owner))
+	}
+	return stalled
+}
+
+// stacks returns stacks of goroutines that have functions of package in their stack.
+func stacks(pkg string) []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	marker := []byte(pkg + ".")
+	var result bytes.Buffer
+	for _, g := range bytes.Split(buf, []byte("\n\n")) {
+		if bytes.Contains(g, marker) {
+			result.Write(g)
+			result.WriteString("\n\n")
+		}
+	}
+	return result.Bytes()
+}
@@ -0,0 +1,70 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+func TestWatchdog_Check(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.Watchdog{Interval: time.Second, StallTimeout: 10 * time.Second}
+	w := NewWatchdog(cfg)
+	c := NewCounter(t.Name(), "github.com/insolar/insolar/instrumentation/watchdog")
+	idle := NewCounter(t.Name()+".idle", "github.com/insolar/insolar/instrumentation/watchdog")
+	require.Equal(t, c, NewCounter(t.Name(), ""))
+
+	now := time.Now()
+	c.Begin()
+	assert.NotContains(t, w.check(ctx, now), c.name)
+	assert.NotContains(t, w.check(ctx, now.Add(cfg.StallTimeout/2)), c.name)
+
+	stalled := w.check(ctx, now.Add(cfg.StallTimeout))
+	assert.Contains(t, stalled, c.name)
+	assert.NotContains(t, stalled, idle.name)
+	assert.NotContains(t, w.check(ctx, now.Add(2*cfg.StallTimeout)), c.name, "stall is reported once")
+
+	c.Done()
+	assert.Equal(t, int64(0), c.Pending())
+	assert.Equal(t, uint64(1), c.Progress())
+	assert.NotContains(t, w.check(ctx, now.Add(3*cfg.StallTimeout)), c.name)
+	assert.False(t, w.samples[c.name].stalled)
+
+	c.Begin()
+	assert.NotContains(t, w.check(ctx, now.Add(4*cfg.StallTimeout)), c.name)
+	assert.Contains(t, w.check(ctx, now.Add(5*cfg.StallTimeout)), c.name)
+	c.Done()
+}
+
+func TestWatchdog_StartDisabled(t *testing.T) {
+	w := NewWatchdog(configuration.Watchdog{})
+	assert.NoError(t, w.Start(context.Background()))
+	assert.NoError(t, w.Stop(context.Background()))
+	assert.NoError(t, w.Stop(context.Background()))
+}
+
+func TestStacks(t *testing.T) {
+	assert.Contains(t, string(stacks("testing")), "testing.tRunner")
+	assert.Empty(t, stacks("github.com/insolar/insolar/nonexistent"))
+}
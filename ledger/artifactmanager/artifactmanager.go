@@ -48,8 +48,10 @@ type LedgerArtifactManager struct {
 	PulseStorage               core.PulseStorage               `inject:""`
 	JetCoordinator             core.JetCoordinator             `inject:""`
 
-	getChildrenChunkSize int
-	senders              *ledgerArtifactSenders
+	getChildrenChunkSize  int
+	maxStateSize          int
+	senders               *ledgerArtifactSenders
+	archiveCircuitBreaker *circuitBreaker
 }
 
 // State returns hash state for artifact manager.
@@ -58,11 +60,14 @@ func (m *LedgerArtifactManager) State() ([]byte, error) {
 	return m.PlatformCryptographyScheme.IntegrityHasher().Hash([]byte{1, 2, 3}), nil
 }
 
-// NewArtifactManger creates new manager instance.
-func NewArtifactManger() *LedgerArtifactManager {
+// NewArtifactManger creates new manager instance. Objects with memory bigger than maxStateSize are rejected,
+// zero means no limit.
+func NewArtifactManger(maxStateSize int) *LedgerArtifactManager {
 	return &LedgerArtifactManager{
-		getChildrenChunkSize: getChildrenChunkSize,
-		senders:              newLedgerArtifactSenders(),
+		getChildrenChunkSize:  getChildrenChunkSize,
+		maxStateSize:          maxStateSize,
+		senders:               newLedgerArtifactSenders(),
+		archiveCircuitBreaker: newCircuitBreaker(archiveFailureThreshold, archiveOpenTimeout),
 	}
 }
 
@@ -138,7 +143,7 @@ func (m *LedgerArtifactManager) GetCode(
 	sender := BuildSender(
 		bus.Send,
 		m.senders.cachedSender(m.PlatformCryptographyScheme),
-		followRedirectSender(bus),
+		followRedirectSender(bus, m.archiveBreaker()),
 		retryJetSender(currentPulse.PulseNumber, m.JetStorage),
 	)
 
@@ -206,7 +211,7 @@ func (m *LedgerArtifactManager) GetObject(
 	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
 	sender := BuildSender(
 		bus.Send,
-		followRedirectSender(bus),
+		followRedirectSender(bus, m.archiveBreaker()),
 		retryJetSender(currentPulse.PulseNumber, m.JetStorage),
 	)
 
@@ -294,7 +299,7 @@ func (m *LedgerArtifactManager) GetDelegate(
 	}
 
 	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
-	sender := BuildSender(bus.Send, followRedirectSender(bus), retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	sender := BuildSender(bus.Send, followRedirectSender(bus, m.archiveBreaker()), retryJetSender(currentPulse.PulseNumber, m.JetStorage))
 	genericReact, err := sender(ctx, &message.GetDelegate{
 		Head:   head,
 		AsType: asType,
@@ -337,11 +342,97 @@ func (m *LedgerArtifactManager) GetChildren(
 	}
 
 	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
-	sender := BuildSender(bus.Send, followRedirectSender(bus), retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	sender := BuildSender(bus.Send, followRedirectSender(bus, m.archiveBreaker()), retryJetSender(currentPulse.PulseNumber, m.JetStorage))
 	iter, err := NewChildIterator(ctx, sender, parent, pulse, m.getChildrenChunkSize)
 	return iter, err
 }
 
+// GetChildrenPage returns one page of object children starting from provided child record.
+//
+// Children are fetched from the latest one if from is nil. Child record to start the next page from is returned if
+// there are more children.
+func (m *LedgerArtifactManager) GetChildrenPage(
+	ctx context.Context, parent core.RecordRef, from *core.RecordID, amount int,
+) ([]core.RecordRef, *core.RecordID, error) {
+	var err error
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetChildrenPage")
+	instrumenter := instrument(ctx, "GetChildrenPage").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	currentPulse, err := m.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, followRedirectSender(bus, m.archiveBreaker()), retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	genericReply, err := sender(ctx, &message.GetChildren{
+		Parent:    parent,
+		FromChild: from,
+		Amount:    amount,
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch rep := genericReply.(type) {
+	case *reply.Children:
+		return rep.Refs, rep.NextFrom, nil
+	case *reply.Error:
+		err = rep.Error()
+		return nil, nil, err
+	default:
+		err = fmt.Errorf("GetChildrenPage: unexpected reply: %#v", rep)
+		return nil, nil, err
+	}
+}
+
+// GetObjectsByPrototype returns page of objects of provided prototype ordered by reference.
+//
+// Objects are fetched from heavy material node index. Page starts after from reference if it is not nil. Next page
+// reference is returned if there are more objects.
+func (m *LedgerArtifactManager) GetObjectsByPrototype(
+	ctx context.Context, prototype core.RecordRef, from *core.RecordRef, amount int,
+) ([]core.RecordRef, *core.RecordRef, error) {
+	var err error
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetObjectsByPrototype")
+	instrumenter := instrument(ctx, "GetObjectsByPrototype").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	genericReply, err := bus.Send(ctx, &message.GetObjectsByPrototype{
+		Prototype: prototype,
+		From:      from,
+		Amount:    amount,
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch rep := genericReply.(type) {
+	case *reply.ObjectsByPrototype:
+		return rep.Refs, rep.NextFrom, nil
+	case *reply.Error:
+		err = rep.Error()
+		return nil, nil, err
+	default:
+		err = fmt.Errorf("GetObjectsByPrototype: unexpected reply: %#v", rep)
+		return nil, nil, err
+	}
+}
+
 // DeclareType creates new type record in storage.
 //
 // Type is a contract interface. It contains one method signature.
@@ -662,6 +753,9 @@ func (m *LedgerArtifactManager) activateObject(
 	asDelegate bool,
 	memory []byte,
 ) (core.ObjectDescriptor, error) {
+	if err := m.checkStateSize(memory); err != nil {
+		return nil, err
+	}
 	parentDesc, err := m.GetObject(ctx, parent, nil, false)
 	if err != nil {
 		return nil, err
@@ -739,6 +833,9 @@ func (m *LedgerArtifactManager) updateObject(
 	memory []byte,
 ) (core.ObjectDescriptor, error) {
 	inslogger.FromContext(ctx).Debug("LedgerArtifactManager.updateObject starts ...")
+	if err := m.checkStateSize(memory); err != nil {
+		return nil, err
+	}
 	var (
 		image *core.RecordRef
 		err   error
@@ -797,6 +894,14 @@ func (m *LedgerArtifactManager) updateObject(
 	}, nil
 }
 
+// checkStateSize fails with core.ErrStateTooBig without sending anything to ledger if memory exceeds limit.
+func (m *LedgerArtifactManager) checkStateSize(memory []byte) error {
+	if m.maxStateSize > 0 && len(memory) > m.maxStateSize {
+		return errors.Wrapf(core.ErrStateTooBig, "state size %d exceeds limit %d", len(memory), m.maxStateSize)
+	}
+	return nil
+}
+
 func (m *LedgerArtifactManager) setRecord(
 	ctx context.Context,
 	rec record.Record,
@@ -80,8 +80,9 @@ func (m *ledgerArtifactSenders) cachedSender(scheme core.PlatformCryptographySch
 	}
 }
 
-// followRedirectSender is using for redirecting responses with delegation token
-func followRedirectSender(bus core.MessageBus) PreSender {
+// followRedirectSender is using for redirecting responses with delegation token, redirects to heavy are guarded by
+// archive circuit breaker
+func followRedirectSender(bus core.MessageBus, archive *archiveBreaker) PreSender {
 	return func(sender Sender) Sender {
 		return func(ctx context.Context, msg core.Message, options *core.MessageSendOptions) (core.Reply, error) {
 			inslog := inslogger.FromContext(ctx)
@@ -98,10 +99,15 @@ func followRedirectSender(bus core.MessageBus) PreSender {
 				redirected := r.Redirected(msg)
 				inslog.Debugf("redirect reciever=%v", r.GetReceiver())
 
-				rep, err = bus.Send(ctx, redirected, &core.MessageSendOptions{
+				options := &core.MessageSendOptions{
 					Token:    r.GetToken(),
 					Receiver: r.GetReceiver(),
-				})
+				}
+				if archive != nil && archive.isArchive(ctx, r.GetReceiver()) {
+					rep, err = archive.send(ctx, bus.Send, redirected, options)
+				} else {
+					rep, err = bus.Send(ctx, redirected, options)
+				}
 				if err != nil {
 					return nil, err
 				}
@@ -38,6 +38,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/testmessagebus"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -302,6 +303,24 @@ func (s *amSuite) TestLedgerArtifactManager_DeployCode_CreatesCorrectRecord() {
 	})
 }
 
+func (s *amSuite) TestLedgerArtifactManager_UpdateObject_RejectsTooBigState() {
+	ctx := inslogger.TestContext(s.T())
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	am := NewArtifactManger(2)
+	obj := testutils.NewObjectDescriptorMock(mc)
+	obj.IsPrototypeMock.Return(false)
+
+	_, err := am.UpdateObject(ctx, domainRef, *genRandomRef(0), obj, []byte{1, 2, 3})
+	require.Error(s.T(), err)
+	assert.Equal(s.T(), core.ErrStateTooBig, errors.Cause(err))
+
+	_, err = am.ActivateObject(ctx, domainRef, *genRandomRef(0), *genRandomRef(0), *genRandomRef(0), false, []byte{1, 2, 3})
+	require.Error(s.T(), err)
+	assert.Equal(s.T(), core.ErrStateTooBig, errors.Cause(err))
+}
+
 func (s *amSuite) TestLedgerArtifactManager_ActivateObject_CreatesCorrectRecord() {
 	ctx, os, am := getTestData(s)
 	jetID := *jet.NewID(0, nil)
@@ -518,7 +537,7 @@ func (s *amSuite) TestLedgerArtifactManager_GetObject_ReturnsCorrectDescriptors(
 
 func (s *amSuite) TestLedgerArtifactManager_GetObject_FollowsRedirect() {
 	mc := minimock.NewController(s.T())
-	am := NewArtifactManger()
+	am := NewArtifactManger(0)
 	mb := testutils.NewMessageBusMock(mc)
 
 	objRef := genRandomRef(0)
@@ -685,6 +704,18 @@ func (s *amSuite) TestLedgerArtifactManager_GetChildren() {
 		_, err = i.Next()
 		assert.Error(t, err)
 	})
+
+	s.T().Run("returns children page by page", func(t *testing.T) {
+		refs, next, err := am.GetChildrenPage(ctx, *genRefWithID(parentID), nil, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []core.RecordRef{*child3Ref, *child2Ref}, refs)
+		assert.Equal(t, childMeta1, next)
+
+		refs, next, err = am.GetChildrenPage(ctx, *genRefWithID(parentID), next, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []core.RecordRef{*child1Ref}, refs)
+		assert.Nil(t, next)
+	})
 }
 
 func makePulseStorage(s *amSuite) core.PulseStorage {
@@ -699,7 +730,7 @@ func makePulseStorage(s *amSuite) core.PulseStorage {
 
 func (s *amSuite) TestLedgerArtifactManager_GetChildren_FollowsRedirect() {
 	mc := minimock.NewController(s.T())
-	am := NewArtifactManger()
+	am := NewArtifactManger(0)
 	mb := testutils.NewMessageBusMock(mc)
 
 	am.DB = s.db
@@ -893,7 +924,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterRequest_JetMiss() {
 	defer mc.Finish()
 
 	cs := testutils.NewPlatformCryptographyScheme()
-	am := NewArtifactManger()
+	am := NewArtifactManger(0)
 	am.PlatformCryptographyScheme = cs
 	pulseStorageMock := testutils.NewPulseStorageMock(s.T())
 	pulseStorageMock.CurrentFunc = func(ctx context.Context) (*core.Pulse, error) {
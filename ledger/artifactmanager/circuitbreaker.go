@@ -0,0 +1,151 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+const (
+	// archiveFailureThreshold is count of consecutive failed or slow reads from heavy that opens the breaker.
+	archiveFailureThreshold = 5
+	// archiveOpenTimeout is time breaker stays open before single probe read is let through to heavy.
+	archiveOpenTimeout = 10 * time.Second
+	// archiveSlowThreshold is duration after which read from heavy is counted as failed even if it succeeded.
+	archiveSlowThreshold = 5 * time.Second
+)
+
+// circuitBreaker fails calls fast after threshold consecutive failures. When open timeout passes, one probe call
+// is allowed: its success closes the breaker, failure opens it again.
+type circuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	lock      sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, openTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+	}
+}
+
+// allow returns true if call can be made.
+func (b *circuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.openTimeout)
+	}
+}
+
+// archiveBreaker guards reads redirected to heavy executor. Reads served by light executors and cached replies
+// are not affected.
+type archiveBreaker struct {
+	breaker        *circuitBreaker
+	slowThreshold  time.Duration
+	jetCoordinator core.JetCoordinator
+	pulseStorage   core.PulseStorage
+}
+
+// archiveBreaker returns guard of reads from heavy, nil if manager has no circuit breaker.
+func (m *LedgerArtifactManager) archiveBreaker() *archiveBreaker {
+	if m.archiveCircuitBreaker == nil || m.JetCoordinator == nil {
+		return nil
+	}
+	return &archiveBreaker{
+		breaker:        m.archiveCircuitBreaker,
+		slowThreshold:  archiveSlowThreshold,
+		jetCoordinator: m.JetCoordinator,
+		pulseStorage:   m.PulseStorage,
+	}
+}
+
+// isArchive checks if receiver is heavy executor of current pulse.
+func (a *archiveBreaker) isArchive(ctx context.Context, receiver *core.RecordRef) bool {
+	if receiver == nil {
+		return false
+	}
+	pulse, err := a.pulseStorage.Current(ctx)
+	if err != nil {
+		return false
+	}
+	heavy, err := a.jetCoordinator.Heavy(ctx, pulse.PulseNumber)
+	if err != nil {
+		return false
+	}
+	return heavy.Equal(*receiver)
+}
+
+// send sends read to heavy unless breaker is open. Returns ErrArchiveUnavailable if breaker is open.
+func (a *archiveBreaker) send(
+	ctx context.Context, sender Sender, msg core.Message, options *core.MessageSendOptions,
+) (core.Reply, error) {
+	if !a.breaker.allow() {
+		stats.Record(ctx, statArchiveRejected.M(1))
+		return nil, ErrArchiveUnavailable
+	}
+	start := a.breaker.now()
+	rep, err := sender(ctx, msg, options)
+	elapsed := a.breaker.now().Sub(start)
+	if err != nil || elapsed > a.slowThreshold {
+		inslogger.FromContext(ctx).Warnf("read from heavy failed or took %s: %v", elapsed, err)
+		a.breaker.failure()
+	} else {
+		a.breaker.success()
+	}
+	return rep, err
+}
@@ -0,0 +1,111 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(2, time.Second)
+	b.now = func() time.Time { return now }
+
+	require.True(t, b.allow())
+	b.failure()
+	require.True(t, b.allow())
+	b.success()
+	b.failure()
+	require.True(t, b.allow())
+	b.failure()
+
+	// breaker is open until timeout passes
+	require.False(t, b.allow())
+	now = now.Add(time.Second)
+
+	// only one probe is allowed, its failure opens breaker again
+	require.True(t, b.allow())
+	require.False(t, b.allow())
+	b.failure()
+	require.False(t, b.allow())
+
+	now = now.Add(time.Second)
+	require.True(t, b.allow())
+	b.success()
+	require.True(t, b.allow())
+	require.True(t, b.allow())
+}
+
+func TestArchiveBreaker_Send(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	now := time.Now()
+	a := &archiveBreaker{
+		breaker:       newCircuitBreaker(2, time.Minute),
+		slowThreshold: time.Second,
+	}
+	a.breaker.now = func() time.Time { return now }
+
+	var calls int
+	failing := func(context.Context, core.Message, *core.MessageSendOptions) (core.Reply, error) {
+		calls++
+		return nil, errors.New("heavy is down")
+	}
+	slow := func(context.Context, core.Message, *core.MessageSendOptions) (core.Reply, error) {
+		calls++
+		now = now.Add(2 * time.Second)
+		return &reply.OK{}, nil
+	}
+
+	_, err := a.send(ctx, failing, &message.GetObject{}, nil)
+	require.EqualError(t, err, "heavy is down")
+	// slow reply is returned but counted as failure
+	rep, err := a.send(ctx, slow, &message.GetObject{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &reply.OK{}, rep)
+
+	_, err = a.send(ctx, failing, &message.GetObject{}, nil)
+	require.Equal(t, ErrArchiveUnavailable, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestArchiveBreaker_IsArchive(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	heavy := testutils.RandomRef()
+	light := testutils.RandomRef()
+
+	jc := testutils.NewJetCoordinatorMock(t)
+	jc.HeavyMock.Return(&heavy, nil)
+	ps := testutils.NewPulseStorageMock(t)
+	ps.CurrentMock.Return(core.GenesisPulse, nil)
+
+	a := &archiveBreaker{jetCoordinator: jc, pulseStorage: ps}
+	require.True(t, a.isArchive(ctx, &heavy))
+	require.False(t, a.isArchive(ctx, &light))
+	require.False(t, a.isArchive(ctx, nil))
+}
@@ -127,12 +127,12 @@ func (s *componentSuite) TestLedgerArtifactManager_PendingRequest() {
 	mb := testmessagebus.NewTestMessageBus(s.T())
 	mb.PulseStorage = amPulseStorageMock
 
-	am := NewArtifactManger()
+	am := NewArtifactManger(0)
 	am.PulseStorage = amPulseStorageMock
 	am.PlatformCryptographyScheme = cs
 	am.DefaultBus = mb
 
-	provider := storage.NewRecentStorageProvider(0)
+	provider := storage.NewRecentStorageProvider(0, 0)
 
 	cryptoScheme := platformpolicy.NewPlatformCryptographyScheme()
 
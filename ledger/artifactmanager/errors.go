@@ -25,4 +25,6 @@ var (
 	ErrInvalidRef        = errors.New("invalid reference")
 	ErrObjectDeactivated = errors.New("object is deactivated")
 	ErrNotFound          = errors.New("object not found")
+	// ErrArchiveUnavailable is returned without reaching heavy executor while reads from it are failing.
+	ErrArchiveUnavailable = errors.New("archive temporarily unavailable")
 )
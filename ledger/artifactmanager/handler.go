@@ -64,6 +64,7 @@ type MessageHandler struct {
 	conf           *configuration.Ledger
 	middleware     *middleware
 	jetTreeUpdater *jetTreeUpdater
+	disputes       *jetDisputes
 	isHeavy        bool
 }
 
@@ -73,6 +74,7 @@ func NewMessageHandler(conf *configuration.Ledger, certificate core.Certificate)
 		certificate:    certificate,
 		replayHandlers: map[core.MessageType]core.MessageHandler{},
 		conf:           conf,
+		disputes:       newJetDisputes(conf.StateDigest.DisputeThreshold),
 	}
 }
 
@@ -113,7 +115,8 @@ func (h *MessageHandler) Init(ctx context.Context) error {
 
 	h.jetTreeUpdater = newJetTreeUpdater(h.NodeStorage, h.JetStorage, h.Bus, h.JetCoordinator)
 
-	h.isHeavy = h.certificate.GetRole() == core.StaticRoleHeavyMaterial
+	// follower keeps the same ledger copy as heavy and is fed by the same replication stream
+	h.isHeavy = h.certificate.GetRole() == core.StaticRoleHeavyMaterial || h.certificate.GetRole() == core.StaticRoleFollower
 
 	// core.StaticRoleUnknown - genesis
 	if h.certificate.GetRole() == core.StaticRoleLightMaterial || h.certificate.GetRole() == core.StaticRoleUnknown {
@@ -130,28 +133,31 @@ func (h *MessageHandler) Init(ctx context.Context) error {
 
 func (h *MessageHandler) setHandlersForLight(m *middleware) {
 	// Generic.
-	h.Bus.MustRegister(core.TypeGetCode, BuildMiddleware(h.handleGetCode))
+	h.Bus.MustRegister(core.TypeGetCode, BuildMiddleware(h.handleGetCode, m.limitReads))
 
 	h.Bus.MustRegister(core.TypeGetObject,
 		BuildMiddleware(h.handleGetObject,
 			instrumentHandler("handleGetObject"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.limitReads))
 
 	h.Bus.MustRegister(core.TypeGetDelegate,
 		BuildMiddleware(h.handleGetDelegate,
 			instrumentHandler("handleGetDelegate"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.limitReads))
 
 	h.Bus.MustRegister(core.TypeGetChildren,
 		BuildMiddleware(h.handleGetChildren,
 			instrumentHandler("handleGetChildren"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.limitReads))
 
 	h.Bus.MustRegister(core.TypeSetRecord,
 		BuildMiddleware(h.handleSetRecord,
@@ -186,14 +192,16 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			instrumentHandler("handleGetObjectIndex"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.limitReads))
 
 	h.Bus.MustRegister(core.TypeGetPendingRequests,
 		BuildMiddleware(h.handleHasPendingRequests,
 			instrumentHandler("handleHasPendingRequests"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.limitReads))
 
 	h.Bus.MustRegister(core.TypeGetJet,
 		BuildMiddleware(h.handleGetJet,
@@ -210,6 +218,7 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			h.handleGetRequest,
 			m.checkJet,
 			instrumentHandler("handleGetRequest"),
+			m.limitReads,
 		),
 	)
 
@@ -228,6 +237,10 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 		BuildMiddleware(h.handleJetDrop,
 			m.addFieldsToLogger,
 			m.checkJet))
+
+	h.Bus.MustRegister(core.TypeJetStateDigest,
+		BuildMiddleware(h.handleJetStateDigest,
+			instrumentHandler("handleJetStateDigest")))
 }
 func (h *MessageHandler) setReplayHandlers(m *middleware) {
 	// Generic.
@@ -284,6 +297,10 @@ func (h *MessageHandler) setHandlersForHeavy(m *middleware) {
 		BuildMiddleware(h.handleGetObjectIndex,
 			instrumentHandler("handleGetObjectIndex"),
 			m.zeroJetForHeavy))
+
+	h.Bus.MustRegister(core.TypeGetObjectsByPrototype,
+		BuildMiddleware(h.handleGetObjectsByPrototype,
+			instrumentHandler("handleGetObjectsByPrototype")))
 }
 
 func (h *MessageHandler) handleSetRecord(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
@@ -314,6 +331,9 @@ func (h *MessageHandler) handleSetRecord(ctx context.Context, parcel core.Parcel
 func (h *MessageHandler) handleSetBlob(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	msg := parcel.Message().(*message.SetBlob)
 	jetID := jetFromContext(ctx)
+	if h.stateTooBig(msg.Memory) {
+		return &reply.Error{ErrType: reply.ErrStateTooBig}, nil
+	}
 	calculatedID := record.CalculateIDForBlob(h.PlatformCryptographyScheme, parcel.Pulse(), msg.Memory)
 
 	_, err := h.ObjectStorage.GetBlob(ctx, jetID, calculatedID)
@@ -334,6 +354,12 @@ func (h *MessageHandler) handleSetBlob(ctx context.Context, parcel core.Parcel)
 	return nil, err
 }
 
+// stateTooBig checks memory against configured state size limit. It guards replication from huge blobs sent by
+// clients that don't check the limit themselves.
+func (h *MessageHandler) stateTooBig(memory []byte) bool {
+	return h.conf.MaxStateSize > 0 && len(memory) > h.conf.MaxStateSize
+}
+
 func (h *MessageHandler) handleGetCode(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	logger := inslogger.FromContext(ctx)
 	logger.Debug("CALL handleGetCode")
@@ -747,6 +773,10 @@ func (h *MessageHandler) handleUpdateObject(ctx context.Context, parcel core.Par
 
 	msg := parcel.Message().(*message.UpdateObject)
 	jetID := jetFromContext(ctx)
+	if h.stateTooBig(msg.Memory) {
+		logger.Warnf("rejected object %v update: state size %d exceeds limit %d", msg.Object.Record().DebugString(), len(msg.Memory), h.conf.MaxStateSize)
+		return &reply.Error{ErrType: reply.ErrStateTooBig}, nil
+	}
 
 	rec := record.DeserializeRecord(msg.Record)
 	state, ok := rec.(record.ObjectState)
@@ -754,24 +784,24 @@ func (h *MessageHandler) handleUpdateObject(ctx context.Context, parcel core.Par
 		return nil, errors.New("wrong object state record")
 	}
 
-	// FIXME: temporary fix. If we calculate blob id on the client, pulse can change before message sending and this
-	//  id will not match the one calculated on the server.
-	blobID, err := h.ObjectStorage.SetBlob(ctx, jetID, parcel.Pulse(), msg.Memory)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to set blob")
-	}
-	logger.Debugf("save blob. pulse: %v, jet: %v, id: %v", parcel.Pulse(), jetID.DebugString(), blobID.DebugString())
+	var idx *index.ObjectLifeline
+	// Blob, state record and index are saved in one transaction, so object is never left partially updated.
+	err := h.DBContext.Update(ctx, func(tx *storage.TransactionManager) error {
+		// FIXME: temporary fix. If we calculate blob id on the client, pulse can change before message sending and
+		//  this id will not match the one calculated on the server.
+		blobID, err := tx.SetBlob(ctx, jetID, parcel.Pulse(), msg.Memory)
+		if err != nil {
+			return errors.Wrap(err, "failed to set blob")
+		}
+		logger.Debugf("save blob. pulse: %v, jet: %v, id: %v", parcel.Pulse(), jetID.DebugString(), blobID.DebugString())
 
-	switch s := state.(type) {
-	case *record.ObjectActivateRecord:
-		s.Memory = blobID
-	case *record.ObjectAmendRecord:
-		s.Memory = blobID
-	}
+		switch s := state.(type) {
+		case *record.ObjectActivateRecord:
+			s.Memory = blobID
+		case *record.ObjectAmendRecord:
+			s.Memory = blobID
+		}
 
-	var idx *index.ObjectLifeline
-	err = h.DBContext.Update(ctx, func(tx *storage.TransactionManager) error {
-		var err error
 		logger.Debugf("Get index for: %v, jet: %v", msg.Object.Record(), jetID.DebugString())
 		idx, err = tx.GetObjectIndex(ctx, jetID, msg.Object.Record(), true)
 		// No index on our node.
@@ -1025,6 +1055,32 @@ func (h *MessageHandler) handleGetObjectIndex(ctx context.Context, parcel core.P
 	return &reply.ObjectIndex{Index: buf}, nil
 }
 
+// maxObjectsByPrototypeAmount limits page size of objects by prototype query.
+const maxObjectsByPrototypeAmount = 1000
+
+func (h *MessageHandler) handleGetObjectsByPrototype(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg := parcel.Message().(*message.GetObjectsByPrototype)
+
+	amount := msg.Amount
+	if amount <= 0 || amount > maxObjectsByPrototypeAmount {
+		amount = maxObjectsByPrototypeAmount
+	}
+
+	// One more object is fetched to find out if there is next page.
+	refs, err := h.ObjectStorage.GetObjectsByPrototype(ctx, msg.Prototype, msg.From, amount+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch objects by prototype")
+	}
+
+	rep := &reply.ObjectsByPrototype{Refs: refs}
+	if len(refs) > amount {
+		rep.Refs = refs[:amount]
+		next := refs[amount-1]
+		rep.NextFrom = &next
+	}
+	return rep, nil
+}
+
 func (h *MessageHandler) handleValidationCheck(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	msg := parcel.Message().(*message.ValidationCheck)
 	jetID := jetFromContext(ctx)
@@ -601,6 +601,48 @@ func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_UpdateIndexState()
 	require.Equal(s.T(), core.FirstPulseNumber, int(idx.LatestUpdate))
 }
 
+func (s *handlerSuite) TestMessageHandler_HandleUpdateObject_RejectsTooBigState() {
+	certificate := testutils.NewCertificateMock(s.T())
+	h := NewMessageHandler(&configuration.Ledger{
+		MaxStateSize: 10,
+	}, certificate)
+	h.ObjectStorage = s.objectStorage
+	h.PlatformCryptographyScheme = s.scheme
+	jetID := *jet.NewID(0, nil)
+	ctx := contextWithJet(s.ctx, jetID)
+
+	amendRecord := record.ObjectAmendRecord{PrevState: *genRandomID(0)}
+	rep, err := h.handleUpdateObject(ctx, &message.Parcel{
+		Msg: &message.UpdateObject{
+			Record: record.SerializeRecord(&amendRecord),
+			Object: *genRandomRef(0),
+			Memory: make([]byte, 11),
+		},
+		PulseNumber: core.FirstPulseNumber,
+	})
+	require.NoError(s.T(), err)
+	errRep, ok := rep.(*reply.Error)
+	require.True(s.T(), ok)
+	require.Equal(s.T(), core.ErrStateTooBig, errRep.Error())
+
+	rep, err = h.handleSetBlob(ctx, &message.Parcel{
+		Msg:         &message.SetBlob{Memory: make([]byte, 11)},
+		PulseNumber: core.FirstPulseNumber,
+	})
+	require.NoError(s.T(), err)
+	errRep, ok = rep.(*reply.Error)
+	require.True(s.T(), ok)
+	require.Equal(s.T(), core.ErrStateTooBig, errRep.Error())
+
+	rep, err = h.handleSetBlob(ctx, &message.Parcel{
+		Msg:         &message.SetBlob{Memory: make([]byte, 10)},
+		PulseNumber: core.FirstPulseNumber,
+	})
+	require.NoError(s.T(), err)
+	_, ok = rep.(*reply.ID)
+	require.True(s.T(), ok)
+}
+
 func (s *handlerSuite) TestMessageHandler_HandleGetObjectIndex() {
 	mc := minimock.NewController(s.T())
 	defer mc.Finish()
@@ -1260,3 +1302,42 @@ func (s *handlerSuite) TestMessageHandler_HandleGetRequest() {
 	require.True(s.T(), ok)
 	assert.Equal(s.T(), req, *record.DeserializeRecord(reqReply.Record).(*record.RequestRecord))
 }
+
+func (s *handlerSuite) TestMessageHandler_HandleGetObjectsByPrototype() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	prototype := *genRandomRef(0)
+	refs := []core.RecordRef{*genRandomRef(0), *genRandomRef(0), *genRandomRef(0)}
+	objectStorage := storage.NewObjectStorageMock(mc)
+	objectStorage.GetObjectsByPrototypeFunc = func(
+		ctx context.Context, p core.RecordRef, from *core.RecordRef, limit int,
+	) ([]core.RecordRef, error) {
+		require.Equal(s.T(), prototype, p)
+		if limit > len(refs) {
+			limit = len(refs)
+		}
+		return refs[:limit], nil
+	}
+	h := MessageHandler{ObjectStorage: objectStorage}
+
+	// page is full, next page starts after the last object
+	rep, err := h.handleGetObjectsByPrototype(s.ctx, &message.Parcel{
+		Msg: &message.GetObjectsByPrototype{Prototype: prototype, Amount: 2},
+	})
+	require.NoError(s.T(), err)
+	page, ok := rep.(*reply.ObjectsByPrototype)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), refs[:2], page.Refs)
+	assert.Equal(s.T(), &refs[1], page.NextFrom)
+
+	// the last page
+	rep, err = h.handleGetObjectsByPrototype(s.ctx, &message.Parcel{
+		Msg: &message.GetObjectsByPrototype{Prototype: prototype, Amount: 3},
+	})
+	require.NoError(s.T(), err)
+	page, ok = rep.(*reply.ObjectsByPrototype)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), refs, page.Refs)
+	assert.Nil(s.T(), page.NextFrom)
+}
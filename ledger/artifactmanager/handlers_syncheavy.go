@@ -40,6 +40,12 @@ func (h *MessageHandler) handleHeavyPayload(ctx context.Context, genericMsg core
 		inslog.Error("Heavy store failed ", err)
 		return heavyerrreply(err)
 	}
+	if msg.Cursor != nil {
+		if err := h.HeavySync.Checkpoint(ctx, msg.JetID, msg.PulseNum, msg.Cursor); err != nil {
+			inslog.Error("Heavy checkpoint failed ", err)
+			return heavyerrreply(err)
+		}
+	}
 	inslog.Debugf("Heavy sync: stores %v records", len(msg.Records))
 	return &reply.OK{}, nil
 }
@@ -60,6 +66,15 @@ func (h *MessageHandler) handleHeavyStartStop(ctx context.Context, genericMsg co
 		}
 		return &reply.OK{}, nil
 	}
+	// resume
+	if msg.Resume {
+		inslog.Debug("Heavy sync: get resume message")
+		cursor, err := h.HeavySync.Resume(ctx, msg.JetID, msg.PulseNum)
+		if err != nil {
+			return heavyerrreply(err)
+		}
+		return &reply.HeavyCheckpoint{JetID: msg.JetID, PulseNum: msg.PulseNum, Cursor: cursor}, nil
+	}
 	// start
 
 	inslog.Debug("Heavy sync: get start message")
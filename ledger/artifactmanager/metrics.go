@@ -33,7 +33,14 @@ var (
 	statCalls   = stats.Int64("artifactmanager/calls", "The number of AM method calls", stats.UnitDimensionless)
 	statLatency = stats.Int64("artifactmanager/latency", "The latency in milliseconds per AM call", stats.UnitMilliseconds)
 
-	statRedirects = stats.Int64("artifactmanager/redirects", "The number redirects happens on AM", stats.UnitDimensionless)
+	statRedirects       = stats.Int64("artifactmanager/redirects", "The number redirects happens on AM", stats.UnitDimensionless)
+	statArchiveRejected = stats.Int64("artifactmanager/archive/rejected", "The number of reads from heavy rejected by open circuit breaker", stats.UnitDimensionless)
+
+	statPriorityReads = stats.Int64("artifactmanager/reads/priority", "The number of high priority reads bypassed read queue", stats.UnitDimensionless)
+	statReadQueueTime = stats.Int64("artifactmanager/reads/queue", "The time in milliseconds reads spend in queue", stats.UnitMilliseconds)
+
+	statStateDigestDivergences = stats.Int64("artifactmanager/statedigest/divergences", "The number of jet state digests diverged from executor ones", stats.UnitDimensionless)
+	statJetDisputes            = stats.Int64("artifactmanager/statedigest/disputes", "The number of jets disputed and resynced from heavy", stats.UnitDimensionless)
 )
 
 func init() {
@@ -60,6 +67,38 @@ func init() {
 			Measure:     statRedirects,
 			Aggregation: view.Count(),
 		},
+		&view.View{
+			Name:        statArchiveRejected.Name(),
+			Description: statArchiveRejected.Description(),
+			Measure:     statArchiveRejected,
+			Aggregation: view.Count(),
+		},
+
+		&view.View{
+			Name:        statPriorityReads.Name(),
+			Description: statPriorityReads.Description(),
+			Measure:     statPriorityReads,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Name:        "artifactmanager_reads_queue",
+			Description: statReadQueueTime.Description(),
+			Measure:     statReadQueueTime,
+			Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 200, 400, 800, 1600),
+		},
+
+		&view.View{
+			Name:        statStateDigestDivergences.Name(),
+			Description: statStateDigestDivergences.Description(),
+			Measure:     statStateDigestDivergences,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Name:        statJetDisputes.Name(),
+			Description: statJetDisputes.Description(),
+			Measure:     statJetDisputes,
+			Aggregation: view.Count(),
+		},
 	)
 	if err != nil {
 		panic(err)
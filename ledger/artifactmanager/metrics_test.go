@@ -130,7 +130,7 @@ func (s *metricSuite) TestLedgerArtifactManager_Metrics() {
 	mb := testutils.NewMessageBusMock(mc)
 	mb.SendMock.Return(&reply.ID{}, nil)
 	cs := testutils.NewPlatformCryptographyScheme()
-	am := NewArtifactManger()
+	am := NewArtifactManger(0)
 	am.DB = s.db
 	am.PlatformCryptographyScheme = cs
 	am.DefaultBus = mb
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
@@ -40,12 +41,14 @@ type middleware struct {
 	hotDataWaiter  HotDataWaiter
 	conf           *configuration.Ledger
 	handler        *MessageHandler
+	// reads is a semaphore of concurrently handled normal priority reads, nil means no limit
+	reads chan struct{}
 }
 
 func newMiddleware(
 	h *MessageHandler,
 ) *middleware {
-	return &middleware{
+	m := &middleware{
 		objectStorage:  h.ObjectStorage,
 		jetStorage:     h.JetStorage,
 		jetCoordinator: h.JetCoordinator,
@@ -55,6 +58,10 @@ func newMiddleware(
 		handler:        h,
 		conf:           h.conf,
 	}
+	if h.conf != nil && h.conf.MaxConcurrentReads > 0 {
+		m.reads = make(chan struct{}, h.conf.MaxConcurrentReads)
+	}
+	return m
 }
 
 func (m *middleware) addFieldsToLogger(handler core.MessageHandler) core.MessageHandler {
@@ -251,3 +258,28 @@ func (m *middleware) releaseHotDataWaiters(handler core.MessageHandler) core.Mes
 		return handler(ctx, parcel)
 	}
 }
+
+// limitReads queues read requests if there are too many of them in progress. High priority reads, issued by
+// validators which must finish within pulse, bypass the queue.
+func (m *middleware) limitReads(handler core.MessageHandler) core.MessageHandler {
+	return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+		if m.reads == nil {
+			return handler(ctx, parcel)
+		}
+		if core.PriorityFromContext(ctx) == core.PriorityHigh {
+			stats.Record(ctx, statPriorityReads.M(1))
+			return handler(ctx, parcel)
+		}
+
+		start := time.Now()
+		select {
+		case m.reads <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		defer func() { <-m.reads }()
+		stats.Record(ctx, statReadQueueTime.M(time.Since(start).Nanoseconds()/1e6))
+
+		return handler(ctx, parcel)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package artifactmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_LimitReads(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	m := newMiddleware(&MessageHandler{conf: &configuration.Ledger{MaxConcurrentReads: 1}})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 3)
+	handler := m.limitReads(func(ctx context.Context, p core.Parcel) (core.Reply, error) {
+		started <- struct{}{}
+		<-release
+		return &reply.OK{}, nil
+	})
+	parcel := &message.Parcel{Msg: &message.GetObject{}}
+
+	// first read takes the only slot
+	go handler(ctx, parcel)
+	<-started
+
+	// second normal read waits in queue
+	go handler(ctx, parcel)
+	select {
+	case <-started:
+		t.Fatal("normal read must wait for free slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// high priority read bypasses queue
+	go handler(core.ContextWithPriority(ctx, core.PriorityHigh), parcel)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("high priority read must not wait")
+	}
+
+	close(release)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("queued read must proceed after slot is released")
+	}
+}
+
+func TestMiddleware_LimitReadsCancelled(t *testing.T) {
+	m := newMiddleware(&MessageHandler{conf: &configuration.Ledger{MaxConcurrentReads: 1}})
+	m.reads <- struct{}{}
+
+	ctx, cancel := context.WithCancel(inslogger.TestContext(t))
+	cancel()
+	handler := m.limitReads(func(ctx context.Context, p core.Parcel) (core.Reply, error) {
+		return &reply.OK{}, nil
+	})
+
+	_, err := handler(ctx, &message.Parcel{Msg: &message.GetObject{}})
+	require.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,204 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package artifactmanager
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+)
+
+// JetStateDigest calculates digest of jet state as merkle root over latest states of objects stored in jet.
+// Leaves are ordered by object id, so equal states give equal digests on all nodes. Count of objects is
+// returned along with digest.
+func JetStateDigest(
+	ctx context.Context,
+	objects storage.ObjectStorage,
+	scheme core.PlatformCryptographyScheme,
+	jetID core.RecordID,
+) ([]byte, int, error) {
+	var ids []core.RecordID
+	err := objects.IterateIndexIDs(ctx, jetID, func(id core.RecordID) error {
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to iterate indexes")
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+
+	leaves := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		id := id
+		idx, err := objects.GetObjectIndex(ctx, jetID, &id, false)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to get index of object %s", id.DebugString())
+		}
+		leaf := append([]byte{}, id[:]...)
+		if idx.LatestState != nil {
+			leaf = append(leaf, idx.LatestState[:]...)
+		}
+		leaves = append(leaves, scheme.IntegrityHasher().Hash(leaf))
+	}
+
+	return merkleRoot(scheme, leaves), len(ids), nil
+}
+
+// merkleRoot hashes pairs of nodes level by level, odd node is promoted to the next level as is.
+func merkleRoot(scheme core.PlatformCryptographyScheme, level [][]byte) []byte {
+	if len(level) == 0 {
+		return scheme.IntegrityHasher().Hash(nil)
+	}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, scheme.IntegrityHasher().Hash(append(append([]byte{}, level[i]...), level[i+1]...)))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// jetDisputes counts consecutive pulses state digests of jets diverged for.
+type jetDisputes struct {
+	lock      sync.Mutex
+	threshold int
+	divergent map[core.RecordID]int
+	disputed  map[core.RecordID]core.PulseNumber
+}
+
+func newJetDisputes(threshold int) *jetDisputes {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &jetDisputes{
+		threshold: threshold,
+		divergent: map[core.RecordID]int{},
+		disputed:  map[core.RecordID]core.PulseNumber{},
+	}
+}
+
+// observe records result of digest comparison on pulse. It returns true when divergence persisted
+// for threshold pulses and jet became disputed.
+func (d *jetDisputes) observe(jetID core.RecordID, pulse core.PulseNumber, match bool) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if match {
+		delete(d.divergent, jetID)
+		delete(d.disputed, jetID)
+		return false
+	}
+	d.divergent[jetID]++
+	if d.divergent[jetID] < d.threshold {
+		return false
+	}
+	d.divergent[jetID] = 0
+	d.disputed[jetID] = pulse
+	return true
+}
+
+// handleJetStateDigest compares digest of jet state calculated by light executor with local one.
+// Persistent divergence marks jet as disputed and local indexes of jet are resynced from heavy.
+func (h *MessageHandler) handleJetStateDigest(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg := parcel.Message().(*message.JetStateDigest)
+	logger := inslogger.FromContext(ctx).WithField("jet", msg.JetID.DebugString())
+
+	executor, err := h.JetCoordinator.LightExecutorForJet(ctx, msg.JetID, msg.PulseNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate executor for jet")
+	}
+	if *executor != parcel.GetSender() {
+		return nil, errors.New("digest is not sent by light executor of jet")
+	}
+
+	digest, count, err := JetStateDigest(ctx, h.ObjectStorage, h.PlatformCryptographyScheme, msg.JetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate jet state digest")
+	}
+	if count == 0 {
+		// node doesn't keep state of jet, nothing to compare
+		return &reply.OK{}, nil
+	}
+
+	match := bytes.Equal(digest, msg.Digest)
+	if !match {
+		stats.Record(ctx, statStateDigestDivergences.M(1))
+		logger.Warnf("jet state digest diverges from executor one on pulse %v", msg.PulseNumber)
+	}
+	if h.disputes.observe(msg.JetID, msg.PulseNumber, match) {
+		stats.Record(ctx, statJetDisputes.M(1))
+		logger.Errorf("jet is disputed on pulse %v, resync from heavy", msg.PulseNumber)
+		go func() {
+			ctx := inslogger.ContextWithTrace(context.Background(), inslogger.TraceID(ctx))
+			if err := h.resyncFromHeavy(ctx, msg.JetID, msg.PulseNumber); err != nil {
+				inslogger.FromContext(ctx).Error(errors.Wrap(err, "failed to resync disputed jet"))
+			}
+		}()
+	}
+
+	if !match {
+		return &reply.NotOK{}, nil
+	}
+	return &reply.OK{}, nil
+}
+
+// resyncFromHeavy replaces local indexes of jet with ones stored on heavy.
+func (h *MessageHandler) resyncFromHeavy(ctx context.Context, jetID core.RecordID, pulse core.PulseNumber) error {
+	heavy, err := h.JetCoordinator.Heavy(ctx, pulse)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate heavy")
+	}
+
+	var ids []core.RecordID
+	err = h.ObjectStorage.IterateIndexIDs(ctx, jetID, func(id core.RecordID) error {
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate indexes")
+	}
+
+	var failed int
+	for _, id := range ids {
+		_, err := h.saveIndexFromHeavy(ctx, jetID, *core.NewRecordRef(core.DomainID, id), heavy)
+		if err != nil {
+			// objects created after the last sync are not on heavy yet
+			failed++
+			inslogger.FromContext(ctx).Debugf("failed to resync index of %s: %s", id.DebugString(), err)
+		}
+	}
+	inslogger.FromContext(ctx).Infof("resynced %d of %d indexes of jet %s from heavy", len(ids)-failed, len(ids), jetID.DebugString())
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package artifactmanager
+
+import (
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestJetDisputes(t *testing.T) {
+	jetID := *jet.NewID(0, nil)
+	disputes := newJetDisputes(2)
+
+	assert.False(t, disputes.observe(jetID, core.FirstPulseNumber, false))
+	assert.False(t, disputes.observe(jetID, core.FirstPulseNumber+1, true))
+	assert.False(t, disputes.observe(jetID, core.FirstPulseNumber+2, false))
+	assert.True(t, disputes.observe(jetID, core.FirstPulseNumber+3, false))
+	assert.Contains(t, disputes.disputed, jetID)
+
+	assert.False(t, disputes.observe(jetID, core.FirstPulseNumber+4, true))
+	assert.NotContains(t, disputes.disputed, jetID)
+}
+
+func (s *handlerSuite) TestJetStateDigest() {
+	jetID := *jet.NewID(0, nil)
+
+	empty, count, err := JetStateDigest(s.ctx, s.objectStorage, s.scheme, jetID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, count)
+
+	for i := 0; i < 3; i++ {
+		err := s.objectStorage.SetObjectIndex(s.ctx, jetID, genRandomID(core.FirstPulseNumber), &index.ObjectLifeline{
+			LatestState: genRandomID(core.FirstPulseNumber),
+		})
+		require.NoError(s.T(), err)
+	}
+	first, count, err := JetStateDigest(s.ctx, s.objectStorage, s.scheme, jetID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, count)
+	assert.NotEqual(s.T(), empty, first)
+
+	second, _, err := JetStateDigest(s.ctx, s.objectStorage, s.scheme, jetID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), first, second)
+
+	err = s.objectStorage.IterateIndexIDs(s.ctx, jetID, func(id core.RecordID) error {
+		return s.objectStorage.SetObjectIndex(s.ctx, jetID, &id, &index.ObjectLifeline{
+			LatestState: genRandomID(core.FirstPulseNumber),
+		})
+	})
+	require.NoError(s.T(), err)
+	changed, _, err := JetStateDigest(s.ctx, s.objectStorage, s.scheme, jetID)
+	require.NoError(s.T(), err)
+	assert.NotEqual(s.T(), first, changed)
+}
+
+func (s *handlerSuite) TestMessageHandler_HandleJetStateDigest() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+	jetID := *jet.NewID(0, nil)
+	executor := testutils.RandomRef()
+
+	jc := testutils.NewJetCoordinatorMock(mc)
+	jc.LightExecutorForJetMock.Return(&executor, nil)
+
+	h := NewMessageHandler(&configuration.Ledger{
+		StateDigest: configuration.StateDigest{Enabled: true, DisputeThreshold: 2},
+	}, nil)
+	h.JetCoordinator = jc
+	h.ObjectStorage = s.objectStorage
+	h.PlatformCryptographyScheme = s.scheme
+
+	err := s.objectStorage.SetObjectIndex(s.ctx, jetID, genRandomID(core.FirstPulseNumber), &index.ObjectLifeline{
+		LatestState: genRandomID(core.FirstPulseNumber),
+	})
+	require.NoError(s.T(), err)
+	digest, _, err := JetStateDigest(s.ctx, s.objectStorage, s.scheme, jetID)
+	require.NoError(s.T(), err)
+
+	// digest from node which is not executor is rejected
+	_, err = h.handleJetStateDigest(s.ctx, &message.Parcel{
+		Sender: testutils.RandomRef(),
+		Msg:    &message.JetStateDigest{JetID: jetID, PulseNumber: core.FirstPulseNumber, Digest: digest},
+	})
+	assert.Error(s.T(), err)
+
+	rep, err := h.handleJetStateDigest(s.ctx, &message.Parcel{
+		Sender: executor,
+		Msg:    &message.JetStateDigest{JetID: jetID, PulseNumber: core.FirstPulseNumber, Digest: digest},
+	})
+	require.NoError(s.T(), err)
+	assert.IsType(s.T(), &reply.OK{}, rep)
+
+	rep, err = h.handleJetStateDigest(s.ctx, &message.Parcel{
+		Sender: executor,
+		Msg:    &message.JetStateDigest{JetID: jetID, PulseNumber: core.FirstPulseNumber + 1, Digest: []byte{1}},
+	})
+	require.NoError(s.T(), err)
+	assert.IsType(s.T(), &reply.NotOK{}, rep)
+	assert.NotContains(s.T(), h.disputes.disputed, jetID)
+}
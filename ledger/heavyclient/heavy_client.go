@@ -37,6 +37,8 @@ type Options struct {
 	SyncMessageLimit int
 	PulsesDeltaLimit int
 	BackoffConf      configuration.Backoff
+	// MaxInFlightPayloads limits payloads sent to heavy concurrently by all clients of pool, zero means no limit.
+	MaxInFlightPayloads int
 }
 
 // JetClient heavy replication client. Replicates records for one jet.
@@ -47,8 +49,11 @@ type JetClient struct {
 	pulseTracker   storage.PulseTracker
 	cleaner        storage.Cleaner
 	db             storage.DBContext
+	nodeStorage    storage.NodeStorage
 
 	opts Options
+	// payloads is shared by clients of pool to limit payloads in flight, nil means no limit
+	payloads chan struct{}
 
 	// life cycle control
 	//
@@ -57,6 +62,8 @@ type JetClient struct {
 	signal    chan struct{}
 	// syncdone closes when syncloop is gracefully finished
 	syncdone chan struct{}
+	// followerQueue holds replication messages for followers, they are sent by followerLoop
+	followerQueue chan followerMessage
 
 	// state:
 	jetID       core.RecordID
@@ -75,6 +82,7 @@ func NewJetClient(
 	pulseTracker storage.PulseTracker,
 	cleaner storage.Cleaner,
 	db storage.DBContext,
+	nodeStorage storage.NodeStorage,
 	jetID core.RecordID,
 	opts Options,
 ) *JetClient {
@@ -85,10 +93,12 @@ func NewJetClient(
 		pulseTracker:   pulseTracker,
 		cleaner:        cleaner,
 		db:             db,
+		nodeStorage:    nodeStorage,
 		jetID:          jetID,
 		syncbackoff:    backoffFromConfig(opts.BackoffConf),
 		signal:         make(chan struct{}, 1),
 		syncdone:       make(chan struct{}),
+		followerQueue:  make(chan followerMessage, followerQueueSize),
 		opts:           opts,
 	}
 	return jsc
@@ -162,6 +172,23 @@ func (c *JetClient) nextPulseNumber() (core.PulseNumber, bool) {
 	return c.leftPulses[0], true
 }
 
+// minPendingPulse returns the earliest pulse waiting for replication to heavy.
+func (c *JetClient) minPendingPulse() (core.PulseNumber, bool) {
+	c.muPulses.Lock()
+	defer c.muPulses.Unlock()
+
+	if len(c.leftPulses) == 0 {
+		return 0, false
+	}
+	min := c.leftPulses[0]
+	for _, pn := range c.leftPulses[1:] {
+		if pn < min {
+			min = pn
+		}
+	}
+	return min, true
+}
+
 func (c *JetClient) runOnce(ctx context.Context) {
 	// retrydelay = m.syncbackoff.ForAttempt(attempt)
 	c.startOnce.Do(func() {
@@ -170,6 +197,7 @@ func (c *JetClient) runOnce(ctx context.Context) {
 		ctx, cancel := context.WithCancel(context.Background())
 		c.cancel = cancel
 		go c.syncloop(ctx)
+		go c.followerLoop(ctx)
 	})
 }
 
@@ -37,8 +37,10 @@ type Pool struct {
 	replicaStorage storage.ReplicaStorage
 	cleaner        storage.Cleaner
 	db             storage.DBContext
+	nodeStorage    storage.NodeStorage
 
 	clientDefaults Options
+	payloads       chan struct{}
 
 	sync.Mutex
 	clients map[core.RecordID]*JetClient
@@ -54,16 +56,23 @@ func NewPool(
 	replicaStorage storage.ReplicaStorage,
 	cleaner storage.Cleaner,
 	db storage.DBContext,
+	nodeStorage storage.NodeStorage,
 	clientDefaults Options,
 ) *Pool {
+	var payloads chan struct{}
+	if clientDefaults.MaxInFlightPayloads > 0 {
+		payloads = make(chan struct{}, clientDefaults.MaxInFlightPayloads)
+	}
 	return &Pool{
 		bus:            bus,
 		pulseStorage:   pulseStorage,
 		pulseTracker:   tracker,
 		replicaStorage: replicaStorage,
 		clientDefaults: clientDefaults,
+		payloads:       payloads,
 		cleaner:        cleaner,
 		db:             db,
+		nodeStorage:    nodeStorage,
 		clients:        map[core.RecordID]*JetClient{},
 	}
 }
@@ -104,9 +113,11 @@ func (scp *Pool) AddPulsesToSyncClient(
 			scp.pulseTracker,
 			scp.cleaner,
 			scp.db,
+			scp.nodeStorage,
 			jetID,
 			scp.clientDefaults,
 		)
+		client.payloads = scp.payloads
 
 		scp.clients[jetID] = client
 	}
@@ -134,7 +145,14 @@ func (scp *Pool) AllClients(ctx context.Context) []*JetClient {
 	return clients
 }
 
-func (scp *Pool) LightCleanup(ctx context.Context, untilPN core.PulseNumber, rsp recentstorage.Provider) error {
+// LightCleanup removes data replicated to heavy until provided pulse number, records of transient classes are
+// removed until pulse numbers of the classes.
+func (scp *Pool) LightCleanup(
+	ctx context.Context,
+	untilPN core.PulseNumber,
+	transient map[storage.RecordClass]core.PulseNumber,
+	rsp recentstorage.Provider,
+) error {
 	inslog := inslogger.FromContext(ctx)
 	start := time.Now()
 	defer func() {
@@ -159,6 +177,24 @@ func (scp *Pool) LightCleanup(ctx context.Context, untilPN core.PulseNumber, rsp
 		wg.Add(len(allClients))
 		for _, c := range allClients {
 			jetID := c.jetID
+			// data of pulses not replicated to heavy yet is kept
+			untilPN := untilPN
+			pending, hasPending := c.minPendingPulse()
+			if hasPending && pending < untilPN {
+				inslogger.FromContext(ctx).Infof(
+					"Light cleanup of jet %v is limited by pulse %v waiting for replication to heavy (retention until %v)",
+					jetID.DebugString(), pending, untilPN)
+				untilPN = pending
+			}
+			classUntil := map[storage.RecordClass]core.PulseNumber{}
+			for class, pn := range transient {
+				if hasPending && pending < pn {
+					pn = pending
+				}
+				if pn > untilPN {
+					classUntil[class] = pn
+				}
+			}
 			go func() {
 				defer wg.Done()
 				inslogger.FromContext(ctx).Debugf("Start light cleanup, until pulse = %v, jet = %v",
@@ -171,6 +207,17 @@ func (scp *Pool) LightCleanup(ctx context.Context, untilPN core.PulseNumber, rsp
 				}
 				inslogger.FromContext(ctx).Debugf("End light cleanup, rm stat=%#v (until pulse = %v, jet = %v)",
 					rmStat, untilPN, jetID.DebugString())
+				if len(classUntil) == 0 {
+					return
+				}
+				transientStat, err := scp.cleaner.RemoveJetRecordsByClassUntil(ctx, jetID, classUntil, rsp.GetStorage(ctx, jetID))
+				if err != nil {
+					inslogger.FromContext(ctx).Errorf("Error on light cleanup of transient records, until = %v, jet = %v: %v",
+						classUntil, jetID.DebugString(), err)
+					return
+				}
+				inslogger.FromContext(ctx).Debugf("End light cleanup of transient records, rm stat=%#v (until = %v, jet = %v)",
+					transientStat, classUntil, jetID.DebugString())
 			}()
 		}
 		wg.Wait()
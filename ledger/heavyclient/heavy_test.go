@@ -36,6 +36,7 @@ import (
 	"github.com/insolar/insolar/ledger/artifactmanager"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/recovery"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -159,7 +160,6 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 	recentMock.GetObjectsMock.Return(nil)
 	recentMock.GetRequestsMock.Return(nil)
 	recentMock.AddObjectMock.Return()
-	recentMock.DecreaseTTLMock.Return()
 
 	// Mock6: JetCoordinatorMock
 	jcMock := testutils.NewJetCoordinatorMock(s.T())
@@ -198,8 +198,19 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 	}
 	syncmessagesPerMessage := map[int32]*messageStat{}
 	var bussendfailed int32
+	// cursors of the last stored payload per pulse, returned on resume
+	synccursors := map[core.PulseNumber][]byte{}
 	busMock.SendFunc = func(ctx context.Context, msg core.Message, ops *core.MessageSendOptions) (core.Reply, error) {
 		// fmt.Printf("got msg: %T (%s)\n", msg, msg.Type())
+		if startmsg, ok := msg.(*message.HeavyStartStop); ok && startmsg.Resume {
+			statMutex.Lock()
+			defer statMutex.Unlock()
+			return &reply.HeavyCheckpoint{
+				JetID:    startmsg.JetID,
+				PulseNum: startmsg.PulseNum,
+				Cursor:   synccursors[startmsg.PulseNum],
+			}, nil
+		}
 		heavymsg, ok := msg.(*message.HeavyPayload)
 		if ok {
 			if withretry && atomic.AddInt32(&bussendfailed, 1) < 2 {
@@ -224,6 +235,7 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 				size: size,
 				keys: keys,
 			}
+			synccursors[heavymsg.PulseNum] = heavymsg.Cursor
 			statMutex.Unlock()
 		}
 		return nil, nil
@@ -262,6 +274,7 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 	pm.StorageCleaner = s.storageCleaner
 	pm.ObjectStorage = s.objectStorage
 	pm.DropStorage = s.dropStorage
+	pm.LastKnownGood = recovery.NewLastKnownGoodMock(s.T()).MarkLastKnownGoodMock.Return(nil)
 
 	ps := storage.NewPulseStorage()
 	ps.PulseTracker = s.pulseTracker
@@ -275,6 +288,8 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 	}
 	providerMock.CloneStorageMock.Return()
 	providerMock.RemoveStorageMock.Return()
+	providerMock.DecreaseTTLMock.Return()
+	providerMock.SetEvictionHandlerMock.Return()
 	pm.RecentStorageProvider = providerMock
 
 	pm.ActiveListSwapper = alsMock
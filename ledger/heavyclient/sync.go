@@ -24,6 +24,7 @@ import (
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
+	"github.com/pkg/errors"
 )
 
 func messageToHeavy(ctx context.Context, bus core.MessageBus, msg core.Message) error {
@@ -40,9 +41,97 @@ func messageToHeavy(ctx context.Context, bus core.MessageBus, msg core.Message)
 	return nil
 }
 
+// resumeOnHeavy sends resume message to heavy and returns cursor replication should be continued from.
+func resumeOnHeavy(ctx context.Context, bus core.MessageBus, msg *message.HeavyStartStop) ([]byte, error) {
+	busreply, err := bus.Send(ctx, msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch r := busreply.(type) {
+	case *reply.HeavyCheckpoint:
+		return r.Cursor, nil
+	case *reply.HeavyError:
+		return nil, r
+	default:
+		return nil, errors.Errorf("unexpected reply on heavy resume: %T", busreply)
+	}
+}
+
+// followers returns active follower nodes, they get a copy of replication stream sent to heavy.
+func (c *JetClient) followers(ctx context.Context) []core.RecordRef {
+	pulse, err := c.pulseStorage.Current(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Error("synchronize: can't get current pulse for followers: ", err)
+		return nil
+	}
+	nodes, err := c.nodeStorage.GetActiveNodesByRole(pulse.PulseNumber, core.StaticRoleFollower)
+	if err != nil {
+		inslogger.FromContext(ctx).Debug("synchronize: can't get active followers: ", err)
+		return nil
+	}
+	refs := make([]core.RecordRef, 0, len(nodes))
+	for _, node := range nodes {
+		refs = append(refs, node.ID())
+	}
+	return refs
+}
+
+// followerQueueSize limits replication messages waiting to be sent to followers.
+const followerQueueSize = 1024
+
+// followerMessage is replication message queued for followers.
+type followerMessage struct {
+	followers []core.RecordRef
+	msg       core.Message
+}
+
+// messageToFollowers queues replication message for followers, so sync with heavy doesn't wait for them.
+// Followers are best effort observers: messages are dropped if queue is full and failures are only logged.
+func (c *JetClient) messageToFollowers(ctx context.Context, followers []core.RecordRef, msg core.Message) {
+	if len(followers) == 0 {
+		return
+	}
+	select {
+	case c.followerQueue <- followerMessage{followers: followers, msg: msg}:
+	default:
+		inslogger.FromContext(ctx).Warnf("synchronize: followers queue is full, %v is dropped", msg.Type())
+	}
+}
+
+// signalToFollowers queues copy of start/stop message for followers, because HeavySync changes the message after
+// it is sent to heavy while queued one waits for followerLoop.
+func (c *JetClient) signalToFollowers(ctx context.Context, followers []core.RecordRef, msg *message.HeavyStartStop) {
+	signal := *msg
+	c.messageToFollowers(ctx, followers, &signal)
+}
+
+// followerLoop sends queued replication messages to followers in order.
+func (c *JetClient) followerLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fm := <-c.followerQueue:
+			for _, follower := range fm.followers {
+				receiver := follower
+				busreply, err := c.bus.Send(ctx, fm.msg, &core.MessageSendOptions{Receiver: &receiver})
+				if err == nil {
+					if herr, ok := busreply.(*reply.HeavyError); ok {
+						err = herr
+					}
+				}
+				if err != nil {
+					inslogger.FromContext(ctx).Warnf("synchronize: follower %v failed on %v: %v", follower, fm.msg.Type(), err)
+				}
+			}
+		}
+	}
+}
+
 // HeavySync syncs records from light to heavy node, returns last synced pulse and error.
 //
-// It syncs records from start to end of provided pulse numbers.
+// It syncs records from start to end of provided pulse numbers. If sync of pulse was interrupted,
+// it is resumed from the last payload stored on heavy.
 func (c *JetClient) HeavySync(
 	ctx context.Context,
 	pn core.PulseNumber,
@@ -54,48 +143,51 @@ func (c *JetClient) HeavySync(
 	inslog = inslog.WithField("pulseNum", pn)
 
 	inslog.Debug("JetClient.HeavySync")
-	if retry {
-		inslog.Info("synchronize: send reset message (retry sync)")
-		resetMsg := &message.HeavyReset{
-			JetID:    jetID,
-			PulseNum: pn,
-		}
-		if err := messageToHeavy(ctx, c.bus, resetMsg); err != nil {
-			inslog.Error("synchronize: reset failed")
-			return err
-		}
-	}
+	followers := c.followers(ctx)
 
+	checkpoint, err := c.replicaStorage.GetSyncClientCheckpoint(ctx, jetID)
+	if err != nil {
+		return errors.Wrap(err, "synchronize: failed to get sync checkpoint")
+	}
 	signalMsg := &message.HeavyStartStop{
 		JetID:    jetID,
 		PulseNum: pn,
 	}
-	if err := messageToHeavy(ctx, c.bus, signalMsg); err != nil {
-		inslog.Error("synchronize: start failed")
+	var cursor []byte
+	if checkpoint != nil && checkpoint.Pulse == pn {
+		signalMsg.Resume = true
+		cursor, err = resumeOnHeavy(ctx, c.bus, signalMsg)
+		if err != nil {
+			inslog.Error("synchronize: resume failed")
+			return err
+		}
+		c.signalToFollowers(ctx, followers, signalMsg)
+		signalMsg.Resume = false
+		inslog.Debug("synchronize: sucessfully send resume message")
+	} else if err := c.startOnHeavy(ctx, followers, signalMsg, retry); err != nil {
 		return err
 	}
-	inslog.Debug("synchronize: sucessfully send start message")
 
 	replicator := storage.NewReplicaIter(
 		ctx, c.db, jetID, pn, pn+1, c.opts.SyncMessageLimit)
-	for {
-		recs, err := replicator.NextRecords()
-		if err == storage.ErrReplicatorDone {
-			break
+	if cursor != nil {
+		if err := replicator.Seek(cursor); err != nil {
+			inslog.Errorf("synchronize: can't resume from heavy checkpoint, sync pulse from the beginning: %v", err)
+			if err := c.startOnHeavy(ctx, followers, signalMsg, true); err != nil {
+				return err
+			}
+			replicator = storage.NewReplicaIter(
+				ctx, c.db, jetID, pn, pn+1, c.opts.SyncMessageLimit)
 		}
+	}
+	for {
+		done, err := c.syncPayload(ctx, followers, replicator, pn)
 		if err != nil {
-			panic(err)
-		}
-		msg := &message.HeavyPayload{
-			JetID:    jetID,
-			PulseNum: pn,
-			Records:  recs,
-		}
-		if err := messageToHeavy(ctx, c.bus, msg); err != nil {
-			inslog.Error("synchronize: payload failed")
 			return err
 		}
-		inslog.Debug("synchronize: sucessfully send save message")
+		if done {
+			break
+		}
 	}
 
 	signalMsg.Finished = true
@@ -103,9 +195,97 @@ func (c *JetClient) HeavySync(
 		inslog.Error("synchronize: finish failed")
 		return err
 	}
+	c.signalToFollowers(ctx, followers, signalMsg)
+	c.setCheckpoint(ctx, nil)
 	inslog.Debug("synchronize: sucessfully send finish message")
 
 	lastMeetPulse := replicator.LastSeenPulse()
 	inslog.Debugf("synchronize: finished (maximum pulse of saved messages is %v)", lastMeetPulse)
 	return nil
 }
+
+// startOnHeavy starts sync of pulse on heavy from the beginning.
+func (c *JetClient) startOnHeavy(
+	ctx context.Context,
+	followers []core.RecordRef,
+	signalMsg *message.HeavyStartStop,
+	retry bool,
+) error {
+	jetID, pn := signalMsg.JetID, signalMsg.PulseNum
+	inslog := inslogger.FromContext(ctx)
+	inslog = inslog.WithField("jetID", jetID.DebugString())
+	inslog = inslog.WithField("pulseNum", pn)
+	if retry {
+		inslog.Info("synchronize: send reset message (retry sync)")
+		resetMsg := &message.HeavyReset{
+			JetID:    jetID,
+			PulseNum: pn,
+		}
+		if err := messageToHeavy(ctx, c.bus, resetMsg); err != nil {
+			inslog.Error("synchronize: reset failed")
+			return err
+		}
+		c.messageToFollowers(ctx, followers, resetMsg)
+	}
+
+	if err := messageToHeavy(ctx, c.bus, signalMsg); err != nil {
+		inslog.Error("synchronize: start failed")
+		return err
+	}
+	c.signalToFollowers(ctx, followers, signalMsg)
+	c.setCheckpoint(ctx, &storage.ReplicaCheckpoint{Pulse: pn})
+	inslog.Debug("synchronize: sucessfully send start message")
+	return nil
+}
+
+// syncPayload sends next chunk of records to heavy, returns true when there are no more records in pulse.
+//
+// Payload slot of pool is held while the chunk is fetched and in flight.
+func (c *JetClient) syncPayload(
+	ctx context.Context,
+	followers []core.RecordRef,
+	replicator *storage.ReplicaIter,
+	pn core.PulseNumber,
+) (bool, error) {
+	inslog := inslogger.FromContext(ctx)
+	inslog = inslog.WithField("jetID", c.jetID.DebugString())
+	inslog = inslog.WithField("pulseNum", pn)
+	if c.payloads != nil {
+		select {
+		case c.payloads <- struct{}{}:
+			defer func() { <-c.payloads }()
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+
+	recs, err := replicator.NextRecords()
+	if err == storage.ErrReplicatorDone {
+		return true, nil
+	}
+	if err != nil {
+		panic(err)
+	}
+	msg := &message.HeavyPayload{
+		JetID:    c.jetID,
+		PulseNum: pn,
+		Records:  recs,
+		Cursor:   replicator.Cursor(),
+	}
+	if err := messageToHeavy(ctx, c.bus, msg); err != nil {
+		inslog.Error("synchronize: payload failed")
+		return false, err
+	}
+	c.messageToFollowers(ctx, followers, msg)
+	c.setCheckpoint(ctx, &storage.ReplicaCheckpoint{Pulse: pn, Cursor: msg.Cursor})
+	inslog.Debug("synchronize: sucessfully send save message")
+	return false, nil
+}
+
+// setCheckpoint persists sync progress of jet, failures are only logged because heavy keeps its own checkpoint.
+func (c *JetClient) setCheckpoint(ctx context.Context, checkpoint *storage.ReplicaCheckpoint) {
+	if err := c.replicaStorage.SetSyncClientCheckpoint(ctx, c.jetID, checkpoint); err != nil {
+		inslogger.FromContext(ctx).Errorf("synchronize: failed to save sync checkpoint for jet %v: %v",
+			c.jetID.DebugString(), err)
+	}
+}
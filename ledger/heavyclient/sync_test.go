@@ -0,0 +1,97 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package heavyclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJetClient_HeavySyncSignalsFollowers(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+	pn := core.PulseNumber(core.FirstPulseNumber + 1)
+
+	follower := network.NewNodeMock(t)
+	follower.IDMock.Return(testutils.RandomRef())
+	nodeStorage := storage.NewNodeStorageMock(t)
+	nodeStorage.GetActiveNodesByRoleMock.Return([]core.Node{follower}, nil)
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentMock.Return(&core.Pulse{PulseNumber: pn}, nil)
+
+	bus := testutils.NewMessageBusMock(t)
+	bus.SendFunc = func(ctx context.Context, msg core.Message, ops *core.MessageSendOptions) (core.Reply, error) {
+		if signal, ok := msg.(*message.HeavyStartStop); ok && signal.Resume {
+			return &reply.HeavyCheckpoint{JetID: signal.JetID, PulseNum: signal.PulseNum}, nil
+		}
+		return nil, nil
+	}
+
+	tests := []struct {
+		name       string
+		checkpoint *storage.ReplicaCheckpoint
+		expected   []message.HeavyStartStop
+	}{
+		{
+			name: "start",
+			expected: []message.HeavyStartStop{
+				{JetID: jet.ZeroJetID, PulseNum: pn},
+				{JetID: jet.ZeroJetID, PulseNum: pn, Finished: true},
+			},
+		},
+		{
+			name:       "resume",
+			checkpoint: &storage.ReplicaCheckpoint{Pulse: pn},
+			expected: []message.HeavyStartStop{
+				{JetID: jet.ZeroJetID, PulseNum: pn, Resume: true},
+				{JetID: jet.ZeroJetID, PulseNum: pn, Finished: true},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			replicaStorage := storage.NewReplicaStorageMock(t)
+			replicaStorage.GetSyncClientCheckpointMock.Return(test.checkpoint, nil)
+			replicaStorage.SetSyncClientCheckpointMock.Return(nil)
+
+			c := NewJetClient(replicaStorage, bus, pulseStorage, nil, nil, db, nodeStorage, jet.ZeroJetID,
+				Options{SyncMessageLimit: 1 << 10})
+			require.NoError(t, c.HeavySync(ctx, pn, false))
+			close(c.followerQueue)
+
+			var signals []message.HeavyStartStop
+			for fm := range c.followerQueue {
+				if signal, ok := fm.msg.(*message.HeavyStartStop); ok {
+					signals = append(signals, *signal)
+				}
+			}
+			require.Equal(t, test.expected, signals)
+		})
+	}
+}
@@ -41,6 +41,15 @@ func errSyncInProgress(jetID core.RecordID, pn core.PulseNumber) *reply.HeavyErr
 	}
 }
 
+func errHeavyBusy(jetID core.RecordID, pn core.PulseNumber) *reply.HeavyError {
+	return &reply.HeavyError{
+		Message:  "Heavy node is busy",
+		SubType:  reply.ErrHeavyBusy,
+		JetID:    jetID,
+		PulseNum: pn,
+	}
+}
+
 // in testnet we start with only one jet
 type syncstate struct {
 	sync.Mutex
@@ -59,14 +68,24 @@ type Sync struct {
 
 	sync.Mutex
 	jetSyncStates map[jetprefix]*syncstate
+
+	// stores limits concurrent payload stores, nil means no limit
+	stores chan struct{}
 }
 
 // NewSync creates new Sync instance.
-func NewSync(db storage.DBContext) *Sync {
-	return &Sync{
+//
+// Param 'maxStores' limits payloads stored concurrently, Store replies with retryable busy error
+// when the limit is reached. Zero value disables the limit.
+func NewSync(db storage.DBContext, maxStores int) *Sync {
+	s := &Sync{
 		DBContext:     db,
 		jetSyncStates: map[jetprefix]*syncstate{},
 	}
+	if maxStores > 0 {
+		s.stores = make(chan struct{}, maxStores)
+	}
+	return s
 }
 
 func (s *Sync) checkIsNextPulse(ctx context.Context, jetID core.RecordID, jetstate *syncstate, pn core.PulseNumber) error {
@@ -116,6 +135,55 @@ func (s *Sync) Start(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 	jetState.Lock()
 	defer jetState.Unlock()
 
+	return s.start(ctx, jetID, jetState, pn)
+}
+
+// Resume continues heavy sync of provided pulse from the last saved checkpoint.
+//
+// Returns cursor of light replica iterator sync should be continued from. If there is no checkpoint
+// for pulse, sync starts from the beginning and nil cursor is returned.
+func (s *Sync) Resume(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) ([]byte, error) {
+	jetState := s.getJetSyncState(ctx, jetID)
+	jetState.Lock()
+	defer jetState.Unlock()
+
+	if jetState.insync {
+		return nil, errSyncInProgress(jetID, pn)
+	}
+
+	checkpoint, err := s.ReplicaStorage.GetHeavySyncCheckpoint(ctx, jetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "heavyserver: GetHeavySyncCheckpoint failed")
+	}
+	if checkpoint != nil && checkpoint.Pulse == pn {
+		inslogger.FromContext(ctx).Debugf("heavyserver: Resume sync: jetID=%v, pulse=%v", jetID, pn)
+		jetState.syncpulse = &pn
+		return checkpoint.Cursor, nil
+	}
+
+	jetState.syncpulse = nil
+	return nil, s.start(ctx, jetID, jetState, pn)
+}
+
+// Checkpoint saves position of light replica iterator after the last stored payload.
+func (s *Sync) Checkpoint(ctx context.Context, jetID core.RecordID, pn core.PulseNumber, cursor []byte) error {
+	jetState := s.getJetSyncState(ctx, jetID)
+	jetState.Lock()
+	defer jetState.Unlock()
+
+	if jetState.syncpulse == nil {
+		return fmt.Errorf("heavyserver: jet %v not in sync mode", jetID)
+	}
+	if *jetState.syncpulse != pn {
+		return fmt.Errorf("heavyserver: passed pulse %v doesn't match in-sync pulse %v", pn, *jetState.syncpulse)
+	}
+
+	err := s.ReplicaStorage.SetHeavySyncCheckpoint(ctx, jetID, &storage.ReplicaCheckpoint{Pulse: pn, Cursor: cursor})
+	return errors.Wrap(err, "heavyserver: SetHeavySyncCheckpoint failed")
+}
+
+// start should be called under jet state lock.
+func (s *Sync) start(ctx context.Context, jetID core.RecordID, jetState *syncstate, pn core.PulseNumber) error {
 	if jetState.syncpulse != nil {
 		if *jetState.syncpulse >= pn {
 			return fmt.Errorf("heavyserver: pulse %v is not greater than current in-sync pulse %v (jet=%v)",
@@ -132,6 +200,10 @@ func (s *Sync) Start(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 		return err
 	}
 
+	err := s.ReplicaStorage.SetHeavySyncCheckpoint(ctx, jetID, &storage.ReplicaCheckpoint{Pulse: pn})
+	if err != nil {
+		return errors.Wrap(err, "heavyserver: SetHeavySyncCheckpoint failed")
+	}
 	jetState.syncpulse = &pn
 	return nil
 }
@@ -143,6 +215,15 @@ func (s *Sync) Store(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 	inslog := inslogger.FromContext(ctx)
 	jetState := s.getJetSyncState(ctx, jetID)
 
+	if s.stores != nil {
+		select {
+		case s.stores <- struct{}{}:
+			defer func() { <-s.stores }()
+		default:
+			return errHeavyBusy(jetID, pn)
+		}
+	}
+
 	err := func() error {
 		jetState.Lock()
 		defer jetState.Unlock()
@@ -213,6 +294,10 @@ func (s *Sync) Stop(ctx context.Context, jetID core.RecordID, pn core.PulseNumbe
 	if err != nil {
 		return err
 	}
+	err = s.ReplicaStorage.SetHeavySyncCheckpoint(ctx, jetID, nil)
+	if err != nil {
+		return errors.Wrap(err, "heavyserver: SetHeavySyncCheckpoint failed")
+	}
 	inslogger.FromContext(ctx).Debugf("heavyserver: Fin sync: jetID=%v, pulse=%v", jetID, pn)
 	jetState.lastok = pn
 	return nil
@@ -230,5 +315,6 @@ func (s *Sync) Reset(ctx context.Context, jetID core.RecordID, pn core.PulseNumb
 
 	inslogger.FromContext(ctx).Debugf("heavyserver: Reset sync: jetID=%v, pulse=%v", jetID, pn)
 	jetState.syncpulse = nil
-	return nil
+	err := s.ReplicaStorage.SetHeavySyncCheckpoint(ctx, jetID, nil)
+	return errors.Wrap(err, "heavyserver: SetHeavySyncCheckpoint failed")
 }
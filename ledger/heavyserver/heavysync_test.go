@@ -22,6 +22,7 @@ import (
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -103,7 +104,7 @@ func (s *heavysyncSuite) TestHeavy_SyncBasic() {
 	// TODO: call every case in subtest
 	jetID := testutils.RandomJet()
 
-	sync := NewSync(s.db)
+	sync := NewSync(s.db, 0)
 	sync.ReplicaStorage = s.replicaStorage
 	err = sync.Start(s.ctx, jetID, pnum)
 	require.Error(s.T(), err, "start with zero pulse")
@@ -172,7 +173,7 @@ func (s *heavysyncSuite) TestHeavy_SyncBasic() {
 	require.NoError(s.T(), err, "stop current range")
 
 	preparepulse(pnumNextPlus) // should set corret next for previous pulse
-	sync = NewSync(s.db)
+	sync = NewSync(s.db, 0)
 	sync.ReplicaStorage = s.replicaStorage
 	err = sync.Start(s.ctx, jetID, pnumNextPlus)
 	require.NoError(s.T(), err, "start next+1 range on new sync instance (checkpoint check)")
@@ -199,7 +200,7 @@ func (s *heavysyncSuite) TestHeavy_SyncByJet() {
 	lastidx := len(jetID1) - 1
 	jetID2[lastidx] ^= 0xFF
 
-	sync := NewSync(s.db)
+	sync := NewSync(s.db, 0)
 	sync.ReplicaStorage = s.replicaStorage
 
 	pnum = core.FirstPulseNumber + 1
@@ -236,7 +237,7 @@ func (s *heavysyncSuite) TestHeavy_SyncLockOnPrefix() {
 	jetID1 := *jet.NewID(1, []byte{})
 	jetID2 := *jet.NewID(2, []byte{})
 
-	sync := NewSync(s.db)
+	sync := NewSync(s.db, 0)
 	sync.ReplicaStorage = s.replicaStorage
 
 	pnum = core.FirstPulseNumber + 2
@@ -258,6 +259,73 @@ func (s *heavysyncSuite) TestHeavy_SyncLockOnPrefix() {
 	require.NoError(s.T(), err, "should start after released lock")
 }
 
+func (s *heavysyncSuite) TestHeavy_SyncResume() {
+	var err error
+	kvalues := []core.KV{
+		{K: []byte("100"), V: []byte("500")},
+	}
+	jetID := testutils.RandomJet()
+	pnum := core.FirstPulseNumber + 1
+	preparepulse(s, pnum)
+
+	sync := NewSync(s.db, 0)
+	sync.ReplicaStorage = s.replicaStorage
+
+	cursor, err := sync.Resume(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err, "resume without checkpoint starts sync")
+	require.Nil(s.T(), cursor)
+
+	err = sync.Store(s.ctx, jetID, pnum, kvalues)
+	require.NoError(s.T(), err)
+	err = sync.Checkpoint(s.ctx, jetID, pnum+1, []byte{1, 2, 3})
+	require.Error(s.T(), err, "checkpoint from other pulse")
+	err = sync.Checkpoint(s.ctx, jetID, pnum, []byte{1, 2, 3})
+	require.NoError(s.T(), err)
+
+	// heavy restart
+	sync = NewSync(s.db, 0)
+	sync.ReplicaStorage = s.replicaStorage
+
+	cursor, err = sync.Resume(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err, "resume from checkpoint on new sync instance")
+	require.Equal(s.T(), []byte{1, 2, 3}, cursor)
+
+	err = sync.Store(s.ctx, jetID, pnum, kvalues)
+	require.NoError(s.T(), err, "store after resume")
+	err = sync.Stop(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err)
+
+	checkpoint, err := s.replicaStorage.GetHeavySyncCheckpoint(s.ctx, jetID)
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), checkpoint, "checkpoint removed on stop")
+}
+
+func (s *heavysyncSuite) TestHeavy_StoreBusy() {
+	kvalues := []core.KV{
+		{K: []byte("100"), V: []byte("500")},
+	}
+	jetID := testutils.RandomJet()
+	pnum := core.FirstPulseNumber + 1
+	preparepulse(s, pnum)
+
+	sync := NewSync(s.db, 1)
+	sync.ReplicaStorage = s.replicaStorage
+	err := sync.Start(s.ctx, jetID, pnum)
+	require.NoError(s.T(), err)
+
+	// occupy the only store slot
+	sync.stores <- struct{}{}
+	err = sync.Store(s.ctx, jetID, pnum, kvalues)
+	require.Error(s.T(), err)
+	herr, ok := err.(*reply.HeavyError)
+	require.True(s.T(), ok, "heavy error expected")
+	require.True(s.T(), herr.IsRetryable(), "busy error should be retryable")
+
+	<-sync.stores
+	err = sync.Store(s.ctx, jetID, pnum, kvalues)
+	require.NoError(s.T(), err, "store after slot released")
+}
+
 func preparepulse(s *heavysyncSuite, pn core.PulseNumber) {
 	pulse := core.Pulse{PulseNumber: pn}
 	err := s.pulseTracker.AddPulse(s.ctx, pulse)
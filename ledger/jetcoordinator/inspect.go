@@ -0,0 +1,112 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetcoordinator
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// InspectRoles returns node selections of object or jet with inputs they were calculated from.
+//
+// For object virtual and light material selections are returned, for jet only light material one.
+// Selections are calculated from scratch and do not use role cache.
+func (jc *JetCoordinator) InspectRoles(
+	ctx context.Context, id core.RecordID, pulse core.PulseNumber,
+) ([]core.RoleSelection, error) {
+	ent, err := jc.entropy(ctx, pulse)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch entropy for pulse %v", pulse)
+	}
+
+	if id.Pulse() == core.PulseNumberJet {
+		light, err := jc.inspect(
+			core.StaticRoleLightMaterial, pulse, ent, lightSeed(ent, id),
+			jc.conf.MaterialExecutorCount, jc.conf.MaterialValidatorCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		light.Jet = id
+		return []core.RoleSelection{*light}, nil
+	}
+
+	virtual, err := jc.inspect(
+		core.StaticRoleVirtual, pulse, ent, virtualSeed(ent, id),
+		jc.conf.VirtualExecutorCount, jc.conf.VirtualValidatorCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	virtual.Object = id
+
+	tree, err := jc.JetStorage.GetJetTree(ctx, pulse)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch jet tree for pulse %v", pulse)
+	}
+	jetID, _ := tree.Find(id)
+	light, err := jc.inspect(
+		core.StaticRoleLightMaterial, pulse, ent, lightSeed(ent, *jetID),
+		jc.conf.MaterialExecutorCount, jc.conf.MaterialValidatorCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	light.Object = id
+	light.Jet = *jetID
+
+	return []core.RoleSelection{*virtual, *light}, nil
+}
+
+func (jc *JetCoordinator) inspect(
+	role core.StaticRole,
+	pulse core.PulseNumber,
+	ent core.Entropy,
+	seed []byte,
+	executors, validators int,
+) (*core.RoleSelection, error) {
+	candidates, err := jc.NodeStorage.GetActiveNodesByRole(pulse, role)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch active %v nodes for pulse %v", role, pulse)
+	}
+	if len(candidates) == 0 {
+		return nil, errors.Errorf("no active %v nodes for pulse %v", role, pulse)
+	}
+	refs := sortedRefs(candidates)
+
+	selected, err := jc.Strategy.Select(jc.PlatformCryptographyScheme, seed, refs, executors+validators)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select %v nodes", role)
+	}
+
+	strategy := jc.conf.Strategy
+	if strategy == "" {
+		strategy = "entropy"
+	}
+	return &core.RoleSelection{
+		Role:       role,
+		Pulse:      pulse,
+		Strategy:   strategy,
+		Entropy:    ent,
+		Seed:       seed,
+		Candidates: refs,
+		Executors:  selected[:executors],
+		Validators: selected[executors:],
+	}, nil
+}
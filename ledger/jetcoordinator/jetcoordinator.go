@@ -22,11 +22,11 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/jet"
-	"github.com/insolar/insolar/utils/entropy"
 	"github.com/pkg/errors"
 )
 
@@ -38,21 +38,40 @@ type JetCoordinator struct {
 	JetStorage                 storage.JetStorage              `inject:""`
 	PulseTracker               storage.PulseTracker            `inject:""`
 	NodeStorage                storage.NodeStorage             `inject:""`
+
+	// Strategy selects nodes for dynamic roles, it is created from configuration on Init if not set.
+	Strategy SelectionStrategy
+
+	conf      configuration.JetCoordinator
+	roleCache *roleCache
 }
 
 // NewJetCoordinator creates new coordinator instance.
-func NewJetCoordinator() *JetCoordinator {
-	return &JetCoordinator{}
+func NewJetCoordinator(conf configuration.JetCoordinator) *JetCoordinator {
+	return &JetCoordinator{conf: conf, roleCache: newRoleCache(conf.RoleCacheSize)}
 }
 
-// Hardcoded roles count for validation and execution
-const (
-	VirtualValidatorCount  = 3
-	MaterialValidatorCount = 3
-
-	VirtualExecutorCount  = 1
-	MaterialExecutorCount = 1
-)
+// Init creates selection strategy and checks counts of roles.
+func (jc *JetCoordinator) Init(ctx context.Context) error {
+	if jc.conf.VirtualExecutorCount < 1 || jc.conf.MaterialExecutorCount < 1 {
+		return errors.New("at least one virtual and material executor should be selected")
+	}
+	if jc.conf.VirtualValidatorCount < 0 || jc.conf.MaterialValidatorCount < 0 {
+		return errors.New("validator count should not be negative")
+	}
+	if jc.conf.HeavyReplicaCount < 1 {
+		return errors.New("at least one heavy replica should be selected")
+	}
+	if jc.Strategy != nil {
+		return nil
+	}
+	strategy, err := NewSelectionStrategy(jc.conf)
+	if err != nil {
+		return errors.Wrap(err, "failed to create node selection strategy")
+	}
+	jc.Strategy = strategy
+	return nil
+}
 
 // Me returns current node.
 func (jc *JetCoordinator) Me() core.RecordRef {
@@ -125,10 +144,35 @@ func (jc *JetCoordinator) QueryRole(
 	panic("unexpected role")
 }
 
+// cached returns nodes selected for role of object or jet on pulse from cache, nodes are selected and cached
+// if missing.
+func (jc *JetCoordinator) cached(
+	role core.DynamicRole,
+	id core.RecordID,
+	pulse core.PulseNumber,
+	selectNodes func() ([]core.RecordRef, error),
+) ([]core.RecordRef, error) {
+	if jc.roleCache == nil {
+		return selectNodes()
+	}
+	key := roleCacheKey{role: role, id: id, pulse: pulse}
+	if nodes, ok := jc.roleCache.get(key); ok {
+		return nodes, nil
+	}
+	nodes, err := selectNodes()
+	if err != nil {
+		return nil, err
+	}
+	jc.roleCache.add(key, nodes)
+	return nodes, nil
+}
+
 func (jc *JetCoordinator) VirtualExecutorForObject(
 	ctx context.Context, objID core.RecordID, pulse core.PulseNumber,
 ) (*core.RecordRef, error) {
-	nodes, err := jc.virtualsForObject(ctx, objID, pulse, VirtualExecutorCount)
+	nodes, err := jc.cached(core.DynamicRoleVirtualExecutor, objID, pulse, func() ([]core.RecordRef, error) {
+		return jc.virtualsForObject(ctx, objID, pulse, jc.conf.VirtualExecutorCount)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -138,19 +182,23 @@ func (jc *JetCoordinator) VirtualExecutorForObject(
 func (jc *JetCoordinator) VirtualValidatorsForObject(
 	ctx context.Context, objID core.RecordID, pulse core.PulseNumber,
 ) ([]core.RecordRef, error) {
-	nodes, err := jc.virtualsForObject(ctx, objID, pulse, VirtualValidatorCount+VirtualExecutorCount)
-	if err != nil {
-		return nil, err
-	}
-	// Skipping `VirtualExecutorCount` for validators
-	// because it will be selected as the executor(s) for the same pulse.
-	return nodes[VirtualExecutorCount:], nil
+	return jc.cached(core.DynamicRoleVirtualValidator, objID, pulse, func() ([]core.RecordRef, error) {
+		nodes, err := jc.virtualsForObject(ctx, objID, pulse, jc.conf.VirtualValidatorCount+jc.conf.VirtualExecutorCount)
+		if err != nil {
+			return nil, err
+		}
+		// Skipping `VirtualExecutorCount` for validators
+		// because it will be selected as the executor(s) for the same pulse.
+		return nodes[jc.conf.VirtualExecutorCount:], nil
+	})
 }
 
 func (jc *JetCoordinator) LightExecutorForJet(
 	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber,
 ) (*core.RecordRef, error) {
-	nodes, err := jc.lightMaterialsForJet(ctx, jetID, pulse, MaterialExecutorCount)
+	nodes, err := jc.cached(core.DynamicRoleLightExecutor, jetID, pulse, func() ([]core.RecordRef, error) {
+		return jc.lightMaterialsForJet(ctx, jetID, pulse, jc.conf.MaterialExecutorCount)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -166,13 +214,15 @@ func (jc *JetCoordinator) LightExecutorForJet(
 func (jc *JetCoordinator) LightValidatorsForJet(
 	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber,
 ) ([]core.RecordRef, error) {
-	nodes, err := jc.lightMaterialsForJet(ctx, jetID, pulse, MaterialValidatorCount+MaterialExecutorCount)
-	if err != nil {
-		return nil, err
-	}
-	// Skipping `MaterialExecutorCount` for validators
-	// because it will be selected as the executor(s) for the same pulse.
-	return nodes[MaterialExecutorCount:], nil
+	return jc.cached(core.DynamicRoleLightValidator, jetID, pulse, func() ([]core.RecordRef, error) {
+		nodes, err := jc.lightMaterialsForJet(ctx, jetID, pulse, jc.conf.MaterialValidatorCount+jc.conf.MaterialExecutorCount)
+		if err != nil {
+			return nil, err
+		}
+		// Skipping `MaterialExecutorCount` for validators
+		// because it will be selected as the executor(s) for the same pulse.
+		return nodes[jc.conf.MaterialExecutorCount:], nil
+	})
 }
 
 func (jc *JetCoordinator) LightExecutorForObject(
@@ -197,7 +247,35 @@ func (jc *JetCoordinator) LightValidatorsForObject(
 	return jc.LightValidatorsForJet(ctx, *jetID, pulse)
 }
 
+// Heavy returns heavy material executor for pulse. It is the first replica of pulse that is active in network,
+// or the first replica if none is active.
 func (jc *JetCoordinator) Heavy(ctx context.Context, pulse core.PulseNumber) (*core.RecordRef, error) {
+	replicas, err := jc.cached(core.DynamicRoleHeavyExecutor, core.RecordID{}, pulse, func() ([]core.RecordRef, error) {
+		return jc.heavyReplicas(ctx, pulse)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(replicas) == 1 {
+		return &replicas[0], nil
+	}
+	for i := range replicas {
+		if jc.NodeNet.GetActiveNode(replicas[i]) != nil {
+			if i > 0 {
+				inslogger.FromContext(ctx).Warnf(
+					"heavy replicas %v are unreachable, failover to %v for pulse %v", replicas[:i], replicas[i], pulse,
+				)
+			}
+			return &replicas[i], nil
+		}
+	}
+	inslogger.FromContext(ctx).Errorf("all heavy replicas for pulse %v are unreachable", pulse)
+	return &replicas[0], nil
+}
+
+// heavyReplicas returns heavy material nodes in failover order, count of replicas is limited by count of active
+// heavy nodes of pulse.
+func (jc *JetCoordinator) heavyReplicas(ctx context.Context, pulse core.PulseNumber) ([]core.RecordRef, error) {
 	candidates, err := jc.NodeStorage.GetActiveNodesByRole(pulse, core.StaticRoleHeavyMaterial)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch active heavy nodes for pulse %v", pulse)
@@ -210,16 +288,15 @@ func (jc *JetCoordinator) Heavy(ctx context.Context, pulse core.PulseNumber) (*c
 		return nil, errors.Wrapf(err, "failed to fetch entropy for pulse %v", pulse)
 	}
 
-	nodes, err := getRefs(
-		jc.PlatformCryptographyScheme,
+	count := jc.conf.HeavyReplicaCount
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	return jc.getRefs(
 		ent[:],
 		candidates,
-		1,
+		count,
 	)
-	if err != nil {
-		return nil, err
-	}
-	return &nodes[0], nil
 }
 
 func (jc *JetCoordinator) virtualsForObject(
@@ -238,9 +315,8 @@ func (jc *JetCoordinator) virtualsForObject(
 		return nil, errors.Wrapf(err, "failed to fetch entropy for pulse %v", pulse)
 	}
 
-	return getRefs(
-		jc.PlatformCryptographyScheme,
-		circleXOR(ent[:], objID.Hash()),
+	return jc.getRefs(
+		virtualSeed(ent, objID),
 		candidates,
 		count,
 	)
@@ -249,8 +325,6 @@ func (jc *JetCoordinator) virtualsForObject(
 func (jc *JetCoordinator) lightMaterialsForJet(
 	ctx context.Context, jetID core.RecordID, pulse core.PulseNumber, count int,
 ) ([]core.RecordRef, error) {
-	_, prefix := jet.Jet(jetID)
-
 	candidates, err := jc.NodeStorage.GetActiveNodesByRole(pulse, core.StaticRoleLightMaterial)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch active light nodes for pulse %v", pulse)
@@ -264,9 +338,8 @@ func (jc *JetCoordinator) lightMaterialsForJet(
 		return nil, errors.Wrapf(err, "failed to fetch entropy for pulse %v", pulse)
 	}
 
-	return getRefs(
-		jc.PlatformCryptographyScheme,
-		circleXOR(ent[:], prefix),
+	return jc.getRefs(
+		lightSeed(ent, jetID),
 		candidates,
 		count,
 	)
@@ -290,32 +363,38 @@ func (jc *JetCoordinator) entropy(ctx context.Context, pulse core.PulseNumber) (
 	return older.Pulse.Entropy, nil
 }
 
-func getRefs(
-	scheme core.PlatformCryptographyScheme,
+func (jc *JetCoordinator) getRefs(
 	e []byte,
 	values []core.Node,
 	count int,
 ) ([]core.RecordRef, error) {
+	return jc.Strategy.Select(jc.PlatformCryptographyScheme, e, sortedRefs(values), count)
+}
+
+// sortedRefs returns references of nodes sorted in ascending order.
+func sortedRefs(values []core.Node) []core.RecordRef {
 	// TODO: remove sort when network provides sorted result from GetActiveNodesByRole (INS-890) - @nordicdyno 5.Dec.2018
 	sort.SliceStable(values, func(i, j int) bool {
 		v1 := values[i].ID()
 		v2 := values[j].ID()
 		return bytes.Compare(v1[:], v2[:]) < 0
 	})
-	in := make([]interface{}, 0, len(values))
+	refs := make([]core.RecordRef, 0, len(values))
 	for _, value := range values {
-		in = append(in, interface{}(value.ID()))
+		refs = append(refs, value.ID())
 	}
+	return refs
+}
 
-	res, err := entropy.SelectByEntropy(scheme, e, in, count)
-	if err != nil {
-		return nil, err
-	}
-	out := make([]core.RecordRef, 0, len(res))
-	for _, value := range res {
-		out = append(out, value.(core.RecordRef))
-	}
-	return out, nil
+// virtualSeed returns seed of virtual nodes selection for object.
+func virtualSeed(ent core.Entropy, objID core.RecordID) []byte {
+	return circleXOR(ent[:], objID.Hash())
+}
+
+// lightSeed returns seed of light material nodes selection for jet.
+func lightSeed(ent core.Entropy, jetID core.RecordID) []byte {
+	_, prefix := jet.Jet(jetID)
+	return circleXOR(ent[:], prefix)
 }
 
 // CircleXOR performs XOR for 'value' and 'src'. The result is returned as new byte slice.
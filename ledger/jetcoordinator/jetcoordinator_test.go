@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage"
@@ -68,7 +69,7 @@ func (s *jetCoordinatorSuite) BeforeTest(suiteName, testName string) {
 	s.pulseStorage = storage.NewPulseStorage()
 	s.jetStorage = storage.NewJetStorage()
 	s.nodeStorages = storage.NewNodeStorage()
-	s.coordinator = NewJetCoordinator()
+	s.coordinator = NewJetCoordinator(configuration.NewLedger().JetCoordinator)
 	s.coordinator.NodeNet = network.NewNodeNetworkMock(s.T())
 
 	s.cm.Inject(
@@ -123,3 +124,93 @@ func (s *jetCoordinatorSuite) TestJetCoordinator_QueryRole() {
 	// Indexes are hard-coded from previously calculated values.
 	assert.Equal(s.T(), []core.RecordRef{nodeRefs[16], nodeRefs[21], nodeRefs[78]}, selected)
 }
+
+func (s *jetCoordinatorSuite) TestJetCoordinator_InspectRoles() {
+	err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: 0, Entropy: core.Entropy{1, 2, 3}})
+	require.NoError(s.T(), err)
+	var nodes []core.Node
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes,
+			storage.Node{FID: testutils.RandomRef(), FRole: core.StaticRoleVirtual},
+			storage.Node{FID: testutils.RandomRef(), FRole: core.StaticRoleLightMaterial},
+		)
+	}
+	err = s.nodeStorages.SetActiveNodes(0, nodes)
+	require.NoError(s.T(), err)
+
+	objID := core.NewRecordID(0, []byte{1, 42, 123})
+	jetID := jet.NewID(50, []byte{1, 42, 123})
+	err = s.jetStorage.UpdateJetTree(s.ctx, 0, true, *jetID)
+	require.NoError(s.T(), err)
+
+	selections, err := s.coordinator.InspectRoles(s.ctx, *objID, 0)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 2, len(selections))
+
+	virtual, light := selections[0], selections[1]
+	assert.Equal(s.T(), core.StaticRoleVirtual, virtual.Role)
+	assert.Equal(s.T(), *objID, virtual.Object)
+	assert.Equal(s.T(), "entropy", virtual.Strategy)
+	assert.Equal(s.T(), core.Entropy{1, 2, 3}, virtual.Entropy)
+	assert.Equal(s.T(), 10, len(virtual.Candidates))
+	executor, err := s.coordinator.VirtualExecutorForObject(s.ctx, *objID, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []core.RecordRef{*executor}, virtual.Executors)
+	validators, err := s.coordinator.VirtualValidatorsForObject(s.ctx, *objID, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), validators, virtual.Validators)
+
+	assert.Equal(s.T(), core.StaticRoleLightMaterial, light.Role)
+	assert.Equal(s.T(), *jetID, light.Jet)
+	executor, err = s.coordinator.LightExecutorForJet(s.ctx, *jetID, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []core.RecordRef{*executor}, light.Executors)
+
+	selections, err = s.coordinator.InspectRoles(s.ctx, *jetID, 0)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, len(selections))
+	assert.Equal(s.T(), core.RecordID{}, selections[0].Object)
+	assert.Equal(s.T(), light.Seed, selections[0].Seed)
+	assert.Equal(s.T(), light.Executors, selections[0].Executors)
+	assert.Equal(s.T(), light.Validators, selections[0].Validators)
+}
+
+func (s *jetCoordinatorSuite) TestJetCoordinator_HeavyFailover() {
+	err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: 0, Entropy: core.Entropy{1, 2, 3}})
+	require.NoError(s.T(), err)
+	var nodes []core.Node
+	for i := 0; i < 5; i++ {
+		nodes = append(nodes, storage.Node{FID: testutils.RandomRef(), FRole: core.StaticRoleHeavyMaterial})
+	}
+	err = s.nodeStorages.SetActiveNodes(0, nodes)
+	require.NoError(s.T(), err)
+
+	s.coordinator.conf.HeavyReplicaCount = 3
+	replicas, err := s.coordinator.heavyReplicas(s.ctx, 0)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 3, len(replicas))
+
+	unreachable := map[core.RecordRef]bool{replicas[0]: true}
+	nodeNet := network.NewNodeNetworkMock(s.T())
+	nodeNet.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		if unreachable[ref] {
+			return nil
+		}
+		return storage.Node{FID: ref, FRole: core.StaticRoleHeavyMaterial}
+	}
+	s.coordinator.NodeNet = nodeNet
+
+	heavy, err := s.coordinator.Heavy(s.ctx, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), replicas[1], *heavy)
+
+	unreachable[replicas[1]] = true
+	selected, err := s.coordinator.QueryRole(s.ctx, core.DynamicRoleHeavyExecutor, core.RecordID{}, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []core.RecordRef{replicas[2]}, selected)
+
+	unreachable[replicas[2]] = true
+	heavy, err = s.coordinator.Heavy(s.ctx, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), replicas[0], *heavy)
+}
@@ -0,0 +1,108 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetcoordinator
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+)
+
+// roleCacheKey identifies nodes selected for dynamic role of object or jet on pulse.
+type roleCacheKey struct {
+	role  core.DynamicRole
+	id    core.RecordID
+	pulse core.PulseNumber
+}
+
+type roleCacheEntry struct {
+	key   roleCacheKey
+	nodes []core.RecordRef
+}
+
+// roleCache is LRU cache of nodes selected for roles. Entries of pulses older than the previous one are dropped
+// when a newer pulse is queried, as selection for them is rarely needed again.
+type roleCache struct {
+	lock    sync.Mutex
+	limit   int
+	entries map[roleCacheKey]*list.Element
+	lru     *list.List
+	// latest and previous are the latest pulses cache was queried with
+	latest, previous core.PulseNumber
+}
+
+func newRoleCache(limit int) *roleCache {
+	return &roleCache{
+		limit:   limit,
+		entries: make(map[roleCacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// get returns copy of cached nodes.
+func (c *roleCache) get(key roleCacheKey) ([]core.RecordRef, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.observe(key.pulse)
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	nodes := e.Value.(*roleCacheEntry).nodes
+	return append([]core.RecordRef(nil), nodes...), true
+}
+
+func (c *roleCache) add(key roleCacheKey, nodes []core.RecordRef) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.limit <= 0 || key.pulse < c.previous {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		return
+	}
+	entry := &roleCacheEntry{key: key, nodes: append([]core.RecordRef(nil), nodes...)}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.limit {
+		c.remove(c.lru.Back())
+	}
+}
+
+// observe drops entries of pulses older than the previous one if pulse is newer than the latest one.
+func (c *roleCache) observe(pulse core.PulseNumber) {
+	if pulse <= c.latest {
+		return
+	}
+	c.previous, c.latest = c.latest, pulse
+	for e := c.lru.Back(); e != nil; {
+		prev := e.Prev()
+		if e.Value.(*roleCacheEntry).key.pulse < c.previous {
+			c.remove(e)
+		}
+		e = prev
+	}
+}
+
+func (c *roleCache) remove(e *list.Element) {
+	c.lru.Remove(e)
+	delete(c.entries, e.Value.(*roleCacheEntry).key)
+}
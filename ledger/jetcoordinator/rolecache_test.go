@@ -0,0 +1,113 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetcoordinator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleCache(t *testing.T) {
+	c := newRoleCache(2)
+	first := roleCacheKey{role: core.DynamicRoleVirtualExecutor, id: *core.NewRecordID(10, []byte{1}), pulse: 10}
+	second := roleCacheKey{role: core.DynamicRoleVirtualValidator, id: first.id, pulse: 10}
+	third := roleCacheKey{role: core.DynamicRoleLightExecutor, id: first.id, pulse: 10}
+	nodes := selectionCandidates(3)
+
+	_, ok := c.get(first)
+	assert.False(t, ok)
+	c.add(first, nodes[:1])
+	c.add(second, nodes[1:])
+
+	got, ok := c.get(first)
+	require.True(t, ok)
+	assert.Equal(t, nodes[:1], got)
+	got[0] = nodes[2]
+	got, _ = c.get(first)
+	assert.Equal(t, nodes[:1], got)
+
+	// second is the least recently used one
+	c.add(third, nodes[2:])
+	_, ok = c.get(second)
+	assert.False(t, ok)
+	_, ok = c.get(third)
+	assert.True(t, ok)
+
+	// entries of the previous pulse are kept, older ones are dropped
+	next := roleCacheKey{role: first.role, id: first.id, pulse: 20}
+	_, ok = c.get(next)
+	assert.False(t, ok)
+	_, ok = c.get(first)
+	assert.True(t, ok)
+	_, ok = c.get(roleCacheKey{role: first.role, id: first.id, pulse: 30})
+	assert.False(t, ok)
+	_, ok = c.get(first)
+	assert.False(t, ok)
+	c.add(first, nodes[:1])
+	_, ok = c.get(first)
+	assert.False(t, ok)
+}
+
+func benchmarkCoordinator(b *testing.B, cacheSize int) *JetCoordinator {
+	var nodes []core.Node
+	for _, ref := range selectionCandidates(100) {
+		nodes = append(nodes, storage.Node{FID: ref, FRole: core.StaticRoleVirtual})
+	}
+	ns := storage.NewNodeStorageMock(b)
+	ns.GetActiveNodesByRoleMock.Return(nodes, nil)
+	ps := testutils.NewPulseStorageMock(b)
+	ps.CurrentMock.Return(&core.Pulse{PulseNumber: core.FirstPulseNumber, Entropy: core.Entropy{1, 2, 3}}, nil)
+
+	conf := configuration.NewLedger().JetCoordinator
+	conf.RoleCacheSize = cacheSize
+	jc := NewJetCoordinator(conf)
+	jc.NodeStorage = ns
+	jc.PulseStorage = ps
+	jc.PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	require.NoError(b, jc.Init(context.Background()))
+	return jc
+}
+
+// BenchmarkJetCoordinator_QueryRole measures routing of messages to validators of 10 objects.
+func BenchmarkJetCoordinator_QueryRole(b *testing.B) {
+	for name, size := range map[string]int{"uncached": 0, "cached": 10000} {
+		b.Run(name, func(b *testing.B) {
+			jc := benchmarkCoordinator(b, size)
+			ctx := context.Background()
+			var objects []core.RecordID
+			for i := 0; i < 10; i++ {
+				objects = append(objects, *core.NewRecordID(core.FirstPulseNumber, []byte{byte(i)}))
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := jc.QueryRole(ctx, core.DynamicRoleVirtualValidator, objects[i%len(objects)], core.FirstPulseNumber)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetcoordinator
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/utils/entropy"
+	"github.com/pkg/errors"
+)
+
+// SelectionStrategy selects nodes for dynamic role.
+type SelectionStrategy interface {
+	// Select returns count of candidates selected by seed. Candidates are sorted by reference.
+	Select(scheme core.PlatformCryptographyScheme, seed []byte, candidates []core.RecordRef, count int) ([]core.RecordRef, error)
+}
+
+// NewSelectionStrategy creates selection strategy configured by name.
+func NewSelectionStrategy(conf configuration.JetCoordinator) (SelectionStrategy, error) {
+	switch conf.Strategy {
+	case "", "entropy":
+		return EntropyStrategy{}, nil
+	case "rendezvous":
+		return RendezvousStrategy{}, nil
+	case "stake":
+		stakes := make(map[core.RecordRef]uint64, len(conf.Stakes))
+		for node, stake := range conf.Stakes {
+			ref, err := core.NewRefFromBase58(node)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid reference of staked node %s", node)
+			}
+			stakes[*ref] = stake
+		}
+		return StakeStrategy{Stakes: stakes}, nil
+	}
+	return nil, errors.Errorf("unknown node selection strategy %s", conf.Strategy)
+}
+
+// EntropyStrategy selects nodes by hashes of seed and step number.
+type EntropyStrategy struct{}
+
+// Select returns count of candidates selected by seed.
+func (EntropyStrategy) Select(
+	scheme core.PlatformCryptographyScheme, seed []byte, candidates []core.RecordRef, count int,
+) ([]core.RecordRef, error) {
+	in := make([]interface{}, 0, len(candidates))
+	for _, candidate := range candidates {
+		in = append(in, interface{}(candidate))
+	}
+
+	res, err := entropy.SelectByEntropy(scheme, seed, in, count)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]core.RecordRef, 0, len(res))
+	for _, value := range res {
+		out = append(out, value.(core.RecordRef))
+	}
+	return out, nil
+}
+
+// RendezvousStrategy selects nodes with the highest hash of seed and node reference. Adding or removing a node
+// changes selection only for seeds it wins or won, unlike EntropyStrategy.
+type RendezvousStrategy struct{}
+
+// Select returns count of candidates selected by seed.
+func (RendezvousStrategy) Select(
+	scheme core.PlatformCryptographyScheme, seed []byte, candidates []core.RecordRef, count int,
+) ([]core.RecordRef, error) {
+	return selectByScore(candidates, count, func(ref core.RecordRef) float64 {
+		return -float64(rendezvousHash(scheme, seed, ref))
+	})
+}
+
+// StakeStrategy is weighted rendezvous hashing, node is selected with probability proportional to its stake.
+// Nodes without stake have weight 1, nodes with zero stake are never selected.
+type StakeStrategy struct {
+	Stakes map[core.RecordRef]uint64
+}
+
+// Select returns count of candidates selected by seed.
+func (s StakeStrategy) Select(
+	scheme core.PlatformCryptographyScheme, seed []byte, candidates []core.RecordRef, count int,
+) ([]core.RecordRef, error) {
+	var staked []core.RecordRef
+	for _, candidate := range candidates {
+		if stake, ok := s.Stakes[candidate]; !ok || stake > 0 {
+			staked = append(staked, candidate)
+		}
+	}
+	return selectByScore(staked, count, func(ref core.RecordRef) float64 {
+		stake, ok := s.Stakes[ref]
+		if !ok {
+			stake = 1
+		}
+		// uniform value in (0, 1)
+		u := (float64(rendezvousHash(scheme, seed, ref)) + 1) / (math.MaxUint64 + 2)
+		return -math.Log(u) / float64(stake)
+	})
+}
+
+func rendezvousHash(scheme core.PlatformCryptographyScheme, seed []byte, ref core.RecordRef) uint64 {
+	h := scheme.ReferenceHasher()
+	_, _ = h.Write(seed)
+	_, _ = h.Write(ref[:])
+	return binary.BigEndian.Uint64(h.Sum(nil))
+}
+
+// selectByScore returns count of candidates with the lowest scores, ties are broken by reference.
+func selectByScore(candidates []core.RecordRef, count int, score func(core.RecordRef) float64) ([]core.RecordRef, error) {
+	if count > len(candidates) {
+		return nil, errors.New("count value should be less than values size")
+	}
+	scores := make(map[core.RecordRef]float64, len(candidates))
+	sorted := make([]core.RecordRef, len(candidates))
+	for i, candidate := range candidates {
+		scores[candidate] = score(candidate)
+		sorted[i] = candidate
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return scores[sorted[i]] < scores[sorted[j]]
+	})
+	return sorted[:count], nil
+}
@@ -0,0 +1,126 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetcoordinator
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selectionCandidates(count int) []core.RecordRef {
+	var refs []core.RecordRef
+	for i := 0; i < count; i++ {
+		refs = append(refs, *core.NewRecordRef(core.DomainID, *core.NewRecordID(0, []byte{byte(i)})))
+	}
+	return refs
+}
+
+func TestNewSelectionStrategy(t *testing.T) {
+	for name, expected := range map[string]SelectionStrategy{
+		"":           EntropyStrategy{},
+		"entropy":    EntropyStrategy{},
+		"rendezvous": RendezvousStrategy{},
+	} {
+		strategy, err := NewSelectionStrategy(configuration.JetCoordinator{Strategy: name})
+		require.NoError(t, err)
+		assert.Equal(t, expected, strategy)
+	}
+
+	node := testutils.RandomRef()
+	strategy, err := NewSelectionStrategy(configuration.JetCoordinator{
+		Strategy: "stake",
+		Stakes:   map[string]uint64{node.String(): 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StakeStrategy{Stakes: map[core.RecordRef]uint64{node: 10}}, strategy)
+
+	_, err = NewSelectionStrategy(configuration.JetCoordinator{Strategy: "stake", Stakes: map[string]uint64{"bad": 1}})
+	assert.Error(t, err)
+	_, err = NewSelectionStrategy(configuration.JetCoordinator{Strategy: "random"})
+	assert.Error(t, err)
+}
+
+func TestRendezvousStrategy_Select(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	candidates := selectionCandidates(20)
+	seed := []byte{1, 2, 3}
+
+	selected, err := RendezvousStrategy{}.Select(scheme, seed, candidates, 3)
+	require.NoError(t, err)
+	assert.Len(t, selected, 3)
+
+	again, err := RendezvousStrategy{}.Select(scheme, seed, candidates, 3)
+	require.NoError(t, err)
+	assert.Equal(t, selected, again)
+
+	// removing not selected nodes doesn't change selection
+	isSelected := map[core.RecordRef]bool{selected[0]: true, selected[1]: true, selected[2]: true}
+	var rest []core.RecordRef
+	for i, candidate := range candidates {
+		if isSelected[candidate] || i%2 == 0 {
+			rest = append(rest, candidate)
+		}
+	}
+	reduced, err := RendezvousStrategy{}.Select(scheme, seed, rest, 3)
+	require.NoError(t, err)
+	assert.Equal(t, selected, reduced)
+
+	_, err = RendezvousStrategy{}.Select(scheme, seed, candidates, 21)
+	assert.Error(t, err)
+}
+
+func TestStakeStrategy_Select(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	candidates := selectionCandidates(3)
+	strategy := StakeStrategy{Stakes: map[core.RecordRef]uint64{
+		candidates[0]: 0,
+		candidates[1]: 100,
+	}}
+
+	wins := make(map[core.RecordRef]int)
+	for i := 0; i < 200; i++ {
+		selected, err := strategy.Select(scheme, []byte{byte(i), byte(i >> 8)}, candidates, 1)
+		require.NoError(t, err)
+		wins[selected[0]]++
+	}
+	assert.Zero(t, wins[candidates[0]])
+	assert.True(t, wins[candidates[1]] > wins[candidates[2]]*10)
+
+	_, err := strategy.Select(scheme, []byte{1}, candidates, 3)
+	assert.Error(t, err)
+}
+
+func TestJetCoordinator_Init(t *testing.T) {
+	conf := configuration.NewLedger().JetCoordinator
+	jc := NewJetCoordinator(conf)
+	require.NoError(t, jc.Init(inslogger.TestContext(t)))
+	assert.Equal(t, EntropyStrategy{}, jc.Strategy)
+
+	conf.Strategy = "random"
+	assert.Error(t, NewJetCoordinator(conf).Init(inslogger.TestContext(t)))
+
+	conf = configuration.NewLedger().JetCoordinator
+	conf.VirtualExecutorCount = 0
+	assert.Error(t, NewJetCoordinator(conf).Init(inslogger.TestContext(t)))
+}
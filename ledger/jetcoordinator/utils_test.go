@@ -117,9 +117,11 @@ func benchSelectByEntropyWrapped(b *testing.B, valuescount int, count int) {
 		values = append(values, storage.Node{FID: coreref})
 	}
 
+	jc := &JetCoordinator{PlatformCryptographyScheme: scheme, Strategy: EntropyStrategy{}}
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		refresults, _ = getRefs(scheme, e[:], values, count)
+		refresults, _ = jc.getRefs(e[:], values, count)
 	}
 }
 
@@ -18,6 +18,7 @@ package ledger
 
 import (
 	"context"
+	"os"
 
 	"github.com/pkg/errors"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/insolar/insolar/ledger/jetcoordinator"
 	"github.com/insolar/insolar/ledger/localstorage"
 	"github.com/insolar/insolar/ledger/pulsemanager"
+	"github.com/insolar/insolar/ledger/recovery"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/log"
 )
@@ -94,30 +96,65 @@ func GetLedgerComponents(conf configuration.Ledger, certificate core.Certificate
 	if err != nil {
 		panic(errors.Wrap(err, "failed to initialize DB"))
 	}
+	if conf.Snapshot.Restore != "" {
+		if err := restoreSnapshot(context.Background(), db, conf.Snapshot); err != nil {
+			panic(errors.Wrap(err, "failed to restore snapshot"))
+		}
+	}
+
+	jetStorage := storage.NewJetStorage()
+	if conf.CheckJetTreeInvariants {
+		jetStorage = storage.NewCheckedJetStorage()
+	}
 
 	return []interface{}{
 		db,
 		storage.NewCleaner(),
 		storage.NewPulseTracker(),
 		storage.NewPulseStorage(),
-		storage.NewJetStorage(),
+		jetStorage,
 		storage.NewDropStorage(conf.JetSizesHistoryDepth),
 		storage.NewNodeStorage(),
 		storage.NewObjectStorage(),
 		storage.NewReplicaStorage(),
+		storage.NewFaultEvidenceStorage(),
+		storage.NewAdminLogStorage(),
+		storage.NewCloudHashStorage(),
 		storage.NewGenesisInitializer(),
-		storage.NewRecentStorageProvider(conf.RecentStorage.DefaultTTL),
+		recovery.NewReporter(conf),
+		storage.NewRecentStorageProvider(conf.RecentStorage.DefaultTTL, conf.RecentStorage.MaxObjects),
 		artifactmanager.NewHotDataWaiterConcrete(),
-		artifactmanager.NewArtifactManger(),
-		jetcoordinator.NewJetCoordinator(),
+		artifactmanager.NewArtifactManger(conf.MaxStateSize),
+		jetcoordinator.NewJetCoordinator(conf.JetCoordinator),
 		pulsemanager.NewPulseManager(conf),
 		artifactmanager.NewMessageHandler(&conf, certificate),
 		localstorage.NewLocalStorage(db),
-		heavyserver.NewSync(db),
+		heavyserver.NewSync(db, conf.PulseManager.HeavySyncMaxConcurrentStores),
 		exporter.NewExporter(conf.Exporter),
 	}
 }
 
+// restoreSnapshot seeds empty storage with snapshot, so node starts from snapshot pulse instead of genesis.
+// Storage with data is left as is.
+func restoreSnapshot(ctx context.Context, db storage.DBContext, conf configuration.Snapshot) error {
+	snapshot, err := os.Open(conf.Restore)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close() // nolint: errcheck
+
+	header, keys, err := storage.RestoreSnapshot(ctx, db, snapshot, conf.BatchSize)
+	if err == storage.ErrNotEmpty {
+		log.Infof("Storage has data, snapshot %s is not restored", conf.Restore)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("Storage is seeded from snapshot %s of pulse %d, %d keys", conf.Restore, header.Pulse, keys)
+	return nil
+}
+
 // Start stub.
 func (l *Ledger) Start(ctx context.Context) error {
 	return nil
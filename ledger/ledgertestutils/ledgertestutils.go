@@ -30,6 +30,7 @@ import (
 	"github.com/insolar/insolar/ledger/localstorage"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/recovery"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/jet"
 	"github.com/insolar/insolar/ledger/storage/storagetest"
@@ -60,14 +61,14 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	gi := storage.NewGenesisInitializer()
 	pt := storage.NewPulseTracker()
 	ps := storage.NewPulseStorage()
-	js := storage.NewJetStorage()
+	js := storage.NewCheckedJetStorage()
 	os := storage.NewObjectStorage()
 	ns := storage.NewNodeStorage()
 	ds := storage.NewDropStorage(10)
 	rs := storage.NewReplicaStorage()
 	cl := storage.NewCleaner()
 
-	am := artifactmanager.NewArtifactManger()
+	am := artifactmanager.NewArtifactManger(conf.MaxStateSize)
 
 	am.PlatformCryptographyScheme = pcs
 
@@ -166,6 +167,7 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	pm.PulseTracker = pt
 	pm.ReplicaStorage = rs
 	pm.StorageCleaner = cl
+	pm.LastKnownGood = recovery.NewLastKnownGoodMock(mc).MarkLastKnownGoodMock.Return(nil)
 
 	hdw := artifactmanager.NewHotDataWaiterConcrete()
 
@@ -0,0 +1,119 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsemanager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/recentstorage"
+)
+
+// evictedObjects keeps objects evicted from full recent storage before their TTL expired, so their indexes are still
+// handed over to the next executor of jet with hot data. Zero value is ready to use.
+type evictedObjects struct {
+	lock    sync.Mutex
+	objects map[core.RecordID]map[core.RecordID]int
+}
+
+// add remembers object evicted from recent storage of jet with its TTL.
+func (e *evictedObjects) add(jetID, id core.RecordID, ttl int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.objects == nil {
+		e.objects = map[core.RecordID]map[core.RecordID]int{}
+	}
+	if _, ok := e.objects[jetID]; !ok {
+		e.objects[jetID] = map[core.RecordID]int{}
+	}
+	e.objects[jetID][id] = ttl
+}
+
+// get returns objects evicted from recent storage of jet with their TTL.
+func (e *evictedObjects) get(jetID core.RecordID) map[core.RecordID]int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	result := make(map[core.RecordID]int, len(e.objects[jetID]))
+	for id, ttl := range e.objects[jetID] {
+		result[id] = ttl
+	}
+	return result
+}
+
+// clone copies evicted objects of jet to another jet on split.
+func (e *evictedObjects) clone(fromJetID, toJetID core.RecordID) {
+	for id, ttl := range e.get(fromJetID) {
+		e.add(toJetID, id, ttl)
+	}
+}
+
+// remove forgets evicted objects of jet after they are handed over.
+func (e *evictedObjects) remove(jetID core.RecordID) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	delete(e.objects, jetID)
+}
+
+// decreaseTTL decreases TTL of evicted objects on pulse, objects with expired TTL are forgotten.
+func (e *evictedObjects) decreaseTTL() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for jetID, objects := range e.objects {
+		for id := range objects {
+			objects[id]--
+			if objects[id] <= 0 {
+				delete(objects, id)
+			}
+		}
+		if len(objects) == 0 {
+			delete(e.objects, jetID)
+		}
+	}
+}
+
+// keepEvictedProvider is recent storage provider for cleaner, indexes of evicted objects are kept as indexes of
+// objects in recent storage until they are handed over.
+type keepEvictedProvider struct {
+	recentstorage.Provider
+	evicted *evictedObjects
+}
+
+// GetStorage returns recent storage of jet which reports evicted objects as cached.
+func (p keepEvictedProvider) GetStorage(ctx context.Context, jetID core.RecordID) recentstorage.RecentStorage {
+	return keepEvictedStorage{
+		RecentStorage: p.Provider.GetStorage(ctx, jetID),
+		evicted:       p.evicted.get(jetID),
+	}
+}
+
+type keepEvictedStorage struct {
+	recentstorage.RecentStorage
+	evicted map[core.RecordID]int
+}
+
+// IsRecordIDCached checks if object is in recent storage or is evicted from it.
+func (s keepEvictedStorage) IsRecordIDCached(obj core.RecordID) bool {
+	if _, ok := s.evicted[obj]; ok {
+		return true
+	}
+	return s.RecentStorage.IsRecordIDCached(obj)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/insolar/insolar/ledger/artifactmanager"
 	"github.com/insolar/insolar/ledger/heavyclient"
 	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/recovery"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -68,10 +69,14 @@ type PulseManager struct {
 	ReplicaStorage             storage.ReplicaStorage          `inject:""`
 	DBContext                  storage.DBContext               `inject:""`
 	StorageCleaner             storage.Cleaner                 `inject:""`
+	LastKnownGood              recovery.LastKnownGood          `inject:""`
 
 	// TODO: move clients pool to component - @nordicdyno - 18.Dec.2018
 	syncClientsPool *heavyclient.Pool
 
+	// evicted are objects evicted from full recent storages, they are handed over with hot data
+	evicted evictedObjects
+
 	currentPulse core.Pulse
 
 	// setLock locks Set method call.
@@ -96,13 +101,35 @@ type pmOptions struct {
 	splitThreshold        uint64
 	dropHistorySize       int
 	storeLightPulses      int
+	recordTTL             map[storage.RecordClass]int
 	heavySyncMessageLimit int
 	lightChainLimit       int
+	heavySyncMaxInFlight  int
+	stateDigest           bool
+	snapshotDir           string
 }
 
 // NewPulseManager creates PulseManager instance.
 func NewPulseManager(conf configuration.Ledger) *PulseManager {
 	pmconf := conf.PulseManager
+	// light material serves requests for LightChainLimit pulses, so data can't be removed earlier
+	keepPulses := conf.LightChainLimit
+	if pmconf.LightKeepPulses > keepPulses {
+		keepPulses = pmconf.LightKeepPulses
+	}
+	// transient records are kept less than other data only if configured so
+	recordTTL := map[storage.RecordClass]int{}
+	for class, ttl := range map[storage.RecordClass]int{
+		storage.RecordClassRequest: pmconf.RecordTTL.Requests,
+		storage.RecordClassResult:  pmconf.RecordTTL.Results,
+	} {
+		if ttl < conf.LightChainLimit {
+			ttl = conf.LightChainLimit
+		}
+		if ttl < keepPulses {
+			recordTTL[class] = ttl
+		}
+	}
 
 	pm := &PulseManager{
 		currentPulse: *core.GenesisPulse,
@@ -110,9 +137,13 @@ func NewPulseManager(conf configuration.Ledger) *PulseManager {
 			enableSync:            pmconf.HeavySyncEnabled,
 			splitThreshold:        pmconf.SplitThreshold,
 			dropHistorySize:       conf.JetSizesHistoryDepth,
-			storeLightPulses:      conf.LightChainLimit,
+			storeLightPulses:      keepPulses,
+			recordTTL:             recordTTL,
 			heavySyncMessageLimit: pmconf.HeavySyncMessageLimit,
 			lightChainLimit:       conf.LightChainLimit,
+			heavySyncMaxInFlight:  pmconf.HeavySyncMaxInFlight,
+			stateDigest:           conf.StateDigest.Enabled,
+			snapshotDir:           conf.Snapshot.Directory,
 		},
 	}
 	return pm
@@ -139,6 +170,14 @@ func (m *PulseManager) processEndPulse(
 				return errors.Wrapf(err, "create drop on pulse %v failed", currentPulse.PulseNumber)
 			}
 
+			if m.options.stateDigest {
+				digest, err := m.stateDigest(ctx, info.id)
+				if err != nil {
+					return errors.Wrapf(err, "state digest for jet id %v failed", info.id)
+				}
+				go m.sendStateDigest(ctx, info.id, currentPulse.PulseNumber, digest)
+			}
+
 			msg, err := m.getExecutorHotData(
 				ctx, info.id, newPulse.PulseNumber, drop, dropSerialized,
 			)
@@ -172,6 +211,7 @@ func (m *PulseManager) processEndPulse(
 				// No split happened.
 				if !info.mineNext {
 					go sender(*msg, info.id)
+					m.evicted.remove(info.id)
 				}
 			} else {
 				// Split happened.
@@ -181,6 +221,7 @@ func (m *PulseManager) processEndPulse(
 				if !info.right.mineNext {
 					go sender(*msg, info.right.id)
 				}
+				m.evicted.remove(info.id)
 			}
 
 			requests := m.RecentStorageProvider.GetStorage(ctx, info.id).GetRequests()
@@ -356,7 +397,7 @@ func (m *PulseManager) getExecutorHotData(
 
 	logger := inslogger.FromContext(ctx)
 	recentStorage := m.RecentStorageProvider.GetStorage(ctx, jetID)
-	recentObjectsIds := recentStorage.GetObjects()
+	recentObjectsIds := m.hotObjects(ctx, jetID)
 
 	recentObjects := map[core.RecordID]*message.HotIndex{}
 	pendingRequests := map[core.RecordID]map[core.RecordID][]byte{}
@@ -424,6 +465,10 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 		return nil, nil
 	}
 
+	// Storages exist for jets the node is executor for, so TTL is decreased for jets processed below.
+	m.RecentStorageProvider.DecreaseTTL(ctx)
+	m.evicted.decreaseTTL()
+
 	var results []jetInfo
 	jetIDs := tree.LeafIDs()
 	me := m.JetCoordinator.Me()
@@ -440,8 +485,6 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 			continue
 		}
 
-		m.RecentStorageProvider.GetStorage(ctx, jetID).DecreaseTTL(ctx)
-
 		info := jetInfo{id: jetID}
 		if indexToSplit == i && splitCount > 0 {
 			splitCount--
@@ -518,10 +561,32 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 	return results, nil
 }
 
+// hotObjects returns objects of recent storage of jet and objects evicted from it with their TTL.
+func (m *PulseManager) hotObjects(ctx context.Context, jetID core.RecordID) map[core.RecordID]int {
+	objects := m.evicted.get(jetID)
+	for id, ttl := range m.RecentStorageProvider.GetStorage(ctx, jetID).GetObjects() {
+		objects[id] = ttl
+	}
+	return objects
+}
+
+// onRecentObjectEvicted keeps object evicted from full recent storage before its TTL expired, so its index is
+// still handed over to the next executor of jet.
+func (m *PulseManager) onRecentObjectEvicted(ctx context.Context, jetID, id core.RecordID, ttl int) {
+	if ttl <= 0 {
+		return
+	}
+	inslogger.FromContext(ctx).Warnf(
+		"object %s is evicted from full recent storage of jet %s with TTL %d, its index is kept for hot data",
+		id.DebugString(), jetID.DebugString(), ttl,
+	)
+	m.evicted.add(jetID, id, ttl)
+}
+
 func (m *PulseManager) rewriteHotData(ctx context.Context, fromJetID, toJetID core.RecordID) error {
 	recentStorage := m.RecentStorageProvider.GetStorage(ctx, fromJetID)
 
-	for id := range recentStorage.GetObjects() {
+	for id := range m.hotObjects(ctx, fromJetID) {
 		idx, err := m.ObjectStorage.GetObjectIndex(ctx, fromJetID, &id, false)
 		if err != nil {
 			return errors.Wrap(err, "failed to rewrite index")
@@ -553,6 +618,7 @@ func (m *PulseManager) rewriteHotData(ctx context.Context, fromJetID, toJetID co
 
 	inslogger.FromContext(ctx).Debugf("{LEAK} CloneStorage from - %v, to - %v", fromJetID, toJetID)
 	m.RecentStorageProvider.CloneStorage(ctx, fromJetID, toJetID)
+	m.evicted.clone(fromJetID, toJetID)
 
 	return nil
 }
@@ -595,12 +661,21 @@ func (m *PulseManager) Set(ctx context.Context, newPulse core.Pulse, persist boo
 		go m.cleanLightData(ctx, newPulse)
 	}
 
-	err = m.Bus.OnPulse(ctx, newPulse)
-	if err != nil {
-		inslogger.FromContext(ctx).Error(errors.Wrap(err, "MessageBus OnPulse() returns error"))
+	busErr := m.Bus.OnPulse(ctx, newPulse)
+	if busErr != nil {
+		inslogger.FromContext(ctx).Error(errors.Wrap(busErr, "MessageBus OnPulse() returns error"))
 	}
 
-	return m.LR.OnPulse(ctx, newPulse)
+	err = m.LR.OnPulse(ctx, newPulse)
+	if err != nil {
+		return err
+	}
+	if busErr == nil {
+		if err := m.LastKnownGood.MarkLastKnownGood(ctx, newPulse.PulseNumber); err != nil {
+			inslogger.FromContext(ctx).Error(err)
+		}
+	}
+	return nil
 }
 
 func (m *PulseManager) setUnderGilSection(
@@ -721,6 +796,7 @@ func (m *PulseManager) cleanLightData(ctx context.Context, newPulse core.Pulse)
 	}()
 
 	delta := m.options.storeLightPulses
+	transient := map[storage.RecordClass]core.PulseNumber{}
 
 	pn := newPulse.PulseNumber
 	func() {
@@ -739,12 +815,18 @@ func (m *PulseManager) cleanLightData(ctx context.Context, newPulse core.Pulse)
 			if pn <= core.FirstPulseNumber {
 				return
 			}
+			for class, ttl := range m.options.recordTTL {
+				if ttl == i {
+					transient[class] = pn
+				}
+			}
 		}
 	}()
 
 	m.NodeStorage.RemoveActiveNodesUntil(pn)
 
-	err := m.syncClientsPool.LightCleanup(ctx, pn, m.RecentStorageProvider)
+	recent := keepEvictedProvider{Provider: m.RecentStorageProvider, evicted: &m.evicted}
+	err := m.syncClientsPool.LightCleanup(ctx, pn, transient, recent)
 	if err != nil {
 		inslogger.FromContext(ctx).Errorf(
 			"Error on light cleanup, until pulse = %v, singlefligt err = %v", pn, err)
@@ -784,6 +866,8 @@ func (m *PulseManager) prepareArtifactManagerMessageHandlerForNextPulse(ctx cont
 
 // Start starts pulse manager, spawns replication goroutine under a hood.
 func (m *PulseManager) Start(ctx context.Context) error {
+	m.RecentStorageProvider.SetEvictionHandler(m.onRecentObjectEvicted)
+
 	// FIXME: @andreyromancev. 21.12.18. Find a proper place for me. Somewhere at the genesis.
 	err := m.NodeStorage.SetActiveNodes(core.FirstPulseNumber, m.NodeNet.GetActiveNodes())
 	if err != nil && err != storage.ErrOverride {
@@ -798,9 +882,11 @@ func (m *PulseManager) Start(ctx context.Context) error {
 			m.ReplicaStorage,
 			m.StorageCleaner,
 			m.DBContext,
+			m.NodeStorage,
 			heavyclient.Options{
-				SyncMessageLimit: m.options.heavySyncMessageLimit,
-				PulsesDeltaLimit: m.options.lightChainLimit,
+				SyncMessageLimit:    m.options.heavySyncMessageLimit,
+				PulsesDeltaLimit:    m.options.lightChainLimit,
+				MaxInFlightPayloads: m.options.heavySyncMaxInFlight,
 			},
 		)
 		m.syncClientsPool = heavySyncPool
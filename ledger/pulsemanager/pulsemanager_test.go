@@ -28,6 +28,7 @@ import (
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/recovery"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -136,6 +137,7 @@ func (s *pulseManagerSuite) TestPulseManager_Set_CheckHotIndexesSending() {
 	providerMock := recentstorage.NewProviderMock(s.T())
 	providerMock.GetStorageMock.Return(recentMock)
 	providerMock.CloneStorageMock.Return()
+	providerMock.DecreaseTTLMock.Return()
 
 	mbMock := testutils.NewMessageBusMock(s.T())
 	mbMock.OnPulseFunc = func(context.Context, core.Pulse) error {
@@ -211,6 +213,10 @@ func (s *pulseManagerSuite) TestPulseManager_Set_CheckHotIndexesSending() {
 	pm.PulseStorage = pulseStorageMock
 	pm.JetCoordinator = jetCoordinatorMock
 
+	lastKnownGoodMock := recovery.NewLastKnownGoodMock(s.T())
+	lastKnownGoodMock.MarkLastKnownGoodMock.Return(nil)
+	pm.LastKnownGood = lastKnownGoodMock
+
 	// Act
 	err := pm.Set(s.ctx, core.Pulse{PulseNumber: core.FirstPulseNumber + 1}, true)
 	require.NoError(s.T(), err)
@@ -222,6 +228,7 @@ func (s *pulseManagerSuite) TestPulseManager_Set_CheckHotIndexesSending() {
 	// Assert
 	require.NotNil(s.T(), savedIndex)
 	require.NotNil(s.T(), firstIndex, savedIndex)
+	assert.Equal(s.T(), uint64(1), lastKnownGoodMock.MarkLastKnownGoodMinimockCounter())
 	recentMock.MinimockFinish()
 }
 
@@ -279,3 +286,33 @@ func TestPulseManager_Set_SendAbandonedRequests(t *testing.T) {
 	require.Equal(t, uint64(1), mb.SendCounter)
 
 }
+
+func TestPulseManager_OnRecentObjectEvicted(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	jetID := testutils.RandomJet()
+	recentID, evictedID, expiredID := testutils.RandomID(), testutils.RandomID(), testutils.RandomID()
+	recentMock := recentstorage.NewRecentStorageMock(mc)
+	recentMock.GetObjectsMock.Return(map[core.RecordID]int{recentID: 1})
+	recentMock.IsRecordIDCachedMock.Return(false)
+	providerMock := recentstorage.NewProviderMock(mc)
+	providerMock.GetStorageMock.Return(recentMock)
+	pm := &PulseManager{RecentStorageProvider: providerMock}
+
+	// object with expired TTL is forgotten, object evicted from full storage is handed over with hot data
+	pm.onRecentObjectEvicted(ctx, jetID, expiredID, 0)
+	pm.onRecentObjectEvicted(ctx, jetID, evictedID, 2)
+	require.Equal(t, map[core.RecordID]int{recentID: 1, evictedID: 2}, pm.hotObjects(ctx, jetID))
+
+	// cleaner keeps index of evicted object
+	recent := keepEvictedProvider{Provider: providerMock, evicted: &pm.evicted}.GetStorage(ctx, jetID)
+	require.True(t, recent.IsRecordIDCached(evictedID))
+	require.False(t, recent.IsRecordIDCached(expiredID))
+
+	pm.evicted.decreaseTTL()
+	require.Equal(t, map[core.RecordID]int{evictedID: 1}, pm.evicted.get(jetID))
+	pm.evicted.decreaseTTL()
+	require.Empty(t, pm.evicted.get(jetID))
+}
@@ -0,0 +1,78 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package pulsemanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage"
+)
+
+// MakeSnapshot saves snapshot of storage with name into snapshot directory. Pulse change and request processing
+// wait until snapshot is written, so snapshot is taken at pulse boundary.
+func (m *PulseManager) MakeSnapshot(ctx context.Context, name string) (*core.SnapshotInfo, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return nil, errors.Errorf("[ MakeSnapshot ] invalid snapshot name %q", name)
+	}
+	if err := os.MkdirAll(m.options.snapshotDir, 0700); err != nil {
+		return nil, errors.Wrap(err, "[ MakeSnapshot ] can't create snapshot directory")
+	}
+	path := filepath.Join(m.options.snapshotDir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Errorf("[ MakeSnapshot ] snapshot %s already exists", name)
+	}
+
+	m.setLock.Lock()
+	defer m.setLock.Unlock()
+	if m.stopped {
+		return nil, errors.New("[ MakeSnapshot ] can't make snapshot after PulseManager stop")
+	}
+	m.GIL.Acquire(ctx)
+	defer m.GIL.Release(ctx)
+
+	// snapshot is written to temporary file, so incomplete snapshot is never seen by its name
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MakeSnapshot ] can't create snapshot file")
+	}
+	defer os.Remove(tmp) // nolint: errcheck
+
+	header, keys, err := storage.WriteSnapshot(ctx, m.DBContext, file)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MakeSnapshot ]")
+	}
+	if closeErr != nil {
+		return nil, errors.Wrap(closeErr, "[ MakeSnapshot ] can't close snapshot file")
+	}
+	stat, err := os.Stat(tmp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MakeSnapshot ]")
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, errors.Wrap(err, "[ MakeSnapshot ] can't rename snapshot file")
+	}
+	return &core.SnapshotInfo{Path: path, Pulse: header.Pulse, Keys: keys, Size: stat.Size()}, nil
+}
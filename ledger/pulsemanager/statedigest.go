@@ -0,0 +1,68 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package pulsemanager
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/artifactmanager"
+)
+
+// sendStateDigest sends digest of jet state to validators of jet, validators compare it with their own one.
+func (m *PulseManager) sendStateDigest(ctx context.Context, jetID core.RecordID, pulse core.PulseNumber, digest []byte) {
+	logger := inslogger.FromContext(ctx)
+
+	validators, err := m.JetCoordinator.LightValidatorsForJet(ctx, jetID, pulse)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to calculate validators for jet"))
+		return
+	}
+
+	msg := &message.JetStateDigest{
+		JetID:       jetID,
+		PulseNumber: pulse,
+		Digest:      digest,
+	}
+	me := m.JetCoordinator.Me()
+	for _, validator := range validators {
+		if validator == me {
+			continue
+		}
+		receiver := validator
+		rep, err := m.Bus.Send(ctx, msg, &core.MessageSendOptions{Receiver: &receiver})
+		if err != nil {
+			logger.Debugf("[jet]: %v send state digest. Pulse: %v, Validator: %v, Error: %s", jetID.DebugString(), pulse, receiver, err)
+			continue
+		}
+		if _, ok := rep.(*reply.NotOK); ok {
+			logger.Warnf("[jet]: %v state digest diverges on validator %v. Pulse: %v", jetID.DebugString(), receiver, pulse)
+		}
+	}
+}
+
+// stateDigest calculates digest of jet state at the end of pulse.
+func (m *PulseManager) stateDigest(ctx context.Context, jetID core.RecordID) ([]byte, error) {
+	digest, _, err := artifactmanager.JetStateDigest(ctx, m.ObjectStorage, m.PlatformCryptographyScheme, jetID)
+	return digest, err
+}
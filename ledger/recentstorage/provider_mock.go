@@ -25,6 +25,11 @@ type ProviderMock struct {
 	CloneStoragePreCounter uint64
 	CloneStorageMock       mProviderMockCloneStorage
 
+	DecreaseTTLFunc       func(p context.Context)
+	DecreaseTTLCounter    uint64
+	DecreaseTTLPreCounter uint64
+	DecreaseTTLMock       mProviderMockDecreaseTTL
+
 	GetStorageFunc       func(p context.Context, p1 core.RecordID) (r RecentStorage)
 	GetStorageCounter    uint64
 	GetStoragePreCounter uint64
@@ -34,6 +39,11 @@ type ProviderMock struct {
 	RemoveStorageCounter    uint64
 	RemoveStoragePreCounter uint64
 	RemoveStorageMock       mProviderMockRemoveStorage
+
+	SetEvictionHandlerFunc       func(p EvictionHandler)
+	SetEvictionHandlerCounter    uint64
+	SetEvictionHandlerPreCounter uint64
+	SetEvictionHandlerMock       mProviderMockSetEvictionHandler
 }
 
 //NewProviderMock returns a mock for github.com/insolar/insolar/ledger/recentstorage.Provider
@@ -45,8 +55,10 @@ func NewProviderMock(t minimock.Tester) *ProviderMock {
 	}
 
 	m.CloneStorageMock = mProviderMockCloneStorage{mock: m}
+	m.DecreaseTTLMock = mProviderMockDecreaseTTL{mock: m}
 	m.GetStorageMock = mProviderMockGetStorage{mock: m}
 	m.RemoveStorageMock = mProviderMockRemoveStorage{mock: m}
+	m.SetEvictionHandlerMock = mProviderMockSetEvictionHandler{mock: m}
 
 	return m
 }
@@ -176,6 +188,129 @@ func (m *ProviderMock) CloneStorageFinished() bool {
 	return true
 }
 
+type mProviderMockDecreaseTTL struct {
+	mock              *ProviderMock
+	mainExpectation   *ProviderMockDecreaseTTLExpectation
+	expectationSeries []*ProviderMockDecreaseTTLExpectation
+}
+
+type ProviderMockDecreaseTTLExpectation struct {
+	input *ProviderMockDecreaseTTLInput
+}
+
+type ProviderMockDecreaseTTLInput struct {
+	p context.Context
+}
+
+//Expect specifies that invocation of Provider.DecreaseTTL is expected from 1 to Infinity times
+func (m *mProviderMockDecreaseTTL) Expect(p context.Context) *mProviderMockDecreaseTTL {
+	m.mock.DecreaseTTLFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ProviderMockDecreaseTTLExpectation{}
+	}
+	m.mainExpectation.input = &ProviderMockDecreaseTTLInput{p}
+	return m
+}
+
+//Return specifies results of invocation of Provider.DecreaseTTL
+func (m *mProviderMockDecreaseTTL) Return() *ProviderMock {
+	m.mock.DecreaseTTLFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ProviderMockDecreaseTTLExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Provider.DecreaseTTL is expected once
+func (m *mProviderMockDecreaseTTL) ExpectOnce(p context.Context) *ProviderMockDecreaseTTLExpectation {
+	m.mock.DecreaseTTLFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ProviderMockDecreaseTTLExpectation{}
+	expectation.input = &ProviderMockDecreaseTTLInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of Provider.DecreaseTTL method
+func (m *mProviderMockDecreaseTTL) Set(f func(p context.Context)) *ProviderMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.DecreaseTTLFunc = f
+	return m.mock
+}
+
+//DecreaseTTL implements github.com/insolar/insolar/ledger/recentstorage.Provider interface
+func (m *ProviderMock) DecreaseTTL(p context.Context) {
+	counter := atomic.AddUint64(&m.DecreaseTTLPreCounter, 1)
+	defer atomic.AddUint64(&m.DecreaseTTLCounter, 1)
+
+	if len(m.DecreaseTTLMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.DecreaseTTLMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ProviderMock.DecreaseTTL. %v", p)
+			return
+		}
+
+		input := m.DecreaseTTLMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ProviderMockDecreaseTTLInput{p}, "Provider.DecreaseTTL got unexpected parameters")
+
+		return
+	}
+
+	if m.DecreaseTTLMock.mainExpectation != nil {
+
+		input := m.DecreaseTTLMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ProviderMockDecreaseTTLInput{p}, "Provider.DecreaseTTL got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.DecreaseTTLFunc == nil {
+		m.t.Fatalf("Unexpected call to ProviderMock.DecreaseTTL. %v", p)
+		return
+	}
+
+	m.DecreaseTTLFunc(p)
+}
+
+//DecreaseTTLMinimockCounter returns a count of ProviderMock.DecreaseTTLFunc invocations
+func (m *ProviderMock) DecreaseTTLMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.DecreaseTTLCounter)
+}
+
+//DecreaseTTLMinimockPreCounter returns the value of ProviderMock.DecreaseTTL invocations
+func (m *ProviderMock) DecreaseTTLMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.DecreaseTTLPreCounter)
+}
+
+//DecreaseTTLFinished returns true if mock invocations count is ok
+func (m *ProviderMock) DecreaseTTLFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.DecreaseTTLMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.DecreaseTTLCounter) == uint64(len(m.DecreaseTTLMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.DecreaseTTLMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.DecreaseTTLCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.DecreaseTTLFunc != nil {
+		return atomic.LoadUint64(&m.DecreaseTTLCounter) > 0
+	}
+
+	return true
+}
+
 type mProviderMockGetStorage struct {
 	mock              *ProviderMock
 	mainExpectation   *ProviderMockGetStorageExpectation
@@ -448,6 +583,129 @@ func (m *ProviderMock) RemoveStorageFinished() bool {
 	return true
 }
 
+type mProviderMockSetEvictionHandler struct {
+	mock              *ProviderMock
+	mainExpectation   *ProviderMockSetEvictionHandlerExpectation
+	expectationSeries []*ProviderMockSetEvictionHandlerExpectation
+}
+
+type ProviderMockSetEvictionHandlerExpectation struct {
+	input *ProviderMockSetEvictionHandlerInput
+}
+
+type ProviderMockSetEvictionHandlerInput struct {
+	p EvictionHandler
+}
+
+//Expect specifies that invocation of Provider.SetEvictionHandler is expected from 1 to Infinity times
+func (m *mProviderMockSetEvictionHandler) Expect(p EvictionHandler) *mProviderMockSetEvictionHandler {
+	m.mock.SetEvictionHandlerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ProviderMockSetEvictionHandlerExpectation{}
+	}
+	m.mainExpectation.input = &ProviderMockSetEvictionHandlerInput{p}
+	return m
+}
+
+//Return specifies results of invocation of Provider.SetEvictionHandler
+func (m *mProviderMockSetEvictionHandler) Return() *ProviderMock {
+	m.mock.SetEvictionHandlerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ProviderMockSetEvictionHandlerExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Provider.SetEvictionHandler is expected once
+func (m *mProviderMockSetEvictionHandler) ExpectOnce(p EvictionHandler) *ProviderMockSetEvictionHandlerExpectation {
+	m.mock.SetEvictionHandlerFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ProviderMockSetEvictionHandlerExpectation{}
+	expectation.input = &ProviderMockSetEvictionHandlerInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of Provider.SetEvictionHandler method
+func (m *mProviderMockSetEvictionHandler) Set(f func(p EvictionHandler)) *ProviderMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SetEvictionHandlerFunc = f
+	return m.mock
+}
+
+//SetEvictionHandler implements github.com/insolar/insolar/ledger/recentstorage.Provider interface
+func (m *ProviderMock) SetEvictionHandler(p EvictionHandler) {
+	counter := atomic.AddUint64(&m.SetEvictionHandlerPreCounter, 1)
+	defer atomic.AddUint64(&m.SetEvictionHandlerCounter, 1)
+
+	if len(m.SetEvictionHandlerMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SetEvictionHandlerMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ProviderMock.SetEvictionHandler. %v", p)
+			return
+		}
+
+		input := m.SetEvictionHandlerMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ProviderMockSetEvictionHandlerInput{p}, "Provider.SetEvictionHandler got unexpected parameters")
+
+		return
+	}
+
+	if m.SetEvictionHandlerMock.mainExpectation != nil {
+
+		input := m.SetEvictionHandlerMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ProviderMockSetEvictionHandlerInput{p}, "Provider.SetEvictionHandler got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.SetEvictionHandlerFunc == nil {
+		m.t.Fatalf("Unexpected call to ProviderMock.SetEvictionHandler. %v", p)
+		return
+	}
+
+	m.SetEvictionHandlerFunc(p)
+}
+
+//SetEvictionHandlerMinimockCounter returns a count of ProviderMock.SetEvictionHandlerFunc invocations
+func (m *ProviderMock) SetEvictionHandlerMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetEvictionHandlerCounter)
+}
+
+//SetEvictionHandlerMinimockPreCounter returns the value of ProviderMock.SetEvictionHandler invocations
+func (m *ProviderMock) SetEvictionHandlerMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetEvictionHandlerPreCounter)
+}
+
+//SetEvictionHandlerFinished returns true if mock invocations count is ok
+func (m *ProviderMock) SetEvictionHandlerFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SetEvictionHandlerMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SetEvictionHandlerCounter) == uint64(len(m.SetEvictionHandlerMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SetEvictionHandlerMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SetEvictionHandlerCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SetEvictionHandlerFunc != nil {
+		return atomic.LoadUint64(&m.SetEvictionHandlerCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *ProviderMock) ValidateCallCounters() {
@@ -456,6 +714,10 @@ func (m *ProviderMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ProviderMock.CloneStorage")
 	}
 
+	if !m.DecreaseTTLFinished() {
+		m.t.Fatal("Expected call to ProviderMock.DecreaseTTL")
+	}
+
 	if !m.GetStorageFinished() {
 		m.t.Fatal("Expected call to ProviderMock.GetStorage")
 	}
@@ -464,6 +726,10 @@ func (m *ProviderMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ProviderMock.RemoveStorage")
 	}
 
+	if !m.SetEvictionHandlerFinished() {
+		m.t.Fatal("Expected call to ProviderMock.SetEvictionHandler")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -485,6 +751,10 @@ func (m *ProviderMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ProviderMock.CloneStorage")
 	}
 
+	if !m.DecreaseTTLFinished() {
+		m.t.Fatal("Expected call to ProviderMock.DecreaseTTL")
+	}
+
 	if !m.GetStorageFinished() {
 		m.t.Fatal("Expected call to ProviderMock.GetStorage")
 	}
@@ -493,6 +763,10 @@ func (m *ProviderMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ProviderMock.RemoveStorage")
 	}
 
+	if !m.SetEvictionHandlerFinished() {
+		m.t.Fatal("Expected call to ProviderMock.SetEvictionHandler")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -508,8 +782,10 @@ func (m *ProviderMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && m.CloneStorageFinished()
+		ok = ok && m.DecreaseTTLFinished()
 		ok = ok && m.GetStorageFinished()
 		ok = ok && m.RemoveStorageFinished()
+		ok = ok && m.SetEvictionHandlerFinished()
 
 		if ok {
 			return
@@ -522,6 +798,10 @@ func (m *ProviderMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ProviderMock.CloneStorage")
 			}
 
+			if !m.DecreaseTTLFinished() {
+				m.t.Error("Expected call to ProviderMock.DecreaseTTL")
+			}
+
 			if !m.GetStorageFinished() {
 				m.t.Error("Expected call to ProviderMock.GetStorage")
 			}
@@ -530,6 +810,10 @@ func (m *ProviderMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ProviderMock.RemoveStorage")
 			}
 
+			if !m.SetEvictionHandlerFinished() {
+				m.t.Error("Expected call to ProviderMock.SetEvictionHandler")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -546,6 +830,10 @@ func (m *ProviderMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.DecreaseTTLFinished() {
+		return false
+	}
+
 	if !m.GetStorageFinished() {
 		return false
 	}
@@ -554,5 +842,9 @@ func (m *ProviderMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.SetEvictionHandlerFinished() {
+		return false
+	}
+
 	return true
 }
@@ -28,8 +28,17 @@ type Provider interface {
 	GetStorage(ctx context.Context, jetID core.RecordID) RecentStorage
 	CloneStorage(ctx context.Context, fromJetID, toJetID core.RecordID)
 	RemoveStorage(ctx context.Context, id core.RecordID)
+
+	// DecreaseTTL decreases TTL of objects in storages of all jets. It is called on every pulse.
+	DecreaseTTL(ctx context.Context)
+	// SetEvictionHandler sets handler called for objects removed from storages by TTL or size limit.
+	SetEvictionHandler(handler EvictionHandler)
 }
 
+// EvictionHandler is called for object removed from recent storage of jet because its TTL is expired or storage
+// is full. TTL is positive if object is evicted from full storage before its TTL expired.
+type EvictionHandler func(ctx context.Context, jetID, id core.RecordID, ttl int)
+
 // RecentStorage is a base interface for the storage of recent objects and indexes
 //go:generate minimock -i github.com/insolar/insolar/ledger/recentstorage.RecentStorage -o ./ -s _mock.go
 type RecentStorage interface {
@@ -0,0 +1,263 @@
+package recovery
+
+/*
+DO NOT EDIT!
+This code was generated automatically using github.com/gojuno/minimock v1.9
+The original interface "LastKnownGood" can be found in github.com/insolar/insolar/ledger/recovery
+*/
+import (
+	context "context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gojuno/minimock"
+	core "github.com/insolar/insolar/core"
+
+	testify_assert "github.com/stretchr/testify/assert"
+)
+
+//LastKnownGoodMock implements github.com/insolar/insolar/ledger/recovery.LastKnownGood
+type LastKnownGoodMock struct {
+	t minimock.Tester
+
+	MarkLastKnownGoodFunc       func(p context.Context, p1 core.PulseNumber) (r error)
+	MarkLastKnownGoodCounter    uint64
+	MarkLastKnownGoodPreCounter uint64
+	MarkLastKnownGoodMock       mLastKnownGoodMockMarkLastKnownGood
+}
+
+//NewLastKnownGoodMock returns a mock for github.com/insolar/insolar/ledger/recovery.LastKnownGood
+func NewLastKnownGoodMock(t minimock.Tester) *LastKnownGoodMock {
+	m := &LastKnownGoodMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.MarkLastKnownGoodMock = mLastKnownGoodMockMarkLastKnownGood{mock: m}
+
+	return m
+}
+
+type mLastKnownGoodMockMarkLastKnownGood struct {
+	mock              *LastKnownGoodMock
+	mainExpectation   *LastKnownGoodMockMarkLastKnownGoodExpectation
+	expectationSeries []*LastKnownGoodMockMarkLastKnownGoodExpectation
+}
+
+type LastKnownGoodMockMarkLastKnownGoodExpectation struct {
+	input  *LastKnownGoodMockMarkLastKnownGoodInput
+	result *LastKnownGoodMockMarkLastKnownGoodResult
+}
+
+type LastKnownGoodMockMarkLastKnownGoodInput struct {
+	p  context.Context
+	p1 core.PulseNumber
+}
+
+type LastKnownGoodMockMarkLastKnownGoodResult struct {
+	r error
+}
+
+//Expect specifies that invocation of LastKnownGood.MarkLastKnownGood is expected from 1 to Infinity times
+func (m *mLastKnownGoodMockMarkLastKnownGood) Expect(p context.Context, p1 core.PulseNumber) *mLastKnownGoodMockMarkLastKnownGood {
+	m.mock.MarkLastKnownGoodFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &LastKnownGoodMockMarkLastKnownGoodExpectation{}
+	}
+	m.mainExpectation.input = &LastKnownGoodMockMarkLastKnownGoodInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of LastKnownGood.MarkLastKnownGood
+func (m *mLastKnownGoodMockMarkLastKnownGood) Return(r error) *LastKnownGoodMock {
+	m.mock.MarkLastKnownGoodFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &LastKnownGoodMockMarkLastKnownGoodExpectation{}
+	}
+	m.mainExpectation.result = &LastKnownGoodMockMarkLastKnownGoodResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of LastKnownGood.MarkLastKnownGood is expected once
+func (m *mLastKnownGoodMockMarkLastKnownGood) ExpectOnce(p context.Context, p1 core.PulseNumber) *LastKnownGoodMockMarkLastKnownGoodExpectation {
+	m.mock.MarkLastKnownGoodFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &LastKnownGoodMockMarkLastKnownGoodExpectation{}
+	expectation.input = &LastKnownGoodMockMarkLastKnownGoodInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *LastKnownGoodMockMarkLastKnownGoodExpectation) Return(r error) {
+	e.result = &LastKnownGoodMockMarkLastKnownGoodResult{r}
+}
+
+//Set uses given function f as a mock of LastKnownGood.MarkLastKnownGood method
+func (m *mLastKnownGoodMockMarkLastKnownGood) Set(f func(p context.Context, p1 core.PulseNumber) (r error)) *LastKnownGoodMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.MarkLastKnownGoodFunc = f
+	return m.mock
+}
+
+//MarkLastKnownGood implements github.com/insolar/insolar/ledger/recovery.LastKnownGood interface
+func (m *LastKnownGoodMock) MarkLastKnownGood(p context.Context, p1 core.PulseNumber) (r error) {
+	counter := atomic.AddUint64(&m.MarkLastKnownGoodPreCounter, 1)
+	defer atomic.AddUint64(&m.MarkLastKnownGoodCounter, 1)
+
+	if len(m.MarkLastKnownGoodMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.MarkLastKnownGoodMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to LastKnownGoodMock.MarkLastKnownGood. %v %v", p, p1)
+			return
+		}
+
+		input := m.MarkLastKnownGoodMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, LastKnownGoodMockMarkLastKnownGoodInput{p, p1}, "LastKnownGood.MarkLastKnownGood got unexpected parameters")
+
+		result := m.MarkLastKnownGoodMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the LastKnownGoodMock.MarkLastKnownGood")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.MarkLastKnownGoodMock.mainExpectation != nil {
+
+		input := m.MarkLastKnownGoodMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, LastKnownGoodMockMarkLastKnownGoodInput{p, p1}, "LastKnownGood.MarkLastKnownGood got unexpected parameters")
+		}
+
+		result := m.MarkLastKnownGoodMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the LastKnownGoodMock.MarkLastKnownGood")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.MarkLastKnownGoodFunc == nil {
+		m.t.Fatalf("Unexpected call to LastKnownGoodMock.MarkLastKnownGood. %v %v", p, p1)
+		return
+	}
+
+	return m.MarkLastKnownGoodFunc(p, p1)
+}
+
+//MarkLastKnownGoodMinimockCounter returns a count of LastKnownGoodMock.MarkLastKnownGoodFunc invocations
+func (m *LastKnownGoodMock) MarkLastKnownGoodMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.MarkLastKnownGoodCounter)
+}
+
+//MarkLastKnownGoodMinimockPreCounter returns the value of LastKnownGoodMock.MarkLastKnownGood invocations
+func (m *LastKnownGoodMock) MarkLastKnownGoodMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.MarkLastKnownGoodPreCounter)
+}
+
+//MarkLastKnownGoodFinished returns true if mock invocations count is ok
+func (m *LastKnownGoodMock) MarkLastKnownGoodFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.MarkLastKnownGoodMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.MarkLastKnownGoodCounter) == uint64(len(m.MarkLastKnownGoodMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.MarkLastKnownGoodMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.MarkLastKnownGoodCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.MarkLastKnownGoodFunc != nil {
+		return atomic.LoadUint64(&m.MarkLastKnownGoodCounter) > 0
+	}
+
+	return true
+}
+
+//ValidateCallCounters checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *LastKnownGoodMock) ValidateCallCounters() {
+
+	if !m.MarkLastKnownGoodFinished() {
+		m.t.Fatal("Expected call to LastKnownGoodMock.MarkLastKnownGood")
+	}
+
+}
+
+//CheckMocksCalled checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *LastKnownGoodMock) CheckMocksCalled() {
+	m.Finish()
+}
+
+//Finish checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish or use Finish method of minimock.Controller
+func (m *LastKnownGoodMock) Finish() {
+	m.MinimockFinish()
+}
+
+//MinimockFinish checks that all mocked methods of the interface have been called at least once
+func (m *LastKnownGoodMock) MinimockFinish() {
+
+	if !m.MarkLastKnownGoodFinished() {
+		m.t.Fatal("Expected call to LastKnownGoodMock.MarkLastKnownGood")
+	}
+
+}
+
+//Wait waits for all mocked methods to be called at least once
+//Deprecated: please use MinimockWait or use Wait method of minimock.Controller
+func (m *LastKnownGoodMock) Wait(timeout time.Duration) {
+	m.MinimockWait(timeout)
+}
+
+//MinimockWait waits for all mocked methods to be called at least once
+//this method is called by minimock.Controller
+func (m *LastKnownGoodMock) MinimockWait(timeout time.Duration) {
+	timeoutCh := time.After(timeout)
+	for {
+		ok := true
+		ok = ok && m.MarkLastKnownGoodFinished()
+
+		if ok {
+			return
+		}
+
+		select {
+		case <-timeoutCh:
+
+			if !m.MarkLastKnownGoodFinished() {
+				m.t.Error("Expected call to LastKnownGoodMock.MarkLastKnownGood")
+			}
+
+			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
+			return
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+//AllMocksCalled returns true if all mocked methods were called before the execution of AllMocksCalled,
+//it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
+func (m *LastKnownGoodMock) AllMocksCalled() bool {
+
+	if !m.MarkLastKnownGoodFinished() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,236 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package recovery reports state of ledger storage recovered on node startup and marks the last known good state.
+// Both are saved as JSON files in storage directory, so doctor command and monitoring can read them without
+// opening the database.
+package recovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReportFile is name of recovery report file in storage directory.
+	ReportFile = "recovery-report.json"
+	// MarkerFile is name of last known good marker file in storage directory.
+	MarkerFile = "last-known-good.json"
+)
+
+// Report describes ledger state recovered on startup.
+type Report struct {
+	Time time.Time
+	// LastPulse is the latest pulse applied before restart.
+	LastPulse core.PulseNumber
+	// WAL summarizes replay of write-ahead log on database open.
+	WAL WALSummary
+	// Drops summarizes verification of jet drops of the latest pulse, mismatches are listed in errors.
+	Drops DropsSummary
+	// JetsWithGaps are jets with pulses not replicated to heavy yet.
+	JetsWithGaps []JetGap
+	// PendingOutbox is count of pulses of all jets waiting for replication to heavy.
+	PendingOutbox int
+	// Errors are failures of particular checks, report is still written.
+	Errors []string
+}
+
+// WALSummary describes write-ahead log replayed on database open. For BadgerDB it includes value log,
+// Replayed is count of commits replayed from storage write-ahead log if it is enabled.
+type WALSummary struct {
+	ReplayDuration time.Duration
+	Replayed       int
+	ValueLogSize   int64
+	LSMSize        int64
+}
+
+// DropsSummary describes jet drops of the latest pulse checked against records they were built from.
+type DropsSummary struct {
+	Pulse    core.PulseNumber
+	Verified int
+}
+
+// JetGap is a jet with pulses not replicated to heavy.
+type JetGap struct {
+	Jet        string
+	FirstPulse core.PulseNumber
+	Pulses     int
+}
+
+// Marker is the last known good state, it is written after the first fully successful pulse.
+type Marker struct {
+	Time  time.Time
+	Pulse core.PulseNumber
+	// Started is time the node started, marker of previous run has earlier value.
+	Started time.Time
+}
+
+// LastKnownGood marks pulse as last known good state of node.
+//go:generate minimock -i github.com/insolar/insolar/ledger/recovery.LastKnownGood -o ./ -s _mock.go
+type LastKnownGood interface {
+	MarkLastKnownGood(ctx context.Context, pulse core.PulseNumber) error
+}
+
+// Reporter writes recovery report on start and last known good marker after the first successful pulse.
+type Reporter struct {
+	DB             storage.DBContext      `inject:""`
+	PulseTracker   storage.PulseTracker   `inject:""`
+	ReplicaStorage storage.ReplicaStorage `inject:""`
+
+	PlatformCryptographyScheme core.PlatformCryptographyScheme `inject:""`
+
+	dir     string
+	started time.Time
+
+	markOnce sync.Once
+}
+
+// NewReporter creates reporter writing to storage directory of ledger.
+func NewReporter(conf configuration.Ledger) *Reporter {
+	return &Reporter{dir: conf.Storage.DataDirectory, started: time.Now()}
+}
+
+// Start builds and saves recovery report.
+func (r *Reporter) Start(ctx context.Context) error {
+	report := r.Build(ctx)
+	inslogger.FromContext(ctx).Infof(
+		"Recovery report: last pulse %d, WAL replayed in %v (%d commits, value log %d bytes), %d drops of pulse %d verified, %d jets with gaps, %d pulses in outbox",
+		report.LastPulse, report.WAL.ReplayDuration, report.WAL.Replayed, report.WAL.ValueLogSize,
+		report.Drops.Verified, report.Drops.Pulse, len(report.JetsWithGaps), report.PendingOutbox,
+	)
+	for _, e := range report.Errors {
+		inslogger.FromContext(ctx).Warn("Recovery report: ", e)
+	}
+	return errors.Wrap(writeJSON(filepath.Join(r.dir, ReportFile), report), "failed to save recovery report")
+}
+
+// Build collects recovery report, failed checks are listed in report errors.
+func (r *Reporter) Build(ctx context.Context) *Report {
+	report := &Report{Time: time.Now()}
+
+	pulse, err := r.PulseTracker.GetLatestPulse(ctx)
+	if err != nil {
+		report.Errors = append(report.Errors, errors.Wrap(err, "failed to get latest pulse").Error())
+	} else {
+		report.LastPulse = pulse.Pulse.PulseNumber
+	}
+
+	if db, ok := r.DB.(interface{ OpenDuration() time.Duration }); ok {
+		report.WAL.ReplayDuration = db.OpenDuration()
+	}
+	if db, ok := r.DB.(interface{ WALReplayed() int }); ok {
+		report.WAL.Replayed = db.WALReplayed()
+	}
+	if bdb := r.DB.GetBadgerDB(); bdb != nil {
+		report.WAL.LSMSize, report.WAL.ValueLogSize = bdb.Size()
+	}
+
+	drops, err := storage.VerifyLastDrops(ctx, r.DB, r.PlatformCryptographyScheme)
+	if err != nil {
+		report.Errors = append(report.Errors, errors.Wrap(err, "failed to verify drops").Error())
+	} else {
+		report.Drops = DropsSummary{Pulse: drops.Pulse, Verified: drops.Drops}
+		for _, m := range drops.Mismatches {
+			report.Errors = append(report.Errors, fmt.Sprintf(
+				"drop hash mismatch for jet prefix %x in pulse %d: recorded %x, computed %x",
+				m.JetPrefix, m.Pulse, m.Recorded, m.Computed,
+			))
+		}
+	}
+
+	jets, err := r.ReplicaStorage.GetAllNonEmptySyncClientJets(ctx)
+	if err != nil {
+		report.Errors = append(report.Errors, errors.Wrap(err, "failed to get jets waiting for replication").Error())
+	}
+	for jetID, pulses := range jets {
+		gap := JetGap{Jet: jetID.DebugString(), Pulses: len(pulses), FirstPulse: pulses[0]}
+		for _, pn := range pulses {
+			if pn < gap.FirstPulse {
+				gap.FirstPulse = pn
+			}
+		}
+		report.JetsWithGaps = append(report.JetsWithGaps, gap)
+		report.PendingOutbox += len(pulses)
+	}
+	sort.Slice(report.JetsWithGaps, func(i, j int) bool {
+		return report.JetsWithGaps[i].Jet < report.JetsWithGaps[j].Jet
+	})
+	return report
+}
+
+// MarkLastKnownGood saves marker of the first pulse processed successfully after start, later calls do nothing.
+func (r *Reporter) MarkLastKnownGood(ctx context.Context, pulse core.PulseNumber) error {
+	var err error
+	r.markOnce.Do(func() {
+		err = writeJSON(filepath.Join(r.dir, MarkerFile), &Marker{Time: time.Now(), Pulse: pulse, Started: r.started})
+		if err == nil {
+			inslogger.FromContext(ctx).Infof("Pulse %d is marked as last known good state", pulse)
+		}
+	})
+	return errors.Wrap(err, "failed to save last known good marker")
+}
+
+// ReadReport reads recovery report from storage directory.
+func ReadReport(dir string) (*Report, error) {
+	report := &Report{}
+	if err := readJSON(filepath.Join(dir, ReportFile), report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ReadMarker reads last known good marker from storage directory.
+func ReadMarker(dir string) (*Marker, error) {
+	marker := &Marker{}
+	if err := readJSON(filepath.Join(dir, MarkerFile), marker); err != nil {
+		return nil, err
+	}
+	return marker, nil
+}
+
+// writeJSON replaces file atomically, so readers never see partially written file.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(json.Unmarshal(data, v), "failed to parse %s", path)
+}
@@ -0,0 +1,152 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package recovery
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReporter(ctx context.Context, t *testing.T) (*Reporter, string, func()) {
+	dir, err := ioutil.TempDir("", "recovery")
+	require.NoError(t, err)
+
+	r := NewReporter(configuration.Ledger{Storage: configuration.Storage{DataDirectory: dir}})
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	r.DB = db
+	r.PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	return r, dir, func() {
+		cleaner()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestReporter_Start(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	r, dir, cleaner := newTestReporter(ctx, t)
+	defer cleaner()
+
+	pt := storage.NewPulseTrackerMock(t)
+	pt.GetLatestPulseMock.Return(&storage.Pulse{Pulse: core.Pulse{PulseNumber: core.FirstPulseNumber + 5}}, nil)
+	r.PulseTracker = pt
+	left, right := jet.NewID(1, []byte{0}), jet.NewID(1, []byte{1 << 7})
+	rs := storage.NewReplicaStorageMock(t)
+	rs.GetAllNonEmptySyncClientJetsMock.Return(map[core.RecordID][]core.PulseNumber{
+		*left:  {core.FirstPulseNumber + 4, core.FirstPulseNumber + 3},
+		*right: {core.FirstPulseNumber + 4},
+	}, nil)
+	r.ReplicaStorage = rs
+
+	require.NoError(t, r.Start(ctx))
+
+	report, err := ReadReport(dir)
+	require.NoError(t, err)
+	assert.Equal(t, core.PulseNumber(core.FirstPulseNumber+5), report.LastPulse)
+	assert.Equal(t, 3, report.PendingOutbox)
+	require.Len(t, report.JetsWithGaps, 2)
+	for _, gap := range report.JetsWithGaps {
+		if gap.Jet == left.DebugString() {
+			assert.Equal(t, core.PulseNumber(core.FirstPulseNumber+3), gap.FirstPulse)
+			assert.Equal(t, 2, gap.Pulses)
+		}
+	}
+	assert.Empty(t, report.Errors)
+}
+
+func TestReporter_Build_Errors(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	r, _, cleaner := newTestReporter(ctx, t)
+	defer cleaner()
+
+	pt := storage.NewPulseTrackerMock(t)
+	pt.GetLatestPulseMock.Return(nil, errors.New("no pulse"))
+	r.PulseTracker = pt
+	rs := storage.NewReplicaStorageMock(t)
+	rs.GetAllNonEmptySyncClientJetsMock.Return(nil, errors.New("broken"))
+	r.ReplicaStorage = rs
+
+	report := r.Build(ctx)
+	assert.Len(t, report.Errors, 2)
+	assert.Empty(t, report.JetsWithGaps)
+}
+
+func TestReporter_Build_Drops(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	r, _, cleaner := newTestReporter(ctx, t)
+	defer cleaner()
+
+	pt := storage.NewPulseTrackerMock(t)
+	pt.GetLatestPulseMock.Return(&storage.Pulse{Pulse: core.Pulse{PulseNumber: core.FirstPulseNumber + 1}}, nil)
+	r.PulseTracker = pt
+	rs := storage.NewReplicaStorageMock(t)
+	rs.GetAllNonEmptySyncClientJetsMock.Return(nil, nil)
+	r.ReplicaStorage = rs
+
+	dropStorage := storage.NewDropStorage(10)
+	cm := &component.Manager{}
+	cm.Inject(r.PlatformCryptographyScheme, r.DB, dropStorage)
+
+	pulse := core.PulseNumber(core.FirstPulseNumber + 1)
+	drop, _, _, err := dropStorage.CreateDrop(ctx, core.TODOJetID, pulse, nil)
+	require.NoError(t, err)
+	require.NoError(t, dropStorage.SetDrop(ctx, core.TODOJetID, drop))
+
+	report := r.Build(ctx)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, DropsSummary{Pulse: pulse, Verified: 1}, report.Drops)
+
+	// drop which hash doesn't match its records
+	otherJet := *jet.NewID(1, []byte{1 << 7})
+	require.NoError(t, dropStorage.SetDrop(ctx, otherJet, &jet.JetDrop{Pulse: pulse, Hash: []byte{4, 5, 6}}))
+
+	report = r.Build(ctx)
+	assert.Equal(t, 2, report.Drops.Verified)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "drop hash mismatch")
+}
+
+func TestReporter_MarkLastKnownGood(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	r, dir, cleaner := newTestReporter(ctx, t)
+	defer cleaner()
+
+	_, err := ReadMarker(dir)
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, r.MarkLastKnownGood(ctx, core.FirstPulseNumber+1))
+	// only the first successful pulse is marked
+	require.NoError(t, r.MarkLastKnownGood(ctx, core.FirstPulseNumber+2))
+
+	marker, err := ReadMarker(dir)
+	require.NoError(t, err)
+	assert.Equal(t, core.PulseNumber(core.FirstPulseNumber+1), marker.Pulse)
+	assert.Equal(t, r.started.Unix(), marker.Started.Unix())
+}
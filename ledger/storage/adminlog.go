@@ -0,0 +1,156 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// errStopIteration stops iteration over db keys without error.
+var errStopIteration = errors.New("stop iteration")
+
+type adminLogStorage struct {
+	DB                         DBContext                       `inject:""`
+	PlatformCryptographyScheme core.PlatformCryptographyScheme `inject:""`
+
+	lock sync.Mutex
+	// last is the last entry of log, it is loaded from db on first append
+	last *core.AdminLogEntry
+}
+
+// NewAdminLogStorage creates new append-only storage of admin API actions.
+func NewAdminLogStorage() core.AdminLog {
+	return new(adminLogStorage)
+}
+
+func adminLogPrefix() []byte {
+	return prefixkey(scopeIDSystem, []byte{sysAdminLog})
+}
+
+func adminLogKey(index uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, index)
+	return bytes.Join([][]byte{adminLogPrefix(), k}, nil)
+}
+
+func decodeAdminLogEntry(v []byte) (*core.AdminLogEntry, error) {
+	var entry core.AdminLogEntry
+	err := gob.NewDecoder(bytes.NewReader(v)).Decode(&entry)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode admin log entry")
+	}
+	return &entry, nil
+}
+
+// AdminLogEntryHash returns hash of entry that is stored in entry.Hash. Hash covers all fields of entry including
+// hash of previous one.
+func AdminLogEntryHash(scheme core.PlatformCryptographyScheme, entry core.AdminLogEntry) ([]byte, error) {
+	entry.Hash = nil
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(entry)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode admin log entry")
+	}
+	return scheme.IntegrityHasher().Hash(buf.Bytes()), nil
+}
+
+func (s *adminLogStorage) loadLast(ctx context.Context) error {
+	return s.DB.iterate(ctx, adminLogPrefix(), func(k, v []byte) error {
+		entry, err := decodeAdminLogEntry(v)
+		if err != nil {
+			return err
+		}
+		s.last = entry
+		return nil
+	})
+}
+
+// AppendAdminAction adds entry to the end of log and returns it with index and hashes set.
+func (s *adminLogStorage) AppendAdminAction(ctx context.Context, entry core.AdminLogEntry) (*core.AdminLogEntry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.last == nil {
+		if err := s.loadLast(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to load last admin log entry")
+		}
+	}
+	entry.Index = 1
+	entry.PrevHash = nil
+	if s.last != nil {
+		entry.Index = s.last.Index + 1
+		entry.PrevHash = s.last.Hash
+	}
+	hash, err := AdminLogEntryHash(s.PlatformCryptographyScheme, entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.Hash = hash
+
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(entry)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode admin log entry")
+	}
+	err = s.DB.Update(ctx, func(tx *TransactionManager) error {
+		k := adminLogKey(entry.Index)
+		_, err := tx.get(ctx, k)
+		if err == nil {
+			return ErrOverride
+		}
+		if err != ErrNotFound {
+			return err
+		}
+		return tx.set(ctx, k, buf.Bytes())
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save admin log entry")
+	}
+	s.last = &entry
+	return &entry, nil
+}
+
+// GetAdminActions returns up to limit entries starting from index from.
+func (s *adminLogStorage) GetAdminActions(ctx context.Context, from uint64, limit int) ([]core.AdminLogEntry, error) {
+	var result []core.AdminLogEntry
+	err := s.DB.iterate(ctx, adminLogPrefix(), func(k, v []byte) error {
+		if binary.BigEndian.Uint64(k) < from {
+			return nil
+		}
+		if limit > 0 && len(result) >= limit {
+			return errStopIteration
+		}
+		entry, err := decodeAdminLogEntry(v)
+		if err != nil {
+			return err
+		}
+		result = append(result, *entry)
+		return nil
+	})
+	if err != nil && err != errStopIteration {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminLogStorage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+
+	newLog := func() core.AdminLog {
+		log := storage.NewAdminLogStorage()
+		cm := &component.Manager{}
+		cm.Inject(scheme, db, log)
+		return log
+	}
+	log := newLog()
+
+	got, err := log.GetAdminActions(ctx, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	actions := []string{"banlist.Unban", "access.Set", "drill.Start", "drill.Stop"}
+	for i, action := range actions {
+		// the log is reopened in the middle, chain must continue from entry saved in db
+		if i == 2 {
+			log = newLog()
+		}
+		entry, err := log.AppendAdminAction(ctx, core.AdminLogEntry{
+			Time:     time.Unix(int64(i), 0).UTC(),
+			Pulse:    core.PulseNumber(100 + i),
+			Operator: "operator",
+			Action:   action,
+			Params:   []byte("{}"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), entry.Index)
+	}
+
+	got, err = log.GetAdminActions(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, got, len(actions))
+	var prev []byte
+	for i, entry := range got {
+		assert.Equal(t, actions[i], entry.Action)
+		assert.Equal(t, prev, entry.PrevHash)
+		hash, err := storage.AdminLogEntryHash(scheme, entry)
+		require.NoError(t, err)
+		assert.Equal(t, hash, entry.Hash)
+		prev = entry.Hash
+	}
+
+	got, err = log.GetAdminActions(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(2), got[0].Index)
+	assert.Equal(t, uint64(3), got[1].Index)
+}
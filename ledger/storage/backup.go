@@ -0,0 +1,162 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/pkg/errors"
+)
+
+// DropMismatch describes jet drop which recorded hash differs from the recomputed one.
+type DropMismatch struct {
+	JetPrefix []byte
+	Pulse     core.PulseNumber
+	Recorded  []byte
+	Computed  []byte
+}
+
+// BackupReport is a result of backup verification.
+type BackupReport struct {
+	// Pulse is the latest pulse of the snapshot.
+	Pulse      core.PulseNumber
+	Drops      int
+	Mismatches []DropMismatch
+	// WALReplayed is count of commits replayed from write-ahead log after snapshot is restored.
+	WALReplayed int
+}
+
+// Passed checks if all drops of the snapshot are consistent.
+func (r *BackupReport) Passed() bool {
+	return len(r.Mismatches) == 0
+}
+
+// VerifyDrops recomputes hashes of all jet drops up to provided pulse and compares them with the hashes recorded
+// in drops. Zero pulse means the latest pulse of the storage.
+func VerifyDrops(
+	ctx context.Context, db DBContext, scheme core.PlatformCryptographyScheme, until core.PulseNumber,
+) (*BackupReport, error) {
+	if until == 0 {
+		tx, err := db.BeginTransaction(false)
+		if err != nil {
+			return nil, err
+		}
+		latest, err := tx.GetLatestPulse(ctx)
+		tx.Discard()
+		if err != nil {
+			return nil, errors.Wrap(err, "[ VerifyDrops ] can't get latest pulse")
+		}
+		until = latest.Pulse.PulseNumber
+	}
+
+	report := &BackupReport{Pulse: until}
+	drops, err := readDrops(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ VerifyDrops ] can't read drops")
+	}
+	var verified []storedDrop
+	for _, d := range drops {
+		if d.drop.Pulse <= report.Pulse {
+			verified = append(verified, d)
+		}
+	}
+	if err := verifyDrops(ctx, db, scheme, verified, report); err != nil {
+		return nil, errors.Wrap(err, "[ VerifyDrops ]")
+	}
+	return report, nil
+}
+
+// VerifyLastDrops recomputes hashes of jet drops of the latest pulse which has drops and compares them with
+// the hashes recorded in drops. Report pulse is zero if storage has no drops.
+func VerifyLastDrops(ctx context.Context, db DBContext, scheme core.PlatformCryptographyScheme) (*BackupReport, error) {
+	drops, err := readDrops(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ VerifyLastDrops ] can't read drops")
+	}
+
+	report := &BackupReport{}
+	for _, d := range drops {
+		if d.drop.Pulse > report.Pulse {
+			report.Pulse = d.drop.Pulse
+		}
+	}
+	var last []storedDrop
+	for _, d := range drops {
+		if d.drop.Pulse == report.Pulse {
+			last = append(last, d)
+		}
+	}
+	if err := verifyDrops(ctx, db, scheme, last, report); err != nil {
+		return nil, errors.Wrap(err, "[ VerifyLastDrops ]")
+	}
+	return report, nil
+}
+
+// storedDrop is a jet drop with jet prefix of its key.
+type storedDrop struct {
+	prefix []byte
+	drop   *jet.JetDrop
+}
+
+// readDrops returns all drops with hashes, genesis drop is a placeholder without hash and is skipped.
+func readDrops(ctx context.Context, db DBContext) ([]storedDrop, error) {
+	var drops []storedDrop
+	err := db.iterate(ctx, []byte{scopeIDJetDrop}, func(k, v []byte) error {
+		if len(k) < core.PulseNumberSize {
+			return errors.Errorf("unexpected drop key %v", k)
+		}
+		drop, err := jet.Decode(v)
+		if err != nil {
+			return err
+		}
+		if len(drop.Hash) == 0 {
+			return nil
+		}
+		drops = append(drops, storedDrop{prefix: k[:len(k)-core.PulseNumberSize], drop: drop})
+		return nil
+	})
+	return drops, err
+}
+
+// verifyDrops recomputes hashes of drops and adds them to report.
+func verifyDrops(
+	ctx context.Context,
+	db DBContext,
+	scheme core.PlatformCryptographyScheme,
+	drops []storedDrop,
+	report *BackupReport,
+) error {
+	for _, d := range drops {
+		computed, _, err := dropHash(ctx, db, scheme.ReferenceHasher(), d.prefix, d.drop.Pulse, d.drop.PrevHash)
+		if err != nil {
+			return errors.Wrapf(err, "can't compute drop hash for pulse %v", d.drop.Pulse)
+		}
+		report.Drops++
+		if !bytes.Equal(computed, d.drop.Hash) {
+			report.Mismatches = append(report.Mismatches, DropMismatch{
+				JetPrefix: d.prefix,
+				Pulse:     d.drop.Pulse,
+				Recorded:  d.drop.Hash,
+				Computed:  computed,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackup_VerifyDrops(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	jetID := core.TODOJetID
+	pulse := core.GenesisPulse.PulseNumber
+
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	objectStorage := storage.NewObjectStorage()
+	dropStorage := storage.NewDropStorage(10)
+	cm := &component.Manager{}
+	cm.Inject(scheme, db, objectStorage, dropStorage)
+
+	memory := testutils.RandomID()
+	_, err := objectStorage.SetRecord(ctx, jetID, pulse, &record.ObjectActivateRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: &memory},
+	})
+	require.NoError(t, err)
+	drop, _, _, err := dropStorage.CreateDrop(ctx, jetID, pulse, []byte{1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, dropStorage.SetDrop(ctx, jetID, drop))
+
+	var snapshot bytes.Buffer
+	_, _, err = storage.WriteSnapshot(ctx, db, &snapshot)
+	require.NoError(t, err)
+
+	restored, restoredCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+	defer restoredCleaner()
+	_, _, err = storage.RestoreSnapshot(ctx, restored, &snapshot, 4<<20)
+	require.NoError(t, err)
+
+	report, err := storage.VerifyDrops(ctx, restored, scheme, 0)
+	require.NoError(t, err)
+	require.True(t, report.Passed())
+	require.Equal(t, 1, report.Drops)
+	require.Equal(t, pulse, report.Pulse)
+
+	// drop which hash doesn't match its records
+	otherJet := *jet.NewID(1, []byte{1 << 7})
+	require.NoError(t, dropStorage.SetDrop(ctx, otherJet, &jet.JetDrop{Pulse: pulse, Hash: []byte{4, 5, 6}}))
+
+	report, err = storage.VerifyDrops(ctx, db, scheme, pulse)
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	require.Equal(t, 2, report.Drops)
+	require.Len(t, report.Mismatches, 1)
+	require.Equal(t, []byte{4, 5, 6}, report.Mismatches[0].Recorded)
+}
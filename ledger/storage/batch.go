@@ -0,0 +1,234 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/insolar/insolar/configuration"
+	"go.opencensus.io/stats"
+)
+
+// latencyWeight is a weight of the last observed commit latency in its moving average.
+const latencyWeight = 0.25
+
+type batchRequest struct {
+	updates []keyval
+	size    int64
+	result  chan error
+}
+
+// apply writes all updates of request into transaction.
+func (req *batchRequest) apply(tx *badger.Txn) error {
+	for _, rec := range req.updates {
+		if err := rec.apply(badgerTxn{txn: tx}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBatcher groups concurrent transaction commits into shared badger transactions.
+//
+// Write transactions never read, so merging them can't produce conflicts. A batch is flushed
+// when it reaches MaxSize or when the flush delay expires. Writes arriving during a commit
+// join the next batch. Under concurrent load the delay follows commit latency: while
+// commits are slow, new writes are better off waiting for the batch than paying for their
+// own commit.
+type writeBatcher struct {
+	db      *badger.DB
+	maxSize int64
+
+	minDelay, maxDelay time.Duration
+	// latency is a moving average of commit latency and merged is a number of commits
+	// in the last batch. Both are owned by the loop goroutine.
+	latency time.Duration
+	merged  int
+
+	requests chan *batchRequest
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newWriteBatcher(db *badger.DB, conf configuration.WriteBatch) *writeBatcher {
+	b := &writeBatcher{
+		db:       db,
+		maxSize:  conf.MaxSize,
+		minDelay: conf.MinDelay,
+		maxDelay: conf.MaxDelay,
+		requests: make(chan *batchRequest),
+		stop:     make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.loop()
+	return b
+}
+
+// write blocks until updates are committed to disk as a part of some batch.
+func (b *writeBatcher) write(updates map[string]keyval) error {
+	req := &batchRequest{
+		updates: make([]keyval, 0, len(updates)),
+		result:  make(chan error, 1),
+	}
+	for _, rec := range updates {
+		req.updates = append(req.updates, rec)
+		req.size += int64(len(rec.k) + len(rec.v))
+	}
+
+	select {
+	case b.requests <- req:
+	case <-b.stop:
+		return ErrClosed
+	}
+	return <-req.result
+}
+
+// close flushes pending batch and stops the batcher.
+func (b *writeBatcher) close() {
+	b.stopOnce.Do(func() {
+		close(b.stop)
+	})
+	b.wg.Wait()
+}
+
+func (b *writeBatcher) loop() {
+	defer b.wg.Done()
+	for {
+		var req *batchRequest
+		select {
+		case req = <-b.requests:
+		case <-b.stop:
+			return
+		}
+
+		batch := []*batchRequest{req}
+		size := req.size
+		// Take everything that queued up during the previous commit.
+	drain:
+		for size < b.maxSize {
+			select {
+			case req = <-b.requests:
+				batch = append(batch, req)
+				size += req.size
+			default:
+				break drain
+			}
+		}
+		if delay := b.delay(); delay > 0 && size < b.maxSize {
+			timer := time.NewTimer(delay)
+		collect:
+			for size < b.maxSize {
+				select {
+				case req = <-b.requests:
+					batch = append(batch, req)
+					size += req.size
+				case <-timer.C:
+					break collect
+				case <-b.stop:
+					break collect
+				}
+			}
+			timer.Stop()
+		}
+
+		b.flush(batch, size)
+	}
+}
+
+// delay returns how long a batch should wait for more writes. Waiting makes sense only
+// under concurrent load, otherwise it just adds latency to a single writer.
+func (b *writeBatcher) delay() time.Duration {
+	if b.merged < 2 {
+		return 0
+	}
+	d := b.latency / 2
+	if d < b.minDelay {
+		d = b.minDelay
+	}
+	if d > b.maxDelay {
+		d = b.maxDelay
+	}
+	return d
+}
+
+// flush commits batch in as few transactions as badger allows. A request is never split between transactions, so
+// commit of TransactionManager stays atomic: if request doesn't fit into transaction with earlier requests, they are
+// committed without it and request gets a transaction of its own, request which doesn't fit even there fails.
+func (b *writeBatcher) flush(batch []*batchRequest, size int64) {
+	start := time.Now()
+
+	// pending holds requests with all updates in the current transaction.
+	var pending []*batchRequest
+	tx := b.db.NewTransaction(true)
+	reply := func(err error) {
+		for _, req := range pending {
+			req.result <- err
+		}
+		pending = pending[:0]
+	}
+
+	for _, req := range batch {
+		err := req.apply(tx)
+		if err == nil {
+			pending = append(pending, req)
+			continue
+		}
+
+		// Transaction holds partial updates of the request, so it is rebuilt from earlier requests.
+		tx.Discard()
+		tx = b.db.NewTransaction(true)
+		for _, done := range pending {
+			if rerr := done.apply(tx); rerr != nil {
+				tx.Discard()
+				tx = b.db.NewTransaction(true)
+				reply(rerr)
+				break
+			}
+		}
+		if err == badger.ErrTxnTooBig && len(pending) > 0 {
+			reply(tx.Commit(nil))
+			tx = b.db.NewTransaction(true)
+			if err = req.apply(tx); err == nil {
+				pending = append(pending, req)
+				continue
+			}
+			tx.Discard()
+			tx = b.db.NewTransaction(true)
+		}
+		req.result <- err
+	}
+	if len(pending) > 0 {
+		reply(tx.Commit(nil))
+	} else {
+		tx.Discard()
+	}
+
+	latency := time.Since(start)
+	b.merged = len(batch)
+	b.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(b.latency))
+
+	stats.Record(
+		context.Background(),
+		statBatchRequests.M(int64(len(batch))),
+		statBatchSize.M(size),
+		statBatchLatency.M(float64(latency)/float64(time.Millisecond)),
+	)
+}
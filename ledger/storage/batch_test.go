@@ -0,0 +1,222 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/insolar/insolar/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tmpBadger(t *testing.T, opts *badger.Options) (*badger.DB, func()) {
+	dir, err := ioutil.TempDir("", "bdb-batch-test-")
+	require.NoError(t, err)
+	opts = setOptions(opts)
+	opts.Dir = dir
+	opts.ValueDir = dir
+	bdb, err := badger.Open(*opts)
+	require.NoError(t, err)
+	return bdb, func() {
+		bdb.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func readKey(t *testing.T, bdb *badger.DB, k []byte) []byte {
+	var v []byte
+	err := bdb.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(k)
+		if err != nil {
+			return err
+		}
+		v, err = item.ValueCopy(nil)
+		return err
+	})
+	require.NoError(t, err)
+	return v
+}
+
+func TestWriteBatcher_ConcurrentWrites(t *testing.T) {
+	bdb, cleaner := tmpBadger(t, nil)
+	defer cleaner()
+
+	b := newWriteBatcher(bdb, configuration.WriteBatch{
+		MaxSize:  1 << 20,
+		MinDelay: time.Millisecond,
+		MaxDelay: 10 * time.Millisecond,
+	})
+	defer b.close()
+
+	const writers = 50
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			k := []byte(fmt.Sprintf("key-%d", i))
+			err := b.write(map[string]keyval{string(k): {k: k, v: []byte{byte(i)}}})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < writers; i++ {
+		k := []byte(fmt.Sprintf("key-%d", i))
+		assert.Equal(t, []byte{byte(i)}, readKey(t, bdb, k))
+	}
+}
+
+// bigRequest creates request of count updates with values of 1Kb.
+func bigRequest(prefix string, count int) *batchRequest {
+	req := &batchRequest{result: make(chan error, 1)}
+	for i := 0; i < count; i++ {
+		k := []byte(fmt.Sprintf("%s-%d", prefix, i))
+		req.updates = append(req.updates, keyval{k: k, v: make([]byte, 1<<10)})
+		req.size += int64(len(k) + 1<<10)
+	}
+	return req
+}
+
+func TestWriteBatcher_SplitsTooBigBatch(t *testing.T) {
+	opts := badger.DefaultOptions
+	opts.MaxTableSize = 1 << 20
+	bdb, cleaner := tmpBadger(t, &opts)
+	defer cleaner()
+
+	// Each request fits into badger transaction, but all of them don't.
+	var batch []*batchRequest
+	var size int64
+	for i := 0; i < 5; i++ {
+		req := bigRequest(fmt.Sprintf("req-%d", i), 100)
+		batch = append(batch, req)
+		size += req.size
+	}
+	b := &writeBatcher{db: bdb}
+	b.flush(batch, size)
+
+	for _, req := range batch {
+		require.NoError(t, <-req.result)
+		for _, rec := range req.updates {
+			assert.Len(t, readKey(t, bdb, rec.k), 1<<10)
+		}
+	}
+}
+
+func TestWriteBatcher_TooBigRequest(t *testing.T) {
+	opts := badger.DefaultOptions
+	opts.MaxTableSize = 1 << 20
+	bdb, cleaner := tmpBadger(t, &opts)
+	defer cleaner()
+
+	// Request exceeds badger transaction limit, it fails as a whole and doesn't affect requests around it.
+	before := bigRequest("before", 10)
+	tooBig := bigRequest("big", 1000)
+	after := bigRequest("after", 10)
+	batch := []*batchRequest{before, tooBig, after}
+	b := &writeBatcher{db: bdb}
+	b.flush(batch, before.size+tooBig.size+after.size)
+
+	require.Equal(t, badger.ErrTxnTooBig, <-tooBig.result)
+	err := bdb.View(func(tx *badger.Txn) error {
+		for _, rec := range tooBig.updates {
+			_, err := tx.Get(rec.k)
+			assert.Equal(t, badger.ErrKeyNotFound, err)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	for _, req := range []*batchRequest{before, after} {
+		require.NoError(t, <-req.result)
+		for _, rec := range req.updates {
+			assert.Len(t, readKey(t, bdb, rec.k), 1<<10)
+		}
+	}
+}
+
+func TestWriteBatcher_Delay(t *testing.T) {
+	b := &writeBatcher{minDelay: time.Millisecond, maxDelay: 10 * time.Millisecond}
+	b.latency = 4 * time.Millisecond
+	assert.Equal(t, time.Duration(0), b.delay(), "no delay for a single writer")
+
+	b.merged = 2
+	assert.Equal(t, 2*time.Millisecond, b.delay())
+
+	b.latency = time.Millisecond
+	assert.Equal(t, time.Millisecond, b.delay())
+
+	b.latency = time.Second
+	assert.Equal(t, 10*time.Millisecond, b.delay())
+}
+
+func TestWriteBatcher_WriteAfterClose(t *testing.T) {
+	bdb, cleaner := tmpBadger(t, nil)
+	defer cleaner()
+
+	b := newWriteBatcher(bdb, configuration.WriteBatch{MaxSize: 1 << 20, MaxDelay: time.Millisecond})
+	b.close()
+
+	err := b.write(map[string]keyval{"k": {k: []byte("k"), v: []byte("v")}})
+	assert.Equal(t, ErrClosed, err)
+}
+
+func BenchmarkWriteBatcher(b *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		b.Run(fmt.Sprintf("batch=%v", enabled), func(b *testing.B) {
+			dir, err := ioutil.TempDir("", "bdb-batch-bench-")
+			require.NoError(b, err)
+			defer os.RemoveAll(dir)
+
+			conf := configuration.NewLedger()
+			conf.Storage.DataDirectory = dir
+			conf.Storage.WriteBatch.Enabled = enabled
+			dbctx, err := NewDB(conf, nil)
+			require.NoError(b, err)
+			defer dbctx.Close()
+			db := dbctx.(*DB)
+
+			ctx := context.Background()
+			var n int64
+			var mu sync.Mutex
+			b.SetParallelism(64)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					mu.Lock()
+					n++
+					k := []byte(fmt.Sprintf("key-%d", n))
+					mu.Unlock()
+					err := db.Update(ctx, func(tx *TransactionManager) error {
+						return tx.set(ctx, k, make([]byte, 128))
+					})
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -19,12 +19,12 @@ package storage
 import (
 	"context"
 
-	"github.com/dgraph-io/badger"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/insmetrics"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
 	"github.com/pkg/errors"
 	"go.opencensus.io/stats"
 )
@@ -40,6 +40,12 @@ type Cleaner interface {
 	) (map[string]RmStat, error)
 
 	RemoveJetIndexesUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber, recent recentstorage.RecentStorage) (RmStat, error)
+	RemoveJetRecordsByClassUntil(
+		ctx context.Context,
+		jetID core.RecordID,
+		until map[RecordClass]core.PulseNumber,
+		recent recentstorage.RecentStorage,
+	) (RmStat, error)
 	RemoveJetBlobsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error)
 	RemoveJetRecordsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error)
 	RemoveJetDropsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error)
@@ -55,6 +61,21 @@ func NewCleaner() Cleaner {
 
 var rmScanFromPulse = core.PulseNumber(core.FirstPulseNumber + 1).Bytes()
 
+// RecordClass is class of records light material may remove earlier than other data.
+type RecordClass string
+
+const (
+	// RecordClassRequest is class of request records.
+	RecordClassRequest RecordClass = "request"
+	// RecordClassResult is class of result records.
+	RecordClassResult RecordClass = "result"
+)
+
+var recordClasses = map[record.TypeID]RecordClass{
+	(&record.RequestRecord{}).Type(): RecordClassRequest,
+	(&record.ResultRecord{}).Type():  RecordClassResult,
+}
+
 type RmStat struct {
 	Scanned int64
 	Removed int64
@@ -91,7 +112,7 @@ func (c *cleaner) RemoveAllForJetUntilPulse(
 		result = multierror.Append(result, errors.Wrap(err, "RemoveJetBlobsUntil"))
 	}
 	allstat["blobs"] = stat
-	if stat, err = c.RemoveJetRecordsUntil(ctx, jetID, pn); err != nil {
+	if stat, err = c.removeJetRecordsUntil(ctx, scopeIDRecord, jetID, pn, pendingRequests(recent)); err != nil {
 		result = multierror.Append(result, errors.Wrap(err, "RemoveJetRecordsUntil"))
 	}
 	allstat["records"] = stat
@@ -107,7 +128,11 @@ func (c *cleaner) RemoveAllForJetUntilPulse(
 // RemoveJetIndexesUntil removes for provided JetID all lifelines older than provided pulse number.
 // Indexes caches by recent storage, we should avoid them deletion.
 func (c *cleaner) RemoveJetIndexesUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber, recent recentstorage.RecentStorage) (RmStat, error) {
-	return c.removeJetRecordsUntil(ctx, scopeIDLifeline, jetID, pn, recent)
+	var keep func(core.RecordID) bool
+	if recent != nil {
+		keep = recent.IsRecordIDCached
+	}
+	return c.removeJetRecordsUntil(ctx, scopeIDLifeline, jetID, pn, keep)
 }
 
 // RemoveJetBlobsUntil removes for provided JetID all blobs older than provided pulse number.
@@ -116,11 +141,99 @@ func (c *cleaner) RemoveJetBlobsUntil(ctx context.Context, jetID core.RecordID,
 }
 
 // RemoveJetRecordsUntil removes for provided JetID all records older than provided pulse number.
-// In recods pending requests live, so we need recent storage here
+// Pending requests live in records, RemoveAllForJetUntilPulse keeps requests pending in recent storage.
 func (c *cleaner) RemoveJetRecordsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error) {
 	return c.removeJetRecordsUntil(ctx, scopeIDRecord, jetID, pn, nil)
 }
 
+// pendingRequests returns check if record is pending request of recent storage, such records are kept until
+// request is closed.
+func pendingRequests(recent recentstorage.RecentStorage) func(core.RecordID) bool {
+	if recent == nil {
+		return nil
+	}
+	pending := map[core.RecordID]struct{}{}
+	for _, requests := range recent.GetRequests() {
+		for id := range requests {
+			pending[id] = struct{}{}
+		}
+	}
+	return func(id core.RecordID) bool {
+		_, ok := pending[id]
+		return ok
+	}
+}
+
+// RemoveJetRecordsByClassUntil removes for provided JetID records of classes older than pulse numbers of classes.
+// Requests pending in recent storage are kept.
+func (c *cleaner) RemoveJetRecordsByClassUntil(
+	ctx context.Context,
+	jetID core.RecordID,
+	until map[RecordClass]core.PulseNumber,
+	recent recentstorage.RecentStorage,
+) (RmStat, error) {
+	var stat RmStat
+	var last core.PulseNumber
+	for _, pn := range until {
+		if pn > last {
+			last = pn
+		}
+	}
+	keep := pendingRequests(recent)
+	_, prefix := jet.Jet(jetID)
+	jetprefix := prefixkey(scopeIDRecord, prefix)
+	startprefix := prefixkey(scopeIDRecord, prefix, rmScanFromPulse)
+
+	err := c.DB.GetKV().Update(func(txn KVTxn) error {
+		var (
+			id      core.RecordID
+			removed [][]byte
+		)
+		err := txn.Iterate(jetprefix, startprefix, func(key, value []byte) (bool, error) {
+			pn := pulseFromKey(key)
+			if pn >= last {
+				return false, nil
+			}
+			stat.Scanned++
+
+			buf, err := decodeRecordValue(c.DB, value)
+			if err != nil {
+				return false, err
+			}
+			if len(buf) < record.TypeIDSize {
+				return true, nil
+			}
+			class, ok := recordClasses[record.DeserializeType(buf[:record.TypeIDSize])]
+			if !ok || pn >= until[class] {
+				return true, nil
+			}
+			if keep != nil {
+				copy(id[:], key[len(jetprefix):])
+				if keep(id) {
+					return true, nil
+				}
+			}
+			removed = append(removed, key)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range removed {
+			if err := txn.Delete(key); err != nil {
+				return err
+			}
+			stat.Removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return stat, err
+	}
+	recordCleanupMetrics(ctx, map[string]RmStat{"transient": stat})
+	return stat, nil
+}
+
 // RemoveJetDropsUntil removes for provided JetID all jet drops older than provided pulse number.
 func (c *cleaner) RemoveJetDropsUntil(ctx context.Context, jetID core.RecordID, pn core.PulseNumber) (RmStat, error) {
 	return c.removeJetRecordsUntil(ctx, scopeIDJetDrop, jetID, pn, nil)
@@ -131,33 +244,37 @@ func (c *cleaner) removeJetRecordsUntil(
 	namespace byte,
 	jetID core.RecordID,
 	pn core.PulseNumber,
-	recent recentstorage.RecentStorage,
+	keep func(core.RecordID) bool,
 ) (RmStat, error) {
 	var stat RmStat
 	_, prefix := jet.Jet(jetID)
 	jetprefix := prefixkey(namespace, prefix)
 	startprefix := prefixkey(namespace, prefix, rmScanFromPulse)
 
-	return stat, c.DB.GetBadgerDB().Update(func(txn *badger.Txn) error {
-		var id core.RecordID
-		opts := badger.DefaultIteratorOptions
-		opts.PrefetchSize = 0
-		it := txn.NewIterator(opts)
-		defer it.Close()
-		for it.Seek(startprefix); it.ValidForPrefix(jetprefix); it.Next() {
-			key := it.Item().KeyCopy(nil)
+	return stat, c.DB.GetKV().Update(func(txn KVTxn) error {
+		var (
+			id      core.RecordID
+			removed [][]byte
+		)
+		err := txn.IterateKeys(jetprefix, startprefix, func(key []byte) (bool, error) {
 			if pulseFromKey(key) >= pn {
-				break
+				return false, nil
 			}
 			stat.Scanned++
 
-			if recent != nil {
+			if keep != nil {
 				copy(id[:], key[len(jetprefix):])
-				if recent.IsRecordIDCached(id) {
-					continue
+				if keep(id) {
+					return true, nil
 				}
 			}
-
+			removed = append(removed, key)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range removed {
 			if err := txn.Delete(key); err != nil {
 				return err
 			}
@@ -41,6 +41,11 @@ type CleanerMock struct {
 	RemoveJetIndexesUntilPreCounter uint64
 	RemoveJetIndexesUntilMock       mCleanerMockRemoveJetIndexesUntil
 
+	RemoveJetRecordsByClassUntilFunc       func(p context.Context, p1 core.RecordID, p2 map[RecordClass]core.PulseNumber, p3 recentstorage.RecentStorage) (r RmStat, r1 error)
+	RemoveJetRecordsByClassUntilCounter    uint64
+	RemoveJetRecordsByClassUntilPreCounter uint64
+	RemoveJetRecordsByClassUntilMock       mCleanerMockRemoveJetRecordsByClassUntil
+
 	RemoveJetRecordsUntilFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r RmStat, r1 error)
 	RemoveJetRecordsUntilCounter    uint64
 	RemoveJetRecordsUntilPreCounter uint64
@@ -59,6 +64,7 @@ func NewCleanerMock(t minimock.Tester) *CleanerMock {
 	m.RemoveJetBlobsUntilMock = mCleanerMockRemoveJetBlobsUntil{mock: m}
 	m.RemoveJetDropsUntilMock = mCleanerMockRemoveJetDropsUntil{mock: m}
 	m.RemoveJetIndexesUntilMock = mCleanerMockRemoveJetIndexesUntil{mock: m}
+	m.RemoveJetRecordsByClassUntilMock = mCleanerMockRemoveJetRecordsByClassUntil{mock: m}
 	m.RemoveJetRecordsUntilMock = mCleanerMockRemoveJetRecordsUntil{mock: m}
 
 	return m
@@ -674,6 +680,159 @@ func (m *CleanerMock) RemoveJetIndexesUntilFinished() bool {
 	return true
 }
 
+type mCleanerMockRemoveJetRecordsByClassUntil struct {
+	mock              *CleanerMock
+	mainExpectation   *CleanerMockRemoveJetRecordsByClassUntilExpectation
+	expectationSeries []*CleanerMockRemoveJetRecordsByClassUntilExpectation
+}
+
+type CleanerMockRemoveJetRecordsByClassUntilExpectation struct {
+	input  *CleanerMockRemoveJetRecordsByClassUntilInput
+	result *CleanerMockRemoveJetRecordsByClassUntilResult
+}
+
+type CleanerMockRemoveJetRecordsByClassUntilInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 map[RecordClass]core.PulseNumber
+	p3 recentstorage.RecentStorage
+}
+
+type CleanerMockRemoveJetRecordsByClassUntilResult struct {
+	r  RmStat
+	r1 error
+}
+
+//Expect specifies that invocation of Cleaner.RemoveJetRecordsByClassUntil is expected from 1 to Infinity times
+func (m *mCleanerMockRemoveJetRecordsByClassUntil) Expect(p context.Context, p1 core.RecordID, p2 map[RecordClass]core.PulseNumber, p3 recentstorage.RecentStorage) *mCleanerMockRemoveJetRecordsByClassUntil {
+	m.mock.RemoveJetRecordsByClassUntilFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CleanerMockRemoveJetRecordsByClassUntilExpectation{}
+	}
+	m.mainExpectation.input = &CleanerMockRemoveJetRecordsByClassUntilInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of Cleaner.RemoveJetRecordsByClassUntil
+func (m *mCleanerMockRemoveJetRecordsByClassUntil) Return(r RmStat, r1 error) *CleanerMock {
+	m.mock.RemoveJetRecordsByClassUntilFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CleanerMockRemoveJetRecordsByClassUntilExpectation{}
+	}
+	m.mainExpectation.result = &CleanerMockRemoveJetRecordsByClassUntilResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Cleaner.RemoveJetRecordsByClassUntil is expected once
+func (m *mCleanerMockRemoveJetRecordsByClassUntil) ExpectOnce(p context.Context, p1 core.RecordID, p2 map[RecordClass]core.PulseNumber, p3 recentstorage.RecentStorage) *CleanerMockRemoveJetRecordsByClassUntilExpectation {
+	m.mock.RemoveJetRecordsByClassUntilFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CleanerMockRemoveJetRecordsByClassUntilExpectation{}
+	expectation.input = &CleanerMockRemoveJetRecordsByClassUntilInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CleanerMockRemoveJetRecordsByClassUntilExpectation) Return(r RmStat, r1 error) {
+	e.result = &CleanerMockRemoveJetRecordsByClassUntilResult{r, r1}
+}
+
+//Set uses given function f as a mock of Cleaner.RemoveJetRecordsByClassUntil method
+func (m *mCleanerMockRemoveJetRecordsByClassUntil) Set(f func(p context.Context, p1 core.RecordID, p2 map[RecordClass]core.PulseNumber, p3 recentstorage.RecentStorage) (r RmStat, r1 error)) *CleanerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.RemoveJetRecordsByClassUntilFunc = f
+	return m.mock
+}
+
+//RemoveJetRecordsByClassUntil implements github.com/insolar/insolar/ledger/storage.Cleaner interface
+func (m *CleanerMock) RemoveJetRecordsByClassUntil(p context.Context, p1 core.RecordID, p2 map[RecordClass]core.PulseNumber, p3 recentstorage.RecentStorage) (r RmStat, r1 error) {
+	counter := atomic.AddUint64(&m.RemoveJetRecordsByClassUntilPreCounter, 1)
+	defer atomic.AddUint64(&m.RemoveJetRecordsByClassUntilCounter, 1)
+
+	if len(m.RemoveJetRecordsByClassUntilMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.RemoveJetRecordsByClassUntilMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CleanerMock.RemoveJetRecordsByClassUntil. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.RemoveJetRecordsByClassUntilMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, CleanerMockRemoveJetRecordsByClassUntilInput{p, p1, p2, p3}, "Cleaner.RemoveJetRecordsByClassUntil got unexpected parameters")
+
+		result := m.RemoveJetRecordsByClassUntilMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CleanerMock.RemoveJetRecordsByClassUntil")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.RemoveJetRecordsByClassUntilMock.mainExpectation != nil {
+
+		input := m.RemoveJetRecordsByClassUntilMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, CleanerMockRemoveJetRecordsByClassUntilInput{p, p1, p2, p3}, "Cleaner.RemoveJetRecordsByClassUntil got unexpected parameters")
+		}
+
+		result := m.RemoveJetRecordsByClassUntilMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CleanerMock.RemoveJetRecordsByClassUntil")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.RemoveJetRecordsByClassUntilFunc == nil {
+		m.t.Fatalf("Unexpected call to CleanerMock.RemoveJetRecordsByClassUntil. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.RemoveJetRecordsByClassUntilFunc(p, p1, p2, p3)
+}
+
+//RemoveJetRecordsByClassUntilMinimockCounter returns a count of CleanerMock.RemoveJetRecordsByClassUntilFunc invocations
+func (m *CleanerMock) RemoveJetRecordsByClassUntilMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.RemoveJetRecordsByClassUntilCounter)
+}
+
+//RemoveJetRecordsByClassUntilMinimockPreCounter returns the value of CleanerMock.RemoveJetRecordsByClassUntil invocations
+func (m *CleanerMock) RemoveJetRecordsByClassUntilMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.RemoveJetRecordsByClassUntilPreCounter)
+}
+
+//RemoveJetRecordsByClassUntilFinished returns true if mock invocations count is ok
+func (m *CleanerMock) RemoveJetRecordsByClassUntilFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.RemoveJetRecordsByClassUntilMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.RemoveJetRecordsByClassUntilCounter) == uint64(len(m.RemoveJetRecordsByClassUntilMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.RemoveJetRecordsByClassUntilMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.RemoveJetRecordsByClassUntilCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.RemoveJetRecordsByClassUntilFunc != nil {
+		return atomic.LoadUint64(&m.RemoveJetRecordsByClassUntilCounter) > 0
+	}
+
+	return true
+}
+
 type mCleanerMockRemoveJetRecordsUntil struct {
 	mock              *CleanerMock
 	mainExpectation   *CleanerMockRemoveJetRecordsUntilExpectation
@@ -846,6 +1005,10 @@ func (m *CleanerMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to CleanerMock.RemoveJetIndexesUntil")
 	}
 
+	if !m.RemoveJetRecordsByClassUntilFinished() {
+		m.t.Fatal("Expected call to CleanerMock.RemoveJetRecordsByClassUntil")
+	}
+
 	if !m.RemoveJetRecordsUntilFinished() {
 		m.t.Fatal("Expected call to CleanerMock.RemoveJetRecordsUntil")
 	}
@@ -883,6 +1046,10 @@ func (m *CleanerMock) MinimockFinish() {
 		m.t.Fatal("Expected call to CleanerMock.RemoveJetIndexesUntil")
 	}
 
+	if !m.RemoveJetRecordsByClassUntilFinished() {
+		m.t.Fatal("Expected call to CleanerMock.RemoveJetRecordsByClassUntil")
+	}
+
 	if !m.RemoveJetRecordsUntilFinished() {
 		m.t.Fatal("Expected call to CleanerMock.RemoveJetRecordsUntil")
 	}
@@ -905,6 +1072,7 @@ func (m *CleanerMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.RemoveJetBlobsUntilFinished()
 		ok = ok && m.RemoveJetDropsUntilFinished()
 		ok = ok && m.RemoveJetIndexesUntilFinished()
+		ok = ok && m.RemoveJetRecordsByClassUntilFinished()
 		ok = ok && m.RemoveJetRecordsUntilFinished()
 
 		if ok {
@@ -930,6 +1098,10 @@ func (m *CleanerMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to CleanerMock.RemoveJetIndexesUntil")
 			}
 
+			if !m.RemoveJetRecordsByClassUntilFinished() {
+				m.t.Error("Expected call to CleanerMock.RemoveJetRecordsByClassUntil")
+			}
+
 			if !m.RemoveJetRecordsUntilFinished() {
 				m.t.Error("Expected call to CleanerMock.RemoveJetRecordsUntil")
 			}
@@ -962,6 +1134,10 @@ func (m *CleanerMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.RemoveJetRecordsByClassUntilFinished() {
+		return false
+	}
+
 	if !m.RemoveJetRecordsUntilFinished() {
 		return false
 	}
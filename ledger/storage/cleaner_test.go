@@ -161,6 +161,61 @@ func removeJetIndexesUntil(s *cleanerSuite, skip bool) {
 		"expected keys and found indexes, doesn't match, jetID=%v", s.jetID.DebugString())
 }
 
+func (s *cleanerSuite) Test_RemoveAllForJetUntilPulse_KeepsPendingRequests() {
+	pn := core.PulseNumber(core.FirstPulseNumber + 1)
+	var ids []*core.RecordID
+	for i := 0; i < 2; i++ {
+		memory := testutils.RandomID()
+		id, err := s.objectStorage.SetRecord(s.ctx, s.jetID, pn, &record.ObjectActivateRecord{
+			ObjectStateRecord: record.ObjectStateRecord{Memory: &memory},
+		})
+		require.NoError(s.T(), err)
+		ids = append(ids, id)
+	}
+	recent := storage.NewRecentStorage(s.jetID, 2)
+	recent.AddPendingRequest(s.ctx, testutils.RandomID(), *ids[0])
+
+	_, err := s.storageCleaner.RemoveAllForJetUntilPulse(s.ctx, s.jetID, pn+1, recent)
+	require.NoError(s.T(), err)
+
+	_, err = s.objectStorage.GetRecord(s.ctx, s.jetID, ids[0])
+	assert.NoError(s.T(), err)
+	_, err = s.objectStorage.GetRecord(s.ctx, s.jetID, ids[1])
+	assert.Equal(s.T(), storage.ErrNotFound, err)
+}
+
+func (s *cleanerSuite) Test_RemoveJetRecordsByClassUntil() {
+	pn := core.PulseNumber(core.FirstPulseNumber + 1)
+	set := func(rec record.Record) *core.RecordID {
+		id, err := s.objectStorage.SetRecord(s.ctx, s.jetID, pn, rec)
+		require.NoError(s.T(), err)
+		return id
+	}
+	memory := testutils.RandomID()
+	pending := set(&record.RequestRecord{Payload: []byte{1}})
+	completed := set(&record.RequestRecord{Payload: []byte{2}})
+	result := set(&record.ResultRecord{Payload: []byte{3}})
+	state := set(&record.ObjectActivateRecord{
+		ObjectStateRecord: record.ObjectStateRecord{Memory: &memory},
+	})
+	recent := storage.NewRecentStorage(s.jetID, 2)
+	recent.AddPendingRequest(s.ctx, testutils.RandomID(), *pending)
+
+	stat, err := s.storageCleaner.RemoveJetRecordsByClassUntil(s.ctx, s.jetID, map[storage.RecordClass]core.PulseNumber{
+		storage.RecordClassRequest: pn + 1,
+		storage.RecordClassResult:  pn,
+	}, recent)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), stat.Removed)
+
+	_, err = s.objectStorage.GetRecord(s.ctx, s.jetID, completed)
+	assert.Equal(s.T(), storage.ErrNotFound, err)
+	for _, id := range []*core.RecordID{pending, result, state} {
+		_, err = s.objectStorage.GetRecord(s.ctx, s.jetID, id)
+		assert.NoError(s.T(), err)
+	}
+}
+
 func sortIDS(ids []core.RecordID) []core.RecordID {
 	sort.Slice(ids, func(i, j int) bool {
 		return bytes.Compare(ids[i][:], ids[j][:]) < 0
@@ -0,0 +1,101 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+type cloudHashStorage struct {
+	DB DBContext `inject:""`
+}
+
+// NewCloudHashStorage creates new storage of cloud hashes calculated on pulses.
+func NewCloudHashStorage() core.CloudHashStorage {
+	return new(cloudHashStorage)
+}
+
+func cloudHashPrefix() []byte {
+	return prefixkey(scopeIDSystem, []byte{sysCloudHash})
+}
+
+func cloudHashKey(pulse core.PulseNumber) []byte {
+	return bytes.Join([][]byte{cloudHashPrefix(), pulse.Bytes()}, nil)
+}
+
+func decodeCloudHash(v []byte) (*core.CloudHashRecord, error) {
+	var record core.CloudHashRecord
+	err := gob.NewDecoder(bytes.NewReader(v)).Decode(&record)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode cloud hash")
+	}
+	return &record, nil
+}
+
+// SaveCloudHash saves cloud hash of pulse, hash of the same pulse is overridden.
+func (cs *cloudHashStorage) SaveCloudHash(ctx context.Context, record core.CloudHashRecord) error {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cloud hash")
+	}
+	return cs.DB.set(ctx, cloudHashKey(record.Pulse), buf.Bytes())
+}
+
+// GetCloudHash returns cloud hash of pulse, ErrNotFound if hash of pulse wasn't saved.
+func (cs *cloudHashStorage) GetCloudHash(ctx context.Context, pulse core.PulseNumber) (*core.CloudHashRecord, error) {
+	v, err := cs.DB.get(ctx, cloudHashKey(pulse))
+	if err != nil {
+		return nil, err
+	}
+	return decodeCloudHash(v)
+}
+
+// GetCloudHashes returns up to limit cloud hashes of pulses in range [from, to] ordered by pulse, limit is ignored
+// if not positive.
+func (cs *cloudHashStorage) GetCloudHashes(
+	ctx context.Context,
+	from, to core.PulseNumber,
+	limit int,
+) ([]core.CloudHashRecord, error) {
+	var result []core.CloudHashRecord
+	err := cs.DB.iterate(ctx, cloudHashPrefix(), func(k, v []byte) error {
+		pulse := core.NewPulseNumber(k)
+		if pulse < from {
+			return nil
+		}
+		if pulse > to || (limit > 0 && len(result) >= limit) {
+			return errStopIteration
+		}
+		record, err := decodeCloudHash(v)
+		if err != nil {
+			return err
+		}
+		result = append(result, *record)
+		return nil
+	})
+	if err != nil && err != errStopIteration {
+		return nil, err
+	}
+	return result, nil
+}
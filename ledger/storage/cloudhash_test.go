@@ -0,0 +1,83 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudHashStorage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	cs := storage.NewCloudHashStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, cs)
+
+	_, err := cs.GetCloudHash(ctx, core.FirstPulseNumber)
+	assert.Equal(t, storage.ErrNotFound, err)
+
+	var records []core.CloudHashRecord
+	prev := []byte{0}
+	for i := 0; i < 5; i++ {
+		record := core.CloudHashRecord{
+			Pulse:         core.FirstPulseNumber + core.PulseNumber(i*10),
+			CloudHash:     []byte{byte(i + 1)},
+			PrevCloudHash: prev,
+			GlobuleProofs: []core.GlobuleProofRecord{{
+				Node:          testutils.RandomRef(),
+				NodeCount:     3,
+				NodeRoot:      []byte{byte(i)},
+				PrevCloudHash: prev,
+				Signature:     []byte{byte(i), 1},
+			}},
+		}
+		prev = record.CloudHash
+		records = append(records, record)
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		require.NoError(t, cs.SaveCloudHash(ctx, records[i]))
+	}
+
+	got, err := cs.GetCloudHash(ctx, records[2].Pulse)
+	require.NoError(t, err)
+	assert.Equal(t, records[2], *got)
+
+	all, err := cs.GetCloudHashes(ctx, 0, core.PulseNumber(1<<32-1), 0)
+	require.NoError(t, err)
+	assert.Equal(t, records, all)
+
+	ranged, err := cs.GetCloudHashes(ctx, records[1].Pulse, records[3].Pulse, 0)
+	require.NoError(t, err)
+	assert.Equal(t, records[1:4], ranged)
+
+	limited, err := cs.GetCloudHashes(ctx, records[1].Pulse+1, records[4].Pulse, 2)
+	require.NoError(t, err)
+	assert.Equal(t, records[2:4], limited)
+}
@@ -0,0 +1,126 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+var recordDictionaryPrefix = []byte{scopeIDSystem, sysRecordDictionary}
+
+func recordDictionaryKey(id uint32) []byte {
+	var idbuf [4]byte
+	binary.BigEndian.PutUint32(idbuf[:], id)
+	return prefixkey(scopeIDSystem, []byte{sysRecordDictionary}, idbuf[:])
+}
+
+// recordDecoder is implemented by storage which may keep records compressed.
+type recordDecoder interface {
+	decodeRecord(v []byte) ([]byte, error)
+}
+
+// decodeRecordValue returns serialized record from value stored in db.
+func decodeRecordValue(db DBContext, v []byte) ([]byte, error) {
+	if d, ok := db.(recordDecoder); ok {
+		return d.decodeRecord(v)
+	}
+	return v, nil
+}
+
+// openCompressor creates record compressor with dictionaries saved in storage.
+func (db *DB) openCompressor(conf configuration.Compression) error {
+	compressor, err := record.NewCompressor(record.CompressorOptions{
+		Level:          conf.Level,
+		DictionarySize: conf.DictionarySize,
+		TrainSamples:   conf.TrainSamples,
+		MinSize:        conf.MinSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	var dicts []*record.Dictionary
+	err = db.kv.View(func(txn KVTxn) error {
+		return txn.Iterate(recordDictionaryPrefix, nil, func(k, v []byte) (bool, error) {
+			if len(k) != len(recordDictionaryPrefix)+4 || len(v) < record.TypeIDSize {
+				return false, errors.Errorf("malformed record dictionary %v", bytes2hex(k))
+			}
+			dicts = append(dicts, &record.Dictionary{
+				ID:   binary.BigEndian.Uint32(k[len(recordDictionaryPrefix):]),
+				Type: record.DeserializeType(v[:record.TypeIDSize]),
+				Data: append([]byte{}, v[record.TypeIDSize:]...),
+			})
+			return true, nil
+		})
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to load record dictionaries")
+	}
+	for _, d := range dicts {
+		if err := compressor.AddDictionary(d); err != nil {
+			return err
+		}
+	}
+
+	db.compressor = compressor
+	db.compressRecords = conf.Enabled
+	return nil
+}
+
+// decodeRecord returns serialized record from its stored representation.
+func (db *DB) decodeRecord(v []byte) ([]byte, error) {
+	if db.compressor == nil {
+		return v, nil
+	}
+	return db.compressor.Decompress(v)
+}
+
+// setRecordValue stores serialized record, compressed if compression is enabled.
+//
+// Dictionary trained by compressor is saved in separate transaction, so it is never used before it is saved.
+func (m *TransactionManager) setRecordValue(ctx context.Context, key, value []byte) error {
+	if !m.db.compressRecords {
+		return m.set(ctx, key, value)
+	}
+
+	compressed, dict := m.db.compressor.Compress(value)
+	if dict != nil {
+		m.db.saveRecordDictionary(ctx, dict)
+	}
+	return m.set(ctx, key, compressed)
+}
+
+func (db *DB) saveRecordDictionary(ctx context.Context, dict *record.Dictionary) {
+	inslog := inslogger.FromContext(ctx)
+	err := db.set(ctx, recordDictionaryKey(dict.ID), append(record.SerializeType(dict.Type), dict.Data...))
+	if err != nil {
+		inslog.Errorf("failed to save dictionary for records of type %v: %v", dict.Type, err)
+		return
+	}
+	if err := db.compressor.AddDictionary(dict); err != nil {
+		inslog.Errorf("failed to use dictionary for records of type %v: %v", dict.Type, err)
+		return
+	}
+	inslog.Infof("trained dictionary %v for records of type %v (%v bytes)", dict.ID, dict.Type, len(dict.Data))
+}
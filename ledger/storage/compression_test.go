@@ -0,0 +1,113 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_RecordCompression(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "compression-db-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := configuration.NewLedger()
+	conf.Storage.DataDirectory = dir
+	conf.Storage.Compression.Enabled = true
+	conf.Storage.Compression.MinSize = 0
+	conf.Storage.Compression.TrainSamples = 10
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	open := func() *DB {
+		db, err := NewDB(conf, nil)
+		require.NoError(t, err)
+		db.(*DB).PlatformCryptographyScheme = scheme
+		return db.(*DB)
+	}
+
+	db := open()
+	jetID := *jet.NewID(0, nil)
+	var domain, image core.RecordRef
+	domain[0], image[0] = 1, 2
+	records := map[core.RecordID]record.Record{}
+	for i := 0; i < 30; i++ {
+		rec := &record.ObjectActivateRecord{
+			SideEffectRecord: record.SideEffectRecord{Domain: domain},
+			ObjectStateRecord: record.ObjectStateRecord{
+				Memory: record.CalculateIDForBlob(scheme, core.FirstPulseNumber, []byte{byte(i)}),
+				Image:  image,
+			},
+		}
+		err := db.Update(ctx, func(tx *TransactionManager) error {
+			id, err := tx.SetRecord(ctx, jetID, core.FirstPulseNumber, rec)
+			records[*id] = rec
+			return err
+		})
+		require.NoError(t, err)
+	}
+
+	var dicts int
+	err = db.GetKV().View(func(txn KVTxn) error {
+		return txn.IterateKeys(recordDictionaryPrefix, nil, func([]byte) (bool, error) {
+			dicts++
+			return true, nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, dicts, "dictionary is trained and saved")
+
+	var compressed int
+	err = db.iterate(ctx, []byte{scopeIDRecord}, func(_, v []byte) error {
+		if v[0] == record.FormatZstd {
+			compressed++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, len(records), compressed, "records are stored compressed")
+
+	checkRecords := func(db *DB) {
+		for id, rec := range records {
+			id := id
+			err := db.View(ctx, func(tx *TransactionManager) error {
+				got, err := tx.GetRecord(ctx, jetID, &id)
+				assert.Equal(t, rec, got)
+				return err
+			})
+			require.NoError(t, err)
+		}
+	}
+	checkRecords(db)
+	require.NoError(t, db.Close())
+
+	// compressed records are readable when compression is disabled
+	conf.Storage.Compression.Enabled = false
+	db = open()
+	defer db.Close()
+	checkRecords(db)
+}
@@ -19,8 +19,8 @@ package storage
 import (
 	"bytes"
 	"context"
-	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/configuration"
@@ -31,14 +31,15 @@ import (
 )
 
 const (
-	scopeIDLifeline byte = 1
-	scopeIDRecord   byte = 2
-	scopeIDJetDrop  byte = 3
-	scopeIDPulse    byte = 4
-	scopeIDSystem   byte = 5
-	scopeIDMessage  byte = 6
-	scopeIDBlob     byte = 7
-	scopeIDLocal    byte = 8
+	scopeIDLifeline  byte = 1
+	scopeIDRecord    byte = 2
+	scopeIDJetDrop   byte = 3
+	scopeIDPulse     byte = 4
+	scopeIDSystem    byte = 5
+	scopeIDMessage   byte = 6
+	scopeIDBlob      byte = 7
+	scopeIDLocal     byte = 8
+	scopeIDPrototype byte = 9
 
 	sysGenesis                byte = 1
 	sysLatestPulse            byte = 2
@@ -47,6 +48,13 @@ const (
 	sysJetTree                byte = 5
 	sysJetList                byte = 6
 	sysDropSizeHistory        byte = 7
+	sysFaultEvidence          byte = 8
+	sysAdminLog               byte = 9
+	sysCloudHash              byte = 10
+	sysWALSequence            byte = 11
+	sysHeavySyncCheckpoint    byte = 12
+	sysSyncClientCheckpoint   byte = 13
+	sysRecordDictionary       byte = 14
 )
 
 // DBContext provides base db methods
@@ -78,6 +86,7 @@ type DBContext interface {
 	StoreKeyValues(ctx context.Context, kvs []core.KV) error
 
 	GetBadgerDB() *badger.DB
+	GetKV() KV
 
 	Close() error
 
@@ -95,10 +104,17 @@ type DBContext interface {
 	) error
 }
 
-// DB represents BadgerDB storage implementation.
+// DB represents ledger storage implementation on top of KV store.
 type DB struct {
+	// pulseWritten and prevPulseWritten are sizes of data written during current and previous pulses. They are
+	// accessed atomically, so they are kept first for alignment.
+	pulseWritten     int64
+	prevPulseWritten int64
+
 	PlatformCryptographyScheme core.PlatformCryptographyScheme `inject:""`
 
+	kv KV
+	// db is set if storage backend is BadgerDB.
 	db *badger.DB
 
 	// dropLock protects dropWG from concurrent calls to Add and Wait
@@ -114,8 +130,25 @@ type DB struct {
 	idlocker             *IDLocker
 	jetHeavyClientLocker *IDLocker
 
+	// batcher merges concurrent commits into shared transactions, nil if batching is disabled.
+	batcher *writeBatcher
+	// wal is write-ahead log of commits, nil if log is disabled.
+	wal *wal
+	// walReplayed is count of commits replayed from write-ahead log on open.
+	walReplayed int
+
+	// compressor decodes compressed records, and compresses new ones if compressRecords is set.
+	compressor      *record.Compressor
+	compressRecords bool
+
+	// statsStop stops collection of storage stats, nil if collection is disabled.
+	statsStop chan struct{}
+
 	closeLock sync.RWMutex
 	isClosed  bool
+
+	// openDuration is time KV store took to open, for BadgerDB it includes replay of value log.
+	openDuration time.Duration
 }
 
 // SetTxRetiries sets number of retries on conflict in Update
@@ -133,33 +166,59 @@ func setOptions(o *badger.Options) *badger.Options {
 	return newo
 }
 
-// NewDB returns storage.DB with BadgerDB instance initialized by opts.
+// NewDB returns storage.DB with KV store of configured backend, BadgerDB instance is initialized by opts.
 // Creates database in provided dir or in current directory if dir parameter is empty.
 func NewDB(conf configuration.Ledger, opts *badger.Options) (DBContext, error) {
-	opts = setOptions(opts)
-	dir, err := filepath.Abs(conf.Storage.DataDirectory)
+	start := time.Now()
+	kv, err := NewKV(conf.Storage, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	opts.Dir = dir
-	opts.ValueDir = dir
-
-	bdb, err := badger.Open(*opts)
-	if err != nil {
-		return nil, errors.Wrap(err, "local database open failed")
-	}
-
 	db := &DB{
-		db:                   bdb,
+		kv:                   kv,
+		openDuration:         time.Since(start),
 		txretiries:           conf.Storage.TxRetriesOnConflict,
 		idlocker:             NewIDLocker(),
 		jetHeavyClientLocker: NewIDLocker(),
 	}
+	if bkv, ok := kv.(*badgerKV); ok {
+		db.db = bkv.db
+		// Writes are batched for BadgerDB only, BoltDB serializes writers anyway.
+		if conf.Storage.WriteBatch.Enabled && !conf.Storage.WAL.Enabled {
+			db.batcher = newWriteBatcher(bkv.db, conf.Storage.WriteBatch)
+		}
+	}
+	if conf.Storage.WAL.Enabled {
+		if err := db.openWAL(conf.Storage); err != nil {
+			kv.Close() // nolint: errcheck
+			return nil, err
+		}
+		db.openDuration = time.Since(start)
+	}
+	if err := db.openCompressor(conf.Storage.Compression); err != nil {
+		db.Close() // nolint: errcheck
+		return nil, err
+	}
+	if rebuild := conf.Storage.RebuildIndexes; rebuild.OnStart {
+		_, err := RebuildIndexes(context.Background(), db, RebuildOptions{
+			Workers:          rebuild.Workers,
+			Force:            rebuild.Force,
+			ProgressInterval: rebuild.ProgressInterval,
+		})
+		if err != nil {
+			db.Close() // nolint: errcheck
+			return nil, err
+		}
+	}
+	if conf.Storage.StatsInterval > 0 {
+		db.statsStop = make(chan struct{})
+		go db.collectStats(conf.Storage.StatsInterval)
+	}
 	return db, nil
 }
 
-// Close wraps BadgerDB Close method.
+// Close closes KV store.
 //
 // From https://godoc.org/github.com/dgraph-io/badger#DB.Close:
 // «It's crucial to call it to ensure all the pending updates make their way to disk.
@@ -172,7 +231,19 @@ func (db *DB) Close() error {
 	}
 	db.isClosed = true
 
-	return db.db.Close()
+	if db.statsStop != nil {
+		close(db.statsStop)
+	}
+	if db.batcher != nil {
+		db.batcher.close()
+	}
+	if db.wal != nil {
+		if err := db.wal.close(); err != nil {
+			db.kv.Close() // nolint: errcheck
+			return errors.Wrap(err, "failed to close WAL")
+		}
+	}
+	return db.kv.Close()
 }
 
 // Stop stops DB component.
@@ -204,6 +275,7 @@ func (db *DB) BeginTransaction(update bool) (*TransactionManager, error) {
 
 // View accepts transaction function. All calls to received transaction manager will be consistent.
 func (db *DB) View(ctx context.Context, fn func(*TransactionManager) error) error {
+	defer recordLatency(ctx, "read", time.Now())
 	tx, err := db.BeginTransaction(false)
 	if err != nil {
 		return err
@@ -215,6 +287,7 @@ func (db *DB) View(ctx context.Context, fn func(*TransactionManager) error) erro
 // Update accepts transaction function and commits changes. All calls to received transaction manager will be
 // consistent and written tp disk or an error will be returned.
 func (db *DB) Update(ctx context.Context, fn func(*TransactionManager) error) error {
+	defer recordLatency(ctx, "write", time.Now())
 	tries := db.txretiries
 	var tx *TransactionManager
 	var err error
@@ -231,7 +304,7 @@ func (db *DB) Update(ctx context.Context, fn func(*TransactionManager) error) er
 		if err == nil {
 			break
 		}
-		if err != badger.ErrConflict {
+		if err != ErrConflict {
 			break
 		}
 		if tries < 1 {
@@ -250,11 +323,26 @@ func (db *DB) Update(ctx context.Context, fn func(*TransactionManager) error) er
 	return err
 }
 
-// GetBadgerDB return badger.DB instance (for internal usage, like tests)
+// OpenDuration returns time KV store took to open, for BadgerDB it includes replay of value log.
+func (db *DB) OpenDuration() time.Duration {
+	return db.openDuration
+}
+
+// WALReplayed returns count of commits replayed from write-ahead log on open.
+func (db *DB) WALReplayed() int {
+	return db.walReplayed
+}
+
+// GetBadgerDB return badger.DB instance (for internal usage, like tests), nil if storage backend is not BadgerDB.
 func (db *DB) GetBadgerDB() *badger.DB {
 	return db.db
 }
 
+// GetKV returns KV store storage is built on.
+func (db *DB) GetKV() KV {
+	return db.kv
+}
+
 // SetLocalData saves provided data to storage.
 func (db *DB) SetLocalData(ctx context.Context, pulse core.PulseNumber, key []byte, data []byte) error {
 	return db.set(
@@ -297,8 +385,12 @@ func (db *DB) IterateRecordsOnPulse(
 
 	return db.iterate(ctx, prefix, func(k, v []byte) error {
 		id := core.NewRecordID(pulse, k)
-		rec := record.DeserializeRecord(v)
-		err := handler(*id, rec)
+		buf, err := db.decodeRecord(v)
+		if err != nil {
+			return err
+		}
+		rec := record.DeserializeRecord(buf)
+		err = handler(*id, rec)
 		if err != nil {
 			return err
 		}
@@ -310,7 +402,15 @@ func (db *DB) IterateRecordsOnPulse(
 func (db *DB) StoreKeyValues(ctx context.Context, kvs []core.KV) error {
 	return db.Update(ctx, func(tx *TransactionManager) error {
 		for _, rec := range kvs {
-			err := tx.set(ctx, rec.K, rec.V)
+			var err error
+			if len(rec.K) > 0 && rec.K[0] == scopeIDRecord {
+				err = tx.setRecordValue(ctx, rec.K, rec.V)
+				if err == nil {
+					err = tx.indexRecord(ctx, rec.K, rec.V)
+				}
+			} else {
+				err = tx.set(ctx, rec.K, rec.V)
+			}
 			if err != nil {
 				return err
 			}
@@ -357,21 +457,9 @@ func (db *DB) iterate(
 		return ErrClosed
 	}
 
-	return db.db.View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
-
-		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			key := it.Item().KeyCopy(nil)[len(prefix):]
-			value, err := it.Item().ValueCopy(nil)
-			if err != nil {
-				return err
-			}
-			err = handler(key, value)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+	return db.kv.View(func(txn KVTxn) error {
+		return txn.Iterate(prefix, nil, func(k, v []byte) (bool, error) {
+			return true, handler(k[len(prefix):], v)
+		})
 	})
 }
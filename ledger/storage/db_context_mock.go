@@ -37,6 +37,11 @@ type DBContextMock struct {
 	GetBadgerDBPreCounter uint64
 	GetBadgerDBMock       mDBContextMockGetBadgerDB
 
+	GetKVFunc       func() (r KV)
+	GetKVCounter    uint64
+	GetKVPreCounter uint64
+	GetKVMock       mDBContextMockGetKV
+
 	GetLocalDataFunc       func(p context.Context, p1 core.PulseNumber, p2 []byte) (r []byte, r1 error)
 	GetLocalDataCounter    uint64
 	GetLocalDataPreCounter uint64
@@ -114,6 +119,7 @@ func NewDBContextMock(t minimock.Tester) *DBContextMock {
 	m.BeginTransactionMock = mDBContextMockBeginTransaction{mock: m}
 	m.CloseMock = mDBContextMockClose{mock: m}
 	m.GetBadgerDBMock = mDBContextMockGetBadgerDB{mock: m}
+	m.GetKVMock = mDBContextMockGetKV{mock: m}
 	m.GetLocalDataMock = mDBContextMockGetLocalData{mock: m}
 	m.GetPlatformCryptographySchemeMock = mDBContextMockGetPlatformCryptographyScheme{mock: m}
 	m.IterateLocalDataMock = mDBContextMockIterateLocalData{mock: m}
@@ -549,6 +555,140 @@ func (m *DBContextMock) GetBadgerDBFinished() bool {
 	return true
 }
 
+type mDBContextMockGetKV struct {
+	mock              *DBContextMock
+	mainExpectation   *DBContextMockGetKVExpectation
+	expectationSeries []*DBContextMockGetKVExpectation
+}
+
+type DBContextMockGetKVExpectation struct {
+	result *DBContextMockGetKVResult
+}
+
+type DBContextMockGetKVResult struct {
+	r KV
+}
+
+//Expect specifies that invocation of DBContext.GetKV is expected from 1 to Infinity times
+func (m *mDBContextMockGetKV) Expect() *mDBContextMockGetKV {
+	m.mock.GetKVFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &DBContextMockGetKVExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of DBContext.GetKV
+func (m *mDBContextMockGetKV) Return(r KV) *DBContextMock {
+	m.mock.GetKVFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &DBContextMockGetKVExpectation{}
+	}
+	m.mainExpectation.result = &DBContextMockGetKVResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of DBContext.GetKV is expected once
+func (m *mDBContextMockGetKV) ExpectOnce() *DBContextMockGetKVExpectation {
+	m.mock.GetKVFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &DBContextMockGetKVExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *DBContextMockGetKVExpectation) Return(r KV) {
+	e.result = &DBContextMockGetKVResult{r}
+}
+
+//Set uses given function f as a mock of DBContext.GetKV method
+func (m *mDBContextMockGetKV) Set(f func() (r KV)) *DBContextMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetKVFunc = f
+	return m.mock
+}
+
+//GetKV implements github.com/insolar/insolar/ledger/storage.DBContext interface
+func (m *DBContextMock) GetKV() (r KV) {
+	counter := atomic.AddUint64(&m.GetKVPreCounter, 1)
+	defer atomic.AddUint64(&m.GetKVCounter, 1)
+
+	if len(m.GetKVMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetKVMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to DBContextMock.GetKV.")
+			return
+		}
+
+		result := m.GetKVMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the DBContextMock.GetKV")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetKVMock.mainExpectation != nil {
+
+		result := m.GetKVMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the DBContextMock.GetKV")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetKVFunc == nil {
+		m.t.Fatalf("Unexpected call to DBContextMock.GetKV.")
+		return
+	}
+
+	return m.GetKVFunc()
+}
+
+//GetKVMinimockCounter returns a count of DBContextMock.GetKVFunc invocations
+func (m *DBContextMock) GetKVMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetKVCounter)
+}
+
+//GetKVMinimockPreCounter returns the value of DBContextMock.GetKV invocations
+func (m *DBContextMock) GetKVMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetKVPreCounter)
+}
+
+//GetKVFinished returns true if mock invocations count is ok
+func (m *DBContextMock) GetKVFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetKVMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetKVCounter) == uint64(len(m.GetKVMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetKVMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetKVCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetKVFunc != nil {
+		return atomic.LoadUint64(&m.GetKVCounter) > 0
+	}
+
+	return true
+}
+
 type mDBContextMockGetLocalData struct {
 	mock              *DBContextMock
 	mainExpectation   *DBContextMockGetLocalDataExpectation
@@ -2427,6 +2567,10 @@ func (m *DBContextMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to DBContextMock.GetBadgerDB")
 	}
 
+	if !m.GetKVFinished() {
+		m.t.Fatal("Expected call to DBContextMock.GetKV")
+	}
+
 	if !m.GetLocalDataFinished() {
 		m.t.Fatal("Expected call to DBContextMock.GetLocalData")
 	}
@@ -2508,6 +2652,10 @@ func (m *DBContextMock) MinimockFinish() {
 		m.t.Fatal("Expected call to DBContextMock.GetBadgerDB")
 	}
 
+	if !m.GetKVFinished() {
+		m.t.Fatal("Expected call to DBContextMock.GetKV")
+	}
+
 	if !m.GetLocalDataFinished() {
 		m.t.Fatal("Expected call to DBContextMock.GetLocalData")
 	}
@@ -2577,6 +2725,7 @@ func (m *DBContextMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.BeginTransactionFinished()
 		ok = ok && m.CloseFinished()
 		ok = ok && m.GetBadgerDBFinished()
+		ok = ok && m.GetKVFinished()
 		ok = ok && m.GetLocalDataFinished()
 		ok = ok && m.GetPlatformCryptographySchemeFinished()
 		ok = ok && m.IterateLocalDataFinished()
@@ -2610,6 +2759,10 @@ func (m *DBContextMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to DBContextMock.GetBadgerDB")
 			}
 
+			if !m.GetKVFinished() {
+				m.t.Error("Expected call to DBContextMock.GetKV")
+			}
+
 			if !m.GetLocalDataFinished() {
 				m.t.Error("Expected call to DBContextMock.GetLocalData")
 			}
@@ -2686,6 +2839,10 @@ func (m *DBContextMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetKVFinished() {
+		return false
+	}
+
 	if !m.GetLocalDataFinished() {
 		return false
 	}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -68,66 +67,50 @@ func (ds *dropStorage) CreateDrop(ctx context.Context, jetID core.RecordID, puls
 	uint64,
 	error,
 ) {
-	var err error
 	ds.DB.waitingFlight()
 
-	hw := ds.PlatformCryptographyScheme.ReferenceHasher()
-	_, err = hw.Write(prevHash)
+	_, jetPrefix := jet.Jet(jetID)
+	hash, dropSize, err := dropHash(ctx, ds.DB, ds.PlatformCryptographyScheme.ReferenceHasher(), jetPrefix, pulse, prevHash)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	var messages [][]byte
-	_, jetPrefix := jet.Jet(jetID)
-	// messagesPrefix := prefixkey(scopeIDMessage, jetPrefix, pulse.Bytes())
-
-	// err = db.db.View(func(txn *badger.Txn) error {
-	// 	it := txn.NewIterator(badger.DefaultIteratorOptions)
-	// 	defer it.Close()
-	//
-	// 	for it.Seek(messagesPrefix); it.ValidForPrefix(messagesPrefix); it.Next() {
-	// 		val, err := it.Item().ValueCopy(nil)
-	// 		if err != nil {
-	// 			return err
-	// 		}
-	// 		messages = append(messages, val)
-	// 	}
-	// 	return nil
-	// })
-	// if err != nil {
-	// 	return nil, nil, 0, err
-	// }
+	drop := jet.JetDrop{
+		Pulse:    pulse,
+		PrevHash: prevHash,
+		Hash:     hash,
+	}
+	return &drop, nil, dropSize, nil
+}
+
+// dropHash calculates hash of drop records for jet prefix and pulse, returns hash and drop size.
+func dropHash(
+	ctx context.Context, db DBContext, hw core.Hasher, jetPrefix []byte, pulse core.PulseNumber, prevHash []byte,
+) ([]byte, uint64, error) {
+	_, err := hw.Write(prevHash)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	var dropSize uint64
 	recordPrefix := prefixkey(scopeIDRecord, jetPrefix, pulse.Bytes())
 
-	err = ds.DB.GetBadgerDB().View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
-
-		for it.Seek(recordPrefix); it.ValidForPrefix(recordPrefix); it.Next() {
-			val, err := it.Item().ValueCopy(nil)
-			if err != nil {
-				return err
-			}
-			_, err = hw.Write(val)
-			if err != nil {
-				return err
-			}
-			dropSize += uint64(len(val))
+	err = db.iterate(ctx, recordPrefix, func(_, val []byte) error {
+		val, err := decodeRecordValue(db, val)
+		if err != nil {
+			return err
+		}
+		_, err = hw.Write(val)
+		if err != nil {
+			return err
 		}
+		dropSize += uint64(len(val))
 		return nil
 	})
 	if err != nil {
-		return nil, nil, 0, err
-	}
-
-	drop := jet.JetDrop{
-		Pulse:    pulse,
-		PrevHash: prevHash,
-		Hash:     hw.Sum(nil),
+		return nil, 0, err
 	}
-	return &drop, messages, dropSize, nil
+	return hw.Sum(nil), dropSize, nil
 }
 
 // SetDrop saves provided JetDrop in db.
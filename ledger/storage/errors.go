@@ -29,7 +29,7 @@ var (
 	// ErrConflictRetriesOver is returned if Update transaction fails on all retry attempts.
 	ErrConflictRetriesOver = errors.New("transaction conflict retries limit exceeded")
 
-	// ErrConflict is the alias for badger.ErrConflict.
+	// ErrConflict is the alias for badger.ErrConflict, KV stores return it on transaction conflicts.
 	ErrConflict = badger.ErrConflict
 
 	// ErrOverride is returned if SetRecord tries to update existing record.
@@ -37,4 +37,7 @@ var (
 
 	// ErrClosed is returned when attempt to read or write to closed db.
 	ErrClosed = errors.New("db is closed")
+
+	// ErrNotEmpty is returned when snapshot is restored into storage with data.
+	ErrNotEmpty = errors.New("storage is not empty")
 )
@@ -0,0 +1,69 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+type faultEvidenceStorage struct {
+	DB DBContext `inject:""`
+}
+
+// NewFaultEvidenceStorage creates new storage of evidences of node misbehaviour.
+func NewFaultEvidenceStorage() core.FaultEvidenceStorage {
+	return new(faultEvidenceStorage)
+}
+
+func faultEvidencePrefix(node core.RecordRef) []byte {
+	return prefixkey(scopeIDSystem, []byte{sysFaultEvidence}, node[:])
+}
+
+// AddFaultEvidence saves evidence, evidence of the same node on the same pulse is overridden.
+func (fs *faultEvidenceStorage) AddFaultEvidence(ctx context.Context, evidence core.FaultEvidence) error {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(evidence)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode fault evidence")
+	}
+	k := bytes.Join([][]byte{faultEvidencePrefix(evidence.Node), evidence.Pulse.Bytes()}, nil)
+	return fs.DB.set(ctx, k, buf.Bytes())
+}
+
+// GetFaultEvidence returns evidences of node ordered by pulse.
+func (fs *faultEvidenceStorage) GetFaultEvidence(ctx context.Context, node core.RecordRef) ([]core.FaultEvidence, error) {
+	var result []core.FaultEvidence
+	err := fs.DB.iterate(ctx, faultEvidencePrefix(node), func(k, v []byte) error {
+		var evidence core.FaultEvidence
+		err := gob.NewDecoder(bytes.NewReader(v)).Decode(&evidence)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode fault evidence")
+		}
+		result = append(result, evidence)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
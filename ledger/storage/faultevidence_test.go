@@ -0,0 +1,63 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultEvidenceStorage(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	fs := storage.NewFaultEvidenceStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, fs)
+
+	node := testutils.RandomRef()
+	other := testutils.RandomRef()
+
+	got, err := fs.GetFaultEvidence(ctx, node)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	late := core.FaultEvidence{Node: node, Pulse: 100500, Reason: "invalid pulse proof", StateHash: []byte{1}, Signature: []byte{2}}
+	early := core.FaultEvidence{Node: node, Pulse: 100, Reason: "invalid pulse proof", StateHash: []byte{3}, Signature: []byte{4}}
+	require.NoError(t, fs.AddFaultEvidence(ctx, late))
+	require.NoError(t, fs.AddFaultEvidence(ctx, early))
+	require.NoError(t, fs.AddFaultEvidence(ctx, core.FaultEvidence{Node: other, Pulse: 100}))
+
+	got, err = fs.GetFaultEvidence(ctx, node)
+	require.NoError(t, err)
+	assert.Equal(t, []core.FaultEvidence{early, late}, got)
+
+	got, err = fs.GetFaultEvidence(ctx, other)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,463 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/pkg/errors"
+)
+
+// rebuildBatchSize is count of indexes written in single transaction on rebuild and count of scanned records
+// collected before they are merged into scan.
+const rebuildBatchSize = 1000
+
+// RebuildOptions configures rebuilding of object indexes.
+type RebuildOptions struct {
+	// Workers is count of jets scanned and written concurrently.
+	Workers int
+	// Force rewrites all indexes, otherwise only missing and corrupt indexes are written.
+	Force bool
+	// ProgressInterval is interval of progress logging, zero disables it.
+	ProgressInterval time.Duration
+}
+
+// RebuildStat is progress of object indexes rebuilding.
+type RebuildStat struct {
+	// Jets is count of scanned jets.
+	Jets int64
+	// Records is count of scanned records.
+	Records int64
+	// Objects is count of objects found in records.
+	Objects int64
+	// Incomplete is count of objects which activation is not in storage, their indexes are left as is.
+	Incomplete int64
+	// Written is count of written indexes.
+	Written int64
+}
+
+// rebuiltObject is lifeline of object collected from records, only ids and fields required by index are kept.
+type rebuiltObject struct {
+	// states maps state records of object to previous states.
+	states map[core.RecordID]*core.RecordID
+	// kinds maps state records of object to their states.
+	kinds map[core.RecordID]record.State
+	// children maps child records of object to previous children.
+	children map[core.RecordID]*core.RecordID
+	// activation is activation of object, nil if it is not found.
+	activation *rebuiltActivation
+	// genesis is set if object is genesis record.
+	genesis   bool
+	delegates map[core.RecordRef]core.RecordRef
+	latest    core.PulseNumber
+	// prefix is jet prefix of the latest record of object, index is stored with it.
+	prefix []byte
+}
+
+func (o *rebuiltObject) touch(id core.RecordID, prefix []byte) {
+	if pn := id.Pulse(); pn >= o.latest {
+		o.latest = pn
+		o.prefix = prefix
+	}
+}
+
+// rebuiltActivation is activation record of object, only fields required by index are kept.
+type rebuiltActivation struct {
+	parent     core.RecordRef
+	isDelegate bool
+	image      core.RecordRef
+	request    core.RecordRef
+}
+
+// rebuildScan holds objects collected by scan of jets.
+type rebuildScan struct {
+	sync.Mutex
+	objects map[core.RecordID]*rebuiltObject
+	// children are child records with jet prefixes they are stored with.
+	children []rebuiltChild
+}
+
+type rebuiltChild struct {
+	id core.RecordID
+	// object is id of child object.
+	object core.RecordID
+	prev   *core.RecordID
+	prefix []byte
+}
+
+// scannedRecord is state or child record read from storage and not merged into scan yet.
+type scannedRecord struct {
+	id  core.RecordID
+	rec record.Record
+}
+
+func (s *rebuildScan) object(id core.RecordID) *rebuiltObject {
+	obj, ok := s.objects[id]
+	if !ok {
+		obj = &rebuiltObject{
+			states:    map[core.RecordID]*core.RecordID{},
+			kinds:     map[core.RecordID]record.State{},
+			children:  map[core.RecordID]*core.RecordID{},
+			delegates: map[core.RecordRef]core.RecordRef{},
+		}
+		s.objects[id] = obj
+	}
+	return obj
+}
+
+// add merges batch of records scanned from jet into scan.
+func (s *rebuildScan) add(prefix []byte, batch []scannedRecord) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, scanned := range batch {
+		switch rec := scanned.rec.(type) {
+		case record.ObjectState:
+			obj := s.object(stateObject(scanned.id, rec))
+			obj.states[scanned.id] = rec.PrevStateID()
+			obj.kinds[scanned.id] = rec.State()
+			obj.touch(scanned.id, prefix)
+			switch state := rec.(type) {
+			case *record.ObjectActivateRecord:
+				obj.activation = &rebuiltActivation{
+					parent:     state.Parent,
+					isDelegate: state.IsDelegate,
+					image:      state.Image,
+					request:    state.Request,
+				}
+			case *record.GenesisRecord:
+				obj.genesis = true
+			}
+		case *record.ChildRecord:
+			s.children = append(s.children, rebuiltChild{
+				id:     scanned.id,
+				object: *rec.Ref.Record(),
+				prev:   rec.PrevChild,
+				prefix: prefix,
+			})
+		}
+	}
+}
+
+// RebuildIndexes restores object lifeline indexes from records of storage. Records are scanned and indexes are
+// written by workers partitioned by jet, an index is written to jet of the latest record of object. Indexes of
+// objects activated on other nodes are left as is.
+func RebuildIndexes(ctx context.Context, db DBContext, opts RebuildOptions) (*RebuildStat, error) {
+	logger := inslogger.FromContext(ctx)
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	stat := &RebuildStat{}
+	if opts.ProgressInterval > 0 {
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			ticker := time.NewTicker(opts.ProgressInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					logger.Infof("Rebuilding indexes: %d jets, %d records scanned, %d objects, %d indexes written",
+						atomic.LoadInt64(&stat.Jets), atomic.LoadInt64(&stat.Records),
+						atomic.LoadInt64(&stat.Objects), atomic.LoadInt64(&stat.Written))
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+
+	prefixes, err := recordJetPrefixes(db.GetKV())
+	if err != nil {
+		return stat, errors.Wrap(err, "[ RebuildIndexes ] can't list jets")
+	}
+	logger.Infof("Rebuilding indexes of %d jets with %d workers", len(prefixes), workers)
+
+	scan := &rebuildScan{
+		objects: map[core.RecordID]*rebuiltObject{},
+	}
+	err = inParallel(workers, prefixes, func(prefix []byte) error {
+		if err := scanJetRecords(db, prefix, scan, stat); err != nil {
+			return errors.Wrapf(err, "can't scan jet %v", bytes2hex(prefix))
+		}
+		atomic.AddInt64(&stat.Jets, 1)
+		return nil
+	})
+	if err != nil {
+		return stat, errors.Wrap(err, "[ RebuildIndexes ]")
+	}
+
+	byJet := resolveLifelines(scan, stat)
+	jets := make([][]byte, 0, len(byJet))
+	for prefix := range byJet {
+		jets = append(jets, []byte(prefix))
+	}
+	err = inParallel(workers, jets, func(prefix []byte) error {
+		written, err := writeLifelines(ctx, db, prefix, byJet[string(prefix)], opts.Force)
+		atomic.AddInt64(&stat.Written, written)
+		return errors.Wrapf(err, "can't write indexes of jet %v", bytes2hex(prefix))
+	})
+	if err != nil {
+		return stat, errors.Wrap(err, "[ RebuildIndexes ]")
+	}
+	logger.Infof("Indexes rebuilt: %d jets, %d records scanned, %d objects (%d incomplete), %d indexes written",
+		stat.Jets, stat.Records, stat.Objects, stat.Incomplete, stat.Written)
+	return stat, nil
+}
+
+// recordJetPrefixes returns jet prefixes of stored records, the next prefix is sought after each found one.
+func recordJetPrefixes(kv KV) ([][]byte, error) {
+	var prefixes [][]byte
+	start := []byte{scopeIDRecord}
+	for start != nil {
+		var prefix []byte
+		err := kv.View(func(txn KVTxn) error {
+			return txn.IterateKeys([]byte{scopeIDRecord}, start, func(k []byte) (bool, error) {
+				if len(k) >= core.RecordHashSize {
+					prefix = append([]byte(nil), k[1:core.RecordHashSize]...)
+				}
+				return false, nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if prefix == nil {
+			break
+		}
+		prefixes = append(prefixes, prefix)
+		start = nextPrefix(prefixkey(scopeIDRecord, prefix))
+	}
+	return prefixes, nil
+}
+
+// nextPrefix returns the least key greater than all keys with provided prefix, nil if there is no such key.
+func nextPrefix(prefix []byte) []byte {
+	next := append([]byte(nil), prefix...)
+	for i := len(next) - 1; i > 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
+
+// inParallel calls fn for items in workers goroutines, returns the first error.
+func inParallel(workers int, items [][]byte, fn func([]byte) error) error {
+	queue := make(chan []byte, len(items))
+	for _, item := range items {
+		queue <- item
+	}
+	close(queue)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if err := fn(item); err != nil {
+					errOnce.Do(func() { firstErr = err })
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// scanJetRecords reads state and child records of jet, they are merged into scan by batches, so memory used by
+// scan of a large jet is bounded.
+func scanJetRecords(db DBContext, prefix []byte, scan *rebuildScan, stat *RebuildStat) error {
+	jetprefix := prefixkey(scopeIDRecord, prefix)
+	batch := make([]scannedRecord, 0, rebuildBatchSize)
+
+	err := db.GetKV().View(func(txn KVTxn) error {
+		return txn.Iterate(jetprefix, jetprefix, func(k, v []byte) (bool, error) {
+			atomic.AddInt64(&stat.Records, 1)
+			buf, err := decodeRecordValue(db, v)
+			if err != nil {
+				return false, err
+			}
+			var id core.RecordID
+			copy(id[:], k[len(jetprefix):])
+			switch rec := record.DeserializeRecord(buf).(type) {
+			case record.ObjectState, *record.ChildRecord:
+				batch = append(batch, scannedRecord{id: id, rec: rec})
+			}
+			if len(batch) >= rebuildBatchSize {
+				scan.add(prefix, batch)
+				batch = batch[:0]
+			}
+			return true, nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	scan.add(prefix, batch)
+	return nil
+}
+
+// stateObject returns id of object of state record, genesis record is state of itself.
+func stateObject(id core.RecordID, state record.ObjectState) core.RecordID {
+	switch s := state.(type) {
+	case *record.ObjectActivateRecord:
+		return *s.Request.Record()
+	case *record.ObjectAmendRecord:
+		return *s.Request.Record()
+	case *record.DeactivationRecord:
+		return *s.Request.Record()
+	}
+	return id
+}
+
+// resolveLifelines builds lifelines of collected objects grouped by jet prefixes they are stored with.
+func resolveLifelines(scan *rebuildScan, stat *RebuildStat) map[string]map[core.RecordID]*index.ObjectLifeline {
+	for _, child := range scan.children {
+		childObj, ok := scan.objects[child.object]
+		if !ok || childObj.activation == nil {
+			continue
+		}
+		parent := scan.object(*childObj.activation.parent.Record())
+		parent.children[child.id] = child.prev
+		parent.touch(child.id, child.prefix)
+	}
+	for _, obj := range scan.objects {
+		if obj.activation == nil || !obj.activation.isDelegate {
+			continue
+		}
+		if parent, ok := scan.objects[*obj.activation.parent.Record()]; ok {
+			parent.delegates[obj.activation.image] = obj.activation.request
+		}
+	}
+
+	byJet := map[string]map[core.RecordID]*index.ObjectLifeline{}
+	for id, obj := range scan.objects {
+		stat.Objects++
+		if obj.activation == nil && !obj.genesis {
+			stat.Incomplete++
+			continue
+		}
+		latest := chainHead(obj.states)
+		idx := &index.ObjectLifeline{
+			LatestState:  latest,
+			ChildPointer: chainHead(obj.children),
+			Delegates:    obj.delegates,
+			State:        obj.kinds[*latest],
+			LatestUpdate: obj.latest,
+		}
+		if obj.genesis {
+			idx.LatestStateApproved = latest
+		} else {
+			idx.Parent = obj.activation.parent
+		}
+		jetIndexes, ok := byJet[string(obj.prefix)]
+		if !ok {
+			jetIndexes = map[core.RecordID]*index.ObjectLifeline{}
+			byJet[string(obj.prefix)] = jetIndexes
+		}
+		jetIndexes[id] = idx
+	}
+	return byJet
+}
+
+// chainHead returns the latest record of chain which is not previous record of any other, nil for empty chain.
+func chainHead(chain map[core.RecordID]*core.RecordID) *core.RecordID {
+	referenced := map[core.RecordID]struct{}{}
+	for _, prev := range chain {
+		if prev != nil {
+			referenced[*prev] = struct{}{}
+		}
+	}
+	var head *core.RecordID
+	for id := range chain {
+		if _, ok := referenced[id]; ok {
+			continue
+		}
+		id := id
+		if head == nil || bytes.Compare(id[:], head[:]) > 0 {
+			head = &id
+		}
+	}
+	return head
+}
+
+// writeLifelines stores lifelines of jet in batches, existing indexes are kept unless force is set.
+func writeLifelines(
+	ctx context.Context,
+	db DBContext,
+	prefix []byte,
+	lifelines map[core.RecordID]*index.ObjectLifeline,
+	force bool,
+) (int64, error) {
+	jetID := *jet.NewID(0, prefix)
+	ids := make([]core.RecordID, 0, len(lifelines))
+	for id := range lifelines {
+		ids = append(ids, id)
+	}
+
+	var written int64
+	for len(ids) > 0 {
+		n := rebuildBatchSize
+		if n > len(ids) {
+			n = len(ids)
+		}
+		batch := ids[:n]
+		ids = ids[n:]
+
+		var batchWritten int64
+		err := db.Update(ctx, func(tx *TransactionManager) error {
+			batchWritten = 0
+			for i := range batch {
+				id := batch[i]
+				if !force {
+					// index is rewritten if it's missing or can't be decoded
+					if _, err := tx.GetObjectIndex(ctx, jetID, &id, false); err == nil {
+						continue
+					}
+				}
+				if err := tx.SetObjectIndex(ctx, jetID, &id, lifelines[id]); err != nil {
+					return err
+				}
+				batchWritten++
+			}
+			return nil
+		})
+		if err != nil {
+			return written, err
+		}
+		written += batchWritten
+	}
+	return written, nil
+}
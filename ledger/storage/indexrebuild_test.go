@@ -0,0 +1,114 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildIndexes(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "rebuild-db-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := configuration.NewLedger()
+	conf.Storage.DataDirectory = dir
+	conf.Storage.StatsInterval = 0
+	db, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	db.(*DB).PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	objects := &objectStorage{DB: db, PlatformCryptographyScheme: db.(*DB).PlatformCryptographyScheme}
+
+	parentJet := *jet.NewID(1, []byte{0x00})
+	childJet := *jet.NewID(1, []byte{0x80})
+	pn := core.PulseNumber(core.FirstPulseNumber + 1)
+	set := func(jetID core.RecordID, pn core.PulseNumber, rec record.Record) *core.RecordID {
+		id, err := objects.SetRecord(ctx, jetID, pn, rec)
+		require.NoError(t, err)
+		return id
+	}
+
+	domain := testutils.RandomID()
+	parent := *core.NewRecordRef(domain, testutils.RandomID())
+	child := *core.NewRecordRef(domain, testutils.RandomID())
+	image := testutils.RandomRef()
+	activated := set(parentJet, pn, &record.ObjectActivateRecord{
+		SideEffectRecord: record.SideEffectRecord{Request: parent},
+	})
+	amended := set(parentJet, pn+1, &record.ObjectAmendRecord{
+		SideEffectRecord: record.SideEffectRecord{Request: parent},
+		PrevState:        *activated,
+	})
+	childActivated := set(childJet, pn+1, &record.ObjectActivateRecord{
+		SideEffectRecord:  record.SideEffectRecord{Request: child},
+		ObjectStateRecord: record.ObjectStateRecord{Image: image},
+		Parent:            parent,
+		IsDelegate:        true,
+	})
+	childRecord := set(parentJet, pn+2, &record.ChildRecord{Ref: child})
+	// amend of object activated on other node is skipped
+	set(parentJet, pn+2, &record.ObjectAmendRecord{
+		SideEffectRecord: record.SideEffectRecord{Request: testutils.RandomRef()},
+		PrevState:        testutils.RandomID(),
+	})
+
+	// existing index is kept unless rebuild is forced
+	stale := &index.ObjectLifeline{LatestState: activated}
+	require.NoError(t, objects.SetObjectIndex(ctx, parentJet, parent.Record(), stale))
+
+	stat, err := RebuildIndexes(ctx, db, RebuildOptions{Workers: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stat.Jets)
+	assert.Equal(t, int64(5), stat.Records)
+	assert.Equal(t, int64(3), stat.Objects)
+	assert.Equal(t, int64(1), stat.Incomplete)
+	assert.Equal(t, int64(1), stat.Written)
+
+	idx, err := objects.GetObjectIndex(ctx, parentJet, parent.Record(), false)
+	require.NoError(t, err)
+	assert.Equal(t, activated, idx.LatestState)
+	idx, err = objects.GetObjectIndex(ctx, childJet, child.Record(), false)
+	require.NoError(t, err)
+	assert.Equal(t, childActivated, idx.LatestState)
+	assert.Equal(t, record.StateActivation, idx.State)
+	assert.Equal(t, parent, idx.Parent)
+
+	_, err = RebuildIndexes(ctx, db, RebuildOptions{Workers: 2, Force: true})
+	require.NoError(t, err)
+	idx, err = objects.GetObjectIndex(ctx, parentJet, parent.Record(), false)
+	require.NoError(t, err)
+	assert.Equal(t, amended, idx.LatestState)
+	assert.Equal(t, record.StateAmend, idx.State)
+	assert.Equal(t, childRecord, idx.ChildPointer)
+	assert.Equal(t, map[core.RecordRef]core.RecordRef{image: child}, idx.Delegates)
+	assert.Equal(t, pn+2, idx.LatestUpdate)
+}
@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jet
+
+import (
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// MaxDepth is max depth of jet, it is count of bits in prefix of jet id.
+const MaxDepth = (core.RecordHashSize - 1) * 8
+
+// CheckTree checks invariants of jet tree. Every record must map to exactly one jet, it holds if every jet is
+// either a leaf or split to both halves. Jets must not be deeper than maxDepth.
+func CheckTree(t *Tree, maxDepth uint8) error {
+	if t == nil || t.Head == nil {
+		return errors.New("jet tree has no root")
+	}
+	return checkJet(t.Head, "", maxDepth)
+}
+
+func checkJet(j *jet, path string, maxDepth uint8) error {
+	if j.Left == nil && j.Right == nil {
+		return nil
+	}
+	if j.Left == nil || j.Right == nil {
+		return errors.Errorf("jet %s is split to one half only, records of other half have no jet", jetPath(path))
+	}
+	if len(path) >= int(maxDepth) {
+		return errors.Errorf("jet %s is split deeper than %d", jetPath(path), maxDepth)
+	}
+	if err := checkJet(j.Left, path+"0", maxDepth); err != nil {
+		return err
+	}
+	return checkJet(j.Right, path+"1", maxDepth)
+}
+
+// CheckRefinement checks that tree of the next pulse is made of jets of previous tree or their splits. Jets are
+// never merged back, so record is in the same jet or in a jet split from it in the next pulse.
+func CheckRefinement(prev, next *Tree) error {
+	if prev == nil || prev.Head == nil || next == nil || next.Head == nil {
+		return errors.New("jet tree has no root")
+	}
+	return checkRefinement(prev.Head, next.Head, "")
+}
+
+func checkRefinement(prev, next *jet, path string) error {
+	if prev.Left == nil && prev.Right == nil {
+		return nil
+	}
+	if next.Left == nil || next.Right == nil {
+		return errors.Errorf("jet %s is merged in the next tree", jetPath(path))
+	}
+	if prev.Left != nil {
+		if err := checkRefinement(prev.Left, next.Left, path+"0"); err != nil {
+			return err
+		}
+	}
+	if prev.Right != nil {
+		return checkRefinement(prev.Right, next.Right, path+"1")
+	}
+	return nil
+}
+
+func jetPath(path string) string {
+	return "root" + path
+}
@@ -0,0 +1,123 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jet
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTree(t *testing.T) {
+	require.Error(t, CheckTree(&Tree{}, MaxDepth))
+	require.NoError(t, CheckTree(NewTree(true), MaxDepth))
+
+	tree := NewTree(true)
+	tree.Update(*NewID(3, []byte{0xA0}), true)
+	require.NoError(t, CheckTree(tree, MaxDepth))
+	require.NoError(t, CheckTree(tree, 3))
+	require.Error(t, CheckTree(tree, 2))
+
+	tree.Head.Right.Left = nil
+	require.Error(t, CheckTree(tree, MaxDepth))
+}
+
+func TestCheckRefinement(t *testing.T) {
+	prev := NewTree(true)
+	prev.Update(*NewID(2, []byte{0x40}), true)
+	next := NewTree(true)
+	next.Update(*NewID(3, []byte{0x60}), true)
+	require.NoError(t, CheckRefinement(prev, next))
+	require.NoError(t, CheckRefinement(prev, prev))
+	require.Error(t, CheckRefinement(next, NewTree(true)))
+}
+
+// contains checks if record with hash belongs to jet.
+func contains(jetID core.RecordID, hash []byte) bool {
+	depth, prefix := Jet(jetID)
+	for i := uint8(0); i < depth; i++ {
+		if getBit(prefix, i) != getBit(hash, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkCoverage checks that every record maps to exactly one leaf jet and it is the jet tree finds.
+func checkCoverage(t *testing.T, r *rand.Rand, tree *Tree) {
+	leaves := tree.LeafIDs()
+	for i := 0; i < 50; i++ {
+		hash := make([]byte, core.RecordHashSize)
+		r.Read(hash)
+		id := core.NewRecordID(core.FirstPulseNumber, hash)
+
+		var owners []core.RecordID
+		for _, leaf := range leaves {
+			if contains(leaf, hash) {
+				owners = append(owners, leaf)
+			}
+		}
+		require.Len(t, owners, 1, "record %x should belong to exactly one jet\n%s", hash, tree)
+		found, _ := tree.Find(*id)
+		require.Equal(t, owners[0], *found)
+	}
+}
+
+func cloneJet(j *jet) *jet {
+	if j == nil {
+		return nil
+	}
+	return &jet{Left: cloneJet(j.Left), Right: cloneJet(j.Right), Actual: j.Actual}
+}
+
+func TestTree_Properties(t *testing.T) {
+	const maxDepth = 6
+	for seed := int64(0); seed < 50; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		tree := NewTree(true)
+		for step := 0; step < 30; step++ {
+			prev := &Tree{Head: cloneJet(tree.Head)}
+			leaves := tree.LeafIDs()
+			leaf := leaves[r.Intn(len(leaves))]
+
+			switch depth, _ := Jet(leaf); {
+			case r.Intn(3) == 0:
+				// tree from other node that knows about split of random jet
+				other := NewTree(false)
+				prefix := make([]byte, core.RecordHashSize-1)
+				r.Read(prefix)
+				otherDepth := uint8(1 + r.Intn(maxDepth))
+				other.Update(*NewID(otherDepth, ResetBits(prefix, otherDepth)), true)
+				tree = tree.Merge(other)
+			case depth < maxDepth:
+				left, right, err := tree.Split(leaf)
+				require.NoError(t, err)
+				assert.Equal(t, leaf, Parent(*left))
+				assert.Equal(t, leaf, Parent(*right))
+				tree.Update(*left, true)
+				tree.Update(*right, true)
+			}
+
+			require.NoError(t, CheckTree(tree, maxDepth), "seed %d step %d\n%s", seed, step, tree)
+			require.NoError(t, CheckRefinement(prev, tree), "seed %d step %d\n%s", seed, step, tree)
+			checkCoverage(t, r, tree)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/pkg/errors"
 	"github.com/ugorji/go/codec"
 )
 
@@ -44,12 +45,35 @@ type jetStorage struct {
 
 	jetTreeLock sync.RWMutex
 	addJetLock  sync.RWMutex
+
+	// checkInvariants enables check of jet trees before they are saved
+	checkInvariants bool
 }
 
 func NewJetStorage() JetStorage {
 	return new(jetStorage)
 }
 
+// NewCheckedJetStorage creates jet storage that checks invariants of jet trees on every change and returns error
+// instead of saving broken tree. Check walks the whole tree, so it is meant for tests and debugging.
+func NewCheckedJetStorage() JetStorage {
+	return &jetStorage{checkInvariants: true}
+}
+
+// check checks invariants of tree if it is enabled. Split tree must cover the same records as tree before split.
+func (js *jetStorage) check(before, tree *jet.Tree) error {
+	if !js.checkInvariants {
+		return nil
+	}
+	if err := jet.CheckTree(tree, jet.MaxDepth); err != nil {
+		return errors.Wrap(err, "jet tree invariant is violated")
+	}
+	if before == nil {
+		return nil
+	}
+	return errors.Wrap(jet.CheckRefinement(before, tree), "jet tree invariant is violated")
+}
+
 // UpdateJetTree updates jet tree for specified pulse.
 func (js *jetStorage) UpdateJetTree(ctx context.Context, pulse core.PulseNumber, setActual bool, ids ...core.RecordID) error {
 	js.jetTreeLock.Lock()
@@ -63,6 +87,9 @@ func (js *jetStorage) UpdateJetTree(ctx context.Context, pulse core.PulseNumber,
 	for _, id := range ids {
 		tree.Update(id, setActual)
 	}
+	if err := js.check(nil, tree); err != nil {
+		return err
+	}
 
 	return js.DB.set(ctx, k, tree.Bytes())
 }
@@ -110,10 +137,20 @@ func (js *jetStorage) SplitJetTree(
 		return nil, nil, err
 	}
 
+	var before *jet.Tree
+	if js.checkInvariants {
+		before, err = js.getJetTree(ctx, pulse)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	left, right, err := tree.Split(jetID)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := js.check(before, tree); err != nil {
+		return nil, nil, err
+	}
 	err = js.DB.set(ctx, k, tree.Bytes())
 	if err != nil {
 		return nil, nil, err
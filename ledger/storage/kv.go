@@ -0,0 +1,123 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BackendBadger is BadgerDB storage backend, LSM tree with value log. It is tuned for write throughput.
+	BackendBadger = "badger"
+	// BackendBolt is BoltDB storage backend, single file B+tree with serialized writers. It is slower on writes
+	// but every committed transaction is synced to disk.
+	BackendBolt = "bolt"
+)
+
+// KV is a low-level key-value store ledger storage is built on.
+type KV interface {
+	// View runs fn in read-only transaction.
+	View(fn func(KVTxn) error) error
+	// Update runs fn in read-write transaction and commits it if fn succeeds. ErrConflict is returned if
+	// transaction conflicts with concurrent one.
+	Update(fn func(KVTxn) error) error
+	// Close flushes pending writes and closes store.
+	Close() error
+}
+
+// KVTxn is a transaction of KV store. Returned keys and values are copies and can be retained.
+type KVTxn interface {
+	// Get returns value by key, ErrNotFound if key is missing.
+	Get(key []byte) ([]byte, error)
+	// Set stores value by key.
+	Set(key, value []byte) error
+	// Delete removes key.
+	Delete(key []byte) error
+	// Iterate calls handler for keys with prefix in ascending order starting from the first key not less than
+	// start, or from prefix if start is nil. Iteration stops when handler returns false or error. Keys must not
+	// be modified until iteration is over.
+	Iterate(prefix, start []byte, handler func(k, v []byte) (bool, error)) error
+	// IterateKeys is Iterate that does not read values.
+	IterateKeys(prefix, start []byte, handler func(k []byte) (bool, error)) error
+}
+
+// NewKV opens KV store of configured backend in data directory. Badger options are used by badger backend only.
+func NewKV(conf configuration.Storage, opts *badger.Options) (KV, error) {
+	dir, err := filepath.Abs(conf.DataDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	switch conf.Backend {
+	case "", BackendBadger:
+		return openBadgerKV(dir, opts)
+	case BackendBolt:
+		return openBoltKV(dir)
+	}
+	return nil, errors.Errorf("unknown storage backend %s", conf.Backend)
+}
+
+// MigrateKV copies all keys from one store to another in transactions of up to batchSize bytes.
+// Returns count of copied keys.
+func MigrateKV(ctx context.Context, from, to KV, batchSize int) (int, error) {
+	var (
+		count int
+		size  int
+		batch []keyval
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := to.Update(func(txn KVTxn) error {
+			for _, kv := range batch {
+				if err := txn.Set(kv.k, kv.v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		count += len(batch)
+		inslogger.FromContext(ctx).Debugf("migrated %d keys", count)
+		batch, size = batch[:0], 0
+		return nil
+	}
+
+	err := from.View(func(txn KVTxn) error {
+		return txn.Iterate(nil, nil, func(k, v []byte) (bool, error) {
+			batch = append(batch, keyval{k: k, v: v})
+			size += len(k) + len(v)
+			if size < batchSize {
+				return true, nil
+			}
+			return true, flush()
+		})
+	})
+	if err != nil {
+		return count, errors.Wrap(err, "failed to migrate storage")
+	}
+	return count, errors.Wrap(flush(), "failed to migrate storage")
+}
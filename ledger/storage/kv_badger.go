@@ -0,0 +1,114 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+)
+
+// badgerKV is KV implementation on top of BadgerDB.
+type badgerKV struct {
+	db *badger.DB
+}
+
+func openBadgerKV(dir string, opts *badger.Options) (*badgerKV, error) {
+	opts = setOptions(opts)
+	opts.Dir = dir
+	opts.ValueDir = dir
+
+	db, err := badger.Open(*opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "local database open failed")
+	}
+	return &badgerKV{db: db}, nil
+}
+
+func (kv *badgerKV) View(fn func(KVTxn) error) error {
+	return kv.db.View(func(txn *badger.Txn) error {
+		return fn(badgerTxn{txn: txn})
+	})
+}
+
+func (kv *badgerKV) Update(fn func(KVTxn) error) error {
+	return kv.db.Update(func(txn *badger.Txn) error {
+		return fn(badgerTxn{txn: txn})
+	})
+}
+
+func (kv *badgerKV) Close() error {
+	return kv.db.Close()
+}
+
+type badgerTxn struct {
+	txn *badger.Txn
+}
+
+func (t badgerTxn) Get(key []byte) ([]byte, error) {
+	item, err := t.txn.Get(key)
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (t badgerTxn) Set(key, value []byte) error {
+	return t.txn.Set(key, value)
+}
+
+func (t badgerTxn) Delete(key []byte) error {
+	return t.txn.Delete(key)
+}
+
+func (t badgerTxn) Iterate(prefix, start []byte, handler func(k, v []byte) (bool, error)) error {
+	return t.iterate(badger.DefaultIteratorOptions, prefix, start, func(item *badger.Item) (bool, error) {
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return false, err
+		}
+		return handler(item.KeyCopy(nil), value)
+	})
+}
+
+func (t badgerTxn) IterateKeys(prefix, start []byte, handler func(k []byte) (bool, error)) error {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	return t.iterate(opts, prefix, start, func(item *badger.Item) (bool, error) {
+		return handler(item.KeyCopy(nil))
+	})
+}
+
+func (t badgerTxn) iterate(
+	opts badger.IteratorOptions, prefix, start []byte, handler func(item *badger.Item) (bool, error),
+) error {
+	if start == nil {
+		start = prefix
+	}
+	it := t.txn.NewIterator(opts)
+	defer it.Close()
+
+	for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+		next, err := handler(it.Item())
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// boltFile is name of BoltDB file in data directory.
+	boltFile = "ledger.bolt"
+	// boltOpenTimeout is time to wait for lock of BoltDB file held by other process.
+	boltOpenTimeout = 5 * time.Second
+)
+
+// boltBucket is the only bucket of BoltDB store, keys are already prefixed by scope.
+var boltBucket = []byte("ledger")
+
+// boltKV is KV implementation on top of BoltDB.
+type boltKV struct {
+	db *bolt.DB
+}
+
+func openBoltKV(dir string) (*boltKV, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create data directory")
+	}
+	db, err := bolt.Open(filepath.Join(dir, boltFile), 0600, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "local database open failed")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close() // nolint: errcheck
+		return nil, errors.Wrap(err, "failed to create bucket")
+	}
+	return &boltKV{db: db}, nil
+}
+
+func (kv *boltKV) View(fn func(KVTxn) error) error {
+	return kv.db.View(func(tx *bolt.Tx) error {
+		return fn(boltTxn{bucket: tx.Bucket(boltBucket)})
+	})
+}
+
+func (kv *boltKV) Update(fn func(KVTxn) error) error {
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		return fn(boltTxn{bucket: tx.Bucket(boltBucket)})
+	})
+}
+
+func (kv *boltKV) Close() error {
+	return kv.db.Close()
+}
+
+type boltTxn struct {
+	bucket *bolt.Bucket
+}
+
+func (t boltTxn) Get(key []byte) ([]byte, error) {
+	value := t.bucket.Get(key)
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	return append([]byte{}, value...), nil
+}
+
+func (t boltTxn) Set(key, value []byte) error {
+	return t.bucket.Put(key, value)
+}
+
+func (t boltTxn) Delete(key []byte) error {
+	return t.bucket.Delete(key)
+}
+
+func (t boltTxn) Iterate(prefix, start []byte, handler func(k, v []byte) (bool, error)) error {
+	if start == nil {
+		start = prefix
+	}
+	c := t.bucket.Cursor()
+	for k, v := c.Seek(start); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		next, err := handler(append([]byte{}, k...), append([]byte{}, v...))
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t boltTxn) IterateKeys(prefix, start []byte, handler func(k []byte) (bool, error)) error {
+	return t.Iterate(prefix, start, func(k, _ []byte) (bool, error) {
+		return handler(k)
+	})
+}
@@ -0,0 +1,159 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tmpKV(t *testing.T, backend string) (KV, func()) {
+	dir, err := ioutil.TempDir("", "kv-test-")
+	require.NoError(t, err)
+	kv, err := NewKV(configuration.Storage{Backend: backend, DataDirectory: dir}, nil)
+	require.NoError(t, err)
+	return kv, func() {
+		kv.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func setKeys(t *testing.T, kv KV, kvs ...[]byte) {
+	err := kv.Update(func(txn KVTxn) error {
+		for i := 0; i < len(kvs); i += 2 {
+			if err := txn.Set(kvs[i], kvs[i+1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestKV(t *testing.T) {
+	for _, backend := range []string{BackendBadger, BackendBolt} {
+		t.Run(backend, func(t *testing.T) {
+			kv, cleaner := tmpKV(t, backend)
+			defer cleaner()
+
+			setKeys(t, kv,
+				[]byte{1, 1}, []byte("a"),
+				[]byte{1, 2}, []byte("b"),
+				[]byte{1, 3}, []byte("c"),
+				[]byte{2, 1}, []byte("d"),
+			)
+
+			err := kv.View(func(txn KVTxn) error {
+				value, err := txn.Get([]byte{1, 2})
+				require.NoError(t, err)
+				assert.Equal(t, []byte("b"), value)
+				_, err = txn.Get([]byte{3})
+				assert.Equal(t, ErrNotFound, err)
+
+				var keys [][]byte
+				err = txn.Iterate([]byte{1}, []byte{1, 2}, func(k, v []byte) (bool, error) {
+					keys = append(keys, k)
+					return true, nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, [][]byte{{1, 2}, {1, 3}}, keys)
+
+				keys = nil
+				err = txn.IterateKeys([]byte{1}, nil, func(k []byte) (bool, error) {
+					keys = append(keys, k)
+					return len(keys) < 2, nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, [][]byte{{1, 1}, {1, 2}}, keys)
+				return nil
+			})
+			require.NoError(t, err)
+
+			err = kv.Update(func(txn KVTxn) error {
+				return txn.Delete([]byte{1, 2})
+			})
+			require.NoError(t, err)
+			err = kv.View(func(txn KVTxn) error {
+				_, err := txn.Get([]byte{1, 2})
+				return err
+			})
+			assert.Equal(t, ErrNotFound, err)
+		})
+	}
+}
+
+func TestMigrateKV(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	from, cleanFrom := tmpKV(t, BackendBadger)
+	defer cleanFrom()
+	to, cleanTo := tmpKV(t, BackendBolt)
+	defer cleanTo()
+
+	var kvs [][]byte
+	for i := 0; i < 100; i++ {
+		kvs = append(kvs, []byte{scopeIDRecord, byte(i)}, []byte{byte(i), byte(i)})
+	}
+	setKeys(t, from, kvs...)
+
+	count, err := MigrateKV(ctx, from, to, 64)
+	require.NoError(t, err)
+	assert.Equal(t, 100, count)
+
+	var migrated [][]byte
+	err = to.View(func(txn KVTxn) error {
+		return txn.Iterate(nil, nil, func(k, v []byte) (bool, error) {
+			migrated = append(migrated, k, v)
+			return true, nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, kvs, migrated)
+}
+
+func TestDB_BoltBackend(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "bolt-db-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := configuration.NewLedger()
+	conf.Storage.Backend = BackendBolt
+	conf.Storage.DataDirectory = dir
+	db, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	assert.Nil(t, db.GetBadgerDB())
+
+	require.NoError(t, db.SetLocalData(ctx, 1, []byte{1}, []byte{2}))
+	value, err := db.GetLocalData(ctx, 1, []byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+
+	err = db.IterateLocalData(ctx, 1, nil, func(k, v []byte) error {
+		assert.Equal(t, []byte{1}, k)
+		assert.Equal(t, []byte{2}, v)
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -25,19 +25,35 @@ import (
 )
 
 var (
-	tagJet     = insmetrics.MustTagKey("jet")
-	recordType = insmetrics.MustTagKey("rectype")
+	tagJet       = insmetrics.MustTagKey("jet")
+	recordType   = insmetrics.MustTagKey("rectype")
+	tagScope     = insmetrics.MustTagKey("scope")
+	tagOperation = insmetrics.MustTagKey("operation")
 )
 
 var (
 	statRecentStorageObjectsAdded   = stats.Int64("storage/recent/objects/added/count", "recent storage objects added", stats.UnitDimensionless)
 	statRecentStorageObjectsRemoved = stats.Int64("storage/recent/objects/removed/count", "recent storage objects removed", stats.UnitDimensionless)
+	statRecentStorageObjectsEvicted = stats.Int64("storage/recent/objects/evicted/count", "recent storage objects evicted by size limit", stats.UnitDimensionless)
+	statRecentStorageObjectsExpired = stats.Int64("storage/recent/objects/expired/count", "recent storage objects removed by ttl", stats.UnitDimensionless)
+	statRecentStorageObjectsHit     = stats.Int64("storage/recent/objects/hit/count", "recent storage objects lookup hits", stats.UnitDimensionless)
+	statRecentStorageObjectsMiss    = stats.Int64("storage/recent/objects/miss/count", "recent storage objects lookup misses", stats.UnitDimensionless)
 
 	statRecentStoragePendingsAdded   = stats.Int64("storage/recent/pending/added/count", "recent storage pending requests added", stats.UnitDimensionless)
 	statRecentStoragePendingsRemoved = stats.Int64("storage/recent/pending/removed/count", "recent storage pending requests removed", stats.UnitDimensionless)
 
 	statCleanScanned = stats.Int64("lightcleanup/scanned", "How many records have been scanned on LM cleanup", stats.UnitDimensionless)
 	statCleanRemoved = stats.Int64("lightcleanup/removed", "How many records have been removed on LM cleanup", stats.UnitDimensionless)
+
+	statBatchRequests = stats.Int64("storage/batch/requests", "How many commits have been merged into a write batch", stats.UnitDimensionless)
+	statBatchSize     = stats.Int64("storage/batch/size", "Write batch size in bytes", stats.UnitBytes)
+	statBatchLatency  = stats.Float64("storage/batch/latency", "Write batch commit latency in milliseconds", stats.UnitMilliseconds)
+
+	statStorageKeys              = stats.Int64("storage/scope/keys", "How many keys are in storage scope", stats.UnitDimensionless)
+	statStorageSize              = stats.Int64("storage/scope/size", "Size of keys and values in storage scope", stats.UnitBytes)
+	statStorageBytesWritten      = stats.Int64("storage/written", "Size of keys and values written to storage", stats.UnitBytes)
+	statStoragePulseBytesWritten = stats.Int64("storage/pulse/written", "Size of keys and values written to storage during pulse", stats.UnitBytes)
+	statStorageLatency           = stats.Float64("storage/latency", "Storage transaction latency in milliseconds", stats.UnitMilliseconds)
 )
 
 func init() {
@@ -57,6 +73,34 @@ func init() {
 			Aggregation: view.Sum(),
 			TagKeys:     commontags,
 		},
+		&view.View{
+			Name:        statRecentStorageObjectsEvicted.Name(),
+			Description: statRecentStorageObjectsEvicted.Description(),
+			Measure:     statRecentStorageObjectsEvicted,
+			Aggregation: view.Sum(),
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        statRecentStorageObjectsExpired.Name(),
+			Description: statRecentStorageObjectsExpired.Description(),
+			Measure:     statRecentStorageObjectsExpired,
+			Aggregation: view.Sum(),
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        statRecentStorageObjectsHit.Name(),
+			Description: statRecentStorageObjectsHit.Description(),
+			Measure:     statRecentStorageObjectsHit,
+			Aggregation: view.Sum(),
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        statRecentStorageObjectsMiss.Name(),
+			Description: statRecentStorageObjectsMiss.Description(),
+			Measure:     statRecentStorageObjectsMiss,
+			Aggregation: view.Sum(),
+			TagKeys:     commontags,
+		},
 		&view.View{
 			Name:        statRecentStoragePendingsAdded.Name(),
 			Description: statRecentStoragePendingsAdded.Description(),
@@ -86,6 +130,59 @@ func init() {
 			Aggregation: view.Sum(),
 			TagKeys:     []tag.Key{recordType},
 		},
+
+		&view.View{
+			Name:        statBatchRequests.Name(),
+			Description: statBatchRequests.Description(),
+			Measure:     statBatchRequests,
+			Aggregation: view.Distribution(1, 2, 5, 10, 25, 50, 100, 250, 500, 1000),
+		},
+		&view.View{
+			Name:        statBatchSize.Name(),
+			Description: statBatchSize.Description(),
+			Measure:     statBatchSize,
+			Aggregation: view.Distribution(1<<10, 16<<10, 64<<10, 256<<10, 1<<20, 4<<20),
+		},
+		&view.View{
+			Name:        statBatchLatency.Name(),
+			Description: statBatchLatency.Description(),
+			Measure:     statBatchLatency,
+			Aggregation: view.Distribution(0.5, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000),
+		},
+
+		&view.View{
+			Name:        statStorageKeys.Name(),
+			Description: statStorageKeys.Description(),
+			Measure:     statStorageKeys,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{tagScope},
+		},
+		&view.View{
+			Name:        statStorageSize.Name(),
+			Description: statStorageSize.Description(),
+			Measure:     statStorageSize,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{tagScope},
+		},
+		&view.View{
+			Name:        statStorageBytesWritten.Name(),
+			Description: statStorageBytesWritten.Description(),
+			Measure:     statStorageBytesWritten,
+			Aggregation: view.Sum(),
+		},
+		&view.View{
+			Name:        statStoragePulseBytesWritten.Name(),
+			Description: statStoragePulseBytesWritten.Description(),
+			Measure:     statStoragePulseBytesWritten,
+			Aggregation: view.Distribution(64<<10, 256<<10, 1<<20, 4<<20, 16<<20, 64<<20, 256<<20),
+		},
+		&view.View{
+			Name:        statStorageLatency.Name(),
+			Description: statStorageLatency.Description(),
+			Measure:     statStorageLatency,
+			Aggregation: view.Distribution(0.1, 0.5, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000),
+			TagKeys:     []tag.Key{tagOperation},
+		},
 	)
 	if err != nil {
 		panic(err)
@@ -39,6 +39,10 @@ func (Node) PhysicalAddress() string {
 	panic("implement me")
 }
 
+func (Node) PhysicalAddresses() []string {
+	panic("implement me")
+}
+
 func (Node) PublicKey() crypto.PublicKey {
 	panic("implement me")
 }
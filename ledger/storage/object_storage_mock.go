@@ -32,6 +32,11 @@ type ObjectStorageMock struct {
 	GetObjectIndexPreCounter uint64
 	GetObjectIndexMock       mObjectStorageMockGetObjectIndex
 
+	GetObjectsByPrototypeFunc       func(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) (r []core.RecordRef, r1 error)
+	GetObjectsByPrototypeCounter    uint64
+	GetObjectsByPrototypePreCounter uint64
+	GetObjectsByPrototypeMock       mObjectStorageMockGetObjectsByPrototype
+
 	GetRecordFunc       func(p context.Context, p1 core.RecordID, p2 *core.RecordID) (r record.Record, r1 error)
 	GetRecordCounter    uint64
 	GetRecordPreCounter uint64
@@ -78,6 +83,7 @@ func NewObjectStorageMock(t minimock.Tester) *ObjectStorageMock {
 
 	m.GetBlobMock = mObjectStorageMockGetBlob{mock: m}
 	m.GetObjectIndexMock = mObjectStorageMockGetObjectIndex{mock: m}
+	m.GetObjectsByPrototypeMock = mObjectStorageMockGetObjectsByPrototype{mock: m}
 	m.GetRecordMock = mObjectStorageMockGetRecord{mock: m}
 	m.IterateIndexIDsMock = mObjectStorageMockIterateIndexIDs{mock: m}
 	m.RemoveObjectIndexMock = mObjectStorageMockRemoveObjectIndex{mock: m}
@@ -394,6 +400,159 @@ func (m *ObjectStorageMock) GetObjectIndexFinished() bool {
 	return true
 }
 
+type mObjectStorageMockGetObjectsByPrototype struct {
+	mock              *ObjectStorageMock
+	mainExpectation   *ObjectStorageMockGetObjectsByPrototypeExpectation
+	expectationSeries []*ObjectStorageMockGetObjectsByPrototypeExpectation
+}
+
+type ObjectStorageMockGetObjectsByPrototypeExpectation struct {
+	input  *ObjectStorageMockGetObjectsByPrototypeInput
+	result *ObjectStorageMockGetObjectsByPrototypeResult
+}
+
+type ObjectStorageMockGetObjectsByPrototypeInput struct {
+	p  context.Context
+	p1 core.RecordRef
+	p2 *core.RecordRef
+	p3 int
+}
+
+type ObjectStorageMockGetObjectsByPrototypeResult struct {
+	r  []core.RecordRef
+	r1 error
+}
+
+//Expect specifies that invocation of ObjectStorage.GetObjectsByPrototype is expected from 1 to Infinity times
+func (m *mObjectStorageMockGetObjectsByPrototype) Expect(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) *mObjectStorageMockGetObjectsByPrototype {
+	m.mock.GetObjectsByPrototypeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockGetObjectsByPrototypeExpectation{}
+	}
+	m.mainExpectation.input = &ObjectStorageMockGetObjectsByPrototypeInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ObjectStorage.GetObjectsByPrototype
+func (m *mObjectStorageMockGetObjectsByPrototype) Return(r []core.RecordRef, r1 error) *ObjectStorageMock {
+	m.mock.GetObjectsByPrototypeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockGetObjectsByPrototypeExpectation{}
+	}
+	m.mainExpectation.result = &ObjectStorageMockGetObjectsByPrototypeResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ObjectStorage.GetObjectsByPrototype is expected once
+func (m *mObjectStorageMockGetObjectsByPrototype) ExpectOnce(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) *ObjectStorageMockGetObjectsByPrototypeExpectation {
+	m.mock.GetObjectsByPrototypeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ObjectStorageMockGetObjectsByPrototypeExpectation{}
+	expectation.input = &ObjectStorageMockGetObjectsByPrototypeInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ObjectStorageMockGetObjectsByPrototypeExpectation) Return(r []core.RecordRef, r1 error) {
+	e.result = &ObjectStorageMockGetObjectsByPrototypeResult{r, r1}
+}
+
+//Set uses given function f as a mock of ObjectStorage.GetObjectsByPrototype method
+func (m *mObjectStorageMockGetObjectsByPrototype) Set(f func(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) (r []core.RecordRef, r1 error)) *ObjectStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetObjectsByPrototypeFunc = f
+	return m.mock
+}
+
+//GetObjectsByPrototype implements github.com/insolar/insolar/ledger/storage.ObjectStorage interface
+func (m *ObjectStorageMock) GetObjectsByPrototype(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) (r []core.RecordRef, r1 error) {
+	counter := atomic.AddUint64(&m.GetObjectsByPrototypePreCounter, 1)
+	defer atomic.AddUint64(&m.GetObjectsByPrototypeCounter, 1)
+
+	if len(m.GetObjectsByPrototypeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetObjectsByPrototypeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ObjectStorageMock.GetObjectsByPrototype. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetObjectsByPrototypeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ObjectStorageMockGetObjectsByPrototypeInput{p, p1, p2, p3}, "ObjectStorage.GetObjectsByPrototype got unexpected parameters")
+
+		result := m.GetObjectsByPrototypeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.GetObjectsByPrototype")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetObjectsByPrototypeMock.mainExpectation != nil {
+
+		input := m.GetObjectsByPrototypeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ObjectStorageMockGetObjectsByPrototypeInput{p, p1, p2, p3}, "ObjectStorage.GetObjectsByPrototype got unexpected parameters")
+		}
+
+		result := m.GetObjectsByPrototypeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.GetObjectsByPrototype")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetObjectsByPrototypeFunc == nil {
+		m.t.Fatalf("Unexpected call to ObjectStorageMock.GetObjectsByPrototype. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetObjectsByPrototypeFunc(p, p1, p2, p3)
+}
+
+//GetObjectsByPrototypeMinimockCounter returns a count of ObjectStorageMock.GetObjectsByPrototypeFunc invocations
+func (m *ObjectStorageMock) GetObjectsByPrototypeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter)
+}
+
+//GetObjectsByPrototypeMinimockPreCounter returns the value of ObjectStorageMock.GetObjectsByPrototype invocations
+func (m *ObjectStorageMock) GetObjectsByPrototypeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectsByPrototypePreCounter)
+}
+
+//GetObjectsByPrototypeFinished returns true if mock invocations count is ok
+func (m *ObjectStorageMock) GetObjectsByPrototypeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetObjectsByPrototypeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter) == uint64(len(m.GetObjectsByPrototypeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetObjectsByPrototypeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetObjectsByPrototypeFunc != nil {
+		return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter) > 0
+	}
+
+	return true
+}
+
 type mObjectStorageMockGetRecord struct {
 	mock              *ObjectStorageMock
 	mainExpectation   *ObjectStorageMockGetRecordExpectation
@@ -1462,6 +1621,10 @@ func (m *ObjectStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetObjectIndex")
 	}
 
+	if !m.GetObjectsByPrototypeFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.GetObjectsByPrototype")
+	}
+
 	if !m.GetRecordFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetRecord")
 	}
@@ -1515,6 +1678,10 @@ func (m *ObjectStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetObjectIndex")
 	}
 
+	if !m.GetObjectsByPrototypeFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.GetObjectsByPrototype")
+	}
+
 	if !m.GetRecordFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetRecord")
 	}
@@ -1559,6 +1726,7 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 		ok := true
 		ok = ok && m.GetBlobFinished()
 		ok = ok && m.GetObjectIndexFinished()
+		ok = ok && m.GetObjectsByPrototypeFinished()
 		ok = ok && m.GetRecordFinished()
 		ok = ok && m.IterateIndexIDsFinished()
 		ok = ok && m.RemoveObjectIndexFinished()
@@ -1582,6 +1750,10 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ObjectStorageMock.GetObjectIndex")
 			}
 
+			if !m.GetObjectsByPrototypeFinished() {
+				m.t.Error("Expected call to ObjectStorageMock.GetObjectsByPrototype")
+			}
+
 			if !m.GetRecordFinished() {
 				m.t.Error("Expected call to ObjectStorageMock.GetRecord")
 			}
@@ -1630,6 +1802,10 @@ func (m *ObjectStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetObjectsByPrototypeFinished() {
+		return false
+	}
+
 	if !m.GetRecordFinished() {
 		return false
 	}
@@ -62,6 +62,13 @@ type ObjectStorage interface {
 		jetID core.RecordID,
 		ref *core.RecordID,
 	) error
+
+	GetObjectsByPrototype(
+		ctx context.Context,
+		prototype core.RecordRef,
+		from *core.RecordRef,
+		limit int,
+	) ([]core.RecordRef, error)
 }
 
 type objectStorage struct {
@@ -0,0 +1,86 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+var activateRecordType = (&record.ObjectActivateRecord{}).Type()
+
+func prototypeIndexKey(prototype core.RecordRef, object []byte) []byte {
+	return prefixkey(scopeIDPrototype, prototype[:], object)
+}
+
+// indexRecord adds object activated by record to index of objects by prototype.
+//
+// Index is maintained for records stored by StoreKeyValues, i.e. on heavy material nodes only, light nodes remove
+// their records on cleanup and do not need it.
+func (m *TransactionManager) indexRecord(ctx context.Context, key, value []byte) error {
+	if len(value) < record.TypeIDSize || len(key) < core.RecordIDSize {
+		return nil
+	}
+	if record.DeserializeType(value[:record.TypeIDSize]) != activateRecordType {
+		return nil
+	}
+	activate := record.DeserializeRecord(value).(*record.ObjectActivateRecord)
+	if activate.IsPrototype {
+		return nil
+	}
+
+	stateID := key[len(key)-core.RecordIDSize:]
+	return m.set(ctx, prototypeIndexKey(activate.Image, activate.Request[:]), stateID)
+}
+
+// GetObjectsByPrototype returns up to limit objects of provided prototype ordered by reference. Iteration starts
+// after from reference if it is not nil.
+func (os *objectStorage) GetObjectsByPrototype(
+	ctx context.Context,
+	prototype core.RecordRef,
+	from *core.RecordRef,
+	limit int,
+) ([]core.RecordRef, error) {
+	prefix := prototypeIndexKey(prototype, nil)
+	var start []byte
+	if from != nil {
+		start = prototypeIndexKey(prototype, from[:])
+	}
+
+	refs := make([]core.RecordRef, 0)
+	err := os.DB.GetKV().View(func(txn KVTxn) error {
+		return txn.IterateKeys(prefix, start, func(k []byte) (bool, error) {
+			if start != nil && bytes.Equal(k, start) {
+				return true, nil
+			}
+			if len(refs) >= limit {
+				return false, nil
+			}
+			var ref core.RecordRef
+			copy(ref[:], k[len(prefix):])
+			refs = append(refs, ref)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
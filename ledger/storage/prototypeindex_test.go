@@ -0,0 +1,100 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStorage_GetObjectsByPrototype(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "prototype-index-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := configuration.NewLedger()
+	conf.Storage.DataDirectory = dir
+	db, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	s := &objectStorage{DB: db, PlatformCryptographyScheme: scheme}
+
+	_, jetPrefix := jet.Jet(*jet.NewID(0, nil))
+	prototype := testutils.RandomRef()
+	kv := func(rec record.Record) core.KV {
+		id := record.NewRecordIDFromRecord(scheme, core.FirstPulseNumber, rec)
+		return core.KV{K: prefixkey(scopeIDRecord, jetPrefix, id[:]), V: record.SerializeRecord(rec)}
+	}
+	activate := func(object, image core.RecordRef, isPrototype bool) record.Record {
+		return &record.ObjectActivateRecord{
+			SideEffectRecord:  record.SideEffectRecord{Request: object},
+			ObjectStateRecord: record.ObjectStateRecord{Image: image, IsPrototype: isPrototype},
+		}
+	}
+
+	var objects []core.RecordRef
+	var kvs []core.KV
+	for i := 0; i < 5; i++ {
+		object := testutils.RandomRef()
+		objects = append(objects, object)
+		kvs = append(kvs, kv(activate(object, prototype, false)))
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Compare(objects[j]) < 0 })
+	// prototype itself, objects of other prototypes and other records are not indexed
+	kvs = append(kvs,
+		kv(activate(prototype, testutils.RandomRef(), true)),
+		kv(activate(testutils.RandomRef(), testutils.RandomRef(), false)),
+		kv(&record.RequestRecord{Object: *prototype.Record()}),
+	)
+	require.NoError(t, db.StoreKeyValues(ctx, kvs))
+
+	refs, err := s.GetObjectsByPrototype(ctx, prototype, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, objects, refs)
+
+	var paged []core.RecordRef
+	var from *core.RecordRef
+	for {
+		refs, err := s.GetObjectsByPrototype(ctx, prototype, from, 2)
+		require.NoError(t, err)
+		if len(refs) == 0 {
+			break
+		}
+		assert.True(t, len(refs) <= 2)
+		paged = append(paged, refs...)
+		from = &refs[len(refs)-1]
+	}
+	assert.Equal(t, objects, paged)
+
+	refs, err = s.GetObjectsByPrototype(ctx, testutils.RandomRef(), nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, refs)
+}
@@ -22,6 +22,8 @@ import (
 	"encoding/hex"
 
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
 	"github.com/ugorji/go/codec"
 )
 
@@ -87,7 +89,7 @@ func (m *TransactionManager) GetPulse(ctx context.Context, num core.PulseNumber)
 
 // AddPulse saves new pulse data and updates index.
 func (pt *pulseTracker) AddPulse(ctx context.Context, pulse core.Pulse) error {
-	return pt.DB.Update(ctx, func(tx *TransactionManager) error {
+	err := pt.DB.Update(ctx, func(tx *TransactionManager) error {
 		var (
 			previousPulseNumber  core.PulseNumber
 			previousSerialNumber int
@@ -136,6 +138,21 @@ func (pt *pulseTracker) AddPulse(ctx context.Context, pulse core.Pulse) error {
 
 		return tx.set(ctx, prefixkey(scopeIDSystem, []byte{sysLatestPulse}), p.Bytes())
 	})
+	if err != nil {
+		return err
+	}
+
+	// Commits of new pulse go to new write-ahead log segment.
+	if db, ok := pt.DB.(interface{ rotateWAL(core.PulseNumber) error }); ok {
+		if err := db.rotateWAL(pulse.PulseNumber); err != nil {
+			inslogger.FromContext(ctx).Error(errors.Wrap(err, "failed to rotate WAL"))
+		}
+	}
+	// Written data is counted per pulse.
+	if db, ok := pt.DB.(interface{ rotateStats(context.Context) }); ok {
+		db.rotateStats(ctx)
+	}
+	return nil
 }
 
 // GetPulse returns pulse for provided pulse number.
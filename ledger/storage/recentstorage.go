@@ -17,6 +17,7 @@
 package storage
 
 import (
+	"container/list"
 	"context"
 	"sync"
 
@@ -32,11 +33,33 @@ type RecentStorageProvider struct {
 	storage    map[core.RecordID]*RecentStorage
 	lock       sync.Mutex
 	DefaultTTL int
+	// MaxObjects is max count of objects in storage of jet, least recently used objects without pending requests
+	// are evicted on overflow. Zero means no limit.
+	MaxObjects int
+
+	onEvict recentstorage.EvictionHandler
 }
 
 // NewRecentStorageProvider creates new provider
-func NewRecentStorageProvider(defaultTTL int) *RecentStorageProvider {
-	return &RecentStorageProvider{DefaultTTL: defaultTTL, storage: map[core.RecordID]*RecentStorage{}}
+func NewRecentStorageProvider(defaultTTL, maxObjects int) *RecentStorageProvider {
+	return &RecentStorageProvider{
+		DefaultTTL: defaultTTL,
+		MaxObjects: maxObjects,
+		storage:    map[core.RecordID]*RecentStorage{},
+	}
+}
+
+// SetEvictionHandler sets handler called for objects removed from storages by TTL or size limit.
+func (p *RecentStorageProvider) SetEvictionHandler(handler recentstorage.EvictionHandler) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.onEvict = handler
+	for _, storage := range p.storage {
+		storage.objectLock.Lock()
+		storage.onEvict = handler
+		storage.objectLock.Unlock()
+	}
 }
 
 // GetStorage returns a recent storage for jet
@@ -46,12 +69,19 @@ func (p *RecentStorageProvider) GetStorage(ctx context.Context, jetID core.Recor
 
 	storage, ok := p.storage[jetID]
 	if !ok {
-		storage = NewRecentStorage(jetID, p.DefaultTTL)
+		storage = p.newStorage(jetID)
 		p.storage[jetID] = storage
 	}
 	return storage
 }
 
+func (p *RecentStorageProvider) newStorage(jetID core.RecordID) *RecentStorage {
+	storage := NewRecentStorage(jetID, p.DefaultTTL)
+	storage.MaxObjects = p.MaxObjects
+	storage.onEvict = p.onEvict
+	return storage
+}
+
 // CloneStorage clones a recent storage from one jet to another
 func (p *RecentStorageProvider) CloneStorage(ctx context.Context, fromJetID, toJetID core.RecordID) {
 	p.lock.Lock()
@@ -61,17 +91,16 @@ func (p *RecentStorageProvider) CloneStorage(ctx context.Context, fromJetID, toJ
 	if !ok {
 		return
 	}
-	toStorage := &RecentStorage{
-		jetID:           toJetID,
-		recentObjects:   make(map[core.RecordID]recentObjectMeta, len(fromStorage.recentObjects)),
-		pendingRequests: make(map[core.RecordID]map[core.RecordID]struct{}, len(fromStorage.pendingRequests)),
-		DefaultTTL:      p.DefaultTTL,
-		objectLock:      sync.Mutex{},
-	}
-	for k, v := range fromStorage.recentObjects {
-		clone := v
-		toStorage.recentObjects[k] = clone
+	toStorage := p.newStorage(toJetID)
+
+	fromStorage.objectLock.Lock()
+	for e := fromStorage.lru.Back(); e != nil; e = e.Prev() {
+		clone := *e.Value.(*recentObject)
+		toStorage.recentObjects[clone.id] = toStorage.lru.PushFront(&clone)
 	}
+	fromStorage.objectLock.Unlock()
+
+	fromStorage.requestLock.RLock()
 	for objID, objRequests := range fromStorage.pendingRequests {
 		clone := make(map[core.RecordID]struct{}, len(objRequests))
 		for reqID, v := range objRequests {
@@ -79,6 +108,8 @@ func (p *RecentStorageProvider) CloneStorage(ctx context.Context, fromJetID, toJ
 		}
 		toStorage.pendingRequests[objID] = clone
 	}
+	fromStorage.requestLock.RUnlock()
+
 	p.storage[toJetID] = toStorage
 }
 
@@ -101,17 +132,40 @@ func (p *RecentStorageProvider) RemoveStorage(ctx context.Context, id core.Recor
 	}
 }
 
+// DecreaseTTL decreases TTL of objects in storages of all jets. It is called on every pulse.
+func (p *RecentStorageProvider) DecreaseTTL(ctx context.Context) {
+	p.lock.Lock()
+	storages := make([]*RecentStorage, 0, len(p.storage))
+	for _, storage := range p.storage {
+		storages = append(storages, storage)
+	}
+	p.lock.Unlock()
+
+	for _, storage := range storages {
+		storage.DecreaseTTL(ctx)
+	}
+}
+
 // RecentStorage is a base structure
 type RecentStorage struct {
-	jetID           core.RecordID
-	recentObjects   map[core.RecordID]recentObjectMeta
+	jetID core.RecordID
+	// recentObjects are elements of lru list, the most recently used object is in front.
+	recentObjects   map[core.RecordID]*list.Element
+	lru             *list.List
 	objectLock      sync.Mutex
 	pendingRequests map[core.RecordID]map[core.RecordID]struct{}
 	requestLock     sync.RWMutex
 	DefaultTTL      int
+	// MaxObjects is max count of objects, least recently used objects without pending requests are evicted on
+	// overflow. Zero means no limit.
+	MaxObjects int
+
+	// onEvict is guarded by objectLock
+	onEvict recentstorage.EvictionHandler
 }
 
-type recentObjectMeta struct {
+type recentObject struct {
+	id  core.RecordID
 	ttl int
 }
 
@@ -119,7 +173,8 @@ type recentObjectMeta struct {
 func NewRecentStorage(jetID core.RecordID, defaultTTL int) *RecentStorage {
 	return &RecentStorage{
 		jetID:           jetID,
-		recentObjects:   map[core.RecordID]recentObjectMeta{},
+		recentObjects:   map[core.RecordID]*list.Element{},
+		lru:             list.New(),
 		pendingRequests: map[core.RecordID]map[core.RecordID]struct{}{},
 		DefaultTTL:      defaultTTL,
 		objectLock:      sync.Mutex{},
@@ -134,12 +189,44 @@ func (r *RecentStorage) AddObject(ctx context.Context, id core.RecordID) {
 // AddObjectWithTLL adds object with specified TTL to the cache
 func (r *RecentStorage) AddObjectWithTLL(ctx context.Context, id core.RecordID, ttl int) {
 	r.objectLock.Lock()
-	defer r.objectLock.Unlock()
 
-	r.recentObjects[id] = recentObjectMeta{ttl: ttl}
+	if e, ok := r.recentObjects[id]; ok {
+		e.Value.(*recentObject).ttl = ttl
+		r.lru.MoveToFront(e)
+	} else {
+		r.recentObjects[id] = r.lru.PushFront(&recentObject{id: id, ttl: ttl})
+	}
+
+	// least recently used objects are evicted, objects with pending requests and the added object are kept
+	var evicted []recentObject
+	for e := r.lru.Back(); r.MaxObjects > 0 && r.lru.Len() > r.MaxObjects && e != r.lru.Front(); {
+		prev := e.Prev()
+		obj := e.Value.(*recentObject)
+		if !r.hasPendingRequests(obj.id) {
+			r.lru.Remove(e)
+			delete(r.recentObjects, obj.id)
+			evicted = append(evicted, *obj)
+		}
+		e = prev
+	}
+	onEvict := r.onEvict
+	r.objectLock.Unlock()
 
 	ctx = insmetrics.InsertTag(ctx, tagJet, r.jetID.DebugString())
 	stats.Record(ctx, statRecentStorageObjectsAdded.M(1))
+	if len(evicted) > 0 {
+		stats.Record(ctx, statRecentStorageObjectsEvicted.M(int64(len(evicted))))
+		r.evict(ctx, onEvict, evicted)
+	}
+}
+
+// hasPendingRequests checks if object has pending requests, such objects are not evicted from full storage.
+func (r *RecentStorage) hasPendingRequests(obj core.RecordID) bool {
+	r.requestLock.RLock()
+	defer r.requestLock.RUnlock()
+
+	_, ok := r.pendingRequests[obj]
+	return ok
 }
 
 // AddPendingRequest adds request to cache.
@@ -179,8 +266,8 @@ func (r *RecentStorage) GetObjects() map[core.RecordID]int {
 	defer r.objectLock.Unlock()
 
 	targetMap := make(map[core.RecordID]int, len(r.recentObjects))
-	for key, value := range r.recentObjects {
-		targetMap[key] = value.ttl
+	for key, e := range r.recentObjects {
+		targetMap[key] = e.Value.(*recentObject).ttl
 	}
 
 	return targetMap
@@ -224,11 +311,14 @@ func (r *RecentStorage) GetRequestsForObject(obj core.RecordID) []core.RecordID
 func (r *RecentStorage) IsRecordIDCached(obj core.RecordID) bool {
 	r.objectLock.Lock()
 	_, ok := r.recentObjects[obj]
+	r.objectLock.Unlock()
+
+	ctx := insmetrics.InsertTag(context.Background(), tagJet, r.jetID.DebugString())
 	if ok {
-		r.objectLock.Unlock()
+		stats.Record(ctx, statRecentStorageObjectsHit.M(1))
 		return ok
 	}
-	r.objectLock.Unlock()
+	stats.Record(ctx, statRecentStorageObjectsMiss.M(1))
 
 	r.requestLock.RLock()
 	_, ok = r.pendingRequests[obj]
@@ -239,14 +329,35 @@ func (r *RecentStorage) IsRecordIDCached(obj core.RecordID) bool {
 // DecreaseTTL decreases ttl and clears objects if their ttl is zero
 func (r *RecentStorage) DecreaseTTL(ctx context.Context) {
 	r.objectLock.Lock()
-	defer r.objectLock.Unlock()
 
-	for key, value := range r.recentObjects {
-		value.ttl--
-		if value.ttl == 0 {
-			delete(r.recentObjects, key)
-			continue
+	var expired []recentObject
+	for e := r.lru.Front(); e != nil; {
+		next := e.Next()
+		obj := e.Value.(*recentObject)
+		obj.ttl--
+		if obj.ttl <= 0 {
+			r.lru.Remove(e)
+			delete(r.recentObjects, obj.id)
+			expired = append(expired, *obj)
 		}
-		r.recentObjects[key] = value
+		e = next
+	}
+	onEvict := r.onEvict
+	r.objectLock.Unlock()
+
+	if len(expired) > 0 {
+		ctx = insmetrics.InsertTag(ctx, tagJet, r.jetID.DebugString())
+		stats.Record(ctx, statRecentStorageObjectsExpired.M(int64(len(expired))))
+		r.evict(ctx, onEvict, expired)
+	}
+}
+
+// evict calls eviction handler for removed objects. It is called without locks, so handler can use storage.
+func (r *RecentStorage) evict(ctx context.Context, onEvict recentstorage.EvictionHandler, objects []recentObject) {
+	if onEvict == nil {
+		return
+	}
+	for _, obj := range objects {
+		onEvict(ctx, r.jetID, obj.id, obj.ttl)
 	}
 }
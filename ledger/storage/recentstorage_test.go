@@ -17,6 +17,7 @@
 package storage
 
 import (
+	"context"
 	"sync"
 	"testing"
 
@@ -149,9 +150,68 @@ func TestRecentObjectsIndex_RemovePendingRequest(t *testing.T) {
 	}, s.GetRequests())
 }
 
+func TestRecentObjectsIndex_DecreaseTTL(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	jetID := testutils.RandomID()
+	s := NewRecentStorage(jetID, 2)
+	var expired []core.RecordID
+	s.onEvict = func(ctx context.Context, jet, id core.RecordID, ttl int) {
+		require.Equal(t, jetID, jet)
+		require.Zero(t, ttl)
+		expired = append(expired, id)
+	}
+
+	first := *core.NewRecordID(123, []byte{1})
+	second := *core.NewRecordID(123, []byte{2})
+	s.AddObject(ctx, first)
+	s.AddObjectWithTLL(ctx, second, 1)
+
+	s.DecreaseTTL(ctx)
+	require.Equal(t, map[core.RecordID]int{first: 1}, s.GetObjects())
+	require.Equal(t, []core.RecordID{second}, expired)
+
+	s.DecreaseTTL(ctx)
+	require.Empty(t, s.GetObjects())
+	require.Equal(t, []core.RecordID{second, first}, expired)
+}
+
+func TestRecentObjectsIndex_MaxObjects(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	s := NewRecentStorage(testutils.RandomID(), 10)
+	s.MaxObjects = 2
+	var evicted []core.RecordID
+	s.onEvict = func(ctx context.Context, jet, id core.RecordID, ttl int) {
+		// object is evicted before its TTL expired
+		require.Equal(t, 10, ttl)
+		evicted = append(evicted, id)
+	}
+
+	first := *core.NewRecordID(123, []byte{1})
+	second := *core.NewRecordID(123, []byte{2})
+	third := *core.NewRecordID(123, []byte{3})
+	s.AddObject(ctx, first)
+	s.AddObject(ctx, second)
+	// first object becomes the most recently used one
+	s.AddObject(ctx, first)
+	s.AddObject(ctx, third)
+
+	require.Equal(t, []core.RecordID{second}, evicted)
+	require.True(t, s.IsRecordIDCached(first))
+	require.False(t, s.IsRecordIDCached(second))
+	require.True(t, s.IsRecordIDCached(third))
+
+	// objects with pending requests are kept even if storage is full
+	fourth := *core.NewRecordID(123, []byte{4})
+	s.AddPendingRequest(ctx, first, testutils.RandomID())
+	s.AddPendingRequest(ctx, third, testutils.RandomID())
+	s.AddObject(ctx, fourth)
+	require.Equal(t, []core.RecordID{second}, evicted)
+	require.Len(t, s.GetObjects(), 3)
+}
+
 func TestNewRecentStorageProvider(t *testing.T) {
 	// Act
-	provider := NewRecentStorageProvider(888)
+	provider := NewRecentStorageProvider(888, 0)
 
 	// Assert
 	require.Equal(t, 888, provider.DefaultTTL)
@@ -160,7 +220,7 @@ func TestNewRecentStorageProvider(t *testing.T) {
 
 func TestRecentStorageProvider_GetStorage(t *testing.T) {
 	// Arrange
-	provider := NewRecentStorageProvider(8)
+	provider := NewRecentStorageProvider(8, 0)
 
 	// Act
 	wg := sync.WaitGroup{}
@@ -180,3 +240,25 @@ func TestRecentStorageProvider_GetStorage(t *testing.T) {
 	// Assert
 	require.Equal(t, 8, len(provider.storage))
 }
+
+func TestRecentStorageProvider_DecreaseTTL(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	provider := NewRecentStorageProvider(1, 1)
+	var evicted []core.RecordID
+	provider.SetEvictionHandler(func(ctx context.Context, jet, id core.RecordID, ttl int) {
+		evicted = append(evicted, id)
+	})
+
+	first := provider.GetStorage(ctx, testutils.RandomJet())
+	second := provider.GetStorage(ctx, testutils.RandomJet())
+	firstID, secondID, thirdID := testutils.RandomID(), testutils.RandomID(), testutils.RandomID()
+	first.AddObject(ctx, firstID)
+	second.AddObject(ctx, secondID)
+	second.AddObject(ctx, thirdID)
+	require.Equal(t, []core.RecordID{secondID}, evicted)
+
+	provider.DecreaseTTL(ctx)
+	require.Empty(t, first.GetObjects())
+	require.Empty(t, second.GetObjects())
+	require.ElementsMatch(t, []core.RecordID{secondID, firstID, thirdID}, evicted)
+}
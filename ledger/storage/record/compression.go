@@ -0,0 +1,228 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package record
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Formats of stored records, format is the first byte of stored record.
+const (
+	// FormatRaw is serialized record as is, serialized record starts with type id which high byte is zero.
+	FormatRaw byte = 0
+	// FormatZstd is serialized record compressed by zstd. Format byte is followed by 4 bytes of dictionary id
+	// (zero if record is compressed without dictionary) and zstd frame.
+	FormatZstd byte = 1
+)
+
+const zstdHeaderSize = 1 + 4
+
+// Dictionary is zstd dictionary trained on serialized records of one type.
+type Dictionary struct {
+	ID   uint32
+	Type TypeID
+	Data []byte
+}
+
+// CompressorOptions configures compression of records.
+type CompressorOptions struct {
+	// Level is zstd compression level.
+	Level int
+	// DictionarySize is max size of dictionary content, zero disables dictionary training.
+	DictionarySize int
+	// TrainSamples is count of records of type dictionary is trained on.
+	TrainSamples int
+	// MinSize is min size of serialized record to compress, smaller records are stored as is.
+	MinSize int
+}
+
+type typeDictionary struct {
+	dict    *Dictionary
+	encoder *zstd.Encoder
+	samples [][]byte
+}
+
+// Compressor compresses serialized records with zstd using dictionaries trained per record type.
+//
+// Compressor only trains dictionaries, they are used for compression after AddDictionary call. So caller
+// saves trained dictionary before any record compressed with it is stored.
+type Compressor struct {
+	opts    CompressorOptions
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+
+	mu       sync.RWMutex
+	lastID   uint32
+	types    map[TypeID]*typeDictionary
+	decoders map[uint32]*zstd.Decoder
+}
+
+// NewCompressor creates record compressor.
+func NewCompressor(opts CompressorOptions) (*Compressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(opts.Level)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create zstd encoder")
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create zstd decoder")
+	}
+	return &Compressor{
+		opts:     opts,
+		encoder:  encoder,
+		decoder:  decoder,
+		types:    map[TypeID]*typeDictionary{},
+		decoders: map[uint32]*zstd.Decoder{},
+	}, nil
+}
+
+// AddDictionary makes dictionary available for decompression and, if it is the latest one for record type,
+// for compression.
+func (c *Compressor) AddDictionary(d *Dictionary) error {
+	encoder, err := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(c.opts.Level)), zstd.WithEncoderDict(d.Data))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create zstd encoder with dictionary %v", d.ID)
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderDicts(d.Data))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create zstd decoder with dictionary %v", d.ID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.decoders[d.ID] = decoder
+	if d.ID > c.lastID {
+		c.lastID = d.ID
+	}
+	td := c.typeDictionary(d.Type)
+	if td.dict == nil || td.dict.ID < d.ID {
+		td.dict = d
+		td.encoder = encoder
+		td.samples = nil
+	}
+	return nil
+}
+
+// Compress returns stored representation of serialized record. Record is left as is if it is too small or
+// compression doesn't make it smaller.
+//
+// Once enough records of type are seen, Compress trains and returns dictionary for the type. If training fails,
+// records of type are compressed without dictionary.
+func (c *Compressor) Compress(buf []byte) ([]byte, *Dictionary) {
+	if len(buf) < TypeIDSize || len(buf) < c.opts.MinSize {
+		return buf, nil
+	}
+	t := DeserializeType(buf[:TypeIDSize])
+	trained := c.train(t, buf)
+
+	encoder, id := c.encoder, uint32(0)
+	c.mu.RLock()
+	if td, ok := c.types[t]; ok && td.dict != nil {
+		encoder, id = td.encoder, td.dict.ID
+	}
+	c.mu.RUnlock()
+
+	compressed := make([]byte, zstdHeaderSize, zstdHeaderSize+len(buf))
+	compressed[0] = FormatZstd
+	binary.BigEndian.PutUint32(compressed[1:], id)
+	compressed = encoder.EncodeAll(buf, compressed)
+	if len(compressed) >= len(buf) {
+		return buf, trained
+	}
+	return compressed, trained
+}
+
+// Decompress returns serialized record from its stored representation.
+func (c *Compressor) Decompress(buf []byte) ([]byte, error) {
+	if len(buf) == 0 || buf[0] == FormatRaw {
+		return buf, nil
+	}
+	if buf[0] != FormatZstd {
+		return nil, errors.Errorf("unknown stored record format %v", buf[0])
+	}
+	if len(buf) < zstdHeaderSize {
+		return nil, errors.New("malformed compressed record")
+	}
+
+	decoder := c.decoder
+	if id := binary.BigEndian.Uint32(buf[1:]); id != 0 {
+		c.mu.RLock()
+		decoder = c.decoders[id]
+		c.mu.RUnlock()
+		if decoder == nil {
+			return nil, errors.Errorf("unknown record dictionary %v", id)
+		}
+	}
+	decompressed, err := decoder.DecodeAll(buf[zstdHeaderSize:], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress record")
+	}
+	return decompressed, nil
+}
+
+// train collects sample of record type and trains dictionary when enough samples are collected.
+func (c *Compressor) train(t TypeID, buf []byte) *Dictionary {
+	if c.opts.DictionarySize <= 0 || c.opts.TrainSamples <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	td := c.typeDictionary(t)
+	if td.dict != nil {
+		return nil
+	}
+	td.samples = append(td.samples, append([]byte{}, buf...))
+	if len(td.samples) < c.opts.TrainSamples {
+		return nil
+	}
+
+	samples := td.samples
+	td.samples = nil
+	history := bytes.Join(samples, nil)
+	if len(history) > c.opts.DictionarySize {
+		// the latest samples are the closest to compressed data
+		history = history[len(history)-c.opts.DictionarySize:]
+	}
+	data, err := zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       c.lastID + 1,
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+	})
+	if err != nil {
+		return nil
+	}
+	c.lastID++
+	return &Dictionary{ID: c.lastID, Type: t, Data: data}
+}
+
+// typeDictionary should be called under lock.
+func (c *Compressor) typeDictionary(t TypeID) *typeDictionary {
+	td, ok := c.types[t]
+	if !ok {
+		td = &typeDictionary{}
+		c.types[t] = td
+	}
+	return td
+}
@@ -0,0 +1,162 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package record
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomRef() (ref core.RecordRef) {
+	rand.Read(ref[:]) // nolint: errcheck
+	return
+}
+
+func testActivateRecords(n int) [][]byte {
+	cs := platformpolicy.NewPlatformCryptographyScheme()
+	domain, image, parent := randomRef(), randomRef(), randomRef()
+	records := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		records = append(records, SerializeRecord(&ObjectActivateRecord{
+			SideEffectRecord: SideEffectRecord{
+				Domain:  domain,
+				Request: randomRef(),
+			},
+			ObjectStateRecord: ObjectStateRecord{
+				Memory: CalculateIDForBlob(cs, core.FirstPulseNumber, []byte{byte(i)}),
+				Image:  image,
+			},
+			Parent: parent,
+		}))
+	}
+	return records
+}
+
+func TestCompressor_CompressDecompress(t *testing.T) {
+	compressor, err := NewCompressor(CompressorOptions{Level: 3})
+	require.NoError(t, err)
+
+	for _, buf := range testActivateRecords(10) {
+		compressed, dict := compressor.Compress(buf)
+		assert.Nil(t, dict, "no dictionary training is configured")
+		decompressed, err := compressor.Decompress(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, buf, decompressed)
+	}
+
+	raw := SerializeType(typeActivate)
+	decompressed, err := compressor.Decompress(raw)
+	require.NoError(t, err)
+	assert.Equal(t, raw, decompressed, "raw records are returned as is")
+
+	_, err = compressor.Decompress([]byte{42, 1, 2})
+	assert.Error(t, err, "unknown format")
+	_, err = compressor.Decompress([]byte{FormatZstd, 0, 0, 0, 42, 1})
+	assert.Error(t, err, "unknown dictionary")
+}
+
+func TestCompressor_Dictionary(t *testing.T) {
+	opts := CompressorOptions{Level: 3, DictionarySize: 4 << 10, TrainSamples: 20}
+	compressor, err := NewCompressor(opts)
+	require.NoError(t, err)
+
+	records := testActivateRecords(40)
+	var dict *Dictionary
+	for _, buf := range records[:opts.TrainSamples] {
+		_, dict = compressor.Compress(buf)
+	}
+	require.NotNil(t, dict, "dictionary is trained on samples")
+	assert.Equal(t, typeActivate, dict.Type)
+
+	withoutDict, _ := compressor.Compress(records[opts.TrainSamples])
+	require.NoError(t, compressor.AddDictionary(dict))
+	withDict, _ := compressor.Compress(records[opts.TrainSamples])
+	assert.Equal(t, FormatZstd, withDict[0])
+	assert.True(t, len(withDict) < len(withoutDict), "dictionary improves compression")
+
+	// new compressor reads records with persisted dictionary
+	reader, err := NewCompressor(CompressorOptions{})
+	require.NoError(t, err)
+	_, err = reader.Decompress(withDict)
+	require.Error(t, err)
+	require.NoError(t, reader.AddDictionary(dict))
+	decompressed, err := reader.Decompress(withDict)
+	require.NoError(t, err)
+	assert.Equal(t, records[opts.TrainSamples], decompressed)
+}
+
+func benchmarkCompressor(b *testing.B, train bool) (*Compressor, [][]byte) {
+	opts := CompressorOptions{Level: 3}
+	if train {
+		opts.DictionarySize = 16 << 10
+		opts.TrainSamples = 100
+	}
+	compressor, err := NewCompressor(opts)
+	require.NoError(b, err)
+	records := testActivateRecords(1000)
+	for _, buf := range records[:100] {
+		if _, dict := compressor.Compress(buf); dict != nil {
+			require.NoError(b, compressor.AddDictionary(dict))
+		}
+	}
+	return compressor, records
+}
+
+func benchmarkCompress(b *testing.B, train bool) {
+	compressor, records := benchmarkCompressor(b, train)
+	var raw, stored int
+	for _, buf := range records {
+		compressed, _ := compressor.Compress(buf)
+		raw += len(buf)
+		stored += len(compressed)
+	}
+	b.Logf("compressed to %.2f of %v bytes", float64(stored)/float64(raw), raw)
+
+	b.SetBytes(int64(raw / len(records)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compressor.Compress(records[i%len(records)])
+	}
+}
+
+func benchmarkDecompress(b *testing.B, train bool) {
+	compressor, records := benchmarkCompressor(b, train)
+	compressed := make([][]byte, len(records))
+	for i, buf := range records {
+		compressed[i], _ = compressor.Compress(buf)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := compressor.Decompress(compressed[i%len(compressed)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompressor_Compress(b *testing.B)           { benchmarkCompress(b, false) }
+func BenchmarkCompressor_CompressDictionary(b *testing.B) { benchmarkCompress(b, true) }
+func BenchmarkCompressor_Decompress(b *testing.B)         { benchmarkDecompress(b, false) }
+func BenchmarkCompressor_DecompressDictionary(b *testing.B) {
+	benchmarkDecompress(b, true)
+}
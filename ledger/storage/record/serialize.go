@@ -17,12 +17,11 @@
 package record
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 
 	"github.com/insolar/insolar/core"
-	"github.com/ugorji/go/codec"
+	"github.com/insolar/insolar/utils/pool"
 )
 
 // record type ids for record types
@@ -78,6 +77,9 @@ func getRecordByTypeID(id TypeID) Record { // nolint: gocyclo
 	}
 }
 
+// recordSizeHint is expected size of serialized record without code and memory blobs
+const recordSizeHint = 256
+
 // SerializeType returns binary representation of provided type.
 func SerializeType(id TypeID) []byte {
 	buf := make([]byte, TypeIDSize)
@@ -92,19 +94,22 @@ func DeserializeType(buf []byte) TypeID {
 
 // SerializeRecord returns binary representation of provided record.
 func SerializeRecord(rec Record) []byte {
-	typeBytes := SerializeType(rec.Type())
-	buff := bytes.NewBuffer(typeBytes)
-	enc := codec.NewEncoder(buff, &codec.CborHandle{})
-	enc.MustEncode(rec)
-	return buff.Bytes()
+	buf := make([]byte, TypeIDSize, TypeIDSize+recordSizeHint)
+	binary.BigEndian.PutUint32(buf, uint32(rec.Type()))
+	buf, err := pool.MarshalCBOR(buf, rec)
+	if err != nil {
+		panic(err)
+	}
+	return buf
 }
 
 // DeserializeRecord returns record decoded from bytes.
 func DeserializeRecord(buf []byte) Record {
 	t := DeserializeType(buf[:TypeIDSize])
-	dec := codec.NewDecoderBytes(buf[TypeIDSize:], &codec.CborHandle{})
 	rec := getRecordByTypeID(t)
-	dec.MustDecode(&rec)
+	if err := pool.UnmarshalCBOR(buf[TypeIDSize:], &rec); err != nil {
+		panic(err)
+	}
 	return rec
 }
 
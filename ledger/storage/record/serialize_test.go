@@ -70,3 +70,29 @@ func TestSerializeDeserializeRecord(t *testing.T) {
 	deserialized := DeserializeRecord(serialized)
 	assert.Equal(t, rec, *deserialized.(*ObjectActivateRecord))
 }
+
+func BenchmarkSerializeRecord(b *testing.B) {
+	rec := &ObjectActivateRecord{
+		ObjectStateRecord: ObjectStateRecord{
+			Memory: CalculateIDForBlob(platformpolicy.NewPlatformCryptographyScheme(), core.GenesisPulse.PulseNumber, []byte{1, 2, 3}),
+		},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SerializeRecord(rec)
+	}
+}
+
+func BenchmarkDeserializeRecord(b *testing.B) {
+	serialized := SerializeRecord(&ObjectActivateRecord{
+		ObjectStateRecord: ObjectStateRecord{
+			Memory: CalculateIDForBlob(platformpolicy.NewPlatformCryptographyScheme(), core.GenesisPulse.PulseNumber, []byte{1, 2, 3}),
+		},
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DeserializeRecord(serialized)
+	}
+}
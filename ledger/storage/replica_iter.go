@@ -19,9 +19,9 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 
-	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/ledger/storage/jet"
 )
@@ -98,7 +98,8 @@ func (r *ReplicaIter) NextRecords() ([]core.KV, error) {
 		return nil, ErrReplicatorDone
 	}
 	fc := &fetchchunk{
-		db:    r.dbContext.GetBadgerDB(),
+		db:    r.dbContext,
+		kv:    r.dbContext.GetKV(),
 		limit: r.limitBytes,
 	}
 	for _, is := range r.istates {
@@ -123,6 +124,54 @@ func (r *ReplicaIter) LastSeenPulse() core.PulseNumber {
 	return r.lastpulse
 }
 
+// Cursor returns encoded position of iterator, iteration could be continued from it with Seek.
+func (r *ReplicaIter) Cursor() []byte {
+	var buf bytes.Buffer
+	for _, is := range r.istates {
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(is.start)))
+		buf.Write(size[:])
+		buf.Write(is.start)
+	}
+	return buf.Bytes()
+}
+
+// Seek restores position of iterator from cursor returned by Cursor.
+func (r *ReplicaIter) Seek(cursor []byte) error {
+	starts := make([][]byte, 0, len(r.istates))
+	for len(cursor) > 0 {
+		if len(cursor) < 4 {
+			return ErrInvalidCursor
+		}
+		size := int(binary.BigEndian.Uint32(cursor))
+		cursor = cursor[4:]
+		if len(cursor) < size {
+			return ErrInvalidCursor
+		}
+		var start []byte
+		if size > 0 {
+			start = append([]byte{}, cursor[:size]...)
+		}
+		starts = append(starts, start)
+		cursor = cursor[size:]
+	}
+	if len(starts) != len(r.istates) {
+		return ErrInvalidCursor
+	}
+	for i, is := range r.istates {
+		if starts[i] != nil && !bytes.HasPrefix(starts[i], is.prefix) {
+			return ErrInvalidCursor
+		}
+	}
+	for i, is := range r.istates {
+		is.start = starts[i]
+	}
+	return nil
+}
+
+// ErrInvalidCursor is returned by an Replicator Seek method when cursor could not be decoded.
+var ErrInvalidCursor = errors.New("invalid replica iterator cursor")
+
 // ErrReplicatorDone is returned by an Replicator NextRecords method when the iteration is complete.
 var ErrReplicatorDone = errors.New("no more items in iterator")
 
@@ -136,7 +185,8 @@ func (r *ReplicaIter) isDone() bool {
 }
 
 type fetchchunk struct {
-	db      *badger.DB
+	db      DBContext
+	kv      KV
 	records []core.KV
 	size    int
 	limit   int
@@ -154,42 +204,35 @@ func (fc *fetchchunk) fetch(
 
 	var nextstart []byte
 	var lastpulse core.PulseNumber
-	err := fc.db.View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
-
-		for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
-			item := it.Item()
-			if item == nil {
-				break
-			}
+	err := fc.kv.View(func(txn KVTxn) error {
+		return txn.Iterate(prefix, start, func(key, value []byte) (bool, error) {
 			// key prefix < end
-			if bytes.Compare(item.Key()[:len(end)], end) != -1 {
-				break
+			if bytes.Compare(key[:len(end)], end) != -1 {
+				return false, nil
 			}
 
-			key := item.KeyCopy(nil)
 			if fc.size > fc.limit {
 				nextstart = key
 				// inslogger.FromContext(ctx).Warnf("size > r.limit: %v > %v (nextstart=%v)",
 				// 	fc.size, fc.limit, hex.EncodeToString(key))
-				return nil
+				return false, nil
 			}
 
 			lastpulse = pulseFromKey(key)
-			// fmt.Printf("Replica> key: %v (pulse=%v)\n", hex.EncodeToString(key), lastpulse)
-
-			value, err := it.Item().ValueCopy(nil)
-			if err != nil {
-				return err
+			// records are sent uncompressed, heavy compresses them with its own dictionaries
+			if key[0] == scopeIDRecord {
+				var err error
+				if value, err = decodeRecordValue(fc.db, value); err != nil {
+					return false, err
+				}
 			}
+			// fmt.Printf("Replica> key: %v (pulse=%v)\n", hex.EncodeToString(key), lastpulse)
 
 			NullifyJetInKey(key)
 			fc.records = append(fc.records, core.KV{K: key, V: value})
 			fc.size += len(key) + len(value)
-		}
-		nextstart = nil
-		return nil
+			return true, nil
+		})
 	})
 	return nextstart, lastpulse, err
 }
@@ -371,6 +371,48 @@ func setDrop(
 	require.NoError(t, err)
 }
 
+func (s *replicaIterSuite) Test_ReplicaIter_CursorSeek() {
+	jetID := *jet.NewID(0, nil)
+	for i := 0; i < 3; i++ {
+		addRecords(s.ctx, s.T(), s.objectStorage, jetID, core.FirstPulseNumber)
+	}
+
+	fetchall := func(replicator *storage.ReplicaIter) (got []key) {
+		for i := 0; ; i++ {
+			if i > 50 {
+				s.T().Fatal("too many loops")
+			}
+			recs, err := replicator.NextRecords()
+			if err == storage.ErrReplicatorDone {
+				return
+			}
+			require.NoError(s.T(), err)
+			for _, rec := range recs {
+				got = append(got, rec.K)
+			}
+		}
+	}
+
+	expected := fetchall(storage.NewReplicaIter(s.ctx, s.db, jetID, core.FirstPulseNumber, core.FirstPulseNumber+1, 100500))
+
+	replicator := storage.NewReplicaIter(s.ctx, s.db, jetID, core.FirstPulseNumber, core.FirstPulseNumber+1, 100)
+	recs, err := replicator.NextRecords()
+	require.NoError(s.T(), err)
+	var got []key
+	for _, rec := range recs {
+		got = append(got, rec.K)
+	}
+	cursor := replicator.Cursor()
+
+	resumed := storage.NewReplicaIter(s.ctx, s.db, jetID, core.FirstPulseNumber, core.FirstPulseNumber+1, 100)
+	require.NoError(s.T(), resumed.Seek(cursor))
+	got = append(got, fetchall(resumed)...)
+	require.Equal(s.T(), sortkeys(expected), sortkeys(got), "resumed iterator returns remaining records")
+
+	require.Equal(s.T(), storage.ErrInvalidCursor, resumed.Seek([]byte{0, 0, 0, 42}))
+	require.Equal(s.T(), storage.ErrInvalidCursor, resumed.Seek(cursor[:len(cursor)-4]))
+}
+
 func addRecords(
 	ctx context.Context,
 	t *testing.T,
@@ -30,21 +30,41 @@ type ReplicaStorageMock struct {
 	GetAllSyncClientJetsPreCounter uint64
 	GetAllSyncClientJetsMock       mReplicaStorageMockGetAllSyncClientJets
 
+	GetHeavySyncCheckpointFunc       func(p context.Context, p1 core.RecordID) (r *ReplicaCheckpoint, r1 error)
+	GetHeavySyncCheckpointCounter    uint64
+	GetHeavySyncCheckpointPreCounter uint64
+	GetHeavySyncCheckpointMock       mReplicaStorageMockGetHeavySyncCheckpoint
+
 	GetHeavySyncedPulseFunc       func(p context.Context, p1 core.RecordID) (r core.PulseNumber, r1 error)
 	GetHeavySyncedPulseCounter    uint64
 	GetHeavySyncedPulsePreCounter uint64
 	GetHeavySyncedPulseMock       mReplicaStorageMockGetHeavySyncedPulse
 
+	GetSyncClientCheckpointFunc       func(p context.Context, p1 core.RecordID) (r *ReplicaCheckpoint, r1 error)
+	GetSyncClientCheckpointCounter    uint64
+	GetSyncClientCheckpointPreCounter uint64
+	GetSyncClientCheckpointMock       mReplicaStorageMockGetSyncClientCheckpoint
+
 	GetSyncClientJetPulsesFunc       func(p context.Context, p1 core.RecordID) (r []core.PulseNumber, r1 error)
 	GetSyncClientJetPulsesCounter    uint64
 	GetSyncClientJetPulsesPreCounter uint64
 	GetSyncClientJetPulsesMock       mReplicaStorageMockGetSyncClientJetPulses
 
+	SetHeavySyncCheckpointFunc       func(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) (r error)
+	SetHeavySyncCheckpointCounter    uint64
+	SetHeavySyncCheckpointPreCounter uint64
+	SetHeavySyncCheckpointMock       mReplicaStorageMockSetHeavySyncCheckpoint
+
 	SetHeavySyncedPulseFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)
 	SetHeavySyncedPulseCounter    uint64
 	SetHeavySyncedPulsePreCounter uint64
 	SetHeavySyncedPulseMock       mReplicaStorageMockSetHeavySyncedPulse
 
+	SetSyncClientCheckpointFunc       func(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) (r error)
+	SetSyncClientCheckpointCounter    uint64
+	SetSyncClientCheckpointPreCounter uint64
+	SetSyncClientCheckpointMock       mReplicaStorageMockSetSyncClientCheckpoint
+
 	SetSyncClientJetPulsesFunc       func(p context.Context, p1 core.RecordID, p2 []core.PulseNumber) (r error)
 	SetSyncClientJetPulsesCounter    uint64
 	SetSyncClientJetPulsesPreCounter uint64
@@ -61,9 +81,13 @@ func NewReplicaStorageMock(t minimock.Tester) *ReplicaStorageMock {
 
 	m.GetAllNonEmptySyncClientJetsMock = mReplicaStorageMockGetAllNonEmptySyncClientJets{mock: m}
 	m.GetAllSyncClientJetsMock = mReplicaStorageMockGetAllSyncClientJets{mock: m}
+	m.GetHeavySyncCheckpointMock = mReplicaStorageMockGetHeavySyncCheckpoint{mock: m}
 	m.GetHeavySyncedPulseMock = mReplicaStorageMockGetHeavySyncedPulse{mock: m}
+	m.GetSyncClientCheckpointMock = mReplicaStorageMockGetSyncClientCheckpoint{mock: m}
 	m.GetSyncClientJetPulsesMock = mReplicaStorageMockGetSyncClientJetPulses{mock: m}
+	m.SetHeavySyncCheckpointMock = mReplicaStorageMockSetHeavySyncCheckpoint{mock: m}
 	m.SetHeavySyncedPulseMock = mReplicaStorageMockSetHeavySyncedPulse{mock: m}
+	m.SetSyncClientCheckpointMock = mReplicaStorageMockSetSyncClientCheckpoint{mock: m}
 	m.SetSyncClientJetPulsesMock = mReplicaStorageMockSetSyncClientJetPulses{mock: m}
 
 	return m
@@ -369,6 +393,157 @@ func (m *ReplicaStorageMock) GetAllSyncClientJetsFinished() bool {
 	return true
 }
 
+type mReplicaStorageMockGetHeavySyncCheckpoint struct {
+	mock              *ReplicaStorageMock
+	mainExpectation   *ReplicaStorageMockGetHeavySyncCheckpointExpectation
+	expectationSeries []*ReplicaStorageMockGetHeavySyncCheckpointExpectation
+}
+
+type ReplicaStorageMockGetHeavySyncCheckpointExpectation struct {
+	input  *ReplicaStorageMockGetHeavySyncCheckpointInput
+	result *ReplicaStorageMockGetHeavySyncCheckpointResult
+}
+
+type ReplicaStorageMockGetHeavySyncCheckpointInput struct {
+	p  context.Context
+	p1 core.RecordID
+}
+
+type ReplicaStorageMockGetHeavySyncCheckpointResult struct {
+	r  *ReplicaCheckpoint
+	r1 error
+}
+
+//Expect specifies that invocation of ReplicaStorage.GetHeavySyncCheckpoint is expected from 1 to Infinity times
+func (m *mReplicaStorageMockGetHeavySyncCheckpoint) Expect(p context.Context, p1 core.RecordID) *mReplicaStorageMockGetHeavySyncCheckpoint {
+	m.mock.GetHeavySyncCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockGetHeavySyncCheckpointExpectation{}
+	}
+	m.mainExpectation.input = &ReplicaStorageMockGetHeavySyncCheckpointInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of ReplicaStorage.GetHeavySyncCheckpoint
+func (m *mReplicaStorageMockGetHeavySyncCheckpoint) Return(r *ReplicaCheckpoint, r1 error) *ReplicaStorageMock {
+	m.mock.GetHeavySyncCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockGetHeavySyncCheckpointExpectation{}
+	}
+	m.mainExpectation.result = &ReplicaStorageMockGetHeavySyncCheckpointResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ReplicaStorage.GetHeavySyncCheckpoint is expected once
+func (m *mReplicaStorageMockGetHeavySyncCheckpoint) ExpectOnce(p context.Context, p1 core.RecordID) *ReplicaStorageMockGetHeavySyncCheckpointExpectation {
+	m.mock.GetHeavySyncCheckpointFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ReplicaStorageMockGetHeavySyncCheckpointExpectation{}
+	expectation.input = &ReplicaStorageMockGetHeavySyncCheckpointInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ReplicaStorageMockGetHeavySyncCheckpointExpectation) Return(r *ReplicaCheckpoint, r1 error) {
+	e.result = &ReplicaStorageMockGetHeavySyncCheckpointResult{r, r1}
+}
+
+//Set uses given function f as a mock of ReplicaStorage.GetHeavySyncCheckpoint method
+func (m *mReplicaStorageMockGetHeavySyncCheckpoint) Set(f func(p context.Context, p1 core.RecordID) (r *ReplicaCheckpoint, r1 error)) *ReplicaStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetHeavySyncCheckpointFunc = f
+	return m.mock
+}
+
+//GetHeavySyncCheckpoint implements github.com/insolar/insolar/ledger/storage.ReplicaStorage interface
+func (m *ReplicaStorageMock) GetHeavySyncCheckpoint(p context.Context, p1 core.RecordID) (r *ReplicaCheckpoint, r1 error) {
+	counter := atomic.AddUint64(&m.GetHeavySyncCheckpointPreCounter, 1)
+	defer atomic.AddUint64(&m.GetHeavySyncCheckpointCounter, 1)
+
+	if len(m.GetHeavySyncCheckpointMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetHeavySyncCheckpointMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ReplicaStorageMock.GetHeavySyncCheckpoint. %v %v", p, p1)
+			return
+		}
+
+		input := m.GetHeavySyncCheckpointMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ReplicaStorageMockGetHeavySyncCheckpointInput{p, p1}, "ReplicaStorage.GetHeavySyncCheckpoint got unexpected parameters")
+
+		result := m.GetHeavySyncCheckpointMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.GetHeavySyncCheckpoint")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetHeavySyncCheckpointMock.mainExpectation != nil {
+
+		input := m.GetHeavySyncCheckpointMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ReplicaStorageMockGetHeavySyncCheckpointInput{p, p1}, "ReplicaStorage.GetHeavySyncCheckpoint got unexpected parameters")
+		}
+
+		result := m.GetHeavySyncCheckpointMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.GetHeavySyncCheckpoint")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetHeavySyncCheckpointFunc == nil {
+		m.t.Fatalf("Unexpected call to ReplicaStorageMock.GetHeavySyncCheckpoint. %v %v", p, p1)
+		return
+	}
+
+	return m.GetHeavySyncCheckpointFunc(p, p1)
+}
+
+//GetHeavySyncCheckpointMinimockCounter returns a count of ReplicaStorageMock.GetHeavySyncCheckpointFunc invocations
+func (m *ReplicaStorageMock) GetHeavySyncCheckpointMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetHeavySyncCheckpointCounter)
+}
+
+//GetHeavySyncCheckpointMinimockPreCounter returns the value of ReplicaStorageMock.GetHeavySyncCheckpoint invocations
+func (m *ReplicaStorageMock) GetHeavySyncCheckpointMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetHeavySyncCheckpointPreCounter)
+}
+
+//GetHeavySyncCheckpointFinished returns true if mock invocations count is ok
+func (m *ReplicaStorageMock) GetHeavySyncCheckpointFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetHeavySyncCheckpointMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetHeavySyncCheckpointCounter) == uint64(len(m.GetHeavySyncCheckpointMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetHeavySyncCheckpointMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetHeavySyncCheckpointCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetHeavySyncCheckpointFunc != nil {
+		return atomic.LoadUint64(&m.GetHeavySyncCheckpointCounter) > 0
+	}
+
+	return true
+}
+
 type mReplicaStorageMockGetHeavySyncedPulse struct {
 	mock              *ReplicaStorageMock
 	mainExpectation   *ReplicaStorageMockGetHeavySyncedPulseExpectation
@@ -520,6 +695,157 @@ func (m *ReplicaStorageMock) GetHeavySyncedPulseFinished() bool {
 	return true
 }
 
+type mReplicaStorageMockGetSyncClientCheckpoint struct {
+	mock              *ReplicaStorageMock
+	mainExpectation   *ReplicaStorageMockGetSyncClientCheckpointExpectation
+	expectationSeries []*ReplicaStorageMockGetSyncClientCheckpointExpectation
+}
+
+type ReplicaStorageMockGetSyncClientCheckpointExpectation struct {
+	input  *ReplicaStorageMockGetSyncClientCheckpointInput
+	result *ReplicaStorageMockGetSyncClientCheckpointResult
+}
+
+type ReplicaStorageMockGetSyncClientCheckpointInput struct {
+	p  context.Context
+	p1 core.RecordID
+}
+
+type ReplicaStorageMockGetSyncClientCheckpointResult struct {
+	r  *ReplicaCheckpoint
+	r1 error
+}
+
+//Expect specifies that invocation of ReplicaStorage.GetSyncClientCheckpoint is expected from 1 to Infinity times
+func (m *mReplicaStorageMockGetSyncClientCheckpoint) Expect(p context.Context, p1 core.RecordID) *mReplicaStorageMockGetSyncClientCheckpoint {
+	m.mock.GetSyncClientCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockGetSyncClientCheckpointExpectation{}
+	}
+	m.mainExpectation.input = &ReplicaStorageMockGetSyncClientCheckpointInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of ReplicaStorage.GetSyncClientCheckpoint
+func (m *mReplicaStorageMockGetSyncClientCheckpoint) Return(r *ReplicaCheckpoint, r1 error) *ReplicaStorageMock {
+	m.mock.GetSyncClientCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockGetSyncClientCheckpointExpectation{}
+	}
+	m.mainExpectation.result = &ReplicaStorageMockGetSyncClientCheckpointResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ReplicaStorage.GetSyncClientCheckpoint is expected once
+func (m *mReplicaStorageMockGetSyncClientCheckpoint) ExpectOnce(p context.Context, p1 core.RecordID) *ReplicaStorageMockGetSyncClientCheckpointExpectation {
+	m.mock.GetSyncClientCheckpointFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ReplicaStorageMockGetSyncClientCheckpointExpectation{}
+	expectation.input = &ReplicaStorageMockGetSyncClientCheckpointInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ReplicaStorageMockGetSyncClientCheckpointExpectation) Return(r *ReplicaCheckpoint, r1 error) {
+	e.result = &ReplicaStorageMockGetSyncClientCheckpointResult{r, r1}
+}
+
+//Set uses given function f as a mock of ReplicaStorage.GetSyncClientCheckpoint method
+func (m *mReplicaStorageMockGetSyncClientCheckpoint) Set(f func(p context.Context, p1 core.RecordID) (r *ReplicaCheckpoint, r1 error)) *ReplicaStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetSyncClientCheckpointFunc = f
+	return m.mock
+}
+
+//GetSyncClientCheckpoint implements github.com/insolar/insolar/ledger/storage.ReplicaStorage interface
+func (m *ReplicaStorageMock) GetSyncClientCheckpoint(p context.Context, p1 core.RecordID) (r *ReplicaCheckpoint, r1 error) {
+	counter := atomic.AddUint64(&m.GetSyncClientCheckpointPreCounter, 1)
+	defer atomic.AddUint64(&m.GetSyncClientCheckpointCounter, 1)
+
+	if len(m.GetSyncClientCheckpointMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetSyncClientCheckpointMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ReplicaStorageMock.GetSyncClientCheckpoint. %v %v", p, p1)
+			return
+		}
+
+		input := m.GetSyncClientCheckpointMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ReplicaStorageMockGetSyncClientCheckpointInput{p, p1}, "ReplicaStorage.GetSyncClientCheckpoint got unexpected parameters")
+
+		result := m.GetSyncClientCheckpointMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.GetSyncClientCheckpoint")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetSyncClientCheckpointMock.mainExpectation != nil {
+
+		input := m.GetSyncClientCheckpointMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ReplicaStorageMockGetSyncClientCheckpointInput{p, p1}, "ReplicaStorage.GetSyncClientCheckpoint got unexpected parameters")
+		}
+
+		result := m.GetSyncClientCheckpointMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.GetSyncClientCheckpoint")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetSyncClientCheckpointFunc == nil {
+		m.t.Fatalf("Unexpected call to ReplicaStorageMock.GetSyncClientCheckpoint. %v %v", p, p1)
+		return
+	}
+
+	return m.GetSyncClientCheckpointFunc(p, p1)
+}
+
+//GetSyncClientCheckpointMinimockCounter returns a count of ReplicaStorageMock.GetSyncClientCheckpointFunc invocations
+func (m *ReplicaStorageMock) GetSyncClientCheckpointMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetSyncClientCheckpointCounter)
+}
+
+//GetSyncClientCheckpointMinimockPreCounter returns the value of ReplicaStorageMock.GetSyncClientCheckpoint invocations
+func (m *ReplicaStorageMock) GetSyncClientCheckpointMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetSyncClientCheckpointPreCounter)
+}
+
+//GetSyncClientCheckpointFinished returns true if mock invocations count is ok
+func (m *ReplicaStorageMock) GetSyncClientCheckpointFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetSyncClientCheckpointMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetSyncClientCheckpointCounter) == uint64(len(m.GetSyncClientCheckpointMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetSyncClientCheckpointMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetSyncClientCheckpointCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetSyncClientCheckpointFunc != nil {
+		return atomic.LoadUint64(&m.GetSyncClientCheckpointCounter) > 0
+	}
+
+	return true
+}
+
 type mReplicaStorageMockGetSyncClientJetPulses struct {
 	mock              *ReplicaStorageMock
 	mainExpectation   *ReplicaStorageMockGetSyncClientJetPulsesExpectation
@@ -671,6 +997,155 @@ func (m *ReplicaStorageMock) GetSyncClientJetPulsesFinished() bool {
 	return true
 }
 
+type mReplicaStorageMockSetHeavySyncCheckpoint struct {
+	mock              *ReplicaStorageMock
+	mainExpectation   *ReplicaStorageMockSetHeavySyncCheckpointExpectation
+	expectationSeries []*ReplicaStorageMockSetHeavySyncCheckpointExpectation
+}
+
+type ReplicaStorageMockSetHeavySyncCheckpointExpectation struct {
+	input  *ReplicaStorageMockSetHeavySyncCheckpointInput
+	result *ReplicaStorageMockSetHeavySyncCheckpointResult
+}
+
+type ReplicaStorageMockSetHeavySyncCheckpointInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 *ReplicaCheckpoint
+}
+
+type ReplicaStorageMockSetHeavySyncCheckpointResult struct {
+	r error
+}
+
+//Expect specifies that invocation of ReplicaStorage.SetHeavySyncCheckpoint is expected from 1 to Infinity times
+func (m *mReplicaStorageMockSetHeavySyncCheckpoint) Expect(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) *mReplicaStorageMockSetHeavySyncCheckpoint {
+	m.mock.SetHeavySyncCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockSetHeavySyncCheckpointExpectation{}
+	}
+	m.mainExpectation.input = &ReplicaStorageMockSetHeavySyncCheckpointInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of ReplicaStorage.SetHeavySyncCheckpoint
+func (m *mReplicaStorageMockSetHeavySyncCheckpoint) Return(r error) *ReplicaStorageMock {
+	m.mock.SetHeavySyncCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockSetHeavySyncCheckpointExpectation{}
+	}
+	m.mainExpectation.result = &ReplicaStorageMockSetHeavySyncCheckpointResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ReplicaStorage.SetHeavySyncCheckpoint is expected once
+func (m *mReplicaStorageMockSetHeavySyncCheckpoint) ExpectOnce(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) *ReplicaStorageMockSetHeavySyncCheckpointExpectation {
+	m.mock.SetHeavySyncCheckpointFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ReplicaStorageMockSetHeavySyncCheckpointExpectation{}
+	expectation.input = &ReplicaStorageMockSetHeavySyncCheckpointInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ReplicaStorageMockSetHeavySyncCheckpointExpectation) Return(r error) {
+	e.result = &ReplicaStorageMockSetHeavySyncCheckpointResult{r}
+}
+
+//Set uses given function f as a mock of ReplicaStorage.SetHeavySyncCheckpoint method
+func (m *mReplicaStorageMockSetHeavySyncCheckpoint) Set(f func(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) (r error)) *ReplicaStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SetHeavySyncCheckpointFunc = f
+	return m.mock
+}
+
+//SetHeavySyncCheckpoint implements github.com/insolar/insolar/ledger/storage.ReplicaStorage interface
+func (m *ReplicaStorageMock) SetHeavySyncCheckpoint(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) (r error) {
+	counter := atomic.AddUint64(&m.SetHeavySyncCheckpointPreCounter, 1)
+	defer atomic.AddUint64(&m.SetHeavySyncCheckpointCounter, 1)
+
+	if len(m.SetHeavySyncCheckpointMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SetHeavySyncCheckpointMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ReplicaStorageMock.SetHeavySyncCheckpoint. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.SetHeavySyncCheckpointMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ReplicaStorageMockSetHeavySyncCheckpointInput{p, p1, p2}, "ReplicaStorage.SetHeavySyncCheckpoint got unexpected parameters")
+
+		result := m.SetHeavySyncCheckpointMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.SetHeavySyncCheckpoint")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SetHeavySyncCheckpointMock.mainExpectation != nil {
+
+		input := m.SetHeavySyncCheckpointMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ReplicaStorageMockSetHeavySyncCheckpointInput{p, p1, p2}, "ReplicaStorage.SetHeavySyncCheckpoint got unexpected parameters")
+		}
+
+		result := m.SetHeavySyncCheckpointMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.SetHeavySyncCheckpoint")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SetHeavySyncCheckpointFunc == nil {
+		m.t.Fatalf("Unexpected call to ReplicaStorageMock.SetHeavySyncCheckpoint. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.SetHeavySyncCheckpointFunc(p, p1, p2)
+}
+
+//SetHeavySyncCheckpointMinimockCounter returns a count of ReplicaStorageMock.SetHeavySyncCheckpointFunc invocations
+func (m *ReplicaStorageMock) SetHeavySyncCheckpointMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetHeavySyncCheckpointCounter)
+}
+
+//SetHeavySyncCheckpointMinimockPreCounter returns the value of ReplicaStorageMock.SetHeavySyncCheckpoint invocations
+func (m *ReplicaStorageMock) SetHeavySyncCheckpointMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetHeavySyncCheckpointPreCounter)
+}
+
+//SetHeavySyncCheckpointFinished returns true if mock invocations count is ok
+func (m *ReplicaStorageMock) SetHeavySyncCheckpointFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SetHeavySyncCheckpointMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SetHeavySyncCheckpointCounter) == uint64(len(m.SetHeavySyncCheckpointMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SetHeavySyncCheckpointMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SetHeavySyncCheckpointCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SetHeavySyncCheckpointFunc != nil {
+		return atomic.LoadUint64(&m.SetHeavySyncCheckpointCounter) > 0
+	}
+
+	return true
+}
+
 type mReplicaStorageMockSetHeavySyncedPulse struct {
 	mock              *ReplicaStorageMock
 	mainExpectation   *ReplicaStorageMockSetHeavySyncedPulseExpectation
@@ -820,6 +1295,155 @@ func (m *ReplicaStorageMock) SetHeavySyncedPulseFinished() bool {
 	return true
 }
 
+type mReplicaStorageMockSetSyncClientCheckpoint struct {
+	mock              *ReplicaStorageMock
+	mainExpectation   *ReplicaStorageMockSetSyncClientCheckpointExpectation
+	expectationSeries []*ReplicaStorageMockSetSyncClientCheckpointExpectation
+}
+
+type ReplicaStorageMockSetSyncClientCheckpointExpectation struct {
+	input  *ReplicaStorageMockSetSyncClientCheckpointInput
+	result *ReplicaStorageMockSetSyncClientCheckpointResult
+}
+
+type ReplicaStorageMockSetSyncClientCheckpointInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 *ReplicaCheckpoint
+}
+
+type ReplicaStorageMockSetSyncClientCheckpointResult struct {
+	r error
+}
+
+//Expect specifies that invocation of ReplicaStorage.SetSyncClientCheckpoint is expected from 1 to Infinity times
+func (m *mReplicaStorageMockSetSyncClientCheckpoint) Expect(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) *mReplicaStorageMockSetSyncClientCheckpoint {
+	m.mock.SetSyncClientCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockSetSyncClientCheckpointExpectation{}
+	}
+	m.mainExpectation.input = &ReplicaStorageMockSetSyncClientCheckpointInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of ReplicaStorage.SetSyncClientCheckpoint
+func (m *mReplicaStorageMockSetSyncClientCheckpoint) Return(r error) *ReplicaStorageMock {
+	m.mock.SetSyncClientCheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ReplicaStorageMockSetSyncClientCheckpointExpectation{}
+	}
+	m.mainExpectation.result = &ReplicaStorageMockSetSyncClientCheckpointResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ReplicaStorage.SetSyncClientCheckpoint is expected once
+func (m *mReplicaStorageMockSetSyncClientCheckpoint) ExpectOnce(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) *ReplicaStorageMockSetSyncClientCheckpointExpectation {
+	m.mock.SetSyncClientCheckpointFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ReplicaStorageMockSetSyncClientCheckpointExpectation{}
+	expectation.input = &ReplicaStorageMockSetSyncClientCheckpointInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ReplicaStorageMockSetSyncClientCheckpointExpectation) Return(r error) {
+	e.result = &ReplicaStorageMockSetSyncClientCheckpointResult{r}
+}
+
+//Set uses given function f as a mock of ReplicaStorage.SetSyncClientCheckpoint method
+func (m *mReplicaStorageMockSetSyncClientCheckpoint) Set(f func(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) (r error)) *ReplicaStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SetSyncClientCheckpointFunc = f
+	return m.mock
+}
+
+//SetSyncClientCheckpoint implements github.com/insolar/insolar/ledger/storage.ReplicaStorage interface
+func (m *ReplicaStorageMock) SetSyncClientCheckpoint(p context.Context, p1 core.RecordID, p2 *ReplicaCheckpoint) (r error) {
+	counter := atomic.AddUint64(&m.SetSyncClientCheckpointPreCounter, 1)
+	defer atomic.AddUint64(&m.SetSyncClientCheckpointCounter, 1)
+
+	if len(m.SetSyncClientCheckpointMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SetSyncClientCheckpointMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ReplicaStorageMock.SetSyncClientCheckpoint. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.SetSyncClientCheckpointMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ReplicaStorageMockSetSyncClientCheckpointInput{p, p1, p2}, "ReplicaStorage.SetSyncClientCheckpoint got unexpected parameters")
+
+		result := m.SetSyncClientCheckpointMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.SetSyncClientCheckpoint")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SetSyncClientCheckpointMock.mainExpectation != nil {
+
+		input := m.SetSyncClientCheckpointMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ReplicaStorageMockSetSyncClientCheckpointInput{p, p1, p2}, "ReplicaStorage.SetSyncClientCheckpoint got unexpected parameters")
+		}
+
+		result := m.SetSyncClientCheckpointMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ReplicaStorageMock.SetSyncClientCheckpoint")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SetSyncClientCheckpointFunc == nil {
+		m.t.Fatalf("Unexpected call to ReplicaStorageMock.SetSyncClientCheckpoint. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.SetSyncClientCheckpointFunc(p, p1, p2)
+}
+
+//SetSyncClientCheckpointMinimockCounter returns a count of ReplicaStorageMock.SetSyncClientCheckpointFunc invocations
+func (m *ReplicaStorageMock) SetSyncClientCheckpointMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetSyncClientCheckpointCounter)
+}
+
+//SetSyncClientCheckpointMinimockPreCounter returns the value of ReplicaStorageMock.SetSyncClientCheckpoint invocations
+func (m *ReplicaStorageMock) SetSyncClientCheckpointMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetSyncClientCheckpointPreCounter)
+}
+
+//SetSyncClientCheckpointFinished returns true if mock invocations count is ok
+func (m *ReplicaStorageMock) SetSyncClientCheckpointFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SetSyncClientCheckpointMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SetSyncClientCheckpointCounter) == uint64(len(m.SetSyncClientCheckpointMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SetSyncClientCheckpointMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SetSyncClientCheckpointCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SetSyncClientCheckpointFunc != nil {
+		return atomic.LoadUint64(&m.SetSyncClientCheckpointCounter) > 0
+	}
+
+	return true
+}
+
 type mReplicaStorageMockSetSyncClientJetPulses struct {
 	mock              *ReplicaStorageMock
 	mainExpectation   *ReplicaStorageMockSetSyncClientJetPulsesExpectation
@@ -981,18 +1605,34 @@ func (m *ReplicaStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.GetAllSyncClientJets")
 	}
 
+	if !m.GetHeavySyncCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.GetHeavySyncCheckpoint")
+	}
+
 	if !m.GetHeavySyncedPulseFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.GetHeavySyncedPulse")
 	}
 
+	if !m.GetSyncClientCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.GetSyncClientCheckpoint")
+	}
+
 	if !m.GetSyncClientJetPulsesFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.GetSyncClientJetPulses")
 	}
 
+	if !m.SetHeavySyncCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.SetHeavySyncCheckpoint")
+	}
+
 	if !m.SetHeavySyncedPulseFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.SetHeavySyncedPulse")
 	}
 
+	if !m.SetSyncClientCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.SetSyncClientCheckpoint")
+	}
+
 	if !m.SetSyncClientJetPulsesFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.SetSyncClientJetPulses")
 	}
@@ -1022,18 +1662,34 @@ func (m *ReplicaStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.GetAllSyncClientJets")
 	}
 
+	if !m.GetHeavySyncCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.GetHeavySyncCheckpoint")
+	}
+
 	if !m.GetHeavySyncedPulseFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.GetHeavySyncedPulse")
 	}
 
+	if !m.GetSyncClientCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.GetSyncClientCheckpoint")
+	}
+
 	if !m.GetSyncClientJetPulsesFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.GetSyncClientJetPulses")
 	}
 
+	if !m.SetHeavySyncCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.SetHeavySyncCheckpoint")
+	}
+
 	if !m.SetHeavySyncedPulseFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.SetHeavySyncedPulse")
 	}
 
+	if !m.SetSyncClientCheckpointFinished() {
+		m.t.Fatal("Expected call to ReplicaStorageMock.SetSyncClientCheckpoint")
+	}
+
 	if !m.SetSyncClientJetPulsesFinished() {
 		m.t.Fatal("Expected call to ReplicaStorageMock.SetSyncClientJetPulses")
 	}
@@ -1054,9 +1710,13 @@ func (m *ReplicaStorageMock) MinimockWait(timeout time.Duration) {
 		ok := true
 		ok = ok && m.GetAllNonEmptySyncClientJetsFinished()
 		ok = ok && m.GetAllSyncClientJetsFinished()
+		ok = ok && m.GetHeavySyncCheckpointFinished()
 		ok = ok && m.GetHeavySyncedPulseFinished()
+		ok = ok && m.GetSyncClientCheckpointFinished()
 		ok = ok && m.GetSyncClientJetPulsesFinished()
+		ok = ok && m.SetHeavySyncCheckpointFinished()
 		ok = ok && m.SetHeavySyncedPulseFinished()
+		ok = ok && m.SetSyncClientCheckpointFinished()
 		ok = ok && m.SetSyncClientJetPulsesFinished()
 
 		if ok {
@@ -1074,18 +1734,34 @@ func (m *ReplicaStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ReplicaStorageMock.GetAllSyncClientJets")
 			}
 
+			if !m.GetHeavySyncCheckpointFinished() {
+				m.t.Error("Expected call to ReplicaStorageMock.GetHeavySyncCheckpoint")
+			}
+
 			if !m.GetHeavySyncedPulseFinished() {
 				m.t.Error("Expected call to ReplicaStorageMock.GetHeavySyncedPulse")
 			}
 
+			if !m.GetSyncClientCheckpointFinished() {
+				m.t.Error("Expected call to ReplicaStorageMock.GetSyncClientCheckpoint")
+			}
+
 			if !m.GetSyncClientJetPulsesFinished() {
 				m.t.Error("Expected call to ReplicaStorageMock.GetSyncClientJetPulses")
 			}
 
+			if !m.SetHeavySyncCheckpointFinished() {
+				m.t.Error("Expected call to ReplicaStorageMock.SetHeavySyncCheckpoint")
+			}
+
 			if !m.SetHeavySyncedPulseFinished() {
 				m.t.Error("Expected call to ReplicaStorageMock.SetHeavySyncedPulse")
 			}
 
+			if !m.SetSyncClientCheckpointFinished() {
+				m.t.Error("Expected call to ReplicaStorageMock.SetSyncClientCheckpoint")
+			}
+
 			if !m.SetSyncClientJetPulsesFinished() {
 				m.t.Error("Expected call to ReplicaStorageMock.SetSyncClientJetPulses")
 			}
@@ -1110,18 +1786,34 @@ func (m *ReplicaStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetHeavySyncCheckpointFinished() {
+		return false
+	}
+
 	if !m.GetHeavySyncedPulseFinished() {
 		return false
 	}
 
+	if !m.GetSyncClientCheckpointFinished() {
+		return false
+	}
+
 	if !m.GetSyncClientJetPulsesFinished() {
 		return false
 	}
 
+	if !m.SetHeavySyncCheckpointFinished() {
+		return false
+	}
+
 	if !m.SetHeavySyncedPulseFinished() {
 		return false
 	}
 
+	if !m.SetSyncClientCheckpointFinished() {
+		return false
+	}
+
 	if !m.SetSyncClientJetPulsesFinished() {
 		return false
 	}
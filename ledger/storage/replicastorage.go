@@ -22,7 +22,6 @@ import (
 	"encoding/gob"
 	"io"
 
-	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/core"
 	"github.com/pkg/errors"
 )
@@ -36,6 +35,19 @@ type ReplicaStorage interface {
 	SetSyncClientJetPulses(ctx context.Context, jetID core.RecordID, pns []core.PulseNumber) error
 	GetAllSyncClientJets(ctx context.Context) (map[core.RecordID][]core.PulseNumber, error)
 	GetAllNonEmptySyncClientJets(ctx context.Context) (map[core.RecordID][]core.PulseNumber, error)
+
+	SetHeavySyncCheckpoint(ctx context.Context, jetID core.RecordID, checkpoint *ReplicaCheckpoint) error
+	GetHeavySyncCheckpoint(ctx context.Context, jetID core.RecordID) (*ReplicaCheckpoint, error)
+	SetSyncClientCheckpoint(ctx context.Context, jetID core.RecordID, checkpoint *ReplicaCheckpoint) error
+	GetSyncClientCheckpoint(ctx context.Context, jetID core.RecordID) (*ReplicaCheckpoint, error)
+}
+
+// ReplicaCheckpoint is position of replication of jet pulse to heavy, replication interrupted in the middle of
+// pulse is resumed from it.
+type ReplicaCheckpoint struct {
+	Pulse core.PulseNumber
+	// Cursor is position of ReplicaIter after the last payload stored on heavy, nil if no payload is stored yet.
+	Cursor []byte
 }
 
 type replicaStorage struct {
@@ -104,30 +116,15 @@ func (rs *replicaStorage) SetSyncClientJetPulses(ctx context.Context, jetID core
 // GetAllSyncClientJets returns map of all jet's processed by node.
 func (rs *replicaStorage) GetAllSyncClientJets(ctx context.Context) (map[core.RecordID][]core.PulseNumber, error) {
 	jets := map[core.RecordID][]core.PulseNumber{}
-	err := rs.DB.GetBadgerDB().View(func(txn *badger.Txn) error {
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
-
-		for it.Seek(sysHeavyClientStatePrefix); it.ValidForPrefix(sysHeavyClientStatePrefix); it.Next() {
-			item := it.Item()
-			if item == nil {
-				break
-			}
-			key := item.Key()
-			value, err := it.Item().Value()
-			if err != nil {
-				return err
-			}
-			syncPulses, err := decodePulsesList(bytes.NewReader(value))
-			if err != nil {
-				return err
-			}
-
-			var jetID core.RecordID
-			offset := len(sysHeavyClientStatePrefix)
-			copy(jetID[:], key[offset:offset+len(jetID)])
-			jets[jetID] = syncPulses
+	err := rs.DB.iterate(ctx, sysHeavyClientStatePrefix, func(k, v []byte) error {
+		syncPulses, err := decodePulsesList(bytes.NewReader(v))
+		if err != nil {
+			return err
 		}
+
+		var jetID core.RecordID
+		copy(jetID[:], k)
+		jets[jetID] = syncPulses
 		return nil
 	})
 	if err != nil {
@@ -149,3 +146,51 @@ func (rs *replicaStorage) GetAllNonEmptySyncClientJets(ctx context.Context) (map
 	}
 	return states, nil
 }
+
+// SetHeavySyncCheckpoint saves position of jet replication stored on heavy node, nil checkpoint removes it.
+func (rs *replicaStorage) SetHeavySyncCheckpoint(ctx context.Context, jetID core.RecordID, checkpoint *ReplicaCheckpoint) error {
+	return rs.setCheckpoint(ctx, prefixkey(scopeIDSystem, []byte{sysHeavySyncCheckpoint}, jetID[:]), checkpoint)
+}
+
+// GetHeavySyncCheckpoint returns position of jet replication stored on heavy node, nil if replication of pulse
+// is not in progress.
+func (rs *replicaStorage) GetHeavySyncCheckpoint(ctx context.Context, jetID core.RecordID) (*ReplicaCheckpoint, error) {
+	return rs.getCheckpoint(ctx, prefixkey(scopeIDSystem, []byte{sysHeavySyncCheckpoint}, jetID[:]))
+}
+
+// SetSyncClientCheckpoint saves position of jet replication acknowledged by heavy node, nil checkpoint removes it.
+func (rs *replicaStorage) SetSyncClientCheckpoint(ctx context.Context, jetID core.RecordID, checkpoint *ReplicaCheckpoint) error {
+	return rs.setCheckpoint(ctx, prefixkey(scopeIDSystem, []byte{sysSyncClientCheckpoint}, jetID[:]), checkpoint)
+}
+
+// GetSyncClientCheckpoint returns position of jet replication acknowledged by heavy node, nil if replication of
+// pulse is not in progress.
+func (rs *replicaStorage) GetSyncClientCheckpoint(ctx context.Context, jetID core.RecordID) (*ReplicaCheckpoint, error) {
+	return rs.getCheckpoint(ctx, prefixkey(scopeIDSystem, []byte{sysSyncClientCheckpoint}, jetID[:]))
+}
+
+func (rs *replicaStorage) setCheckpoint(ctx context.Context, k []byte, checkpoint *ReplicaCheckpoint) error {
+	return rs.DB.Update(ctx, func(tx *TransactionManager) error {
+		if checkpoint == nil {
+			return tx.remove(ctx, k)
+		}
+		return tx.set(ctx, k, append(checkpoint.Pulse.Bytes(), checkpoint.Cursor...))
+	})
+}
+
+func (rs *replicaStorage) getCheckpoint(ctx context.Context, k []byte) (*ReplicaCheckpoint, error) {
+	buf, err := rs.DB.get(ctx, k)
+	if err == ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get replication checkpoint")
+	}
+	if len(buf) < core.PulseNumberSize {
+		return nil, errors.New("malformed replication checkpoint")
+	}
+	checkpoint := &ReplicaCheckpoint{Pulse: core.NewPulseNumber(buf[:core.PulseNumberSize])}
+	if len(buf) > core.PulseNumberSize {
+		checkpoint.Cursor = buf[core.PulseNumberSize:]
+	}
+	return checkpoint, nil
+}
@@ -0,0 +1,208 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// snapshotMagic starts snapshot file, the last byte is format version.
+var snapshotMagic = []byte("INSSNAP\x01")
+
+// SnapshotHeader describes storage snapshot. Snapshot is a header followed by all keys of storage, it does not
+// depend on storage backend.
+type SnapshotHeader struct {
+	Time time.Time
+	// Pulse is the latest pulse of storage.
+	Pulse core.PulseNumber
+}
+
+// WriteSnapshot writes all keys of storage to w, keys are read in single transaction so snapshot is consistent.
+// Returns header of snapshot and count of written keys.
+func WriteSnapshot(ctx context.Context, db DBContext, w io.Writer) (*SnapshotHeader, int, error) {
+	bw := bufio.NewWriter(w)
+	header := &SnapshotHeader{Time: time.Now()}
+	var count int
+	err := db.GetKV().View(func(txn KVTxn) error {
+		raw, err := txn.Get(prefixkey(scopeIDSystem, []byte{sysLatestPulse}))
+		if err != nil {
+			return errors.Wrap(err, "can't get latest pulse")
+		}
+		pulse, err := toPulse(raw)
+		if err != nil {
+			return errors.Wrap(err, "can't decode latest pulse")
+		}
+		header.Pulse = pulse.Pulse.PulseNumber
+
+		data, err := json.Marshal(header)
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(snapshotMagic); err != nil {
+			return err
+		}
+		if err := writeSnapshotBytes(bw, data); err != nil {
+			return err
+		}
+		err = txn.Iterate(nil, nil, func(k, v []byte) (bool, error) {
+			// sequence of write-ahead log belongs to log of this node
+			if bytes.Equal(k, walSequenceKey) {
+				return true, nil
+			}
+			if err := writeSnapshotBytes(bw, k); err != nil {
+				return false, err
+			}
+			count++
+			return true, writeSnapshotBytes(bw, v)
+		})
+		if err != nil {
+			return err
+		}
+		// empty key ends snapshot, it is followed by count of keys
+		if err := writeSnapshotBytes(bw, nil); err != nil {
+			return err
+		}
+		return binary.Write(bw, binary.BigEndian, uint64(count))
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "[ WriteSnapshot ]")
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, 0, errors.Wrap(err, "[ WriteSnapshot ]")
+	}
+	inslogger.FromContext(ctx).Infof("Snapshot of pulse %d written, %d keys", header.Pulse, count)
+	return header, count, nil
+}
+
+// RestoreSnapshot loads snapshot into empty storage in transactions of up to batchSize bytes. ErrNotEmpty is
+// returned if storage has data. Returns header of snapshot and count of loaded keys.
+func RestoreSnapshot(ctx context.Context, db DBContext, r io.Reader, batchSize int) (*SnapshotHeader, int, error) {
+	kv := db.GetKV()
+	empty := true
+	err := kv.View(func(txn KVTxn) error {
+		return txn.IterateKeys(nil, nil, func(k []byte) (bool, error) {
+			empty = false
+			return false, nil
+		})
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "[ RestoreSnapshot ]")
+	}
+	if !empty {
+		return nil, 0, ErrNotEmpty
+	}
+
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return nil, 0, errors.New("[ RestoreSnapshot ] not a snapshot or unsupported snapshot version")
+	}
+	data, err := readSnapshotBytes(br)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "[ RestoreSnapshot ] can't read header")
+	}
+	header := &SnapshotHeader{}
+	if err := json.Unmarshal(data, header); err != nil {
+		return nil, 0, errors.Wrap(err, "[ RestoreSnapshot ] can't parse header")
+	}
+
+	var (
+		count int
+		size  int
+		batch []keyval
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := kv.Update(func(txn KVTxn) error {
+			for _, rec := range batch {
+				if err := rec.apply(txn); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		count += len(batch)
+		inslogger.FromContext(ctx).Debugf("restored %d keys", count)
+		batch, size = batch[:0], 0
+		return nil
+	}
+	for {
+		k, err := readSnapshotBytes(br)
+		if err != nil {
+			return nil, count, errors.Wrap(err, "[ RestoreSnapshot ] can't read key")
+		}
+		if len(k) == 0 {
+			break
+		}
+		v, err := readSnapshotBytes(br)
+		if err != nil {
+			return nil, count, errors.Wrap(err, "[ RestoreSnapshot ] can't read value")
+		}
+		batch = append(batch, keyval{k: k, v: v})
+		size += len(k) + len(v)
+		if size >= batchSize {
+			if err := flush(); err != nil {
+				return nil, count, errors.Wrap(err, "[ RestoreSnapshot ] can't save keys")
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, count, errors.Wrap(err, "[ RestoreSnapshot ] can't save keys")
+	}
+
+	var expected uint64
+	if err := binary.Read(br, binary.BigEndian, &expected); err != nil {
+		return nil, count, errors.Wrap(err, "[ RestoreSnapshot ] can't read count of keys")
+	}
+	if uint64(count) != expected {
+		return nil, count, errors.Errorf("[ RestoreSnapshot ] snapshot has %d keys, %d restored", expected, count)
+	}
+	inslogger.FromContext(ctx).Infof("Snapshot of pulse %d restored, %d keys", header.Pulse, count)
+	return header, count, nil
+}
+
+func writeSnapshotBytes(w io.Writer, b []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readSnapshotBytes(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
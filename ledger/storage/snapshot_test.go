@@ -0,0 +1,82 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_WriteRestore(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+	require.NoError(t, db.SetLocalData(ctx, core.FirstPulseNumber, []byte{1}, []byte{2}))
+
+	var snapshot bytes.Buffer
+	header, keys, err := storage.WriteSnapshot(ctx, db, &snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, core.GenesisPulse.PulseNumber, header.Pulse)
+	assert.NotZero(t, keys)
+
+	// storage with data is not overwritten
+	_, _, err = storage.RestoreSnapshot(ctx, db, bytes.NewReader(snapshot.Bytes()), 1<<20)
+	assert.Equal(t, storage.ErrNotEmpty, err)
+
+	restored, restoredCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+	defer restoredCleaner()
+	// small batch size makes restore use several transactions
+	restoredHeader, restoredKeys, err := storage.RestoreSnapshot(ctx, restored, bytes.NewReader(snapshot.Bytes()), 16)
+	require.NoError(t, err)
+	assert.Equal(t, header.Pulse, restoredHeader.Pulse)
+	assert.Equal(t, keys, restoredKeys)
+
+	value, err := restored.GetLocalData(ctx, core.FirstPulseNumber, []byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+	tx, err := restored.BeginTransaction(false)
+	require.NoError(t, err)
+	defer tx.Discard()
+	pulse, err := tx.GetLatestPulse(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, header.Pulse, pulse.Pulse.PulseNumber)
+}
+
+func TestSnapshot_RestoreTruncated(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	var snapshot bytes.Buffer
+	_, _, err := storage.WriteSnapshot(ctx, db, &snapshot)
+	require.NoError(t, err)
+
+	restored, restoredCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+	defer restoredCleaner()
+	truncated := snapshot.Bytes()[:snapshot.Len()-1]
+	_, _, err = storage.RestoreSnapshot(ctx, restored, bytes.NewReader(truncated), 1<<20)
+	assert.Error(t, err)
+}
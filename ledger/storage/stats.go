@@ -0,0 +1,126 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+// storageScopes are scopes of storage in order of stats, with names used in stats and metrics.
+var storageScopes = []struct {
+	id   byte
+	name string
+}{
+	{scopeIDRecord, "records"},
+	{scopeIDLifeline, "indexes"},
+	{scopeIDBlob, "blobs"},
+	{scopeIDJetDrop, "drops"},
+	{scopeIDPulse, "pulses"},
+	{scopeIDMessage, "messages"},
+	{scopeIDPrototype, "prototypes"},
+	{scopeIDLocal, "local"},
+	{scopeIDSystem, "system"},
+}
+
+// StorageStats counts keys and their sizes in storage scopes and records them to metrics.
+func (db *DB) StorageStats(ctx context.Context) (*core.StorageStats, error) {
+	db.closeLock.RLock()
+	defer db.closeLock.RUnlock()
+	if db.isClosed {
+		return nil, ErrClosed
+	}
+
+	result := &core.StorageStats{
+		Scopes:                make([]core.StorageScopeStats, 0, len(storageScopes)),
+		PulseBytesWritten:     atomic.LoadInt64(&db.pulseWritten),
+		PrevPulseBytesWritten: atomic.LoadInt64(&db.prevPulseWritten),
+	}
+	err := db.kv.View(func(txn KVTxn) error {
+		for _, scope := range storageScopes {
+			scopeStats := core.StorageScopeStats{Scope: scope.name}
+			err := txn.Iterate([]byte{scope.id}, nil, func(k, v []byte) (bool, error) {
+				scopeStats.Keys++
+				scopeStats.Size += int64(len(k) + len(v))
+				return true, nil
+			})
+			if err != nil {
+				return err
+			}
+			result.Scopes = append(result.Scopes, scopeStats)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scopeStats := range result.Scopes {
+		ctx := insmetrics.InsertTag(ctx, tagScope, scopeStats.Scope)
+		stats.Record(ctx, statStorageKeys.M(scopeStats.Keys), statStorageSize.M(scopeStats.Size))
+	}
+	return result, nil
+}
+
+// collectStats records storage stats to metrics every interval until storage is closed.
+func (db *DB) collectStats(interval time.Duration) {
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-db.statsStop:
+			return
+		case <-ticker.C:
+			if _, err := db.StorageStats(ctx); err != nil && err != ErrClosed {
+				inslogger.FromContext(ctx).Error("failed to collect storage stats: ", err)
+			}
+		}
+	}
+}
+
+// addWritten accounts size of committed updates.
+func (db *DB) addWritten(updates map[string]keyval) {
+	var size int64
+	for _, rec := range updates {
+		if !rec.deleted {
+			size += int64(len(rec.k) + len(rec.v))
+		}
+	}
+	atomic.AddInt64(&db.pulseWritten, size)
+	stats.Record(context.Background(), statStorageBytesWritten.M(size))
+}
+
+// rotateStats starts counting of data written during new pulse.
+func (db *DB) rotateStats(ctx context.Context) {
+	written := atomic.SwapInt64(&db.pulseWritten, 0)
+	atomic.StoreInt64(&db.prevPulseWritten, written)
+	stats.Record(ctx, statStoragePulseBytesWritten.M(written))
+}
+
+// recordLatency records latency of storage transaction started at start.
+func recordLatency(ctx context.Context, operation string, start time.Time) {
+	ctx = insmetrics.InsertTag(ctx, tagOperation, operation)
+	stats.Record(ctx, statStorageLatency.M(float64(time.Since(start))/float64(time.Millisecond)))
+}
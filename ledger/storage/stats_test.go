@@ -0,0 +1,72 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_StorageStats(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	dir, err := ioutil.TempDir("", "stats-db-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := configuration.NewLedger()
+	conf.Storage.DataDirectory = dir
+	conf.Storage.StatsInterval = 0
+	db, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	db.(*DB).PlatformCryptographyScheme = platformpolicy.NewPlatformCryptographyScheme()
+	objects := &objectStorage{DB: db, PlatformCryptographyScheme: db.(*DB).PlatformCryptographyScheme}
+	pulses := &pulseTracker{DB: db}
+
+	jetID := *jet.NewID(0, nil)
+	blob := make([]byte, 100)
+	_, err = objects.SetBlob(ctx, jetID, core.FirstPulseNumber, blob)
+	require.NoError(t, err)
+
+	stats, err := db.(*DB).StorageStats(ctx)
+	require.NoError(t, err)
+	scopes := map[string]core.StorageScopeStats{}
+	for _, scope := range stats.Scopes {
+		scopes[scope.Scope] = scope
+	}
+	assert.Equal(t, int64(1), scopes["blobs"].Keys)
+	assert.True(t, scopes["blobs"].Size > int64(len(blob)))
+	assert.Equal(t, int64(0), scopes["records"].Keys)
+	assert.Equal(t, scopes["blobs"].Size, stats.PulseBytesWritten)
+	assert.Equal(t, int64(0), stats.PrevPulseBytesWritten)
+
+	// written data is counted per pulse
+	require.NoError(t, pulses.AddPulse(ctx, core.Pulse{PulseNumber: core.FirstPulseNumber + 1}))
+	stats, err = db.(*DB).StorageStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stats.PulseBytesWritten)
+	assert.True(t, stats.PrevPulseBytesWritten > scopes["blobs"].Size, "pulses are written too")
+}
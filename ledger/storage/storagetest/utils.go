@@ -76,7 +76,7 @@ func TmpDB(ctx context.Context, t testing.TB, options ...Option) (storage.DBCont
 	cm.Inject(
 		platformpolicy.NewPlatformCryptographyScheme(),
 		db,
-		storage.NewJetStorage(),
+		storage.NewCheckedJetStorage(),
 		storage.NewObjectStorage(),
 		storage.NewDropStorage(10),
 		storage.NewPulseTracker(),
@@ -39,3 +39,15 @@ type Store interface {
 	GetLatestPulse(ctx context.Context) (*Pulse, error)
 	GetPulse(ctx context.Context, num core.PulseNumber) (*Pulse, error)
 }
+
+// Batch is a Store which keeps writes in memory and applies them atomically on Commit, so record, blob and
+// index of an object are either all saved or none of them is. Discard rolls back uncommitted writes and releases
+// index locks, it should be called after Commit as well.
+//
+// Batch is returned by DBContext.BeginTransaction, DBContext.Update commits it with retries on conflicts.
+type Batch interface {
+	Store
+
+	Commit() error
+	Discard()
+}
@@ -19,7 +19,6 @@ package storage
 import (
 	"context"
 
-	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/ledger/storage/index"
 	"github.com/insolar/insolar/ledger/storage/jet"
@@ -29,14 +28,26 @@ import (
 type keyval struct {
 	k []byte
 	v []byte
+	// deleted is set if key is removed in transaction.
+	deleted bool
 }
 
-// TransactionManager is used to ensure persistent writes to disk.
+// apply writes update into KV transaction.
+func (kv keyval) apply(txn KVTxn) error {
+	if kv.deleted {
+		return txn.Delete(kv.k)
+	}
+	return txn.Set(kv.k, kv.v)
+}
+
+// TransactionManager is used to ensure persistent writes to disk. All writes including removals are kept in
+// memory until Commit and applied atomically, Discard rolls them back.
 type TransactionManager struct {
 	db        *DB
 	update    bool
 	locks     []*core.RecordID
 	txupdates map[string]keyval
+	discarded bool
 }
 
 func (m *TransactionManager) lockOnID(id *core.RecordID) {
@@ -55,23 +66,37 @@ func (m *TransactionManager) Commit() error {
 	if len(m.txupdates) == 0 {
 		return nil
 	}
-	var err error
-	tx := m.db.db.NewTransaction(m.update)
-	defer tx.Discard()
-	for _, rec := range m.txupdates {
-		err = tx.Set(rec.k, rec.v)
-		if err != nil {
-			break
-		}
+	err := m.commit()
+	if err == nil {
+		m.db.addWritten(m.txupdates)
 	}
-	if err != nil {
-		return err
+	return err
+}
+
+func (m *TransactionManager) commit() error {
+	if m.update && m.db.wal != nil {
+		return m.db.commitWAL(m.txupdates)
 	}
-	return tx.Commit(nil)
+	if m.update && m.db.batcher != nil {
+		return m.db.batcher.write(m.txupdates)
+	}
+	return m.db.kv.Update(func(tx KVTxn) error {
+		for _, rec := range m.txupdates {
+			if err := rec.apply(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-// Discard terminates transaction without disk writes.
+// Discard terminates transaction without disk writes. Writes are rolled back unless transaction was committed.
+// It is safe to call Discard more than once, e.g. deferred after Commit.
 func (m *TransactionManager) Discard() {
+	if m.discarded {
+		return
+	}
+	m.discarded = true
 	m.txupdates = nil
 	m.releaseLocks()
 	if m.update {
@@ -134,6 +159,10 @@ func (m *TransactionManager) GetRecord(ctx context.Context, jetID core.RecordID,
 	if err != nil {
 		return nil, err
 	}
+	buf, err = m.db.decodeRecord(buf)
+	if err != nil {
+		return nil, err
+	}
 	return record.DeserializeRecord(buf), nil
 }
 
@@ -145,18 +174,18 @@ func (m *TransactionManager) SetRecord(ctx context.Context, j core.RecordID, pul
 	id := record.NewRecordIDFromRecord(m.db.PlatformCryptographyScheme, pulseNumber, rec)
 	_, prefix := jet.Jet(j)
 	k := prefixkey(scopeIDRecord, prefix, id[:])
-	geterr := m.db.db.View(func(tx *badger.Txn) error {
+	geterr := m.db.kv.View(func(tx KVTxn) error {
 		_, err := tx.Get(k)
 		return err
 	})
 	if geterr == nil {
 		return id, ErrOverride
 	}
-	if geterr != badger.ErrKeyNotFound {
+	if geterr != ErrNotFound {
 		return nil, geterr
 	}
 
-	err := m.set(ctx, k, record.SerializeRecord(rec))
+	err := m.setRecordValue(ctx, k, record.SerializeRecord(rec))
 	if err != nil {
 		return nil, err
 	}
@@ -222,32 +251,25 @@ func (m *TransactionManager) set(ctx context.Context, key, value []byte) error {
 // get returns value by key.
 func (m *TransactionManager) get(ctx context.Context, key []byte) ([]byte, error) {
 	if kv, ok := m.txupdates[string(key)]; ok {
-		return kv.v, nil
-	}
-
-	txn := m.db.db.NewTransaction(false)
-	defer txn.Discard()
-	item, err := txn.Get(key)
-	if err != nil {
-		if err == badger.ErrKeyNotFound {
+		if kv.deleted {
 			return nil, ErrNotFound
 		}
-		return nil, err
+		return kv.v, nil
 	}
-	return item.ValueCopy(nil)
+
+	var value []byte
+	err := m.db.kv.View(func(txn KVTxn) error {
+		var err error
+		value, err = txn.Get(key)
+		return err
+	})
+	return value, err
 }
 
-// removes value by key
+// remove removes value by key.
 func (m *TransactionManager) remove(ctx context.Context, key []byte) error {
-	debugf(ctx, "get key %v", bytes2hex(key))
-
-	txn := m.db.db.NewTransaction(true)
-	defer txn.Discard()
+	debugf(ctx, "remove key %v", bytes2hex(key))
 
-	err := txn.Delete(key)
-	if err != nil {
-		return err
-	}
-
-	return txn.Commit(nil)
+	m.txupdates[string(key)] = keyval{k: key, deleted: true}
+	return nil
 }
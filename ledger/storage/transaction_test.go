@@ -193,3 +193,43 @@ func (s *txnSuite) TestStore_Transaction_LockOnUpdate() {
 		assert.Equal(t, objid, idxlife.LatestState)
 	})
 }
+
+func (s *txnSuite) TestStore_Transaction_Batch() {
+	jetID := *jet.NewID(0, nil)
+	idxid := core.NewRecordID(0, []byte{1})
+	blob := []byte{1, 2, 3}
+	err := s.objectStorage.SetObjectIndex(s.ctx, jetID, idxid, &index.ObjectLifeline{})
+	require.NoError(s.T(), err)
+
+	// Discarded batch leaves storage untouched.
+	var batch storage.Batch
+	batch, err = s.db.BeginTransaction(true)
+	require.NoError(s.T(), err)
+	blobID, err := batch.SetBlob(s.ctx, jetID, core.FirstPulseNumber, blob)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), batch.RemoveObjectIndex(s.ctx, jetID, idxid))
+	_, err = batch.GetObjectIndex(s.ctx, jetID, idxid, false)
+	assert.Equal(s.T(), storage.ErrNotFound, err)
+	batch.Discard()
+
+	_, err = s.objectStorage.GetBlob(s.ctx, jetID, blobID)
+	assert.Equal(s.T(), storage.ErrNotFound, err)
+	_, err = s.objectStorage.GetObjectIndex(s.ctx, jetID, idxid, false)
+	require.NoError(s.T(), err)
+
+	// Committed batch applies all writes.
+	batch, err = s.db.BeginTransaction(true)
+	require.NoError(s.T(), err)
+	_, err = batch.SetBlob(s.ctx, jetID, core.FirstPulseNumber, blob)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), batch.RemoveObjectIndex(s.ctx, jetID, idxid))
+	require.NoError(s.T(), batch.Commit())
+	batch.Discard()
+	batch.Discard()
+
+	saved, err := s.objectStorage.GetBlob(s.ctx, jetID, blobID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), blob, saved)
+	_, err = s.objectStorage.GetObjectIndex(s.ctx, jetID, idxid, false)
+	assert.Equal(s.T(), storage.ErrNotFound, err)
+}
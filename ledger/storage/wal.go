@@ -0,0 +1,419 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+const (
+	walSegmentExt = ".wal"
+
+	walEntryCommit byte = 1
+	walEntryAbort  byte = 2
+
+	// walHeaderSize is size of entry header: payload length and checksum.
+	walHeaderSize = 8
+)
+
+// walEntry is a commit of storage transaction, or abort of commit that failed to apply to KV store.
+type walEntry struct {
+	kind    byte
+	seq     uint64
+	updates []keyval
+}
+
+// wal is write-ahead log of storage commits. Log is split into segments by pulse. Every commit has sequence number
+// which is saved into KV store in the same transaction as updates, so on start only commits missing in KV store
+// are replayed.
+type wal struct {
+	dir  string
+	keep int
+
+	lock    sync.Mutex
+	file    *os.File
+	segment core.PulseNumber
+	seq     uint64
+}
+
+// openWAL reads log from directory and opens the latest segment for appending. Torn entry at the end of segment,
+// left by unclean shutdown, is truncated. Returns all read entries in log order.
+func openWAL(dir string, keep int) (*wal, []walEntry, error) {
+	if keep < 1 {
+		return nil, nil, errors.Errorf("WAL must keep at least one pulse segment, got %d", keep)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create WAL directory")
+	}
+	segments, err := walSegments(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w := &wal{dir: dir, keep: keep}
+	var entries []walEntry
+	for _, segment := range segments {
+		read, err := readWALSegment(w.path(segment))
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, read...)
+	}
+	for _, entry := range entries {
+		if entry.seq > w.seq {
+			w.seq = entry.seq
+		}
+	}
+
+	if len(segments) > 0 {
+		w.segment = segments[len(segments)-1]
+	}
+	w.file, err = os.OpenFile(w.path(w.segment), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open WAL segment")
+	}
+	return w, entries, nil
+}
+
+func (w *wal) path(segment core.PulseNumber) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%010d%s", segment, walSegmentExt))
+}
+
+// commit appends updates to log and calls apply with sequence number of commit, apply should save updates with
+// sequence number into KV store. Commit is marked aborted in log if apply fails.
+func (w *wal) commit(updates []keyval, apply func(seq uint64) error) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.seq++
+	seq := w.seq
+	if err := w.write(walEntry{kind: walEntryCommit, seq: seq, updates: updates}); err != nil {
+		return errors.Wrap(err, "failed to write WAL")
+	}
+	if err := apply(seq); err != nil {
+		if abortErr := w.write(walEntry{kind: walEntryAbort, seq: seq}); abortErr != nil {
+			return errors.Wrapf(abortErr, "failed to abort commit in WAL after error: %v", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// setSeq makes sequence numbers of next commits greater than seq.
+func (w *wal) setSeq(seq uint64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if seq > w.seq {
+		w.seq = seq
+	}
+}
+
+// rotate starts new segment for pulse and removes segments except the latest keep ones. Current segment is never
+// removed.
+func (w *wal) rotate(pulse core.PulseNumber) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if pulse <= w.segment {
+		return nil
+	}
+	file, err := os.OpenFile(w.path(pulse), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create WAL segment")
+	}
+	if err := w.file.Close(); err != nil {
+		file.Close() // nolint: errcheck
+		return errors.Wrap(err, "failed to close WAL segment")
+	}
+	w.file, w.segment = file, pulse
+
+	segments, err := walSegments(w.dir)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(segments)-w.keep; i++ {
+		if segments[i] == w.segment {
+			continue
+		}
+		if err := os.Remove(w.path(segments[i])); err != nil {
+			return errors.Wrap(err, "failed to remove WAL segment")
+		}
+	}
+	return nil
+}
+
+func (w *wal) close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.file.Close()
+}
+
+// write appends entry to segment and syncs it to disk.
+func (w *wal) write(entry walEntry) error {
+	var payload bytes.Buffer
+	payload.WriteByte(entry.kind)
+	writeWALUint64(&payload, entry.seq)
+	writeWALUint64(&payload, uint64(len(entry.updates)))
+	for _, kv := range entry.updates {
+		if kv.deleted {
+			payload.WriteByte(1)
+		} else {
+			payload.WriteByte(0)
+		}
+		writeWALBytes(&payload, kv.k)
+		writeWALBytes(&payload, kv.v)
+	}
+
+	buf := make([]byte, walHeaderSize, walHeaderSize+payload.Len())
+	binary.BigEndian.PutUint32(buf[:4], uint32(payload.Len()))
+	binary.BigEndian.PutUint32(buf[4:], crc32.ChecksumIEEE(payload.Bytes()))
+	buf = append(buf, payload.Bytes()...)
+	if _, err := w.file.Write(buf); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// walSegments returns pulses of log segments in ascending order.
+func walSegments(dir string) ([]core.PulseNumber, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list WAL segments")
+	}
+	var segments []core.PulseNumber
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasSuffix(name, walSegmentExt) {
+			continue
+		}
+		var pulse core.PulseNumber
+		if _, err := fmt.Sscanf(strings.TrimSuffix(name, walSegmentExt), "%d", &pulse); err != nil {
+			return nil, errors.Errorf("unexpected WAL segment %s", name)
+		}
+		segments = append(segments, pulse)
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i] < segments[j]
+	})
+	return segments, nil
+}
+
+// readWALSegment reads entries of segment, segment is truncated after the last valid entry.
+func readWALSegment(path string) ([]walEntry, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open WAL segment")
+	}
+	defer file.Close() // nolint: errcheck
+
+	var (
+		entries []walEntry
+		valid   int64
+		header  = make([]byte, walHeaderSize)
+	)
+	r := bufio.NewReader(file)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			break
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(header[:4]))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			break
+		}
+		entry, err := decodeWALEntry(payload)
+		if err != nil {
+			break
+		}
+		entries = append(entries, entry)
+		valid += int64(walHeaderSize + len(payload))
+	}
+
+	if err := file.Truncate(valid); err != nil {
+		return nil, errors.Wrap(err, "failed to truncate torn WAL entry")
+	}
+	return entries, nil
+}
+
+func decodeWALEntry(payload []byte) (walEntry, error) {
+	r := bytes.NewReader(payload)
+	var entry walEntry
+	var count uint64
+	kind, err := r.ReadByte()
+	if err != nil {
+		return entry, err
+	}
+	entry.kind = kind
+	if err := binary.Read(r, binary.BigEndian, &entry.seq); err != nil {
+		return entry, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return entry, err
+	}
+	for i := uint64(0); i < count; i++ {
+		deleted, err := r.ReadByte()
+		if err != nil {
+			return entry, err
+		}
+		k, err := readWALBytes(r)
+		if err != nil {
+			return entry, err
+		}
+		v, err := readWALBytes(r)
+		if err != nil {
+			return entry, err
+		}
+		entry.updates = append(entry.updates, keyval{k: k, v: v, deleted: deleted == 1})
+	}
+	return entry, nil
+}
+
+func writeWALUint64(buf *bytes.Buffer, v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeWALBytes(buf *bytes.Buffer, b []byte) {
+	writeWALUint64(buf, uint64(len(b)))
+	buf.Write(b)
+}
+
+func readWALBytes(r *bytes.Reader) ([]byte, error) {
+	var size uint64
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := make([]byte, size)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+var walSequenceKey = prefixkey(scopeIDSystem, []byte{sysWALSequence})
+
+// openWAL opens write-ahead log in data directory and replays commits missing in KV store.
+func (db *DB) openWAL(conf configuration.Storage) error {
+	dir, err := filepath.Abs(conf.DataDirectory)
+	if err != nil {
+		return err
+	}
+	w, entries, err := openWAL(filepath.Join(dir, "wal"), conf.WAL.KeepPulses)
+	if err != nil {
+		return err
+	}
+	db.wal = w
+	db.walReplayed, err = db.replayWAL(entries)
+	if err != nil {
+		w.close() // nolint: errcheck
+		db.wal = nil
+		return errors.Wrap(err, "failed to replay WAL")
+	}
+	return nil
+}
+
+// replayWAL applies commits which sequence numbers are greater than the last applied one. Returns count of
+// replayed commits.
+func (db *DB) replayWAL(entries []walEntry) (int, error) {
+	var applied uint64
+	err := db.kv.View(func(txn KVTxn) error {
+		v, err := txn.Get(walSequenceKey)
+		if err == ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		applied = binary.BigEndian.Uint64(v)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	db.wal.setSeq(applied)
+
+	aborted := map[uint64]bool{}
+	for _, entry := range entries {
+		if entry.kind == walEntryAbort {
+			aborted[entry.seq] = true
+		}
+	}
+	var replayed int
+	for _, entry := range entries {
+		if entry.kind != walEntryCommit || entry.seq <= applied || aborted[entry.seq] {
+			continue
+		}
+		if err := db.applyWAL(entry.updates, entry.seq); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// commitWAL appends updates to write-ahead log and applies them to KV store.
+func (db *DB) commitWAL(updates map[string]keyval) error {
+	list := make([]keyval, 0, len(updates))
+	for _, rec := range updates {
+		list = append(list, rec)
+	}
+	return db.wal.commit(list, func(seq uint64) error {
+		return db.applyWAL(list, seq)
+	})
+}
+
+// applyWAL writes updates to KV store together with sequence number of their commit.
+func (db *DB) applyWAL(updates []keyval, seq uint64) error {
+	return db.kv.Update(func(txn KVTxn) error {
+		for _, rec := range updates {
+			if err := rec.apply(txn); err != nil {
+				return err
+			}
+		}
+		v := make([]byte, 8)
+		binary.BigEndian.PutUint64(v, seq)
+		return txn.Set(walSequenceKey, v)
+	})
+}
+
+// rotateWAL starts write-ahead log segment for pulse, noop if log is disabled.
+func (db *DB) rotateWAL(pulse core.PulseNumber) error {
+	if db.wal == nil {
+		return nil
+	}
+	return db.wal.rotate(pulse)
+}
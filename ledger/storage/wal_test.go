@@ -0,0 +1,132 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_WAL_Replay(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wal-db-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := configuration.NewLedger()
+	conf.Storage.DataDirectory = dir
+	conf.Storage.WAL.Enabled = true
+	db, err := NewDB(conf, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.SetLocalData(ctx, 1, []byte{1}, []byte{2}))
+	require.NoError(t, db.Close())
+
+	// commit is logged but lost by KV store, aborted commit and torn entry are not replayed
+	w, entries, err := openWAL(filepath.Join(dir, "wal"), 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	lost := []keyval{{k: []byte{42}, v: []byte{43}}}
+	require.NoError(t, w.commit(lost, func(uint64) error { return nil }))
+	aborted := []keyval{{k: []byte{44}, v: []byte{45}}}
+	require.Error(t, w.commit(aborted, func(uint64) error { return errors.New("failed") }))
+	require.NoError(t, w.close())
+	segment, err := os.OpenFile(w.path(0), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = segment.Write([]byte{0, 0, 1})
+	require.NoError(t, err)
+	require.NoError(t, segment.Close())
+
+	db, err = NewDB(conf, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, db.(*DB).WALReplayed())
+	err = db.GetKV().View(func(txn KVTxn) error {
+		v, err := txn.Get([]byte{42})
+		require.NoError(t, err)
+		assert.Equal(t, []byte{43}, v)
+		_, err = txn.Get([]byte{44})
+		assert.Equal(t, ErrNotFound, err)
+		return nil
+	})
+	require.NoError(t, err)
+	value, err := db.GetLocalData(ctx, 1, []byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+
+	require.NoError(t, db.SetLocalData(ctx, 1, []byte{3}, []byte{4}))
+	require.NoError(t, db.Close())
+
+	db, err = NewDB(conf, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	assert.Equal(t, 0, db.(*DB).WALReplayed())
+	value, err = db.GetLocalData(ctx, 1, []byte{3})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4}, value)
+}
+
+func TestWAL_Rotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, _, err := openWAL(dir, 2)
+	require.NoError(t, err)
+	defer w.close()
+
+	for _, pulse := range []core.PulseNumber{10, 20, 30, 20} {
+		require.NoError(t, w.rotate(pulse))
+		require.NoError(t, w.commit([]keyval{{k: []byte{1}, v: pulse.Bytes()}}, func(uint64) error { return nil }))
+	}
+	segments, err := walSegments(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []core.PulseNumber{20, 30}, segments)
+
+	_, entries, err := openWAL(dir, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, uint64(4), entries[2].seq)
+	assert.Equal(t, core.PulseNumber(20).Bytes(), entries[2].updates[0].v)
+}
+
+func TestWAL_KeepZero(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, _, err = openWAL(dir, 0)
+	require.Error(t, err)
+
+	// rotate never removes the current segment.
+	w, _, err := openWAL(dir, 1)
+	require.NoError(t, err)
+	defer w.close()
+	w.keep = 0
+
+	require.NoError(t, w.rotate(10))
+	require.NoError(t, w.commit([]keyval{{k: []byte{1}, v: []byte{1}}}, func(uint64) error { return nil }))
+	segments, err := walSegments(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []core.PulseNumber{10}, segments)
+}
@@ -0,0 +1,206 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package argschema describes arguments of smart contract methods and constructors.
+// Schemas are generated from contract method signatures, saved as memory of prototypes
+// and used to validate arguments of incoming calls before contract code is loaded.
+package argschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// Argument is a name and Go type of method argument as they are declared in contract code.
+type Argument struct {
+	Name string
+	Type string
+}
+
+// Schema holds arguments of contract methods and constructors by their names.
+type Schema struct {
+	Methods      map[string][]Argument
+	Constructors map[string][]Argument
+}
+
+// New creates empty schema.
+func New() *Schema {
+	return &Schema{
+		Methods:      make(map[string][]Argument),
+		Constructors: make(map[string][]Argument),
+	}
+}
+
+// Serialize serializes schema to store it as prototype memory.
+func (s *Schema) Serialize() ([]byte, error) {
+	return core.Serialize(s)
+}
+
+// Deserialize restores schema from prototype memory. Returns nil schema for empty memory.
+func Deserialize(data []byte) (*Schema, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	s := New()
+	err := core.Deserialize(data, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ argschema.Deserialize ] Can't deserialize schema")
+	}
+	return s, nil
+}
+
+// MismatchError describes why call arguments doesn't match schema.
+type MismatchError struct {
+	Function string
+	Reason   string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("argument mismatch in call of %s: %s", e.Function, e.Reason)
+}
+
+// ValidateMethod checks serialized arguments of method call. Methods not described by schema are not checked.
+func (s *Schema) ValidateMethod(name string, args core.Arguments) error {
+	if s == nil {
+		return nil
+	}
+	expected, ok := s.Methods[name]
+	if !ok {
+		return nil
+	}
+	return validate(name, expected, args)
+}
+
+// ValidateConstructor checks serialized arguments of constructor call. Constructors not described by schema are
+// not checked.
+func (s *Schema) ValidateConstructor(name string, args core.Arguments) error {
+	if s == nil {
+		return nil
+	}
+	expected, ok := s.Constructors[name]
+	if !ok {
+		return nil
+	}
+	return validate(name, expected, args)
+}
+
+func validate(name string, expected []Argument, args core.Arguments) error {
+	var values []interface{}
+	if len(args) > 0 {
+		err := core.Deserialize(args, &values)
+		if err != nil {
+			return &MismatchError{Function: name, Reason: "arguments are not a serialized list: " + err.Error()}
+		}
+	}
+
+	if len(values) != len(expected) {
+		return &MismatchError{
+			Function: name,
+			Reason:   fmt.Sprintf("expected %d arguments, got %d", len(expected), len(values)),
+		}
+	}
+
+	for i, arg := range expected {
+		if !compatible(arg.Type, values[i]) {
+			return &MismatchError{
+				Function: name,
+				Reason:   fmt.Sprintf("argument #%d %s must be %s, got %T", i, arg.Name, arg.Type, values[i]),
+			}
+		}
+	}
+	return nil
+}
+
+// recordSizes are sizes of serialized references and ids.
+var recordSizes = map[string]int{
+	"core.RecordRef": core.RecordRefSize,
+	"core.RecordID":  core.RecordIDSize,
+}
+
+// compatible checks that generic value decoded from arguments can be decoded to type.
+// Types that can't be checked without contract code (structs, aliases) are considered compatible.
+func compatible(typ string, value interface{}) bool {
+	if strings.HasPrefix(typ, "*") {
+		return value == nil || compatible(typ[1:], value)
+	}
+
+	switch typ {
+	case "interface{}":
+		return true
+	case "string":
+		// text and byte strings are decoded to each other
+		switch value.(type) {
+		case string, []byte:
+			return true
+		}
+		return false
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	case "[]byte", "[]uint8":
+		switch value.(type) {
+		case nil, string, []byte:
+			return true
+		}
+		return false
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		switch value.(type) {
+		case int64, uint64:
+			return true
+		}
+		return false
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte", "uintptr":
+		_, ok := value.(uint64)
+		return ok
+	case "float32", "float64":
+		switch value.(type) {
+		case float64, float32, int64, uint64:
+			return true
+		}
+		return false
+	}
+
+	if size, ok := recordSizes[typ]; ok {
+		b, ok := value.([]byte)
+		return ok && len(b) == size
+	}
+	if strings.HasPrefix(typ, "[]") {
+		if value == nil {
+			return true
+		}
+		list, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, elem := range list {
+			if !compatible(typ[2:], elem) {
+				return false
+			}
+		}
+		return true
+	}
+	if strings.HasPrefix(typ, "map[") {
+		if value == nil {
+			return true
+		}
+		_, ok := value.(map[interface{}]interface{})
+		return ok
+	}
+	return true
+}
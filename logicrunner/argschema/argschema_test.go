@@ -0,0 +1,99 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package logicrunner - infrastructure for executing smartcontracts
+package argschema
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchema() *Schema {
+	s := New()
+	s.Methods["Call"] = []Argument{
+		{Name: "rootDomain", Type: "core.RecordRef"},
+		{Name: "method", Type: "string"},
+		{Name: "params", Type: "[]byte"},
+		{Name: "amount", Type: "uint"},
+		{Name: "to", Type: "*core.RecordRef"},
+		{Name: "tags", Type: "[]string"},
+	}
+	s.Constructors["New"] = []Argument{{Name: "name", Type: "string"}}
+	return s
+}
+
+func TestSchema_Serialize(t *testing.T) {
+	s := testSchema()
+	data, err := s.Serialize()
+	require.NoError(t, err)
+
+	restored, err := Deserialize(data)
+	require.NoError(t, err)
+	require.Equal(t, s, restored)
+
+	restored, err = Deserialize(nil)
+	require.NoError(t, err)
+	require.Nil(t, restored)
+}
+
+func TestSchema_ValidateMethod(t *testing.T) {
+	s := testSchema()
+	ref := testutils.RandomRef()
+
+	tests := []struct {
+		name  string
+		args  []interface{}
+		valid bool
+	}{
+		{"valid", []interface{}{ref, "m", []byte("p"), uint(1), &ref, []string{"a"}}, true},
+		{"nil pointer and slice", []interface{}{ref, "m", []byte(nil), uint(1), nil, nil}, true},
+		{"too few", []interface{}{ref, "m"}, false},
+		{"too many", []interface{}{ref, "m", []byte("p"), uint(1), &ref, []string{}, 1}, false},
+		{"wrong ref", []interface{}{[]byte("short"), "m", []byte("p"), uint(1), &ref, []string{}}, false},
+		{"string instead of number", []interface{}{ref, "m", []byte("p"), "1", &ref, []string{}}, false},
+		{"negative unsigned", []interface{}{ref, "m", []byte("p"), -1, &ref, []string{}}, false},
+		{"wrong element", []interface{}{ref, "m", []byte("p"), uint(1), &ref, []int{1}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args, err := core.MarshalArgs(test.args...)
+			require.NoError(t, err)
+			err = s.ValidateMethod("Call", args)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.IsType(t, &MismatchError{}, err)
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateUnknown(t *testing.T) {
+	var s *Schema
+	require.NoError(t, s.ValidateMethod("Call", nil))
+
+	s = testSchema()
+	require.NoError(t, s.ValidateMethod("Unknown", nil))
+	require.Error(t, s.ValidateConstructor("New", nil))
+
+	args, err := core.MarshalArgs("name")
+	require.NoError(t, err)
+	require.NoError(t, s.ValidateConstructor("New", args))
+}
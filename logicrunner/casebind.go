@@ -100,10 +100,16 @@ func (cb *CaseBind) getCaseBindForMessage(ctx context.Context) []message.CaseBin
 }
 
 func (cb *CaseBind) ToValidateMessage(ctx context.Context, ref Ref, pulse core.Pulse) *message.ValidateCaseBind {
+	requests := cb.getCaseBindForMessage(ctx)
+	order := make([]core.RecordRef, len(requests))
+	for i, req := range requests {
+		order[i] = req.Request
+	}
 	res := &message.ValidateCaseBind{
-		RecordRef: ref,
-		Requests:  cb.getCaseBindForMessage(ctx),
-		Pulse:     pulse,
+		RecordRef:      ref,
+		Requests:       requests,
+		Pulse:          pulse,
+		ExecutionOrder: order,
 	}
 	return res
 }
@@ -157,6 +163,9 @@ func (lr *LogicRunner) Validate(ctx context.Context, ref Ref, p core.Pulse, cb C
 	}
 	vs.Behaviour = checker
 
+	// validator must finish within pulse, so its reads bypass ledger read queues
+	ctx = core.ContextWithPriority(ctx, core.PriorityHigh)
+
 	for {
 		request := checker.NextRequest()
 		if request == nil {
@@ -202,9 +211,12 @@ func (lr *LogicRunner) HandleValidateCaseBindMessage(ctx context.Context, inmsg
 		return nil, errors.Wrap(err, "[ HandleValidateCaseBindMessage ] can't play role")
 	}
 
-	passedStepsCount, validationError := lr.Validate(
-		ctx, msg.GetReference(), msg.GetPulse(), *NewCaseBindFromValidateMessage(ctx, lr.MessageBus, msg),
-	)
+	cb := NewCaseBindFromValidateMessage(ctx, lr.MessageBus, msg)
+	passedStepsCount := 0
+	validationError := checkExecutionOrder(cb.Requests, msg.ExecutionOrder)
+	if validationError == nil {
+		passedStepsCount, validationError = lr.Validate(ctx, msg.GetReference(), msg.GetPulse(), *cb)
+	}
 	errstr := ""
 	if validationError != nil {
 		errstr = validationError.Error()
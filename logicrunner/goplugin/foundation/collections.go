@@ -0,0 +1,218 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package foundation server implementation of smartcontract functions
+package foundation
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+)
+
+// ChildrenList is a list of object's children of one prototype, children are loaded
+// lazily page by page while list is iterated, so the object doesn't keep references in its state
+type ChildrenList struct {
+	Parent    core.RecordRef
+	Prototype core.RecordRef
+}
+
+// NewChildrenList returns list of children of parent with given prototype
+func NewChildrenList(parent core.RecordRef, prototype core.RecordRef) *ChildrenList {
+	return &ChildrenList{Parent: parent, Prototype: prototype}
+}
+
+// NewChildrenList returns list of contract children with given prototype
+func (bc *BaseContract) NewChildrenList(prototype core.RecordRef) *ChildrenList {
+	return NewChildrenList(bc.GetReference(), prototype)
+}
+
+// ForEach calls fn for every child until fn returns false or error
+func (l *ChildrenList) ForEach(fn func(ref core.RecordRef) (bool, error)) error {
+	iterator, err := proxyctx.Current.GetObjChildrenIterator(l.Parent, l.Prototype, "")
+	if err != nil {
+		return fmt.Errorf("[ ChildrenList.ForEach ] Can't get children: %s", err.Error())
+	}
+	for iterator.HasNext() {
+		ref, err := iterator.Next()
+		if err != nil {
+			return fmt.Errorf("[ ChildrenList.ForEach ] Can't get next child: %s", err.Error())
+		}
+		next, err := fn(ref)
+		if err != nil {
+			return err
+		}
+		if !next {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Page returns children found among amount children records starting from child record from, nil from means
+// the latest child. Page may have less than amount children of list prototype, next is nil on the last page.
+func (l *ChildrenList) Page(from *core.RecordID, amount int) (refs []core.RecordRef, next *core.RecordID, err error) {
+	refs, next, err = proxyctx.Current.GetObjChildrenPage(l.Parent, l.Prototype, from, amount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("[ ChildrenList.Page ] Can't get children: %s", err.Error())
+	}
+	return refs, next, nil
+}
+
+// Map is a string map split into buckets which are stored as children of the object owning the map.
+// Owner keeps only references to buckets in its state, bucket is loaded when one of its keys is accessed.
+// BucketPrototype must be a prototype of contract with methods of application/contract/bucket.
+type Map struct {
+	BucketPrototype core.RecordRef
+	Buckets         []core.RecordRef
+}
+
+// NewMap creates map with fixed number of buckets, buckets are created on first write
+func NewMap(bucketPrototype core.RecordRef, buckets int) *Map {
+	if buckets <= 0 {
+		buckets = 1
+	}
+	return &Map{
+		BucketPrototype: bucketPrototype,
+		Buckets:         make([]core.RecordRef, buckets),
+	}
+}
+
+func (m *Map) bucketIndex(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(m.Buckets)))
+}
+
+// Get returns value stored by key and whether it exists
+func (m *Map) Get(key string) (string, bool, error) {
+	bucket := m.Buckets[m.bucketIndex(key)]
+	if bucket.IsEmpty() {
+		return "", false, nil
+	}
+
+	var value string
+	var found bool
+	err := m.call(bucket, "Get", []interface{}{key}, &value, &found)
+	if err != nil {
+		return "", false, fmt.Errorf("[ Map.Get ] %s", err.Error())
+	}
+	return value, found, nil
+}
+
+// Set stores value by key, it must be called from contract owning the map
+func (m *Map) Set(key string, value string) error {
+	i := m.bucketIndex(key)
+	if m.Buckets[i].IsEmpty() {
+		bucket, err := m.newBucket()
+		if err != nil {
+			return fmt.Errorf("[ Map.Set ] %s", err.Error())
+		}
+		m.Buckets[i] = bucket
+	}
+
+	err := m.call(m.Buckets[i], "Set", []interface{}{key, value})
+	if err != nil {
+		return fmt.Errorf("[ Map.Set ] %s", err.Error())
+	}
+	return nil
+}
+
+// Delete removes key from map, it must be called from contract owning the map
+func (m *Map) Delete(key string) error {
+	bucket := m.Buckets[m.bucketIndex(key)]
+	if bucket.IsEmpty() {
+		return nil
+	}
+
+	err := m.call(bucket, "Delete", []interface{}{key})
+	if err != nil {
+		return fmt.Errorf("[ Map.Delete ] %s", err.Error())
+	}
+	return nil
+}
+
+// ForEach calls fn for every entry until fn returns false or error. Buckets are loaded one by one,
+// entries of one bucket are passed in order of keys
+func (m *Map) ForEach(fn func(key string, value string) (bool, error)) error {
+	for _, bucket := range m.Buckets {
+		if bucket.IsEmpty() {
+			continue
+		}
+
+		var entries map[string]string
+		err := m.call(bucket, "Entries", []interface{}{}, &entries)
+		if err != nil {
+			return fmt.Errorf("[ Map.ForEach ] %s", err.Error())
+		}
+
+		keys := make([]string, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			next, err := fn(key, entries[key])
+			if err != nil {
+				return err
+			}
+			if !next {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Map) newBucket() (core.RecordRef, error) {
+	var argsSerialized []byte
+	err := proxyctx.Current.Serialize([0]interface{}{}, &argsSerialized)
+	if err != nil {
+		return core.RecordRef{}, err
+	}
+	return proxyctx.Current.SaveAsChild(*GetContext().Callee, m.BucketPrototype, "New", argsSerialized)
+}
+
+// call calls method of bucket the same way generated proxies do, results are decoded into
+// results pointers, error returned by the method is returned as error
+func (m *Map) call(bucket core.RecordRef, method string, args []interface{}, results ...interface{}) error {
+	var argsSerialized []byte
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	res, err := proxyctx.Current.RouteCall(bucket, true, method, argsSerialized, m.BucketPrototype)
+	if err != nil {
+		return err
+	}
+
+	var methodErr *Error
+	ret := make([]interface{}, 0, len(results)+1)
+	ret = append(ret, results...)
+	ret = append(ret, &methodErr)
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return err
+	}
+	if methodErr != nil {
+		return methodErr
+	}
+	return nil
+}
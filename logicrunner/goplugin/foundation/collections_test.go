@@ -0,0 +1,166 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package foundation server implementation of smartcontract functions
+package foundation
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/tylerb/gls"
+	"github.com/ugorji/go/codec"
+)
+
+// bucketsHelper emulates bucket contracts and children of one object
+type bucketsHelper struct {
+	proxyctx.ProxyHelper
+
+	children []core.RecordRef
+	buckets  map[core.RecordRef]map[string]string
+}
+
+func (h *bucketsHelper) Serialize(what interface{}, to *[]byte) error {
+	return codec.NewEncoderBytes(to, new(codec.CborHandle)).Encode(what)
+}
+
+func (h *bucketsHelper) Deserialize(from []byte, into interface{}) error {
+	return codec.NewDecoderBytes(from, new(codec.CborHandle)).Decode(into)
+}
+
+func (h *bucketsHelper) GetObjChildrenIterator(head core.RecordRef, prototype core.RecordRef, iteratorID string) (*proxyctx.ChildrenTypedIterator, error) {
+	return &proxyctx.ChildrenTypedIterator{Parent: head, ChildPrototype: prototype, Buff: h.children}, nil
+}
+
+func (h *bucketsHelper) GetObjChildrenPage(head core.RecordRef, prototype core.RecordRef, from *core.RecordID, amount int) ([]core.RecordRef, *core.RecordID, error) {
+	start := 0
+	if from != nil {
+		for start < len(h.children) && *h.children[start].Record() != *from {
+			start++
+		}
+	}
+	end := start + amount
+	if end >= len(h.children) {
+		return h.children[start:], nil, nil
+	}
+	return h.children[start:end], h.children[end].Record(), nil
+}
+
+func (h *bucketsHelper) SaveAsChild(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error) {
+	ref := testutils.RandomRef()
+	h.buckets[ref] = map[string]string{}
+	return ref, nil
+}
+
+func (h *bucketsHelper) RouteCall(ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef) ([]byte, error) {
+	var params []interface{}
+	if err := h.Deserialize(args, &params); err != nil {
+		return nil, err
+	}
+	items := h.buckets[ref]
+
+	var res []interface{}
+	switch method {
+	case "Get":
+		value, ok := items[params[0].(string)]
+		res = []interface{}{value, ok, nil}
+	case "Set":
+		items[params[0].(string)] = params[1].(string)
+		res = []interface{}{nil}
+	case "Delete":
+		delete(items, params[0].(string))
+		res = []interface{}{nil}
+	case "Entries":
+		res = []interface{}{items, nil}
+	}
+
+	var data []byte
+	err := h.Serialize(res, &data)
+	return data, err
+}
+
+func withHelper(t *testing.T, h proxyctx.ProxyHelper, fn func()) {
+	old := proxyctx.Current
+	proxyctx.Current = h
+	defer func() { proxyctx.Current = old }()
+
+	callee := testutils.RandomRef()
+	gls.With(gls.Values{"callCtx": &core.LogicCallContext{Callee: &callee}}, fn)
+}
+
+func TestChildrenList_Page(t *testing.T) {
+	h := &bucketsHelper{}
+	for i := 0; i < 10; i++ {
+		h.children = append(h.children, testutils.RandomRef())
+	}
+
+	withHelper(t, h, func() {
+		list := NewChildrenList(testutils.RandomRef(), testutils.RandomRef())
+
+		page, next, err := list.Page(nil, 4)
+		require.NoError(t, err)
+		require.Equal(t, h.children[0:4], page)
+		require.Equal(t, h.children[4].Record(), next)
+
+		page, next, err = list.Page(next, 4)
+		require.NoError(t, err)
+		require.Equal(t, h.children[4:8], page)
+
+		page, next, err = list.Page(next, 4)
+		require.NoError(t, err)
+		require.Equal(t, h.children[8:], page)
+		require.Nil(t, next)
+	})
+}
+
+func TestMap(t *testing.T) {
+	h := &bucketsHelper{buckets: map[core.RecordRef]map[string]string{}}
+
+	withHelper(t, h, func() {
+		m := NewMap(testutils.RandomRef(), 4)
+
+		_, ok, err := m.Get("missing")
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Empty(t, h.buckets)
+
+		for _, key := range []string{"a", "b", "c", "d", "e"} {
+			require.NoError(t, m.Set(key, "value_"+key))
+		}
+		require.True(t, len(h.buckets) <= 4)
+
+		value, ok, err := m.Get("c")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "value_c", value)
+
+		require.NoError(t, m.Delete("c"))
+		_, ok, err = m.Get("c")
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		entries := map[string]string{}
+		err = m.ForEach(func(key string, value string) (bool, error) {
+			entries[key] = value
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"a": "value_a", "b": "value_b", "d": "value_d", "e": "value_e"}, entries)
+	})
+}
@@ -18,6 +18,8 @@
 package foundation
 
 import (
+	"strings"
+
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 	"github.com/tylerb/gls"
@@ -25,6 +27,8 @@ import (
 
 // BaseContract is a base class for all contracts.
 type BaseContract struct {
+	// StateSchemaVersion is a schema version of contract state, see RegisterStateSchema
+	StateSchemaVersion uint
 }
 
 // ProxyInterface interface any proxy of a contract implements
@@ -77,6 +81,11 @@ func GetContext() *core.LogicCallContext {
 	}
 }
 
+// GetContractLimits returns limits applied to contracts in current pulse.
+func GetContractLimits() core.ContractLimits {
+	return GetContext().Pulse.GetContractLimits()
+}
+
 // GetImplementationFor finds delegate typed r in object and returns it
 func GetImplementationFor(object, ofType core.RecordRef) (core.RecordRef, error) {
 	return proxyctx.Current.GetDelegate(object, ofType)
@@ -111,3 +120,9 @@ type Error struct {
 func (e *Error) Error() string {
 	return e.S
 }
+
+// IsStateTooBig checks if error returned by ledger or by call of another contract means that
+// object state exceeded maximum size. Errors reach contracts as strings, so message is checked.
+func IsStateTooBig(err error) bool {
+	return err != nil && strings.Contains(err.Error(), core.ErrStateTooBig.Error())
+}
@@ -0,0 +1,34 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStateTooBig(t *testing.T) {
+	require.False(t, IsStateTooBig(nil))
+	require.False(t, IsStateTooBig(errors.New("some error")))
+	require.True(t, IsStateTooBig(core.ErrStateTooBig))
+	require.True(t, IsStateTooBig(errors.Wrap(core.ErrStateTooBig, "contract limits exceeded")))
+	// errors of other contracts come as foundation.Error
+	require.True(t, IsStateTooBig(&Error{S: errors.Wrap(core.ErrStateTooBig, "call failed").Error()}))
+}
@@ -0,0 +1,124 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+)
+
+// StateMigration upgrades serialized contract state of some schema version to the next one
+type StateMigration func(state []byte) ([]byte, error)
+
+type stateSchema struct {
+	version    uint
+	migrations map[uint]StateMigration
+}
+
+var (
+	stateSchemas     = map[reflect.Type]stateSchema{}
+	stateSchemasLock sync.RWMutex
+)
+
+// RegisterStateSchema declares current schema version of contract state, should be called from init of contract,
+// migrations[N] upgrades state of version N to N+1, states saved before schema was declared have version 0
+//    foundation.RegisterStateSchema(&Wallet{}, 1, map[uint]foundation.StateMigration{0: migrateWalletV0})
+func RegisterStateSchema(contract interface{}, version uint, migrations map[uint]StateMigration) {
+	for v := uint(0); v < version; v++ {
+		if migrations[v] == nil {
+			panic(fmt.Sprintf("no migration of %T state from version %d", contract, v))
+		}
+	}
+
+	stateSchemasLock.Lock()
+	defer stateSchemasLock.Unlock()
+	stateSchemas[reflect.TypeOf(contract)] = stateSchema{version: version, migrations: migrations}
+}
+
+func getStateSchema(contract interface{}) (stateSchema, bool) {
+	stateSchemasLock.RLock()
+	defer stateSchemasLock.RUnlock()
+	schema, ok := stateSchemas[reflect.TypeOf(contract)]
+	return schema, ok
+}
+
+type stateVersioned interface {
+	setStateSchemaVersion(version uint)
+}
+
+func (bc *BaseContract) setStateSchemaVersion(version uint) {
+	bc.StateSchemaVersion = version
+}
+
+// InitStateSchema sets current schema version on newly constructed contract
+func InitStateSchema(self interface{}) {
+	schema, ok := getStateSchema(self)
+	if !ok {
+		return
+	}
+	if versioned, ok := self.(stateVersioned); ok {
+		versioned.setStateSchemaVersion(schema.version)
+	}
+}
+
+// DeserializeState deserializes contract state into self, state saved with older schema version
+// is upgraded by registered migrations first and the migration is recorded on ledger
+func DeserializeState(state []byte, self interface{}) error {
+	ph := proxyctx.Current
+	schema, ok := getStateSchema(self)
+	if !ok {
+		return ph.Deserialize(state, self)
+	}
+
+	var saved struct {
+		StateSchemaVersion uint
+	}
+	err := ph.Deserialize(state, &saved)
+	if err != nil {
+		return err
+	}
+	if saved.StateSchemaVersion > schema.version {
+		return fmt.Errorf(
+			"[ DeserializeState ] state schema version %d is newer than %d of contract",
+			saved.StateSchemaVersion, schema.version,
+		)
+	}
+	for v := saved.StateSchemaVersion; v < schema.version; v++ {
+		state, err = schema.migrations[v](state)
+		if err != nil {
+			return fmt.Errorf("[ DeserializeState ] Can't migrate state from version %d: %s", v, err.Error())
+		}
+	}
+
+	err = ph.Deserialize(state, self)
+	if err != nil {
+		return err
+	}
+	if saved.StateSchemaVersion == schema.version {
+		return nil
+	}
+
+	InitStateSchema(self)
+	callee := GetContext().Callee
+	if callee == nil {
+		return fmt.Errorf("[ DeserializeState ] context has no callee set")
+	}
+	return ph.RecordStateMigration(*callee, saved.StateSchemaVersion, schema.version)
+}
@@ -0,0 +1,116 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/require"
+)
+
+// migrationHelper records migrations of state reported by DeserializeState
+type migrationHelper struct {
+	bucketsHelper
+
+	migrations [][2]uint
+}
+
+func (h *migrationHelper) RecordStateMigration(object core.RecordRef, from, to uint) error {
+	h.migrations = append(h.migrations, [2]uint{from, to})
+	return nil
+}
+
+type walletV0 struct {
+	BaseContract
+	Balance string
+}
+
+type walletV1 struct {
+	BaseContract
+	Balance uint
+}
+
+type wallet struct {
+	BaseContract
+	Balance  uint
+	Currency string
+}
+
+func TestDeserializeState(t *testing.T) {
+	h := &migrationHelper{}
+	RegisterStateSchema(&wallet{}, 2, map[uint]StateMigration{
+		0: func(state []byte) ([]byte, error) {
+			old := walletV0{}
+			if err := h.Deserialize(state, &old); err != nil {
+				return nil, err
+			}
+			balance, err := strconv.ParseUint(old.Balance, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			var res []byte
+			err = h.Serialize(walletV1{Balance: uint(balance)}, &res)
+			return res, err
+		},
+		1: func(state []byte) ([]byte, error) {
+			old := walletV1{}
+			if err := h.Deserialize(state, &old); err != nil {
+				return nil, err
+			}
+			var res []byte
+			err := h.Serialize(wallet{Balance: old.Balance, Currency: "XNS"}, &res)
+			return res, err
+		},
+	})
+
+	withHelper(t, h, func() {
+		var state []byte
+		require.NoError(t, h.Serialize(walletV0{Balance: "42"}, &state))
+
+		w := &wallet{}
+		require.NoError(t, DeserializeState(state, w))
+		require.Equal(t, &wallet{BaseContract: BaseContract{StateSchemaVersion: 2}, Balance: 42, Currency: "XNS"}, w)
+		require.Equal(t, [][2]uint{{0, 2}}, h.migrations)
+
+		require.NoError(t, h.Serialize(w, &state))
+		w = &wallet{}
+		require.NoError(t, DeserializeState(state, w))
+		require.Equal(t, uint(42), w.Balance)
+		require.Len(t, h.migrations, 1)
+
+		require.NoError(t, h.Serialize(wallet{BaseContract: BaseContract{StateSchemaVersion: 3}}, &state))
+		require.Error(t, DeserializeState(state, &wallet{}))
+
+		require.NoError(t, h.Serialize(walletV0{Balance: "7"}, &state))
+		old := &walletV0{}
+		require.NoError(t, DeserializeState(state, old))
+		require.Equal(t, "7", old.Balance)
+		require.Len(t, h.migrations, 1)
+	})
+
+	w := &wallet{}
+	InitStateSchema(w)
+	require.Equal(t, uint(2), w.StateSchemaVersion)
+}
+
+func TestRegisterStateSchema_MissingMigration(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterStateSchema(&walletV1{}, 2, map[uint]StateMigration{0: nil, 1: nil})
+	})
+}
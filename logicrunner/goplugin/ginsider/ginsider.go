@@ -33,8 +33,8 @@ import (
 
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 
+	"github.com/insolar/insolar/utils/pool"
 	"github.com/pkg/errors"
-	"github.com/ugorji/go/codec"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -390,6 +390,35 @@ func (gi *GoInsider) GetObjChildrenIterator(obj core.RecordRef, prototype core.R
 	}, nil
 }
 
+// GetObjChildrenPage rpc call to insolard service, returns one page of children of object with specified
+// prototype starting from child record from, and child record to start the next page from
+func (gi *GoInsider) GetObjChildrenPage(obj core.RecordRef, prototype core.RecordRef, from *core.RecordID, amount int) ([]core.RecordRef, *core.RecordID, error) {
+	client, err := gi.Upstream()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := rpctypes.UpGetObjChildrenPageResp{}
+	req := rpctypes.UpGetObjChildrenPageReq{
+		UpBaseReq: MakeUpBaseReq(),
+
+		Obj:       obj,
+		Prototype: prototype,
+		From:      from,
+		Amount:    amount,
+	}
+	err = client.Call("RPC.GetObjChildrenPage", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Fatal("GetObjChildrenPage: ginsider can't connect to insgocc, shutdown")
+			os.Exit(0)
+		}
+		return nil, nil, errors.Wrap(err, "on calling main API RPC.GetObjChildrenPage")
+	}
+
+	return res.Refs, res.NextFrom, nil
+}
+
 // SaveAsDelegate ...
 func (gi *GoInsider) SaveAsDelegate(intoRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error) {
 	client, err := gi.Upstream()
@@ -468,18 +497,44 @@ func (gi *GoInsider) DeactivateObject(object core.RecordRef) error {
 	return nil
 }
 
+// RecordStateMigration ...
+func (gi *GoInsider) RecordStateMigration(object core.RecordRef, from, to uint) error {
+	client, err := gi.Upstream()
+	if err != nil {
+		return err
+	}
+
+	req := rpctypes.UpRecordStateMigrationReq{
+		UpBaseReq: MakeUpBaseReq(),
+		From:      from,
+		To:        to,
+	}
+
+	res := rpctypes.UpRecordStateMigrationResp{}
+	err = client.Call("RPC.RecordStateMigration", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Error("Insgorund can't connect to Insolard")
+			os.Exit(0)
+		}
+		return errors.Wrap(err, "[ RecordStateMigration ] on calling main API")
+	}
+
+	return nil
+}
+
 // Serialize - CBOR serializer wrapper: `what` -> `to`
 func (gi *GoInsider) Serialize(what interface{}, to *[]byte) error {
-	ch := new(codec.CborHandle)
 	log.Debugf("serializing %+v", what)
-	return codec.NewEncoderBytes(to, ch).Encode(what)
+	data, err := pool.MarshalCBOR((*to)[:0], what)
+	*to = data
+	return err
 }
 
 // Deserialize - CBOR de-serializer wrapper: `from` -> `into`
 func (gi *GoInsider) Deserialize(from []byte, into interface{}) error {
-	ch := new(codec.CborHandle)
 	log.Debugf("de-serializing %+v", from)
-	return codec.NewDecoderBytes(from, ch).Decode(into)
+	return pool.UnmarshalCBOR(from, into)
 }
 
 // MakeErrorSerializable converts errors satisfying error interface to foundation.Error
@@ -166,6 +166,20 @@ func (t *TestArtifactManager) GetChildren(ctx context.Context, parent core.Recor
 	panic("implement me")
 }
 
+// GetChildrenPage implementation for tests
+func (t *TestArtifactManager) GetChildrenPage(
+	ctx context.Context, parent core.RecordRef, from *core.RecordID, amount int,
+) ([]core.RecordRef, *core.RecordID, error) {
+	panic("implement me")
+}
+
+// GetObjectsByPrototype implementation for tests
+func (t *TestArtifactManager) GetObjectsByPrototype(
+	ctx context.Context, prototype core.RecordRef, from *core.RecordRef, amount int,
+) ([]core.RecordRef, *core.RecordRef, error) {
+	panic("implement me")
+}
+
 // NewTestArtifactManager implementation for tests
 func NewTestArtifactManager() *TestArtifactManager {
 	return &TestArtifactManager{
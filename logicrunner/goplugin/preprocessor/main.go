@@ -39,6 +39,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
 
 	"github.com/pkg/errors"
 )
@@ -46,6 +47,7 @@ import (
 var foundationPath = "github.com/insolar/insolar/logicrunner/goplugin/foundation"
 var proxyctxPath = "github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 var corePath = "github.com/insolar/insolar/core"
+var argschemaPath = "github.com/insolar/insolar/logicrunner/argschema"
 
 // ParsedFile struct with prepared info we extract from source code
 type ParsedFile struct {
@@ -263,6 +265,33 @@ func (pf *ParsedFile) functionInfoForWrapper(list []*ast.FuncDecl) []map[string]
 	return res
 }
 
+// ArgumentSchema generates schema of arguments of contract's methods and constructors
+func (pf *ParsedFile) ArgumentSchema() *argschema.Schema {
+	schema := argschema.New()
+	for _, method := range pf.methods[pf.contract] {
+		schema.Methods[method.Name.Name] = pf.schemaArguments(method.Type.Params)
+	}
+	for _, constructor := range pf.constructors[pf.contract] {
+		schema.Constructors[constructor.Name.Name] = pf.schemaArguments(constructor.Type.Params)
+	}
+	return schema
+}
+
+func (pf *ParsedFile) schemaArguments(params *ast.FieldList) []argschema.Argument {
+	res := make([]argschema.Argument, 0, params.NumFields())
+	for _, field := range params.List {
+		typ := pf.codeOfNode(field.Type)
+		if len(field.Names) == 0 {
+			res = append(res, argschema.Argument{Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			res = append(res, argschema.Argument{Name: name.Name, Type: typ})
+		}
+	}
+	return res
+}
+
 // WriteProxy generates and writes into `out` source code of contract's proxy
 func (pf *ParsedFile) WriteProxy(classReference string, out io.Writer) error {
 	proxyPackageName, err := pf.ProxyPackageName()
@@ -333,6 +362,9 @@ func (pf *ParsedFile) functionInfoForProxy(list []*ast.FuncDecl) []map[string]st
 			"ResultsWithErr":  commaAppend(numberedVarsI(fun.Type.Results.NumFields()-1, "ret"), "err"),
 			"ResultsNilError": commaAppend(numberedVarsI(fun.Type.Results.NumFields()-1, "ret"), "nil"),
 			"ResultsTypes":    genFieldList(pf, fun.Type.Results, false),
+
+			"DescriptorArguments": generateDescriptorArguments(pf.schemaArguments(fun.Type.Params)),
+			"DescriptorResults":   generateDescriptorResults(pf, fun.Type.Results),
 		}
 		res = append(res, info)
 	}
@@ -364,8 +396,11 @@ func (pf *ParsedFile) typeName(t ast.Expr) string {
 func (pf *ParsedFile) generateImports(wrapper bool) map[string]bool {
 	imports := make(map[string]bool)
 	imports[fmt.Sprintf(`"%s"`, proxyctxPath)] = true
-	if !wrapper {
+	if wrapper {
+		imports[fmt.Sprintf(`"%s"`, foundationPath)] = true
+	} else {
 		imports[fmt.Sprintf(`"%s"`, corePath)] = true
+		imports[fmt.Sprintf(`"%s"`, argschemaPath)] = true
 	}
 	for _, method := range pf.methods[pf.contract] {
 		extendImportsMap(pf, method.Type.Params, imports)
@@ -550,6 +585,40 @@ func genFieldList(parsed *ParsedFile, params *ast.FieldList, withNames bool) str
 	return res
 }
 
+// generateDescriptorArguments returns arguments of method as literal for proxy descriptor
+func generateDescriptorArguments(args []argschema.Argument) string {
+	res := ""
+	for i, arg := range args {
+		if i > 0 {
+			res += ", "
+		}
+		res += fmt.Sprintf("{Name: %q, Type: %q}", arg.Name, arg.Type)
+	}
+	return res
+}
+
+// generateDescriptorResults returns result types of method as literal for proxy descriptor
+func generateDescriptorResults(parsed *ParsedFile, list *ast.FieldList) string {
+	res := ""
+	if list == nil {
+		return res
+	}
+	for i, field := range list.List {
+		typ := fmt.Sprintf("%q", parsed.codeOfNode(field.Type))
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			if i > 0 || j > 0 {
+				res += ", "
+			}
+			res += typ
+		}
+	}
+	return res
+}
+
 func generateInitArguments(list *ast.FieldList) string {
 	initArgs := ""
 	initArgs += fmt.Sprintf("var args [%d]interface{}\n", list.NumFields())
@@ -30,6 +30,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
 )
 
@@ -654,3 +655,41 @@ func TestProxyGeneration(t *testing.T) {
 		})
 	}
 }
+
+func TestArgumentSchema(t *testing.T) {
+	t.Parallel()
+	tmpDir, err := ioutil.TempDir("", "test-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir) //nolint: errcheck
+
+	testContract := "/test.go"
+
+	err = goplugintestutils.WriteFile(tmpDir, testContract, `
+package main
+
+type A struct{
+	foundation.BaseContract
+}
+
+func New(name string) (*A, error) {
+	return nil, nil
+}
+
+func (a *A) Get(a, b int, c []byte, d *core.RecordRef) (int, error) {
+	return
+}
+`)
+	assert.NoError(t, err)
+
+	parsed, err := ParseFile(tmpDir + testContract)
+	require.NoError(t, err)
+
+	schema := parsed.ArgumentSchema()
+	require.Equal(t, []argschema.Argument{
+		{Name: "a", Type: "int"},
+		{Name: "b", Type: "int"},
+		{Name: "c", Type: "[]byte"},
+		{Name: "d", Type: "*core.RecordRef"},
+	}, schema.Methods["Get"])
+	require.Equal(t, []argschema.Argument{{Name: "name", Type: "string"}}, schema.Constructors["New"])
+}
@@ -207,3 +207,28 @@ func (r *{{ $.ContractType }}) {{ $method.Name }}NoWait( {{ $method.Arguments }}
 	return nil
 }
 {{ end }}
+
+func init() {
+	proxyctx.RegisterPrototype(proxyctx.PrototypeDescriptor{
+		Name:      "{{ .ContractType }}",
+		Reference: *PrototypeReference,
+		Methods: []proxyctx.MethodDescriptor{
+			{{- range $method := .MethodsProxies }}
+			{
+				Name:      "{{ $method.Name }}",
+				Arguments: []argschema.Argument{ {{ $method.DescriptorArguments }} },
+				Results:   []string{ {{ $method.DescriptorResults }} },
+			},
+			{{- end }}
+		},
+		Constructors: []proxyctx.MethodDescriptor{
+			{{- range $func := .ConstructorsProxies }}
+			{
+				Name:      "{{ $func.Name }}",
+				Arguments: []argschema.Argument{ {{ $func.DescriptorArguments }} },
+				Results:   []string{ {{ $func.DescriptorResults }} },
+			},
+			{{- end }}
+		},
+	})
+}
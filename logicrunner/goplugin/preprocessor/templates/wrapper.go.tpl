@@ -38,7 +38,7 @@ func INSMETHOD_GetCode(object []byte, data []byte) ([]byte, []byte, error) {
 		return nil, nil, &ExtendableError{ S: "[ Fake GetCode ] ( Generated Method ) Object is nil"}
 	}
 
-    err := ph.Deserialize(object, self)
+    err := foundation.DeserializeState(object, self)
 	if err != nil {
 		e := &ExtendableError{ S: "[ Fake GetCode ] ( Generated Method ) Can't deserialize args.Data: " + err.Error() }
 		return nil, nil, e
@@ -64,7 +64,7 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 		return nil, nil, &ExtendableError{ S: "[ Fake GetPrototype ] ( Generated Method ) Object is nil"}
 	}
 
-    err := ph.Deserialize(object, self)
+    err := foundation.DeserializeState(object, self)
 	if err != nil {
 		e := &ExtendableError{ S: "[ Fake GetPrototype ] ( Generated Method ) Can't deserialize args.Data: " + err.Error() }
 		return nil, nil, e
@@ -92,7 +92,7 @@ func INSMETHOD_{{ $method.Name }}(object []byte, data []byte) ([]byte, []byte, e
 		return nil, nil, &ExtendableError{ S: "[ Fake{{ $method.Name }} ] ( INSMETHOD_* ) ( Generated Method ) Object is nil"}
 	}
 
-    err := ph.Deserialize(object, self)
+    err := foundation.DeserializeState(object, self)
     if err != nil {
         e := &ExtendableError{ S: "[ Fake{{ $method.Name }} ] ( INSMETHOD_* ) ( Generated Method ) Can't deserialize args.Data: " + err.Error() }
         return nil, nil, e
@@ -144,17 +144,18 @@ func INSCONSTRUCTOR_{{ $f.Name }}(data []byte) ([]byte, error) {
         return nil, ret1
     }
 
+    if ret0 == nil {
+        e := &ExtendableError{ S: "[ Fake{{ $f.Name }} ] ( INSCONSTRUCTOR_* ) ( Generated Method ) Constructor returns nil" }
+        return nil, e
+    }
+    foundation.InitStateSchema(ret0)
+
     ret := []byte{}
     err = ph.Serialize(ret0, &ret)
     if err != nil {
         return nil, err
     }
 
-    if ret0 == nil {
-        e := &ExtendableError{ S: "[ Fake{{ $f.Name }} ] ( INSCONSTRUCTOR_* ) ( Generated Method ) Constructor returns nil" }
-        return nil, e
-    }
-
     return ret, err
 }
 {{ end }}
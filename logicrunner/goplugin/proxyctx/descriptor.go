@@ -0,0 +1,70 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package proxyctx
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/argschema"
+)
+
+// MethodDescriptor describes signature of contract method or constructor
+type MethodDescriptor struct {
+	Name      string               `json:"name"`
+	Arguments []argschema.Argument `json:"arguments"`
+	Results   []string             `json:"results"`
+}
+
+// PrototypeDescriptor describes callable prototype, generated proxies register it on init
+type PrototypeDescriptor struct {
+	Name         string             `json:"name"`
+	Reference    core.RecordRef     `json:"-"`
+	Methods      []MethodDescriptor `json:"methods"`
+	Constructors []MethodDescriptor `json:"constructors"`
+}
+
+var (
+	descriptors     = make(map[core.RecordRef]PrototypeDescriptor)
+	descriptorsLock sync.RWMutex
+)
+
+// RegisterPrototype adds prototype descriptor to the registry, descriptor with the same reference is replaced
+func RegisterPrototype(desc PrototypeDescriptor) {
+	descriptorsLock.Lock()
+	defer descriptorsLock.Unlock()
+	descriptors[desc.Reference] = desc
+}
+
+// Prototypes returns all registered prototype descriptors ordered by name
+func Prototypes() []PrototypeDescriptor {
+	descriptorsLock.RLock()
+	res := make([]PrototypeDescriptor, 0, len(descriptors))
+	for _, desc := range descriptors {
+		res = append(res, desc)
+	}
+	descriptorsLock.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].Reference.String() < res[j].Reference.String()
+	})
+	return res
+}
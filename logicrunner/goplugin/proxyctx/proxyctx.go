@@ -25,9 +25,11 @@ type ProxyHelper interface {
 	RouteCall(ref core.RecordRef, wait bool, method string, args []byte, proxyPrototype core.RecordRef) ([]byte, error)
 	SaveAsChild(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error)
 	GetObjChildrenIterator(head core.RecordRef, prototype core.RecordRef, iteratorID string) (*ChildrenTypedIterator, error)
+	GetObjChildrenPage(head core.RecordRef, prototype core.RecordRef, from *core.RecordID, amount int) ([]core.RecordRef, *core.RecordID, error)
 	SaveAsDelegate(parentRef, classRef core.RecordRef, constructorName string, argsSerialized []byte) (core.RecordRef, error)
 	GetDelegate(object, ofType core.RecordRef) (core.RecordRef, error)
 	DeactivateObject(object core.RecordRef) error
+	RecordStateMigration(object core.RecordRef, from, to uint) error
 	Serialize(what interface{}, to *[]byte) error
 	Deserialize(from []byte, into interface{}) error
 	MakeErrorSerializable(error) error
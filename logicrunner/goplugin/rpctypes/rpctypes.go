@@ -118,6 +118,21 @@ type UpGetObjChildrenIteratorResp struct {
 	Iterator ChildIterator
 }
 
+// UpGetObjChildrenPageReq is a set of arguments for GetObjChildrenPage RPC in goplugin
+type UpGetObjChildrenPageReq struct {
+	UpBaseReq
+	Obj       core.RecordRef
+	Prototype core.RecordRef
+	From      *core.RecordID
+	Amount    int
+}
+
+// UpGetObjChildrenPageResp is response from GetObjChildrenPage RPC in goplugin
+type UpGetObjChildrenPageResp struct {
+	Refs     []core.RecordRef
+	NextFrom *core.RecordID
+}
+
 // ChildIterator hold an iterator data of GetObjChildrenIterator method
 type ChildIterator struct {
 	ID       string
@@ -159,3 +174,14 @@ type UpDeactivateObjectReq struct {
 // UpDeactivateObjectResp is response from DeactivateObject RPC in goplugin
 type UpDeactivateObjectResp struct {
 }
+
+// UpRecordStateMigrationReq is a set of arguments for RecordStateMigration RPC in goplugin
+type UpRecordStateMigrationReq struct {
+	UpBaseReq
+	From uint
+	To   uint
+}
+
+// UpRecordStateMigrationResp is response from RecordStateMigration RPC in goplugin
+type UpRecordStateMigrationResp struct {
+}
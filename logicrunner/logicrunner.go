@@ -37,12 +37,16 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/watchdog"
+	"github.com/insolar/insolar/logicrunner/argschema"
 	"github.com/insolar/insolar/logicrunner/builtin"
 	"github.com/insolar/insolar/logicrunner/goplugin"
 )
 
 const maxQueueLength = 10
 
+var executionsCounter = watchdog.NewCounter("logicrunner.executions", "github.com/insolar/insolar/logicrunner")
+
 type Ref = core.RecordRef
 
 // Context of one contract execution
@@ -62,6 +66,7 @@ type ExecutionState struct {
 
 	objectbody *ObjectBody
 	deactivate bool
+	migration  *message.StateMigration
 	nonce      uint64
 
 	Behaviour ValidationBehaviour
@@ -220,6 +225,11 @@ type LogicRunner struct {
 	state      map[Ref]*ObjectState // if object exists, we are validating or executing it right now
 	stateMutex sync.RWMutex
 
+	argSchemas     map[Ref]*argschema.Schema // argument schemas by prototype
+	argSchemasLock sync.RWMutex
+
+	tracer *executionTracer
+
 	sock net.Listener
 }
 
@@ -229,8 +239,10 @@ func NewLogicRunner(cfg *configuration.LogicRunner) (*LogicRunner, error) {
 		return nil, errors.New("LogicRunner have nil configuration")
 	}
 	res := LogicRunner{
-		Cfg:   cfg,
-		state: make(map[Ref]*ObjectState),
+		Cfg:        cfg,
+		state:      make(map[Ref]*ObjectState),
+		argSchemas: make(map[Ref]*argschema.Schema),
+		tracer:     newExecutionTracer(cfg.ExecutionTrace),
 	}
 	return &res, nil
 }
@@ -406,7 +418,7 @@ func (lr *LogicRunner) executeActual(ctx context.Context, parcel core.Parcel, ms
 		pulse:   lr.pulse(ctx).PulseNumber,
 	}
 
-	es.Queue = append(es.Queue, qElement)
+	es.enqueue(qElement)
 	es.Unlock()
 
 	err = lr.StartQueueProcessorIfNeeded(ctx, es, msg)
@@ -568,7 +580,9 @@ func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionS
 		inslogger.FromContext(qe.ctx).Debug("Registering request within execution behaviour")
 		es.Behaviour.(*ValidationSaver).NewRequest(qe.parcel, *qe.request, recordingBus)
 
+		executionsCounter.Begin()
 		res.reply, res.err = lr.executeOrValidate(current.Context, es, qe.parcel)
+		executionsCounter.Done()
 
 		inslogger.FromContext(qe.ctx).Debug("Registering result within execution behaviour")
 		err := es.Behaviour.Result(res.reply, res.err)
@@ -785,22 +799,45 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 	current.LogicContext.Prototype = es.objectbody.Prototype
 	current.LogicContext.Code = es.objectbody.CodeRef
 	current.LogicContext.Parent = es.objectbody.Parent
+	if lr.tracer.traced(ctx) {
+		lr.tracer.record(ctx, core.ExecutionTraceEvent{
+			Type:   core.TraceEventCall,
+			Caller: refString(current.LogicContext.Caller),
+			Object: m.ObjectRef.String(),
+			Method: m.Method,
+			State:  stateString(es.objectbody.objDescriptor),
+		})
+	}
 	// it's needed to assure that we call method on ref, that has same prototype as proxy, that we import in contract code
 	if !m.ProxyPrototype.IsEmpty() && !m.ProxyPrototype.Equal(*es.objectbody.Prototype) {
 		return nil, errors.New("proxy call error: try to call method of prototype as method of another prototype")
 	}
 
+	if es.objectbody.Prototype != nil {
+		schema, err := lr.getArgumentSchema(ctx, *es.objectbody.Prototype)
+		if err != nil {
+			return nil, es.WrapError(err, "couldn't get argument schema")
+		}
+		if err := schema.ValidateMethod(m.Method, m.Arguments); err != nil {
+			return nil, es.WrapError(err, "invalid arguments")
+		}
+	}
+
 	executor, err := lr.GetExecutor(es.objectbody.CodeMachineType)
 	if err != nil {
 		return nil, es.WrapError(err, "no executor registered")
 	}
 
+	es.migration = nil
 	newData, result, err := executor.CallMethod(
 		ctx, current.LogicContext, *es.objectbody.CodeRef, es.objectbody.Object, m.Method, m.Arguments,
 	)
 	if err != nil {
 		return nil, es.WrapError(err, "executor error")
 	}
+	if err := checkStateSize(current.LogicContext, newData); err != nil {
+		return nil, es.WrapError(err, "contract limits exceeded")
+	}
 
 	am := lr.ArtifactManager
 	if es.deactivate {
@@ -810,6 +847,7 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't deactivate object")
 		}
+		lr.tracer.record(ctx, core.ExecutionTraceEvent{Type: core.TraceEventDeactivate, Object: m.ObjectRef.String()})
 	} else if !bytes.Equal(es.objectbody.Object, newData) {
 		od, err := am.UpdateObject(ctx, Ref{}, *current.Request, es.objectbody.objDescriptor, newData)
 		if err != nil {
@@ -819,6 +857,21 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 			return nil, es.WrapError(err, "couldn't update object")
 		}
 		es.objectbody.objDescriptor = od
+		if es.migration != nil {
+			_, err := am.RegisterRequest(ctx, m.ObjectRef, &message.Parcel{Msg: es.migration})
+			es.migration = nil
+			if err != nil {
+				return nil, es.WrapError(err, "couldn't record state migration")
+			}
+		}
+		if lr.tracer.traced(ctx) {
+			lr.tracer.record(ctx, core.ExecutionTraceEvent{
+				Type:   core.TraceEventAmend,
+				Object: m.ObjectRef.String(),
+				Method: m.Method,
+				State:  stateString(od),
+			})
+		}
 	}
 	_, err = am.RegisterResult(ctx, m.ObjectRef, *current.Request, result)
 	if err != nil {
@@ -830,6 +883,43 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 	return &reply.CallMethod{Result: result, Request: *current.Request}, nil
 }
 
+// checkStateSize checks that contract memory doesn't exceed limit distributed with pulse.
+func checkStateSize(ctx *core.LogicCallContext, data []byte) error {
+	limit := ctx.Pulse.GetContractLimits().MaxStateSize
+	if uint64(len(data)) > uint64(limit) {
+		return errors.Wrapf(core.ErrStateTooBig, "state size %d exceeds limit %d", len(data), limit)
+	}
+	return nil
+}
+
+// getArgumentSchema returns schema of arguments stored in prototype memory. Schemas are cached, because prototypes
+// are not changed. Returns nil schema for prototypes without it.
+func (lr *LogicRunner) getArgumentSchema(ctx context.Context, protoRef Ref) (*argschema.Schema, error) {
+	lr.argSchemasLock.RLock()
+	schema, ok := lr.argSchemas[protoRef]
+	lr.argSchemasLock.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	protoDesc, err := lr.ArtifactManager.GetObject(ctx, protoRef, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get prototype descriptor")
+	}
+	schema, err = argschema.Deserialize(protoDesc.Memory())
+	if err != nil {
+		return nil, err
+	}
+
+	lr.argSchemasLock.Lock()
+	if lr.argSchemas == nil {
+		lr.argSchemas = make(map[Ref]*argschema.Schema)
+	}
+	lr.argSchemas[protoRef] = schema
+	lr.argSchemasLock.Unlock()
+	return schema, nil
+}
+
 func (lr *LogicRunner) getDescriptorsByPrototypeRef(
 	ctx context.Context, protoRef Ref,
 ) (
@@ -895,6 +985,20 @@ func (lr *LogicRunner) executeConstructorCall(
 	}
 	current.LogicContext.Prototype = protoDesc.HeadRef()
 	current.LogicContext.Code = codeDesc.Ref()
+	lr.tracer.record(ctx, core.ExecutionTraceEvent{
+		Type:   core.TraceEventConstructor,
+		Caller: refString(current.LogicContext.Caller),
+		Object: m.PrototypeRef.String(),
+		Method: m.Name,
+	})
+
+	schema, err := argschema.Deserialize(protoDesc.Memory())
+	if err != nil {
+		return nil, es.WrapError(err, "couldn't get argument schema")
+	}
+	if err := schema.ValidateConstructor(m.Name, m.Arguments); err != nil {
+		return nil, es.WrapError(err, "invalid arguments")
+	}
 
 	executor, err := lr.GetExecutor(codeDesc.MachineType())
 	if err != nil {
@@ -905,6 +1009,9 @@ func (lr *LogicRunner) executeConstructorCall(
 	if err != nil {
 		return nil, es.WrapError(err, "executer error")
 	}
+	if err := checkStateSize(current.LogicContext, newData); err != nil {
+		return nil, es.WrapError(err, "contract limits exceeded")
+	}
 
 	switch m.SaveAs {
 	case message.Child, message.Delegate:
@@ -912,6 +1019,12 @@ func (lr *LogicRunner) executeConstructorCall(
 			ctx,
 			Ref{}, *current.Request, m.ParentRef, m.PrototypeRef, m.SaveAs == message.Delegate, newData,
 		)
+		lr.tracer.record(ctx, core.ExecutionTraceEvent{
+			Type:   core.TraceEventActivate,
+			Caller: m.ParentRef.String(),
+			Object: current.Request.String(),
+			Error:  errString(err),
+		})
 		_, err = lr.ArtifactManager.RegisterResult(ctx, *current.Request, *current.Request, nil)
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't save results")
@@ -994,6 +1107,7 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
 							Requests:              requests,
 							Queue:                 messagesQueue,
 							LedgerHasMoreRequests: es.LedgerHasMoreRequests || ledgerHasMoreRequest,
+							ExecutionOrder:        caseBind.ExecutionOrder(),
 						},
 					)
 				}
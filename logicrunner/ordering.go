@@ -0,0 +1,104 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/pkg/errors"
+)
+
+// Requests to the same object are executed in deterministic order, so executor and
+// validators process them identically. Requests of earlier pulses go first. Within one
+// pulse requests are grouped by caller and ordered by caller's nonce, so calls made by
+// one object keep their order, remaining ties are broken by reference of request record,
+// which is the hash of the request.
+
+// executedBefore reports whether request a should be executed before request b.
+func executedBefore(a, b ExecutionQueueElement) bool {
+	if a.pulse != b.pulse {
+		return a.pulse < b.pulse
+	}
+
+	aBase, bBase := baseLogicMessage(a), baseLogicMessage(b)
+	if cmp := bytes.Compare(aBase.Caller[:], bBase.Caller[:]); cmp != 0 {
+		return cmp < 0
+	}
+	if aBase.Nonce != bBase.Nonce {
+		return aBase.Nonce < bBase.Nonce
+	}
+
+	if a.request == nil || b.request == nil {
+		return a.request != nil
+	}
+	return bytes.Compare(a.request[:], b.request[:]) < 0
+}
+
+func baseLogicMessage(qe ExecutionQueueElement) message.BaseLogicMessage {
+	if qe.parcel == nil {
+		return message.BaseLogicMessage{}
+	}
+	if msg, ok := qe.parcel.Message().(message.IBaseLogicMessage); ok {
+		return *msg.GetBaseLogicMessage()
+	}
+	return message.BaseLogicMessage{}
+}
+
+// enqueue inserts element into the queue keeping execution order, must be called with es.Lock
+func (es *ExecutionState) enqueue(qe ExecutionQueueElement) {
+	i := sort.Search(len(es.Queue), func(i int) bool {
+		return executedBefore(qe, es.Queue[i])
+	})
+	es.Queue = append(es.Queue, ExecutionQueueElement{})
+	copy(es.Queue[i+1:], es.Queue[i:])
+	es.Queue[i] = qe
+}
+
+// ExecutionOrder returns references of requests in order they were executed
+func (cb *CaseBind) ExecutionOrder() []core.RecordRef {
+	if cb == nil {
+		return nil
+	}
+	res := make([]core.RecordRef, len(cb.Requests))
+	for i, req := range cb.Requests {
+		res[i] = req.Request
+	}
+	return res
+}
+
+// checkExecutionOrder compares requests being validated with execution order recorded by executor
+func checkExecutionOrder(requests []CaseRequest, order []core.RecordRef) error {
+	if order == nil {
+		return nil
+	}
+	if len(requests) != len(order) {
+		return errors.Errorf(
+			"execution order mismatch: %d requests to validate, %d executed", len(requests), len(order),
+		)
+	}
+	for i, req := range requests {
+		if req.Request != order[i] {
+			return errors.Errorf(
+				"execution order mismatch at step %d: expected request %s, got %s", i, order[i], req.Request,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionState_enqueue(t *testing.T) {
+	es := &ExecutionState{Queue: make([]ExecutionQueueElement, 0)}
+
+	var elements []ExecutionQueueElement
+	for i := 0; i < 10; i++ {
+		ref := testutils.RandomRef()
+		elements = append(elements, ExecutionQueueElement{request: &ref, pulse: core.FirstPulseNumber + 1})
+	}
+	old := testutils.RandomRef()
+	elements = append(elements, ExecutionQueueElement{request: &old, pulse: core.FirstPulseNumber})
+
+	for _, qe := range elements {
+		es.enqueue(qe)
+	}
+
+	require.Len(t, es.Queue, len(elements))
+	require.Equal(t, &old, es.Queue[0].request)
+	for i := 2; i < len(es.Queue); i++ {
+		require.True(t, bytes.Compare(es.Queue[i-1].request[:], es.Queue[i].request[:]) < 0)
+	}
+}
+
+func TestExecutionState_enqueue_KeepsCallerOrder(t *testing.T) {
+	es := &ExecutionState{Queue: make([]ExecutionQueueElement, 0)}
+	caller := testutils.RandomRef()
+
+	var refs []core.RecordRef
+	for nonce := uint64(1); nonce <= 10; nonce++ {
+		ref := testutils.RandomRef()
+		refs = append(refs, ref)
+		es.enqueue(ExecutionQueueElement{
+			request: &ref,
+			pulse:   core.FirstPulseNumber,
+			parcel: &message.Parcel{Msg: &message.CallMethod{
+				BaseLogicMessage: message.BaseLogicMessage{Caller: caller, Nonce: nonce},
+			}},
+		})
+	}
+
+	for i, qe := range es.Queue {
+		require.Equal(t, refs[i], *qe.request)
+	}
+}
+
+func TestCheckExecutionOrder(t *testing.T) {
+	first, second := testutils.RandomRef(), testutils.RandomRef()
+	requests := []CaseRequest{{Request: first}, {Request: second}}
+
+	require.NoError(t, checkExecutionOrder(requests, nil))
+	require.NoError(t, checkExecutionOrder(requests, []core.RecordRef{first, second}))
+	require.Error(t, checkExecutionOrder(requests, []core.RecordRef{second, first}))
+	require.Error(t, checkExecutionOrder(requests, []core.RecordRef{first}))
+
+	cb := CaseBind{Requests: requests}
+	require.Equal(t, []core.RecordRef{first, second}, cb.ExecutionOrder())
+}
@@ -133,6 +133,13 @@ func (gpr *RPC) RouteCall(req rpctypes.UpRouteReq, rep *rpctypes.UpRouteResp) (e
 		req.Arguments,
 		&req.ProxyPrototype,
 	)
+	gpr.lr.tracer.record(ctx, core.ExecutionTraceEvent{
+		Type:   core.TraceEventRouteCall,
+		Caller: req.Callee.String(),
+		Object: req.Object.String(),
+		Method: req.Method,
+		Error:  errString(err),
+	})
 	if err != nil {
 		return err
 	}
@@ -154,6 +161,13 @@ func (gpr *RPC) SaveAsChild(req rpctypes.UpSaveAsChildReq, rep *rpctypes.UpSaveA
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
 	ref, err := gpr.lr.ContractRequester.CallConstructor(ctx, &bm, false, &req.Prototype, &req.Parent, req.ConstructorName, req.ArgsSerialized, int(message.Child))
+	gpr.lr.tracer.record(ctx, core.ExecutionTraceEvent{
+		Type:   core.TraceEventSaveAs,
+		Caller: req.Callee.String(),
+		Object: refString(ref),
+		Method: req.ConstructorName,
+		Error:  errString(err),
+	})
 
 	rep.Reference = ref
 
@@ -170,6 +184,13 @@ func (gpr *RPC) SaveAsDelegate(req rpctypes.UpSaveAsDelegateReq, rep *rpctypes.U
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
 	ref, err := gpr.lr.ContractRequester.CallConstructor(ctx, &bm, false, &req.Prototype, &req.Into, req.ConstructorName, req.ArgsSerialized, int(message.Delegate))
+	gpr.lr.tracer.record(ctx, core.ExecutionTraceEvent{
+		Type:   core.TraceEventSaveAs,
+		Caller: req.Callee.String(),
+		Object: refString(ref),
+		Method: req.ConstructorName,
+		Error:  errString(err),
+	})
 
 	rep.Reference = ref
 	return err
@@ -201,6 +222,13 @@ func (gpr *RPC) GetObjChildrenIterator(
 
 	if !ok {
 		newIterator, err := am.GetChildren(ctx, req.Obj, nil)
+		gpr.lr.tracer.record(ctx, core.ExecutionTraceEvent{
+			Type:   core.TraceEventRead,
+			Caller: req.Callee.String(),
+			Object: req.Obj.String(),
+			Method: "GetChildren",
+			Error:  errString(err),
+		})
 		if err != nil {
 			return errors.Wrap(err, "[ GetObjChildrenIterator ] Can't get children")
 		}
@@ -259,6 +287,57 @@ func (gpr *RPC) GetObjChildrenIterator(
 	return nil
 }
 
+// GetObjChildrenPage is an RPC returns one page of object children with specified prototype. Page is fetched
+// from ledger by child cursor, so nothing is kept on service side between calls
+func (gpr *RPC) GetObjChildrenPage(
+	req rpctypes.UpGetObjChildrenPageReq,
+	rep *rpctypes.UpGetObjChildrenPageResp,
+) (
+	err error,
+) {
+	defer recoverRPC(&err)
+
+	os := gpr.lr.MustObjectState(req.Callee)
+	es := os.MustModeState(req.Mode)
+	ctx := es.Current.Context
+
+	am := gpr.lr.ArtifactManager
+
+	refs, next, err := am.GetChildrenPage(ctx, req.Obj, req.From, req.Amount)
+	gpr.lr.tracer.record(ctx, core.ExecutionTraceEvent{
+		Type:   core.TraceEventRead,
+		Caller: req.Callee.String(),
+		Object: req.Obj.String(),
+		Method: "GetChildrenPage",
+		Error:  errString(err),
+	})
+	if err != nil {
+		return errors.Wrap(err, "[ GetObjChildrenPage ] Can't get children")
+	}
+
+	rep.Refs = make([]core.RecordRef, 0, len(refs))
+	for _, r := range refs {
+		o, err := am.GetObject(ctx, r, nil, false)
+		if err != nil {
+			if err == core.ErrDeactivated {
+				continue
+			}
+			return errors.Wrap(err, "[ GetObjChildrenPage ] Can't call GetObject on child")
+		}
+		protoRef, err := o.Prototype()
+		if err != nil {
+			return errors.Wrap(err, "[ GetObjChildrenPage ] Can't get prototype reference")
+		}
+
+		if protoRef.Equal(req.Prototype) {
+			rep.Refs = append(rep.Refs, r)
+		}
+	}
+	rep.NextFrom = next
+
+	return nil
+}
+
 // GetDelegate is an RPC saving data as memory of a contract as child a parent
 func (gpr *RPC) GetDelegate(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDelegateResp) (err error) {
 	defer recoverRPC(&err)
@@ -269,6 +348,13 @@ func (gpr *RPC) GetDelegate(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDe
 
 	am := gpr.lr.ArtifactManager
 	ref, err := am.GetDelegate(ctx, req.Object, req.OfType)
+	gpr.lr.tracer.record(ctx, core.ExecutionTraceEvent{
+		Type:   core.TraceEventRead,
+		Caller: req.Callee.String(),
+		Object: req.Object.String(),
+		Method: "GetDelegate",
+		Error:  errString(err),
+	})
 	if err != nil {
 		return err
 	}
@@ -286,6 +372,22 @@ func (gpr *RPC) DeactivateObject(req rpctypes.UpDeactivateObjectReq, rep *rpctyp
 	return nil
 }
 
+// RecordStateMigration is an RPC noting that object state was migrated to a new schema version,
+// migration is recorded on ledger when migrated state is saved
+func (gpr *RPC) RecordStateMigration(req rpctypes.UpRecordStateMigrationReq, rep *rpctypes.UpRecordStateMigrationResp) (err error) {
+	defer recoverRPC(&err)
+
+	os := gpr.lr.MustObjectState(req.Callee)
+	es := os.MustModeState(req.Mode)
+	es.migration = &message.StateMigration{
+		Object:    req.Callee,
+		Prototype: req.Prototype,
+		From:      req.From,
+		To:        req.To,
+	}
+	return nil
+}
+
 // atomicLoadAndIncrementUint64 performs CAS loop, increments counter and returns old value.
 func atomicLoadAndIncrementUint64(addr *uint64) uint64 {
 	for {
@@ -0,0 +1,142 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxTraces is max count of requests traced at once
+	maxTraces = 100
+	// maxTraceEvents is max count of steps recorded for one request, the rest are dropped
+	maxTraceEvents = 1000
+)
+
+// executionTracer keeps steps of contract calls for traced requests by their trace IDs.
+type executionTracer struct {
+	enabled bool
+
+	lock   sync.Mutex
+	traces map[string][]core.ExecutionTraceEvent
+}
+
+func newExecutionTracer(enabled bool) *executionTracer {
+	return &executionTracer{
+		enabled: enabled,
+		traces:  make(map[string][]core.ExecutionTraceEvent),
+	}
+}
+
+func (t *executionTracer) start(traceID string) error {
+	if !t.enabled {
+		return errors.New("execution trace is disabled")
+	}
+	if traceID == "" {
+		return errors.New("trace ID is empty")
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.traces[traceID]; ok {
+		return errors.Errorf("request %s is already traced", traceID)
+	}
+	if len(t.traces) >= maxTraces {
+		return errors.Errorf("too many traced requests, limit is %d", maxTraces)
+	}
+	t.traces[traceID] = make([]core.ExecutionTraceEvent, 0)
+	return nil
+}
+
+func (t *executionTracer) finish(traceID string) []core.ExecutionTraceEvent {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	events := t.traces[traceID]
+	delete(t.traces, traceID)
+	return events
+}
+
+// traced returns true if request from ctx is traced.
+func (t *executionTracer) traced(ctx context.Context) bool {
+	if !t.enabled {
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.traces[inslogger.TraceID(ctx)]
+	return ok
+}
+
+// record adds step to the trace of request from ctx, if the request is traced.
+func (t *executionTracer) record(ctx context.Context, event core.ExecutionTraceEvent) {
+	if !t.enabled {
+		return
+	}
+	traceID := inslogger.TraceID(ctx)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	events, ok := t.traces[traceID]
+	if !ok || len(events) >= maxTraceEvents {
+		return
+	}
+	event.Time = time.Now()
+	t.traces[traceID] = append(events, event)
+}
+
+func refString(ref *core.RecordRef) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.String()
+}
+
+func stateString(desc core.ObjectDescriptor) string {
+	if desc == nil || desc.StateID() == nil {
+		return ""
+	}
+	return desc.StateID().String()
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// StartTrace starts recording steps of contract calls made on behalf of request with traceID.
+func (lr *LogicRunner) StartTrace(traceID string) error {
+	return lr.tracer.start(traceID)
+}
+
+// FinishTrace stops recording and returns steps of contract calls made on behalf of request with traceID.
+func (lr *LogicRunner) FinishTrace(traceID string) []core.ExecutionTraceEvent {
+	return lr.tracer.finish(traceID)
+}
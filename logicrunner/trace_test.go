@@ -0,0 +1,70 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package logicrunner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionTracer(t *testing.T) {
+	tracer := newExecutionTracer(true)
+	ctx := inslogger.ContextWithTrace(context.Background(), "trace")
+	other := inslogger.ContextWithTrace(context.Background(), "other")
+
+	require.NoError(t, tracer.start("trace"))
+	require.Error(t, tracer.start("trace"))
+	require.Error(t, tracer.start(""))
+	require.True(t, tracer.traced(ctx))
+	require.False(t, tracer.traced(other))
+
+	tracer.record(ctx, core.ExecutionTraceEvent{Type: core.TraceEventCall, Method: "Transfer"})
+	tracer.record(other, core.ExecutionTraceEvent{Type: core.TraceEventCall, Method: "GetBalance"})
+	tracer.record(ctx, core.ExecutionTraceEvent{Type: core.TraceEventAmend, Method: "Transfer"})
+
+	events := tracer.finish("trace")
+	require.Len(t, events, 2)
+	require.Equal(t, core.TraceEventCall, events[0].Type)
+	require.Equal(t, core.TraceEventAmend, events[1].Type)
+	require.False(t, events[0].Time.IsZero())
+	require.False(t, tracer.traced(ctx))
+	require.Empty(t, tracer.finish("trace"))
+}
+
+func TestExecutionTracer_Limits(t *testing.T) {
+	tracer := newExecutionTracer(false)
+	require.Error(t, tracer.start("trace"))
+
+	tracer = newExecutionTracer(true)
+	ctx := inslogger.ContextWithTrace(context.Background(), "trace")
+	require.NoError(t, tracer.start("trace"))
+	for i := 0; i < maxTraceEvents+10; i++ {
+		tracer.record(ctx, core.ExecutionTraceEvent{Type: core.TraceEventRead})
+	}
+	require.Len(t, tracer.finish("trace"), maxTraceEvents)
+
+	for i := 0; i < maxTraces; i++ {
+		require.NoError(t, tracer.start(fmt.Sprintf("trace-%d", i)))
+	}
+	require.Error(t, tracer.start("overflow"))
+}
@@ -34,11 +34,14 @@ import (
 	"github.com/insolar/insolar/instrumentation/hack"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/instrumentation/watchdog"
 	"github.com/insolar/insolar/metrics"
 )
 
 const deliverRPCMethodName = "MessageBus.Deliver"
 
+var deliverCounter = watchdog.NewCounter("messagebus.deliver", "github.com/insolar/insolar/messagebus")
+
 // MessageBus is component that routes application logic requests,
 // e.g. glue between network and logic runner
 type MessageBus struct {
@@ -200,9 +203,8 @@ func (mb *MessageBus) SendParcel(
 
 	if len(nodes) > 1 {
 		cascade := core.Cascade{
-			NodeIds:           nodes,
-			Entropy:           currentPulse.Entropy,
-			ReplicationFactor: 2,
+			NodeIds: nodes,
+			Entropy: currentPulse.Entropy,
 		}
 		err := mb.Network.SendCascadeMessage(cascade, deliverRPCMethodName, parcel)
 		return nil, err
@@ -215,7 +217,7 @@ func (mb *MessageBus) SendParcel(
 		return mb.doDeliver(parcel.Context(context.Background()), parcel)
 	}
 
-	res, err := mb.Network.SendMessage(nodes[0], deliverRPCMethodName, parcel)
+	res, err := mb.Network.SendMessage(ctx, nodes[0], deliverRPCMethodName, parcel)
 	if err != nil {
 		return nil, err
 	}
@@ -369,6 +371,9 @@ func (mb *MessageBus) handleParcelFromTheFuture(ctx context.Context, parcel core
 // this method is registered as RPC stub
 func (mb *MessageBus) deliver(ctx context.Context, args [][]byte) (result []byte, err error) {
 	inslogger.FromContext(ctx).Debug("MessageBus.deliver starts ...")
+	deliverCounter.Begin()
+	defer deliverCounter.Done()
+
 	if len(args) < 1 {
 		return nil, errors.New("need exactly one argument when mb.deliver()")
 	}
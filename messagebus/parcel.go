@@ -57,6 +57,7 @@ func (pf *parcelFactory) Create(ctx context.Context, msg core.Message, sender co
 		Sender:        sender,
 		Token:         token,
 		PulseNumber:   currentPulse.PulseNumber,
+		Priority:      core.PriorityFromContext(ctx),
 	}, nil
 }
 
@@ -25,3 +25,44 @@ var APIContractExecutionTime = prometheus.NewSummaryVec(prometheus.SummaryOpts{
 	Subsystem:  "API",
 	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.95: 0.005, 0.99: 0.001},
 }, []string{"method", "success"})
+
+var APIRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "API",
+		Name:      "requests_total",
+		Help:      "Total number of requests to API",
+	},
+	[]string{"query_type"},
+)
+
+var APIRequestTime = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "API",
+		Name:      "request_time",
+		Help:      "Time spent on handling API request",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{"query_type"},
+)
+
+var APIRequestsInFlight = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "API",
+		Name:      "requests_in_flight",
+		Help:      "Number of API requests being handled",
+	},
+	[]string{"query_type"},
+)
+
+var APIErrorsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "API",
+		Name:      "errors_total",
+		Help:      "Total number of failed API requests by error code",
+	},
+	[]string{"query_type", "code"},
+)
@@ -0,0 +1,57 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var CanaryTransactionsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "canary",
+		Name:      "transactions_total",
+		Help:      "Total number of canary transactions by result",
+	},
+	[]string{"result"},
+)
+
+var CanaryTransactionTime = prometheus.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "canary",
+		Name:      "transaction_time",
+		Help:      "Time spent on successful canary transaction",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	},
+)
+
+var CanaryConsecutiveFailures = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "canary",
+		Name:      "consecutive_failures",
+		Help:      "Count of canary transactions failed in a row",
+	},
+)
+
+var CanaryAlert = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: insolarNamespace,
+		Subsystem: "canary",
+		Name:      "alert",
+		Help:      "Is 1 when consecutive failures of canary transactions reached threshold",
+	},
+)
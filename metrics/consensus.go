@@ -0,0 +1,63 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ConsensusPhaseTime is duration of consensus phases metric
+var ConsensusPhaseTime = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+	Name:       "phase_seconds",
+	Help:       "Duration of consensus phases",
+	Namespace:  insolarNamespace,
+	Subsystem:  "consensus",
+	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+}, []string{"phase"})
+
+// ConsensusPacketsSentTotal is total number of packets sent in consensus phases metric
+var ConsensusPacketsSentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "packets_sent_total",
+	Help:      "Total number of packets sent to participants in consensus phases",
+	Namespace: insolarNamespace,
+	Subsystem: "consensus",
+}, []string{"phase"})
+
+// ConsensusPacketsReceivedTotal is total number of packets received in consensus phases metric
+var ConsensusPacketsReceivedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "packets_received_total",
+	Help:      "Total number of packets received from participants in consensus phases",
+	Namespace: insolarNamespace,
+	Subsystem: "consensus",
+}, []string{"phase"})
+
+// ConsensusClaimQueue is depth of claim queue at the beginning of consensus metric
+var ConsensusClaimQueue = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name:      "claim_queue",
+	Help:      "Depth of claim queue at the beginning of consensus",
+	Namespace: insolarNamespace,
+	Subsystem: "consensus",
+})
+
+// ConsensusProofFailuresTotal is total number of proofs failed validation metric
+var ConsensusProofFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "proof_failures_total",
+	Help:      "Total number of proofs and packet signatures that failed validation in consensus phases",
+	Namespace: insolarNamespace,
+	Subsystem: "consensus",
+}, []string{"phase"})
@@ -40,6 +40,24 @@ func GetInsolarRegistry() *prometheus.Registry {
 	registry.MustRegister(NetworkPacketReceivedTotal)
 	registry.MustRegister(NetworkParcelReceivedTotal)
 	registry.MustRegister(NetworkComplete)
+	registry.MustRegister(NetworkCompressionRatio)
+	registry.MustRegister(NetworkCompressionTime)
+	registry.MustRegister(NetworkCompressionSavedBytes)
+	registry.MustRegister(NetworkPacketDroppedBannedTotal)
+	registry.MustRegister(NetworkPacketDroppedDeniedTotal)
+	registry.MustRegister(NetworkPacketDroppedDuplicateTotal)
+	registry.MustRegister(NetworkPacketDroppedFaultTotal)
+	registry.MustRegister(NetworkPacketDroppedSignatureTotal)
+	registry.MustRegister(NetworkPacketDroppedQueueTotal)
+	registry.MustRegister(NetworkBootstrapAttempts)
+	registry.MustRegister(NetworkBootstrapAttemptTime)
+	registry.MustRegister(NetworkRejoinAttempts)
+
+	registry.MustRegister(ConsensusPhaseTime)
+	registry.MustRegister(ConsensusPacketsSentTotal)
+	registry.MustRegister(ConsensusPacketsReceivedTotal)
+	registry.MustRegister(ConsensusClaimQueue)
+	registry.MustRegister(ConsensusProofFailuresTotal)
 
 	registry.MustRegister(ParcelsSentTotal)
 	registry.MustRegister(ParcelsTime)
@@ -50,6 +68,19 @@ func GetInsolarRegistry() *prometheus.Registry {
 	registry.MustRegister(GopluginContractExecutionTime)
 
 	registry.MustRegister(APIContractExecutionTime)
+	registry.MustRegister(APIRequestsTotal)
+	registry.MustRegister(APIRequestTime)
+	registry.MustRegister(APIRequestsInFlight)
+	registry.MustRegister(APIErrorsTotal)
+
+	registry.MustRegister(CanaryTransactionsTotal)
+	registry.MustRegister(CanaryTransactionTime)
+	registry.MustRegister(CanaryConsecutiveFailures)
+	registry.MustRegister(CanaryAlert)
+
+	registry.MustRegister(WatchdogStalled)
+	registry.MustRegister(WatchdogStallsTotal)
+	registry.MustRegister(WatchdogPending)
 
 	return registry
 }
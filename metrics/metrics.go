@@ -38,15 +38,87 @@ import (
 const insolarNamespace = "insolar"
 const insgorundNamespace = "insgorund"
 
-// Metrics is a component which serve metrics data to Prometheus.
+// Metrics is a component which serves metrics data to Prometheus and ships telemetry to other configured providers.
 type Metrics struct {
-	server   *http.Server
-	listener net.Listener
+	providers  []Provider
+	prometheus *prometheusProvider
 }
 
 // NewMetrics creates new Metrics component.
 func NewMetrics(ctx context.Context, cfg configuration.Metrics, registry *prometheus.Registry) (*Metrics, error) {
 	errlogger := &errorLogger{inslogger.FromContext(ctx)}
+
+	_, err := insmetrics.RegisterPrometheus(ctx, cfg.Namespace, registry, cfg.ReportingPeriod)
+	if err != nil {
+		errlogger.Println(err.Error())
+	}
+
+	names := cfg.Providers
+	if len(names) == 0 {
+		names = []string{ProviderPrometheus}
+	}
+	m := &Metrics{}
+	for _, name := range names {
+		switch name {
+		case ProviderPrometheus:
+			m.prometheus = newPrometheusProvider(cfg, registry, errlogger)
+			m.providers = append(m.providers, m.prometheus)
+		case ProviderOTLP:
+			if cfg.OTLP.Endpoint == "" {
+				return nil, errors.New("OTLP endpoint is not set")
+			}
+			m.providers = append(m.providers, newOTLPProvider(cfg.OTLP, cfg.Namespace, registry))
+		default:
+			return nil, errors.Errorf("unknown metrics provider %s", name)
+		}
+	}
+
+	return m, nil
+}
+
+// Start is implementation of core.Component interface.
+func (m *Metrics) Start(ctx context.Context) error {
+	for _, p := range m.providers {
+		if err := p.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop is implementation of core.Component interface.
+func (m *Metrics) Stop(ctx context.Context) error {
+	var errs []string
+	for _, p := range m.providers {
+		if err := p.Stop(ctx); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// AddrString returns listener address of Prometheus endpoint, empty if Prometheus provider is not used.
+func (m *Metrics) AddrString() string {
+	if m.prometheus == nil {
+		return ""
+	}
+	return m.prometheus.AddrString()
+}
+
+// ErrBind special case for Start method.
+// We can use it for easier check in metrics creation code.
+var ErrBind = errors.New("Failed to bind")
+
+// prometheusProvider serves metrics data to Prometheus along with pprof and zpages.
+type prometheusProvider struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+func newPrometheusProvider(cfg configuration.Metrics, registry *prometheus.Registry, errlogger *errorLogger) *prometheusProvider {
 	promhandler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorLog: errlogger})
 
 	mux := http.NewServeMux()
@@ -57,27 +129,15 @@ func NewMetrics(ctx context.Context, cfg configuration.Metrics, registry *promet
 		zpages.Handle(mux, "/debug")
 	}
 
-	m := &Metrics{
+	return &prometheusProvider{
 		server: &http.Server{
 			Addr:    cfg.ListenAddress,
 			Handler: mux,
 		},
 	}
-
-	_, err := insmetrics.RegisterPrometheus(ctx, cfg.Namespace, registry, cfg.ReportingPeriod)
-	if err != nil {
-		errlogger.Println(err.Error())
-	}
-
-	return m, nil
 }
 
-// ErrBind special case for Start method.
-// We can use it for easier check in metrics creation code.
-var ErrBind = errors.New("Failed to bind")
-
-// Start is implementation of core.Component interface.
-func (m *Metrics) Start(ctx context.Context) error {
+func (m *prometheusProvider) Start(ctx context.Context) error {
 	inslog := inslogger.FromContext(ctx)
 
 	listener, err := net.Listen("tcp", m.server.Addr)
@@ -107,8 +167,7 @@ func (m *Metrics) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop is implementation of core.Component interface.
-func (m *Metrics) Stop(ctx context.Context) error {
+func (m *prometheusProvider) Stop(ctx context.Context) error {
 	const timeOut = 3
 	inslogger.FromContext(ctx).Info("Shutting down metrics server")
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeOut)*time.Second)
@@ -122,7 +181,7 @@ func (m *Metrics) Stop(ctx context.Context) error {
 }
 
 // AddrString returns listener address.
-func (m *Metrics) AddrString() string {
+func (m *prometheusProvider) AddrString() string {
 	return m.listener.Addr().String()
 }
 
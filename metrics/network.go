@@ -83,3 +83,102 @@ var NetworkComplete = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: insolarNamespace,
 	Subsystem: "network",
 })
+
+// NetworkCompressionRatio is ratio of original to compressed size of packets metric
+var NetworkCompressionRatio = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+	Name:       "compression_ratio",
+	Help:       "Ratio of original to compressed size of packets",
+	Namespace:  insolarNamespace,
+	Subsystem:  "network",
+	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+}, []string{"packetType"})
+
+// NetworkCompressionTime is time spent on compression of packets metric
+var NetworkCompressionTime = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+	Name:       "compression_time",
+	Help:       "Time spent on compression of packets",
+	Namespace:  insolarNamespace,
+	Subsystem:  "network",
+	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+}, []string{"packetType"})
+
+// NetworkCompressionSavedBytes is total number of bytes saved by compression metric
+var NetworkCompressionSavedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "compression_saved_bytes",
+	Help:      "Total number of bytes saved by compression of packets",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"packetType"})
+
+// NetworkPacketDroppedBannedTotal is total number of packets dropped from banned peers metric
+var NetworkPacketDroppedBannedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_dropped_banned_total",
+	Help:      "Total number of packets and connections dropped from banned peers",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPacketDroppedDeniedTotal is total number of packets dropped from peers denied by access lists metric
+var NetworkPacketDroppedDeniedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_dropped_denied_total",
+	Help:      "Total number of packets and connections dropped from peers denied by allow and deny lists",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPacketDroppedDuplicateTotal is total number of duplicated and replayed requests dropped metric
+var NetworkPacketDroppedDuplicateTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_dropped_duplicate_total",
+	Help:      "Total number of duplicated and replayed request packets dropped",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPacketDroppedFaultTotal is total number of packets dropped by fault injection metric
+var NetworkPacketDroppedFaultTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_dropped_fault_total",
+	Help:      "Total number of packets to and from isolated peers dropped by fault injection",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPacketDroppedSignatureTotal is total number of packets dropped for missing or invalid signature metric
+var NetworkPacketDroppedSignatureTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name:      "packet_dropped_signature_total",
+	Help:      "Total number of packets dropped for missing or invalid signature",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkPacketDroppedQueueTotal is total number of outgoing packets dropped by full send queue metric
+var NetworkPacketDroppedQueueTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "packet_dropped_queue_total",
+	Help:      "Total number of outgoing packets dropped by full send queue of priority class",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"class"})
+
+// NetworkBootstrapAttempts is total number of bootstrap attempts metric
+var NetworkBootstrapAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "bootstrap_attempts_total",
+	Help:      "Total number of bootstrap attempts to discovery nodes",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"status"})
+
+// NetworkBootstrapAttemptTime is duration of bootstrap attempts metric
+var NetworkBootstrapAttemptTime = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+	Name:       "bootstrap_attempt_seconds",
+	Help:       "Duration of bootstrap attempts to discovery nodes",
+	Namespace:  insolarNamespace,
+	Subsystem:  "network",
+	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+}, []string{"status"})
+
+// NetworkRejoinAttempts is total number of attempts to rejoin network after expulsion metric
+var NetworkRejoinAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "rejoin_attempts_total",
+	Help:      "Total number of attempts to rejoin network after node was expelled from active list",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"status"})
@@ -0,0 +1,448 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opencensus.io/trace"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+const (
+	otlpMetricsPath = "/v1/metrics"
+	otlpTracesPath  = "/v1/traces"
+
+	// otlpDefaultInterval is used if export interval is not configured
+	otlpDefaultInterval = 10 * time.Second
+	// otlpMaxSpans bounds spans buffered between exports, extra spans are dropped
+	otlpMaxSpans = 10000
+	// otlpCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE
+	otlpCumulative = 2
+)
+
+// otlpProvider periodically pushes metrics of registry and spans sampled by tracer to OpenTelemetry
+// collector using OTLP/HTTP with JSON encoding.
+type otlpProvider struct {
+	cfg      configuration.OTLP
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	resource otlpResource
+
+	lock  sync.Mutex
+	spans []otlpSpan
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newOTLPProvider(cfg configuration.OTLP, namespace string, gatherer prometheus.Gatherer) *otlpProvider {
+	if cfg.ExportInterval <= 0 {
+		cfg.ExportInterval = otlpDefaultInterval
+	}
+	hostname, _ := os.Hostname()
+	return &otlpProvider{
+		cfg:      cfg,
+		gatherer: gatherer,
+		client:   &http.Client{Timeout: cfg.ExportInterval},
+		resource: otlpResource{Attributes: []otlpKeyValue{
+			otlpAttribute("service.name", namespace),
+			otlpAttribute("host.name", hostname),
+		}},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (p *otlpProvider) Start(ctx context.Context) error {
+	if p.cfg.Traces {
+		trace.RegisterExporter(p)
+	}
+	go p.loop(ctx)
+	inslogger.FromContext(ctx).Infoln("Started OTLP export to", p.cfg.Endpoint)
+	return nil
+}
+
+func (p *otlpProvider) Stop(ctx context.Context) error {
+	if p.cfg.Traces {
+		trace.UnregisterExporter(p)
+	}
+	close(p.stop)
+	<-p.done
+	// flush what is collected since the last export
+	return p.export(ctx)
+}
+
+// ExportSpan implements trace.Exporter, spans are buffered until the next export.
+func (p *otlpProvider) ExportSpan(sd *trace.SpanData) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.spans) < otlpMaxSpans {
+		p.spans = append(p.spans, convertSpan(sd))
+	}
+}
+
+func (p *otlpProvider) loop(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.cfg.ExportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.export(ctx); err != nil {
+				inslogger.FromContext(ctx).Warn("Failed to export telemetry with OTLP: ", err)
+			}
+		}
+	}
+}
+
+// export sends current values of metrics and buffered spans to collector.
+func (p *otlpProvider) export(ctx context.Context) error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return errors.Wrap(err, "failed to gather metrics")
+	}
+	err = p.post(ctx, otlpMetricsPath, otlpMetricsRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource:     p.resource,
+		ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: "insolar"}, Metrics: convertFamilies(families, time.Now())}},
+	}}})
+	if err != nil {
+		return errors.Wrap(err, "failed to export metrics")
+	}
+
+	p.lock.Lock()
+	spans := p.spans
+	p.spans = nil
+	p.lock.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+	err = p.post(ctx, otlpTracesPath, otlpTracesRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   p.resource,
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "insolar"}, Spans: spans}},
+	}}})
+	return errors.Wrap(err, "failed to export traces")
+}
+
+func (p *otlpProvider) post(ctx context.Context, path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal payload")
+	}
+	req, err := http.NewRequest("POST", p.cfg.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range p.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// convertFamilies converts gathered Prometheus metrics to OTLP metrics.
+func convertFamilies(families []*dto.MetricFamily, now time.Time) []otlpMetric {
+	timestamp := strconv.FormatInt(now.UnixNano(), 10)
+	result := make([]otlpMetric, 0, len(families))
+	for _, family := range families {
+		metric := otlpMetric{Name: family.GetName(), Description: family.GetHelp()}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			metric.Sum = &otlpSum{AggregationTemporality: otlpCumulative, IsMonotonic: true}
+			for _, m := range family.Metric {
+				metric.Sum.DataPoints = append(metric.Sum.DataPoints,
+					otlpNumberPoint{Attributes: convertLabels(m.Label), TimeUnixNano: timestamp, AsDouble: m.GetCounter().GetValue()})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			metric.Gauge = &otlpGauge{}
+			for _, m := range family.Metric {
+				value := m.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				metric.Gauge.DataPoints = append(metric.Gauge.DataPoints,
+					otlpNumberPoint{Attributes: convertLabels(m.Label), TimeUnixNano: timestamp, AsDouble: value})
+			}
+		case dto.MetricType_HISTOGRAM:
+			metric.Histogram = &otlpHistogram{AggregationTemporality: otlpCumulative}
+			for _, m := range family.Metric {
+				metric.Histogram.DataPoints = append(metric.Histogram.DataPoints,
+					convertHistogram(m, timestamp))
+			}
+		case dto.MetricType_SUMMARY:
+			metric.Summary = &otlpSummary{}
+			for _, m := range family.Metric {
+				point := otlpSummaryPoint{
+					Attributes:   convertLabels(m.Label),
+					TimeUnixNano: timestamp,
+					Count:        strconv.FormatUint(m.GetSummary().GetSampleCount(), 10),
+					Sum:          m.GetSummary().GetSampleSum(),
+				}
+				for _, q := range m.GetSummary().GetQuantile() {
+					point.QuantileValues = append(point.QuantileValues, otlpQuantile{Quantile: q.GetQuantile(), Value: q.GetValue()})
+				}
+				metric.Summary.DataPoints = append(metric.Summary.DataPoints, point)
+			}
+		default:
+			continue
+		}
+		result = append(result, metric)
+	}
+	return result
+}
+
+// convertHistogram converts cumulative Prometheus buckets to OTLP bucket counts,
+// the last OTLP bucket counts values above the greatest bound.
+func convertHistogram(m *dto.Metric, timestamp string) otlpHistogramPoint {
+	h := m.GetHistogram()
+	point := otlpHistogramPoint{
+		Attributes:   convertLabels(m.Label),
+		TimeUnixNano: timestamp,
+		Count:        strconv.FormatUint(h.GetSampleCount(), 10),
+		Sum:          h.GetSampleSum(),
+	}
+	var previous uint64
+	for _, b := range h.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, b.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, strconv.FormatUint(b.GetCumulativeCount()-previous, 10))
+		previous = b.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, strconv.FormatUint(h.GetSampleCount()-previous, 10))
+	return point
+}
+
+func convertLabels(labels []*dto.LabelPair) []otlpKeyValue {
+	result := make([]otlpKeyValue, 0, len(labels))
+	for _, l := range labels {
+		result = append(result, otlpAttribute(l.GetName(), l.GetValue()))
+	}
+	return result
+}
+
+// convertSpan converts OpenCensus span to OTLP span.
+func convertSpan(sd *trace.SpanData) otlpSpan {
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(sd.TraceID[:]),
+		SpanID:            hex.EncodeToString(sd.SpanID[:]),
+		Name:              sd.Name,
+		Kind:              otlpSpanKind(sd.SpanKind),
+		StartTimeUnixNano: strconv.FormatInt(sd.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(sd.EndTime.UnixNano(), 10),
+		Status:            otlpStatus{Message: sd.Status.Message},
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanID = hex.EncodeToString(sd.ParentSpanID[:])
+	}
+	if sd.Status.Code != trace.StatusCodeOK {
+		span.Status.Code = otlpStatusError
+	}
+	for key, value := range sd.Attributes {
+		span.Attributes = append(span.Attributes, otlpKeyValue{Key: key, Value: otlpValue(value)})
+	}
+	return span
+}
+
+// otlpSpanKind maps OpenCensus span kind to OTLP one.
+func otlpSpanKind(kind int) int {
+	switch kind {
+	case trace.SpanKindServer:
+		return 2
+	case trace.SpanKindClient:
+		return 3
+	default:
+		// SPAN_KIND_INTERNAL
+		return 1
+	}
+}
+
+// otlpStatusError is STATUS_CODE_ERROR, spans completed successfully are left with unset status
+const otlpStatusError = 2
+
+func otlpAttribute(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpValue(value interface{}) otlpAnyValue {
+	switch v := value.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &v}
+	case bool:
+		return otlpAnyValue{BoolValue: &v}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return otlpAnyValue{IntValue: &s}
+	case float64:
+		return otlpAnyValue{DoubleValue: &v}
+	default:
+		s := fmt.Sprint(v)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+// OTLP JSON payload, see opentelemetry-proto. 64 bit integers are encoded as strings, ids as hex.
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+	Summary     *otlpSummary   `json:"summary,omitempty"`
+}
+
+type otlpNumberPoint struct {
+	Attributes   []otlpKeyValue `json:"attributes"`
+	TimeUnixNano string         `json:"timeUnixNano"`
+	AsDouble     float64        `json:"asDouble"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type otlpHistogramPoint struct {
+	Attributes     []otlpKeyValue `json:"attributes"`
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	Count          string         `json:"count"`
+	Sum            float64        `json:"sum"`
+	BucketCounts   []string       `json:"bucketCounts"`
+	ExplicitBounds []float64      `json:"explicitBounds"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramPoint `json:"dataPoints"`
+	AggregationTemporality int                  `json:"aggregationTemporality"`
+}
+
+type otlpQuantile struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type otlpSummaryPoint struct {
+	Attributes     []otlpKeyValue `json:"attributes"`
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	Count          string         `json:"count"`
+	Sum            float64        `json:"sum"`
+	QuantileValues []otlpQuantile `json:"quantileValues"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryPoint `json:"dataPoints"`
+}
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
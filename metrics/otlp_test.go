@@ -0,0 +1,116 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package metrics
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func TestOTLPProvider_Export(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	requests := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		requests[r.URL.Path] = body
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "test counter"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Buckets: []float64{1, 2}})
+	registry.MustRegister(counter, histogram)
+	counter.Add(3)
+	histogram.Observe(0.5)
+	histogram.Observe(1.5)
+	histogram.Observe(5)
+
+	cfg := configuration.OTLP{
+		Endpoint:       server.URL,
+		Headers:        map[string]string{"Authorization": "secret"},
+		ExportInterval: time.Hour,
+	}
+	p := newOTLPProvider(cfg, "insolar", registry)
+	p.ExportSpan(&trace.SpanData{
+		SpanContext: trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}},
+		Name:        "call",
+		SpanKind:    trace.SpanKindServer,
+		Status:      trace.Status{Code: 2, Message: "failed"},
+	})
+	require.NoError(t, p.export(ctx))
+
+	var metrics otlpMetricsRequest
+	require.NoError(t, json.Unmarshal(requests[otlpMetricsPath], &metrics))
+	require.Len(t, metrics.ResourceMetrics, 1)
+	result := map[string]otlpMetric{}
+	for _, m := range metrics.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		result[m.Name] = m
+	}
+
+	require.NotNil(t, result["test_total"].Sum)
+	assert.True(t, result["test_total"].Sum.IsMonotonic)
+	assert.Equal(t, 3.0, result["test_total"].Sum.DataPoints[0].AsDouble)
+
+	require.NotNil(t, result["test_seconds"].Histogram)
+	point := result["test_seconds"].Histogram.DataPoints[0]
+	assert.Equal(t, "3", point.Count)
+	assert.Equal(t, []float64{1, 2}, point.ExplicitBounds)
+	assert.Equal(t, []string{"1", "1", "1"}, point.BucketCounts)
+
+	var traces otlpTracesRequest
+	require.NoError(t, json.Unmarshal(requests[otlpTracesPath], &traces))
+	spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	assert.Equal(t, "01000000000000000000000000000000", spans[0].TraceID)
+	assert.Equal(t, "0200000000000000", spans[0].SpanID)
+	assert.Empty(t, spans[0].ParentSpanID)
+	assert.Equal(t, 2, spans[0].Kind)
+	assert.Equal(t, otlpStatusError, spans[0].Status.Code)
+
+	// buffered spans are sent once
+	delete(requests, otlpTracesPath)
+	require.NoError(t, p.export(ctx))
+	assert.NotContains(t, requests, otlpTracesPath)
+}
+
+func TestOTLPProvider_ExportFailed(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	p := newOTLPProvider(configuration.OTLP{Endpoint: server.URL}, "insolar", prometheus.NewRegistry())
+	assert.Error(t, p.export(ctx))
+}
@@ -0,0 +1,45 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WatchdogStalled is metric that is set to 1 while watched worker pool or channel makes no progress
+var WatchdogStalled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name:      "stalled",
+	Help:      "Is worker pool or channel stalled",
+	Namespace: insolarNamespace,
+	Subsystem: "watchdog",
+}, []string{"probe"})
+
+// WatchdogStallsTotal is total number of detected stalls of worker pools and channels metric
+var WatchdogStallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "stalls_total",
+	Help:      "Total number of detected stalls of worker pools and channels",
+	Namespace: insolarNamespace,
+	Subsystem: "watchdog",
+}, []string{"probe"})
+
+// WatchdogPending is count of items waiting in watched worker pools and channels metric
+var WatchdogPending = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name:      "pending",
+	Help:      "Count of items waiting in worker pools and channels",
+	Namespace: insolarNamespace,
+	Subsystem: "watchdog",
+}, []string{"probe"})
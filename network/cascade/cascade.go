@@ -134,3 +134,24 @@ func CalculateNextNodes(scheme core.PlatformCryptographyScheme, data core.Cascad
 	}
 	return nodeIds[startIndex:min(endIndex, len(nodeIds))], nil
 }
+
+// CalculateSubtree gets node and all nodes that receive cascade message through it, depth is count of layers in the subtree
+func CalculateSubtree(scheme core.PlatformCryptographyScheme, data core.Cascade, node core.RecordRef) (subtree []core.RecordRef, depth int, err error) {
+	subtree = []core.RecordRef{node}
+	layer := []core.RecordRef{node}
+	for len(layer) > 0 {
+		depth++
+		next := make([]core.RecordRef, 0)
+		for _, nodeID := range layer {
+			nodeID := nodeID
+			children, err := CalculateNextNodes(scheme, data, &nodeID)
+			if err != nil {
+				return nil, 0, err
+			}
+			next = append(next, children...)
+		}
+		subtree = append(subtree, next...)
+		layer = next
+	}
+	return subtree, depth, nil
+}
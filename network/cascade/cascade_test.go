@@ -132,3 +132,35 @@ func Test_getNextCascadeLayerIndexes(t *testing.T) {
 	require.Equal(t, len(nodeIds), startIndex)
 	require.Equal(t, len(nodeIds), endIndex)
 }
+
+func TestCalculateSubtree(t *testing.T) {
+	nodeIds := make([]core.RecordRef, 0, 20)
+	for i := 0; i < 20; i++ {
+		nodeIds = append(nodeIds, testutils.RandomRef())
+	}
+	c := core.Cascade{
+		NodeIds:           nodeIds,
+		Entropy:           core.Entropy{0},
+		ReplicationFactor: 2,
+	}
+	pcs := platformpolicy.NewPlatformCryptographyScheme()
+
+	first, err := CalculateNextNodes(pcs, c, nil)
+	require.NoError(t, err)
+
+	// subtrees of the first layer nodes cover all nodes exactly once
+	covered := make(map[core.RecordRef]int)
+	for _, node := range first {
+		subtree, depth, err := CalculateSubtree(pcs, c, node)
+		require.NoError(t, err)
+		require.Equal(t, node, subtree[0])
+		require.True(t, depth > 1)
+		for _, ref := range subtree {
+			covered[ref]++
+		}
+	}
+	require.Len(t, covered, len(nodeIds))
+	for _, count := range covered {
+		require.Equal(t, 1, count)
+	}
+}
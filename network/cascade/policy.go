@@ -0,0 +1,125 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package cascade
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// ReplicationPolicy derives cascade replication factor from count of cascade nodes and measured loss rate.
+type ReplicationPolicy interface {
+	// Factor returns replication factor for cascade of count nodes.
+	Factor(count int) uint
+	// Observe records delivery coverage of cascade sent on current pulse.
+	Observe(ctx context.Context, total, delivered int)
+}
+
+// PolicyOptions are bounds of replication factor chosen by policy.
+type PolicyOptions struct {
+	// MinFactor and MaxFactor bound replication factor
+	MinFactor uint
+	MaxFactor uint
+	// MaxDepth is count of cascade layers factor is raised to keep within
+	MaxDepth int
+	// LossThreshold is loss rate above which factor is raised to make cascade shallower
+	LossThreshold float64
+}
+
+type replicationPolicy struct {
+	PulseStorage core.PulseStorage `inject:""`
+
+	options PolicyOptions
+
+	lock     sync.Mutex
+	pulse    core.PulseNumber
+	sent     int
+	lost     int
+	lossRate float64
+}
+
+// NewReplicationPolicy creates policy that chooses minimal factor keeping cascade depth within bound.
+// Small networks get minimal factor, factor grows with network size and when loss rate of previous pulses is high.
+func NewReplicationPolicy(options PolicyOptions) ReplicationPolicy {
+	if options.MinFactor < 1 {
+		options.MinFactor = 1
+	}
+	if options.MaxFactor < options.MinFactor {
+		options.MaxFactor = options.MinFactor
+	}
+	return &replicationPolicy{options: options}
+}
+
+// depth returns count of cascade layers needed to reach count nodes with replication factor.
+func depth(count int, factor uint) int {
+	layers := 0
+	covered := 0
+	width := 1
+	for covered < count {
+		width *= int(factor)
+		covered += width
+		layers++
+	}
+	return layers
+}
+
+func (p *replicationPolicy) Factor(count int) uint {
+	factor := p.options.MinFactor
+	for factor < p.options.MaxFactor && depth(count, factor) > p.options.MaxDepth {
+		factor++
+	}
+
+	p.lock.Lock()
+	lossRate := p.lossRate
+	p.lock.Unlock()
+
+	if lossRate > p.options.LossThreshold && factor < p.options.MaxFactor {
+		factor++
+	}
+	return factor
+}
+
+func (p *replicationPolicy) Observe(ctx context.Context, total, delivered int) {
+	pulse, err := p.PulseStorage.Current(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Debugf("[ ReplicationPolicy ] Failed to get current pulse: %s", err)
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if pulse.PulseNumber != p.pulse {
+		p.nextPulse(pulse.PulseNumber)
+	}
+	p.sent += total
+	p.lost += total - delivered
+}
+
+// nextPulse updates loss rate with losses measured on previous pulse, rate is smoothed over pulses.
+func (p *replicationPolicy) nextPulse(pulse core.PulseNumber) {
+	if p.sent > 0 {
+		p.lossRate = (p.lossRate + float64(p.lost)/float64(p.sent)) / 2
+	}
+	p.pulse = pulse
+	p.sent = 0
+	p.lost = 0
+}
@@ -0,0 +1,59 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package cascade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_depth(t *testing.T) {
+	assert.Equal(t, 1, depth(2, 2))
+	assert.Equal(t, 2, depth(3, 2))
+	assert.Equal(t, 3, depth(14, 2))
+	assert.Equal(t, 4, depth(15, 2))
+}
+
+func TestReplicationPolicy(t *testing.T) {
+	ctx := context.Background()
+	pulse := core.Pulse{PulseNumber: 1}
+	storage := testutils.NewPulseStorageMock(t)
+	storage.CurrentFunc = func(context.Context) (*core.Pulse, error) {
+		return &pulse, nil
+	}
+
+	policy := NewReplicationPolicy(PolicyOptions{MinFactor: 2, MaxFactor: 5, MaxDepth: 3, LossThreshold: 0.1})
+	policy.(*replicationPolicy).PulseStorage = storage
+
+	// small network gets minimal factor, large one keeps depth bounded
+	assert.Equal(t, uint(2), policy.Factor(10))
+	assert.Equal(t, uint(3), policy.Factor(30))
+	assert.Equal(t, uint(5), policy.Factor(10000))
+
+	// losses are accounted on the next pulse
+	policy.Observe(ctx, 10, 5)
+	assert.Equal(t, uint(2), policy.Factor(10))
+	pulse.PulseNumber = 2
+	policy.Observe(ctx, 10, 10)
+	assert.Equal(t, uint(3), policy.Factor(10))
+	assert.Equal(t, uint(5), policy.Factor(10000))
+}
@@ -0,0 +1,333 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"sync/atomic"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/bootstrap"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// AntiEntropyController every few pulses compares digest of active node list with a random peer and pulls
+// entries missing locally, so membership divergence caused by lost consensus packets heals without restart.
+// Entries are never pushed to peer, receiver of request doesn't change its active list.
+type AntiEntropyController interface {
+	component.Starter
+	// OnPulse starts reconciliation with random peer each AntiEntropyPulses pulses.
+	OnPulse(ctx context.Context, pulse core.Pulse)
+	// Reconcile starts reconciliation with peer unless another reconciliation is in progress.
+	Reconcile(ctx context.Context, peer core.RecordRef)
+}
+
+type antiEntropyController struct {
+	NodeKeeper         network.NodeKeeper              `inject:""`
+	Cryptography       core.CryptographyService        `inject:""`
+	CryptographyScheme core.PlatformCryptographyScheme `inject:""`
+
+	options      *common.Options
+	hostNetwork  network.HostNetwork
+	routingTable network.RoutingTable
+
+	pulses      uint32
+	reconciling uint32
+}
+
+// AntiEntropyRequest contains sender active list digest. When digests differ, the second request also contains
+// references of entries missing on sender.
+type AntiEntropyRequest struct {
+	Digest    []byte
+	Wanted    []core.RecordRef
+	Signature []byte
+}
+
+// AntiEntropyResponse contains responder active list digest, references of its active nodes if digests differ
+// and entries wanted by requester.
+type AntiEntropyResponse struct {
+	Digest    []byte
+	Refs      []core.RecordRef
+	Nodes     []*bootstrap.NodeStruct
+	Signature []byte
+	Error     string
+}
+
+func init() {
+	gob.Register(&AntiEntropyRequest{})
+	gob.Register(&AntiEntropyResponse{})
+}
+
+func antiEntropySignedData(sender core.RecordRef, digest []byte, refs []core.RecordRef, nodes []*bootstrap.NodeStruct) []byte {
+	var buf bytes.Buffer
+	buf.Write(sender[:])
+	writeBytes(&buf, digest)
+	for _, ref := range refs {
+		buf.Write(ref[:])
+	}
+	for _, n := range nodes {
+		writeNode(&buf, n)
+	}
+	return buf.Bytes()
+}
+
+// signedData returns data that is covered by request signature.
+func (r *AntiEntropyRequest) signedData(sender core.RecordRef) []byte {
+	return antiEntropySignedData(sender, r.Digest, r.Wanted, nil)
+}
+
+// signedData returns data that is covered by response signature.
+func (r *AntiEntropyResponse) signedData(sender core.RecordRef) []byte {
+	return antiEntropySignedData(sender, r.Digest, r.Refs, r.Nodes)
+}
+
+func (ac *antiEntropyController) Start(ctx context.Context) error {
+	ac.hostNetwork.RegisterRequestHandler(types.AntiEntropy, ac.processAntiEntropy)
+	return nil
+}
+
+func (ac *antiEntropyController) OnPulse(ctx context.Context, pulse core.Pulse) {
+	if ac.options.AntiEntropyPulses <= 0 || !ac.NodeKeeper.IsBootstrapped() {
+		return
+	}
+	if atomic.AddUint32(&ac.pulses, 1)%uint32(ac.options.AntiEntropyPulses) != 0 {
+		return
+	}
+	peers := ac.routingTable.GetRandomNodes(1, []core.RecordRef{ac.NodeKeeper.GetOrigin().ID()})
+	if len(peers) == 0 {
+		return
+	}
+	ac.Reconcile(ctx, peers[0].NodeID)
+}
+
+func (ac *antiEntropyController) Reconcile(ctx context.Context, peer core.RecordRef) {
+	if !atomic.CompareAndSwapUint32(&ac.reconciling, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreUint32(&ac.reconciling, 0)
+		ac.reconcile(ctx, peer)
+	}()
+}
+
+// localState returns active nodes and their digest.
+func (ac *antiEntropyController) localState() ([]*bootstrap.NodeStruct, []byte, error) {
+	active := ac.NodeKeeper.GetActiveNodes()
+	nodes := make([]*bootstrap.NodeStruct, 0, len(active))
+	for _, n := range active {
+		ns, err := bootstrap.NewNodeStruct(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, ns)
+	}
+	return nodes, nodesDigest(ac.CryptographyScheme, nodes), nil
+}
+
+func (ac *antiEntropyController) sign(data []byte) ([]byte, error) {
+	sign, err := ac.Cryptography.Sign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign")
+	}
+	return sign.Bytes(), nil
+}
+
+// verify checks that data is signed by active node.
+func (ac *antiEntropyController) verify(sender core.RecordRef, signature []byte, data []byte) error {
+	senderNode := ac.NodeKeeper.GetActiveNode(sender)
+	if senderNode == nil {
+		return errors.Errorf("sender %s is not in active list", sender)
+	}
+	if !ac.Cryptography.Verify(senderNode.PublicKey(), core.SignatureFromBytes(signature), data) {
+		return errors.New("signature is invalid")
+	}
+	return nil
+}
+
+// difference returns local entries absent in remote list and remote references absent in local list.
+func difference(local []*bootstrap.NodeStruct, remote []core.RecordRef) ([]*bootstrap.NodeStruct, []core.RecordRef) {
+	known := make(map[core.RecordRef]bool, len(local))
+	for _, ns := range local {
+		known[ns.ID] = true
+	}
+	remoteSet := make(map[core.RecordRef]bool, len(remote))
+	wanted := make([]core.RecordRef, 0)
+	for _, ref := range remote {
+		remoteSet[ref] = true
+		if !known[ref] {
+			wanted = append(wanted, ref)
+		}
+	}
+	missing := make([]*bootstrap.NodeStruct, 0)
+	for _, ns := range local {
+		if !remoteSet[ns.ID] {
+			missing = append(missing, ns)
+		}
+	}
+	return missing, wanted
+}
+
+func (ac *antiEntropyController) reconcile(ctx context.Context, peer core.RecordRef) {
+	logger := inslogger.FromContext(ctx)
+
+	nodes, digest, err := ac.localState()
+	if err != nil {
+		logger.Warn(errors.Wrap(err, "[ AntiEntropy ] Failed to build digest"))
+		return
+	}
+	response, err := ac.exchange(ctx, peer, &AntiEntropyRequest{Digest: digest})
+	if err != nil {
+		logger.Debugf("[ AntiEntropy ] Failed to compare digest with %s: %s", peer, err)
+		return
+	}
+	if bytes.Equal(response.Digest, digest) {
+		return
+	}
+
+	missing, wanted := difference(nodes, response.Refs)
+	if len(wanted) == 0 {
+		if len(missing) > 0 {
+			logger.Debugf("[ AntiEntropy ] %s misses %d entries, it will pull them itself", peer, len(missing))
+		}
+		return
+	}
+	response, err = ac.exchange(ctx, peer, &AntiEntropyRequest{Digest: digest, Wanted: wanted})
+	if err != nil {
+		logger.Debugf("[ AntiEntropy ] Failed to exchange entries with %s: %s", peer, err)
+		return
+	}
+	added, err := ac.merge(ctx, response.Nodes, wanted)
+	if err != nil {
+		logger.Warnf("[ AntiEntropy ] Failed to merge entries of %s: %s", peer, err)
+		return
+	}
+	logger.Infof("[ AntiEntropy ] Reconciled active list with %s: %d entries added", peer, added)
+}
+
+func (ac *antiEntropyController) exchange(ctx context.Context, receiver core.RecordRef, data *AntiEntropyRequest) (*AntiEntropyResponse, error) {
+	signature, err := ac.sign(data.signedData(ac.NodeKeeper.GetOrigin().ID()))
+	if err != nil {
+		return nil, err
+	}
+	data.Signature = signature
+
+	request := ac.hostNetwork.NewRequestBuilder().Type(types.AntiEntropy).Data(data).Build()
+	future, err := ac.hostNetwork.SendRequest(ctx, request, receiver)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+	response, err := future.GetResponse(ac.options.PacketTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get response")
+	}
+	result := response.GetData().(*AntiEntropyResponse)
+	if result.Error != "" {
+		return nil, errors.New(result.Error)
+	}
+	if err := ac.verify(receiver, result.Signature, result.signedData(receiver)); err != nil {
+		return nil, errors.Wrap(err, "invalid response")
+	}
+	return result, nil
+}
+
+// merge adds entries that are not active yet, only entries with allowed references are added.
+func (ac *antiEntropyController) merge(ctx context.Context, nodes []*bootstrap.NodeStruct, allowed []core.RecordRef) (int, error) {
+	allowedSet := make(map[core.RecordRef]bool, len(allowed))
+	for _, ref := range allowed {
+		allowedSet[ref] = true
+	}
+
+	newNodes := make([]core.Node, 0)
+	for _, ns := range nodes {
+		if !allowedSet[ns.ID] {
+			continue
+		}
+		if ac.NodeKeeper.GetActiveNode(ns.ID) != nil || ac.NodeKeeper.GetActiveNodeByShortID(ns.SID) != nil {
+			continue
+		}
+		n, err := bootstrap.NewNode(ns)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to restore node %s", ns.ID)
+		}
+		newNodes = append(newNodes, n)
+	}
+	if len(newNodes) > 0 {
+		ac.NodeKeeper.AddActiveNodes(newNodes)
+	}
+	return len(newNodes), nil
+}
+
+// answer builds response with references of local entries or with entries requester wants.
+func (ac *antiEntropyController) answer(ctx context.Context, sender core.RecordRef, data *AntiEntropyRequest) (*AntiEntropyResponse, error) {
+	if err := ac.verify(sender, data.Signature, data.signedData(sender)); err != nil {
+		return nil, err
+	}
+
+	nodes, digest, err := ac.localState()
+	if err != nil {
+		return nil, err
+	}
+	response := &AntiEntropyResponse{Digest: digest}
+	if len(data.Wanted) == 0 && !bytes.Equal(digest, data.Digest) {
+		for _, ns := range nodes {
+			response.Refs = append(response.Refs, ns.ID)
+		}
+	}
+	wanted := make(map[core.RecordRef]bool, len(data.Wanted))
+	for _, ref := range data.Wanted {
+		wanted[ref] = true
+	}
+	for _, ns := range nodes {
+		if wanted[ns.ID] {
+			response.Nodes = append(response.Nodes, ns)
+		}
+	}
+
+	response.Signature, err = ac.sign(response.signedData(ac.NodeKeeper.GetOrigin().ID()))
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (ac *antiEntropyController) processAntiEntropy(ctx context.Context, request network.Request) (network.Response, error) {
+	data := request.GetData().(*AntiEntropyRequest)
+	response, err := ac.answer(ctx, request.GetSender(), data)
+	if err != nil {
+		return ac.hostNetwork.BuildResponse(ctx, request, &AntiEntropyResponse{Error: err.Error()}), nil
+	}
+	return ac.hostNetwork.BuildResponse(ctx, request, response), nil
+}
+
+// NewAntiEntropyController creates new anti-entropy controller.
+func NewAntiEntropyController(
+	options *common.Options, hostNetwork network.HostNetwork, routingTable network.RoutingTable,
+) AntiEntropyController {
+	return &antiEntropyController{
+		options:      options,
+		hostNetwork:  hostNetwork,
+		routingTable: routingTable,
+	}
+}
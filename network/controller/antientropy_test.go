@@ -0,0 +1,149 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/platformpolicy"
+	networkUtils "github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAntiEntropyController(t *testing.T, origin core.Node, crypto core.CryptographyService, active ...core.Node) *antiEntropyController {
+	keeper := networkUtils.NewNodeKeeperMock(t)
+	keeper.GetOriginMock.Return(origin)
+	keeper.GetActiveNodesFunc = func() []core.Node {
+		return active
+	}
+	keeper.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		for _, n := range active {
+			if n.ID() == ref {
+				return n
+			}
+		}
+		return nil
+	}
+	keeper.GetActiveNodeByShortIDFunc = func(id core.ShortNodeID) core.Node {
+		for _, n := range active {
+			if n.ShortID() == id {
+				return n
+			}
+		}
+		return nil
+	}
+	keeper.AddActiveNodesFunc = func(nodes []core.Node) {
+		active = append(active, nodes...)
+	}
+
+	return &antiEntropyController{
+		NodeKeeper:         keeper,
+		Cryptography:       crypto,
+		CryptographyScheme: platformpolicy.NewPlatformCryptographyScheme(),
+		options:            &common.Options{AntiEntropyPulses: 1},
+	}
+}
+
+func signAntiEntropyRequest(t *testing.T, ac *antiEntropyController, request *AntiEntropyRequest) {
+	signature, err := ac.sign(request.signedData(ac.NodeKeeper.GetOrigin().ID()))
+	require.NoError(t, err)
+	request.Signature = signature
+}
+
+func TestAntiEntropy_Reconcile(t *testing.T) {
+	ctx := context.Background()
+
+	a, aCrypto := newTestNode(t)
+	b, bCrypto := newTestNode(t)
+	onlyA, _ := newTestNode(t)
+	onlyB, _ := newTestNode(t)
+
+	requester := newAntiEntropyController(t, a, aCrypto, a, b, onlyA)
+	responder := newAntiEntropyController(t, b, bCrypto, a, b, onlyB)
+
+	// first step compares digests, responder returns its references
+	nodes, digest, err := requester.localState()
+	require.NoError(t, err)
+	request := &AntiEntropyRequest{Digest: digest}
+	signAntiEntropyRequest(t, requester, request)
+
+	response, err := responder.answer(ctx, a.ID(), request)
+	require.NoError(t, err)
+	require.NoError(t, requester.verify(b.ID(), response.Signature, response.signedData(b.ID())))
+	assert.NotEqual(t, digest, response.Digest)
+	assert.Len(t, response.Refs, 3)
+	assert.Empty(t, response.Nodes)
+
+	missing, wanted := difference(nodes, response.Refs)
+	require.Len(t, missing, 1)
+	assert.Equal(t, onlyA.ID(), missing[0].ID)
+	assert.Equal(t, []core.RecordRef{onlyB.ID()}, wanted)
+
+	// second step receives wanted entries, responder never takes entries from requester
+	request = &AntiEntropyRequest{Digest: digest, Wanted: wanted}
+	signAntiEntropyRequest(t, requester, request)
+
+	response, err = responder.answer(ctx, a.ID(), request)
+	require.NoError(t, err)
+	require.NoError(t, requester.verify(b.ID(), response.Signature, response.signedData(b.ID())))
+	assert.Empty(t, response.Refs)
+	require.Len(t, response.Nodes, 1)
+	assert.Nil(t, responder.NodeKeeper.GetActiveNode(onlyA.ID()))
+
+	// entries that were not asked for are ignored
+	added, err := requester.merge(ctx, nodeStructs(t, b), wanted)
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	added, err = requester.merge(ctx, response.Nodes, wanted)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.NotNil(t, requester.NodeKeeper.GetActiveNode(onlyB.ID()))
+}
+
+func TestAntiEntropy_AnswerRejects(t *testing.T) {
+	ctx := context.Background()
+
+	a, aCrypto := newTestNode(t)
+	b, bCrypto := newTestNode(t)
+	unknown, unknownCrypto := newTestNode(t)
+
+	requester := newAntiEntropyController(t, a, aCrypto, a, b)
+	responder := newAntiEntropyController(t, b, bCrypto, a, b)
+
+	_, digest, err := requester.localState()
+	require.NoError(t, err)
+
+	// request from node out of active list is rejected
+	stranger := newAntiEntropyController(t, unknown, unknownCrypto, unknown)
+	request := &AntiEntropyRequest{Digest: digest}
+	signAntiEntropyRequest(t, stranger, request)
+	_, err = responder.answer(ctx, unknown.ID(), request)
+	assert.Error(t, err)
+
+	// tampered request is rejected
+	request = &AntiEntropyRequest{Digest: digest}
+	signAntiEntropyRequest(t, requester, request)
+	request.Wanted = []core.RecordRef{unknown.ID()}
+	_, err = responder.answer(ctx, a.ID(), request)
+	assert.Error(t, err)
+}
@@ -19,7 +19,7 @@ package bootstrap
 
 import (
 	"context"
-	"encoding/gob"
+	"time"
 
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/component"
@@ -29,6 +29,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/pkg/errors"
@@ -38,7 +39,10 @@ import (
 type AuthorizationController interface {
 	component.Starter
 
-	Authorize(ctx context.Context, discoveryNode *DiscoveryNode, cert core.AuthorizationCertificate) (SessionID, error)
+	// Authorize returns ID of new session and its TTL, zero TTL if discovery node doesn't report it.
+	Authorize(ctx context.Context, discoveryNode *DiscoveryNode, cert core.AuthorizationCertificate) (SessionID, time.Duration, error)
+	// Renew restarts TTL of session that is not expired yet.
+	Renew(ctx context.Context, discoveryNode *DiscoveryNode, cert core.AuthorizationCertificate, sessionID SessionID) error
 	Register(ctx context.Context, discoveryNode *DiscoveryNode, sessionID SessionID) error
 }
 
@@ -61,6 +65,9 @@ const (
 // AuthorizationRequest
 type AuthorizationRequest struct {
 	Certificate []byte
+	// Renew requests renewal of SessionID instead of new session.
+	Renew     bool
+	SessionID SessionID
 }
 
 // AuthorizationResponse
@@ -68,12 +75,16 @@ type AuthorizationResponse struct {
 	Code      OperationCode
 	Error     string
 	SessionID SessionID
+	// TTL of session, joiner should renew session if handshake takes longer.
+	TTL time.Duration
 }
 
 // RegistrationRequest
 type RegistrationRequest struct {
 	SessionID SessionID
 	JoinClaim *packets.NodeJoinClaim
+	// AddressClaim is optional, it is sent by nodes that advertise several addresses.
+	AddressClaim *packets.NodeAddressClaim
 }
 
 // RegistrationResponse
@@ -82,15 +93,8 @@ type RegistrationResponse struct {
 	Error string
 }
 
-func init() {
-	gob.Register(&AuthorizationRequest{})
-	gob.Register(&AuthorizationResponse{})
-	gob.Register(&RegistrationRequest{})
-	gob.Register(&RegistrationResponse{})
-}
-
 // Authorize node on the discovery node (step 2 of the bootstrap process)
-func (ac *authorizationController) Authorize(ctx context.Context, discoveryNode *DiscoveryNode, cert core.AuthorizationCertificate) (SessionID, error) {
+func (ac *authorizationController) Authorize(ctx context.Context, discoveryNode *DiscoveryNode, cert core.AuthorizationCertificate) (SessionID, time.Duration, error) {
 	inslogger.FromContext(ctx).Infof("Authorizing on host: %s", discoveryNode)
 
 	ctx, span := instracer.StartSpan(ctx, "AuthorizationController.Authorize")
@@ -98,27 +102,49 @@ func (ac *authorizationController) Authorize(ctx context.Context, discoveryNode
 		trace.StringAttribute("node", discoveryNode.Node.GetNodeRef().String()),
 	)
 	defer span.End()
+	data, err := ac.sendAuthorizationRequest(ctx, discoveryNode, cert, &AuthorizationRequest{})
+	if err != nil {
+		return 0, 0, err
+	}
+	return data.SessionID, data.TTL, nil
+}
+
+// Renew session on the discovery node, session must not be expired.
+func (ac *authorizationController) Renew(ctx context.Context, discoveryNode *DiscoveryNode, cert core.AuthorizationCertificate, sessionID SessionID) error {
+	inslogger.FromContext(ctx).Infof("Renewing session %d on host: %s", sessionID, discoveryNode)
+
+	ctx, span := instracer.StartSpan(ctx, "AuthorizationController.Renew")
+	span.AddAttributes(
+		trace.StringAttribute("node", discoveryNode.Node.GetNodeRef().String()),
+	)
+	defer span.End()
+	_, err := ac.sendAuthorizationRequest(ctx, discoveryNode, cert, &AuthorizationRequest{Renew: true, SessionID: sessionID})
+	return err
+}
+
+func (ac *authorizationController) sendAuthorizationRequest(ctx context.Context, discoveryNode *DiscoveryNode,
+	cert core.AuthorizationCertificate, data *AuthorizationRequest) (*AuthorizationResponse, error) {
+
 	serializedCert, err := certificate.Serialize(cert)
 	if err != nil {
-		return 0, errors.Wrap(err, "Error serializing certificate")
+		return nil, errors.Wrap(err, "Error serializing certificate")
 	}
+	data.Certificate = serializedCert
 
-	request := ac.transport.NewRequestBuilder().Type(types.Authorize).Data(&AuthorizationRequest{
-		Certificate: serializedCert,
-	}).Build()
+	request := ac.transport.NewRequestBuilder().Type(types.Authorize).Data(data).Build()
 	future, err := ac.transport.SendRequestPacket(ctx, request, discoveryNode.Host)
 	if err != nil {
-		return 0, errors.Wrapf(err, "Error sending authorize request")
+		return nil, errors.Wrapf(err, "Error sending authorize request")
 	}
 	response, err := future.GetResponse(ac.options.PacketTimeout)
 	if err != nil {
-		return 0, errors.Wrapf(err, "Error getting response for authorize request")
+		return nil, errors.Wrapf(err, "Error getting response for authorize request")
 	}
-	data := response.GetData().(*AuthorizationResponse)
-	if data.Code == OpRejected {
-		return 0, errors.New("Authorize rejected: " + data.Error)
+	result := response.GetData().(*AuthorizationResponse)
+	if result.Code == OpRejected {
+		return nil, errors.New("Authorize rejected: " + result.Error)
 	}
-	return data.SessionID, nil
+	return result, nil
 }
 
 // Register node on the discovery node (step 4 of the bootstrap process)
@@ -134,9 +160,14 @@ func (ac *authorizationController) Register(ctx context.Context, discoveryNode *
 	if err != nil {
 		return errors.Wrap(err, "[ Register ] failed to get origin claim")
 	}
+	addressClaim, err := ac.getOriginAddressClaim()
+	if err != nil {
+		return errors.Wrap(err, "[ Register ] failed to get origin address claim")
+	}
 	request := ac.transport.NewRequestBuilder().Type(types.Register).Data(&RegistrationRequest{
-		SessionID: sessionID,
-		JoinClaim: originClaim,
+		SessionID:    sessionID,
+		JoinClaim:    originClaim,
+		AddressClaim: addressClaim,
 	}).Build()
 	future, err := ac.transport.SendRequestPacket(ctx, request, discoveryNode.Host)
 	if err != nil {
@@ -153,7 +184,11 @@ func (ac *authorizationController) Register(ctx context.Context, discoveryNode *
 	return nil
 }
 
-func (ac *authorizationController) checkClaim(sessionID SessionID, claim *packets.NodeJoinClaim) error {
+func (ac *authorizationController) checkClaim(sessionID SessionID, sender core.RecordRef, claim *packets.NodeJoinClaim) error {
+	err := ac.SessionManager.CheckSession(sessionID, sender, Challenge2)
+	if err != nil {
+		return errors.Wrapf(err, "Error checking session %d for authorization", sessionID)
+	}
 	session, err := ac.SessionManager.ReleaseSession(sessionID)
 	if err != nil {
 		return errors.Wrapf(err, "Error getting session %d for authorization", sessionID)
@@ -161,21 +196,41 @@ func (ac *authorizationController) checkClaim(sessionID SessionID, claim *packet
 	if !claim.NodeRef.Equal(session.NodeID) {
 		return errors.New("Claim node ID is not equal to session node ID")
 	}
+	if session.Cert == nil {
+		return errors.New("Session has no certificate to check claim role")
+	}
+	// role of joining node is trusted by other nodes, so it must be the role of certificate
+	if role := core.StaticRole(claim.NodeRoleRecID); role != session.Cert.GetRole() {
+		return errors.Errorf("Claim role %s is not equal to certificate role %s", role, session.Cert.GetRole())
+	}
 	// TODO: check claim signature
 	return nil
 }
 
 func (ac *authorizationController) processRegisterRequest(ctx context.Context, request network.Request) (network.Response, error) {
 	data := request.GetData().(*RegistrationRequest)
-	err := ac.checkClaim(data.SessionID, data.JoinClaim)
+	err := ac.checkClaim(data.SessionID, request.GetSender(), data.JoinClaim)
 	if err != nil {
 		responseAuthorize := &RegistrationResponse{Code: OpRejected, Error: err.Error()}
 		return ac.transport.BuildResponse(ctx, request, responseAuthorize), nil
 	}
 	ac.NodeKeeper.AddPendingClaim(data.JoinClaim)
+	if data.AddressClaim != nil && data.AddressClaim.NodeRef.Equal(data.JoinClaim.NodeRef) {
+		ac.NodeKeeper.AddPendingClaim(data.AddressClaim)
+	}
 	return ac.transport.BuildResponse(ctx, request, &RegistrationResponse{Code: OpConfirmed}), nil
 }
 
+// getOriginAddressClaim returns claim with extra addresses of origin or nil if origin has only one address.
+func (ac *authorizationController) getOriginAddressClaim() (*packets.NodeAddressClaim, error) {
+	origin := ac.NodeKeeper.GetOrigin()
+	addresses := origin.PhysicalAddresses()
+	if len(addresses) < 2 {
+		return nil, nil
+	}
+	return packets.NewNodeAddressClaim(origin.ID(), addresses)
+}
+
 func (ac *authorizationController) processAuthorizeRequest(ctx context.Context, request network.Request) (network.Response, error) {
 	data := request.GetData().(*AuthorizationRequest)
 	cert, err := certificate.Deserialize(data.Certificate, platformpolicy.NewKeyProcessor())
@@ -187,10 +242,26 @@ func (ac *authorizationController) processAuthorizeRequest(ctx context.Context,
 		if err == nil {
 			err = errors.New("Certificate validation failed")
 		}
+		// address reported by sender may belong to another peer, so only connection address is penalized
+		if remote := request.GetRemoteAddress(); remote != "" {
+			ac.transport.Reputation().Penalize(remote, host.OffenceFailedAuth)
+		}
 		return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpRejected, Error: err.Error()}), nil
 	}
-	session := ac.SessionManager.NewSession(request.GetSender(), cert, ac.options.HandshakeSessionTTL)
-	return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpConfirmed, SessionID: session}), nil
+	if !cert.GetNodeRef().Equal(request.GetSender()) {
+		err = errors.Errorf("Certificate of node %s is sent by node %s", cert.GetNodeRef(), request.GetSender())
+		return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpRejected, Error: err.Error()}), nil
+	}
+	ttl := ac.options.HandshakeSessionTTL
+	if data.Renew {
+		err = ac.SessionManager.RenewSession(data.SessionID, request.GetSender(), ttl)
+		if err != nil {
+			return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpRejected, Error: err.Error()}), nil
+		}
+		return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpConfirmed, SessionID: data.SessionID, TTL: ttl}), nil
+	}
+	session := ac.SessionManager.NewSession(request.GetSender(), cert, ttl)
+	return ac.transport.BuildResponse(ctx, request, &AuthorizationResponse{Code: OpConfirmed, SessionID: session, TTL: ttl}), nil
 }
 
 func (ac *authorizationController) Start(ctx context.Context) error {
@@ -19,8 +19,8 @@ package bootstrap
 
 import (
 	"context"
-	"encoding/gob"
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -30,6 +30,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/controller/common"
 	"github.com/insolar/insolar/network/controller/pinger"
@@ -56,8 +57,9 @@ type Bootstrapper interface {
 }
 
 type bootstrapper struct {
-	Certificate core.Certificate   `inject:""`
-	NodeKeeper  network.NodeKeeper `inject:""`
+	Certificate  core.Certificate   `inject:""`
+	NodeKeeper   network.NodeKeeper `inject:""`
+	PulseStorage core.PulseStorage  `inject:""`
 
 	options   *common.Options
 	transport network.InternalTransport
@@ -71,6 +73,8 @@ type bootstrapper struct {
 
 	genesisRequestsReceived map[core.RecordRef]*GenesisRequest
 	genesisLock             sync.Mutex
+
+	joiners joinerQuota
 }
 
 func (bc *bootstrapper) getRequest(ref core.RecordRef) *GenesisRequest {
@@ -87,12 +91,17 @@ func (bc *bootstrapper) setRequest(ref core.RecordRef, req *GenesisRequest) {
 	bc.genesisRequestsReceived[ref] = req
 }
 
-type NodeBootstrapRequest struct{}
+type NodeBootstrapRequest struct {
+	// Probe requests load of discovery node without joining it.
+	Probe bool
+}
 
 type NodeBootstrapResponse struct {
 	Code         Code
 	RedirectHost string
 	RejectReason string
+	// Load is count of joiners accepted by discovery node during current pulse, set in response to probe.
+	Load int
 }
 
 type GenesisRequest struct {
@@ -118,9 +127,12 @@ type NodeStruct struct {
 	PK      []byte
 	Address string
 	Version string
+	// Addresses are all endpoints advertised by node, Address is the first one.
+	Addresses []string
 }
 
-func newNode(n *NodeStruct) (core.Node, error) {
+// NewNode restores node from its serializable representation.
+func NewNode(n *NodeStruct) (core.Node, error) {
 	pk, err := platformpolicy.NewKeyProcessor().ImportPublicKeyPEM(n.PK)
 	if err != nil {
 		return nil, errors.Wrap(err, "error deserializing node public key")
@@ -129,22 +141,25 @@ func newNode(n *NodeStruct) (core.Node, error) {
 	result := nodenetwork.NewNode(n.ID, n.Role, pk, n.Address, n.Version)
 	mNode := result.(nodenetwork.MutableNode)
 	mNode.SetShortID(n.SID)
+	mNode.SetPhysicalAddresses(n.Addresses)
 	return mNode, nil
 }
 
-func newNodeStruct(node core.Node) (*NodeStruct, error) {
+// NewNodeStruct makes serializable representation of node.
+func NewNodeStruct(node core.Node) (*NodeStruct, error) {
 	pk, err := platformpolicy.NewKeyProcessor().ExportPublicKeyPEM(node.PublicKey())
 	if err != nil {
 		return nil, errors.Wrap(err, "error serializing node public key")
 	}
 
 	return &NodeStruct{
-		ID:      node.ID(),
-		SID:     node.ShortID(),
-		Role:    node.Role(),
-		PK:      pk,
-		Address: node.PhysicalAddress(),
-		Version: node.Version(),
+		ID:        node.ID(),
+		SID:       node.ShortID(),
+		Role:      node.Role(),
+		PK:        pk,
+		Address:   node.PhysicalAddress(),
+		Version:   node.Version(),
+		Addresses: node.PhysicalAddresses(),
 	}, nil
 }
 
@@ -156,20 +171,23 @@ const (
 	Redirected
 )
 
-func init() {
-	gob.Register(&NodeBootstrapRequest{})
-	gob.Register(&NodeBootstrapResponse{})
-	gob.Register(&StartSessionRequest{})
-	gob.Register(&StartSessionResponse{})
-	gob.Register(&GenesisRequest{})
-	gob.Register(&GenesisResponse{})
-}
-
 // Bootstrap on the discovery node (step 1 of the bootstrap process)
 func (bc *bootstrapper) Bootstrap(ctx context.Context) (*DiscoveryNode, error) {
 	log.Info("Bootstrapping to discovery node")
 	ctx, span := instracer.StartSpan(ctx, "Bootstrapper.Bootstrap")
 	defer span.End()
+	if bc.options.BootstrapProbeRTT {
+		for _, probe := range bc.probeDiscoveryNodes(ctx, bc.Certificate.GetDiscoveryNodes()) {
+			inslogger.FromContext(ctx).Infof("Bootstrapping to discovery node %s, RTT %v", probe.address, probe.rtt)
+			host, err := bc.startBootstrap(ctx, probe.address)
+			if err != nil {
+				inslogger.FromContext(ctx).Warnf("Error bootstrapping to address %s: %s", probe.address, err)
+				continue
+			}
+			return &DiscoveryNode{Host: host, Node: FindDiscovery(bc.Certificate, host.NodeID)}, nil
+		}
+		log.Info("Failed to bootstrap to probed discovery nodes, bootstrapping to the first one that answers")
+	}
 	ch := bc.getDiscoveryNodesChannel(ctx, bc.Certificate.GetDiscoveryNodes(), 1)
 	host := bc.waitResultFromChannel(ctx, ch)
 	if host == nil {
@@ -286,7 +304,7 @@ func (bc *bootstrapper) calculateLastIgnoredPulse(ctx context.Context, lastPulse
 func (bc *bootstrapper) sendGenesisRequest(ctx context.Context, h *host.Host) (*GenesisResponse, error) {
 	ctx, span := instracer.StartSpan(ctx, "Bootstrapper.sendGenesisRequest")
 	defer span.End()
-	discovery, err := newNodeStruct(bc.NodeKeeper.GetOrigin())
+	discovery, err := NewNodeStruct(bc.NodeKeeper.GetOrigin())
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to prepare genesis request to address %s", h)
 	}
@@ -394,7 +412,7 @@ func (bc *bootstrapper) waitGenesisResults(ctx context.Context, ch <-chan *Genes
 	for {
 		select {
 		case res := <-ch:
-			discovery, err := newNode(res.Response.Discovery)
+			discovery, err := NewNode(res.Response.Discovery)
 			if err != nil {
 				return nil, nil, errors.Wrap(err, "Error deserializing node from discovery node")
 			}
@@ -411,24 +429,65 @@ func (bc *bootstrapper) waitGenesisResults(ctx context.Context, ch <-chan *Genes
 }
 
 func bootstrap(ctx context.Context, address string, options *common.Options, bootstrapF func(context.Context, string) (*host.Host, error)) (*host.Host, error) {
-	minTO := options.MinTimeout
 	if !options.InfinityBootstrap {
-		return bootstrapF(ctx, address)
+		return bootstrapAttempt(ctx, address, bootstrapF)
 	}
-	for {
-		result, err := bootstrapF(ctx, address)
+	delay := options.MinTimeout
+	for attempt := 1; ; attempt++ {
+		result, err := bootstrapAttempt(ctx, address, bootstrapF)
 		if err == nil {
 			return result, nil
 		}
-		time.Sleep(minTO)
-		minTO *= options.TimeoutMult
-		if minTO > options.MaxTimeout {
-			minTO = options.MaxTimeout
+		if options.BootstrapMaxAttempts > 0 && attempt >= options.BootstrapMaxAttempts {
+			return nil, errors.Wrapf(err, "Bootstrap to address %s failed after %d attempts", address, attempt)
+		}
+		wait := withJitter(delay, options.BootstrapJitter)
+		inslogger.FromContext(ctx).Debugf("Bootstrap attempt %d to address %s failed, retry in %v: %s", attempt, address, wait, err)
+		if options.BootstrapAbortOnCancel {
+			select {
+			case <-ctx.Done():
+				return nil, errors.Wrapf(ctx.Err(), "Bootstrap to address %s canceled", address)
+			case <-time.After(wait):
+			}
+		} else {
+			time.Sleep(wait)
+		}
+		delay = time.Duration(float64(delay) * options.TimeoutMult)
+		if delay > options.MaxTimeout {
+			delay = options.MaxTimeout
 		}
 	}
 }
 
+func bootstrapAttempt(ctx context.Context, address string, bootstrapF func(context.Context, string) (*host.Host, error)) (*host.Host, error) {
+	start := time.Now()
+	result, err := bootstrapF(ctx, address)
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	metrics.NetworkBootstrapAttempts.WithLabelValues(status).Inc()
+	metrics.NetworkBootstrapAttemptTime.WithLabelValues(status).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+// withJitter randomizes jitter fraction of delay, so nodes restarted at the same time do not retry simultaneously.
+func withJitter(delay time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return delay
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	return delay - time.Duration(jitter*rand.Float64()*float64(delay))
+}
+
 func (bc *bootstrapper) startBootstrap(ctx context.Context, address string) (*host.Host, error) {
+	return bc.startBootstrapRedirected(ctx, address, nil)
+}
+
+// startBootstrapRedirected bootstraps to address, redirects are addresses of discovery nodes passed before.
+func (bc *bootstrapper) startBootstrapRedirected(ctx context.Context, address string, redirects []string) (*host.Host, error) {
 	ctx, span := instracer.StartSpan(ctx, "Bootstrapper.startBootstrap")
 	defer span.End()
 	bootstrapHost, err := bc.pinger.Ping(ctx, address, bc.options.PingTimeout)
@@ -449,19 +508,39 @@ func (bc *bootstrapper) startBootstrap(ctx context.Context, address string) (*ho
 		return nil, errors.New("Rejected: " + data.RejectReason)
 	}
 	if data.Code == Redirected {
-		return bootstrap(ctx, data.RedirectHost, bc.options, bc.startBootstrap)
+		redirects = append(redirects, address)
+		if err := checkRedirect(redirects, data.RedirectHost, bc.options.BootstrapMaxRedirects); err != nil {
+			return nil, err
+		}
+		inslogger.FromContext(ctx).Infof("Bootstrap to address %s redirected to %s", address, data.RedirectHost)
+		return bootstrap(ctx, data.RedirectHost, bc.options, func(ctx context.Context, address string) (*host.Host, error) {
+			return bc.startBootstrapRedirected(ctx, address, redirects)
+		})
 	}
 	return response.GetSenderHost(), nil
 }
 
 func (bc *bootstrapper) processBootstrap(ctx context.Context, request network.Request) (network.Response, error) {
-	// TODO: redirect logic
-	return bc.transport.BuildResponse(ctx, request, &NodeBootstrapResponse{Code: Accepted}), nil
+	data := request.GetData().(*NodeBootstrapRequest)
+	pulse := bc.currentPulse(ctx)
+	if data.Probe {
+		return bc.transport.BuildResponse(ctx, request, &NodeBootstrapResponse{Code: Accepted, Load: bc.joiners.load(pulse)}), nil
+	}
+	if bc.joiners.take(pulse, bc.options.BootstrapJoinerQuota) {
+		return bc.transport.BuildResponse(ctx, request, &NodeBootstrapResponse{Code: Accepted}), nil
+	}
+	address, err := bc.leastLoadedDiscovery(ctx, pulse)
+	if err != nil {
+		inslogger.FromContext(ctx).Warnf("Joiner quota is exceeded, accepting joiner anyway: %s", err)
+		bc.joiners.take(pulse, 0)
+		return bc.transport.BuildResponse(ctx, request, &NodeBootstrapResponse{Code: Accepted}), nil
+	}
+	return bc.transport.BuildResponse(ctx, request, &NodeBootstrapResponse{Code: Redirected, RedirectHost: address}), nil
 }
 
 func (bc *bootstrapper) processGenesis(ctx context.Context, request network.Request) (network.Response, error) {
 	data := request.GetData().(*GenesisRequest)
-	discovery, err := newNodeStruct(bc.NodeKeeper.GetOrigin())
+	discovery, err := NewNodeStruct(bc.NodeKeeper.GetOrigin())
 	if err != nil {
 		return bc.transport.BuildResponse(ctx, request, &GenesisResponse{Error: err.Error()}), nil
 	}
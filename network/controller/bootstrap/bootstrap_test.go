@@ -30,7 +30,7 @@ import (
 
 func getOptions(infinity bool) *common.Options {
 	return &common.Options{
-		TimeoutMult:       2 * time.Millisecond,
+		TimeoutMult:       2,
 		InfinityBootstrap: infinity,
 		MinTimeout:        100 * time.Millisecond,
 		MaxTimeout:        200 * time.Millisecond,
@@ -68,3 +68,37 @@ func TestBootstrap(t *testing.T) {
 	assert.NoError(t, err)
 	assert.WithinDuration(t, expectedTime.Round(time.Millisecond), endTime.Round(time.Millisecond), time.Millisecond*100)
 }
+
+func TestBootstrap_MaxAttempts(t *testing.T) {
+	options := getOptions(true)
+	options.MinTimeout = time.Millisecond
+	options.BootstrapMaxAttempts = 3
+	attempts := 0
+	_, err := bootstrap(context.Background(), "192.180.0.1:1234", options, func(context.Context, string) (*host.Host, error) {
+		attempts++
+		return nil, BootstrapError
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestBootstrap_AbortOnCancel(t *testing.T) {
+	options := getOptions(true)
+	options.BootstrapAbortOnCancel = true
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+	_, err := bootstrap(ctx, "192.180.0.1:1234", options, mockBootstrap)
+	assert.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+	assert.True(t, time.Since(startTime) < options.MinTimeout)
+}
+
+func TestWithJitter(t *testing.T) {
+	assert.Equal(t, time.Second, withJitter(time.Second, 0))
+	for i := 0; i < 100; i++ {
+		delay := withJitter(time.Second, 0.5)
+		assert.True(t, delay > 500*time.Millisecond && delay <= time.Second)
+	}
+}
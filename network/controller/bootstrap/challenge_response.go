@@ -19,7 +19,6 @@ package bootstrap
 
 import (
 	"context"
-	"encoding/gob"
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/core"
@@ -44,6 +43,7 @@ type challengeResponseController struct {
 	SessionManager SessionManager           `inject:""`
 	Cryptography   core.CryptographyService `inject:""`
 	NodeKeeper     network.NodeKeeper       `inject:""`
+	Standby        StandbyController        `inject:""`
 
 	options   *common.Options
 	transport network.InternalTransport
@@ -99,19 +99,12 @@ type ChallengePayload struct {
 	AssignShortID core.ShortNodeID
 }
 
-func init() {
-	gob.Register(&ChallengeRequest{})
-	gob.Register(&SignedChallengeResponse{})
-	gob.Register(&SignedChallengeRequest{})
-	gob.Register(&ChallengeResponse{})
-}
-
 func (cr *challengeResponseController) processChallenge1(ctx context.Context, request network.Request) (network.Response, error) {
 	ctx, span := instracer.StartSpan(ctx, "ChallengeResponseController.processChallenge1")
 	defer span.End()
 	data := request.GetData().(*ChallengeRequest)
 	// CheckSession is performed in SetDiscoveryNonce too, but we want to return early if the request is invalid
-	err := cr.SessionManager.CheckSession(data.SessionID, Authorized)
+	err := cr.SessionManager.CheckSession(data.SessionID, request.GetSender(), Authorized)
 	if err != nil {
 		return cr.buildChallenge1ErrorResponse(ctx, request, err.Error()), nil
 	}
@@ -119,7 +112,8 @@ func (cr *challengeResponseController) processChallenge1(ctx context.Context, re
 	if err != nil {
 		return cr.buildChallenge1ErrorResponse(ctx, request, "error generating discovery xor nonce: "+err.Error()), nil
 	}
-	sign, err := cr.Cryptography.Sign(Xor(data.Nonce, xorNonce))
+	// promoted standby signs on behalf of its primary, joining node checks signature with primary key
+	sign, err := cr.Standby.Signer().Sign(Xor(data.Nonce, xorNonce))
 	if err != nil {
 		return cr.buildChallenge1ErrorResponse(ctx, request, "error signing nonce: "+err.Error()), nil
 	}
@@ -146,7 +140,7 @@ func (cr *challengeResponseController) processChallenge1(ctx context.Context, re
 
 func (cr *challengeResponseController) buildChallenge1ErrorResponse(ctx context.Context, request network.Request, err string) network.Response {
 	log.Warn(err)
-	return cr.transport.BuildResponse(ctx, request, &ChallengeResponse{
+	return cr.transport.BuildResponse(ctx, request, &SignedChallengeResponse{
 		Header: ChallengeResponseHeader{
 			Success: false,
 			Error:   err,
@@ -158,6 +152,10 @@ func (cr *challengeResponseController) processChallenge2(ctx context.Context, re
 	ctx, span := instracer.StartSpan(ctx, "ChallengeResponseController.processChallenge2")
 	defer span.End()
 	data := request.GetData().(*SignedChallengeRequest)
+	err := cr.SessionManager.CheckSession(data.SessionID, request.GetSender(), Challenge1)
+	if err != nil {
+		return cr.buildChallenge2ErrorResponse(ctx, request, err.Error()), nil
+	}
 	cert, discoveryNonce, err := cr.SessionManager.GetChallengeData(data.SessionID)
 	if err != nil {
 		return cr.buildChallenge2ErrorResponse(ctx, request, err.Error()), nil
@@ -184,7 +182,7 @@ func (cr *challengeResponseController) processChallenge2(ctx context.Context, re
 
 func (cr *challengeResponseController) buildChallenge2ErrorResponse(ctx context.Context, request network.Request, err string) network.Response {
 	log.Warn(err)
-	return cr.transport.BuildResponse(ctx, request, &SignedChallengeResponse{
+	return cr.transport.BuildResponse(ctx, request, &ChallengeResponse{
 		Header: ChallengeResponseHeader{
 			Success: false,
 			Error:   err,
@@ -19,6 +19,7 @@ package bootstrap
 
 import (
 	"context"
+	"time"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/instracer"
@@ -76,15 +77,23 @@ func (nb *networkBootstrapper) bootstrapJoiner(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "Error bootstrapping to discovery node")
 	}
-	sessionID, err := nb.AuthController.Authorize(ctx, discoveryNode, nb.Certificate)
+	sessionID, ttl, err := nb.AuthController.Authorize(ctx, discoveryNode, nb.Certificate)
 	if err != nil {
 		return errors.Wrap(err, "Error authorizing on discovery node")
 	}
+	authorized := time.Now()
 
 	data, err := nb.ChallengeController.Execute(ctx, discoveryNode, sessionID)
 	if err != nil {
 		return errors.Wrap(err, "Error executing double challenge response")
 	}
+	// slow challenge leaves too little time for registration
+	if ttl > 0 && time.Since(authorized) > ttl/2 {
+		err = nb.AuthController.Renew(ctx, discoveryNode, nb.Certificate, sessionID)
+		if err != nil {
+			return errors.Wrap(err, "Error renewing session on discovery node")
+		}
+	}
 	origin := nb.NodeKeeper.GetOrigin()
 	mutableOrigin := origin.(nodenetwork.MutableNode)
 	mutableOrigin.SetShortID(data.AssignShortID)
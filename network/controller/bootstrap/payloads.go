@@ -0,0 +1,74 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package bootstrap
+
+import (
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// payloadLimit is max size of encoded bootstrap payload.
+	payloadLimit = 64 * 1024
+	// snapshotLimit is max size of encoded standby snapshot.
+	snapshotLimit = 32 * 1024 * 1024
+)
+
+type payload struct {
+	key       packet.PayloadKey
+	prototype interface{}
+	limit     int
+}
+
+func requestPayload(t types.PacketType, version uint16, prototype interface{}, limit int) payload {
+	return payload{key: packet.PayloadKey{Type: t, Version: version}, prototype: prototype, limit: limit}
+}
+
+func responsePayload(t types.PacketType, version uint16, prototype interface{}, limit int) payload {
+	return payload{key: packet.PayloadKey{Type: t, Response: true, Version: version}, prototype: prototype, limit: limit}
+}
+
+// payloads are all bootstrap payloads. Layout of payload must never change once released: add new type with next
+// version instead, so nodes of different releases reject each other's packets instead of misreading them.
+// The only exception is a new optional field, gob leaves it empty when decoding packets of older nodes.
+var payloads = []payload{
+	requestPayload(types.Bootstrap, 1, &NodeBootstrapRequest{}, payloadLimit),
+	responsePayload(types.Bootstrap, 1, &NodeBootstrapResponse{}, payloadLimit),
+	requestPayload(types.Genesis, 1, &GenesisRequest{}, payloadLimit),
+	responsePayload(types.Genesis, 1, &GenesisResponse{}, payloadLimit),
+	requestPayload(types.Authorize, 1, &AuthorizationRequest{}, payloadLimit),
+	responsePayload(types.Authorize, 1, &AuthorizationResponse{}, payloadLimit),
+	requestPayload(types.Register, 1, &RegistrationRequest{}, payloadLimit),
+	responsePayload(types.Register, 1, &RegistrationResponse{}, payloadLimit),
+	requestPayload(types.Challenge1, 1, &ChallengeRequest{}, payloadLimit),
+	responsePayload(types.Challenge1, 1, &SignedChallengeResponse{}, payloadLimit),
+	requestPayload(types.Challenge2, 1, &SignedChallengeRequest{}, payloadLimit),
+	responsePayload(types.Challenge2, 1, &ChallengeResponse{}, payloadLimit),
+	requestPayload(types.StandbySync, 1, &StandbySyncRequest{}, payloadLimit),
+	responsePayload(types.StandbySync, 1, &StandbySyncResponse{}, snapshotLimit),
+}
+
+// RegisterPayloads registers bootstrap payloads in registry.
+func RegisterPayloads(registry *packet.PayloadRegistry) error {
+	for _, p := range payloads {
+		if err := registry.Register(p.key, p.prototype, p.limit); err != nil {
+			return errors.Wrap(err, "failed to register bootstrap payload")
+		}
+	}
+	return nil
+}
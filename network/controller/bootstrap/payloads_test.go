@@ -0,0 +1,55 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package bootstrap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterPayloads(t *testing.T) {
+	registry := packet.NewPayloadRegistry()
+	require.NoError(t, RegisterPayloads(registry))
+	require.NoError(t, RegisterPayloads(registry))
+}
+
+func TestBootstrapPayloadSerialization(t *testing.T) {
+	require.NoError(t, RegisterPayloads(packet.Payloads))
+	sender, err := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	require.NoError(t, err)
+	builder := packet.NewBuilder(sender).Receiver(sender).Type(types.Challenge1)
+
+	request := builder.Request(&ChallengeRequest{SessionID: 1, Nonce: Nonce{1, 2, 3}}).Build()
+	response := builder.Response(&SignedChallengeResponse{Header: ChallengeResponseHeader{Error: "error"}}).Build()
+	for _, msg := range []*packet.Packet{request, response} {
+		serialized, err := packet.SerializePacket(msg)
+		require.NoError(t, err)
+		deserialized, err := packet.DeserializePacket(bytes.NewReader(serialized))
+		require.NoError(t, err)
+		require.Equal(t, msg, deserialized)
+	}
+
+	// challenge 1 is never answered with challenge 2 response
+	_, err = packet.SerializePacket(builder.Response(&ChallengeResponse{}).Build())
+	require.Error(t, err)
+}
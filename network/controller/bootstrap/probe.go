@@ -0,0 +1,97 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package bootstrap
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/host"
+)
+
+// probeResult is RTT of discovery node weighted by preferences.
+type probeResult struct {
+	address string
+	rtt     time.Duration
+	score   float64
+}
+
+// probeDiscoveryNodes pings discovery nodes concurrently and returns reachable ones ordered by weighted RTT.
+func (bc *bootstrapper) probeDiscoveryNodes(ctx context.Context, discoveryNodes []core.DiscoveryNode) []probeResult {
+	origin := hostIP(bc.transport.PublicAddress())
+	results := make([]probeResult, 0, len(discoveryNodes))
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(discoveryNodes))
+	for _, discoveryNode := range discoveryNodes {
+		go func(address string) {
+			defer wg.Done()
+			start := time.Now()
+			_, err := bc.pinger.Ping(ctx, address, bc.options.PingTimeout)
+			if err != nil {
+				inslogger.FromContext(ctx).Warnf("Discovery node %s is unreachable: %s", address, err)
+				return
+			}
+			rtt := time.Since(start)
+			result := probeResult{address: address, rtt: rtt, score: float64(rtt) * preferenceWeight(bc.options, origin, hostIP(address))}
+
+			lock.Lock()
+			defer lock.Unlock()
+			results = append(results, result)
+		}(discoveryNode.GetHost())
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].score < results[j].score
+	})
+	return results
+}
+
+// preferenceWeight returns multiplier of RTT to discovery node, nodes in the same subnet or region are preferred.
+func preferenceWeight(options *common.Options, origin, discovery net.IP) float64 {
+	weight := 1.0
+	if origin == nil || discovery == nil {
+		return weight
+	}
+	if options.BootstrapSameSubnetWeight > 0 && host.SameSubnet(origin, discovery) {
+		weight *= options.BootstrapSameSubnetWeight
+	}
+	if options.BootstrapSameRegionWeight > 0 {
+		for _, subnet := range options.BootstrapRegionSubnets {
+			if subnet.Contains(discovery) {
+				weight *= options.BootstrapSameRegionWeight
+				break
+			}
+		}
+	}
+	return weight
+}
+
+func hostIP(address string) net.IP {
+	h, _, err := net.SplitHostPort(address)
+	if err != nil {
+		h = address
+	}
+	return net.ParseIP(h)
+}
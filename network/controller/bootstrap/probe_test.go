@@ -0,0 +1,40 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package bootstrap
+
+import (
+	"net"
+	"testing"
+
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreferenceWeight(t *testing.T) {
+	_, region, _ := net.ParseCIDR("10.1.0.0/16")
+	options := &common.Options{
+		BootstrapSameSubnetWeight: 0.5,
+		BootstrapSameRegionWeight: 0.8,
+		BootstrapRegionSubnets:    []*net.IPNet{region},
+	}
+	origin := hostIP("10.1.1.1:31337")
+
+	assert.Equal(t, 1.0, preferenceWeight(options, origin, hostIP("192.168.1.1:31337")))
+	assert.Equal(t, 0.8, preferenceWeight(options, origin, hostIP("10.1.2.1:31337")))
+	assert.Equal(t, 0.4, preferenceWeight(options, origin, hostIP("10.1.1.2:31337")))
+	assert.Equal(t, 1.0, preferenceWeight(options, nil, hostIP("10.1.1.2:31337")))
+}
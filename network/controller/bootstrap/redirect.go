@@ -0,0 +1,150 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package bootstrap
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// joinerQuota counts joiners accepted by discovery node during current pulse.
+type joinerQuota struct {
+	lock  sync.Mutex
+	pulse core.PulseNumber
+	count int
+}
+
+// load returns count of joiners accepted during pulse.
+func (q *joinerQuota) load(pulse core.PulseNumber) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.pulse != pulse {
+		return 0
+	}
+	return q.count
+}
+
+// take counts joiner if less than limit joiners were accepted during pulse, 0 limit means no limit.
+func (q *joinerQuota) take(pulse core.PulseNumber, limit int) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.pulse != pulse {
+		q.pulse = pulse
+		q.count = 0
+	}
+	if limit > 0 && q.count >= limit {
+		return false
+	}
+	q.count++
+	return true
+}
+
+// checkRedirect fails if joiner was redirected too many times or redirect leads to discovery node passed before.
+func checkRedirect(redirects []string, address string, maxRedirects int) error {
+	if maxRedirects > 0 && len(redirects) > maxRedirects {
+		return errors.Errorf("Too many bootstrap redirects: %v", redirects)
+	}
+	for _, passed := range redirects {
+		if passed == address {
+			return errors.Errorf("Bootstrap redirect loop to address %s: %v", address, redirects)
+		}
+	}
+	return nil
+}
+
+func (bc *bootstrapper) currentPulse(ctx context.Context) core.PulseNumber {
+	pulse, err := bc.PulseStorage.Current(ctx)
+	if err != nil {
+		// no pulse yet, all joiners before the first pulse share one quota
+		return 0
+	}
+	return pulse.PulseNumber
+}
+
+// leastLoadedDiscovery probes other discovery nodes concurrently and returns address of the one that accepted
+// the least joiners during pulse and still has free quota. Discovery nodes are expected to share quota setting.
+func (bc *bootstrapper) leastLoadedDiscovery(ctx context.Context, pulse core.PulseNumber) (string, error) {
+	discoveryNodes := append([]core.DiscoveryNode{}, bc.Certificate.GetDiscoveryNodes()...)
+	discoveryNodes, err := RemoveOrigin(discoveryNodes, *bc.Certificate.GetNodeRef())
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to get peer discovery nodes")
+	}
+
+	type result struct {
+		address string
+		load    int
+	}
+	results := make([]result, 0, len(discoveryNodes))
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(discoveryNodes))
+	for _, discoveryNode := range discoveryNodes {
+		go func(address string) {
+			defer wg.Done()
+			load, err := bc.probeLoad(ctx, address)
+			if err != nil {
+				inslogger.FromContext(ctx).Debugf("Failed to get load of discovery node %s: %s", address, err)
+				return
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			results = append(results, result{address: address, load: load})
+		}(discoveryNode.GetHost())
+	}
+	wg.Wait()
+
+	best := -1
+	for i, r := range results {
+		if r.load >= bc.options.BootstrapJoinerQuota {
+			continue
+		}
+		if best < 0 || r.load < results[best].load {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", errors.Errorf("No discovery node with free joiner quota in pulse %d", pulse)
+	}
+	return results[best].address, nil
+}
+
+// probeLoad requests count of joiners accepted by discovery node during current pulse.
+func (bc *bootstrapper) probeLoad(ctx context.Context, address string) (int, error) {
+	h, err := bc.pinger.Ping(ctx, address, bc.options.PingTimeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to ping address %s", address)
+	}
+	request := bc.transport.NewRequestBuilder().Type(types.Bootstrap).Data(&NodeBootstrapRequest{Probe: true}).Build()
+	future, err := bc.transport.SendRequestPacket(ctx, request, h)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to send bootstrap probe to address %s", address)
+	}
+	response, err := future.GetResponse(bc.options.PingTimeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Failed to get response to bootstrap probe from address %s", address)
+	}
+	return response.GetData().(*NodeBootstrapResponse).Load, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinerQuota(t *testing.T) {
+	q := joinerQuota{}
+	pulse := core.PulseNumber(core.FirstPulseNumber)
+
+	assert.True(t, q.take(pulse, 2))
+	assert.True(t, q.take(pulse, 2))
+	assert.False(t, q.take(pulse, 2))
+	assert.Equal(t, 2, q.load(pulse))
+	assert.Equal(t, 0, q.load(pulse+1))
+
+	// quota is reset on the next pulse
+	assert.True(t, q.take(pulse+1, 2))
+	assert.Equal(t, 1, q.load(pulse+1))
+
+	// no limit
+	for i := 0; i < 10; i++ {
+		assert.True(t, q.take(pulse+1, 0))
+	}
+	assert.Equal(t, 11, q.load(pulse+1))
+}
+
+func TestCheckRedirect(t *testing.T) {
+	assert.NoError(t, checkRedirect([]string{"127.0.0.1:1"}, "127.0.0.1:2", 3))
+	// loop
+	assert.Error(t, checkRedirect([]string{"127.0.0.1:1", "127.0.0.1:2"}, "127.0.0.1:1", 3))
+	// too many redirects
+	assert.NoError(t, checkRedirect([]string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"}, "127.0.0.1:4", 3))
+	assert.Error(t, checkRedirect([]string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3", "127.0.0.1:4"}, "127.0.0.1:5", 3))
+	// no limit
+	assert.NoError(t, checkRedirect([]string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3", "127.0.0.1:4"}, "127.0.0.1:5", 0))
+}
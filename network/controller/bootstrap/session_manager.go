@@ -76,11 +76,19 @@ type SessionManager interface {
 	component.Stopper
 
 	NewSession(ref core.RecordRef, cert core.AuthorizationCertificate, ttl time.Duration) SessionID
-	CheckSession(id SessionID, expected SessionState) error
+	// RenewSession restarts TTL of session, session must not be expired and must belong to node.
+	RenewSession(id SessionID, ref core.RecordRef, ttl time.Duration) error
+	// CheckSession checks that session is not expired, belongs to node and has expected state.
+	CheckSession(id SessionID, ref core.RecordRef, expected SessionState) error
 	SetDiscoveryNonce(id SessionID, discoveryNonce Nonce) error
 	GetChallengeData(id SessionID) (core.AuthorizationCertificate, Nonce, error)
 	ChallengePassed(id SessionID) error
 	ReleaseSession(id SessionID) (*Session, error)
+
+	// Snapshot returns copy of all active sessions.
+	Snapshot() map[SessionID]Session
+	// Restore adds sessions from snapshot, expired ones are skipped.
+	Restore(sessions map[SessionID]Session)
 }
 
 type sessionManager struct {
@@ -150,23 +158,68 @@ func (sm *sessionManager) NewSession(ref core.RecordRef, cert core.Authorization
 	return sessionID
 }
 
-func (sm *sessionManager) CheckSession(id SessionID, expected SessionState) error {
+func (sm *sessionManager) RenewSession(id SessionID, ref core.RecordRef, ttl time.Duration) error {
+	_, span := instracer.StartSpan(context.Background(), "SessionManager.RenewSession wait lock")
+	sm.lock.Lock()
+	span.End()
+
+	session, err := sm.getSession(id)
+	if err == nil {
+		err = checkSessionNode(id, session, ref)
+	}
+	if err != nil {
+		sm.lock.Unlock()
+		return err
+	}
+	session.Time = time.Now()
+	session.TTL = ttl
+	sm.lock.Unlock()
+
+	// expiration order has changed
+	sm.newSessionNotification <- notification{}
+	return nil
+}
+
+func (sm *sessionManager) CheckSession(id SessionID, ref core.RecordRef, expected SessionState) error {
 	_, span := instracer.StartSpan(context.Background(), "SessionManager.CheckSession wait lock")
 	sm.lock.RLock()
 	span.End()
 	defer sm.lock.RUnlock()
 
-	_, err := sm.checkSession(id, expected)
-	return err
+	session, err := sm.checkSession(id, expected)
+	if err != nil {
+		return err
+	}
+	return checkSessionNode(id, session, ref)
 }
 
 func (sm *sessionManager) checkSession(id SessionID, expected SessionState) (*Session, error) {
+	session, err := sm.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.State != expected {
+		return nil, errors.New(fmt.Sprintf("session %d should have state %s but has %s", id, expected, session.State))
+	}
+	return session, nil
+}
+
+// checkSessionNode binds session to the node that authorized it.
+func checkSessionNode(id SessionID, session *Session, ref core.RecordRef) error {
+	if !session.NodeID.Equal(ref) {
+		return errors.New(fmt.Sprintf("session %d belongs to another node", id))
+	}
+	return nil
+}
+
+// getSession returns session that is not expired, cleanup of expired sessions may lag behind.
+func (sm *sessionManager) getSession(id SessionID) (*Session, error) {
 	session := sm.sessions[id]
 	if session == nil {
 		return nil, errors.New(fmt.Sprintf("no such session ID: %d", id))
 	}
-	if session.State != expected {
-		return nil, errors.New(fmt.Sprintf("session %d should have state %s but has %s", id, expected, session.State))
+	if !session.expirationTime().After(time.Now()) {
+		return nil, errors.New(fmt.Sprintf("session %d is expired", id))
 	}
 	return session, nil
 }
@@ -227,6 +280,48 @@ func (sm *sessionManager) ReleaseSession(id SessionID) (*Session, error) {
 	return session, nil
 }
 
+func (sm *sessionManager) Snapshot() map[SessionID]Session {
+	_, span := instracer.StartSpan(context.Background(), "SessionManager.Snapshot wait lock")
+	sm.lock.RLock()
+	span.End()
+	defer sm.lock.RUnlock()
+
+	result := make(map[SessionID]Session, len(sm.sessions))
+	for id, session := range sm.sessions {
+		result[id] = *session
+	}
+	return result
+}
+
+func (sm *sessionManager) Restore(sessions map[SessionID]Session) {
+	_, span := instracer.StartSpan(context.Background(), "SessionManager.Restore wait lock")
+	sm.lock.Lock()
+	span.End()
+
+	now := time.Now()
+	restored := 0
+	for id, session := range sessions {
+		session := session
+		if !session.expirationTime().After(now) {
+			continue
+		}
+		sm.sessions[id] = &session
+		restored++
+		// new sessions must not reuse restored IDs
+		for {
+			sequence := atomic.LoadUint64(&sm.sequence)
+			if uint64(id) < sequence || atomic.CompareAndSwapUint64(&sm.sequence, sequence, uint64(id)+1) {
+				break
+			}
+		}
+	}
+	sm.lock.Unlock()
+
+	if restored > 0 {
+		sm.newSessionNotification <- notification{}
+	}
+}
+
 func (sm *sessionManager) cleanupExpiredSessions() {
 	var sessionsByExpirationTime []*sessionWithID
 	for {
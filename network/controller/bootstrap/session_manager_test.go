@@ -136,3 +136,60 @@ func TestSessionManager_DoubleStop(t *testing.T) {
 	err = sm.Stop(context.Background())
 	require.NoError(t, err)
 }
+
+func TestSessionManager_SnapshotRestore(t *testing.T) {
+	primary := NewSessionManager()
+	require.NoError(t, primary.Start(context.Background()))
+	defer primary.Stop(context.Background())
+
+	id := primary.NewSession(core.RecordRef{1}, nil, time.Minute)
+	require.NoError(t, primary.SetDiscoveryNonce(id, Nonce{1, 2, 3}))
+	primary.NewSession(core.RecordRef{2}, nil, time.Minute)
+
+	snapshot := primary.Snapshot()
+	require.Len(t, snapshot, 2)
+	snapshot[id+10] = Session{NodeID: core.RecordRef{3}, State: Authorized, Time: time.Now().Add(-time.Hour), TTL: time.Minute}
+
+	standby := NewSessionManager()
+	require.NoError(t, standby.Start(context.Background()))
+	defer standby.Stop(context.Background())
+
+	standby.Restore(snapshot)
+	assert.Equal(t, 2, sessionMapLen(standby))
+
+	_, nonce, err := standby.GetChallengeData(id)
+	require.NoError(t, err)
+	assert.Equal(t, Nonce{1, 2, 3}, nonce)
+
+	newID := standby.NewSession(core.RecordRef{4}, nil, time.Minute)
+	assert.True(t, newID > id+1)
+}
+
+func TestSessionManager_BindingAndRenewal(t *testing.T) {
+	sm := NewSessionManager()
+	require.NoError(t, sm.Start(context.Background()))
+	defer sm.Stop(context.Background())
+
+	node := core.RecordRef{1}
+	id := sm.NewSession(node, nil, time.Minute)
+	require.NoError(t, sm.CheckSession(id, node, Authorized))
+	assert.Error(t, sm.CheckSession(id, core.RecordRef{2}, Authorized))
+	assert.Error(t, sm.CheckSession(id, node, Challenge1))
+	assert.Error(t, sm.RenewSession(id, core.RecordRef{2}, time.Minute))
+	assert.Error(t, sm.RenewSession(id+1, node, time.Minute))
+
+	// expired session is rejected even if it is not cleaned up yet
+	s := sm.(*sessionManager)
+	s.lock.Lock()
+	s.sessions[id].Time = time.Now().Add(-2 * time.Minute)
+	s.lock.Unlock()
+	assert.Error(t, sm.CheckSession(id, node, Authorized))
+	assert.Error(t, sm.RenewSession(id, node, time.Minute))
+
+	id = sm.NewSession(node, nil, time.Second)
+	time.Sleep(600 * time.Millisecond)
+	require.NoError(t, sm.RenewSession(id, node, time.Second))
+	time.Sleep(600 * time.Millisecond)
+	// session would be expired without renewal
+	assert.NoError(t, sm.CheckSession(id, node, Authorized))
+}
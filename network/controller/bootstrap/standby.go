@@ -0,0 +1,335 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// maxStandbyClockSkew is max allowed difference between standby sync request time and primary time.
+const maxStandbyClockSkew = time.Minute
+
+// StandbyController replicates handshake sessions and pending join claims of the primary discovery node
+// to the standby node. When primary fails the standby is promoted: it restores replicated state and signs
+// discovery challenges with primary keys, so joining nodes may finish bootstrap with their existing
+// certificates as soon as primary address is switched to the standby.
+type StandbyController interface {
+	component.Starter
+	component.Stopper
+
+	// Promote makes standby serve joining nodes on behalf of its primary.
+	Promote(ctx context.Context) error
+	// IsPromoted returns true if standby was promoted.
+	IsPromoted() bool
+	// Signer returns cryptography service that signs discovery challenges.
+	Signer() core.CryptographyService
+}
+
+type standbyController struct {
+	SessionManager SessionManager           `inject:""`
+	NodeKeeper     network.NodeKeeper       `inject:""`
+	Cryptography   core.CryptographyService `inject:""`
+
+	options   *common.Options
+	transport network.InternalTransport
+
+	lock     sync.RWMutex
+	snapshot *StandbySnapshot
+	signer   core.CryptographyService
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// StandbySyncRequest is sent by standby node to its primary.
+type StandbySyncRequest struct {
+	Time      int64
+	Signature []byte
+}
+
+// StandbySyncResponse
+type StandbySyncResponse struct {
+	Error    string
+	Snapshot *StandbySnapshot
+}
+
+// StandbySnapshot is replicated state of the primary discovery node.
+type StandbySnapshot struct {
+	Sessions   []*StandbySession
+	JoinClaims []*packets.NodeJoinClaim
+}
+
+// StandbySession is over-the-wire representation of handshake session.
+type StandbySession struct {
+	ID             SessionID
+	NodeID         core.RecordRef
+	Cert           []byte
+	State          SessionState
+	DiscoveryNonce Nonce
+	Time           time.Time
+	TTL            time.Duration
+}
+
+func (r *StandbySyncRequest) signedData(sender core.RecordRef) []byte {
+	var buf bytes.Buffer
+	buf.Write(sender[:])
+	_ = binary.Write(&buf, binary.BigEndian, r.Time)
+	return buf.Bytes()
+}
+
+func (sc *standbyController) Start(ctx context.Context) error {
+	sc.transport.RegisterPacketHandler(types.StandbySync, sc.processStandbySync)
+	if sc.options.StandbyPrimary != "" && sc.options.StandbySyncInterval > 0 {
+		go sc.loop(ctx)
+	}
+	return nil
+}
+
+func (sc *standbyController) Stop(ctx context.Context) error {
+	sc.stopOnce.Do(func() {
+		close(sc.stop)
+	})
+	return nil
+}
+
+func (sc *standbyController) IsPromoted() bool {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.signer != nil
+}
+
+func (sc *standbyController) Signer() core.CryptographyService {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	if sc.signer != nil {
+		return sc.signer
+	}
+	return sc.Cryptography
+}
+
+func (sc *standbyController) Promote(ctx context.Context) error {
+	logger := inslogger.FromContext(ctx)
+	if sc.options.StandbyPrimary == "" {
+		return errors.New("[ Promote ] node is not configured as standby")
+	}
+	if sc.IsPromoted() {
+		return errors.New("[ Promote ] standby is already promoted")
+	}
+	if sc.options.StandbyPrimaryKeysPath == "" {
+		return errors.New("[ Promote ] primary keys path is not configured")
+	}
+	signer, err := cryptography.NewStorageBoundCryptographyService(sc.options.StandbyPrimaryKeysPath)
+	if err != nil {
+		return errors.Wrap(err, "[ Promote ] failed to load primary keys")
+	}
+
+	// primary is probably down, but if it is still reachable take the freshest state
+	if err := sc.sync(ctx); err != nil {
+		logger.Debugf("[ Promote ] Final sync with primary failed: %s", err)
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if sc.snapshot == nil {
+		logger.Warn("[ Promote ] Standby has no replicated state, promoting with empty state")
+	} else {
+		sc.restore(ctx, sc.snapshot)
+	}
+	sc.signer = signer
+	sc.stopOnce.Do(func() {
+		close(sc.stop)
+	})
+	logger.Infof("[ Promote ] Standby is promoted and serves on behalf of %s", sc.options.StandbyPrimary)
+	return nil
+}
+
+func (sc *standbyController) restore(ctx context.Context, snapshot *StandbySnapshot) {
+	logger := inslogger.FromContext(ctx)
+	sessions := make(map[SessionID]Session, len(snapshot.Sessions))
+	for _, s := range snapshot.Sessions {
+		var cert core.AuthorizationCertificate
+		if len(s.Cert) > 0 {
+			var err error
+			cert, err = certificate.Deserialize(s.Cert, platformpolicy.NewKeyProcessor())
+			if err != nil {
+				logger.Warnf("[ Promote ] Skip session %d of node %s: %s", s.ID, s.NodeID, err)
+				continue
+			}
+		}
+		sessions[s.ID] = Session{
+			NodeID:         s.NodeID,
+			Cert:           cert,
+			State:          s.State,
+			DiscoveryNonce: s.DiscoveryNonce,
+			Time:           s.Time,
+			TTL:            s.TTL,
+		}
+	}
+	sc.SessionManager.Restore(sessions)
+
+	claims := 0
+	for _, claim := range snapshot.JoinClaims {
+		if sc.NodeKeeper.GetActiveNode(claim.NodeRef) != nil {
+			continue
+		}
+		if sc.NodeKeeper.AddPendingClaim(claim) {
+			claims++
+		}
+	}
+	logger.Infof("[ Promote ] Restored %d sessions and %d join claims", len(sessions), claims)
+}
+
+func (sc *standbyController) loop(ctx context.Context) {
+	ticker := time.NewTicker(sc.options.StandbySyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sc.stop:
+			return
+		case <-ticker.C:
+			if err := sc.sync(ctx); err != nil {
+				inslogger.FromContext(ctx).Debugf("[ StandbySync ] Failed to sync with primary: %s", err)
+			}
+		}
+	}
+}
+
+func (sc *standbyController) sync(ctx context.Context) error {
+	primary, err := host.NewHost(sc.options.StandbyPrimary)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve primary address")
+	}
+	data := &StandbySyncRequest{Time: time.Now().UnixNano()}
+	sign, err := sc.Cryptography.Sign(data.signedData(sc.NodeKeeper.GetOrigin().ID()))
+	if err != nil {
+		return errors.Wrap(err, "failed to sign sync request")
+	}
+	data.Signature = sign.Bytes()
+
+	request := sc.transport.NewRequestBuilder().Type(types.StandbySync).Data(data).Build()
+	future, err := sc.transport.SendRequestPacket(ctx, request, primary)
+	if err != nil {
+		return errors.Wrap(err, "failed to send sync request")
+	}
+	response, err := future.GetResponse(sc.options.PacketTimeout)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sync response")
+	}
+	result := response.GetData().(*StandbySyncResponse)
+	if result.Error != "" {
+		return errors.New("sync rejected by primary: " + result.Error)
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	if sc.signer == nil {
+		sc.snapshot = result.Snapshot
+	}
+	return nil
+}
+
+func (sc *standbyController) processStandbySync(ctx context.Context, request network.Request) (network.Response, error) {
+	data := request.GetData().(*StandbySyncRequest)
+	if err := sc.checkStandby(request.GetSender(), data); err != nil {
+		inslogger.FromContext(ctx).Warnf("[ StandbySync ] Rejected sync request: %s", err)
+		return sc.transport.BuildResponse(ctx, request, &StandbySyncResponse{Error: err.Error()}), nil
+	}
+	snapshot, err := sc.buildSnapshot()
+	if err != nil {
+		return sc.transport.BuildResponse(ctx, request, &StandbySyncResponse{Error: err.Error()}), nil
+	}
+	return sc.transport.BuildResponse(ctx, request, &StandbySyncResponse{Snapshot: snapshot}), nil
+}
+
+func (sc *standbyController) checkStandby(sender core.RecordRef, data *StandbySyncRequest) error {
+	if sc.options.StandbyNode == "" || sc.options.StandbyNode != sender.String() {
+		return errors.Errorf("node %s is not a standby of this node", sender)
+	}
+	skew := time.Since(time.Unix(0, data.Time))
+	if skew > maxStandbyClockSkew || skew < -maxStandbyClockSkew {
+		return errors.New("sync request is outdated")
+	}
+	node := sc.NodeKeeper.GetActiveNode(sender)
+	if node == nil {
+		return errors.Errorf("standby %s is not in active list", sender)
+	}
+	sign := core.SignatureFromBytes(data.Signature)
+	if !sc.Cryptography.Verify(node.PublicKey(), sign, data.signedData(sender)) {
+		return errors.New("sync request signature is invalid")
+	}
+	return nil
+}
+
+func (sc *standbyController) buildSnapshot() (*StandbySnapshot, error) {
+	sessions := sc.SessionManager.Snapshot()
+	snapshot := &StandbySnapshot{Sessions: make([]*StandbySession, 0, len(sessions))}
+	for id, session := range sessions {
+		var cert []byte
+		if session.Cert != nil {
+			var err error
+			cert, err = certificate.Serialize(session.Cert)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to serialize certificate of session %d", id)
+			}
+		}
+		snapshot.Sessions = append(snapshot.Sessions, &StandbySession{
+			ID:             id,
+			NodeID:         session.NodeID,
+			Cert:           cert,
+			State:          session.State,
+			DiscoveryNonce: session.DiscoveryNonce,
+			Time:           session.Time,
+			TTL:            session.TTL,
+		})
+	}
+	for _, claim := range sc.NodeKeeper.GetClaimQueue().Snapshot() {
+		if joinClaim, ok := claim.(*packets.NodeJoinClaim); ok {
+			snapshot.JoinClaims = append(snapshot.JoinClaims, joinClaim)
+		}
+	}
+	return snapshot, nil
+}
+
+// NewStandbyController creates new standby controller.
+func NewStandbyController(options *common.Options, transport network.InternalTransport) StandbyController {
+	return &standbyController{
+		options:   options,
+		transport: transport,
+		stop:      make(chan struct{}),
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package bootstrap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/controller/common"
+	networkUtils "github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandbyController_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	joinClaim := &packets.NodeJoinClaim{NodeRef: core.RecordRef{1}}
+	activeClaim := &packets.NodeJoinClaim{NodeRef: core.RecordRef{2}}
+
+	queue := networkUtils.NewClaimQueueMock(t)
+	queue.SnapshotMock.Return([]packets.ReferendumClaim{joinClaim, &packets.NodeLeaveClaim{}, activeClaim})
+	primaryKeeper := networkUtils.NewNodeKeeperMock(t)
+	primaryKeeper.GetClaimQueueMock.Return(queue)
+
+	primarySessions := NewSessionManager()
+	require.NoError(t, primarySessions.Start(ctx))
+	defer primarySessions.Stop(ctx)
+	id := primarySessions.NewSession(core.RecordRef{1}, nil, time.Minute)
+
+	primary := NewStandbyController(&common.Options{}, nil).(*standbyController)
+	primary.SessionManager = primarySessions
+	primary.NodeKeeper = primaryKeeper
+
+	snapshot, err := primary.buildSnapshot()
+	require.NoError(t, err)
+	require.Len(t, snapshot.Sessions, 1)
+	require.Len(t, snapshot.JoinClaims, 2)
+
+	var added []packets.ReferendumClaim
+	standbyKeeper := networkUtils.NewNodeKeeperMock(t)
+	standbyKeeper.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		if ref == activeClaim.NodeRef {
+			return networkUtils.NewNodeMock(t)
+		}
+		return nil
+	}
+	standbyKeeper.AddPendingClaimFunc = func(claim packets.ReferendumClaim) bool {
+		added = append(added, claim)
+		return true
+	}
+	standbySessions := NewSessionManager()
+	require.NoError(t, standbySessions.Start(ctx))
+	defer standbySessions.Stop(ctx)
+
+	standby := NewStandbyController(&common.Options{StandbyPrimary: "127.0.0.1:0"}, nil).(*standbyController)
+	standby.SessionManager = standbySessions
+	standby.NodeKeeper = standbyKeeper
+	standby.restore(ctx, snapshot)
+
+	assert.NoError(t, standbySessions.CheckSession(id, Authorized))
+	assert.Equal(t, []packets.ReferendumClaim{joinClaim}, added)
+}
+
+func TestStandbyController_PromoteNotConfigured(t *testing.T) {
+	sc := NewStandbyController(&common.Options{}, nil)
+	assert.Error(t, sc.Promote(context.Background()))
+	assert.False(t, sc.IsPromoted())
+
+	sc = NewStandbyController(&common.Options{StandbyPrimary: "127.0.0.1:0"}, nil)
+	assert.Error(t, sc.Promote(context.Background()))
+	assert.False(t, sc.IsPromoted())
+}
+
+func TestStandbyController_CheckStandby(t *testing.T) {
+	sc := NewStandbyController(&common.Options{}, nil).(*standbyController)
+	request := &StandbySyncRequest{Time: time.Now().UnixNano()}
+	assert.Error(t, sc.checkStandby(core.RecordRef{1}, request))
+
+	sc.options.StandbyNode = core.RecordRef{2}.String()
+	assert.Error(t, sc.checkStandby(core.RecordRef{1}, request))
+
+	request.Time = time.Now().Add(-time.Hour).UnixNano()
+	assert.Error(t, sc.checkStandby(core.RecordRef{2}, request))
+}
@@ -18,7 +18,10 @@
 package common
 
 import (
+	"net"
 	"time"
+
+	"github.com/insolar/insolar/network/cascade"
 )
 
 // Options contains configuration options for the local host.
@@ -41,11 +44,80 @@ type Options struct {
 	MaxTimeout time.Duration
 
 	// Multiplier for boostrap retry time
-	TimeoutMult time.Duration
+	TimeoutMult float64
+
+	// Fraction of bootstrap retry time that is randomized, from 0 to 1
+	BootstrapJitter float64
+
+	// Max count of bootstrap attempts to one host, 0 for infinity
+	BootstrapMaxAttempts int
+
+	// True - bootstrap retries are stopped when context is canceled
+	BootstrapAbortOnCancel bool
+
+	// True - discovery nodes are pinged and tried in order of weighted RTT
+	BootstrapProbeRTT bool
+
+	// RTT multiplier of discovery nodes in the same subnet as origin
+	BootstrapSameSubnetWeight float64
+
+	// RTT multiplier of discovery nodes in BootstrapRegionSubnets
+	BootstrapSameRegionWeight float64
+
+	// Subnets of discovery nodes in the same region as origin
+	BootstrapRegionSubnets []*net.IPNet
+
+	// Max count of joiners accepted during one pulse, 0 for no limit
+	BootstrapJoinerQuota int
+
+	// Max count of redirects followed by joiner
+	BootstrapMaxRedirects int
 
 	// True - infinity tries to bootstrap
 	InfinityBootstrap bool
 
 	// HandshakeSession TTL
 	HandshakeSessionTTL time.Duration
+
+	// Period of peer exchange, 0 disables it
+	PeerExchangeInterval time.Duration
+
+	// Max count of nodes in one peer exchange packet
+	PeerExchangeSampleSize int
+
+	// Count of random peers to share sample with
+	PeerExchangeFanout int
+
+	// Reference of standby node allowed to replicate state of this discovery node
+	StandbyNode string
+
+	// Address of primary discovery node that is replicated by this standby node
+	StandbyPrimary string
+
+	// Period of standby state replication
+	StandbySyncInterval time.Duration
+
+	// Keys file of primary discovery node used after promotion
+	StandbyPrimaryKeysPath string
+
+	// Period of active node digests gossip
+	GossipInterval time.Duration
+
+	// Count of random peers to exchange digest with
+	GossipFanout int
+
+	// Count of peers that must confirm active list before it is applied
+	GossipQuorum int
+
+	// Count of pulses between reconciliations of active list with random peer
+	AntiEntropyPulses int
+
+	// Time to wait for delivery acknowledgement per cascade layer
+	CascadeAckTimeout time.Duration
+
+	// Count of re-sends to substitute nodes when cascade delivery is not confirmed
+	CascadeRetries int
+
+	// Bounds and loss threshold of adaptive cascade replication factor
+	CascadePolicy cascade.PolicyOptions
 }
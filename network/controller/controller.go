@@ -19,11 +19,15 @@ package controller
 
 import (
 	"context"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/cascade"
 	"github.com/insolar/insolar/network/controller/bootstrap"
 	"github.com/insolar/insolar/network/controller/common"
 	"github.com/insolar/insolar/network/transport/packet/types"
@@ -46,8 +50,8 @@ func (c *Controller) GetLastIgnoredPulse() core.PulseNumber {
 }
 
 // SendParcel send message to nodeID.
-func (c *Controller) SendMessage(nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error) {
-	return c.RPCController.SendMessage(nodeID, name, msg)
+func (c *Controller) SendMessage(ctx context.Context, nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error) {
+	return c.RPCController.SendMessage(ctx, nodeID, name, msg)
 }
 
 // RemoteProcedureRegister register remote procedure that will be executed when message is received.
@@ -77,14 +81,69 @@ func (c *Controller) Start(ctx context.Context) error {
 func ConfigureOptions(config configuration.HostNetwork) *common.Options {
 	return &common.Options{
 		InfinityBootstrap:   config.InfinityBootstrap,
-		TimeoutMult:         time.Duration(config.TimeoutMult) * time.Second,
+		TimeoutMult:         float64(config.TimeoutMult),
 		MinTimeout:          time.Duration(config.MinTimeout) * time.Second,
 		MaxTimeout:          time.Duration(config.MaxTimeout) * time.Second,
 		PingTimeout:         1 * time.Second,
 		PacketTimeout:       10 * time.Second,
 		BootstrapTimeout:    10 * time.Second,
 		HandshakeSessionTTL: time.Duration(config.HandshakeSessionTTL) * time.Millisecond,
+
+		BootstrapJitter:        config.BootstrapJitter,
+		BootstrapMaxAttempts:   config.BootstrapMaxAttempts,
+		BootstrapAbortOnCancel: config.BootstrapAbortOnCancel,
+
+		BootstrapProbeRTT:         config.BootstrapProbeRTT,
+		BootstrapSameSubnetWeight: config.BootstrapSameSubnetWeight,
+		BootstrapSameRegionWeight: config.BootstrapSameRegionWeight,
+		BootstrapRegionSubnets:    parseSubnets(config.BootstrapRegionSubnets),
+
+		BootstrapJoinerQuota:  config.BootstrapJoinerQuota,
+		BootstrapMaxRedirects: config.BootstrapMaxRedirects,
+
+		PeerExchangeInterval:   time.Duration(config.PeerExchangeInterval) * time.Millisecond,
+		PeerExchangeSampleSize: config.PeerExchangeSampleSize,
+		PeerExchangeFanout:     config.PeerExchangeFanout,
+
+		StandbyNode:            config.StandbyNode,
+		StandbyPrimary:         config.StandbyPrimary,
+		StandbySyncInterval:    time.Duration(config.StandbySyncInterval) * time.Millisecond,
+		StandbyPrimaryKeysPath: config.StandbyPrimaryKeysPath,
+
+		GossipInterval: time.Duration(config.GossipInterval) * time.Millisecond,
+		GossipFanout:   config.GossipFanout,
+		GossipQuorum:   config.GossipQuorum,
+
+		AntiEntropyPulses: config.AntiEntropyPulses,
+
+		CascadeAckTimeout: time.Duration(config.CascadeAckTimeout) * time.Millisecond,
+		CascadeRetries:    config.CascadeRetries,
+
+		CascadePolicy: cascade.PolicyOptions{
+			MinFactor:     config.CascadeMinReplicationFactor,
+			MaxFactor:     config.CascadeMaxReplicationFactor,
+			MaxDepth:      config.CascadeMaxDepth,
+			LossThreshold: config.CascadeLossThreshold,
+		},
+	}
+}
+
+// parseSubnets parses comma separated list of CIDRs, invalid ones are skipped.
+func parseSubnets(list string) []*net.IPNet {
+	var result []*net.IPNet
+	for _, cidr := range strings.Split(list, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Warnf("Skip invalid region subnet %s: %s", cidr, err)
+			continue
+		}
+		result = append(result, subnet)
 	}
+	return result
 }
 
 // NewNetworkController create new network controller.
@@ -0,0 +1,301 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/bootstrap"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// GossipController periodically exchanges signed digests of active node list with random peers. Peers with
+// different digest and not older pulse send their active lists back. When a list differing from local one is
+// confirmed by a quorum of peers, node that missed consensus round requests resync through bootstrap.
+type GossipController interface {
+	component.Starter
+	component.Stopper
+}
+
+type gossipController struct {
+	NodeKeeper         network.NodeKeeper              `inject:""`
+	PulseStorage       core.PulseStorage               `inject:""`
+	Cryptography       core.CryptographyService        `inject:""`
+	CryptographyScheme core.PlatformCryptographyScheme `inject:""`
+	Resyncer           network.Resyncer                `inject:""`
+
+	options      *common.Options
+	hostNetwork  network.HostNetwork
+	routingTable network.RoutingTable
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// GossipDigest is a signed digest of sender active node list.
+type GossipDigest struct {
+	Pulse     core.PulseNumber
+	Digest    []byte
+	Signature []byte
+}
+
+// GossipRequest
+type GossipRequest struct {
+	Digest GossipDigest
+}
+
+// GossipResponse contains responder digest and its active list if the list differs from requester one and
+// responder pulse is not older.
+type GossipResponse struct {
+	Digest GossipDigest
+	Nodes  []*bootstrap.NodeStruct
+	Error  string
+}
+
+func init() {
+	gob.Register(&GossipRequest{})
+	gob.Register(&GossipResponse{})
+}
+
+// signedData returns data that is covered by digest signature.
+func (d *GossipDigest) signedData(sender core.RecordRef) []byte {
+	var buf bytes.Buffer
+	buf.Write(sender[:])
+	_ = binary.Write(&buf, binary.BigEndian, uint32(d.Pulse))
+	buf.Write(d.Digest)
+	return buf.Bytes()
+}
+
+func (gc *gossipController) Start(ctx context.Context) error {
+	gc.hostNetwork.RegisterRequestHandler(types.Gossip, gc.processGossip)
+	if gc.options.GossipInterval > 0 && gc.options.GossipFanout > 0 {
+		go gc.loop(ctx)
+	}
+	return nil
+}
+
+func (gc *gossipController) Stop(ctx context.Context) error {
+	gc.stopOnce.Do(func() {
+		close(gc.stop)
+	})
+	return nil
+}
+
+func (gc *gossipController) loop(ctx context.Context) {
+	ticker := time.NewTicker(gc.options.GossipInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-gc.stop:
+			return
+		case <-ticker.C:
+			if !gc.NodeKeeper.IsBootstrapped() {
+				continue
+			}
+			gc.round(ctx)
+		}
+	}
+}
+
+func (gc *gossipController) digest(nodes []*bootstrap.NodeStruct) []byte {
+	return nodesDigest(gc.CryptographyScheme, nodes)
+}
+
+// nodesDigest calculates hash of node list, the list is sorted by node reference.
+func nodesDigest(scheme core.PlatformCryptographyScheme, nodes []*bootstrap.NodeStruct) []byte {
+	sorted := make([]*bootstrap.NodeStruct, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID.Compare(sorted[j].ID) < 0
+	})
+
+	var buf bytes.Buffer
+	for _, n := range sorted {
+		writeNode(&buf, n)
+	}
+	return scheme.IntegrityHasher().Hash(buf.Bytes())
+}
+
+// localState returns active nodes and their signed digest.
+func (gc *gossipController) localState(ctx context.Context) ([]*bootstrap.NodeStruct, *GossipDigest, error) {
+	pulse, err := gc.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get current pulse")
+	}
+	active := gc.NodeKeeper.GetActiveNodes()
+	nodes := make([]*bootstrap.NodeStruct, 0, len(active))
+	for _, n := range active {
+		ns, err := bootstrap.NewNodeStruct(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, ns)
+	}
+
+	digest := &GossipDigest{Pulse: pulse.PulseNumber, Digest: gc.digest(nodes)}
+	sign, err := gc.Cryptography.Sign(digest.signedData(gc.NodeKeeper.GetOrigin().ID()))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign digest")
+	}
+	digest.Signature = sign.Bytes()
+	return nodes, digest, nil
+}
+
+// verify checks that digest is signed by active node.
+func (gc *gossipController) verify(sender core.RecordRef, digest *GossipDigest) error {
+	senderNode := gc.NodeKeeper.GetActiveNode(sender)
+	if senderNode == nil {
+		return errors.Errorf("sender %s is not in active list", sender)
+	}
+	sign := core.SignatureFromBytes(digest.Signature)
+	if !gc.Cryptography.Verify(senderNode.PublicKey(), sign, digest.signedData(sender)) {
+		return errors.New("digest signature is invalid")
+	}
+	return nil
+}
+
+func (gc *gossipController) round(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	origin := gc.NodeKeeper.GetOrigin().ID()
+
+	peers := gc.routingTable.GetRandomNodes(gc.options.GossipFanout, []core.RecordRef{origin})
+	if len(peers) == 0 {
+		return
+	}
+	_, digest, err := gc.localState(ctx)
+	if err != nil {
+		logger.Warn(errors.Wrap(err, "[ Gossip ] Failed to build digest"))
+		return
+	}
+
+	responses := make(chan *GossipResponse, len(peers))
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(receiver core.RecordRef) {
+			defer wg.Done()
+			if response := gc.send(ctx, digest, receiver); response != nil {
+				responses <- response
+			}
+		}(peer.NodeID)
+	}
+	wg.Wait()
+	close(responses)
+
+	views := make([]*GossipResponse, 0, len(peers))
+	for response := range responses {
+		views = append(views, response)
+	}
+	if nodes := gc.confirmed(digest, views); nodes != nil {
+		gc.resync(ctx, nodes)
+	}
+}
+
+func (gc *gossipController) send(ctx context.Context, digest *GossipDigest, receiver core.RecordRef) *GossipResponse {
+	logger := inslogger.FromContext(ctx)
+	request := gc.hostNetwork.NewRequestBuilder().Type(types.Gossip).Data(&GossipRequest{Digest: *digest}).Build()
+	future, err := gc.hostNetwork.SendRequest(ctx, request, receiver)
+	if err != nil {
+		logger.Debugf("[ Gossip ] Failed to send digest to %s: %s", receiver, err)
+		return nil
+	}
+	response, err := future.GetResponse(gc.options.PacketTimeout)
+	if err != nil {
+		logger.Debugf("[ Gossip ] Failed to get response from %s: %s", receiver, err)
+		return nil
+	}
+	result := response.GetData().(*GossipResponse)
+	if result.Error != "" {
+		logger.Warnf("[ Gossip ] Digest rejected by %s: %s", receiver, result.Error)
+		return nil
+	}
+	if err := gc.verify(receiver, &result.Digest); err != nil {
+		logger.Warnf("[ Gossip ] Invalid response from %s: %s", receiver, err)
+		return nil
+	}
+	return result
+}
+
+// confirmed returns active list that differs from local one, is not older and is confirmed by quorum of peers.
+func (gc *gossipController) confirmed(local *GossipDigest, views []*GossipResponse) []*bootstrap.NodeStruct {
+	counts := make(map[string]int)
+	for _, view := range views {
+		if len(view.Nodes) == 0 || view.Digest.Pulse < local.Pulse || bytes.Equal(view.Digest.Digest, local.Digest) {
+			continue
+		}
+		// list must match signed digest
+		if !bytes.Equal(gc.digest(view.Nodes), view.Digest.Digest) {
+			continue
+		}
+		key := string(view.Digest.Digest)
+		counts[key]++
+		if counts[key] >= gc.options.GossipQuorum {
+			return view.Nodes
+		}
+	}
+	return nil
+}
+
+// resync requests rejoin of node when active list confirmed by peers differs from local one. Active list is
+// changed by consensus only, so gossip never edits it and node catches up through bootstrap.
+func (gc *gossipController) resync(ctx context.Context, nodes []*bootstrap.NodeStruct) {
+	inslogger.FromContext(ctx).Warnf(
+		"[ Gossip ] Active list of %d nodes confirmed by peers differs from local one, requesting resync", len(nodes),
+	)
+	gc.Resyncer.Resync(ctx)
+}
+
+func (gc *gossipController) processGossip(ctx context.Context, request network.Request) (network.Response, error) {
+	data := request.GetData().(*GossipRequest)
+	if err := gc.verify(request.GetSender(), &data.Digest); err != nil {
+		return gc.hostNetwork.BuildResponse(ctx, request, &GossipResponse{Error: err.Error()}), nil
+	}
+	nodes, digest, err := gc.localState(ctx)
+	if err != nil {
+		return gc.hostNetwork.BuildResponse(ctx, request, &GossipResponse{Error: err.Error()}), nil
+	}
+	response := &GossipResponse{Digest: *digest}
+	if !bytes.Equal(digest.Digest, data.Digest.Digest) && digest.Pulse >= data.Digest.Pulse {
+		response.Nodes = nodes
+	}
+	return gc.hostNetwork.BuildResponse(ctx, request, response), nil
+}
+
+// NewGossipController creates new gossip controller.
+func NewGossipController(
+	options *common.Options, hostNetwork network.HostNetwork, routingTable network.RoutingTable,
+) GossipController {
+	return &gossipController{
+		options:      options,
+		hostNetwork:  hostNetwork,
+		routingTable: routingTable,
+		stop:         make(chan struct{}),
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/controller/bootstrap"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/platformpolicy"
+	networkUtils "github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeStructs(t *testing.T, nodes ...core.Node) []*bootstrap.NodeStruct {
+	result := make([]*bootstrap.NodeStruct, 0, len(nodes))
+	for _, n := range nodes {
+		ns, err := bootstrap.NewNodeStruct(n)
+		require.NoError(t, err)
+		result = append(result, ns)
+	}
+	return result
+}
+
+func TestGossip_Confirmed(t *testing.T) {
+	gc := &gossipController{
+		CryptographyScheme: platformpolicy.NewPlatformCryptographyScheme(),
+		options:            &common.Options{GossipQuorum: 2},
+	}
+
+	a, _ := newTestNode(t)
+	b, _ := newTestNode(t)
+	c, _ := newTestNode(t)
+
+	local := &GossipDigest{Pulse: 10, Digest: gc.digest(nodeStructs(t, a, b))}
+	newer := nodeStructs(t, a, b, c)
+	view := &GossipResponse{Digest: GossipDigest{Pulse: 10, Digest: gc.digest(newer)}, Nodes: newer}
+
+	// digest does not depend on node order
+	assert.Equal(t, view.Digest.Digest, gc.digest(nodeStructs(t, c, b, a)))
+
+	// single peer is not enough
+	assert.Nil(t, gc.confirmed(local, []*GossipResponse{view}))
+	assert.Equal(t, newer, gc.confirmed(local, []*GossipResponse{view, view}))
+
+	// views from older pulse are ignored
+	older := &GossipResponse{Digest: GossipDigest{Pulse: 9, Digest: view.Digest.Digest}, Nodes: newer}
+	assert.Nil(t, gc.confirmed(local, []*GossipResponse{older, older}))
+
+	// list that does not match signed digest is ignored
+	tampered := &GossipResponse{Digest: view.Digest, Nodes: nodeStructs(t, a)}
+	assert.Nil(t, gc.confirmed(local, []*GossipResponse{tampered, tampered}))
+}
+
+type testResyncer struct {
+	calls int
+}
+
+func (r *testResyncer) Resync(ctx context.Context) {
+	r.calls++
+}
+
+func TestGossip_Resync(t *testing.T) {
+	missed, _ := newTestNode(t)
+
+	// active list is never edited by gossip
+	keeper := networkUtils.NewNodeKeeperMock(t)
+	resyncer := &testResyncer{}
+
+	gc := &gossipController{NodeKeeper: keeper, Resyncer: resyncer, options: &common.Options{}}
+	gc.resync(context.Background(), nodeStructs(t, missed))
+
+	assert.Equal(t, 1, resyncer.calls)
+}
@@ -0,0 +1,248 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/bootstrap"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// PeerExchangeController periodically shares a bounded signed sample of known active nodes with random peers.
+// Nodes of received sample missing in local active list become routing hints, and anti-entropy reconciliation
+// with sender is started, so the missing entries are pulled from sender active list without waiting for the
+// next scheduled reconciliation.
+type PeerExchangeController interface {
+	component.Starter
+	component.Stopper
+}
+
+type peerExchangeController struct {
+	NodeKeeper   network.NodeKeeper       `inject:""`
+	Cryptography core.CryptographyService `inject:""`
+	AntiEntropy  AntiEntropyController    `inject:""`
+
+	options      *common.Options
+	hostNetwork  network.HostNetwork
+	routingTable network.RoutingTable
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// PeerExchangeRequest contains signed sample of sender active nodes.
+type PeerExchangeRequest struct {
+	Nodes     []*bootstrap.NodeStruct
+	Signature []byte
+}
+
+// PeerExchangeResponse contains count of nodes from sample that were unknown to receiver.
+type PeerExchangeResponse struct {
+	Hints int
+	Error string
+}
+
+func init() {
+	gob.Register(&PeerExchangeRequest{})
+	gob.Register(&PeerExchangeResponse{})
+}
+
+// signedData returns data that is covered by request signature.
+func (r *PeerExchangeRequest) signedData(sender core.RecordRef) []byte {
+	var buf bytes.Buffer
+	buf.Write(sender[:])
+	for _, n := range r.Nodes {
+		writeNode(&buf, n)
+	}
+	return buf.Bytes()
+}
+
+func writeNode(buf *bytes.Buffer, n *bootstrap.NodeStruct) {
+	buf.Write(n.ID[:])
+	_ = binary.Write(buf, binary.BigEndian, n.SID)
+	_ = binary.Write(buf, binary.BigEndian, uint32(n.Role))
+	writeBytes(buf, n.PK)
+	writeBytes(buf, []byte(n.Address))
+	writeBytes(buf, []byte(n.Version))
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+}
+
+func (pc *peerExchangeController) Start(ctx context.Context) error {
+	pc.hostNetwork.RegisterRequestHandler(types.PeerExchange, pc.processPeerExchange)
+	if pc.options.PeerExchangeInterval > 0 && pc.options.PeerExchangeFanout > 0 {
+		go pc.loop(ctx)
+	}
+	return nil
+}
+
+func (pc *peerExchangeController) Stop(ctx context.Context) error {
+	pc.stopOnce.Do(func() {
+		close(pc.stop)
+	})
+	return nil
+}
+
+func (pc *peerExchangeController) loop(ctx context.Context) {
+	ticker := time.NewTicker(pc.options.PeerExchangeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pc.stop:
+			return
+		case <-ticker.C:
+			if !pc.NodeKeeper.IsBootstrapped() {
+				continue
+			}
+			pc.exchange(ctx)
+		}
+	}
+}
+
+func (pc *peerExchangeController) exchange(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	origin := pc.NodeKeeper.GetOrigin().ID()
+
+	peers := pc.routingTable.GetRandomNodes(pc.options.PeerExchangeFanout, []core.RecordRef{origin})
+	if len(peers) == 0 {
+		return
+	}
+
+	request, err := pc.buildRequest(origin)
+	if err != nil {
+		logger.Warn(errors.Wrap(err, "[ PeerExchange ] Failed to build request"))
+		return
+	}
+
+	for _, peer := range peers {
+		go pc.send(ctx, request, peer.NodeID)
+	}
+}
+
+func (pc *peerExchangeController) send(ctx context.Context, data *PeerExchangeRequest, receiver core.RecordRef) {
+	logger := inslogger.FromContext(ctx)
+	request := pc.hostNetwork.NewRequestBuilder().Type(types.PeerExchange).Data(data).Build()
+	future, err := pc.hostNetwork.SendRequest(ctx, request, receiver)
+	if err != nil {
+		logger.Debugf("[ PeerExchange ] Failed to send sample to %s: %s", receiver, err)
+		return
+	}
+	response, err := future.GetResponse(pc.options.PacketTimeout)
+	if err != nil {
+		logger.Debugf("[ PeerExchange ] Failed to get response from %s: %s", receiver, err)
+		return
+	}
+	result := response.GetData().(*PeerExchangeResponse)
+	if result.Error != "" {
+		logger.Warnf("[ PeerExchange ] Sample rejected by %s: %s", receiver, result.Error)
+	}
+}
+
+func (pc *peerExchangeController) buildRequest(origin core.RecordRef) (*PeerExchangeRequest, error) {
+	sample := pc.routingTable.GetRandomNodes(pc.options.PeerExchangeSampleSize, nil)
+	request := &PeerExchangeRequest{Nodes: make([]*bootstrap.NodeStruct, 0, len(sample))}
+	for _, h := range sample {
+		n := pc.NodeKeeper.GetActiveNode(h.NodeID)
+		if n == nil {
+			continue
+		}
+		ns, err := bootstrap.NewNodeStruct(n)
+		if err != nil {
+			return nil, err
+		}
+		request.Nodes = append(request.Nodes, ns)
+	}
+
+	sign, err := pc.Cryptography.Sign(request.signedData(origin))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign sample")
+	}
+	request.Signature = sign.Bytes()
+	return request, nil
+}
+
+func (pc *peerExchangeController) processPeerExchange(ctx context.Context, request network.Request) (network.Response, error) {
+	data := request.GetData().(*PeerExchangeRequest)
+	hints, err := pc.mergeSample(ctx, request.GetSender(), data)
+	if err != nil {
+		return pc.hostNetwork.BuildResponse(ctx, request, &PeerExchangeResponse{Error: err.Error()}), nil
+	}
+	return pc.hostNetwork.BuildResponse(ctx, request, &PeerExchangeResponse{Hints: hints}), nil
+}
+
+func (pc *peerExchangeController) mergeSample(ctx context.Context, sender core.RecordRef, data *PeerExchangeRequest) (int, error) {
+	if len(data.Nodes) > pc.options.PeerExchangeSampleSize {
+		return 0, errors.Errorf("sample size %d exceeds limit %d", len(data.Nodes), pc.options.PeerExchangeSampleSize)
+	}
+	senderNode := pc.NodeKeeper.GetActiveNode(sender)
+	if senderNode == nil {
+		return 0, errors.Errorf("sender %s is not in active list", sender)
+	}
+	sign := core.SignatureFromBytes(data.Signature)
+	if !pc.Cryptography.Verify(senderNode.PublicKey(), sign, data.signedData(sender)) {
+		return 0, errors.New("sample signature is invalid")
+	}
+
+	// sample is only a claim of one node, so unknown nodes are not added to active list directly, they are
+	// remembered as routing hints and pulled by anti-entropy from sender signed active list
+	hints := 0
+	for _, ns := range data.Nodes {
+		if pc.NodeKeeper.GetActiveNode(ns.ID) != nil || pc.NodeKeeper.GetActiveNodeByShortID(ns.SID) != nil {
+			continue
+		}
+		h, err := host.NewHostNS(ns.Address, ns.ID, ns.SID)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse address of node %s", ns.ID)
+		}
+		pc.routingTable.AddRoutingHint(h)
+		hints++
+	}
+	if hints > 0 {
+		inslogger.FromContext(ctx).Debugf("[ PeerExchange ] Got %d routing hints from sample of %s", hints, sender)
+		pc.AntiEntropy.Reconcile(ctx, sender)
+	}
+	return hints, nil
+}
+
+// NewPeerExchangeController creates new peer exchange controller.
+func NewPeerExchangeController(
+	options *common.Options, hostNetwork network.HostNetwork, routingTable network.RoutingTable,
+) PeerExchangeController {
+	return &peerExchangeController{
+		options:      options,
+		hostNetwork:  hostNetwork,
+		routingTable: routingTable,
+		stop:         make(chan struct{}),
+	}
+}
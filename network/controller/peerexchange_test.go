@@ -0,0 +1,120 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/routing"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	networkUtils "github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNode(t *testing.T) (core.Node, core.CryptographyService) {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	n := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, kp.ExtractPublicKey(key), "127.0.0.1:0", "")
+	return n, cryptography.NewKeyBoundCryptographyService(key)
+}
+
+// reconcileRecorder records peers anti-entropy reconciliation is started with.
+type reconcileRecorder struct {
+	AntiEntropyController
+	peers []core.RecordRef
+}
+
+func (r *reconcileRecorder) Reconcile(ctx context.Context, peer core.RecordRef) {
+	r.peers = append(r.peers, peer)
+}
+
+func TestPeerExchange_MergeSample(t *testing.T) {
+	ctx := context.Background()
+	options := &common.Options{PeerExchangeSampleSize: 16}
+
+	sender, senderCrypto := newTestNode(t)
+	other, _ := newTestNode(t)
+
+	senderKeeper := networkUtils.NewNodeKeeperMock(t)
+	senderKeeper.GetActiveNodesMock.Return([]core.Node{sender, other})
+	senderKeeper.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		for _, n := range []core.Node{sender, other} {
+			if n.ID() == ref {
+				return n
+			}
+		}
+		return nil
+	}
+	senderPEX := &peerExchangeController{
+		NodeKeeper: senderKeeper, Cryptography: senderCrypto, options: options,
+		routingTable: &routing.Table{NodeKeeper: senderKeeper},
+	}
+
+	request, err := senderPEX.buildRequest(sender.ID())
+	require.NoError(t, err)
+	require.Len(t, request.Nodes, 2)
+
+	receiverKeeper := networkUtils.NewNodeKeeperMock(t)
+	receiverKeeper.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		if ref == sender.ID() {
+			return sender
+		}
+		return nil
+	}
+	receiverKeeper.GetActiveNodeByShortIDMock.Return(nil)
+	receiverKeeper.GetOriginMock.Return(sender)
+	table := &routing.Table{NodeKeeper: receiverKeeper}
+	antiEntropy := &reconcileRecorder{}
+	receiverPEX := &peerExchangeController{
+		NodeKeeper: receiverKeeper, Cryptography: senderCrypto, AntiEntropy: antiEntropy, options: options,
+		routingTable: table,
+	}
+
+	// unknown node becomes routing hint and is pulled from sender by anti-entropy, active list is not changed
+	count, err := receiverPEX.mergeSample(ctx, sender.ID(), request)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []core.RecordRef{sender.ID()}, antiEntropy.peers)
+	h, err := table.Resolve(other.ID())
+	require.NoError(t, err)
+	assert.Equal(t, other.ShortID(), h.ShortID)
+	assert.Equal(t, other.PhysicalAddress(), h.Address.String())
+
+	// sample from unknown sender is rejected
+	_, err = receiverPEX.mergeSample(ctx, other.ID(), request)
+	assert.Error(t, err)
+
+	// tampered sample is rejected
+	request.Nodes[0].Address = "127.0.0.1:1"
+	_, err = receiverPEX.mergeSample(ctx, sender.ID(), request)
+	assert.Error(t, err)
+
+	// oversized sample is rejected
+	options.PeerExchangeSampleSize = 1
+	_, err = receiverPEX.mergeSample(ctx, sender.ID(), request)
+	assert.Error(t, err)
+	assert.Len(t, antiEntropy.peers, 1)
+}
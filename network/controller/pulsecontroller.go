@@ -51,7 +51,7 @@ func (pc *pulseController) processPulse(ctx context.Context, request network.Req
 
 func (pc *pulseController) processGetRandomHosts(ctx context.Context, request network.Request) (network.Response, error) {
 	data := request.GetData().(*packet.RequestGetRandomHosts)
-	randomHosts := pc.routingTable.GetRandomNodes(data.HostsNumber)
+	randomHosts := pc.routingTable.GetRandomNodes(data.HostsNumber, nil)
 	return pc.hostNetwork.BuildResponse(ctx, request, &packet.ResponseGetRandomHosts{Hosts: randomHosts}), nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/insolar/insolar/component"
@@ -45,13 +46,14 @@ type RPCController interface {
 	// hack for DI, else we receive ServiceNetwork injection in RPCController instead of rpcController that leads to stack overflow
 	IAmRPCController()
 
-	SendMessage(nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error)
+	SendMessage(ctx context.Context, nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error)
 	SendCascadeMessage(data core.Cascade, method string, msg core.Parcel) error
 	RemoteProcedureRegister(name string, method core.RemoteProcedure)
 }
 
 type rpcController struct {
 	Scheme core.PlatformCryptographyScheme `inject:""`
+	Policy cascade.ReplicationPolicy       `inject:""`
 
 	options     *common.Options
 	hostNetwork network.HostNetwork
@@ -78,6 +80,16 @@ type RequestCascade struct {
 type ResponseCascade struct {
 	Success bool
 	Error   string
+	// nodes of responder cascade subtree that confirmed delivery
+	Delivered []core.RecordRef
+}
+
+// replicationMessages are types of ledger replication parcels. They are sent in Replication packets, so send queue
+// of transport doesn't mix them with other RPC traffic.
+var replicationMessages = map[core.MessageType]bool{
+	core.TypeHeavyStartStop: true,
+	core.TypeHeavyPayload:   true,
+	core.TypeHeavyReset:     true,
 }
 
 func init() {
@@ -120,11 +132,18 @@ func (rpc *rpcController) SendCascadeMessage(data core.Cascade, method string, m
 	)
 	defer span.End()
 	ctx = msg.Context(ctx)
-	return rpc.initCascadeSendMessage(ctx, data, false, method, [][]byte{message.ParcelToBytes(msg)})
+	if data.ReplicationFactor == 0 {
+		data.ReplicationFactor = rpc.Policy.Factor(len(data.NodeIds))
+	}
+	delivered, err := rpc.initCascadeSendMessage(ctx, data, false, method, [][]byte{message.ParcelToBytes(msg)})
+	rpc.Policy.Observe(ctx, len(data.NodeIds), len(delivered))
+	inslogger.FromContext(ctx).Debugf("Cascade message delivered to %d of %d nodes", len(delivered), len(data.NodeIds))
+	return err
 }
 
+// initCascadeSendMessage sends message to the next cascade layer and returns nodes of the layer subtrees that confirmed delivery.
 func (rpc *rpcController) initCascadeSendMessage(ctx context.Context, data core.Cascade,
-	findCurrentNode bool, method string, args [][]byte) error {
+	findCurrentNode bool, method string, args [][]byte) ([]core.RecordRef, error) {
 
 	_, span := instracer.StartSpan(context.Background(), "RPCController.initCascadeSendMessage")
 	span.AddAttributes(
@@ -132,10 +151,10 @@ func (rpc *rpcController) initCascadeSendMessage(ctx context.Context, data core.
 	)
 	defer span.End()
 	if len(data.NodeIds) == 0 {
-		return errors.New("node IDs list should not be empty")
+		return nil, errors.New("node IDs list should not be empty")
 	}
 	if data.ReplicationFactor == 0 {
-		return errors.New("replication factor should not be zero")
+		return nil, errors.New("replication factor should not be zero")
 	}
 
 	var nextNodes []core.RecordRef
@@ -148,30 +167,119 @@ func (rpc *rpcController) initCascadeSendMessage(ctx context.Context, data core.
 		nextNodes, err = cascade.CalculateNextNodes(rpc.Scheme, data, nil)
 	}
 	if err != nil {
-		return errors.Wrap(err, "Failed to CalculateNextNodes")
+		return nil, errors.Wrap(err, "Failed to CalculateNextNodes")
 	}
 	if len(nextNodes) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	var failedNodes []string
-	for _, nextNode := range nextNodes {
-		err = rpc.requestCascadeSendMessage(ctx, data, nextNode, method, args)
+	scope, err := rpc.subtrees(data, nextNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	delivered := make(map[core.RecordRef]bool)
+	for attempt := 0; len(nextNodes) > 0; attempt++ {
+		uncovered, err := rpc.sendToLayer(ctx, data, nextNodes, method, args, delivered)
+		if err != nil {
+			return nil, err
+		}
+		if len(uncovered) == 0 || attempt >= rpc.options.CascadeRetries {
+			break
+		}
+		// substitute nodes are the first layer of new cascade built from unconfirmed nodes
+		inslogger.FromContext(ctx).Debugf("Re-sending cascade message to %d unconfirmed nodes", len(uncovered))
+		data = core.Cascade{NodeIds: uncovered, Entropy: data.Entropy, ReplicationFactor: data.ReplicationFactor}
+		nextNodes, err = cascade.CalculateNextNodes(rpc.Scheme, data, nil)
 		if err != nil {
-			inslogger.FromContext(ctx).Warnf("Failed to send cascade message to node %s: %s", nextNode, err.Error())
-			failedNodes = append(failedNodes, nextNode.String())
+			return nil, errors.Wrap(err, "Failed to CalculateNextNodes")
+		}
+	}
+
+	result := make([]core.RecordRef, 0, len(scope))
+	var failedNodes []string
+	for _, nodeID := range scope {
+		if delivered[nodeID] {
+			result = append(result, nodeID)
+		} else {
+			failedNodes = append(failedNodes, nodeID.String())
 		}
 	}
 
 	if len(failedNodes) > 0 {
-		return errors.New("Failed to send cascade message to nodes: " + strings.Join(failedNodes, ", "))
+		return result, errors.New("Failed to send cascade message to nodes: " + strings.Join(failedNodes, ", "))
 	}
 	inslogger.FromContext(ctx).Debug("Cascade message successfully sent to all nodes of the next layer")
-	return nil
+	return result, nil
+}
+
+// subtrees returns all nodes that receive cascade message through nodes.
+func (rpc *rpcController) subtrees(data core.Cascade, nodes []core.RecordRef) ([]core.RecordRef, error) {
+	result := make([]core.RecordRef, 0)
+	for _, nodeID := range nodes {
+		subtree, _, err := cascade.CalculateSubtree(rpc.Scheme, data, nodeID)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to CalculateSubtree")
+		}
+		result = append(result, subtree...)
+	}
+	return result, nil
+}
+
+// sendToLayer sends cascade message to nodes of the layer concurrently and waits for their acknowledgements.
+// Nodes that confirmed delivery are marked in delivered. Unconfirmed nodes of the layer subtrees are returned,
+// except of layer nodes that did not respond at all, they are not used as substitutes.
+func (rpc *rpcController) sendToLayer(ctx context.Context, data core.Cascade, nextNodes []core.RecordRef,
+	method string, args [][]byte, delivered map[core.RecordRef]bool) ([]core.RecordRef, error) {
+
+	subtrees := make([][]core.RecordRef, len(nextNodes))
+	acks := make([][]core.RecordRef, len(nextNodes))
+	responded := make([]bool, len(nextNodes))
+
+	var wg sync.WaitGroup
+	for i, nextNode := range nextNodes {
+		subtree, depth, err := cascade.CalculateSubtree(rpc.Scheme, data, nextNode)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to CalculateSubtree")
+		}
+		subtrees[i] = subtree
+
+		wg.Add(1)
+		go func(i int, nodeID core.RecordRef, timeout time.Duration) {
+			defer wg.Done()
+			ack, err := rpc.requestCascadeSendMessage(ctx, data, nodeID, method, args, timeout)
+			if err != nil {
+				inslogger.FromContext(ctx).Warnf("Failed to send cascade message to node %s: %s", nodeID, err.Error())
+				return
+			}
+			acks[i], responded[i] = ack, true
+		}(i, nextNode, rpc.ackTimeout(depth))
+	}
+	wg.Wait()
+
+	var uncovered []core.RecordRef
+	for i, nextNode := range nextNodes {
+		for _, nodeID := range acks[i] {
+			delivered[nodeID] = true
+		}
+		for _, nodeID := range subtrees[i] {
+			if delivered[nodeID] || (nodeID == nextNode && !responded[i]) {
+				continue
+			}
+			uncovered = append(uncovered, nodeID)
+		}
+	}
+	return uncovered, nil
+}
+
+// ackTimeout returns time to wait for acknowledgement from node with subtree of given depth,
+// every layer of the subtree may re-send message to substitute nodes.
+func (rpc *rpcController) ackTimeout(depth int) time.Duration {
+	return rpc.options.CascadeAckTimeout * time.Duration(depth*(rpc.options.CascadeRetries+1))
 }
 
 func (rpc *rpcController) requestCascadeSendMessage(ctx context.Context, data core.Cascade, nodeID core.RecordRef,
-	method string, args [][]byte) error {
+	method string, args [][]byte, timeout time.Duration) ([]core.RecordRef, error) {
 
 	_, span := instracer.StartSpan(context.Background(), "RPCController.requestCascadeSendMessage")
 	defer span.End()
@@ -186,37 +294,35 @@ func (rpc *rpcController) requestCascadeSendMessage(ctx context.Context, data co
 
 	future, err := rpc.hostNetwork.SendRequest(ctx, request, nodeID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	go func(ctx context.Context, f network.Future, duration time.Duration) {
-		response, err := f.GetResponse(duration)
-		if err != nil {
-			inslogger.FromContext(ctx).Warnf("Failed to get response to cascade message request from node %s: %s",
-				future.GetRequest().GetSender(), err.Error())
-			return
-		}
-		data := response.GetData().(*ResponseCascade)
-		if !data.Success {
-			inslogger.FromContext(ctx).Warnf("Error response to cascade message request from node %s: %s",
-				response.GetSender(), data.Error)
-			return
-		}
-	}(ctx, future, rpc.options.PacketTimeout)
-
-	return nil
+	response, err := future.GetResponse(timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get response to cascade message request")
+	}
+	result := response.GetData().(*ResponseCascade)
+	if !result.Success {
+		inslogger.FromContext(ctx).Warnf("Error response to cascade message request from node %s: %s",
+			response.GetSender(), result.Error)
+	}
+	return result.Delivered, nil
 }
 
-func (rpc *rpcController) SendMessage(nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error) {
+func (rpc *rpcController) SendMessage(ctx context.Context, nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error) {
 	msgBytes := message.ParcelToBytes(msg)
 	metrics.ParcelsSentSizeBytes.WithLabelValues(msg.Type().String()).Observe(float64(len(msgBytes)))
-	request := rpc.hostNetwork.NewRequestBuilder().Type(types.RPC).Data(&RequestRPC{
+	packetType := types.RPC
+	if replicationMessages[msg.Type()] {
+		packetType = types.Replication
+	}
+	request := rpc.hostNetwork.NewRequestBuilder().Type(packetType).Data(&RequestRPC{
 		Method: name,
 		Data:   [][]byte{msgBytes},
 	}).Build()
 
 	start := time.Now()
-	ctx := msg.Context(context.Background())
+	ctx = msg.Context(ctx)
 	logger := inslogger.FromContext(ctx)
 	logger.Debugf("SendParcel with nodeID = %s method = %s, message reference = %s, RequestID = %d", nodeID.String(),
 		name, msg.DefaultTarget().String(), request.GetRequestID())
@@ -224,7 +330,7 @@ func (rpc *rpcController) SendMessage(nodeID core.RecordRef, name string, msg co
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error sending RPC request to node %s", nodeID.String())
 	}
-	response, err := future.GetResponse(rpc.options.PacketTimeout)
+	response, err := future.GetResponseContext(ctx, rpc.options.PacketTimeout)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error getting RPC response from node %s", nodeID.String())
 	}
@@ -254,25 +360,31 @@ func (rpc *rpcController) processCascade(ctx context.Context, request network.Re
 	ctx, logger := inslogger.WithTraceField(ctx, payload.TraceID)
 
 	generalError := ""
+	delivered := make([]core.RecordRef, 0)
 	_, invokeErr := rpc.invoke(ctx, payload.RPC.Method, payload.RPC.Data)
 	if invokeErr != nil {
 		logger.Debugf("failed to invoke RPC: %s", invokeErr.Error())
 		generalError += invokeErr.Error() + "; "
+	} else {
+		delivered = append(delivered, rpc.hostNetwork.GetNodeID())
 	}
-	sendErr := rpc.initCascadeSendMessage(ctx, payload.Cascade, true, payload.RPC.Method, payload.RPC.Data)
+	subtreeDelivered, sendErr := rpc.initCascadeSendMessage(ctx, payload.Cascade, true, payload.RPC.Method, payload.RPC.Data)
+	delivered = append(delivered, subtreeDelivered...)
 	if sendErr != nil {
 		logger.Debugf("failed to send message to next cascade layer: %s", sendErr.Error())
 		generalError += sendErr.Error()
 	}
 
 	if generalError != "" {
-		return rpc.hostNetwork.BuildResponse(ctx, request, &ResponseCascade{Success: false, Error: generalError}), nil
+		return rpc.hostNetwork.BuildResponse(ctx, request,
+			&ResponseCascade{Success: false, Error: generalError, Delivered: delivered}), nil
 	}
-	return rpc.hostNetwork.BuildResponse(ctx, request, &ResponseCascade{Success: true}), nil
+	return rpc.hostNetwork.BuildResponse(ctx, request, &ResponseCascade{Success: true, Delivered: delivered}), nil
 }
 
 func (rpc *rpcController) Start(ctx context.Context) error {
 	rpc.hostNetwork.RegisterRequestHandler(types.RPC, rpc.processMessage)
+	rpc.hostNetwork.RegisterRequestHandler(types.Replication, rpc.processMessage)
 	rpc.hostNetwork.RegisterRequestHandler(types.Cascade, rpc.processCascade)
 	return nil
 }
@@ -50,6 +50,10 @@ func (b *Builder) GetSenderHost() *host.Host {
 	return b.sender
 }
 
+func (b *Builder) GetRemoteAddress() string {
+	return ""
+}
+
 func (b *Builder) GetType() types.PacketType {
 	return b.t
 }
@@ -19,6 +19,7 @@ package hostnetwork
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/keystore"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/sequence"
 	"github.com/insolar/insolar/network/transport"
@@ -52,6 +54,10 @@ func (p *packetWrapper) GetSenderHost() *host.Host {
 	return p.Sender
 }
 
+func (p *packetWrapper) GetRemoteAddress() string {
+	return p.RemoteAddress
+}
+
 func (p *packetWrapper) GetType() types.PacketType {
 	return p.Type
 }
@@ -83,7 +89,12 @@ func (f future) Response() <-chan network.Response {
 
 // GetResponse get response to sent request with `duration` timeout
 func (f future) GetResponse(duration time.Duration) (network.Response, error) {
-	result, err := f.GetResult(duration)
+	return f.GetResponseContext(context.Background(), duration)
+}
+
+// GetResponseContext get response to sent request with `duration` timeout or until ctx is done
+func (f future) GetResponseContext(ctx context.Context, duration time.Duration) (network.Response, error) {
+	result, err := f.GetResultContext(ctx, duration)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +164,18 @@ func (h *hostTransport) BuildResponse(ctx context.Context, request network.Reque
 }
 
 func NewInternalTransport(conf configuration.Configuration, nodeRef string) (network.InternalTransport, error) {
-	tp, err := transport.NewTransport(conf.Host.Transport, relay.NewProxy())
+	var privateKey crypto.PrivateKey
+	if conf.Host.Transport.TLS || conf.Host.Transport.SignPackets {
+		keyStore, err := keystore.NewKeyStore(conf.KeysPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading node keys for TLS and packet signing")
+		}
+		privateKey, err = keyStore.GetPrivateKey("")
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading node keys for TLS and packet signing")
+		}
+	}
+	tp, err := transport.NewTransportWithKey(conf.Host.Transport, relay.NewProxy(), privateKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating transport")
 	}
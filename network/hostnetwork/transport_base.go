@@ -19,6 +19,7 @@ package hostnetwork
 
 import (
 	"context"
+	"crypto"
 	"sync/atomic"
 
 	"github.com/insolar/insolar/core"
@@ -96,6 +97,41 @@ func (h *transportBase) GetNodeID() core.RecordRef {
 	return h.origin.NodeID
 }
 
+// Reputation returns tracker of peer scores.
+func (h *transportBase) Reputation() *host.Reputation {
+	return h.transport.Reputation()
+}
+
+// Faults returns fault injection layer of transport.
+func (h *transportBase) Faults() *host.Faults {
+	return h.transport.Faults()
+}
+
+// AccessList returns allow and deny lists of peers.
+func (h *transportBase) AccessList() *host.AccessList {
+	return h.transport.AccessList()
+}
+
+// Capture returns packet capture of transport.
+func (h *transportBase) Capture() *host.Capture {
+	return h.transport.Capture()
+}
+
+// SendQueue returns priority queue of outgoing packets of transport.
+func (h *transportBase) SendQueue() *host.SendQueue {
+	return h.transport.SendQueue()
+}
+
+// SetSendQueue makes transport send packets through queue shared with another transport.
+func (h *transportBase) SetSendQueue(queue *host.SendQueue) {
+	h.transport.SetSendQueue(queue)
+}
+
+// SetKeyResolver enables verification of signatures of incoming packets with keys of authenticated nodes.
+func (h *transportBase) SetKeyResolver(resolve func(ref core.RecordRef) crypto.PublicKey) {
+	h.transport.SetKeyResolver(resolve)
+}
+
 // NewRequestBuilder create packet Builder for an outgoing request with sender set to current node.
 func (h *transportBase) NewRequestBuilder() network.RequestBuilder {
 	return &Builder{sender: h.origin, id: network.RequestID(h.sequenceGenerator.Generate())}
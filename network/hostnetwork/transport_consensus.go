@@ -24,6 +24,7 @@ import (
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/sequence"
 	"github.com/insolar/insolar/network/transport"
@@ -67,6 +68,11 @@ func (tc *transportConsensus) processMessage(msg *packet.Packet) {
 		log.Errorf("Error processing incoming message: failed to resolve ShortID (%d) -> NodeID", msg.Sender.ShortID)
 		return
 	}
+	if !tc.AccessList().IsNodeAllowed(sender.NodeID) {
+		log.Debugf("Dropped %s request from denied node %s", msg.Type.String(), sender.NodeID.String())
+		metrics.NetworkPacketDroppedDeniedTotal.Inc()
+		return
+	}
 	msg.Sender = sender
 	handler, exist := tc.handlers[msg.Type]
 	if !exist {
@@ -80,10 +86,22 @@ func (tc *transportConsensus) processMessage(msg *packet.Packet) {
 func NewConsensusNetwork(address, nodeID string, shortID core.ShortNodeID,
 	resolver network.RoutingTable) (network.ConsensusNetwork, error) {
 
+	return NewConsensusNetworkWithConfig(configuration.Transport{Address: address}, nodeID, shortID, resolver)
+}
+
+// NewConsensusNetworkWithConfig creates consensus network listening on address of transport configuration. Consensus
+// transport is PURE_UDP, or MEMORY_UDP on the same in-memory network if configured protocol is MEMORY.
+func NewConsensusNetworkWithConfig(cfg configuration.Transport, nodeID string, shortID core.ShortNodeID,
+	resolver network.RoutingTable) (network.ConsensusNetwork, error) {
+
 	conf := configuration.Transport{}
-	conf.Address = address
+	conf.Address = cfg.Address
 	conf.Protocol = "PURE_UDP"
 	conf.BehindNAT = false
+	if cfg.Protocol == "MEMORY" {
+		conf.Protocol = "MEMORY_UDP"
+		conf.MemoryNetwork = cfg.MemoryNetwork
+	}
 
 	tp, err := transport.NewTransport(conf, relay.NewProxy())
 	if err != nil {
@@ -19,9 +19,12 @@ package hostnetwork
 
 import (
 	"context"
+	"time"
 
+	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport"
 	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/pkg/errors"
 )
@@ -52,13 +55,53 @@ func (tr *TransportResolvable) GetNodeID() core.RecordRef {
 	return tr.internalTransport.GetNodeID()
 }
 
-// SendRequest send request to a remote node.
+// SendRequest send request to a remote node. If node advertises several addresses and sending fails,
+// next healthy address of node is tried.
 func (tr *TransportResolvable) SendRequest(ctx context.Context, request network.Request, receiver core.RecordRef) (network.Future, error) {
-	h, err := tr.resolver.Resolve(receiver)
-	if err != nil {
-		return nil, errors.Wrap(err, "error resolving NodeID -> Address")
+	tried := make(map[string]struct{}, packets.MaxNodeAddresses)
+	var lastErr error
+	for i := 0; i < packets.MaxNodeAddresses; i++ {
+		h, err := tr.resolver.Resolve(receiver)
+		if err != nil {
+			return nil, errors.Wrap(err, "error resolving NodeID -> Address")
+		}
+		if h.Address == nil {
+			return tr.internalTransport.SendRequestPacket(ctx, request, h)
+		}
+		address := h.Address.String()
+		if _, ok := tried[address]; ok {
+			break
+		}
+		tried[address] = struct{}{}
+		f, err := tr.internalTransport.SendRequestPacket(ctx, request, h)
+		if err == nil {
+			return &healthFuture{Future: f, address: address, resolver: tr.resolver}, nil
+		}
+		tr.resolver.ReportFailure(address)
+		lastErr = err
 	}
-	return tr.internalTransport.SendRequestPacket(ctx, request, h)
+	return nil, lastErr
+}
+
+// healthFuture reports health of receiver address to routing table when response is received or timed out.
+type healthFuture struct {
+	network.Future
+	address  string
+	resolver network.RoutingTable
+}
+
+func (f *healthFuture) GetResponse(duration time.Duration) (network.Response, error) {
+	return f.GetResponseContext(context.Background(), duration)
+}
+
+func (f *healthFuture) GetResponseContext(ctx context.Context, duration time.Duration) (network.Response, error) {
+	response, err := f.Future.GetResponseContext(ctx, duration)
+	if err == transport.ErrTimeout {
+		f.resolver.ReportFailure(f.address)
+	} else if err == nil {
+		f.resolver.ReportSuccess(f.address)
+	}
+	return response, err
 }
 
 // RegisterRequestHandler register a handler function to process incoming requests of a specific type.
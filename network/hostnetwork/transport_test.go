@@ -66,10 +66,12 @@ func (m *MockResolver) ResolveS(id core.ShortNodeID) (*host.Host, error) {
 	return result, nil
 }
 
-func (m *MockResolver) Inject(nodeKeeper network.NodeKeeper) {}
-func (m *MockResolver) AddToKnownHosts(h *host.Host)         {}
-func (m *MockResolver) Rebalance(network.PartitionPolicy)    {}
-func (m *MockResolver) GetRandomNodes(int) []host.Host       { return nil }
+func (m *MockResolver) Inject(nodeKeeper network.NodeKeeper)             {}
+func (m *MockResolver) AddToKnownHosts(h *host.Host)                     {}
+func (m *MockResolver) Rebalance(network.PartitionPolicy)                {}
+func (m *MockResolver) GetRandomNodes(int, []core.RecordRef) []host.Host { return nil }
+func (m *MockResolver) ReportFailure(string)                             {}
+func (m *MockResolver) ReportSuccess(string)                             {}
 
 func (m *MockResolver) addMapping(key, value string) error {
 	k, err := core.NewRefFromBase58(key)
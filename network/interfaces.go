@@ -19,6 +19,7 @@ package network
 
 import (
 	"context"
+	"crypto"
 	"time"
 
 	"github.com/insolar/insolar/component"
@@ -32,7 +33,7 @@ import (
 type Controller interface {
 	component.Starter
 	// SendParcel send message to nodeID.
-	SendMessage(nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error)
+	SendMessage(ctx context.Context, nodeID core.RecordRef, name string, msg core.Parcel) ([]byte, error)
 	// RemoteProcedureRegister register remote procedure that will be executed when message is received.
 	RemoteProcedureRegister(name string, method core.RemoteProcedure)
 	// SendCascadeMessage sends a message from MessageBus to a cascade of nodes.
@@ -92,6 +93,14 @@ type ConsensusNetwork interface {
 	RegisterRequestHandler(t types.PacketType, handler ConsensusRequestHandler)
 	// NewRequestBuilder create packet builder for an outgoing request with sender set to current node.
 	NewRequestBuilder() RequestBuilder
+	// Faults returns fault injection layer of transport.
+	Faults() *host.Faults
+	// AccessList returns allow and deny lists of peers.
+	AccessList() *host.AccessList
+	// Capture returns packet capture of transport.
+	Capture() *host.Capture
+	// SetSendQueue makes transport send packets through priority queue shared with another transport.
+	SetSendQueue(queue *host.SendQueue)
 }
 
 // RequestID is 64 bit unsigned int request id.
@@ -101,6 +110,8 @@ type RequestID uint64
 type Packet interface {
 	GetSender() core.RecordRef
 	GetSenderHost() *host.Host
+	// GetRemoteAddress returns address of connection packet was received from, it is empty for local packets.
+	GetRemoteAddress() string
 	GetType() types.PacketType
 	GetData() interface{}
 	GetRequestID() RequestID
@@ -117,6 +128,7 @@ type Future interface {
 	GetRequest() Request
 	Response() <-chan Response
 	GetResponse(duration time.Duration) (Response, error)
+	GetResponseContext(ctx context.Context, duration time.Duration) (Response, error)
 }
 
 // RequestBuilder allows to build a Request.
@@ -155,6 +167,8 @@ type NodeKeeper interface {
 	SetCloudHash([]byte)
 	// AddActiveNodes add active nodes.
 	AddActiveNodes([]core.Node)
+	// RemoveActiveNodes removes active nodes by their references.
+	RemoveActiveNodes([]core.RecordRef)
 	// GetActiveNodeByShortID get active node by short ID. Returns nil if node is not found.
 	GetActiveNodeByShortID(shortID core.ShortNodeID) core.Node
 	// SetState set state of the NodeKeeper
@@ -180,6 +194,18 @@ type NodeKeeper interface {
 	Sync(list UnsyncList)
 	// MoveSyncToActive merge sync list with active nodes
 	MoveSyncToActive()
+	// Reset drops active nodes, pending claims and bootstrap state. Used before rejoining the network.
+	Reset()
+	// AddFaultEvidence records evidence of node misbehaviour detected during consensus.
+	AddFaultEvidence(ctx context.Context, evidence core.FaultEvidence) error
+	// GetFaultEvidence returns recorded evidences of node misbehaviour.
+	GetFaultEvidence(ctx context.Context, ref core.RecordRef) ([]core.FaultEvidence, error)
+}
+
+// Resyncer restores node state from network when node detects that its state diverged from other nodes.
+type Resyncer interface {
+	// Resync schedules rejoin of node to the network, active list is restored by bootstrap and consensus.
+	Resync(ctx context.Context)
 }
 
 // UnsyncList is interface to manage unsync list
@@ -213,10 +239,17 @@ type RoutingTable interface {
 	ResolveS(core.ShortNodeID) (*host.Host, error)
 	// AddToKnownHosts add host to routing table.
 	AddToKnownHosts(*host.Host)
+	// AddRoutingHint adds host of node learned from other nodes, it is used only to resolve node missing in active list.
+	AddRoutingHint(*host.Host)
 	// Rebalance recreate shards of routing table with known hosts according to new partition policy.
 	Rebalance(PartitionPolicy)
 	// GetRandomNodes get a specified number of random nodes. Returns less if there are not enough nodes in network.
-	GetRandomNodes(count int) []host.Host
+	// Nodes from exclude list are never returned.
+	GetRandomNodes(count int, exclude []core.RecordRef) []host.Host
+	// ReportFailure marks node address as unhealthy, other addresses of node are preferred while it recovers.
+	ReportFailure(address string)
+	// ReportSuccess marks node address as healthy.
+	ReportSuccess(address string)
 }
 
 // InternalTransport simple interface to send network requests and process network responses.
@@ -238,6 +271,19 @@ type InternalTransport interface {
 	NewRequestBuilder() RequestBuilder
 	// BuildResponse create response to an incoming request with Data set to responseData.
 	BuildResponse(ctx context.Context, request Request, responseData interface{}) Response
+	// Reputation returns tracker of peer scores.
+	Reputation() *host.Reputation
+	// Faults returns fault injection layer of transport.
+	Faults() *host.Faults
+	// AccessList returns allow and deny lists of peers.
+	AccessList() *host.AccessList
+	// Capture returns packet capture of transport.
+	Capture() *host.Capture
+	// SendQueue returns priority queue of outgoing packets, nil if packets are sent without queue.
+	SendQueue() *host.SendQueue
+	// SetKeyResolver enables verification of signatures of incoming packets, resolve returns public key of
+	// authenticated node or nil if node is not authenticated.
+	SetKeyResolver(resolve func(ref core.RecordRef) crypto.PublicKey)
 }
 
 // ClaimQueue is the queue that contains consensus claims.
@@ -249,4 +295,6 @@ type ClaimQueue interface {
 	Front() consensus.ReferendumClaim
 	// Length returns the length of the queue
 	Length() int
+	// Snapshot returns a copy of the claims currently in the queue.
+	Snapshot() []consensus.ReferendumClaim
 }
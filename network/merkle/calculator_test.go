@@ -141,7 +141,7 @@ func TestCalculator(t *testing.T) {
 	}
 
 	cm := component.Manager{}
-	cm.Inject(nk, am, calculator, service, scheme)
+	cm.Inject(nk, testutils.NewFaultEvidenceStorageMock(t), am, calculator, service, scheme)
 
 	require.NotNil(t, calculator.ArtifactManager)
 	require.NotNil(t, calculator.NodeNetwork)
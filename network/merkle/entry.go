@@ -18,9 +18,8 @@
 package merkle
 
 import (
-	"sort"
-
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
 	"github.com/pkg/errors"
 )
 
@@ -41,8 +40,22 @@ type GlobuleEntry struct {
 }
 
 func (ge *GlobuleEntry) hash(helper *merkleHelper) ([]byte, error) {
-	nodeEntryByRole := nodeEntryByRole(ge.PulseEntry, ge.ProofSet)
+	root, _, err := globuleNodeRoot(helper, ge.PulseEntry, sortedProofs(ge.ProofSet), nil)
+	return root, err
+}
+
+// globuleNodeRoot calculates root of node tree of globule. If target is set, merkle path from state hash
+// of target node to the root is returned too.
+func globuleNodeRoot(
+	helper *merkleHelper,
+	pulseEntry *PulseEntry,
+	proofs []nodeProof,
+	target *core.RecordRef,
+) ([]byte, []PathStep, error) {
+	nodeEntryByRole := nodeEntryByRole(pulseEntry, proofs)
 	var bucketHashes [][]byte
+	var path []PathStep
+	targetBucket := -1
 
 	for _, role := range core.AllStaticRoles {
 		roleEntries, ok := nodeEntryByRole[role]
@@ -50,14 +63,17 @@ func (ge *GlobuleEntry) hash(helper *merkleHelper) ([]byte, error) {
 			continue
 		}
 
-		sortEntries(roleEntries)
-		bucketEntryRoot, err := roleEntryRoot(roleEntries, helper)
+		bucketEntryRoot, entryPath, err := roleEntryRoot(roleEntries, helper, target)
 
 		if err != nil {
-			return nil, errors.Wrap(err, "[ hash ] Failed to create tree for bucket role entry")
+			return nil, nil, errors.Wrap(err, "[ hash ] Failed to create tree for bucket role entry")
 		}
 
 		bucketInfoHash := helper.bucketInfoHash(role, uint32(len(roleEntries)))
+		if entryPath != nil {
+			targetBucket = len(bucketHashes)
+			path = append(entryPath, PathStep{Left: true, Hash: bucketInfoHash})
+		}
 		bucketHash := helper.bucketHash(bucketInfoHash, bucketEntryRoot)
 		bucketHashes = append(bucketHashes, bucketHash)
 	}
@@ -65,10 +81,16 @@ func (ge *GlobuleEntry) hash(helper *merkleHelper) ([]byte, error) {
 	tree, err := treeFromHashList(bucketHashes, helper.scheme.IntegrityHasher())
 
 	if err != nil {
-		return nil, errors.Wrap(err, "[ hash ] Failed to create tree for bucket hashes")
+		return nil, nil, errors.Wrap(err, "[ hash ] Failed to create tree for bucket hashes")
 	}
 
-	return tree.Root(), nil
+	if target != nil {
+		if targetBucket < 0 {
+			return nil, nil, errors.Errorf("[ hash ] Node %s is not in globule", target)
+		}
+		path = append(path, tree.path(targetBucket)...)
+	}
+	return tree.Root(), path, nil
 }
 
 type CloudEntry struct {
@@ -77,26 +99,50 @@ type CloudEntry struct {
 }
 
 func (ce *CloudEntry) hash(helper *merkleHelper) ([]byte, error) {
+	root, _, err := cloudRoot(helper, ce.PrevCloudHash, sortedGlobuleProofs(ce.ProofSet), nil)
+	return root, err
+}
+
+// cloudRoot calculates cloud hash of globule proofs. If target is set, merkle path from node root of target
+// globule to the cloud hash is returned too.
+func cloudRoot(
+	helper *merkleHelper,
+	prevCloudHash []byte,
+	proofs []*GlobuleProof,
+	target *core.GlobuleID,
+) ([]byte, []PathStep, error) {
 	var result [][]byte
+	var path []PathStep
+	targetIndex := -1
 
-	for _, proof := range ce.ProofSet {
-		globuleInfoHash := helper.globuleInfoHash(ce.PrevCloudHash, uint32(proof.GlobuleID), proof.NodeCount)
+	for index, proof := range proofs {
+		globuleInfoHash := helper.globuleInfoHash(prevCloudHash, uint32(proof.GlobuleID), proof.NodeCount)
 		globuleHash := helper.globuleHash(globuleInfoHash, proof.NodeRoot)
 		result = append(result, globuleHash)
+		if target != nil && proof.GlobuleID == *target {
+			targetIndex = index
+			path = append(path, PathStep{Left: true, Hash: globuleInfoHash})
+		}
 	}
 
 	tree, err := treeFromHashList(result, helper.scheme.IntegrityHasher())
 	if err != nil {
-		return nil, errors.Wrap(err, "[ hash ] Failed to create tree")
+		return nil, nil, errors.Wrap(err, "[ hash ] Failed to create tree")
 	}
 
-	return tree.Root(), nil
+	if target != nil {
+		if targetIndex < 0 {
+			return nil, nil, errors.Errorf("[ hash ] Globule %d is not in cloud", *target)
+		}
+		path = append(path, tree.path(targetIndex)...)
+	}
+	return tree.Root(), path, nil
 }
 
 type nodeEntry struct {
 	*PulseEntry
 	PulseProof *PulseProof
-	Node       core.Node
+	Ref        core.RecordRef
 }
 
 func (ne *nodeEntry) hash(helper *merkleHelper) []byte {
@@ -105,36 +151,51 @@ func (ne *nodeEntry) hash(helper *merkleHelper) []byte {
 	return helper.nodeHash(ne.PulseProof.Signature.Bytes(), nodeInfoHash)
 }
 
-func nodeEntryByRole(pulseEntry *PulseEntry, nodeProofs map[core.Node]*PulseProof) map[core.StaticRole][]*nodeEntry {
+// path returns merkle path from state hash of node to node entry hash.
+func (ne *nodeEntry) path(helper *merkleHelper) []PathStep {
+	return []PathStep{
+		{Left: true, Hash: ne.PulseEntry.hash(helper)},
+		{Left: true, Hash: helper.leafHasher.Hash(ne.PulseProof.Signature.Bytes())},
+	}
+}
+
+func nodeEntryByRole(pulseEntry *PulseEntry, nodeProofs []nodeProof) map[core.StaticRole][]*nodeEntry {
 	roleMap := make(map[core.StaticRole][]*nodeEntry)
-	for node, pulseProof := range nodeProofs {
-		role := node.Role()
-		roleMap[role] = append(roleMap[role], &nodeEntry{
+	for _, np := range nodeProofs {
+		roleMap[np.Role] = append(roleMap[np.Role], &nodeEntry{
 			PulseEntry: pulseEntry,
-			Node:       node,
-			PulseProof: pulseProof,
+			Ref:        np.Ref,
+			PulseProof: np.Proof,
 		})
 	}
 	return roleMap
 }
 
-func sortEntries(roleEntries []*nodeEntry) {
-	sort.SliceStable(roleEntries, func(i, j int) bool {
-		return roleEntries[i].Node.ID().Compare(roleEntries[j].Node.ID()) < 0
-	})
-}
-
-func roleEntryRoot(roleEntries []*nodeEntry, helper *merkleHelper) ([]byte, error) {
+// roleEntryRoot calculates root of bucket entries tree, merkle path from state hash of target node to the root
+// is returned if target is in bucket.
+func roleEntryRoot(roleEntries []*nodeEntry, helper *merkleHelper, target *core.RecordRef) ([]byte, []PathStep, error) {
 	var roleEntriesHashes [][]byte
+	var path []PathStep
+	targetIndex := -1
 	for index, entry := range roleEntries {
 		bucketEntryHash := helper.bucketEntryHash(uint32(index), entry.hash(helper))
 		roleEntriesHashes = append(roleEntriesHashes, bucketEntryHash)
+		if target != nil && entry.Ref == *target {
+			targetIndex = index
+			path = append(entry.path(helper), PathStep{
+				Left: true,
+				Hash: helper.leafHasher.Hash(utils.UInt32ToBytes(uint32(index))),
+			})
+		}
 	}
 
 	tree, err := treeFromHashList(roleEntriesHashes, helper.scheme.IntegrityHasher())
 	if err != nil {
-		return nil, errors.Wrap(err, "[ hash ] Failed to create tree")
+		return nil, nil, errors.Wrap(err, "[ hash ] Failed to create tree")
 	}
 
-	return tree.Root(), nil
+	if targetIndex >= 0 {
+		path = append(path, tree.path(targetIndex)...)
+	}
+	return tree.Root(), path, nil
 }
@@ -152,7 +152,7 @@ func TestCalculatorError(t *testing.T) {
 
 	jc := testutils.NewJetCoordinatorMock(t)
 
-	cm.Inject(nk, jc, l.ArtifactManager, calculator, service, scheme, pulseManager)
+	cm.Inject(nk, testutils.NewFaultEvidenceStorageMock(t), jc, l.ArtifactManager, calculator, service, scheme, pulseManager)
 
 	require.NotNil(t, calculator.ArtifactManager)
 	require.NotNil(t, calculator.NodeNetwork)
@@ -203,7 +203,7 @@ func TestCalculatorLedgerError(t *testing.T) {
 
 	scheme := platformpolicy.NewPlatformCryptographyScheme()
 	nk := nodekeeper.GetTestNodekeeper(service)
-	cm.Inject(nk, am, calculator, service, scheme)
+	cm.Inject(nk, testutils.NewFaultEvidenceStorageMock(t), am, calculator, service, scheme)
 
 	require.NotNil(t, calculator.ArtifactManager)
 	require.NotNil(t, calculator.NodeNetwork)
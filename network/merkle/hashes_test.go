@@ -144,7 +144,7 @@ func TestCalculatorHashes(t *testing.T) {
 	nk := nodekeeper.GetTestNodekeeper(service)
 
 	cm := component.Manager{}
-	cm.Inject(nk, am, calculator, service, scheme)
+	cm.Inject(nk, testutils.NewFaultEvidenceStorageMock(t), am, calculator, service, scheme)
 
 	require.NotNil(t, calculator.ArtifactManager)
 	require.NotNil(t, calculator.NodeNetwork)
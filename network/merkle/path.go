@@ -0,0 +1,110 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package merkle
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// PathStep is a sibling hash on the way from leaf to root of merkle tree. Every step hashes concatenation of
+// sibling and current hash in order defined by Left.
+type PathStep struct {
+	// Left is true if sibling is the left operand
+	Left bool
+	Hash []byte
+}
+
+// VerifyPath checks that merkle path leads from leaf to root.
+func VerifyPath(scheme core.PlatformCryptographyScheme, leaf []byte, path []PathStep, root []byte) bool {
+	helper := newMerkleHelper(scheme)
+	hash := leaf
+	for _, step := range path {
+		if step.Left {
+			hash = helper.doubleSliceHash(step.Hash, hash)
+		} else {
+			hash = helper.doubleSliceHash(hash, step.Hash)
+		}
+	}
+	return bytes.Equal(hash, root)
+}
+
+// CloudPath returns merkle path from state hash of node to the cloud hash of record. Record must contain
+// globule entry of globule node belongs to.
+func CloudPath(scheme core.PlatformCryptographyScheme, record core.CloudHashRecord, node core.RecordRef) ([]PathStep, error) {
+	helper := newMerkleHelper(scheme)
+
+	pulseEntry := &PulseEntry{Pulse: &core.Pulse{PulseNumber: record.Pulse, Entropy: record.Entropy}}
+	proofs := make([]nodeProof, len(record.NodeProofs))
+	for i, proof := range record.NodeProofs {
+		proofs[i] = nodeProof{
+			Ref:  proof.Node,
+			Role: proof.Role,
+			Proof: &PulseProof{
+				BaseProof: BaseProof{Signature: core.SignatureFromBytes(proof.Signature)},
+				StateHash: proof.StateHash,
+			},
+		}
+	}
+	sort.Slice(proofs, func(i, j int) bool {
+		return proofs[i].Ref.Compare(proofs[j].Ref) < 0
+	})
+
+	nodeRoot, path, err := globuleNodeRoot(helper, pulseEntry, proofs, &node)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ CloudPath ] Failed to build path to node root")
+	}
+
+	globules := recordGlobules(record)
+	for _, globule := range globules {
+		if globule.GlobuleID == record.GlobuleID && !bytes.Equal(globule.NodeRoot, nodeRoot) {
+			return nil, errors.New("[ CloudPath ] Node root doesn't match globule proof")
+		}
+	}
+	cloudHash, cloudPath, err := cloudRoot(helper, record.PrevCloudHash, globules, &record.GlobuleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ CloudPath ] Failed to build path to cloud hash")
+	}
+	if !bytes.Equal(cloudHash, record.CloudHash) {
+		return nil, errors.New("[ CloudPath ] Cloud hash doesn't match globule proofs")
+	}
+	return append(path, cloudPath...), nil
+}
+
+// recordGlobules returns globule proofs of cloud entry record was calculated from, proofs signed by different
+// nodes of the same globule are merged.
+func recordGlobules(record core.CloudHashRecord) []*GlobuleProof {
+	seen := make(map[core.GlobuleID]bool)
+	var result []*GlobuleProof
+	for _, proof := range record.GlobuleProofs {
+		if seen[proof.GlobuleID] {
+			continue
+		}
+		seen[proof.GlobuleID] = true
+		result = append(result, &GlobuleProof{
+			PrevCloudHash: proof.PrevCloudHash,
+			GlobuleID:     proof.GlobuleID,
+			NodeCount:     proof.NodeCount,
+			NodeRoot:      proof.NodeRoot,
+		})
+	}
+	return sortedGlobuleProofs(result)
+}
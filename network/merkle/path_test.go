@@ -0,0 +1,97 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package merkle
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudPath(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	helper := newMerkleHelper(scheme)
+
+	pulseEntry := goldenPulse()
+	roles := []core.StaticRole{
+		core.StaticRoleVirtual, core.StaticRoleVirtual, core.StaticRoleVirtual,
+		core.StaticRoleLightMaterial, core.StaticRoleHeavyMaterial,
+	}
+	proofSet := make(map[core.Node]*PulseProof)
+	record := core.CloudHashRecord{
+		Pulse:         pulseEntry.Pulse.PulseNumber,
+		Entropy:       pulseEntry.Pulse.Entropy,
+		PrevCloudHash: []byte{0xbe, 0xef},
+		GlobuleID:     1,
+	}
+	for i, role := range roles {
+		node := goldenNode(byte(i+1), role)
+		proof := &PulseProof{
+			BaseProof: BaseProof{Signature: core.SignatureFromBytes([]byte{byte(i), 0xaa})},
+			StateHash: scheme.IntegrityHasher().Hash([]byte{byte(i)}),
+		}
+		proofSet[node] = proof
+		record.NodeProofs = append(record.NodeProofs, core.PulseProofRecord{
+			Node:      node.ID(),
+			Role:      role,
+			StateHash: proof.StateHash,
+			Signature: proof.Signature.Bytes(),
+		})
+	}
+
+	globule := &GlobuleEntry{PulseEntry: pulseEntry, ProofSet: proofSet, PrevCloudHash: record.PrevCloudHash, GlobuleID: 1}
+	nodeRoot, err := globule.hash(helper)
+	require.NoError(t, err)
+
+	cloud := &CloudEntry{
+		ProofSet: []*GlobuleProof{
+			{GlobuleID: 2, NodeCount: 3, NodeRoot: []byte{2}, PrevCloudHash: record.PrevCloudHash},
+			{GlobuleID: 1, NodeCount: uint32(len(roles)), NodeRoot: nodeRoot, PrevCloudHash: record.PrevCloudHash},
+			{GlobuleID: 3, NodeCount: 3, NodeRoot: []byte{3}, PrevCloudHash: record.PrevCloudHash},
+		},
+		PrevCloudHash: record.PrevCloudHash,
+	}
+	record.CloudHash, err = cloud.hash(helper)
+	require.NoError(t, err)
+	for i, proof := range cloud.ProofSet {
+		record.GlobuleProofs = append(record.GlobuleProofs, core.GlobuleProofRecord{
+			Node:          goldenNode(byte(10+i), core.StaticRoleVirtual).ID(),
+			GlobuleID:     proof.GlobuleID,
+			NodeCount:     proof.NodeCount,
+			NodeRoot:      proof.NodeRoot,
+			PrevCloudHash: proof.PrevCloudHash,
+		})
+	}
+
+	for _, proof := range record.NodeProofs {
+		path, err := CloudPath(scheme, record, proof.Node)
+		require.NoError(t, err)
+		assert.True(t, VerifyPath(scheme, proof.StateHash, path, record.CloudHash))
+		assert.False(t, VerifyPath(scheme, []byte{1}, path, record.CloudHash))
+	}
+
+	_, err = CloudPath(scheme, record, goldenNode(0xff, core.StaticRoleVirtual).ID())
+	assert.Error(t, err)
+
+	record.CloudHash = []byte{1}
+	_, err = CloudPath(scheme, record, record.NodeProofs[0].Node)
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package merkle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// EntryLayoutVersion is the version of canonical layout of serialized merkle entries. It must be increased on
+// any change of the layout, so other implementations can tell which layout they are reading.
+const EntryLayoutVersion uint8 = 1
+
+// entryKind distinguishes serialized merkle entries of different levels.
+type entryKind uint8
+
+const (
+	pulseEntryKind = entryKind(iota + 1)
+	globuleEntryKind
+	cloudEntryKind
+)
+
+var entryByteOrder = binary.BigEndian
+
+// nodeProof is pulse proof of node in canonical order of globule entry.
+type nodeProof struct {
+	Ref   core.RecordRef
+	Role  core.StaticRole
+	Proof *PulseProof
+}
+
+// sortedProofs returns proofs of globule entry sorted by node reference, so result doesn't depend on map iteration.
+func sortedProofs(proofSet map[core.Node]*PulseProof) []nodeProof {
+	result := make([]nodeProof, 0, len(proofSet))
+	for node, proof := range proofSet {
+		result = append(result, nodeProof{Ref: node.ID(), Role: node.Role(), Proof: proof})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Ref.Compare(result[j].Ref) < 0
+	})
+	return result
+}
+
+// sortedGlobuleProofs returns copy of globule proofs sorted by globule id.
+func sortedGlobuleProofs(proofSet []*GlobuleProof) []*GlobuleProof {
+	result := make([]*GlobuleProof, len(proofSet))
+	copy(result, proofSet)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].GlobuleID < result[j].GlobuleID
+	})
+	return result
+}
+
+// Serialize returns canonical binary representation of pulse entry.
+//
+// Layout: version (1 byte), kind (1 byte), pulse number (4 bytes), entropy (64 bytes).
+func (pe *PulseEntry) Serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	writeEntryHeader(&buf, pulseEntryKind)
+	if err := pe.serializeBody(&buf); err != nil {
+		return nil, errors.Wrap(err, "[ PulseEntry.Serialize ] Failed to serialize pulse entry")
+	}
+	return buf.Bytes(), nil
+}
+
+func (pe *PulseEntry) serializeBody(buf *bytes.Buffer) error {
+	if pe == nil || pe.Pulse == nil {
+		return errors.New("pulse is not set")
+	}
+	writeUint32(buf, uint32(pe.Pulse.PulseNumber))
+	buf.Write(pe.Pulse.Entropy[:])
+	return nil
+}
+
+// Serialize returns canonical binary representation of globule entry. Proofs are ordered by node reference.
+//
+// Layout: version (1 byte), kind (1 byte), pulse entry body, globule id (4 bytes), pulse hash, previous cloud hash,
+// proof count (4 bytes) and for every proof: node reference (64 bytes), node role (4 bytes), signature, state hash.
+// Every variable length field is prefixed with its length (4 bytes).
+func (ge *GlobuleEntry) Serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	writeEntryHeader(&buf, globuleEntryKind)
+	if err := ge.PulseEntry.serializeBody(&buf); err != nil {
+		return nil, errors.Wrap(err, "[ GlobuleEntry.Serialize ] Failed to serialize pulse entry")
+	}
+	writeUint32(&buf, uint32(ge.GlobuleID))
+	writeBytes(&buf, ge.PulseHash)
+	writeBytes(&buf, ge.PrevCloudHash)
+
+	proofs := sortedProofs(ge.ProofSet)
+	writeUint32(&buf, uint32(len(proofs)))
+	for _, np := range proofs {
+		if np.Proof == nil {
+			return nil, errors.Errorf("[ GlobuleEntry.Serialize ] Proof of node %s is not set", np.Ref)
+		}
+		buf.Write(np.Ref[:])
+		writeUint32(&buf, uint32(np.Role))
+		writeBytes(&buf, np.Proof.Signature.Bytes())
+		writeBytes(&buf, np.Proof.StateHash)
+	}
+	return buf.Bytes(), nil
+}
+
+// Serialize returns canonical binary representation of cloud entry. Proofs are ordered by globule id.
+//
+// Layout: version (1 byte), kind (1 byte), previous cloud hash, proof count (4 bytes) and for every proof:
+// globule id (4 bytes), node count (4 bytes), previous cloud hash, node root, signature.
+// Every variable length field is prefixed with its length (4 bytes).
+func (ce *CloudEntry) Serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	writeEntryHeader(&buf, cloudEntryKind)
+	writeBytes(&buf, ce.PrevCloudHash)
+
+	proofs := sortedGlobuleProofs(ce.ProofSet)
+	writeUint32(&buf, uint32(len(proofs)))
+	for i, proof := range proofs {
+		if proof == nil {
+			return nil, errors.Errorf("[ CloudEntry.Serialize ] Proof %d is not set", i)
+		}
+		writeUint32(&buf, uint32(proof.GlobuleID))
+		writeUint32(&buf, proof.NodeCount)
+		writeBytes(&buf, proof.PrevCloudHash)
+		writeBytes(&buf, proof.NodeRoot)
+		writeBytes(&buf, proof.Signature.Bytes())
+	}
+	return buf.Bytes(), nil
+}
+
+func writeEntryHeader(buf *bytes.Buffer, kind entryKind) {
+	buf.WriteByte(EntryLayoutVersion)
+	buf.WriteByte(byte(kind))
+}
+
+func writeUint32(buf *bytes.Buffer, value uint32) {
+	var data [4]byte
+	entryByteOrder.PutUint32(data[:], value)
+	buf.Write(data[:])
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	writeUint32(buf, uint32(len(data)))
+	buf.Write(data)
+}
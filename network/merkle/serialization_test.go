@@ -0,0 +1,149 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package merkle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Golden vectors of EntryLayoutVersion 1, they must not change unless the layout version is increased.
+const (
+	goldenEntropy = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f" +
+		"202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f"
+	goldenPulseEntry   = "0101" + "00000539" + goldenEntropy
+	goldenGlobuleEntry = "0102" + "00000539" + goldenEntropy +
+		"00000007" + "00000002dead" + "00000002beef" + "00000002" +
+		"01010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101" +
+		"00000001" + "00000001aa" + "000000020102" +
+		"02020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202" +
+		"00000003" + "00000001bb" + "000000020304"
+	goldenCloudEntry = "0103" + "00000002beef" + "00000002" +
+		"00000001" + "00000002" + "00000002beef" + "0000000111" + "00000001cc" +
+		"00000002" + "00000001" + "00000002beef" + "0000000122" + "00000001dd"
+)
+
+func goldenPulse() *PulseEntry {
+	pulse := &core.Pulse{PulseNumber: core.PulseNumber(1337)}
+	for i := range pulse.Entropy {
+		pulse.Entropy[i] = byte(i)
+	}
+	return &PulseEntry{Pulse: pulse}
+}
+
+func goldenNode(fill byte, role core.StaticRole) core.Node {
+	var ref core.RecordRef
+	copy(ref[:], bytes.Repeat([]byte{fill}, core.RecordRefSize))
+	return nodenetwork.NewNode(ref, role, nil, "127.0.0.1:0", "")
+}
+
+func goldenGlobule() *GlobuleEntry {
+	return &GlobuleEntry{
+		PulseEntry: goldenPulse(),
+		ProofSet: map[core.Node]*PulseProof{
+			goldenNode(2, core.StaticRoleLightMaterial): {
+				BaseProof: BaseProof{Signature: core.SignatureFromBytes([]byte{0xbb})},
+				StateHash: []byte{3, 4},
+			},
+			goldenNode(1, core.StaticRoleVirtual): {
+				BaseProof: BaseProof{Signature: core.SignatureFromBytes([]byte{0xaa})},
+				StateHash: []byte{1, 2},
+			},
+		},
+		PulseHash:     []byte{0xde, 0xad},
+		PrevCloudHash: []byte{0xbe, 0xef},
+		GlobuleID:     7,
+	}
+}
+
+func assertGolden(t *testing.T, golden string, data []byte) {
+	expected, err := hex.DecodeString(golden)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(data))
+}
+
+func TestPulseEntry_Serialize(t *testing.T) {
+	data, err := goldenPulse().Serialize()
+	require.NoError(t, err)
+	assertGolden(t, goldenPulseEntry, data)
+
+	_, err = (&PulseEntry{}).Serialize()
+	assert.Error(t, err)
+}
+
+func TestGlobuleEntry_Serialize(t *testing.T) {
+	entry := goldenGlobule()
+	for i := 0; i < 10; i++ {
+		data, err := entry.Serialize()
+		require.NoError(t, err)
+		assertGolden(t, goldenGlobuleEntry, data)
+	}
+
+	entry.ProofSet[goldenNode(3, core.StaticRoleVirtual)] = nil
+	_, err := entry.Serialize()
+	assert.Error(t, err)
+
+	_, err = (&GlobuleEntry{}).Serialize()
+	assert.Error(t, err)
+}
+
+func TestCloudEntry_Serialize(t *testing.T) {
+	second := &GlobuleProof{
+		BaseProof:     BaseProof{Signature: core.SignatureFromBytes([]byte{0xdd})},
+		PrevCloudHash: []byte{0xbe, 0xef},
+		GlobuleID:     2,
+		NodeCount:     1,
+		NodeRoot:      []byte{0x22},
+	}
+	first := &GlobuleProof{
+		BaseProof:     BaseProof{Signature: core.SignatureFromBytes([]byte{0xcc})},
+		PrevCloudHash: []byte{0xbe, 0xef},
+		GlobuleID:     1,
+		NodeCount:     2,
+		NodeRoot:      []byte{0x11},
+	}
+	entry := &CloudEntry{
+		ProofSet:      []*GlobuleProof{second, first},
+		PrevCloudHash: []byte{0xbe, 0xef},
+	}
+
+	data, err := entry.Serialize()
+	require.NoError(t, err)
+	assertGolden(t, goldenCloudEntry, data)
+	assert.Equal(t, []*GlobuleProof{second, first}, entry.ProofSet)
+}
+
+func TestGlobuleEntry_HashIsOrderIndependent(t *testing.T) {
+	helper := newMerkleHelper(platformpolicy.NewPlatformCryptographyScheme())
+	entry := goldenGlobule()
+	expected, err := entry.hash(helper)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		hash, err := goldenGlobule().hash(helper)
+		require.NoError(t, err)
+		assert.Equal(t, expected, hash)
+	}
+}
@@ -25,15 +25,33 @@ import (
 
 type tree interface {
 	Root() []byte
+	path(index int) []PathStep
+}
+
+type hashTree struct {
+	gomerkle.Tree
+}
+
+// path returns merkle path from leaf with index to the root.
+func (t *hashTree) path(index int) []PathStep {
+	var result []PathStep
+	for _, sibling := range t.GetProof(index) {
+		if hash, ok := sibling["left"]; ok {
+			result = append(result, PathStep{Left: true, Hash: hash})
+			continue
+		}
+		result = append(result, PathStep{Hash: sibling["right"]})
+	}
+	return result
 }
 
 func treeFromHashList(list [][]byte, hasher core.Hasher) (tree, error) {
-	mt := gomerkle.NewTree(hasher)
+	mt := &hashTree{Tree: gomerkle.NewTree(hasher)}
 	mt.AddHash(list...)
 
 	if err := mt.Generate(); err != nil {
 		return nil, errors.Wrap(err, "[ treeFromHashList ] Failed to generate merkle tree")
 	}
 
-	return &mt, nil
+	return mt, nil
 }
@@ -85,3 +85,16 @@ func (cq *claimQueue) Push(claim packets.ReferendumClaim) {
 
 	cq.data = append(cq.data, claim)
 }
+
+func (cq *claimQueue) Snapshot() []packets.ReferendumClaim {
+	ctx, span := instracer.StartSpan(context.Background(), "claimQueue.Snapshot wait lock")
+	cq.lock.RLock()
+	span.End()
+	_, span = instracer.StartSpan(ctx, "claimQueue.Snapshot lock")
+	defer span.End()
+	defer cq.lock.RUnlock()
+
+	result := make([]packets.ReferendumClaim, len(cq.data))
+	copy(result, cq.data)
+	return result
+}
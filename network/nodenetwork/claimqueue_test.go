@@ -56,3 +56,19 @@ func TestClaimQueue_Pop(t *testing.T) {
 	assert.Equal(t, packets.TypeNodeJoinClaim, cq.Pop().Type())
 	assert.Equal(t, packets.TypeNodeBroadcast, cq.Pop().Type())
 }
+
+func TestClaimQueue_Snapshot(t *testing.T) {
+	cq := newClaimQueue()
+	assert.Empty(t, cq.Snapshot())
+
+	cq.Push(newTestClaim(packets.TypeNodeJoinClaim))
+	cq.Push(newTestClaim(packets.TypeNodeBroadcast))
+
+	snapshot := cq.Snapshot()
+	assert.Len(t, snapshot, 2)
+	assert.Equal(t, packets.TypeNodeJoinClaim, snapshot[0].Type())
+
+	cq.Pop()
+	assert.Len(t, snapshot, 2)
+	assert.Equal(t, 1, cq.Length())
+}
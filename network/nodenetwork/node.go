@@ -29,6 +29,8 @@ type MutableNode interface {
 	core.Node
 
 	SetShortID(shortID core.ShortNodeID)
+	// SetPhysicalAddresses sets advertised addresses of node, the first one becomes PhysicalAddress.
+	SetPhysicalAddresses(addresses []string)
 }
 
 type node struct {
@@ -41,6 +43,8 @@ type node struct {
 
 	NodePhysicalAddress string
 	NodeVersion         string
+	// NodeAddresses are addresses advertised by node besides NodePhysicalAddress in order of preference
+	NodeAddresses []string
 }
 
 func newMutableNode(
@@ -88,6 +92,12 @@ func (n *node) PhysicalAddress() string {
 	return n.NodePhysicalAddress
 }
 
+func (n *node) PhysicalAddresses() []string {
+	result := make([]string, 0, len(n.NodeAddresses)+1)
+	result = append(result, n.NodePhysicalAddress)
+	return append(result, n.NodeAddresses...)
+}
+
 func (n *node) GetGlobuleID() core.GlobuleID {
 	return 0
 }
@@ -100,6 +110,19 @@ func (n *node) SetShortID(id core.ShortNodeID) {
 	n.NodeShortID = id
 }
 
+func (n *node) SetPhysicalAddresses(addresses []string) {
+	if len(addresses) == 0 {
+		return
+	}
+	n.NodePhysicalAddress = addresses[0]
+	n.NodeAddresses = nil
+	for _, address := range addresses[1:] {
+		if address != "" && address != n.NodePhysicalAddress {
+			n.NodeAddresses = append(n.NodeAddresses, address)
+		}
+	}
+}
+
 func init() {
 	gob.Register(&node{})
 }
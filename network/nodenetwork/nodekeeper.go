@@ -26,7 +26,6 @@ import (
 	"github.com/insolar/insolar/configuration"
 	consensus "github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
-	coreutils "github.com/insolar/insolar/core/utils"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
@@ -64,13 +63,24 @@ func createOrigin(configuration configuration.HostNetwork, certificate core.Cert
 	}
 
 	// TODO: get roles from certificate
-	return newMutableNode(
+	origin := newMutableNode(
 		*certificate.GetNodeRef(),
 		role,
 		certificate.GetPublicKey(),
 		publicAddress,
 		version.Version,
-	), nil
+	)
+	addresses := []string{publicAddress}
+	for _, address := range strings.Split(configuration.AdvertisedAddresses, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) > consensus.MaxNodeAddresses {
+		return nil, errors.Errorf("node can advertise at most %d addresses", consensus.MaxNodeAddresses)
+	}
+	origin.SetPhysicalAddresses(addresses)
+	return origin, nil
 }
 
 func resolveAddress(configuration configuration.HostNetwork) (string, error) {
@@ -101,9 +111,6 @@ type nodekeeper struct {
 	origin     core.Node
 	originLock sync.RWMutex
 	state      network.NodeKeeperState
-	claimQueue *claimQueue
-
-	nodesJoinedDuringPrevPulse bool
 
 	cloudHashLock sync.RWMutex
 	cloudHash     []byte
@@ -113,13 +120,17 @@ type nodekeeper struct {
 	indexNode    map[core.StaticRole]*recordRefSet
 	indexShortID map[core.ShortNodeID]core.Node
 
-	sync     network.UnsyncList
-	syncLock sync.Mutex
+	// syncLock guards sync list, claim queue and nodesJoinedDuringPrevPulse, they are replaced on Reset.
+	sync                       network.UnsyncList
+	claimQueue                 *claimQueue
+	nodesJoinedDuringPrevPulse bool
+	syncLock                   sync.Mutex
 
 	isBootstrap     bool
 	isBootstrapLock sync.RWMutex
 
-	Cryptography core.CryptographyService `inject:""`
+	Cryptography  core.CryptographyService  `inject:""`
+	FaultEvidence core.FaultEvidenceStorage `inject:""`
 }
 
 // IsBootstrapped method returns true when bootstrapNodes are connected to each other
@@ -217,6 +228,20 @@ func (nk *nodekeeper) AddActiveNodes(nodes []core.Node) {
 	log.Debugf("Added active nodes: %s", strings.Join(activeNodes, ", "))
 }
 
+func (nk *nodekeeper) RemoveActiveNodes(refs []core.RecordRef) {
+	_, span := instracer.StartSpan(context.Background(), "nodekeeper.RemoveActiveNodes wait lock")
+	nk.activeLock.Lock()
+	span.End()
+	defer nk.activeLock.Unlock()
+
+	removedNodes := make([]string, len(refs))
+	for i, ref := range refs {
+		nk.delActiveNode(ref)
+		removedNodes[i] = ref.String()
+	}
+	log.Debugf("Removed active nodes: %s", strings.Join(removedNodes, ", "))
+}
+
 func (nk *nodekeeper) GetActiveNode(ref core.RecordRef) core.Node {
 	_, span := instracer.StartSpan(context.Background(), "nodekeeper.GetActiveNode wait lock")
 	nk.activeLock.RLock()
@@ -254,14 +279,8 @@ func (nk *nodekeeper) addActiveNode(node core.Node) {
 
 func (nk *nodekeeper) delActiveNode(ref core.RecordRef) {
 	if ref.Equal(nk.origin.ID()) {
-		// we received acknowledge to leave, can gracefully stop
-
-		// graceful stop instead of panic
-		err := coreutils.SendGracefulStopSignal()
-		if err != nil {
-			// we tried :(
-			panic("Node leave acknowledged by network. Goodbye!")
-		}
+		// service network decides whether to stop or to rejoin the network
+		log.Warn("Origin node is removed from active list")
 	}
 	active, ok := nk.active[ref]
 	if !ok {
@@ -290,15 +309,24 @@ func (nk *nodekeeper) GetOriginClaim() (*consensus.NodeJoinClaim, error) {
 }
 
 func (nk *nodekeeper) AddPendingClaim(claim consensus.ReferendumClaim) bool {
+	nk.syncLock.Lock()
+	defer nk.syncLock.Unlock()
+
 	nk.claimQueue.Push(claim)
 	return true
 }
 
 func (nk *nodekeeper) GetClaimQueue() network.ClaimQueue {
+	nk.syncLock.Lock()
+	defer nk.syncLock.Unlock()
+
 	return nk.claimQueue
 }
 
 func (nk *nodekeeper) NodesJoinedDuringPreviousPulse() bool {
+	nk.syncLock.Lock()
+	defer nk.syncLock.Unlock()
+
 	return nk.nodesJoinedDuringPrevPulse
 }
 
@@ -337,6 +365,42 @@ func (nk *nodekeeper) MoveSyncToActive() {
 	sync.mergeWith(sync.claims, nk.addActiveNode, nk.delActiveNode)
 }
 
+func (nk *nodekeeper) Reset() {
+	nk.activeLock.Lock()
+	nk.active = make(map[core.RecordRef]core.Node)
+	nk.indexNode = make(map[core.StaticRole]*recordRefSet)
+	nk.indexShortID = make(map[core.ShortNodeID]core.Node)
+	nk.activeLock.Unlock()
+
+	nk.syncLock.Lock()
+	nk.sync = nil
+	nk.claimQueue = newClaimQueue()
+	nk.nodesJoinedDuringPrevPulse = false
+	nk.syncLock.Unlock()
+
+	nk.SetState(network.Undefined)
+	nk.SetCloudHash(nil)
+	nk.SetIsBootstrapped(false)
+}
+
+// AddFaultEvidence records evidence of node misbehaviour detected during consensus.
+func (nk *nodekeeper) AddFaultEvidence(ctx context.Context, evidence core.FaultEvidence) error {
+	err := nk.FaultEvidence.AddFaultEvidence(ctx, evidence)
+	if err != nil {
+		return errors.Wrapf(err, "[ AddFaultEvidence ] failed to save evidence of node %s", evidence.Node)
+	}
+	return nil
+}
+
+// GetFaultEvidence returns recorded evidences of node misbehaviour.
+func (nk *nodekeeper) GetFaultEvidence(ctx context.Context, ref core.RecordRef) ([]core.FaultEvidence, error) {
+	result, err := nk.FaultEvidence.GetFaultEvidence(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ GetFaultEvidence ] failed to get evidences of node %s", ref)
+	}
+	return result, nil
+}
+
 func (nk *nodekeeper) nodeToClaim() (*consensus.NodeJoinClaim, error) {
 	key, err := nk.Cryptography.GetPublicKey()
 	if err != nil {
@@ -356,7 +420,7 @@ func (nk *nodekeeper) nodeToClaim() (*consensus.NodeJoinClaim, error) {
 		RelayNodeID:             nk.origin.ShortID(),
 		ProtocolVersionAndFlags: 0,
 		JoinsAfter:              0,
-		NodeRoleRecID:           0, // TODO: how to get a role as int?
+		NodeRoleRecID:           uint32(nk.origin.Role()),
 		NodeRef:                 nk.origin.ID(),
 		NodePK:                  keyData,
 		Signature:               s,
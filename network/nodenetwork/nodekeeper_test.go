@@ -16,3 +16,42 @@
  */
 
 package nodenetwork
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodekeeper_Reset(t *testing.T) {
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	other := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	nk := NewNodeKeeper(origin)
+	nk.AddActiveNodes([]core.Node{origin, other})
+	nk.SetState(network.Ready)
+	nk.SetIsBootstrapped(true)
+
+	nk.Reset()
+
+	assert.Empty(t, nk.GetActiveNodes())
+	assert.Nil(t, nk.GetActiveNode(other.ID()))
+	assert.Equal(t, network.Undefined, nk.GetState())
+	assert.False(t, nk.IsBootstrapped())
+	assert.Equal(t, origin.ID(), nk.GetOrigin().ID())
+}
+
+func TestNodekeeper_RemoveActiveNodes(t *testing.T) {
+	origin := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:0", "")
+	other := newMutableNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:1", "")
+	nk := NewNodeKeeper(origin)
+	nk.AddActiveNodes([]core.Node{origin, other})
+
+	nk.RemoveActiveNodes([]core.RecordRef{other.ID(), testutils.RandomRef()})
+
+	assert.Len(t, nk.GetActiveNodes(), 1)
+	assert.Nil(t, nk.GetActiveNode(other.ID()))
+	assert.Equal(t, []core.RecordRef{origin.ID()}, nk.GetActiveNodesByRole(core.DynamicRoleVirtualExecutor))
+}
@@ -38,6 +38,7 @@ func copyMap(m map[core.RecordRef]core.Node) map[core.RecordRef]core.Node {
 type unsyncList struct {
 	activeNodes map[core.RecordRef]core.Node
 	addressMap  map[core.RecordRef]string
+	advertised  map[core.RecordRef][]string
 	claims      map[core.RecordRef][]consensus.ReferendumClaim
 	refToIndex  map[core.RecordRef]int
 	indexToRef  map[int]core.RecordRef
@@ -67,6 +68,30 @@ func (ul *unsyncList) AddClaims(claims map[core.RecordRef][]consensus.Referendum
 	ul.addressMap = addressMap
 	ul.claims = claims
 	ul.cache = nil
+
+	// address claims are sent along with join claims, they may come in any order
+	ul.advertised = make(map[core.RecordRef][]string)
+	for _, claimList := range claims {
+		for _, claim := range claimList {
+			if c, ok := claim.(*consensus.NodeAddressClaim); ok {
+				ul.advertised[c.NodeRef] = c.GetAddresses()
+			}
+		}
+	}
+}
+
+// claimToNode makes node of joining node claim with addresses the node advertised, address it was seen from
+// is used if node advertised none.
+func (ul *unsyncList) claimToNode(claim *consensus.NodeJoinClaim) (core.Node, error) {
+	// TODO: fix version
+	node, err := claimToNode(ul.addressMap[claim.NodeRef], "", claim)
+	if err != nil {
+		return nil, err
+	}
+	if addresses := ul.advertised[claim.NodeRef]; len(addresses) > 0 {
+		node.(MutableNode).SetPhysicalAddresses(addresses)
+	}
+	return node, nil
 }
 
 func (ul *unsyncList) CalculateHash() ([]byte, error) {
@@ -113,8 +138,7 @@ func (ul *unsyncList) mergeWith(claims map[core.RecordRef][]consensus.Referendum
 func (ul *unsyncList) mergeClaim(claim consensus.ReferendumClaim, addFunc adder, delFunc deleter) {
 	switch t := claim.(type) {
 	case *consensus.NodeJoinClaim:
-		// TODO: fix version
-		node, err := claimToNode(ul.addressMap[t.NodeRef], "", t)
+		node, err := ul.claimToNode(t)
 		if err != nil {
 			log.Error("[ mergeClaim ] failed to convert Claim -> Node")
 		}
@@ -189,8 +213,7 @@ func (ul *sparseUnsyncList) AddClaims(claims map[core.RecordRef][]consensus.Refe
 				log.Error("[ AddClaims ] Could not convert claim with type TypeNodeAnnounceClaim to NodeAnnounceClaim")
 			}
 
-			// TODO: fix version
-			node, err := claimToNode(ul.addressMap[c.NodeRef], "", &c.NodeJoinClaim)
+			node, err := ul.claimToNode(&c.NodeJoinClaim)
 			if err != nil {
 				log.Error("[ AddClaims ] failed to convert Claim -> Node")
 			}
@@ -0,0 +1,113 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/utils/backoff"
+)
+
+// defaultAddressBackoff is used when Table.AddressBackoff is not set.
+var defaultAddressBackoff = backoff.Backoff{Min: time.Second, Max: time.Minute, Factor: 2}
+
+type addressHealth struct {
+	failures       int
+	unhealthyUntil time.Time
+}
+
+// addressHealthTracker marks addresses that failed as unhealthy for exponentially growing period.
+type addressHealthTracker struct {
+	lock   sync.Mutex
+	now    func() time.Time
+	health map[string]*addressHealth
+}
+
+func (t *addressHealthTracker) currentTime() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+	return t.now()
+}
+
+func (t *addressHealthTracker) reportFailure(address string, b *backoff.Backoff) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.health == nil {
+		t.health = make(map[string]*addressHealth)
+	}
+	h, ok := t.health[address]
+	if !ok {
+		h = &addressHealth{}
+		t.health[address] = h
+	}
+	if b == nil {
+		b = &defaultAddressBackoff
+	}
+	h.unhealthyUntil = t.currentTime().Add(b.ForAttempt(h.failures))
+	h.failures++
+}
+
+func (t *addressHealthTracker) reportSuccess(address string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.health, address)
+}
+
+// choose returns first healthy address in preference order. If all addresses are unhealthy, the one that
+// recovers first is returned.
+func (t *addressHealthTracker) choose(addresses []string) string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(addresses) == 0 {
+		return ""
+	}
+	now := t.currentTime()
+	best := addresses[0]
+	var bestUntil time.Time
+	for i, address := range addresses {
+		h, ok := t.health[address]
+		if !ok || !now.Before(h.unhealthyUntil) {
+			return address
+		}
+		if i == 0 || h.unhealthyUntil.Before(bestUntil) {
+			best, bestUntil = address, h.unhealthyUntil
+		}
+	}
+	return best
+}
+
+// nodeAddress returns address of node that should be used to connect to it.
+func (t *Table) nodeAddress(node core.Node) string {
+	return t.addressHealth.choose(node.PhysicalAddresses())
+}
+
+// ReportFailure marks address as unhealthy, it is not used while node has other healthy addresses.
+func (t *Table) ReportFailure(address string) {
+	t.addressHealth.reportFailure(address, t.AddressBackoff)
+}
+
+// ReportSuccess marks address as healthy.
+func (t *Table) ReportSuccess(address string) {
+	t.addressHealth.reportSuccess(address)
+}
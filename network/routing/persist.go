@@ -0,0 +1,80 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package routing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/pkg/errors"
+)
+
+// knownHost is a peer saved to disk.
+type knownHost struct {
+	NodeID  string
+	ShortID core.ShortNodeID
+	Address string
+}
+
+// Save writes active nodes of the table to file at path, so table can be seeded with them after restart.
+func (t *Table) Save(path string) error {
+	nodes := t.NodeKeeper.GetActiveNodes()
+	hosts := make([]knownHost, 0, len(nodes))
+	for _, n := range nodes {
+		hosts = append(hosts, knownHost{NodeID: n.ID().String(), ShortID: n.ShortID(), Address: n.PhysicalAddress()})
+	}
+	data, err := json.MarshalIndent(hosts, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "[ Save ] failed to serialize known hosts")
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "[ Save ] failed to write known hosts")
+	}
+	return errors.Wrap(os.Rename(tmp, path), "[ Save ] failed to write known hosts")
+}
+
+// Load seeds the table with hosts saved to file at path. Missing file is not an error.
+func (t *Table) Load(path string) error {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "[ Load ] failed to read known hosts")
+	}
+	var hosts []knownHost
+	if err := json.Unmarshal(data, &hosts); err != nil {
+		return errors.Wrap(err, "[ Load ] failed to deserialize known hosts")
+	}
+	for _, h := range hosts {
+		ref, err := core.NewRefFromBase58(h.NodeID)
+		if err != nil {
+			return errors.Wrapf(err, "[ Load ] failed to parse reference of host %s", h.Address)
+		}
+		remote, err := host.NewHostNS(h.Address, *ref, h.ShortID)
+		if err != nil {
+			return errors.Wrapf(err, "[ Load ] failed to parse address of host %s", h.NodeID)
+		}
+		t.addRemoteHost(remote)
+	}
+	return nil
+}
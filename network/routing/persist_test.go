@@ -0,0 +1,67 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package routing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_SaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "routing_table.json")
+
+	node := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:31337", "")
+	node.(nodenetwork.MutableNode).SetShortID(42)
+	keeper := network.NewNodeKeeperMock(t)
+	keeper.GetActiveNodesMock.Return([]core.Node{node})
+	require.NoError(t, (&Table{NodeKeeper: keeper}).Save(path))
+
+	empty := network.NewNodeKeeperMock(t)
+	empty.GetActiveNodeMock.Return(nil)
+	empty.GetActiveNodeByShortIDMock.Return(nil)
+	empty.GetOriginMock.Return(nil)
+	table := &Table{NodeKeeper: empty}
+	require.NoError(t, table.Load(path))
+
+	h, err := table.Resolve(node.ID())
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:31337", h.Address.String())
+	require.Equal(t, core.ShortNodeID(42), h.ShortID)
+
+	h, err = table.ResolveS(42)
+	require.NoError(t, err)
+	require.Equal(t, node.ID(), h.NodeID)
+
+	_, err = table.ResolveS(43)
+	require.Error(t, err)
+}
+
+func TestTable_LoadMissingFile(t *testing.T) {
+	table := &Table{}
+	require.NoError(t, table.Load(filepath.Join(os.TempDir(), "missing_routing_table.json")))
+}
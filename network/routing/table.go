@@ -18,29 +18,138 @@
 package routing
 
 import (
+	"container/list"
+	"math/rand"
 	"strconv"
+	"sync"
 
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/utils"
+	"github.com/insolar/insolar/utils/backoff"
 	"github.com/pkg/errors"
 )
 
 type Table struct {
 	NodeKeeper network.NodeKeeper
+	// Reputation is tracker of peer scores, banned hosts are not added to table
+	Reputation *host.Reputation
+	// Gateways are nodes of current globe that forward requests to nodes of other globes
+	Gateways []core.RecordRef
+	// RemoteHostsLimit is max count of cached remote hosts, least recently used hosts are evicted, 0 for no limit
+	RemoteHostsLimit int
+	// Random is source of random nodes sampling, global source is used if nil. Set it with fixed seed in tests.
+	Random *rand.Rand
+	// AddressBackoff is period for which failed node address is considered unhealthy, default is used if nil
+	AddressBackoff *backoff.Backoff
+
+	// remoteHosts are hosts of other globes and hosts loaded from disk, used to resolve nodes missing in active list
+	remoteHosts     map[core.RecordRef]*list.Element
+	remoteHostsLRU  *list.List
+	remoteHostsLock sync.Mutex
+
+	randomLock sync.Mutex
+
+	addressHealth addressHealthTracker
+}
+
+// globeOf returns globe of node. Nodes of one globe are registered in the same node domain.
+func globeOf(ref core.RecordRef) core.RecordID {
+	return *ref.Domain()
 }
 
-func (t *Table) isLocalNode(core.RecordRef) bool {
-	return true
+// isLocalNode returns true if node belongs to current globe. Nodes of active list are always local.
+func (t *Table) isLocalNode(ref core.RecordRef) bool {
+	if t.NodeKeeper == nil || t.NodeKeeper.GetActiveNode(ref) != nil {
+		return true
+	}
+	origin := t.NodeKeeper.GetOrigin()
+	if origin == nil {
+		return true
+	}
+	return globeOf(ref) == globeOf(origin.ID())
 }
 
+// resolveRemoteNode resolves node of other globe. Node is resolved to its cached host or to address of gateway.
 func (t *Table) resolveRemoteNode(ref core.RecordRef) (*host.Host, error) {
-	return nil, errors.New("not implemented")
+	if h := t.getRemoteHost(ref); h != nil {
+		return h, nil
+	}
+	gateway, err := t.resolveGateway(ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "no such remote node with NodeID: "+ref.String())
+	}
+	return &host.Host{NodeID: ref, Address: gateway.Address}, nil
+}
+
+// resolveGateway chooses active gateway for node, requests to the same node are always routed via the same gateway
+// while set of active gateways does not change.
+func (t *Table) resolveGateway(ref core.RecordRef) (*host.Host, error) {
+	var origin core.RecordRef
+	if n := t.NodeKeeper.GetOrigin(); n != nil {
+		origin = n.ID()
+	}
+	gateways := make([]core.Node, 0, len(t.Gateways))
+	for _, g := range t.Gateways {
+		if g == origin {
+			continue
+		}
+		if n := t.NodeKeeper.GetActiveNode(g); n != nil {
+			gateways = append(gateways, n)
+		}
+	}
+	if len(gateways) == 0 {
+		return nil, errors.New("no active gateways")
+	}
+	gateway := gateways[int(utils.GenerateShortID(ref))%len(gateways)]
+	return host.NewHostNS(t.nodeAddress(gateway), gateway.ID(), gateway.ShortID())
+}
+
+func (t *Table) getRemoteHost(ref core.RecordRef) *host.Host {
+	t.remoteHostsLock.Lock()
+	defer t.remoteHostsLock.Unlock()
+
+	e, ok := t.remoteHosts[ref]
+	if !ok {
+		return nil
+	}
+	t.remoteHostsLRU.MoveToFront(e)
+	return e.Value.(*host.Host)
+}
+
+func (t *Table) resolveRemoteNodeS(id core.ShortNodeID) (*host.Host, error) {
+	t.remoteHostsLock.Lock()
+	defer t.remoteHostsLock.Unlock()
+
+	for _, e := range t.remoteHosts {
+		if h := e.Value.(*host.Host); h.ShortID == id {
+			return h, nil
+		}
+	}
+	return nil, errors.New("no such remote node with ShortID: " + strconv.FormatUint(uint64(id), 10))
 }
 
 func (t *Table) addRemoteHost(h *host.Host) {
-	log.Warn("not implemented")
+	t.remoteHostsLock.Lock()
+	defer t.remoteHostsLock.Unlock()
+
+	if t.remoteHosts == nil {
+		t.remoteHosts = make(map[core.RecordRef]*list.Element)
+		t.remoteHostsLRU = list.New()
+	}
+	if e, ok := t.remoteHosts[h.NodeID]; ok {
+		e.Value = h
+		t.remoteHostsLRU.MoveToFront(e)
+		return
+	}
+	t.remoteHosts[h.NodeID] = t.remoteHostsLRU.PushFront(h)
+	if t.RemoteHostsLimit > 0 && t.remoteHostsLRU.Len() > t.RemoteHostsLimit {
+		oldest := t.remoteHostsLRU.Back()
+		t.remoteHostsLRU.Remove(oldest)
+		delete(t.remoteHosts, oldest.Value.(*host.Host).NodeID)
+	}
 }
 
 // Resolve NodeID -> ShortID, Address. Can initiate network requests.
@@ -48,9 +157,12 @@ func (t *Table) Resolve(ref core.RecordRef) (*host.Host, error) {
 	if t.isLocalNode(ref) {
 		node := t.NodeKeeper.GetActiveNode(ref)
 		if node == nil {
+			if h := t.getRemoteHost(ref); h != nil {
+				return h, nil
+			}
 			return nil, errors.New("no such local node with NodeID: " + ref.String())
 		}
-		return host.NewHostNS(node.PhysicalAddress(), node.ID(), node.ShortID())
+		return host.NewHostNS(t.nodeAddress(node), node.ID(), node.ShortID())
 	}
 	return t.resolveRemoteNode(ref)
 }
@@ -59,9 +171,12 @@ func (t *Table) Resolve(ref core.RecordRef) (*host.Host, error) {
 func (t *Table) ResolveS(id core.ShortNodeID) (*host.Host, error) {
 	node := t.NodeKeeper.GetActiveNodeByShortID(id)
 	if node == nil {
+		if h, err := t.resolveRemoteNodeS(id); err == nil {
+			return h, nil
+		}
 		return nil, errors.New("no such local node with ShortID: " + strconv.FormatUint(uint64(id), 10))
 	}
-	return host.NewHostNS(node.PhysicalAddress(), node.ID(), node.ShortID())
+	return host.NewHostNS(t.nodeAddress(node), node.ID(), node.ShortID())
 }
 
 // AddToKnownHosts add host to routing table.
@@ -70,46 +185,70 @@ func (t *Table) AddToKnownHosts(h *host.Host) {
 		// we should already have this node in NodeNetwork active list, do nothing
 		return
 	}
+	if t.Reputation != nil && h.Address != nil && t.Reputation.IsBanned(h.Address.String()) {
+		log.Debugf("Host %s is banned, skip adding to routing table", h)
+		return
+	}
+	t.addRemoteHost(h)
+}
+
+// AddRoutingHint adds host of node learned from other nodes. Hint is used only to resolve node missing
+// in active list, node becomes active only after its join claim is accepted by consensus.
+func (t *Table) AddRoutingHint(h *host.Host) {
+	if t.NodeKeeper != nil && t.NodeKeeper.GetActiveNode(h.NodeID) != nil {
+		return
+	}
+	if t.Reputation != nil && h.Address != nil && t.Reputation.IsBanned(h.Address.String()) {
+		log.Debugf("Host %s is banned, skip adding routing hint", h)
+		return
+	}
 	t.addRemoteHost(h)
 }
 
 // GetRandomNodes get a specified number of random nodes. Returns less if there are not enough nodes in network.
-func (t *Table) GetRandomNodes(count int) []host.Host {
-	// TODO: this workaround returns all nodes
-	nodes := t.NodeKeeper.GetActiveNodes()
-	result := make([]host.Host, 0)
-	for _, n := range nodes {
-		address, err := host.NewAddress(n.PhysicalAddress())
+// Nodes from exclude list are never returned.
+func (t *Table) GetRandomNodes(count int, exclude []core.RecordRef) []host.Host {
+	if count <= 0 {
+		return nil
+	}
+	excluded := make(map[core.RecordRef]struct{}, len(exclude))
+	for _, ref := range exclude {
+		excluded[ref] = struct{}{}
+	}
+
+	t.randomLock.Lock()
+	defer t.randomLock.Unlock()
+
+	// reservoir sampling: i-th suitable node replaces random element of result with probability count/(i+1)
+	result := make([]host.Host, 0, count)
+	i := 0
+	for _, n := range t.NodeKeeper.GetActiveNodes() {
+		if _, ok := excluded[n.ID()]; ok {
+			continue
+		}
+		address, err := host.NewAddress(t.nodeAddress(n))
 		if err != nil {
 			log.Error(err)
 			continue
 		}
-		result = append(result, host.Host{NodeID: n.ID(), Address: address})
-	}
-
-	// TODO: original implementation
-	/*
-		// not so random for now
-		nodes := t.NodeKeeper.GetActiveNodes()
-		//return nodes
-		resultCount := count
-		if count > len(nodes) {
-			resultCount = len(nodes)
-		}
-		result := make([]host.Host, 0)
-		for i := 0; i < resultCount; i++ {
-			address, err := host.NewAddress(nodes[i].PhysicalAddress())
-			if err != nil {
-				log.Error(err)
-				continue
-			}
-			h := host.Host{NodeID: nodes[i].ID(), Address: address}
+		h := host.Host{NodeID: n.ID(), Address: address}
+		if i < count {
 			result = append(result, h)
+		} else if j := t.intn(i + 1); j < count {
+			result[j] = h
 		}
-	*/
+		i++
+	}
 	return result
 }
 
+func (t *Table) intn(n int) int {
+	if t.Random == nil {
+		return rand.Intn(n)
+	}
+	return t.Random.Intn(n)
+}
+
 // Rebalance recreate shards of routing table with known hosts according to new partition policy.
 func (t *Table) Rebalance(network.PartitionPolicy) {
 	log.Warn("not implemented")
@@ -0,0 +1,235 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package routing
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/insolar/insolar/utils/backoff"
+	"github.com/stretchr/testify/require"
+)
+
+func newGlobeNode(domain core.RecordID, address string) core.Node {
+	return nodenetwork.NewNode(*core.NewRecordRef(domain, testutils.RandomID()), core.StaticRoleVirtual, nil, address, "")
+}
+
+func newGlobeTable(t *testing.T, origin core.Node, active ...core.Node) *Table {
+	keeper := network.NewNodeKeeperMock(t)
+	keeper.GetOriginMock.Return(origin)
+	keeper.GetActiveNodeFunc = func(ref core.RecordRef) core.Node {
+		for _, n := range active {
+			if n.ID() == ref {
+				return n
+			}
+		}
+		return nil
+	}
+	return &Table{NodeKeeper: keeper}
+}
+
+func TestTable_ResolveRemoteGlobe(t *testing.T) {
+	localGlobe := testutils.RandomID()
+	remoteGlobe := testutils.RandomID()
+
+	origin := newGlobeNode(localGlobe, "127.0.0.1:1000")
+	gateway := newGlobeNode(localGlobe, "127.0.0.1:1001")
+	remote := newGlobeNode(remoteGlobe, "127.0.0.1:2000")
+
+	table := newGlobeTable(t, origin, origin, gateway)
+	require.True(t, table.isLocalNode(gateway.ID()))
+	require.True(t, table.isLocalNode(*core.NewRecordRef(localGlobe, testutils.RandomID())))
+	require.False(t, table.isLocalNode(remote.ID()))
+
+	// no gateways configured
+	_, err := table.Resolve(remote.ID())
+	require.Error(t, err)
+
+	// origin is never used as gateway
+	table.Gateways = []core.RecordRef{origin.ID()}
+	_, err = table.Resolve(remote.ID())
+	require.Error(t, err)
+
+	table.Gateways = []core.RecordRef{origin.ID(), gateway.ID()}
+	h, err := table.Resolve(remote.ID())
+	require.NoError(t, err)
+	require.Equal(t, remote.ID(), h.NodeID)
+	require.Equal(t, "127.0.0.1:1001", h.Address.String())
+
+	// host learned from incoming request is used instead of gateway
+	remoteHost, err := host.NewHostNS(remote.PhysicalAddress(), remote.ID(), remote.ShortID())
+	require.NoError(t, err)
+	table.AddToKnownHosts(remoteHost)
+	h, err = table.Resolve(remote.ID())
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:2000", h.Address.String())
+
+	// hosts of current globe are not cached
+	gatewayHost, err := host.NewHostNS(gateway.PhysicalAddress(), gateway.ID(), gateway.ShortID())
+	require.NoError(t, err)
+	table.AddToKnownHosts(gatewayHost)
+	require.Nil(t, table.getRemoteHost(gateway.ID()))
+}
+
+func TestTable_AddRoutingHint(t *testing.T) {
+	globe := testutils.RandomID()
+	origin := newGlobeNode(globe, "127.0.0.1:1000")
+	active := newGlobeNode(globe, "127.0.0.1:1001")
+	joiner := newGlobeNode(globe, "127.0.0.1:1002")
+
+	table := newGlobeTable(t, origin, origin, active)
+	_, err := table.Resolve(joiner.ID())
+	require.Error(t, err)
+
+	joinerHost, err := host.NewHostNS(joiner.PhysicalAddress(), joiner.ID(), joiner.ShortID())
+	require.NoError(t, err)
+	table.AddRoutingHint(joinerHost)
+	h, err := table.Resolve(joiner.ID())
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:1002", h.Address.String())
+
+	// hint never overrides address of active node
+	activeHost, err := host.NewHostNS("127.0.0.1:3000", active.ID(), active.ShortID())
+	require.NoError(t, err)
+	table.AddRoutingHint(activeHost)
+	h, err = table.Resolve(active.ID())
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:1001", h.Address.String())
+}
+
+func TestTable_RemoteHostsEviction(t *testing.T) {
+	table := &Table{RemoteHostsLimit: 2}
+
+	hosts := make([]*host.Host, 0, 3)
+	for i := 0; i < 3; i++ {
+		h, err := host.NewHostNS("127.0.0.1:3000", testutils.RandomRef(), core.ShortNodeID(i))
+		require.NoError(t, err)
+		hosts = append(hosts, h)
+	}
+
+	table.addRemoteHost(hosts[0])
+	table.addRemoteHost(hosts[1])
+	// access makes host recently used
+	require.NotNil(t, table.getRemoteHost(hosts[0].NodeID))
+	table.addRemoteHost(hosts[2])
+
+	require.NotNil(t, table.getRemoteHost(hosts[0].NodeID))
+	require.Nil(t, table.getRemoteHost(hosts[1].NodeID))
+	require.NotNil(t, table.getRemoteHost(hosts[2].NodeID))
+
+	// re-adding known host does not evict others
+	table.addRemoteHost(hosts[2])
+	require.NotNil(t, table.getRemoteHost(hosts[0].NodeID))
+	require.NotNil(t, table.getRemoteHost(hosts[2].NodeID))
+
+	_, err := table.resolveRemoteNodeS(1)
+	require.Error(t, err)
+	h, err := table.resolveRemoteNodeS(2)
+	require.NoError(t, err)
+	require.Equal(t, hosts[2].NodeID, h.NodeID)
+}
+
+func TestTable_GetRandomNodes(t *testing.T) {
+	domain := testutils.RandomID()
+	nodes := make([]core.Node, 0, 10)
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, newGlobeNode(domain, "127.0.0.1:"+strconv.Itoa(4000+i)))
+	}
+	keeper := network.NewNodeKeeperMock(t)
+	keeper.GetActiveNodesMock.Return(nodes)
+
+	sample := func(seed int64, count int, exclude []core.RecordRef) []host.Host {
+		table := &Table{NodeKeeper: keeper, Random: rand.New(rand.NewSource(seed))}
+		return table.GetRandomNodes(count, exclude)
+	}
+
+	require.Empty(t, sample(1, 0, nil))
+	require.Len(t, sample(1, 20, nil), len(nodes))
+
+	exclude := []core.RecordRef{nodes[0].ID(), nodes[5].ID()}
+	result := sample(1, 20, exclude)
+	require.Len(t, result, len(nodes)-len(exclude))
+
+	result = sample(1, 3, exclude)
+	require.Len(t, result, 3)
+	unique := make(map[core.RecordRef]struct{})
+	for _, h := range result {
+		require.NotEqual(t, nodes[0].ID(), h.NodeID)
+		require.NotEqual(t, nodes[5].ID(), h.NodeID)
+		unique[h.NodeID] = struct{}{}
+	}
+	require.Len(t, unique, 3)
+
+	// the same seed gives the same sample
+	require.Equal(t, result, sample(1, 3, exclude))
+
+	// every node is sampled eventually
+	seen := make(map[core.RecordRef]struct{})
+	for seed := int64(0); seed < 100; seed++ {
+		for _, h := range sample(seed, 1, nil) {
+			seen[h.NodeID] = struct{}{}
+		}
+	}
+	require.Len(t, seen, len(nodes))
+}
+
+func TestTable_ResolveAddressFailover(t *testing.T) {
+	domain := testutils.RandomID()
+	origin := newGlobeNode(domain, "127.0.0.1:1000")
+	n := newGlobeNode(domain, "127.0.0.1:5000")
+	n.(nodenetwork.MutableNode).SetPhysicalAddresses([]string{"127.0.0.1:5000", "127.0.0.1:5001", "127.0.0.1:5002"})
+
+	table := newGlobeTable(t, origin, origin, n)
+	now := time.Now()
+	table.addressHealth.now = func() time.Time { return now }
+	table.AddressBackoff = &backoff.Backoff{Min: time.Second, Max: time.Minute}
+
+	resolve := func() string {
+		h, err := table.Resolve(n.ID())
+		require.NoError(t, err)
+		return h.Address.String()
+	}
+
+	require.Equal(t, "127.0.0.1:5000", resolve())
+
+	table.ReportFailure("127.0.0.1:5000")
+	require.Equal(t, "127.0.0.1:5001", resolve())
+
+	table.ReportFailure("127.0.0.1:5001")
+	table.ReportFailure("127.0.0.1:5001")
+	require.Equal(t, "127.0.0.1:5002", resolve())
+
+	// all addresses are unhealthy, the one that recovers first is used
+	table.ReportFailure("127.0.0.1:5002")
+	require.Equal(t, "127.0.0.1:5000", resolve())
+
+	// preferred address is used again after it recovers
+	now = now.Add(time.Second)
+	require.Equal(t, "127.0.0.1:5000", resolve())
+
+	table.ReportFailure("127.0.0.1:5000")
+	table.ReportSuccess("127.0.0.1:5001")
+	require.Equal(t, "127.0.0.1:5001", resolve())
+}
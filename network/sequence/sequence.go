@@ -18,6 +18,9 @@
 package sequence
 
 import (
+	"crypto/rand"
+	"encoding/binary"
+
 	"github.com/insolar/insolar/network/utils"
 )
 
@@ -31,9 +34,16 @@ type generatorImpl struct {
 	sequence *uint64
 }
 
+// NewGeneratorImpl creates generator starting from random value on each boot, so request ids sent by node after
+// restart don't repeat ids it sent before and receivers don't drop them as replayed.
 func NewGeneratorImpl() Generator {
+	start := new(uint64)
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err == nil {
+		*start = binary.BigEndian.Uint64(nonce[:])
+	}
 	return &generatorImpl{
-		sequence: new(uint64),
+		sequence: start,
 	}
 }
 
@@ -0,0 +1,166 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/pkg/errors"
+)
+
+const (
+	drillIsolated   = "isolated"
+	drillRecovering = "recovering"
+	drillFinished   = "finished"
+	drillAborted    = "aborted"
+)
+
+// drillRecoveryPulses is count of pulses drill waits for node to leave safe mode after isolation is healed.
+const drillRecoveryPulses = 10
+
+// partitionDrill isolates node from peers for count of pulses using fault injection layer of transports
+// and watches safe mode entry and recovery of node.
+type partitionDrill struct {
+	faults []*host.Faults
+	now    func() time.Time
+
+	lock      sync.Mutex
+	report    *core.PartitionDrillReport
+	remaining int
+}
+
+func newPartitionDrill(faults ...*host.Faults) *partitionDrill {
+	return &partitionDrill{faults: faults, now: time.Now}
+}
+
+// start isolates addresses of peers for count of pulses.
+func (d *partitionDrill) start(peers []core.RecordRef, addresses []string, pulses int, pulse core.PulseNumber) error {
+	if pulses <= 0 {
+		return errors.New("count of pulses must be positive")
+	}
+	if len(addresses) == 0 {
+		return errors.New("no peers to isolate from")
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.isRunning() {
+		return errors.New("partition drill is already running")
+	}
+	for _, f := range d.faults {
+		f.Isolate(addresses)
+	}
+	d.remaining = pulses
+	d.report = &core.PartitionDrillReport{
+		Peers:      peers,
+		Pulses:     pulses,
+		Status:     drillIsolated,
+		Started:    d.now(),
+		StartPulse: pulse,
+	}
+	return nil
+}
+
+// stop aborts running drill.
+func (d *partitionDrill) stop() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if !d.isRunning() {
+		return errors.New("partition drill is not running")
+	}
+	d.finish(drillAborted)
+	return nil
+}
+
+func (d *partitionDrill) running() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.isRunning()
+}
+
+func (d *partitionDrill) isRunning() bool {
+	return d.report != nil && (d.report.Status == drillIsolated || d.report.Status == drillRecovering)
+}
+
+// onPulse counts pulses of isolation and watches safe mode of node on pulse.
+func (d *partitionDrill) onPulse(pulse core.PulseNumber, safeMode bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if !d.isRunning() {
+		return
+	}
+	if safeMode && !d.report.SafeModeEntered {
+		d.report.SafeModeEntered = true
+		d.report.SafeModePulse = pulse
+	}
+
+	switch d.report.Status {
+	case drillIsolated:
+		d.remaining--
+		if d.remaining > 0 {
+			return
+		}
+		d.heal()
+		d.report.Status = drillRecovering
+		d.report.HealPulse = pulse
+		d.remaining = drillRecoveryPulses
+	case drillRecovering:
+		if !safeMode {
+			d.report.Recovered = true
+			d.report.RecoveryPulse = pulse
+			d.finish(drillFinished)
+			return
+		}
+		d.remaining--
+		if d.remaining <= 0 {
+			d.finish(drillFinished)
+		}
+	}
+}
+
+func (d *partitionDrill) heal() {
+	for _, f := range d.faults {
+		f.Heal()
+	}
+}
+
+func (d *partitionDrill) finish(status string) {
+	d.heal()
+	d.report.Status = status
+	d.report.Finished = d.now()
+}
+
+// getReport returns copy of the last drill report.
+func (d *partitionDrill) getReport() *core.PartitionDrillReport {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.report == nil {
+		return nil
+	}
+	report := *d.report
+	report.Peers = append([]core.RecordRef{}, d.report.Peers...)
+	return &report
+}
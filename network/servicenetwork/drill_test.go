@@ -0,0 +1,68 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionDrill(t *testing.T) {
+	faults := host.NewFaults()
+	drill := newPartitionDrill(faults)
+	assert.Nil(t, drill.getReport())
+
+	peer := testutils.RandomRef()
+	require.Error(t, drill.start([]core.RecordRef{peer}, []string{"127.0.0.1:1"}, 0, 1))
+	require.NoError(t, drill.start([]core.RecordRef{peer}, []string{"127.0.0.1:1"}, 2, 1))
+	require.Error(t, drill.start([]core.RecordRef{peer}, []string{"127.0.0.1:1"}, 2, 1))
+	assert.True(t, faults.IsIsolated("127.0.0.1:1"))
+
+	drill.onPulse(2, false)
+	drill.onPulse(3, true)
+	report := drill.getReport()
+	assert.Equal(t, drillRecovering, report.Status)
+	assert.Equal(t, core.PulseNumber(3), report.HealPulse)
+	assert.True(t, report.SafeModeEntered)
+	assert.Equal(t, core.PulseNumber(3), report.SafeModePulse)
+	assert.False(t, faults.IsIsolated("127.0.0.1:1"))
+
+	drill.onPulse(4, true)
+	drill.onPulse(5, false)
+	report = drill.getReport()
+	assert.Equal(t, drillFinished, report.Status)
+	assert.True(t, report.Recovered)
+	assert.Equal(t, core.PulseNumber(5), report.RecoveryPulse)
+	assert.False(t, drill.running())
+}
+
+func TestPartitionDrill_Stop(t *testing.T) {
+	faults := host.NewFaults()
+	drill := newPartitionDrill(faults)
+	require.Error(t, drill.stop())
+
+	require.NoError(t, drill.start([]core.RecordRef{testutils.RandomRef()}, []string{"127.0.0.1:1"}, 5, 1))
+	require.NoError(t, drill.stop())
+	assert.Equal(t, drillAborted, drill.getReport().Status)
+	assert.False(t, faults.IsIsolated("127.0.0.1:1"))
+}
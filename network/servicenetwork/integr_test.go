@@ -33,6 +33,7 @@ import (
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/transport"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
 	"github.com/stretchr/testify/assert"
@@ -47,14 +48,22 @@ type testSuite struct {
 	networkNodes   []networkNode
 	testNode       networkNode
 	networkPort    int
+	// network connects nodes in memory, it simulates latency, loss and partitions
+	network *transport.MemoryNetwork
 }
 
+// memoryNetworkName is name nodes of test suite find their in-memory network by.
+const memoryNetworkName = "servicenetwork"
+
 func NewTestSuite() *testSuite {
+	network := transport.NewMemoryNetwork(1)
+	transport.RegisterMemoryNetwork(memoryNetworkName, network)
 	return &testSuite{
 		Suite:        suite.Suite{},
 		ctx:          context.Background(),
 		networkNodes: make([]networkNode, 0),
 		networkPort:  10001,
+		network:      network,
 	}
 }
 func (s *testSuite) InitNodes() {
@@ -164,6 +173,8 @@ func (s *testSuite) createNetworkNode(t *testing.T) networkNode {
 
 	cfg := configuration.NewConfiguration()
 	cfg.Host.Transport.Address = address
+	cfg.Host.Transport.Protocol = "MEMORY"
+	cfg.Host.Transport.MemoryNetwork = memoryNetworkName
 
 	pulseManagerMock := testutils.NewPulseManagerMock(t)
 	netCoordinator := testutils.NewNetworkCoordinatorMock(t)
@@ -180,7 +191,11 @@ func (s *testSuite) createNetworkNode(t *testing.T) networkNode {
 	keeper := &nodeKeeperWrapper{realKeeper}
 
 	cm := &component.Manager{}
-	cm.Register(keeper, pulseManagerMock, netCoordinator, amMock, realKeeper)
+	faultEvidence := testutils.NewFaultEvidenceStorageMock(t)
+	faultEvidence.AddFaultEvidenceMock.Return(nil)
+	cloudHashes := testutils.NewCloudHashStorageMock(t)
+	cloudHashes.SaveCloudHashMock.Return(nil)
+	cm.Register(keeper, pulseManagerMock, netCoordinator, amMock, realKeeper, faultEvidence, cloudHashes)
 	cm.Register(certManager, cryptographyService)
 	cm.Inject(netSwitcher)
 
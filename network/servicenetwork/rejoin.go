@@ -0,0 +1,106 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package servicenetwork
+
+import (
+	"sync"
+)
+
+type rejoinState int
+
+const (
+	// rejoinJoining means that origin has not appeared in active list yet
+	rejoinJoining = rejoinState(iota + 1)
+	// rejoinJoined means that origin is in active list
+	rejoinJoined
+	// rejoinExpelled means that origin was removed from active list or resync was requested and rejoin is needed
+	rejoinExpelled
+	// rejoinRunning means that rejoin is in progress
+	rejoinRunning
+)
+
+// rejoiner tracks presence of origin in active list and detects expulsion of node from the network.
+type rejoiner struct {
+	lock      sync.Mutex
+	state     rejoinState
+	missed    int
+	threshold int
+}
+
+func newRejoiner(threshold int) *rejoiner {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &rejoiner{state: rejoinJoining, threshold: threshold}
+}
+
+// onPulse updates state with presence of origin in active list on new pulse.
+// Returns true if node is expelled and rejoin should be started, state is switched to running then.
+func (r *rejoiner) onPulse(active bool) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	switch r.state {
+	case rejoinJoining:
+		if active {
+			r.state = rejoinJoined
+		}
+	case rejoinJoined:
+		if active {
+			r.missed = 0
+			return false
+		}
+		r.missed++
+		if r.missed < r.threshold {
+			return false
+		}
+		r.missed = 0
+		r.state = rejoinRunning
+		return true
+	case rejoinExpelled:
+		// previous attempt failed, retry
+		r.state = rejoinRunning
+		return true
+	}
+	return false
+}
+
+// resync requests rejoin of joined node on the next pulse. Returns false if node is not joined yet
+// or rejoin is already requested.
+func (r *rejoiner) resync() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.state != rejoinJoined {
+		return false
+	}
+	r.missed = 0
+	r.state = rejoinExpelled
+	return true
+}
+
+// finish completes rejoin, failed rejoin is retried on the next pulse.
+func (r *rejoiner) finish(success bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if success {
+		r.state = rejoinJoining
+	} else {
+		r.state = rejoinExpelled
+	}
+}
@@ -19,6 +19,9 @@ package servicenetwork
 
 import (
 	"context"
+	"crypto"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -26,26 +29,44 @@ import (
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/consensus/phases"
 	"github.com/insolar/insolar/core"
+	coreutils "github.com/insolar/insolar/core/utils"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/cascade"
 	"github.com/insolar/insolar/network/controller"
 	"github.com/insolar/insolar/network/controller/bootstrap"
 	"github.com/insolar/insolar/network/hostnetwork"
 	"github.com/insolar/insolar/network/merkle"
 	"github.com/insolar/insolar/network/routing"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 )
 
+const (
+	// maxCapturedPacketsPerPeer is max count of packets captured for each peer.
+	maxCapturedPacketsPerPeer = 10000
+	// maxCapturedPayloadSize is max size of captured payload.
+	maxCapturedPayloadSize = 64 * 1024
+)
+
 // ServiceNetwork is facade for network.
 type ServiceNetwork struct {
 	cfg configuration.Configuration
 	cm  *component.Manager
 
-	hostNetwork  network.HostNetwork  // TODO: should be injected
-	routingTable network.RoutingTable // TODO: should be injected
+	hostNetwork  network.HostNetwork // TODO: should be injected
+	routingTable *routing.Table      // TODO: should be injected
+	reputation   *host.Reputation
+	accessLists  []*host.AccessList
+	captures     []*host.Capture
+	rejoiner     *rejoiner
+	drill        *partitionDrill
 
 	// dependencies
 	CertificateManager  core.CertificateManager         `inject:""`
@@ -61,6 +82,9 @@ type ServiceNetwork struct {
 	PhaseManager phases.PhaseManager `inject:"subcomponent"`
 	Controller   network.Controller  `inject:"subcomponent"`
 
+	Standby     bootstrap.StandbyController      `inject:"subcomponent"`
+	AntiEntropy controller.AntiEntropyController `inject:"subcomponent"`
+
 	// fakePulsar *fakepulsar.FakePulsar
 	isGenesis bool
 	skip      int
@@ -69,12 +93,13 @@ type ServiceNetwork struct {
 // NewServiceNetwork returns a new ServiceNetwork.
 func NewServiceNetwork(conf configuration.Configuration, scheme core.PlatformCryptographyScheme, rootCm *component.Manager, isGenesis bool) (*ServiceNetwork, error) {
 	serviceNetwork := &ServiceNetwork{cm: component.NewManager(rootCm), cfg: conf, CryptographyScheme: scheme, isGenesis: isGenesis, skip: conf.Service.Skip}
+	serviceNetwork.rejoiner = newRejoiner(conf.Service.RejoinMissedPulses)
 	return serviceNetwork, nil
 }
 
 // SendMessage sends a message from MessageBus.
-func (n *ServiceNetwork) SendMessage(nodeID core.RecordRef, method string, msg core.Parcel) ([]byte, error) {
-	return n.Controller.SendMessage(nodeID, method, msg)
+func (n *ServiceNetwork) SendMessage(ctx context.Context, nodeID core.RecordRef, method string, msg core.Parcel) ([]byte, error) {
+	return n.Controller.SendMessage(ctx, nodeID, method, msg)
 }
 
 // SendCascadeMessage sends a message from MessageBus to a cascade of nodes
@@ -87,13 +112,174 @@ func (n *ServiceNetwork) RemoteProcedureRegister(name string, method core.Remote
 	n.Controller.RemoteProcedureRegister(name, method)
 }
 
+// GetBannedHosts returns hosts banned for misbehaviour.
+func (n *ServiceNetwork) GetBannedHosts() []core.BannedHost {
+	if n.reputation == nil {
+		return []core.BannedHost{}
+	}
+	return n.reputation.Banned()
+}
+
+// UnbanHost removes host with address from ban list.
+func (n *ServiceNetwork) UnbanHost(address string) bool {
+	if n.reputation == nil {
+		return false
+	}
+	return n.reputation.Unban(address)
+}
+
+// GetAccessRules returns rules of allow and deny lists of peers.
+func (n *ServiceNetwork) GetAccessRules() (allow []string, deny []string) {
+	if len(n.accessLists) == 0 {
+		return []string{}, []string{}
+	}
+	return n.accessLists[0].Rules()
+}
+
+// SetAccessRules replaces rules of allow and deny lists of peers in all transports.
+func (n *ServiceNetwork) SetAccessRules(allow []string, deny []string) error {
+	if len(n.accessLists) == 0 {
+		return errors.New("network is not initialized")
+	}
+	for _, l := range n.accessLists {
+		if err := l.Update(allow, deny); err != nil {
+			return errors.Wrap(err, "failed to update access lists")
+		}
+	}
+	return nil
+}
+
+// StartPacketCapture starts capturing the last packets of each peer by all transports.
+func (n *ServiceNetwork) StartPacketCapture(perPeer int, payloadSize int) error {
+	if perPeer <= 0 || perPeer > maxCapturedPacketsPerPeer {
+		return errors.Errorf("count of packets per peer must be from 1 to %d", maxCapturedPacketsPerPeer)
+	}
+	if payloadSize < 0 || payloadSize > maxCapturedPayloadSize {
+		return errors.Errorf("payload size must be from 0 to %d", maxCapturedPayloadSize)
+	}
+	if len(n.captures) == 0 {
+		return errors.New("network is not initialized")
+	}
+	for _, c := range n.captures {
+		c.Start(perPeer, payloadSize)
+	}
+	return nil
+}
+
+// StopPacketCapture stops capturing packets by all transports.
+func (n *ServiceNetwork) StopPacketCapture() {
+	for _, c := range n.captures {
+		c.Stop()
+	}
+}
+
+// GetCapturedPackets returns packets captured by all transports ordered by time. Peer is address or reference of
+// node, packets of all peers are returned if it is empty.
+func (n *ServiceNetwork) GetCapturedPackets(peer string) []core.CapturedPacket {
+	result := make([]core.CapturedPacket, 0)
+	for _, c := range n.captures {
+		for _, p := range c.Packets("") {
+			if peer == "" || p.Address == peer || p.Sender.String() == peer || p.Receiver.String() == peer {
+				result = append(result, p)
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result
+}
+
+// PromoteStandby promotes standby discovery node after its primary failed.
+func (n *ServiceNetwork) PromoteStandby(ctx context.Context) error {
+	return n.Standby.Promote(ctx)
+}
+
+// IsStandbyPromoted returns true if standby discovery node was promoted.
+func (n *ServiceNetwork) IsStandbyPromoted() bool {
+	return n.Standby.IsPromoted()
+}
+
+// StartPartitionDrill isolates node from peers for count of pulses, packets to and from peers are dropped by transports.
+func (n *ServiceNetwork) StartPartitionDrill(ctx context.Context, peers []core.RecordRef, pulses int) error {
+	if !n.cfg.Service.PartitionDrill {
+		return errors.New("partition drills are disabled in configuration")
+	}
+	if n.drill == nil {
+		return errors.New("network is not initialized")
+	}
+	origin := n.NodeKeeper.GetOrigin().ID()
+	addresses := make([]string, 0, 2*len(peers))
+	for _, peer := range peers {
+		if peer.Equal(origin) {
+			return errors.New("node can't be isolated from itself")
+		}
+		node := n.NodeKeeper.GetActiveNode(peer)
+		if node == nil {
+			return errors.Errorf("peer %s is not in active list", peer)
+		}
+		consensusAddress, err := incrementPort(node.PhysicalAddress())
+		if err != nil {
+			return errors.Wrapf(err, "failed to get consensus address of peer %s", peer)
+		}
+		addresses = append(addresses, node.PhysicalAddress(), consensusAddress)
+	}
+
+	var pulse core.PulseNumber
+	if current, err := n.PulseStorage.Current(ctx); err == nil {
+		pulse = current.PulseNumber
+	}
+	if err := n.drill.start(peers, addresses, pulses, pulse); err != nil {
+		return errors.Wrap(err, "failed to start partition drill")
+	}
+	inslogger.FromContext(ctx).Warnf("Partition drill started: node is isolated from %d peers for %d pulses", len(peers), pulses)
+	return nil
+}
+
+// StopPartitionDrill heals isolation and aborts running drill.
+func (n *ServiceNetwork) StopPartitionDrill(ctx context.Context) error {
+	if n.drill == nil {
+		return errors.New("network is not initialized")
+	}
+	if err := n.drill.stop(); err != nil {
+		return err
+	}
+	inslogger.FromContext(ctx).Warn("Partition drill aborted")
+	return nil
+}
+
+// GetPartitionDrillReport returns report of the last partition drill.
+func (n *ServiceNetwork) GetPartitionDrillReport() *core.PartitionDrillReport {
+	if n.drill == nil {
+		return nil
+	}
+	return n.drill.getReport()
+}
+
+// GetConsensusTimeline returns timeline of consensus phases executed on pulse.
+func (n *ServiceNetwork) GetConsensusTimeline(pulse core.PulseNumber) *core.ConsensusTimeline {
+	return n.PhaseManager.Timeline(pulse)
+}
+
+// observeDrill passes safe mode of node on pulse to running partition drill. Node works in safe mode
+// when it is not bootstrapped, is absent in active list or network is not in complete state.
+func (n *ServiceNetwork) observeDrill(pulse core.PulseNumber) {
+	if n.drill == nil || !n.drill.running() {
+		return
+	}
+	safeMode := !n.NodeKeeper.IsBootstrapped() ||
+		n.NodeKeeper.GetActiveNode(n.NodeKeeper.GetOrigin().ID()) == nil ||
+		n.NetworkSwitcher.GetState() != core.CompleteNetworkState
+	n.drill.onPulse(pulse, safeMode)
+}
+
 // incrementPort increments port number if it not equals 0
 func incrementPort(address string) (string, error) {
-	parts := strings.Split(address, ":")
-	if len(parts) < 2 {
-		return address, errors.New("failed to get port from address")
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, errors.Wrap(err, "failed to get port from address")
 	}
-	port, err := strconv.Atoi(parts[len(parts)-1])
+	port, err := strconv.Atoi(portStr)
 	if err != nil {
 		return address, err
 	}
@@ -102,17 +288,58 @@ func incrementPort(address string) (string, error) {
 		port++
 	}
 
-	parts = append(parts[:len(parts)-1], strconv.Itoa(port))
-	return strings.Join(parts, ":"), nil
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+// parseGateways parses comma separated list of node references.
+func parseGateways(list string) ([]core.RecordRef, error) {
+	result := make([]core.RecordRef, 0)
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		ref, err := core.NewRefFromBase58(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad reference %s", s)
+		}
+		result = append(result, *ref)
+	}
+	return result, nil
+}
+
+// authenticatedKey returns public key of active node, packets of other nodes must be signed with key sent in packet.
+func (n *ServiceNetwork) authenticatedKey(ref core.RecordRef) crypto.PublicKey {
+	node := n.NodeKeeper.GetActiveNode(ref)
+	if node == nil {
+		return nil
+	}
+	return node.PublicKey()
 }
 
 // Start implements component.Initer
 func (n *ServiceNetwork) Init(ctx context.Context) error {
-	n.routingTable = &routing.Table{}
+	err := bootstrap.RegisterPayloads(packet.Payloads)
+	if err != nil {
+		return errors.Wrap(err, "Failed to register bootstrap payloads")
+	}
 	internalTransport, err := hostnetwork.NewInternalTransport(n.cfg, n.CertificateManager.GetCertificate().GetNodeRef().String())
 	if err != nil {
 		return errors.Wrap(err, "Failed to create internal transport")
 	}
+	if n.cfg.Host.Transport.SignPackets {
+		internalTransport.SetKeyResolver(n.authenticatedKey)
+	}
+	n.reputation = internalTransport.Reputation()
+	gateways, err := parseGateways(n.cfg.Host.GlobeGateways)
+	if err != nil {
+		return errors.Wrap(err, "Failed to parse globe gateways")
+	}
+	n.routingTable = &routing.Table{
+		Reputation:       n.reputation,
+		Gateways:         gateways,
+		RemoteHostsLimit: n.cfg.Host.RemoteHostsCacheSize,
+	}
 
 	// workaround for Consensus transport, port+=1 of default transport
 	n.cfg.Host.Transport.Address, err = incrementPort(n.cfg.Host.Transport.Address)
@@ -120,8 +347,8 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		return errors.Wrap(err, "failed to increment port.")
 	}
 
-	consensusNetwork, err := hostnetwork.NewConsensusNetwork(
-		n.cfg.Host.Transport.Address,
+	consensusNetwork, err := hostnetwork.NewConsensusNetworkWithConfig(
+		n.cfg.Host.Transport,
 		n.CertificateManager.GetCertificate().GetNodeRef().String(),
 		n.NodeKeeper.GetOrigin().ShortID(),
 		n.routingTable,
@@ -130,6 +357,15 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		return errors.Wrap(err, "Failed to create consensus network.")
 	}
 
+	// consensus packets go through send queue of internal transport, so they are prioritized over replication traffic
+	consensusNetwork.SetSendQueue(internalTransport.SendQueue())
+	n.drill = newPartitionDrill(internalTransport.Faults(), consensusNetwork.Faults())
+	// consensus transport is created without configuration, it shares rules of internal transport
+	n.accessLists = []*host.AccessList{internalTransport.AccessList(), consensusNetwork.AccessList()}
+	n.captures = []*host.Capture{internalTransport.Capture(), consensusNetwork.Capture()}
+	if err := n.SetAccessRules(internalTransport.AccessList().Rules()); err != nil {
+		return errors.Wrap(err, "Failed to configure access lists")
+	}
 	n.hostNetwork = hostnetwork.NewHostTransport(internalTransport, n.routingTable)
 	options := controller.ConfigureOptions(n.cfg.Host)
 
@@ -146,10 +382,15 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		bootstrap.NewSessionManager(),
 		controller.NewNetworkController(n.hostNetwork),
 		controller.NewRPCController(options, n.hostNetwork),
+		cascade.NewReplicationPolicy(options.CascadePolicy),
 		controller.NewPulseController(n.hostNetwork, n.routingTable),
+		controller.NewPeerExchangeController(options, n.hostNetwork, n.routingTable),
+		controller.NewGossipController(options, n.hostNetwork, n.routingTable),
+		controller.NewAntiEntropyController(options, n.hostNetwork, n.routingTable),
 		bootstrap.NewBootstrapper(options, internalTransport),
 		bootstrap.NewAuthorizationController(options, internalTransport),
 		bootstrap.NewChallengeResponseController(options, internalTransport),
+		bootstrap.NewStandbyController(options, internalTransport),
 		bootstrap.NewNetworkBootstrapper(),
 	)
 
@@ -162,6 +403,11 @@ func (n *ServiceNetwork) Start(ctx context.Context) error {
 	log.Infoln("Network starts listening...")
 	n.hostNetwork.Start(ctx)
 	n.routingTable.Inject(n.NodeKeeper)
+	if n.cfg.Host.RoutingTablePath != "" {
+		if err := n.routingTable.Load(n.cfg.Host.RoutingTablePath); err != nil {
+			log.Warn("Failed to seed routing table with saved hosts: ", err.Error())
+		}
+	}
 
 	log.Info("Starting network component manager...")
 	err := n.cm.Start(ctx)
@@ -184,6 +430,12 @@ func (n *ServiceNetwork) Start(ctx context.Context) error {
 func (n *ServiceNetwork) Stop(ctx context.Context) error {
 	logger := inslogger.FromContext(ctx)
 
+	if n.cfg.Host.RoutingTablePath != "" {
+		logger.Info("Saving routing table")
+		if err := n.routingTable.Save(n.cfg.Host.RoutingTablePath); err != nil {
+			logger.Error("Failed to save routing table: ", err.Error())
+		}
+	}
 	logger.Info("Stopping network components")
 	if err := n.cm.Stop(ctx); err != nil {
 		log.Errorf("Error while stopping network components: %s", err.Error())
@@ -214,6 +466,8 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, pulse core.Pulse) {
 		logger.Error("PulseManager is not initialized")
 		return
 	}
+	n.checkExpulsion(ctx)
+	n.observeDrill(pulse.PulseNumber)
 	if !n.NodeKeeper.IsBootstrapped() {
 		n.Controller.SetLastIgnoredPulse(pulse.NextPulseNumber)
 		return
@@ -243,6 +497,7 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, pulse core.Pulse) {
 		}
 
 		logger.Infof("Set new current pulse number: %d", pulse.PulseNumber)
+		n.AntiEntropy.OnPulse(ctx, pulse)
 		// go func(logger core.Logger, network *ServiceNetwork) {
 		// 	TODO: make PhaseManager works and uncomment this (after NETD18-75)
 		// 	err = n.PhaseManager.OnPulse(ctx, &pulse)
@@ -255,6 +510,58 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, pulse core.Pulse) {
 	}
 }
 
+// checkExpulsion detects that node was expelled from active list or requested resync and starts rejoin to the network.
+func (n *ServiceNetwork) checkExpulsion(ctx context.Context) {
+	origin := n.NodeKeeper.GetOrigin()
+	if !n.rejoiner.onPulse(n.NodeKeeper.GetActiveNode(origin.ID()) != nil) {
+		return
+	}
+	if !n.cfg.Service.Rejoin {
+		inslogger.FromContext(ctx).Error("Node is expelled from active list, stopping")
+		err := coreutils.SendGracefulStopSignal()
+		if err != nil {
+			panic("Node is expelled from network. Goodbye!")
+		}
+		return
+	}
+	go n.rejoin(ctx)
+}
+
+// Resync implements network.Resyncer. Node rejoins network on the next pulse, so its active list is restored
+// by bootstrap and consensus.
+func (n *ServiceNetwork) Resync(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	if !n.cfg.Service.Rejoin {
+		logger.Warn("Node state diverged from network, but rejoin is disabled")
+		return
+	}
+	if n.rejoiner.resync() {
+		logger.Warn("Node state diverged from network, rejoin is scheduled on the next pulse")
+	}
+}
+
+// rejoin resets node state and runs bootstrap and authorization again.
+func (n *ServiceNetwork) rejoin(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	logger.Warn("Rejoining network")
+
+	n.NodeKeeper.Reset()
+	if utils.OriginIsDiscovery(n.CertificateManager.GetCertificate()) {
+		n.NodeKeeper.AddActiveNodes([]core.Node{n.NodeKeeper.GetOrigin()})
+	}
+
+	err := n.Controller.Bootstrap(ctx)
+	if err != nil {
+		metrics.NetworkRejoinAttempts.WithLabelValues("fail").Inc()
+		logger.Error(errors.Wrap(err, "Failed to rejoin network, will retry on next pulse"))
+		n.rejoiner.finish(false)
+		return
+	}
+	metrics.NetworkRejoinAttempts.WithLabelValues("success").Inc()
+	logger.Info("Node rejoined network")
+	n.rejoiner.finish(true)
+}
+
 // func (n *ServiceNetwork) isFakePulse(pulse *core.Pulse) bool {
 // 	return (pulse.NextPulseNumber == 0) && (pulse.PulseNumber == 0)
 // }
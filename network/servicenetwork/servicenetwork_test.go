@@ -32,6 +32,10 @@ func TestNewServiceNetwork_incrementPort(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "[::]:8081", addr)
 
+	addr, err = incrementPort("[2001:db8::1]:8080")
+	assert.NoError(t, err)
+	assert.Equal(t, "[2001:db8::1]:8081", addr)
+
 	addr, err = incrementPort("0.0.0.0:0")
 	assert.NoError(t, err)
 	assert.Equal(t, "0.0.0.0:0", addr)
@@ -143,7 +147,7 @@ func TestServiceNetwork_SendMessage(t *testing.T) {
 	require.NoError(t, err)
 
 	ref := testutils.RandomRef()
-	serviceNetwork.SendMessage(ref, "test", parcel)
+	serviceNetwork.SendMessage(ctx, ref, "test", parcel)
 }
 
 func mockServiceConfiguration(host string, bootstrapHosts []string, nodeID string) configuration.Configuration {
@@ -216,7 +220,7 @@ func TestServiceNetwork_SendMessage2(t *testing.T) {
 	parcel, err := pf.Create(ctx, e, firstNode.GetNodeID(), nil)
 	require.NoError(t, err)
 
-	firstNode.SendMessage(core.NewRefFromBase58(secondNodeId), "test", parcel)
+	firstNode.SendMessage(ctx, core.NewRefFromBase58(secondNodeId), "test", parcel)
 	success := utils.WaitTimeout(&wg, 100*time.Millisecond)
 
 	require.True(t, success)
@@ -290,10 +294,6 @@ func TestServiceNetwork_SendCascadeMessage(t *testing.T) {
 
 	err = firstNode.SendCascadeMessage(c, "test", nil)
 	require.Error(t, err)
-	c.ReplicationFactor = 0
-	err = firstNode.SendCascadeMessage(c, "test", parcel)
-	require.Error(t, err)
-	c.ReplicationFactor = 2
 	c.NodeIds = nil
 	err = firstNode.SendCascadeMessage(c, "test", parcel)
 	require.Error(t, err)
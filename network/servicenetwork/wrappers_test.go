@@ -19,6 +19,7 @@ package servicenetwork
 
 import (
 	"context"
+	"time"
 
 	consensus "github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/consensus/phases"
@@ -44,6 +45,14 @@ func (p *phaseManagerWrapper) OnPulse(ctx context.Context, pulse *core.Pulse) er
 	return res
 }
 
+func (p *phaseManagerWrapper) RegisterHook(name string, hook interface{}, budget time.Duration) error {
+	return p.original.RegisterHook(name, hook, budget)
+}
+
+func (p *phaseManagerWrapper) HookClaims(ctx context.Context, pulse *core.Pulse) []consensus.ReferendumClaim {
+	return p.original.HookClaims(ctx, pulse)
+}
+
 func (n *nodeKeeperWrapper) GetOrigin() core.Node {
 	return n.original.GetOrigin()
 }
@@ -125,3 +134,19 @@ func (n *nodeKeeperWrapper) Sync(list network.UnsyncList) {
 func (n *nodeKeeperWrapper) MoveSyncToActive() {
 	n.original.MoveSyncToActive()
 }
+
+func (n *nodeKeeperWrapper) RemoveActiveNodes(refs []core.RecordRef) {
+	n.original.RemoveActiveNodes(refs)
+}
+
+func (n *nodeKeeperWrapper) Reset() {
+	n.original.Reset()
+}
+
+func (n *nodeKeeperWrapper) AddFaultEvidence(ctx context.Context, evidence core.FaultEvidence) error {
+	return n.original.AddFaultEvidence(ctx, evidence)
+}
+
+func (n *nodeKeeperWrapper) GetFaultEvidence(ctx context.Context, ref core.RecordRef) ([]core.FaultEvidence, error) {
+	return n.original.GetFaultEvidence(ctx, ref)
+}
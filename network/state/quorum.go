@@ -0,0 +1,116 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package state
+
+import (
+	"sort"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/core"
+)
+
+// QuorumEvaluator classifies active node list against majority rule and min roles rule from certificate.
+type QuorumEvaluator struct {
+	majorityRule int
+	minRoles     map[core.StaticRole]int
+}
+
+// NewQuorumEvaluator creates evaluator with majority rule and min roles rule.
+func NewQuorumEvaluator(majorityRule int, minRoles map[core.StaticRole]int) *QuorumEvaluator {
+	return &QuorumEvaluator{
+		majorityRule: majorityRule,
+		minRoles:     minRoles,
+	}
+}
+
+// NewQuorumEvaluatorFromCertificate creates evaluator with rules from certificate. Certificates without
+// network rules produce evaluator that accepts any active node list.
+func NewQuorumEvaluatorFromCertificate(cert core.Certificate) *QuorumEvaluator {
+	c, ok := cert.(*certificate.Certificate)
+	if !ok {
+		return NewQuorumEvaluator(0, nil)
+	}
+	return NewQuorumEvaluator(c.MajorityRule, map[core.StaticRole]int{
+		core.StaticRoleVirtual:       int(c.MinRoles.Virtual),
+		core.StaticRoleHeavyMaterial: int(c.MinRoles.HeavyMaterial),
+		core.StaticRoleLightMaterial: int(c.MinRoles.LightMaterial),
+	})
+}
+
+// Evaluate checks active nodes. Majority rule is checked first, then nodes are grouped by globe and the largest
+// globe must satisfy majority rule alone, then min roles rule is checked on nodes of the largest globe.
+func (qe *QuorumEvaluator) Evaluate(nodes []core.Node) core.QuorumResult {
+	if len(nodes) < qe.majorityRule {
+		return core.QuorumResult{
+			Status:   core.QuorumBelowMajority,
+			Active:   len(nodes),
+			Required: qe.majorityRule,
+		}
+	}
+
+	globes := make(map[core.GlobuleID][]core.Node)
+	for _, n := range nodes {
+		globes[n.GetGlobuleID()] = append(globes[n.GetGlobuleID()], n)
+	}
+	var largest []core.Node
+	for _, globe := range globes {
+		if len(globe) > len(largest) {
+			largest = globe
+		}
+	}
+	if len(globes) > 1 && len(largest) < qe.majorityRule {
+		return core.QuorumResult{
+			Status:   core.QuorumSplitGlobes,
+			Active:   len(largest),
+			Required: qe.majorityRule,
+			Globes:   len(globes),
+		}
+	}
+
+	roles := make(map[core.StaticRole]int)
+	for _, n := range largest {
+		roles[n.Role()]++
+	}
+	for _, role := range qe.sortedRoles() {
+		if roles[role] < qe.minRoles[role] {
+			return core.QuorumResult{
+				Status:   core.QuorumBelowMinRoles,
+				Active:   roles[role],
+				Required: qe.minRoles[role],
+				Role:     role,
+				Globes:   len(globes),
+			}
+		}
+	}
+
+	return core.QuorumResult{
+		Status:   core.QuorumMet,
+		Active:   len(largest),
+		Required: qe.majorityRule,
+		Globes:   len(globes),
+	}
+}
+
+func (qe *QuorumEvaluator) sortedRoles() []core.StaticRole {
+	roles := make([]core.StaticRole, 0, len(qe.minRoles))
+	for role := range qe.minRoles {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+	return roles
+}
@@ -0,0 +1,126 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package state
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func newQuorumNode(t *testing.T, role core.StaticRole, globe core.GlobuleID) core.Node {
+	n := network.NewNodeMock(t)
+	n.RoleMock.Return(role)
+	n.GetGlobuleIDMock.Return(globe)
+	return n
+}
+
+func newQuorumNodes(t *testing.T, count int, role core.StaticRole, globe core.GlobuleID) []core.Node {
+	nodes := make([]core.Node, 0, count)
+	for i := 0; i < count; i++ {
+		nodes = append(nodes, newQuorumNode(t, role, globe))
+	}
+	return nodes
+}
+
+func TestQuorumEvaluator_Majority(t *testing.T) {
+	qe := NewQuorumEvaluator(3, nil)
+
+	result := qe.Evaluate(newQuorumNodes(t, 2, core.StaticRoleVirtual, 0))
+	assert.Equal(t, core.QuorumResult{Status: core.QuorumBelowMajority, Active: 2, Required: 3}, result)
+
+	// exactly majority is enough
+	result = qe.Evaluate(newQuorumNodes(t, 3, core.StaticRoleVirtual, 0))
+	assert.Equal(t, core.QuorumMet, result.Status)
+	assert.Equal(t, 3, result.Active)
+	assert.Equal(t, 1, result.Globes)
+
+	result = qe.Evaluate(nil)
+	assert.Equal(t, core.QuorumBelowMajority, result.Status)
+	assert.Equal(t, 0, result.Active)
+}
+
+func TestQuorumEvaluator_NoRules(t *testing.T) {
+	qe := NewQuorumEvaluator(0, nil)
+
+	assert.Equal(t, core.QuorumMet, qe.Evaluate(nil).Status)
+
+	nodes := append(newQuorumNodes(t, 1, core.StaticRoleVirtual, 1), newQuorumNodes(t, 1, core.StaticRoleVirtual, 2)...)
+	assert.Equal(t, core.QuorumMet, qe.Evaluate(nodes).Status)
+}
+
+func TestQuorumEvaluator_SplitGlobes(t *testing.T) {
+	qe := NewQuorumEvaluator(3, nil)
+
+	// 4 nodes satisfy majority in total, but neither globe does
+	nodes := append(newQuorumNodes(t, 2, core.StaticRoleVirtual, 1), newQuorumNodes(t, 2, core.StaticRoleVirtual, 2)...)
+	result := qe.Evaluate(nodes)
+	assert.Equal(t, core.QuorumResult{Status: core.QuorumSplitGlobes, Active: 2, Required: 3, Globes: 2}, result)
+
+	// largest globe satisfies majority alone
+	nodes = append(newQuorumNodes(t, 3, core.StaticRoleVirtual, 1), newQuorumNodes(t, 1, core.StaticRoleVirtual, 2)...)
+	result = qe.Evaluate(nodes)
+	assert.Equal(t, core.QuorumMet, result.Status)
+	assert.Equal(t, 3, result.Active)
+	assert.Equal(t, 2, result.Globes)
+}
+
+func TestQuorumEvaluator_MinRoles(t *testing.T) {
+	qe := NewQuorumEvaluator(2, map[core.StaticRole]int{
+		core.StaticRoleVirtual:       2,
+		core.StaticRoleHeavyMaterial: 1,
+		core.StaticRoleLightMaterial: 1,
+	})
+
+	nodes := append(newQuorumNodes(t, 2, core.StaticRoleVirtual, 0), newQuorumNode(t, core.StaticRoleLightMaterial, 0))
+	result := qe.Evaluate(nodes)
+	assert.Equal(t, core.QuorumResult{
+		Status:   core.QuorumBelowMinRoles,
+		Active:   0,
+		Required: 1,
+		Role:     core.StaticRoleHeavyMaterial,
+		Globes:   1,
+	}, result)
+
+	nodes = append(nodes, newQuorumNode(t, core.StaticRoleHeavyMaterial, 0))
+	assert.Equal(t, core.QuorumMet, qe.Evaluate(nodes).Status)
+
+	// roles are counted only in the largest globe
+	nodes = append(newQuorumNodes(t, 3, core.StaticRoleVirtual, 1), newQuorumNode(t, core.StaticRoleHeavyMaterial, 2))
+	nodes = append(nodes, newQuorumNode(t, core.StaticRoleLightMaterial, 1))
+	result = qe.Evaluate(nodes)
+	assert.Equal(t, core.QuorumBelowMinRoles, result.Status)
+	assert.Equal(t, core.StaticRoleHeavyMaterial, result.Role)
+}
+
+func TestNewQuorumEvaluatorFromCertificate(t *testing.T) {
+	cert := &certificate.Certificate{MajorityRule: 2}
+	cert.MinRoles.Virtual = 1
+
+	qe := NewQuorumEvaluatorFromCertificate(cert)
+	assert.Equal(t, 2, qe.majorityRule)
+	assert.Equal(t, 1, qe.minRoles[core.StaticRoleVirtual])
+
+	// certificate without network rules accepts any list
+	qe = NewQuorumEvaluatorFromCertificate(testutils.NewCertificateMock(t))
+	assert.Equal(t, core.QuorumMet, qe.Evaluate(nil).Status)
+}
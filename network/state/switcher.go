@@ -40,10 +40,12 @@ type NetworkSwitcher struct {
 	NodeNetwork        core.NodeNetwork        `inject:""`
 	SwitcherWorkAround core.SwitcherWorkAround `inject:""`
 	MBLocker           messageBusLocker        `inject:""`
+	CertificateManager core.CertificateManager `inject:""`
 
 	counter uint64
 
 	state     core.NetworkState
+	quorum    core.QuorumResult
 	stateLock sync.RWMutex
 	span      *trace.Span
 }
@@ -65,6 +67,14 @@ func (ns *NetworkSwitcher) GetState() core.NetworkState {
 	return ns.state
 }
 
+// GetQuorum method returns result of majority rule evaluation on last pulse
+func (ns *NetworkSwitcher) GetQuorum() core.QuorumResult {
+	ns.stateLock.RLock()
+	defer ns.stateLock.RUnlock()
+
+	return ns.quorum
+}
+
 // OnPulse method checks current state and finds out reasons to update this state
 func (ns *NetworkSwitcher) OnPulse(ctx context.Context, pulse core.Pulse) error {
 	ns.stateLock.Lock()
@@ -77,6 +87,8 @@ func (ns *NetworkSwitcher) OnPulse(ctx context.Context, pulse core.Pulse) error
 	defer span.End()
 	inslogger.FromContext(ctx).Infof("Current NetworkSwitcher state is: %s", ns.state)
 
+	ns.evaluateQuorum(ctx)
+
 	if ns.SwitcherWorkAround.IsBootstrapped() && ns.state != core.CompleteNetworkState {
 		ns.state = core.CompleteNetworkState
 		ns.Release(ctx)
@@ -87,6 +99,30 @@ func (ns *NetworkSwitcher) OnPulse(ctx context.Context, pulse core.Pulse) error
 	return nil
 }
 
+func (ns *NetworkSwitcher) evaluateQuorum(ctx context.Context) {
+	evaluator := NewQuorumEvaluatorFromCertificate(ns.CertificateManager.GetCertificate())
+	quorum := evaluator.Evaluate(ns.NodeNetwork.GetActiveNodes())
+	if quorum.Status == ns.quorum.Status {
+		ns.quorum = quorum
+		return
+	}
+	ns.quorum = quorum
+
+	logger := inslogger.FromContext(ctx)
+	switch quorum.Status {
+	case core.QuorumMet:
+		logger.Infof("Quorum is met: %d active nodes, majority rule %d", quorum.Active, quorum.Required)
+	case core.QuorumBelowMinRoles:
+		logger.Warnf("Quorum is lost: %s, %d active nodes of role %s, %d required",
+			quorum.Status, quorum.Active, quorum.Role, quorum.Required)
+	case core.QuorumSplitGlobes:
+		logger.Warnf("Quorum is lost: %s, %d globes, largest globe has %d nodes, %d required",
+			quorum.Status, quorum.Globes, quorum.Active, quorum.Required)
+	default:
+		logger.Warnf("Quorum is lost: %s, %d active nodes, %d required", quorum.Status, quorum.Active, quorum.Required)
+	}
+}
+
 // Acquire increases lock counter and locks message bus if it wasn't lock before
 func (ns *NetworkSwitcher) Acquire(ctx context.Context) {
 	ctx, span := instracer.StartSpan(ctx, "NetworkSwitcher.Acquire")
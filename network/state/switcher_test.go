@@ -24,6 +24,7 @@ import (
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/network"
 	"github.com/stretchr/testify/require"
 )
@@ -42,20 +43,34 @@ func mockMessageBusLocker(t *testing.T) *messageBusLockerMock {
 	return mblMock
 }
 
+func mockCertificateManager(t *testing.T) *testutils.CertificateManagerMock {
+	cmMock := testutils.NewCertificateManagerMock(t)
+	cmMock.GetCertificateMock.Return(testutils.NewCertificateMock(t))
+	return cmMock
+}
+
+func mockNodeNetwork(t *testing.T) *network.NodeNetworkMock {
+	nnMock := network.NewNodeNetworkMock(t)
+	nnMock.GetActiveNodesMock.Return(nil)
+	return nnMock
+}
+
 func TestNewNetworkSwitcher(t *testing.T) {
-	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet := mockNodeNetwork(t)
 	switcherWorkAround := mockSwitcherWorkAround(t, false)
 	messageBusLocker := mockMessageBusLocker(t)
+	certManager := mockCertificateManager(t)
 
 	switcher, err := NewNetworkSwitcher()
 	require.NoError(t, err)
 
 	cm := &component.Manager{}
-	cm.Inject(nodeNet, switcherWorkAround, messageBusLocker, switcher)
+	cm.Inject(nodeNet, switcherWorkAround, messageBusLocker, certManager, switcher)
 
 	require.Equal(t, nodeNet, switcher.NodeNetwork)
 	require.Equal(t, switcherWorkAround, switcher.SwitcherWorkAround)
 	require.Equal(t, messageBusLocker, switcher.MBLocker)
+	require.Equal(t, certManager, switcher.CertificateManager)
 	require.Equal(t, core.NoNetworkState, switcher.state)
 	require.Equal(t, sync.RWMutex{}, switcher.stateLock)
 }
@@ -72,11 +87,12 @@ func TestOnPulseNoChange(t *testing.T) {
 	switcher, err := NewNetworkSwitcher()
 	require.NoError(t, err)
 	switcherWorkAround := mockSwitcherWorkAround(t, false)
-	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet := mockNodeNetwork(t)
 	messageBusLocker := mockMessageBusLocker(t)
+	certManager := mockCertificateManager(t)
 
 	cm := &component.Manager{}
-	cm.Inject(switcherWorkAround, switcher, nodeNet, messageBusLocker)
+	cm.Inject(switcherWorkAround, switcher, nodeNet, messageBusLocker, certManager)
 
 	err = switcher.OnPulse(context.Background(), core.Pulse{})
 	require.NoError(t, err)
@@ -88,27 +104,30 @@ func TestOnPulseStateChanged(t *testing.T) {
 	switcher, err := NewNetworkSwitcher()
 	require.NoError(t, err)
 	switcherWorkAround := mockSwitcherWorkAround(t, true)
-	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet := mockNodeNetwork(t)
 	messageBusLocker := mockMessageBusLocker(t)
+	certManager := mockCertificateManager(t)
 
 	cm := &component.Manager{}
-	cm.Inject(switcherWorkAround, switcher, nodeNet, messageBusLocker)
+	cm.Inject(switcherWorkAround, switcher, nodeNet, messageBusLocker, certManager)
 
 	err = switcher.OnPulse(context.Background(), core.Pulse{})
 	require.NoError(t, err)
 	require.Equal(t, core.CompleteNetworkState, switcher.state)
 	require.Equal(t, uint64(1), messageBusLocker.UnlockCounter)
+	require.Equal(t, core.QuorumMet, switcher.GetQuorum().Status)
 }
 
 func TestGetStateAfterStateChanged(t *testing.T) {
 	switcher, err := NewNetworkSwitcher()
 	require.NoError(t, err)
 	switcherWorkAround := mockSwitcherWorkAround(t, true)
-	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet := mockNodeNetwork(t)
 	messageBusLocker := mockMessageBusLocker(t)
+	certManager := mockCertificateManager(t)
 
 	cm := &component.Manager{}
-	cm.Inject(switcherWorkAround, switcher, nodeNet, messageBusLocker)
+	cm.Inject(switcherWorkAround, switcher, nodeNet, messageBusLocker, certManager)
 
 	err = switcher.OnPulse(context.Background(), core.Pulse{})
 	require.NoError(t, err)
@@ -19,15 +19,19 @@ package transport
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/pkg/errors"
 )
@@ -37,10 +41,17 @@ type transportSerializer interface {
 	DeserializePacket(conn io.Reader) (*packet.Packet, error)
 }
 
-type baseSerializer struct{}
+// codecLearner is serializer which chooses codecs for peers from headers of packets received from them.
+type codecLearner interface {
+	learnCodecs(remote string, accepted []byte)
+}
+
+type baseSerializer struct {
+	signer packet.Signer
+}
 
 func (b *baseSerializer) SerializePacket(q *packet.Packet) ([]byte, error) {
-	return packet.SerializePacket(q)
+	return packet.SerializePacketNegotiated(q, packet.SerializeOptions{Signer: b.signer})
 }
 
 func (b *baseSerializer) DeserializePacket(conn io.Reader) (*packet.Packet, error) {
@@ -52,6 +63,16 @@ type baseTransport struct {
 	serializer    transportSerializer
 	proxy         relay.Proxy
 	packetHandler packetHandler
+	reputation    *host.Reputation
+	faults        *host.Faults
+	accessList    *host.AccessList
+	replayCache   *host.ReplayCache
+	capture       *host.Capture
+	verifier      *packetVerifier
+	// sendQueue is nil if packets are sent by callers without queue
+	sendQueue *host.SendQueue
+	// sharedSendQueue is true if send queue is set by SetSendQueue and is closed by its owner
+	sharedSendQueue bool
 
 	disconnectStarted  chan bool
 	disconnectFinished chan bool
@@ -59,7 +80,7 @@ type baseTransport struct {
 	mutex *sync.RWMutex
 
 	publicAddress string
-	sendFunc      func(recvAddress string, data []byte) error
+	sendFunc      func(recvAddress string, packetType types.PacketType, data []byte) error
 }
 
 func newBaseTransport(proxy relay.Proxy, publicAddress string) baseTransport {
@@ -69,6 +90,11 @@ func newBaseTransport(proxy relay.Proxy, publicAddress string) baseTransport {
 		packetHandler: newPacketHandler(futureManager),
 		proxy:         proxy,
 		serializer:    &baseSerializer{},
+		reputation:    host.NewReputation(0, 0),
+		faults:        host.NewFaults(),
+		accessList:    &host.AccessList{},
+		replayCache:   host.NewReplayCache(0, 0),
+		capture:       host.NewCapture(),
 
 		mutex: &sync.RWMutex{},
 
@@ -88,7 +114,25 @@ func (t *baseTransport) SendRequest(ctx context.Context, msg *packet.Packet) (Fu
 		return nil, errors.Wrap(err, "Failed to send transport packet")
 	}
 	metrics.NetworkPacketSentTotal.WithLabelValues(msg.Type.String()).Inc()
-	return future, nil
+	return &reputationFuture{Future: future, reputation: t.reputation}, nil
+}
+
+// reputationFuture penalizes actor if response is not received in time.
+type reputationFuture struct {
+	Future
+	reputation *host.Reputation
+}
+
+func (f *reputationFuture) GetResult(duration time.Duration) (*packet.Packet, error) {
+	return f.GetResultContext(context.Background(), duration)
+}
+
+func (f *reputationFuture) GetResultContext(ctx context.Context, duration time.Duration) (*packet.Packet, error) {
+	result, err := f.Future.GetResultContext(ctx, duration)
+	if err == ErrTimeout && f.Actor().Address != nil {
+		f.reputation.Penalize(f.Actor().Address.String(), host.OffenceTimeout)
+	}
+	return result, err
 }
 
 // SendResponse sends response packet.
@@ -103,6 +147,9 @@ func (t *baseTransport) Close() {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
+	if t.sendQueue != nil && !t.sharedSendQueue {
+		t.sendQueue.Close()
+	}
 	close(t.disconnectFinished)
 }
 
@@ -125,7 +172,144 @@ func (t *baseTransport) prepareDisconnect() {
 }
 
 func (t *baseTransport) getRemoteAddress(conn net.Conn) string {
-	return strings.Split(conn.RemoteAddr().String(), ":")[0]
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
+// Reputation returns tracker of peer scores.
+func (t *baseTransport) Reputation() *host.Reputation {
+	return t.reputation
+}
+
+// isBanned checks if packets from address should be dropped.
+func (t *baseTransport) isBanned(address net.Addr) bool {
+	if !t.reputation.IsBanned(address.String()) {
+		return false
+	}
+	metrics.NetworkPacketDroppedBannedTotal.Inc()
+	return true
+}
+
+// Faults returns fault injection layer of transport.
+func (t *baseTransport) Faults() *host.Faults {
+	return t.faults
+}
+
+// AccessList returns allow and deny lists of peers.
+func (t *baseTransport) AccessList() *host.AccessList {
+	return t.accessList
+}
+
+// Capture returns packet capture of transport.
+func (t *baseTransport) Capture() *host.Capture {
+	return t.capture
+}
+
+// SendQueue returns priority queue of outgoing packets, nil if packets are sent without queue.
+func (t *baseTransport) SendQueue() *host.SendQueue {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.sendQueue
+}
+
+// SetSendQueue makes transport send packets through queue of another transport, queue created by transport
+// configuration is closed.
+func (t *baseTransport) SetSendQueue(queue *host.SendQueue) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.sendQueue != nil && !t.sharedSendQueue && t.sendQueue != queue {
+		t.sendQueue.Close()
+	}
+	t.sendQueue = queue
+	t.sharedSendQueue = true
+}
+
+// capturePacket captures packet sent to or received from peer with address if capture is enabled.
+func (t *baseTransport) capturePacket(msg *packet.Packet, address string, outgoing bool) {
+	if !t.capture.IsEnabled() {
+		return
+	}
+	captured := core.CapturedPacket{
+		Time:       time.Now(),
+		Outgoing:   outgoing,
+		Address:    address,
+		Type:       msg.Type.String(),
+		RequestID:  uint64(msg.RequestID),
+		TraceID:    msg.TraceID,
+		IsResponse: msg.IsResponse,
+	}
+	if msg.Sender != nil {
+		captured.Sender = msg.Sender.NodeID
+	}
+	if msg.Receiver != nil {
+		captured.Receiver = msg.Receiver.NodeID
+	}
+	t.capture.Add(captured, func() string {
+		if msg.Error != nil {
+			return fmt.Sprintf("error: %s", msg.Error)
+		}
+		return fmt.Sprintf("%+v", msg.Data)
+	})
+}
+
+// isDenied checks if packets from address are rejected by access list, it is checked before packet is deserialized.
+func (t *baseTransport) isDenied(address net.Addr) bool {
+	if t.accessList.IsAddressAllowed(address.String()) {
+		return false
+	}
+	metrics.NetworkPacketDroppedDeniedTotal.Inc()
+	return true
+}
+
+// isReplayed checks if request was already received from the same sender. Sender reference is trusted only if
+// packet is signed by authenticated sender, otherwise request is also keyed by IP of connection it came from.
+// Responses are not checked, duplicated response finds no future and is dropped anyway.
+func (t *baseTransport) isReplayed(msg *packet.Packet, remote net.Addr, authenticated bool) bool {
+	if msg.IsResponse || msg.Sender == nil || msg.Sender.NodeID.IsEmpty() {
+		return false
+	}
+	var origin string
+	if !authenticated {
+		origin = remote.String()
+		if ip, _, err := net.SplitHostPort(origin); err == nil {
+			origin = ip
+		}
+	}
+	return t.replayCache.IsReplayed(msg.Sender.NodeID, origin, uint64(msg.RequestID))
+}
+
+// handlePacket passes incoming packet to handler unless sender is isolated by fault injection. Remote is address
+// of connection packet is received from, it replaces address reported by sender in packet.
+func (t *baseTransport) handlePacket(ctx context.Context, msg *packet.Packet, remote net.Addr) {
+	msg.RemoteAddress = remote.String()
+	t.capturePacket(msg, msg.RemoteAddress, false)
+	if msg.Sender != nil && msg.Sender.Address != nil && t.faults.IsIsolated(msg.Sender.Address.String()) {
+		metrics.NetworkPacketDroppedFaultTotal.Inc()
+		return
+	}
+	if msg.Sender != nil && !msg.Sender.NodeID.IsEmpty() && !t.accessList.IsNodeAllowed(msg.Sender.NodeID) {
+		metrics.NetworkPacketDroppedDeniedTotal.Inc()
+		return
+	}
+	forged, authenticated := t.isForged(msg, remote)
+	if forged {
+		inslogger.FromContext(ctx).Warnf("Drop %s packet from %s with missing or invalid signature", msg.Type, msg.Sender)
+		return
+	}
+	if learner, ok := t.serializer.(codecLearner); ok {
+		learner.learnCodecs(msg.RemoteAddress, msg.Accepted().Codecs)
+	}
+	if t.isReplayed(msg, remote, authenticated) {
+		inslogger.FromContext(ctx).Debugf("Drop duplicated %s request from %s with RequestID = %d", msg.Type, msg.Sender, msg.RequestID)
+		metrics.NetworkPacketDroppedDuplicateTotal.Inc()
+		return
+	}
+	t.packetHandler.Handle(ctx, msg)
 }
 
 // PublicAddress returns transport public ip address
@@ -141,12 +325,22 @@ func (t *baseTransport) SendPacket(ctx context.Context, p *packet.Packet) error
 	if len(recvAddress) == 0 {
 		recvAddress = p.Receiver.Address.String()
 	}
+	if t.faults.IsIsolated(p.Receiver.Address.String()) {
+		metrics.NetworkPacketDroppedFaultTotal.Inc()
+		return errors.New("packet is dropped by fault injection, receiver is isolated")
+	}
 
 	data, err := t.serializer.SerializePacket(p)
 	if err != nil {
 		return errors.Wrap(err, "Failed to serialize packet")
 	}
+	t.capturePacket(p, p.Receiver.Address.String(), true)
 
 	inslogger.FromContext(ctx).Debugf("Send %s packet to %s with RequestID = %d", p.Type, recvAddress, p.RequestID)
-	return t.sendFunc(recvAddress, data)
+	if queue := t.SendQueue(); queue != nil {
+		return queue.Send(packetPriority(p.Type), func() error {
+			return t.sendFunc(recvAddress, p.Type, data)
+		})
+	}
+	return t.sendFunc(recvAddress, p.Type, data)
 }
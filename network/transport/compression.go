@@ -0,0 +1,161 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/pkg/errors"
+)
+
+// compressionMagic starts compression handshake on tcp connection. It can't be confused with length of a packet,
+// because it would be a packet larger than packet.MaxPacketSize.
+var compressionMagic = [8]byte{0xFF, 0xFF, 0xFF, 0xFF, 'I', 'C', 'M', 'P'}
+
+const (
+	// codecNone is id of codec that leaves data as is.
+	codecNone byte = 0
+
+	handshakeTimeout = 5 * time.Second
+)
+
+type compressionCodec = packet.Codec
+
+// compressionCodecs are codecs known by transport.
+var compressionCodecs = packet.Codecs
+
+// compressor holds codecs enabled on the local node.
+type compressor struct {
+	codecs []compressionCodec
+}
+
+// newCompressor creates compressor with codecs from list of names in order of preference.
+// Returns nil if list is empty.
+func newCompressor(names []string) (*compressor, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	c := &compressor{}
+	for _, name := range names {
+		codec, ok := compressionCodecs[name]
+		if !ok {
+			return nil, errors.Errorf("unknown compression codec %s", name)
+		}
+		c.codecs = append(c.codecs, codec)
+	}
+	return c, nil
+}
+
+// choose returns first codec from offered ids supported by compressor, or nil.
+func (c *compressor) choose(offered []byte) compressionCodec {
+	if c == nil {
+		return nil
+	}
+	for _, id := range offered {
+		for _, codec := range c.codecs {
+			if codec.ID() == id {
+				return codec
+			}
+		}
+	}
+	return nil
+}
+
+// handshake offers local codecs to remote side of just created connection and returns the chosen one, packet bodies
+// sent over the connection are compressed with it. Returns nil codec if remote side doesn't want to compress.
+func (c *compressor) handshake(conn net.Conn) (compressionCodec, error) {
+	offer := append([]byte{}, compressionMagic[:]...)
+	offer = append(offer, byte(len(c.codecs)))
+	for _, codec := range c.codecs {
+		offer = append(offer, codec.ID())
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return nil, errors.Wrap(err, "failed to set handshake deadline")
+	}
+	defer conn.SetDeadline(time.Time{}) // nolint
+
+	if _, err := conn.Write(offer); err != nil {
+		return nil, errors.Wrap(err, "failed to send compression offer")
+	}
+	var chosen [1]byte
+	if _, err := io.ReadFull(conn, chosen[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to read compression answer")
+	}
+	if chosen[0] == codecNone {
+		return nil, nil
+	}
+	codec := c.choose(chosen[:])
+	if codec == nil {
+		return nil, errors.Errorf("remote side has chosen unknown codec %d", chosen[0])
+	}
+	return codec, nil
+}
+
+// acceptHandshake checks if accepted connection starts with compression offer and answers it.
+// Returns reader of packets. Compressed packet bodies are read by packet serializer, so nothing is chosen for
+// packets sent back: they go over connection opened by this node with its own handshake.
+func (c *compressor) acceptHandshake(conn net.Conn) (io.Reader, error) {
+	reader := bufio.NewReader(conn)
+	head, err := reader.Peek(len(compressionMagic))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(head, compressionMagic[:]) {
+		return reader, nil
+	}
+	if _, err := reader.Discard(len(compressionMagic)); err != nil {
+		return nil, err
+	}
+
+	count, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	offered := make([]byte, count)
+	if _, err := io.ReadFull(reader, offered); err != nil {
+		return nil, err
+	}
+
+	answer := codecNone
+	if codec := c.choose(offered); codec != nil {
+		answer = codec.ID()
+	}
+	if _, err := conn.Write([]byte{answer}); err != nil {
+		return nil, errors.Wrap(err, "failed to send compression answer")
+	}
+	return reader, nil
+}
+
+func codecName(codec compressionCodec) string {
+	if codec == nil {
+		return "none"
+	}
+	for name, c := range compressionCodecs {
+		if c.ID() == codec.ID() {
+			return name
+		}
+	}
+	return strconv.Itoa(int(codec.ID()))
+}
@@ -0,0 +1,128 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net"
+	"testing"
+
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCompressor(t *testing.T) {
+	c, err := newCompressor(nil)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	_, err = newCompressor([]string{"unknown"})
+	require.Error(t, err)
+
+	c, err = newCompressor(splitList(" flate, "))
+	require.NoError(t, err)
+	require.Len(t, c.codecs, 1)
+}
+
+func TestCompressionHandshake(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *compressor
+		codec  compressionCodec
+	}{
+		{"both", &compressor{codecs: []compressionCodec{compressionCodecs["flate"]}}, compressionCodecs["flate"]},
+		{"client only", nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			defer server.Close()
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := test.server.acceptHandshake(server)
+				done <- err
+			}()
+
+			c := &compressor{codecs: []compressionCodec{compressionCodecs["flate"]}}
+			codec, err := c.handshake(client)
+			require.NoError(t, err)
+			assert.Equal(t, test.codec, codec)
+			require.NoError(t, <-done)
+		})
+	}
+}
+
+func TestCompressingSerializer(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	receiver, _ := host.NewHostN("127.0.0.2:31338", testutils.RandomRef())
+	data := bytes.Repeat([]byte("insolar"), 1024)
+
+	c, err := newCompressor([]string{"flate"})
+	require.NoError(t, err)
+	serializerA := newNegotiatingSerializer(c, nil)
+	serializerB := newNegotiatingSerializer(c, nil)
+
+	// B doesn't know if A accepts compression
+	response := packet.NewBuilder(receiver).Receiver(sender).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: data}).Build()
+	plain, err := serializerB.SerializePacket(response)
+	require.NoError(t, err)
+
+	// A tells B about its codecs in request header
+	request := packet.NewBuilder(sender).Receiver(receiver).Type(packet.TestPacket).
+		Request(&packet.RequestTest{}).Build()
+	serialized, err := serializerA.SerializePacket(request)
+	require.NoError(t, err)
+	msg, err := serializerB.DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Equal(t, []byte{c.codecs[0].ID()}, msg.Accepted().Codecs)
+
+	// codecs are bound to connection address packet came from, not to address reported by sender
+	serializerB.learnCodecs("127.0.0.4:31340", msg.Accepted().Codecs)
+	spoofed, err := serializerB.SerializePacket(response)
+	require.NoError(t, err)
+	require.Equal(t, len(plain), len(spoofed))
+
+	serializerB.learnCodecs(sender.Address.String(), msg.Accepted().Codecs)
+	compressed, err := serializerB.SerializePacket(response)
+	require.NoError(t, err)
+	require.True(t, len(compressed) < len(plain))
+
+	msg, err = serializerA.DeserializePacket(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	require.Equal(t, data, msg.Data.(*packet.RequestTest).Data)
+
+	// peer without compression still reads compressed packet
+	msg, err = (&baseSerializer{}).DeserializePacket(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	require.Equal(t, data, msg.Data.(*packet.RequestTest).Data)
+
+	// codecs negotiated on connection handshake are not overridden by packet headers
+	serializerB.handshake = true
+	serializerB.learnCodecs(sender.Address.String(), nil)
+	compressed, err = serializerB.SerializePacket(response)
+	require.NoError(t, err)
+	require.True(t, len(compressed) < len(plain))
+}
@@ -18,6 +18,7 @@
 package transport
 
 import (
+	"context"
 	"errors"
 	"sync/atomic"
 	"time"
@@ -56,6 +57,9 @@ type Future interface {
 	// GetResult gets the future result from Result() channel with a timeout set to `duration`.
 	GetResult(duration time.Duration) (*packet.Packet, error)
 
+	// GetResultContext is like GetResult, but also gives up waiting and cancels Future when ctx is done.
+	GetResultContext(ctx context.Context, duration time.Duration) (*packet.Packet, error)
+
 	// Cancel closes all channels and cleans up underlying structures.
 	Cancel()
 }
@@ -114,6 +118,11 @@ func (future *future) SetResult(msg *packet.Packet) {
 
 // GetResult gets the future result from Result() channel with a timeout set to `duration`.
 func (future *future) GetResult(duration time.Duration) (*packet.Packet, error) {
+	return future.GetResultContext(context.Background(), duration)
+}
+
+// GetResultContext gets the future result with a timeout set to `duration` or until ctx is done.
+func (future *future) GetResultContext(ctx context.Context, duration time.Duration) (*packet.Packet, error) {
 	select {
 	case result, ok := <-future.Result():
 		if !ok {
@@ -124,6 +133,9 @@ func (future *future) GetResult(duration time.Duration) (*packet.Packet, error)
 		future.Cancel()
 		metrics.NetworkPacketTimeoutTotal.WithLabelValues(future.request.Type.String()).Inc()
 		return nil, ErrTimeout
+	case <-ctx.Done():
+		future.Cancel()
+		return nil, ctx.Err()
 	}
 }
 
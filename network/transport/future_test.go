@@ -18,6 +18,7 @@
 package transport
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -148,6 +149,26 @@ func TestFuture_GetResult2(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestFuture_GetResultContext(t *testing.T) {
+	n, _ := host.NewHost("127.0.0.1:8080")
+	m := &packet.Packet{}
+	var cancelled uint32 = 0
+	cancelCallback := func(f Future) {
+		atomic.StoreUint32(&cancelled, 1)
+	}
+	f := NewFuture(network.RequestID(1), n, m, cancelCallback)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond)
+		cancel()
+	}()
+
+	_, err := f.GetResultContext(ctx, time.Minute)
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, uint32(1), atomic.LoadUint32(&cancelled))
+}
+
 func TestFuture_SetResult_Cancel_Concurrency(t *testing.T) {
 	n, _ := host.NewHost("127.0.0.1:8080")
 
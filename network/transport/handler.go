@@ -21,11 +21,14 @@ import (
 	"context"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/watchdog"
 	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/packet/types"
 )
 
+var receivedCounter = watchdog.NewCounter("transport.received", "github.com/insolar/insolar/network/transport")
+
 type packetHandlerImpl struct {
 	futureManager futureManager
 
@@ -74,7 +77,9 @@ func (ph *packetHandlerImpl) processRequest(ctx context.Context, msg *packet.Pac
 	logger := inslogger.FromContext(ctx)
 	logger.Debugf("[ processRequest ] Process request %s from %s with RequestID = %d", msg.Type, msg.RemoteAddress, msg.RequestID)
 
+	receivedCounter.Begin()
 	ph.received <- msg
+	receivedCounter.Done()
 }
 
 func shouldProcessPacket(future Future, msg *packet.Packet) bool {
@@ -0,0 +1,161 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// accessRules are parsed rules of one list.
+type accessRules struct {
+	rules []string
+	nets  []*net.IPNet
+	refs  map[core.RecordRef]struct{}
+}
+
+func parseAccessRules(rules []string) (*accessRules, error) {
+	result := &accessRules{refs: make(map[core.RecordRef]struct{})}
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(rule); err == nil {
+			result.nets = append(result.nets, ipNet)
+		} else if ip := net.ParseIP(rule); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			result.nets = append(result.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		} else if ref, err := core.NewRefFromBase58(rule); err == nil {
+			result.refs[*ref] = struct{}{}
+		} else {
+			return nil, errors.Errorf("invalid access rule %s: should be CIDR, IP or node reference", rule)
+		}
+		result.rules = append(result.rules, rule)
+	}
+	return result, nil
+}
+
+func (r *accessRules) matchIP(ip net.IP) bool {
+	if r == nil {
+		return false
+	}
+	for _, n := range r.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *accessRules) hasNets() bool {
+	return r != nil && len(r.nets) > 0
+}
+
+func (r *accessRules) hasRefs() bool {
+	return r != nil && len(r.refs) > 0
+}
+
+func (r *accessRules) list() []string {
+	if r == nil {
+		return []string{}
+	}
+	return append([]string{}, r.rules...)
+}
+
+func (r *accessRules) matchNode(ref core.RecordRef) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.refs[ref]
+	return ok
+}
+
+// AccessList holds allow and deny lists of peers. Rule of list is CIDR, IP or node reference. Peer is denied if it
+// matches deny list or if allow list has rules of its kind (addresses or references) and peer matches none of them.
+// Zero AccessList accepts all peers.
+type AccessList struct {
+	lock  sync.RWMutex
+	allow *accessRules
+	deny  *accessRules
+}
+
+// NewAccessList creates new AccessList from rules.
+func NewAccessList(allow, deny []string) (*AccessList, error) {
+	l := &AccessList{}
+	if err := l.Update(allow, deny); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update replaces rules of both lists, lists are not changed if any rule is invalid.
+func (l *AccessList) Update(allow, deny []string) error {
+	allowRules, err := parseAccessRules(allow)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse allow list")
+	}
+	denyRules, err := parseAccessRules(deny)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse deny list")
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.allow, l.deny = allowRules, denyRules
+	return nil
+}
+
+// Rules returns rules of allow and deny lists.
+func (l *AccessList) Rules() (allow, deny []string) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.allow.list(), l.deny.list()
+}
+
+// IsAddressAllowed checks if packets from address are accepted.
+func (l *AccessList) IsAddressAllowed(address string) bool {
+	ip := net.ParseIP(peerIP(address))
+	if ip == nil {
+		return true
+	}
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.deny.matchIP(ip) {
+		return false
+	}
+	return !l.allow.hasNets() || l.allow.matchIP(ip)
+}
+
+// IsNodeAllowed checks if packets from node are accepted.
+func (l *AccessList) IsNodeAllowed(ref core.RecordRef) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.deny.matchNode(ref) {
+		return false
+	}
+	return !l.allow.hasRefs() || l.allow.matchNode(ref)
+}
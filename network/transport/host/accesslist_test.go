@@ -0,0 +1,83 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessList_Empty(t *testing.T) {
+	l := &AccessList{}
+	require.True(t, l.IsAddressAllowed("127.0.0.1:31337"))
+	require.True(t, l.IsNodeAllowed(testutils.RandomRef()))
+	allow, deny := l.Rules()
+	require.Empty(t, allow)
+	require.Empty(t, deny)
+}
+
+func TestAccessList_Deny(t *testing.T) {
+	denied := testutils.RandomRef()
+	l, err := NewAccessList(nil, []string{"10.0.0.0/8", "192.168.1.1", "::1", denied.String()})
+	require.NoError(t, err)
+
+	require.False(t, l.IsAddressAllowed("10.1.2.3:31337"))
+	require.False(t, l.IsAddressAllowed("192.168.1.1:31337"))
+	require.False(t, l.IsAddressAllowed("[::1]:31337"))
+	require.True(t, l.IsAddressAllowed("192.168.1.2:31337"))
+	require.False(t, l.IsNodeAllowed(denied))
+	require.True(t, l.IsNodeAllowed(testutils.RandomRef()))
+}
+
+func TestAccessList_Allow(t *testing.T) {
+	allowed := testutils.RandomRef()
+	l, err := NewAccessList([]string{"127.0.0.0/24", allowed.String()}, []string{"127.0.0.2"})
+	require.NoError(t, err)
+
+	require.True(t, l.IsAddressAllowed("127.0.0.1:31337"))
+	require.False(t, l.IsAddressAllowed("127.0.1.1:31337"))
+	// deny list takes precedence
+	require.False(t, l.IsAddressAllowed("127.0.0.2:31337"))
+	require.True(t, l.IsNodeAllowed(allowed))
+	require.False(t, l.IsNodeAllowed(testutils.RandomRef()))
+
+	// allow list of addresses doesn't restrict nodes
+	l, err = NewAccessList([]string{"127.0.0.0/24"}, nil)
+	require.NoError(t, err)
+	require.True(t, l.IsNodeAllowed(testutils.RandomRef()))
+}
+
+func TestAccessList_Update(t *testing.T) {
+	l, err := NewAccessList(nil, []string{" 10.0.0.1 ", ""})
+	require.NoError(t, err)
+	_, deny := l.Rules()
+	require.Equal(t, []string{"10.0.0.1"}, deny)
+
+	_, err = NewAccessList([]string{"not a rule"}, nil)
+	require.Error(t, err)
+
+	// invalid rules don't change lists
+	require.Error(t, l.Update(nil, []string{"10.0.0.2", "10.0.0.0/33"}))
+	require.False(t, l.IsAddressAllowed("10.0.0.1:31337"))
+	require.True(t, l.IsAddressAllowed("10.0.0.2:31337"))
+
+	require.NoError(t, l.Update(nil, nil))
+	require.True(t, l.IsAddressAllowed("10.0.0.1:31337"))
+}
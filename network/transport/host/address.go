@@ -28,7 +28,13 @@ type Address struct {
 	net.UDPAddr
 }
 
-// NewAddress is constructor.
+// Prefix lengths of subnets hosts are grouped by.
+const (
+	SubnetPrefixIPv4 = 24
+	SubnetPrefixIPv6 = 64
+)
+
+// NewAddress is constructor. Address is "host:port", IPv6 host must be in brackets: "[::1]:port".
 func NewAddress(address string) (*Address, error) {
 	udpAddr, err := net.ResolveUDPAddr("udp", address)
 	if err != nil {
@@ -41,3 +47,31 @@ func NewAddress(address string) (*Address, error) {
 func (address Address) Equal(other Address) bool {
 	return address.IP.Equal(other.IP) && address.Port == other.Port
 }
+
+// IsIPv6 returns true if address is IPv6 address and not IPv4-mapped one.
+func (address Address) IsIPv6() bool {
+	return address.IP.To4() == nil && address.IP.To16() != nil
+}
+
+// Subnet returns subnet of IP that hosts are grouped by: /24 for IPv4 and /64 for IPv6.
+func Subnet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		mask := net.CIDRMask(SubnetPrefixIPv4, 8*net.IPv4len)
+		return &net.IPNet{IP: ip4.Mask(mask), Mask: mask}
+	}
+	if ip16 := ip.To16(); ip16 != nil {
+		mask := net.CIDRMask(SubnetPrefixIPv6, 8*net.IPv6len)
+		return &net.IPNet{IP: ip16.Mask(mask), Mask: mask}
+	}
+	return nil
+}
+
+// SameSubnet checks if IPs are in the same subnet.
+func SameSubnet(a, b net.IP) bool {
+	subnet := Subnet(a)
+	if subnet == nil {
+		return false
+	}
+	other := Subnet(b)
+	return other != nil && subnet.IP.Equal(other.IP) && len(subnet.IP) == len(other.IP)
+}
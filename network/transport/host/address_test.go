@@ -45,3 +45,24 @@ func TestAddress_Equal(t *testing.T) {
 	require.False(t, addr1.Equal(*addr3))
 	require.False(t, addr3.Equal(*addr1))
 }
+
+func TestNewAddress_IPv6(t *testing.T) {
+	addr, err := NewAddress("[::1]:31337")
+	require.NoError(t, err)
+	require.True(t, addr.IsIPv6())
+	require.Equal(t, "[::1]:31337", addr.String())
+
+	addr, err = NewAddress("127.0.0.1:31337")
+	require.NoError(t, err)
+	require.False(t, addr.IsIPv6())
+}
+
+func TestSameSubnet(t *testing.T) {
+	require.True(t, SameSubnet(net.ParseIP("10.0.1.1"), net.ParseIP("10.0.1.200")))
+	require.False(t, SameSubnet(net.ParseIP("10.0.1.1"), net.ParseIP("10.0.2.1")))
+	require.True(t, SameSubnet(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::ffff")))
+	require.False(t, SameSubnet(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8:0:1::1")))
+	require.False(t, SameSubnet(net.ParseIP("10.0.1.1"), net.ParseIP("2001:db8::1")))
+	require.True(t, SameSubnet(net.ParseIP("::ffff:10.0.1.1"), net.ParseIP("10.0.1.2")))
+	require.False(t, SameSubnet(nil, net.ParseIP("10.0.1.2")))
+}
@@ -0,0 +1,128 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+)
+
+// Capture keeps the last packets of each peer in ring buffers while capture is enabled. It is toggled at runtime
+// to diagnose misbehaving peers without capturing traffic on host.
+type Capture struct {
+	lock        sync.RWMutex
+	enabled     bool
+	perPeer     int
+	payloadSize int
+	peers       map[string]*captureRing
+}
+
+// captureRing is ring buffer of packets of one peer.
+type captureRing struct {
+	packets []core.CapturedPacket
+	next    int
+}
+
+// NewCapture creates new disabled Capture.
+func NewCapture() *Capture {
+	return &Capture{peers: make(map[string]*captureRing)}
+}
+
+// Start enables capture of up to perPeer last packets of each peer with payloads truncated to payloadSize bytes,
+// packets captured before are discarded.
+func (c *Capture) Start(perPeer int, payloadSize int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.enabled = true
+	c.perPeer = perPeer
+	c.payloadSize = payloadSize
+	c.peers = make(map[string]*captureRing)
+}
+
+// Stop disables capture, captured packets are kept.
+func (c *Capture) Stop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.enabled = false
+}
+
+// IsEnabled checks if packets are captured.
+func (c *Capture) IsEnabled() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.enabled
+}
+
+// Add captures packet of peer with address if capture is enabled. Payload is made lazily, so disabled capture
+// costs nothing.
+func (c *Capture) Add(packet core.CapturedPacket, payload func() string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.enabled || c.perPeer <= 0 {
+		return
+	}
+	packet.Payload = payload()
+	if len(packet.Payload) > c.payloadSize {
+		packet.Payload = packet.Payload[:c.payloadSize]
+		packet.Truncated = true
+	}
+	ring, ok := c.peers[packet.Address]
+	if !ok {
+		ring = &captureRing{}
+		c.peers[packet.Address] = ring
+	}
+	if len(ring.packets) < c.perPeer {
+		ring.packets = append(ring.packets, packet)
+		return
+	}
+	ring.packets[ring.next] = packet
+	ring.next = (ring.next + 1) % c.perPeer
+}
+
+// Packets returns captured packets of peer with address in order they were captured, packets of all peers
+// ordered by time if address is empty.
+func (c *Capture) Packets(address string) []core.CapturedPacket {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make([]core.CapturedPacket, 0)
+	if address != "" {
+		if ring, ok := c.peers[address]; ok {
+			result = ring.ordered(result)
+		}
+		return result
+	}
+	for _, ring := range c.peers {
+		result = ring.ordered(result)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result
+}
+
+func (r *captureRing) ordered(result []core.CapturedPacket) []core.CapturedPacket {
+	result = append(result, r.packets[r.next:]...)
+	return append(result, r.packets[:r.next]...)
+}
@@ -0,0 +1,67 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func capturedPayload(payload string) func() string {
+	return func() string { return payload }
+}
+
+func TestCapture(t *testing.T) {
+	c := NewCapture()
+	c.Add(core.CapturedPacket{Address: "127.0.0.1:1"}, capturedPayload("ignored"))
+	assert.Empty(t, c.Packets(""))
+
+	c.Start(2, 4)
+	assert.True(t, c.IsEnabled())
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		c.Add(core.CapturedPacket{Address: "127.0.0.1:1", RequestID: uint64(i), Time: now.Add(time.Duration(i) * time.Second)},
+			capturedPayload("data"))
+	}
+	c.Add(core.CapturedPacket{Address: "127.0.0.1:2", RequestID: 10, Time: now.Add(1500 * time.Millisecond)},
+		capturedPayload("payload"))
+
+	packets := c.Packets("127.0.0.1:1")
+	assert.Len(t, packets, 2)
+	assert.Equal(t, uint64(1), packets[0].RequestID)
+	assert.Equal(t, uint64(2), packets[1].RequestID)
+	assert.False(t, packets[0].Truncated)
+
+	all := c.Packets("")
+	assert.Len(t, all, 3)
+	assert.Equal(t, uint64(10), all[1].RequestID)
+	assert.Equal(t, "payl", all[1].Payload)
+	assert.True(t, all[1].Truncated)
+
+	c.Stop()
+	assert.False(t, c.IsEnabled())
+	c.Add(core.CapturedPacket{Address: "127.0.0.1:1"}, capturedPayload("data"))
+	assert.Len(t, c.Packets(""), 3)
+
+	c.Start(2, 4)
+	assert.Empty(t, c.Packets(""))
+	assert.Empty(t, c.Packets("127.0.0.1:3"))
+}
@@ -0,0 +1,74 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"sort"
+	"sync"
+)
+
+// Faults is a fault injection layer of transport. Packets to and from isolated addresses are dropped
+// until isolation is healed, it is used to rehearse network partitions.
+type Faults struct {
+	lock     sync.RWMutex
+	isolated map[string]bool
+}
+
+// NewFaults creates new Faults without isolated addresses.
+func NewFaults() *Faults {
+	return &Faults{isolated: make(map[string]bool)}
+}
+
+// Isolate adds addresses to the isolated set.
+func (f *Faults) Isolate(addresses []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, address := range addresses {
+		f.isolated[address] = true
+	}
+}
+
+// Heal removes all addresses from the isolated set.
+func (f *Faults) Heal() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.isolated = make(map[string]bool)
+}
+
+// IsIsolated checks if packets to and from address should be dropped.
+func (f *Faults) IsIsolated(address string) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.isolated[address]
+}
+
+// Isolated returns sorted isolated addresses.
+func (f *Faults) Isolated() []string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	result := make([]string, 0, len(f.isolated))
+	for address := range f.isolated {
+		result = append(result, address)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,101 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+)
+
+// DefaultReplayLimit is max count of requests remembered by ReplayCache during one window.
+const DefaultReplayLimit = 1 << 16
+
+type replayKey struct {
+	sender    core.RecordRef
+	origin    string
+	requestID uint64
+}
+
+// ReplayCache remembers recently seen requests by sender and request id to suppress duplicated and replayed
+// packets. Transport does not know pulse numbers, so requests are remembered for one to two windows of pulse
+// length instead: cache has current and previous generation, generations are rotated when window passes or
+// current one is full.
+type ReplayCache struct {
+	window time.Duration
+	limit  int
+	now    func() time.Time
+
+	lock     sync.Mutex
+	rotated  time.Time
+	current  map[replayKey]struct{}
+	previous map[replayKey]struct{}
+}
+
+// NewReplayCache creates new ReplayCache, zero window disables suppression.
+func NewReplayCache(window time.Duration, limit int) *ReplayCache {
+	if limit <= 0 {
+		limit = DefaultReplayLimit
+	}
+	return &ReplayCache{
+		window:  window,
+		limit:   limit,
+		now:     time.Now,
+		current: make(map[replayKey]struct{}),
+	}
+}
+
+func (c *ReplayCache) rotate(now time.Time) {
+	if now.Sub(c.rotated) >= 2*c.window {
+		c.previous = nil
+	} else {
+		c.previous = c.current
+	}
+	c.current = make(map[replayKey]struct{})
+	c.rotated = now
+}
+
+// IsReplayed remembers request and returns true if request with the same sender and id was already seen.
+// Origin is IP request came from if sender is not authenticated by packet signature and empty otherwise,
+// so peer claiming reference of another node can't suppress its requests.
+func (c *ReplayCache) IsReplayed(sender core.RecordRef, origin string, requestID uint64) bool {
+	if c == nil || c.window <= 0 {
+		return false
+	}
+	key := replayKey{sender: sender, origin: origin, requestID: requestID}
+	now := c.now()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if now.Sub(c.rotated) >= c.window {
+		c.rotate(now)
+	}
+	if _, ok := c.current[key]; ok {
+		return true
+	}
+	if _, ok := c.previous[key]; ok {
+		return true
+	}
+	if len(c.current) >= c.limit {
+		c.rotate(now)
+	}
+	c.current[key] = struct{}{}
+	return false
+}
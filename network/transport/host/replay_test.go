@@ -0,0 +1,83 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCache_IsReplayed(t *testing.T) {
+	now := time.Now()
+	cache := NewReplayCache(10*time.Second, 0)
+	cache.now = func() time.Time { return now }
+
+	sender := testutils.RandomRef()
+	other := testutils.RandomRef()
+
+	require.False(t, cache.IsReplayed(sender, "", 1))
+	require.True(t, cache.IsReplayed(sender, "", 1))
+	require.False(t, cache.IsReplayed(sender, "", 2))
+	require.False(t, cache.IsReplayed(other, "", 1))
+
+	// not authenticated peer can't suppress requests of the same sender from other address
+	require.False(t, cache.IsReplayed(sender, "127.0.0.2", 2))
+	require.True(t, cache.IsReplayed(sender, "127.0.0.2", 2))
+	require.False(t, cache.IsReplayed(sender, "127.0.0.3", 2))
+
+	// request is remembered in previous generation
+	now = now.Add(10 * time.Second)
+	require.True(t, cache.IsReplayed(sender, "", 1))
+
+	// previous generation is forgotten on next rotation
+	now = now.Add(10 * time.Second)
+	require.False(t, cache.IsReplayed(sender, "", 1))
+
+	// both generations are forgotten if cache was idle for two windows
+	now = now.Add(20 * time.Second)
+	require.False(t, cache.IsReplayed(sender, "", 1))
+}
+
+func TestReplayCache_Limit(t *testing.T) {
+	cache := NewReplayCache(time.Hour, 2)
+	sender := testutils.RandomRef()
+
+	require.False(t, cache.IsReplayed(sender, "", 1))
+	require.False(t, cache.IsReplayed(sender, "", 2))
+	// current generation is full and becomes previous one
+	require.False(t, cache.IsReplayed(sender, "", 3))
+	require.True(t, cache.IsReplayed(sender, "", 1))
+	require.False(t, cache.IsReplayed(sender, "", 4))
+	// second rotation forgets first generation
+	require.False(t, cache.IsReplayed(sender, "", 5))
+	require.False(t, cache.IsReplayed(sender, "", 1))
+}
+
+func TestReplayCache_Disabled(t *testing.T) {
+	cache := NewReplayCache(0, 0)
+	sender := testutils.RandomRef()
+
+	require.False(t, cache.IsReplayed(sender, "", 1))
+	require.False(t, cache.IsReplayed(sender, "", 1))
+
+	var nilCache *ReplayCache
+	require.False(t, nilCache.IsReplayed(sender, "", 1))
+}
@@ -0,0 +1,210 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package host
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+)
+
+// Offence is a kind of peer misbehaviour that lowers its score.
+type Offence int
+
+const (
+	// OffenceTimeout is a request to peer that was not answered in time.
+	OffenceTimeout = Offence(iota + 1)
+	// OffenceMalformedPacket is a packet from peer that failed to deserialize.
+	OffenceMalformedPacket
+	// OffenceFailedAuth is a failed TLS handshake or rejected authorization of peer.
+	OffenceFailedAuth
+)
+
+// penalties are amounts peer score is lowered by offences.
+var penalties = map[Offence]int{
+	OffenceTimeout:         1,
+	OffenceMalformedPacket: 10,
+	OffenceFailedAuth:      25,
+}
+
+// scoreRecoveryInterval is interval after which peer score is raised by one point until it reaches zero.
+const scoreRecoveryInterval = time.Minute
+
+type peerScore struct {
+	score       int
+	updated     time.Time
+	bannedUntil time.Time
+}
+
+// Reputation tracks scores of peers by their IP. Peers with score not greater than threshold are banned for
+// ban duration, their score is reset when ban expires. Peers are not tracked when banning is disabled, peers
+// whose score recovered to zero are forgotten.
+type Reputation struct {
+	threshold   int
+	banDuration time.Duration
+	now         func() time.Time
+
+	lock   sync.Mutex
+	peers  map[string]*peerScore
+	pruned time.Time
+}
+
+// NewReputation creates new Reputation, zero banDuration disables banning.
+func NewReputation(threshold int, banDuration time.Duration) *Reputation {
+	return &Reputation{
+		threshold:   threshold,
+		banDuration: banDuration,
+		now:         time.Now,
+		peers:       make(map[string]*peerScore),
+	}
+}
+
+// peerIP returns IP of address, address is returned as is if it has no port.
+func peerIP(address string) string {
+	ip, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return ip
+}
+
+// recover raises score of peer for time passed since last update and resets score of peer whose ban expired.
+func (r *Reputation) recover(peer *peerScore, now time.Time) {
+	if !peer.bannedUntil.IsZero() {
+		if now.Before(peer.bannedUntil) {
+			return
+		}
+		peer.bannedUntil = time.Time{}
+		peer.score = 0
+		peer.updated = now
+		return
+	}
+	recovered := int(now.Sub(peer.updated) / scoreRecoveryInterval)
+	if recovered <= 0 {
+		return
+	}
+	peer.score += recovered
+	if peer.score > 0 {
+		peer.score = 0
+	}
+	peer.updated = peer.updated.Add(time.Duration(recovered) * scoreRecoveryInterval)
+}
+
+// prune forgets peers that are not banned and whose score recovered to zero.
+func (r *Reputation) prune(now time.Time) {
+	for ip, peer := range r.peers {
+		r.recover(peer, now)
+		if peer.bannedUntil.IsZero() && peer.score == 0 {
+			delete(r.peers, ip)
+		}
+	}
+	r.pruned = now
+}
+
+// Penalize lowers score of peer with address for offence and bans peer if score reaches threshold.
+// Returns true if peer is banned.
+func (r *Reputation) Penalize(address string, offence Offence) bool {
+	if r.banDuration <= 0 {
+		return false
+	}
+	ip := peerIP(address)
+	now := r.now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if now.Sub(r.pruned) >= scoreRecoveryInterval {
+		r.prune(now)
+	}
+	peer, ok := r.peers[ip]
+	if !ok {
+		peer = &peerScore{updated: now}
+		r.peers[ip] = peer
+	}
+	r.recover(peer, now)
+	if !peer.bannedUntil.IsZero() {
+		return true
+	}
+	peer.score -= penalties[offence]
+	peer.updated = now
+	if peer.score <= r.threshold {
+		peer.bannedUntil = now.Add(r.banDuration)
+		return true
+	}
+	return false
+}
+
+// IsBanned checks if peer with address is banned.
+func (r *Reputation) IsBanned(address string) bool {
+	ip := peerIP(address)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	peer, ok := r.peers[ip]
+	if !ok {
+		return false
+	}
+	r.recover(peer, r.now())
+	if peer.bannedUntil.IsZero() {
+		if peer.score == 0 {
+			delete(r.peers, ip)
+		}
+		return false
+	}
+	return true
+}
+
+// Unban removes peer with address from ban list and resets its score. Returns false if peer was not banned.
+func (r *Reputation) Unban(address string) bool {
+	ip := peerIP(address)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	peer, ok := r.peers[ip]
+	if !ok {
+		return false
+	}
+	r.recover(peer, r.now())
+	delete(r.peers, ip)
+	return !peer.bannedUntil.IsZero()
+}
+
+// Banned returns peers that are banned now sorted by address.
+func (r *Reputation) Banned() []core.BannedHost {
+	now := r.now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.prune(now)
+	result := make([]core.BannedHost, 0, len(r.peers))
+	for ip, peer := range r.peers {
+		if peer.bannedUntil.IsZero() {
+			continue
+		}
+		result = append(result, core.BannedHost{Address: ip, Score: peer.score, Until: peer.bannedUntil})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Address < result[j].Address
+	})
+	return result
+}
@@ -0,0 +1,101 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReputation(now *time.Time) *Reputation {
+	r := NewReputation(-30, time.Minute)
+	r.now = func() time.Time { return *now }
+	return r
+}
+
+func TestReputation_Ban(t *testing.T) {
+	now := time.Now()
+	r := newTestReputation(&now)
+
+	require.False(t, r.Penalize("127.0.0.1:31337", OffenceFailedAuth))
+	require.False(t, r.IsBanned("127.0.0.1"))
+	require.True(t, r.Penalize("127.0.0.1:12345", OffenceMalformedPacket))
+	require.True(t, r.IsBanned("127.0.0.1:31337"))
+	require.False(t, r.IsBanned("10.10.11.11:31337"))
+
+	banned := r.Banned()
+	require.Len(t, banned, 1)
+	require.Equal(t, "127.0.0.1", banned[0].Address)
+	require.Equal(t, -35, banned[0].Score)
+	require.Equal(t, now.Add(time.Minute), banned[0].Until)
+
+	now = now.Add(time.Minute)
+	require.False(t, r.IsBanned("127.0.0.1:31337"))
+	require.Empty(t, r.Banned())
+}
+
+func TestReputation_Recovery(t *testing.T) {
+	now := time.Now()
+	r := newTestReputation(&now)
+
+	require.False(t, r.Penalize("127.0.0.1:31337", OffenceFailedAuth))
+	now = now.Add(10 * scoreRecoveryInterval)
+	require.False(t, r.Penalize("127.0.0.1:31337", OffenceMalformedPacket))
+	require.False(t, r.IsBanned("127.0.0.1:31337"))
+	require.True(t, r.Penalize("127.0.0.1:31337", OffenceMalformedPacket))
+}
+
+func TestReputation_Unban(t *testing.T) {
+	now := time.Now()
+	r := newTestReputation(&now)
+
+	require.False(t, r.Unban("127.0.0.1"))
+	r.Penalize("127.0.0.1:31337", OffenceFailedAuth)
+	r.Penalize("127.0.0.1:31337", OffenceFailedAuth)
+	require.True(t, r.IsBanned("127.0.0.1:31337"))
+	require.True(t, r.Unban("127.0.0.1"))
+	require.False(t, r.IsBanned("127.0.0.1:31337"))
+	require.False(t, r.Penalize("127.0.0.1:31337", OffenceTimeout))
+}
+
+func TestReputation_BanDisabled(t *testing.T) {
+	r := NewReputation(-1, 0)
+
+	require.False(t, r.Penalize("127.0.0.1:31337", OffenceFailedAuth))
+	require.False(t, r.IsBanned("127.0.0.1:31337"))
+	require.Empty(t, r.Banned())
+	require.Empty(t, r.peers)
+}
+
+func TestReputation_Prune(t *testing.T) {
+	now := time.Now()
+	r := newTestReputation(&now)
+
+	r.Penalize("10.0.0.1:31337", OffenceTimeout)
+	r.Penalize("10.0.0.2:31337", OffenceTimeout)
+	require.Len(t, r.peers, 2)
+
+	now = now.Add(scoreRecoveryInterval)
+	require.False(t, r.IsBanned("10.0.0.1:31337"))
+	require.Len(t, r.peers, 1)
+
+	r.Penalize("10.0.0.3:31337", OffenceTimeout)
+	require.Len(t, r.peers, 1)
+	require.Contains(t, r.peers, "10.0.0.3")
+}
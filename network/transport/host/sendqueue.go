@@ -0,0 +1,205 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"sync"
+
+	"github.com/insolar/insolar/metrics"
+	"github.com/pkg/errors"
+)
+
+// Priority is class of outgoing packets. When send queue is congested packets of classes with higher weight are
+// sent more often, so replication traffic can't starve consensus.
+type Priority int
+
+const (
+	// PriorityConsensus is class of consensus phase packets.
+	PriorityConsensus = Priority(iota)
+	// PriorityPulse is class of pulse distribution packets.
+	PriorityPulse
+	// PriorityRPC is class of RPC, bootstrap and other request packets.
+	PriorityRPC
+	// PriorityReplication is class of ledger replication, cascade, gossip, anti-entropy and standby replication packets.
+	PriorityReplication
+
+	// PriorityCount is count of priority classes.
+	PriorityCount
+)
+
+var priorityNames = [PriorityCount]string{"consensus", "pulse", "rpc", "replication"}
+
+// String returns name of priority class used in configuration and metrics.
+func (p Priority) String() string {
+	if p < 0 || p >= PriorityCount {
+		return "unknown"
+	}
+	return priorityNames[p]
+}
+
+// DropPolicy is which packet is dropped when queue of priority class is full.
+type DropPolicy int
+
+const (
+	// DropNewest rejects packet that doesn't fit queue.
+	DropNewest = DropPolicy(iota)
+	// DropOldest drops the oldest queued packet to make room for the new one, it suits packets that are useless
+	// when stale.
+	DropOldest
+)
+
+var (
+	// DefaultPriorityWeights are weights of priority classes which are not set in configuration.
+	DefaultPriorityWeights = [PriorityCount]int{8, 4, 2, 1}
+	// DefaultDropPolicies are drop policies of priority classes which are not set in configuration.
+	DefaultDropPolicies = [PriorityCount]DropPolicy{DropOldest, DropOldest, DropNewest, DropNewest}
+)
+
+// ErrPacketDropped is returned when packet is dropped by send queue.
+var ErrPacketDropped = errors.New("packet is dropped by send queue")
+
+// sendItem is queued packet, result of sending is passed to done.
+type sendItem struct {
+	send func() error
+	done chan error
+}
+
+// queueClass is queue of packets of one priority class.
+type queueClass struct {
+	weight int
+	policy DropPolicy
+	items  []*sendItem
+	// current is state of smooth weighted round robin
+	current int
+}
+
+// SendQueue sends packets by a fixed count of workers, which take packets of priority classes by smooth weighted
+// round robin. Sender waits until its packet is sent, so errors are returned as without queue. Queue can be shared
+// by several transports, so consensus packets are prioritized over traffic of all of them.
+type SendQueue struct {
+	lock    sync.Mutex
+	ready   *sync.Cond
+	classes [PriorityCount]*queueClass
+	limit   int
+	workers int
+	started bool
+	closed  bool
+}
+
+// NewSendQueue creates send queue, workers are started on first packet.
+func NewSendQueue(workers int, limit int, weights [PriorityCount]int, policies [PriorityCount]DropPolicy) *SendQueue {
+	q := &SendQueue{limit: limit, workers: workers}
+	q.ready = sync.NewCond(&q.lock)
+	for i := range q.classes {
+		q.classes[i] = &queueClass{weight: weights[i], policy: policies[i]}
+	}
+	return q
+}
+
+// Send queues packet and waits until it is sent or dropped. Packet is sent by caller if queue is closed.
+func (q *SendQueue) Send(priority Priority, send func() error) error {
+	item := &sendItem{send: send, done: make(chan error, 1)}
+
+	q.lock.Lock()
+	if q.closed {
+		q.lock.Unlock()
+		return send()
+	}
+	if !q.started {
+		q.started = true
+		for i := 0; i < q.workers; i++ {
+			go q.work()
+		}
+	}
+	class := q.classes[priority]
+	if len(class.items) >= q.limit {
+		if class.policy == DropNewest {
+			q.lock.Unlock()
+			metrics.NetworkPacketDroppedQueueTotal.WithLabelValues(priority.String()).Inc()
+			return ErrPacketDropped
+		}
+		oldest := class.items[0]
+		class.items = class.items[1:]
+		oldest.done <- ErrPacketDropped
+		metrics.NetworkPacketDroppedQueueTotal.WithLabelValues(priority.String()).Inc()
+	}
+	class.items = append(class.items, item)
+	q.ready.Signal()
+	q.lock.Unlock()
+
+	return <-item.done
+}
+
+// next takes packet to send, it blocks until there is a packet or queue is closed.
+func (q *SendQueue) next() *sendItem {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for {
+		if q.closed {
+			return nil
+		}
+		var chosen *queueClass
+		total := 0
+		for _, class := range q.classes {
+			if len(class.items) == 0 {
+				continue
+			}
+			class.current += class.weight
+			total += class.weight
+			if chosen == nil || class.current > chosen.current {
+				chosen = class
+			}
+		}
+		if chosen != nil {
+			chosen.current -= total
+			item := chosen.items[0]
+			chosen.items = chosen.items[1:]
+			return item
+		}
+		q.ready.Wait()
+	}
+}
+
+func (q *SendQueue) work() {
+	for {
+		item := q.next()
+		if item == nil {
+			return
+		}
+		item.done <- item.send()
+	}
+}
+
+// Close stops workers, queued packets are dropped and later packets are sent by callers.
+func (q *SendQueue) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	for _, class := range q.classes {
+		for _, item := range class.items {
+			item.done <- ErrPacketDropped
+		}
+		class.items = nil
+	}
+	q.ready.Broadcast()
+}
@@ -0,0 +1,126 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package host
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockWorker occupies the only worker of queue until returned function is called.
+func blockWorker(t *testing.T, q *SendQueue) func() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_ = q.Send(PriorityRPC, func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+	return func() { close(release) }
+}
+
+// waitQueued waits until count of packets are queued.
+func waitQueued(t *testing.T, q *SendQueue, count int) {
+	queued := func() int {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		total := 0
+		for _, class := range q.classes {
+			total += len(class.items)
+		}
+		return total
+	}
+	deadline := time.Now().Add(time.Second)
+	for queued() != count {
+		require.True(t, time.Now().Before(deadline), "packets are not queued in time")
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSendQueue_Weights(t *testing.T) {
+	q := NewSendQueue(1, 10, [PriorityCount]int{3, 1, 1, 1}, DefaultDropPolicies)
+	defer q.Close()
+	release := blockWorker(t, q)
+
+	var lock sync.Mutex
+	var sent []Priority
+	var wg sync.WaitGroup
+	enqueue := func(priority Priority, count int) {
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, q.Send(priority, func() error {
+					lock.Lock()
+					sent = append(sent, priority)
+					lock.Unlock()
+					return nil
+				}))
+			}()
+		}
+	}
+	enqueue(PriorityConsensus, 4)
+	enqueue(PriorityReplication, 2)
+	waitQueued(t, q, 6)
+	release()
+	wg.Wait()
+
+	c, r := PriorityConsensus, PriorityReplication
+	require.Equal(t, []Priority{c, c, r, c, c, r}, sent)
+}
+
+func TestSendQueue_DropPolicies(t *testing.T) {
+	q := NewSendQueue(1, 1, DefaultPriorityWeights, DefaultDropPolicies)
+	release := blockWorker(t, q)
+
+	// rpc drops new packets
+	dropped := make(chan error, 1)
+	go func() {
+		dropped <- q.Send(PriorityRPC, func() error { return nil })
+	}()
+	waitQueued(t, q, 1)
+	require.Equal(t, ErrPacketDropped, q.Send(PriorityRPC, func() error { return nil }))
+
+	// consensus drops oldest packets
+	go func() {
+		dropped <- q.Send(PriorityConsensus, func() error { return nil })
+	}()
+	waitQueued(t, q, 2)
+	go func() {
+		_ = q.Send(PriorityConsensus, func() error { return nil })
+	}()
+	require.Equal(t, ErrPacketDropped, <-dropped)
+
+	// queued packets are dropped on close, later packets are sent by callers
+	waitQueued(t, q, 2)
+	q.Close()
+	require.Equal(t, ErrPacketDropped, <-dropped)
+	release()
+	sent := false
+	require.NoError(t, q.Send(PriorityRPC, func() error {
+		sent = true
+		return nil
+	}))
+	require.True(t, sent)
+}
@@ -20,10 +20,13 @@ package transport
 import (
 	"sync"
 
+	"github.com/insolar/insolar/instrumentation/watchdog"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/transport/packet"
 )
 
+var futuresCounter = watchdog.NewCounter("transport.futures", "github.com/insolar/insolar/network/transport")
+
 type futureManagerImpl struct {
 	mutex   sync.RWMutex
 	futures map[network.RequestID]Future
@@ -38,7 +41,9 @@ func newFutureManagerImpl() *futureManagerImpl {
 func (fm *futureManagerImpl) Create(msg *packet.Packet) Future {
 	future := NewFuture(msg.RequestID, msg.Receiver, msg, func(f Future) {
 		fm.delete(f.ID())
+		futuresCounter.Done()
 	})
+	futuresCounter.Begin()
 
 	fm.mutex.Lock()
 	defer fm.mutex.Unlock()
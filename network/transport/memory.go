@@ -0,0 +1,316 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/network/transport/relay"
+	"github.com/pkg/errors"
+)
+
+// MemoryNetwork connects transports with MEMORY and MEMORY_UDP protocols without sockets, it is used to run
+// multi-node scenarios in tests. Delivery of packets is subject to latency, loss and scheduled partitions. Random
+// decisions are made by generator created from seed, so runs with the same seed and order of packets drop the
+// same packets.
+type MemoryNetwork struct {
+	lock       sync.Mutex
+	rand       *rand.Rand
+	transports map[string]*memoryTransport
+	lastPort   int
+
+	latency    time.Duration
+	jitter     time.Duration
+	loss       float64
+	partitions []memoryPartition
+}
+
+// memoryPartition splits network to groups of addresses during time interval, zero end means until healed.
+// Packets between addresses of different groups are dropped, addresses not listed in any group are not affected.
+type memoryPartition struct {
+	start  time.Time
+	end    time.Time
+	groups map[string]int
+}
+
+func (p *memoryPartition) separates(now time.Time, from, to string) bool {
+	if now.Before(p.start) || (!p.end.IsZero() && !now.Before(p.end)) {
+		return false
+	}
+	fromGroup, ok := p.groups[from]
+	if !ok {
+		return false
+	}
+	toGroup, ok := p.groups[to]
+	return ok && fromGroup != toGroup
+}
+
+// NewMemoryNetwork creates in-memory network without latency, loss and partitions.
+func NewMemoryNetwork(seed int64) *MemoryNetwork {
+	return &MemoryNetwork{
+		rand:       rand.New(rand.NewSource(seed)),
+		transports: make(map[string]*memoryTransport),
+	}
+}
+
+// SetLatency sets delay of packet delivery, every packet is delayed for latency plus random duration up to jitter.
+func (n *MemoryNetwork) SetLatency(latency, jitter time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.latency = latency
+	n.jitter = jitter
+}
+
+// SetLoss sets rate of dropped packets, from 0 to 1.
+func (n *MemoryNetwork) SetLoss(rate float64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.loss = rate
+}
+
+// Partition splits network to groups of addresses until it is healed.
+func (n *MemoryNetwork) Partition(groups ...[]string) {
+	n.SchedulePartition(0, 0, groups...)
+}
+
+// SchedulePartition splits network to groups of addresses after delay for duration, zero duration means until
+// network is healed.
+func (n *MemoryNetwork) SchedulePartition(after, duration time.Duration, groups ...[]string) {
+	p := memoryPartition{start: time.Now().Add(after), groups: make(map[string]int)}
+	if duration > 0 {
+		p.end = p.start.Add(duration)
+	}
+	for i, group := range groups {
+		for _, address := range group {
+			p.groups[address] = i
+		}
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.partitions = append(n.partitions, p)
+}
+
+// Heal removes all partitions.
+func (n *MemoryNetwork) Heal() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.partitions = nil
+}
+
+// listen binds transport to address, port 0 is replaced with free port.
+func (n *MemoryNetwork) listen(address string, t *memoryTransport) (string, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	ip, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid address")
+	}
+	if port == "0" {
+		for {
+			n.lastPort++
+			address = net.JoinHostPort(ip, strconv.Itoa(n.lastPort))
+			if _, ok := n.transports[address]; !ok {
+				break
+			}
+		}
+	}
+	if _, ok := n.transports[address]; ok {
+		return "", errors.Errorf("address %s is already in use", address)
+	}
+	n.transports[address] = t
+	return address, nil
+}
+
+func (n *MemoryNetwork) close(address string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.transports, address)
+}
+
+// route decides if packet is delivered and returns its delay.
+func (n *MemoryNetwork) route(from, to string) (time.Duration, bool, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.transports[to]; !ok {
+		return 0, false, errors.Errorf("no transport listens on %s", to)
+	}
+	now := time.Now()
+	for i := range n.partitions {
+		if n.partitions[i].separates(now, from, to) {
+			return 0, false, nil
+		}
+	}
+	if n.loss > 0 && n.rand.Float64() < n.loss {
+		return 0, false, nil
+	}
+	delay := n.latency
+	if n.jitter > 0 {
+		delay += time.Duration(n.rand.Int63n(int64(n.jitter)))
+	}
+	return delay, true, nil
+}
+
+func (n *MemoryNetwork) deliver(from, to string, data []byte) {
+	n.lock.Lock()
+	t, ok := n.transports[to]
+	n.lock.Unlock()
+
+	if ok {
+		t.receive(memoryAddr(from), data)
+	}
+}
+
+var memoryNetworks = struct {
+	sync.Mutex
+	networks map[string]*MemoryNetwork
+}{networks: make(map[string]*MemoryNetwork)}
+
+// RegisterMemoryNetwork makes network available to transports with MemoryNetwork name in configuration.
+func RegisterMemoryNetwork(name string, n *MemoryNetwork) {
+	memoryNetworks.Lock()
+	defer memoryNetworks.Unlock()
+
+	memoryNetworks.networks[name] = n
+}
+
+// UnregisterMemoryNetwork removes network registered with name.
+func UnregisterMemoryNetwork(name string) {
+	memoryNetworks.Lock()
+	defer memoryNetworks.Unlock()
+
+	delete(memoryNetworks.networks, name)
+}
+
+func getMemoryNetwork(name string) (*MemoryNetwork, error) {
+	memoryNetworks.Lock()
+	defer memoryNetworks.Unlock()
+
+	n, ok := memoryNetworks.networks[name]
+	if !ok {
+		return nil, errors.Errorf("memory network %s is not registered", name)
+	}
+	return n, nil
+}
+
+// memoryAddr is address of memory transport.
+type memoryAddr string
+
+func (memoryAddr) Network() string {
+	return "memory"
+}
+
+func (a memoryAddr) String() string {
+	return string(a)
+}
+
+// memoryTransport sends packets through MemoryNetwork. If datagram is true, sending to address nobody listens is
+// not an error, as it is for UDP.
+type memoryTransport struct {
+	baseTransport
+	network  *MemoryNetwork
+	address  string
+	datagram bool
+}
+
+func newMemoryTransport(network *MemoryNetwork, address string, proxy relay.Proxy, datagram bool) (*memoryTransport, error) {
+	t := &memoryTransport{network: network, datagram: datagram}
+	var err error
+	t.address, err = network.listen(address, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen on memory network")
+	}
+	t.baseTransport = newBaseTransport(proxy, t.address)
+	t.sendFunc = t.send
+	return t, nil
+}
+
+func (t *memoryTransport) send(recvAddress string, _ types.PacketType, data []byte) error {
+	delay, ok, err := t.network.route(t.address, recvAddress)
+	if err != nil {
+		if t.datagram {
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		metrics.NetworkPacketDroppedFaultTotal.Inc()
+		return nil
+	}
+
+	data = append([]byte(nil), data...)
+	time.AfterFunc(delay, func() {
+		t.network.deliver(t.address, recvAddress, data)
+	})
+	return nil
+}
+
+func (t *memoryTransport) receive(from net.Addr, data []byte) {
+	if t.isBanned(from) {
+		log.Debug("[ memoryTransport ] Dropped packet from banned peer ", from)
+		return
+	}
+	if t.isDenied(from) {
+		log.Debug("[ memoryTransport ] Dropped packet from denied peer ", from)
+		return
+	}
+	msg, err := t.serializer.DeserializePacket(bytes.NewReader(data))
+	if err != nil {
+		log.Error("[ memoryTransport ] Failed to deserialize packet: ", err)
+		t.reputation.Penalize(from.String(), host.OffenceMalformedPacket)
+		return
+	}
+	ctx, _ := inslogger.WithTraceField(context.Background(), msg.TraceID)
+	t.handlePacket(ctx, msg, from)
+}
+
+// Listen starts networking.
+func (t *memoryTransport) Listen(ctx context.Context, started chan struct{}) error {
+	inslogger.FromContext(ctx).Info("Start memory transport on ", t.address)
+	started <- struct{}{}
+	<-t.disconnectFinished
+	return nil
+}
+
+// Stop stops networking.
+func (t *memoryTransport) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	log.Info("Stop memory transport")
+	t.prepareDisconnect()
+	t.network.close(t.address)
+}
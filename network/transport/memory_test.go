@@ -0,0 +1,135 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"context"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/relay"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMemoryTransport(t *testing.T) {
+	RegisterMemoryNetwork("TestMemoryTransport", NewMemoryNetwork(1))
+	defer UnregisterMemoryNetwork("TestMemoryTransport")
+
+	cfg1 := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:1", MemoryNetwork: "TestMemoryTransport"}
+	cfg2 := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:2", MemoryNetwork: "TestMemoryTransport",
+		Compression: "flate"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestMemoryUDPTransport(t *testing.T) {
+	RegisterMemoryNetwork("TestMemoryUDPTransport", NewMemoryNetwork(1))
+	defer UnregisterMemoryNetwork("TestMemoryUDPTransport")
+
+	cfg1 := configuration.Transport{Protocol: "MEMORY_UDP", Address: "127.0.0.1:1", MemoryNetwork: "TestMemoryUDPTransport"}
+	cfg2 := configuration.Transport{Protocol: "MEMORY_UDP", Address: "127.0.0.1:2", MemoryNetwork: "TestMemoryUDPTransport"}
+
+	suite.Run(t, NewConsensusSuite(cfg1, cfg2))
+}
+
+func startMemoryTransport(t *testing.T, name, address string) (Transport, *host.Host) {
+	tp, err := NewTransport(configuration.Transport{Protocol: "MEMORY", Address: address, MemoryNetwork: name}, relay.NewProxy())
+	require.NoError(t, err)
+	started := make(chan struct{}, 1)
+	go tp.Listen(context.Background(), started) // nolint
+	<-started
+
+	h, err := host.NewHost(tp.PublicAddress())
+	require.NoError(t, err)
+	return tp, h
+}
+
+func stopMemoryTransport(tp Transport) {
+	go tp.Stop()
+	<-tp.Stopped()
+	tp.Close()
+}
+
+// receive returns packet received by transport in timeout or nil.
+func receive(tp Transport, timeout time.Duration) *packet.Packet {
+	select {
+	case msg := <-tp.Packets():
+		return msg
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+func TestMemoryNetwork(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	network := NewMemoryNetwork(1)
+	RegisterMemoryNetwork("TestMemoryNetwork", network)
+	defer UnregisterMemoryNetwork("TestMemoryNetwork")
+
+	_, err := NewTransport(configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:1", MemoryNetwork: "unknown"}, relay.NewProxy())
+	require.Error(t, err)
+
+	tp1, host1 := startMemoryTransport(t, "TestMemoryNetwork", "127.0.0.1:0")
+	defer stopMemoryTransport(tp1)
+	tp2, host2 := startMemoryTransport(t, "TestMemoryNetwork", "127.0.0.1:0")
+	defer stopMemoryTransport(tp2)
+	require.NotEqual(t, host1.Address.String(), host2.Address.String())
+
+	_, err = NewTransport(configuration.Transport{Protocol: "MEMORY", Address: tp1.PublicAddress(), MemoryNetwork: "TestMemoryNetwork"}, relay.NewProxy())
+	require.Error(t, err)
+
+	ctx := context.Background()
+	send := func() {
+		msg := packet.NewBuilder(host1).Receiver(host2).Type(packet.TestPacket).Request(&packet.RequestTest{}).Build()
+		require.NoError(t, tp1.SendPacket(ctx, msg))
+	}
+
+	send()
+	require.NotNil(t, receive(tp2, time.Second))
+
+	network.SetLoss(1)
+	send()
+	require.Nil(t, receive(tp2, 100*time.Millisecond))
+	network.SetLoss(0)
+
+	network.Partition([]string{tp1.PublicAddress()}, []string{tp2.PublicAddress()})
+	send()
+	require.Nil(t, receive(tp2, 100*time.Millisecond))
+	network.Heal()
+
+	network.SchedulePartition(time.Hour, 0, []string{tp1.PublicAddress()}, []string{tp2.PublicAddress()})
+	send()
+	require.NotNil(t, receive(tp2, time.Second))
+	network.Heal()
+
+	network.SetLatency(200*time.Millisecond, 0)
+	sent := time.Now()
+	send()
+	require.NotNil(t, receive(tp2, time.Second))
+	require.True(t, time.Since(sent) >= 200*time.Millisecond)
+
+	// nobody listens on address
+	msg := packet.NewBuilder(host1).Receiver(host1).Type(packet.TestPacket).Request(&packet.RequestTest{}).Build()
+	msg.Receiver, _ = host.NewHost("127.0.0.1:65000")
+	require.Error(t, tp1.SendPacket(ctx, msg))
+}
@@ -0,0 +1,173 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package nat
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// mappingLifetime is lifetime of mapping requested from gateway, mapping is renewed at half of it
+	mappingLifetime = time.Hour
+	// discoveryTimeout limits time spent on each discovery protocol
+	discoveryTimeout = 2 * time.Second
+)
+
+// PortMapper creates port mappings on NAT gateway.
+type PortMapper interface {
+	// ExternalIP returns public ip address of gateway.
+	ExternalIP() (net.IP, error)
+	// AddMapping maps external port of gateway to internal port of this host, returns mapped external port.
+	AddMapping(protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error)
+	// DeleteMapping removes mapping from gateway.
+	DeleteMapping(protocol string, internalPort, externalPort int) error
+	// String returns name of port mapping protocol.
+	String() string
+}
+
+// Discover finds gateway supporting UPnP IGD, then NAT-PMP.
+func Discover(timeout time.Duration) (PortMapper, error) {
+	upnp, upnpErr := discoverUPnP(timeout)
+	if upnpErr == nil {
+		return upnp, nil
+	}
+	pmp, pmpErr := discoverNATPMP(timeout)
+	if pmpErr == nil {
+		return pmp, nil
+	}
+	return nil, errors.Errorf("[ Discover ] no gateway found: upnp: %s; nat-pmp: %s", upnpErr, pmpErr)
+}
+
+// Mapping is port mapping on NAT gateway which is renewed until closed.
+type Mapping struct {
+	mapper       PortMapper
+	protocol     string
+	internalPort int
+	externalPort int
+	externalIP   net.IP
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+var (
+	mappings     = make(map[string]*Mapping)
+	mappingsLock sync.Mutex
+
+	// discover is replaced in tests
+	discover = Discover
+)
+
+func mappingKey(protocol string, port int) string {
+	return protocol + ":" + strconv.Itoa(port)
+}
+
+// Map maps port of this host with protocol (TCP or UDP) on NAT gateway. If port is already mapped
+// existing mapping is returned.
+func Map(protocol string, port int) (*Mapping, error) {
+	if port == 0 {
+		return nil, errors.New("[ Map ] can't map random port")
+	}
+
+	mappingsLock.Lock()
+	defer mappingsLock.Unlock()
+
+	key := mappingKey(protocol, port)
+	if m, ok := mappings[key]; ok {
+		return m, nil
+	}
+
+	mapper, err := discover(discoveryTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Map ] failed to discover gateway")
+	}
+	ip, err := mapper.ExternalIP()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Map ] failed to get external ip")
+	}
+	externalPort, err := mapper.AddMapping(protocol, port, port, mappingLifetime)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Map ] failed to add mapping")
+	}
+
+	m := &Mapping{
+		mapper:       mapper,
+		protocol:     protocol,
+		internalPort: port,
+		externalPort: externalPort,
+		externalIP:   ip,
+		stop:         make(chan struct{}),
+	}
+	mappings[key] = m
+	go m.renew()
+
+	log.Infof("[ Map ] %s mapped %s port %d to %s", mapper, protocol, port, m.ExternalAddress())
+	return m, nil
+}
+
+// ExternalAddress returns address of this host as it's seen from outside of NAT.
+func (m *Mapping) ExternalAddress() string {
+	return net.JoinHostPort(m.externalIP.String(), strconv.Itoa(m.externalPort))
+}
+
+func (m *Mapping) renew() {
+	ticker := time.NewTicker(mappingLifetime / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			_, err := m.mapper.AddMapping(m.protocol, m.internalPort, m.externalPort, mappingLifetime)
+			if err != nil {
+				log.Warnf("[ renew ] failed to renew %s mapping of port %d: %s", m.protocol, m.internalPort, err)
+			}
+		}
+	}
+}
+
+// Close stops renewal and removes mapping from gateway.
+func (m *Mapping) Close() error {
+	var err error
+	m.stopOnce.Do(func() {
+		mappingsLock.Lock()
+		delete(mappings, mappingKey(m.protocol, m.internalPort))
+		mappingsLock.Unlock()
+
+		close(m.stop)
+		err = m.mapper.DeleteMapping(m.protocol, m.internalPort, m.externalPort)
+	})
+	return err
+}
+
+// localIPFor returns local address used to reach host.
+func localIPFor(host string) (net.IP, error) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(host, "1"))
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseVerbose(conn)
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
@@ -0,0 +1,238 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package nat
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGateway answers NAT-PMP requests mapping every port to port+1000.
+func fakeGateway(t *testing.T) (*net.UDPAddr, func()) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var res []byte
+			switch {
+			case n == 2 && buf[1] == natpmpOpExternalIP:
+				res = make([]byte, 12)
+				copy(res[8:], []byte{203, 0, 113, 7})
+			case n == 12:
+				res = make([]byte, 16)
+				binary.BigEndian.PutUint16(res[8:10], binary.BigEndian.Uint16(buf[4:6]))
+				binary.BigEndian.PutUint16(res[10:12], binary.BigEndian.Uint16(buf[4:6])+1000)
+				copy(res[12:16], buf[8:12])
+			default:
+				continue
+			}
+			res[1] = buf[1] | natpmpResponseFlag
+			_, _ = conn.WriteTo(res, addr)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr), func() { _ = conn.Close() }
+}
+
+func TestNATPMP(t *testing.T) {
+	addr, stop := fakeGateway(t)
+	defer stop()
+
+	pmp := newNATPMP(addr, time.Second)
+	ip, err := pmp.ExternalIP()
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", ip.String())
+
+	port, err := pmp.AddMapping("UDP", 19000, 19000, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 20000, port)
+
+	assert.NoError(t, pmp.DeleteMapping("UDP", 19000, 20000))
+
+	_, err = pmp.AddMapping("SCTP", 19000, 19000, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestNATPMP_Timeout(t *testing.T) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	pmp := newNATPMP(conn.LocalAddr().(*net.UDPAddr), 300*time.Millisecond)
+	_, err = pmp.ExternalIP()
+	assert.Error(t, err)
+}
+
+func TestGatewayFromProcRoute(t *testing.T) {
+	f, err := ioutil.TempFile("", "route")
+	require.NoError(t, err)
+	_, err = f.WriteString("Iface\tDestination\tGateway\tFlags\n" +
+		"eth0\t0000A8C0\t00000000\t0001\n" +
+		"eth0\t00000000\t0100A8C0\t0003\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	ip, err := gatewayFromProcRoute(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.1", ip.String())
+}
+
+const testDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+func TestParseDescription(t *testing.T) {
+	control, service, err := parseDescription(strings.NewReader(testDescription), "http://192.168.0.1:5000/rootDesc.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "http://192.168.0.1:5000/ctl/IPConn", control)
+	assert.Equal(t, wanIPService, service)
+
+	_, _, err = parseDescription(strings.NewReader(`<root><device></device></root>`), "http://192.168.0.1/")
+	assert.Error(t, err)
+}
+
+func TestSSDPLocation(t *testing.T) {
+	res := "HTTP/1.1 200 OK\r\n" +
+		"ST: urn:schemas-upnp-org:device:InternetGatewayDevice:1\r\n" +
+		"LOCATION: http://192.168.0.1:5000/rootDesc.xml\r\n\r\n"
+	assert.Equal(t, "http://192.168.0.1:5000/rootDesc.xml", ssdpLocation([]byte(res)))
+	assert.Equal(t, "", ssdpLocation([]byte("garbage")))
+}
+
+func TestUPnP(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_, _ = w.Write([]byte(testDescription))
+			return
+		}
+		action := r.Header.Get("SOAPAction")
+		actions = append(actions, action)
+		if strings.HasSuffix(action, `#GetExternalIPAddress"`) {
+			_, _ = w.Write([]byte(`<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">` +
+				`<s:Body><u:GetExternalIPAddressResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">` +
+				`<NewExternalIPAddress>203.0.113.7</NewExternalIPAddress>` +
+				`</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`))
+		}
+	}))
+	defer server.Close()
+
+	u, err := newUPnP(server.URL+"/rootDesc.xml", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/ctl/IPConn", u.controlURL)
+
+	ip, err := u.ExternalIP()
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", ip.String())
+
+	port, err := u.AddMapping("TCP", 19000, 19000, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 19000, port)
+	assert.NoError(t, u.DeleteMapping("TCP", 19000, 19000))
+
+	assert.Equal(t, []string{
+		`"` + wanIPService + `#GetExternalIPAddress"`,
+		`"` + wanIPService + `#AddPortMapping"`,
+		`"` + wanIPService + `#DeletePortMapping"`,
+	}, actions)
+}
+
+type testMapper struct {
+	added   int
+	deleted int
+}
+
+func (m *testMapper) ExternalIP() (net.IP, error) {
+	return net.IPv4(203, 0, 113, 7), nil
+}
+
+func (m *testMapper) AddMapping(protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	m.added++
+	return externalPort, nil
+}
+
+func (m *testMapper) DeleteMapping(protocol string, internalPort, externalPort int) error {
+	m.deleted++
+	return nil
+}
+
+func (m *testMapper) String() string {
+	return "test"
+}
+
+func TestMap(t *testing.T) {
+	mapper := &testMapper{}
+	defer func() { discover = Discover }()
+	discover = func(time.Duration) (PortMapper, error) {
+		return mapper, nil
+	}
+
+	_, err := Map("UDP", 0)
+	assert.Error(t, err)
+
+	m, err := Map("UDP", 19000)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7:19000", m.ExternalAddress())
+
+	same, err := Map("UDP", 19000)
+	require.NoError(t, err)
+	assert.True(t, m == same)
+	assert.Equal(t, 1, mapper.added)
+
+	require.NoError(t, m.Close())
+	require.NoError(t, m.Close())
+	assert.Equal(t, 1, mapper.deleted)
+
+	other, err := Map("UDP", 19000)
+	require.NoError(t, err)
+	assert.False(t, m == other)
+	require.NoError(t, other.Close())
+}
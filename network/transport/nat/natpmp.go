@@ -0,0 +1,200 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package nat
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/insolar/insolar/network/utils"
+	"github.com/pkg/errors"
+)
+
+// NAT-PMP protocol, RFC 6886
+const (
+	natpmpPort          = 5351
+	natpmpVersion       = 0
+	natpmpOpExternalIP  = 0
+	natpmpOpMapUDP      = 1
+	natpmpOpMapTCP      = 2
+	natpmpResponseFlag  = 128
+	natpmpInitialTimout = 250 * time.Millisecond
+)
+
+type natpmp struct {
+	gateway *net.UDPAddr
+	timeout time.Duration
+}
+
+func discoverNATPMP(timeout time.Duration) (*natpmp, error) {
+	gateway, err := defaultGateway()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ discoverNATPMP ] failed to find default gateway")
+	}
+	pmp := newNATPMP(&net.UDPAddr{IP: gateway, Port: natpmpPort}, timeout)
+	if _, err := pmp.ExternalIP(); err != nil {
+		return nil, errors.Wrap(err, "[ discoverNATPMP ] gateway doesn't respond")
+	}
+	return pmp, nil
+}
+
+func newNATPMP(gateway *net.UDPAddr, timeout time.Duration) *natpmp {
+	return &natpmp{gateway: gateway, timeout: timeout}
+}
+
+func (n *natpmp) String() string {
+	return "NAT-PMP"
+}
+
+// ExternalIP returns public ip address of gateway.
+func (n *natpmp) ExternalIP() (net.IP, error) {
+	res, err := n.call([]byte{natpmpVersion, natpmpOpExternalIP}, 12)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ExternalIP ] request failed")
+	}
+	return net.IPv4(res[8], res[9], res[10], res[11]), nil
+}
+
+// AddMapping maps external port of gateway to internal port of this host.
+func (n *natpmp) AddMapping(protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	op, err := natpmpOp(protocol)
+	if err != nil {
+		return 0, err
+	}
+	res, err := n.call(natpmpMappingRequest(op, internalPort, externalPort, uint32(lifetime/time.Second)), 16)
+	if err != nil {
+		return 0, errors.Wrap(err, "[ AddMapping ] request failed")
+	}
+	return int(binary.BigEndian.Uint16(res[10:12])), nil
+}
+
+// DeleteMapping removes mapping from gateway.
+func (n *natpmp) DeleteMapping(protocol string, internalPort, externalPort int) error {
+	op, err := natpmpOp(protocol)
+	if err != nil {
+		return err
+	}
+	_, err = n.call(natpmpMappingRequest(op, internalPort, 0, 0), 16)
+	return errors.Wrap(err, "[ DeleteMapping ] request failed")
+}
+
+func natpmpOp(protocol string) (byte, error) {
+	switch protocol {
+	case "UDP":
+		return natpmpOpMapUDP, nil
+	case "TCP":
+		return natpmpOpMapTCP, nil
+	}
+	return 0, errors.Errorf("unsupported protocol %s", protocol)
+}
+
+func natpmpMappingRequest(op byte, internalPort, externalPort int, lifetime uint32) []byte {
+	req := make([]byte, 12)
+	req[0] = natpmpVersion
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:12], lifetime)
+	return req
+}
+
+// call sends request to gateway retrying with doubling timeout as RFC requires, but no longer than n.timeout.
+func (n *natpmp) call(req []byte, size int) ([]byte, error) {
+	conn, err := net.DialUDP("udp", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseVerbose(conn)
+
+	deadline := time.Now().Add(n.timeout)
+	res := make([]byte, 16)
+	for timeout := natpmpInitialTimout; time.Now().Before(deadline); timeout *= 2 {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+		readDeadline := time.Now().Add(timeout)
+		if readDeadline.After(deadline) {
+			readDeadline = deadline
+		}
+		if err := conn.SetReadDeadline(readDeadline); err != nil {
+			return nil, err
+		}
+
+		read, err := conn.Read(res)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				continue
+			}
+			return nil, err
+		}
+		if read < size || res[0] != natpmpVersion || res[1] != req[1]|natpmpResponseFlag {
+			continue
+		}
+		if code := binary.BigEndian.Uint16(res[2:4]); code != 0 {
+			return nil, errors.Errorf("gateway returned result code %d", code)
+		}
+		return res[:size], nil
+	}
+	return nil, errors.New("timeout")
+}
+
+// defaultGateway returns ip of default gateway from linux routing table,
+// on other systems it guesses first address of local network.
+func defaultGateway() (net.IP, error) {
+	if ip, err := gatewayFromProcRoute("/proc/net/route"); err == nil {
+		return ip, nil
+	}
+	local, err := localIPFor("8.8.8.8")
+	if err != nil {
+		return nil, err
+	}
+	local = local.To4()
+	if local == nil {
+		return nil, errors.New("no local ipv4 address")
+	}
+	return net.IPv4(local[0], local[1], local[2], 1), nil
+}
+
+func gatewayFromProcRoute(path string) (net.IP, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseVerbose(f)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Iface Destination Gateway ...
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		raw, err := hex.DecodeString(fields[2])
+		if err != nil || len(raw) != 4 {
+			continue
+		}
+		// addresses are written in host (little endian) byte order
+		return net.IPv4(raw[3], raw[2], raw[1], raw[0]), nil
+	}
+	return nil, errors.New("default route not found")
+}
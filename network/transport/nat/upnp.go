@@ -0,0 +1,264 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/insolar/insolar/network/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	ssdpAddress   = "239.255.255.250:1900"
+	ssdpSearch    = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+	wanIPService  = "urn:schemas-upnp-org:service:WANIPConnection:1"
+	wanPPPService = "urn:schemas-upnp-org:service:WANPPPConnection:1"
+	upnpMappingID = "insolar"
+)
+
+type upnp struct {
+	controlURL string
+	service    string
+	localIP    net.IP
+	client     *http.Client
+}
+
+func discoverUPnP(timeout time.Duration) (*upnp, error) {
+	location, err := ssdpDiscover(timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ discoverUPnP ] ssdp search failed")
+	}
+	return newUPnP(location, timeout)
+}
+
+// newUPnP reads gateway description from location and finds WAN connection service.
+func newUPnP(location string, timeout time.Duration) (*upnp, error) {
+	client := &http.Client{Timeout: timeout}
+	res, err := client.Get(location)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newUPnP ] failed to get device description")
+	}
+	defer utils.CloseVerbose(res.Body)
+
+	controlURL, service, err := parseDescription(res.Body, location)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newUPnP ] failed to parse device description")
+	}
+
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newUPnP ] bad location")
+	}
+	localIP, err := localIPFor(locationURL.Hostname())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newUPnP ] failed to get local address")
+	}
+
+	return &upnp{controlURL: controlURL, service: service, localIP: localIP, client: client}, nil
+}
+
+func (u *upnp) String() string {
+	return "UPnP"
+}
+
+// ExternalIP returns public ip address of gateway.
+func (u *upnp) ExternalIP() (net.IP, error) {
+	var res struct {
+		IP string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := u.soap("GetExternalIPAddress", "", &res); err != nil {
+		return nil, errors.Wrap(err, "[ ExternalIP ] request failed")
+	}
+	ip := net.ParseIP(res.IP)
+	if ip == nil {
+		return nil, errors.Errorf("[ ExternalIP ] bad address %q", res.IP)
+	}
+	return ip, nil
+}
+
+// AddMapping maps external port of gateway to internal port of this host.
+func (u *upnp) AddMapping(protocol string, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	args := fmt.Sprintf("<NewRemoteHost></NewRemoteHost>"+
+		"<NewExternalPort>%d</NewExternalPort>"+
+		"<NewProtocol>%s</NewProtocol>"+
+		"<NewInternalPort>%d</NewInternalPort>"+
+		"<NewInternalClient>%s</NewInternalClient>"+
+		"<NewEnabled>1</NewEnabled>"+
+		"<NewPortMappingDescription>%s</NewPortMappingDescription>"+
+		"<NewLeaseDuration>%d</NewLeaseDuration>",
+		externalPort, protocol, internalPort, u.localIP, upnpMappingID, int(lifetime/time.Second))
+	if err := u.soap("AddPortMapping", args, nil); err != nil {
+		return 0, errors.Wrap(err, "[ AddMapping ] request failed")
+	}
+	return externalPort, nil
+}
+
+// DeleteMapping removes mapping from gateway.
+func (u *upnp) DeleteMapping(protocol string, internalPort, externalPort int) error {
+	args := fmt.Sprintf("<NewRemoteHost></NewRemoteHost>"+
+		"<NewExternalPort>%d</NewExternalPort>"+
+		"<NewProtocol>%s</NewProtocol>",
+		externalPort, protocol)
+	return errors.Wrap(u.soap("DeletePortMapping", args, nil), "[ DeleteMapping ] request failed")
+}
+
+func (u *upnp) soap(action, args string, result interface{}) error {
+	body := `<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" ` +
+		`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:` + action + ` xmlns:u="` + u.service + `">` + args + `</u:` + action + `></s:Body>` +
+		`</s:Envelope>`
+
+	req, err := http.NewRequest("POST", u.controlURL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", `"`+u.service+"#"+action+`"`)
+
+	res, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer utils.CloseVerbose(res.Body)
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("gateway returned status %s", res.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return xml.Unmarshal(data, result)
+}
+
+type upnpDevice struct {
+	Services []struct {
+		ServiceType string `xml:"serviceType"`
+		ControlURL  string `xml:"controlURL"`
+	} `xml:"serviceList>service"`
+	Devices []upnpDevice `xml:"deviceList>device"`
+}
+
+// parseDescription finds control url of WAN connection service in device description.
+func parseDescription(r io.Reader, location string) (string, string, error) {
+	var root struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return "", "", err
+	}
+
+	base := location
+	if root.URLBase != "" {
+		base = root.URLBase
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", "", err
+	}
+
+	control, service := findWANService(root.Device)
+	if control == "" {
+		return "", "", errors.New("WAN connection service not found")
+	}
+	controlURL, err := baseURL.Parse(control)
+	if err != nil {
+		return "", "", err
+	}
+	return controlURL.String(), service, nil
+}
+
+func findWANService(device upnpDevice) (string, string) {
+	for _, s := range device.Services {
+		if s.ServiceType == wanIPService || s.ServiceType == wanPPPService {
+			return s.ControlURL, s.ServiceType
+		}
+	}
+	for _, d := range device.Devices {
+		if control, service := findWANService(d); control != "" {
+			return control, service
+		}
+	}
+	return "", ""
+}
+
+// ssdpDiscover searches internet gateway device with SSDP and returns location of its description.
+func ssdpDiscover(timeout time.Duration) (string, error) {
+	addr, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return "", err
+	}
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return "", err
+	}
+	defer utils.CloseVerbose(conn)
+
+	search := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddress + "\r\n" +
+		"ST: " + ssdpSearch + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: " + strconv.Itoa(int(timeout/time.Second)+1) + "\r\n\r\n"
+	if _, err := conn.WriteTo([]byte(search), addr); err != nil {
+		return "", err
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return "", err
+		}
+		if location := ssdpLocation(buf[:n]); location != "" {
+			return location, nil
+		}
+	}
+}
+
+// ssdpLocation returns LOCATION header of SSDP response for internet gateway device.
+func ssdpLocation(data []byte) string {
+	res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+	if err != nil {
+		return ""
+	}
+	utils.CloseVerbose(res.Body)
+	if !strings.Contains(res.Header.Get("ST"), "InternetGatewayDevice") {
+		return ""
+	}
+	return res.Header.Get("LOCATION")
+}
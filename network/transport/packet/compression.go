@@ -0,0 +1,205 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// CompressionThreshold is min size of serialized packet body to compress it for packet types without own threshold.
+const CompressionThreshold = 1024
+
+// compressionThresholds are min sizes of serialized bodies of packet types that differ from CompressionThreshold.
+var compressionThresholds = map[types.PacketType]int{
+	// RPC and Cascade carry parcels such as ExecutorResults and HeavyPayload
+	types.RPC:         4096,
+	types.Cascade:     4096,
+	types.Replication: 4096,
+}
+
+// ErrTooLarge is returned by Decompress if decompressed data is larger than MaxPacketSize.
+var ErrTooLarge = errors.New("decompressed data is larger than max packet size")
+
+// Codec compresses packet bodies. ID is written to packet header, it must be in range 1..8.
+type Codec interface {
+	ID() byte
+	Compress(data []byte) ([]byte, error)
+	// Decompress returns ErrTooLarge if data is decompressed to more than MaxPacketSize bytes, so a peer can't
+	// make node allocate memory it never sent.
+	Decompress(data []byte) ([]byte, error)
+}
+
+type flateCodec struct{}
+
+func (flateCodec) ID() byte {
+	return 1
+}
+
+func (flateCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (flateCodec) Decompress(data []byte) ([]byte, error) {
+	result, err := ioutil.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), MaxPacketSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(result) > MaxPacketSize {
+		return nil, ErrTooLarge
+	}
+	return result, nil
+}
+
+// snappyCodec writes snappy compatible blocks.
+type snappyCodec struct{}
+
+func (snappyCodec) ID() byte {
+	return 2
+}
+
+func (snappyCodec) Compress(data []byte) ([]byte, error) {
+	return s2.EncodeSnappy(nil, data), nil
+}
+
+func (snappyCodec) Decompress(data []byte) ([]byte, error) {
+	length, err := s2.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+	if length > MaxPacketSize {
+		return nil, ErrTooLarge
+	}
+	return s2.Decode(nil, data)
+}
+
+// zstdCodec shares encoder and decoder between packets, their EncodeAll and DecodeAll are safe for concurrent use.
+type zstdCodec struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCodec() zstdCodec {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create zstd encoder"))
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxPacketSize))
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create zstd decoder"))
+	}
+	return zstdCodec{encoder: encoder, decoder: decoder}
+}
+
+func (zstdCodec) ID() byte {
+	return 3
+}
+
+func (c zstdCodec) Compress(data []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(data, nil), nil
+}
+
+func (c zstdCodec) Decompress(data []byte) ([]byte, error) {
+	result, err := c.decoder.DecodeAll(data, nil)
+	if err == zstd.ErrDecoderSizeExceeded {
+		return nil, ErrTooLarge
+	}
+	return result, err
+}
+
+// Codecs are known compression codecs by name.
+var Codecs = map[string]Codec{
+	"flate":  flateCodec{},
+	"snappy": snappyCodec{},
+	"zstd":   newZstdCodec(),
+}
+
+// compress returns body compressed with codec, or nil if body is too small or compression doesn't make it smaller.
+func compress(codec Codec, packetType types.PacketType, body []byte) []byte {
+	threshold, ok := compressionThresholds[packetType]
+	if !ok {
+		threshold = CompressionThreshold
+	}
+	if codec == nil || len(body) < threshold {
+		return nil
+	}
+
+	start := time.Now()
+	compressed, err := codec.Compress(body)
+	if err != nil || len(compressed) >= len(body) {
+		return nil
+	}
+
+	label := packetType.String()
+	metrics.NetworkCompressionRatio.WithLabelValues(label).Observe(float64(len(body)) / float64(len(compressed)))
+	metrics.NetworkCompressionTime.WithLabelValues(label).Observe(time.Since(start).Seconds())
+	metrics.NetworkCompressionSavedBytes.WithLabelValues(label).Add(float64(len(body) - len(compressed)))
+	return compressed
+}
+
+// CodecByID returns known codec with id or nil.
+func CodecByID(id byte) Codec {
+	for _, c := range Codecs {
+		if c.ID() == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// codecMask packs codec ids to bitmask for packet header.
+func codecMask(ids []byte) byte {
+	var mask byte
+	for _, id := range ids {
+		if id > 0 && id <= 8 {
+			mask |= 1 << (id - 1)
+		}
+	}
+	return mask
+}
+
+// codecIDs unpacks codec ids from packet header bitmask.
+func codecIDs(mask byte) []byte {
+	var ids []byte
+	for id := byte(1); id <= 8; id++ {
+		if mask&(1<<(id-1)) != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
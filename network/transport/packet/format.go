@@ -0,0 +1,206 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"net"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// Format encodes packets to bodies of serialized packets. ID is written to packet header, it must be in range 0..4.
+// Zero is gob format that every node reads.
+type Format interface {
+	ID() byte
+	Marshal(buf *bytes.Buffer, q *Packet) error
+	Unmarshal(body io.Reader, q *Packet) error
+}
+
+type gobFormat struct{}
+
+func (gobFormat) ID() byte {
+	return 0
+}
+
+func (gobFormat) Marshal(buf *bytes.Buffer, q *Packet) error {
+	return gob.NewEncoder(buf).Encode(q)
+}
+
+func (gobFormat) Unmarshal(body io.Reader, q *Packet) error {
+	return gob.NewDecoder(body).Decode(q)
+}
+
+// protobufFormat encodes packets as Packet messages of packet.proto, so packets can be read by implementations
+// in other languages. Registered payloads are sent as their envelopes, other payloads are gob-encoded.
+type protobufFormat struct{}
+
+func (protobufFormat) ID() byte {
+	return 1
+}
+
+func (protobufFormat) Marshal(buf *bytes.Buffer, q *Packet) error {
+	msg := &pbPacket{
+		Sender:        hostToProto(q.Sender),
+		Receiver:      hostToProto(q.Receiver),
+		Type:          int32(q.Type),
+		RequestId:     uint64(q.RequestID),
+		RemoteAddress: q.RemoteAddress,
+		TraceId:       q.TraceID,
+		IsResponse:    q.IsResponse,
+	}
+	if q.Error != nil {
+		msg.Error = q.Error.Error()
+	}
+	switch data := q.Data.(type) {
+	case nil:
+	case *RegisteredPayload:
+		msg.Payload = &pbPayload{Registered: true, Version: uint32(data.Version), Body: data.Body}
+	default:
+		var body bytes.Buffer
+		if err := gob.NewEncoder(&body).Encode(&gobPayload{Data: q.Data}); err != nil {
+			return errors.Wrap(err, "failed to encode payload")
+		}
+		msg.Payload = &pbPayload{Body: body.Bytes()}
+	}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = buf.Write(data)
+	return err
+}
+
+func (protobufFormat) Unmarshal(body io.Reader, q *Packet) error {
+	var data []byte
+	if buf, ok := body.(*bytes.Buffer); ok {
+		data = buf.Bytes()
+	} else {
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(body); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+	msg := &pbPacket{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+
+	q.Sender = hostFromProto(msg.Sender)
+	q.Receiver = hostFromProto(msg.Receiver)
+	q.Type = types.PacketType(msg.Type)
+	q.RequestID = network.RequestID(msg.RequestId)
+	q.RemoteAddress = msg.RemoteAddress
+	q.TraceID = msg.TraceId
+	q.IsResponse = msg.IsResponse
+	if msg.Error != "" {
+		q.Error = errors.New(msg.Error)
+	}
+	switch {
+	case msg.Payload == nil:
+	case msg.Payload.Registered:
+		if msg.Payload.Version > 0xFFFF {
+			return errors.Errorf("invalid payload version %d", msg.Payload.Version)
+		}
+		q.Data = &RegisteredPayload{Version: uint16(msg.Payload.Version), Body: msg.Payload.Body}
+	default:
+		payload := &gobPayload{}
+		if err := gob.NewDecoder(bytes.NewReader(msg.Payload.Body)).Decode(payload); err != nil {
+			return errors.Wrap(err, "failed to decode payload")
+		}
+		q.Data = payload.Data
+	}
+	return nil
+}
+
+// gobPayload wraps payload that is not registered in PayloadRegistry, so gob sends its type name.
+type gobPayload struct {
+	Data interface{}
+}
+
+func hostToProto(h *host.Host) *pbHost {
+	if h == nil {
+		return nil
+	}
+	msg := &pbHost{NodeId: h.NodeID[:], ShortId: uint32(h.ShortID)}
+	if h.Address != nil {
+		msg.Ip = h.Address.IP
+		msg.Port = uint32(h.Address.Port)
+		msg.Zone = h.Address.Zone
+	}
+	return msg
+}
+
+func hostFromProto(msg *pbHost) *host.Host {
+	if msg == nil {
+		return nil
+	}
+	h := &host.Host{ShortID: core.ShortNodeID(msg.ShortId)}
+	copy(h.NodeID[:], msg.NodeId)
+	if len(msg.Ip) > 0 || msg.Port != 0 {
+		h.Address = &host.Address{UDPAddr: net.UDPAddr{IP: net.IP(msg.Ip), Port: int(msg.Port), Zone: msg.Zone}}
+	}
+	return h
+}
+
+// Formats are known packet formats by name.
+var Formats = map[string]Format{
+	"gob":      gobFormat{},
+	"protobuf": protobufFormat{},
+}
+
+// FormatByID returns known format with id or nil.
+func FormatByID(id byte) Format {
+	for _, f := range Formats {
+		if f.ID() == id {
+			return f
+		}
+	}
+	return nil
+}
+
+// formatMask packs ids of formats other than gob to high half of format byte of packet header.
+func formatMask(ids []byte) byte {
+	var mask byte
+	for _, id := range ids {
+		if id > 0 && id <= 4 {
+			mask |= 1 << (id + 3)
+		}
+	}
+	return mask
+}
+
+// formatIDs unpacks format ids from format byte of packet header, gob is not included.
+func formatIDs(b byte) []byte {
+	var ids []byte
+	for id := byte(1); id <= 4; id++ {
+		if b&(1<<(id+3)) != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -22,82 +22,222 @@ import (
 	"encoding/binary"
 	"encoding/gob"
 	"io"
+	"io/ioutil"
 
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/utils/pool"
 	"github.com/pkg/errors"
 )
 
 // Packet is DHT packet object.
 type Packet struct {
-	Sender        *host.Host
-	Receiver      *host.Host
-	Type          types.PacketType
-	RequestID     network.RequestID
+	Sender    *host.Host
+	Receiver  *host.Host
+	Type      types.PacketType
+	RequestID network.RequestID
+	// RemoteAddress is address of connection received packet came from
 	RemoteAddress string
 
 	TraceID    string
 	Data       interface{}
 	Error      error
 	IsResponse bool
+
+	// signature is set for received signed packets
+	signature *Signature
+	// accepted are capabilities of sender of received packet
+	accepted Capabilities
+}
+
+// Accepted returns codecs and formats sender of received packet is able to read.
+func (q *Packet) Accepted() Capabilities {
+	return q.accepted
+}
+
+// Header of serialized packet is 8 bytes: uvarint length of body takes at most 5 bytes, then format byte, id of codec
+// the body is compressed with and bitmask of codecs accepted by sender. Zero codec means uncompressed body, high bit
+// of codec byte means signed body.
+// Low half of format byte is id of format the body is encoded with, high half is bitmask of formats accepted by
+// sender. Zero format is gob, so headers of nodes which don't know about formats are valid.
+const (
+	headerSize         = 8
+	headerFormatOffset = 5
+	headerCodecOffset  = 6
+	headerAcceptOffset = 7
+)
+
+// MaxPacketSize is max size of packet body, its length takes at most 4 bytes of uvarint in header.
+const MaxPacketSize = 1<<28 - 1
+
+// Capabilities are codecs and formats a node is able to read. Gob format is always readable and is not listed.
+type Capabilities struct {
+	Codecs  []byte
+	Formats []byte
+}
+
+// SerializeOptions are parameters of packet serialization negotiated with receiver.
+type SerializeOptions struct {
+	// Codec compresses body if it is not nil and body is large enough
+	Codec Codec
+	// Format encodes body, nil means gob
+	Format Format
+	// Accepted are capabilities of sender, receiver may use them for packets it sends back
+	Accepted Capabilities
+	// Signer signs body with node key if it is not nil
+	Signer Signer
 }
 
 // SerializePacket converts packet to byte slice.
 func SerializePacket(q *Packet) ([]byte, error) {
-	var msgBuffer bytes.Buffer
-	enc := gob.NewEncoder(&msgBuffer)
-	err := enc.Encode(q)
+	return SerializePacketNegotiated(q, SerializeOptions{})
+}
+
+// SerializePacketCompressed converts packet to byte slice, body is compressed with codec if it is not nil and body
+// is large enough. Accepted are ids of codecs the sender is able to decompress, receiver may use them to compress
+// packets back.
+func SerializePacketCompressed(q *Packet, codec Codec, accepted []byte) ([]byte, error) {
+	return SerializePacketNegotiated(q, SerializeOptions{Codec: codec, Accepted: Capabilities{Codecs: accepted}})
+}
+
+// SerializePacketNegotiated converts packet to byte slice with format and codec negotiated with receiver.
+func SerializePacketNegotiated(q *Packet, options SerializeOptions) ([]byte, error) {
+	msgBuffer := pool.GetBuffer()
+	defer pool.PutBuffer(msgBuffer)
+
+	// reserve space for header
+	var header [headerSize]byte
+	msgBuffer.Write(header[:])
+
+	q, err := Payloads.encode(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to serialize packet")
+	}
+	format := options.Format
+	if format == nil {
+		format = gobFormat{}
+	}
+	err = format.Marshal(msgBuffer, q)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to serialize packet")
 	}
 
-	length := msgBuffer.Len()
-
-	var lengthBytes [8]byte
-	binary.PutUvarint(lengthBytes[:], uint64(length))
+	body := msgBuffer.Bytes()[headerSize:]
+	if compressed := compress(options.Codec, q.Type, body); compressed != nil {
+		body = compressed
+		header[headerCodecOffset] = options.Codec.ID()
+	}
+	if options.Signer != nil {
+		body, err = signBody(options.Signer, body)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to sign packet")
+		}
+		header[headerCodecOffset] |= headerSignedFlag
+	}
+	if len(body) > MaxPacketSize {
+		return nil, errors.New("Failed to serialize packet: packet is too big")
+	}
+	binary.PutUvarint(header[:], uint64(len(body)))
+	header[headerFormatOffset] = format.ID() | formatMask(options.Accepted.Formats)
+	header[headerAcceptOffset] = codecMask(options.Accepted.Codecs)
 
-	var result []byte
-	result = append(result, lengthBytes[:]...)
-	result = append(result, msgBuffer.Bytes()...)
+	result := make([]byte, headerSize+len(body))
+	copy(result, header[:])
+	copy(result[headerSize:], body)
 
 	return result, nil
 }
 
 // DeserializePacket reads packet from io.Reader.
 func DeserializePacket(conn io.Reader) (*Packet, error) {
+	msg, _, err := DeserializePacketNegotiated(conn)
+	return msg, err
+}
+
+// DeserializePacketCompressed reads packet from io.Reader and decompresses its body if needed.
+// Returns ids of codecs accepted by sender.
+func DeserializePacketCompressed(conn io.Reader) (*Packet, []byte, error) {
+	msg, accepted, err := DeserializePacketNegotiated(conn)
+	return msg, accepted.Codecs, err
+}
 
-	lengthBytes := make([]byte, 8)
-	if _, err := io.ReadFull(conn, lengthBytes); err != nil {
-		return nil, err
+// DeserializePacketNegotiated reads packet of any known format from io.Reader and decompresses its body if needed.
+// Returns capabilities of sender.
+func DeserializePacketNegotiated(conn io.Reader) (*Packet, Capabilities, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		return nil, Capabilities{}, err
 	}
-	lengthReader := bytes.NewBuffer(lengthBytes)
-	length, err := binary.ReadUvarint(lengthReader)
-	if err != nil {
-		return nil, io.ErrUnexpectedEOF
+	length, n := binary.Uvarint(header[:headerFormatOffset])
+	if n <= 0 {
+		return nil, Capabilities{}, io.ErrUnexpectedEOF
+	}
+	if length > MaxPacketSize {
+		return nil, Capabilities{}, errors.Errorf("[ DeserializePacket ] packet length %d exceeds max packet size", length)
+	}
+	format := FormatByID(header[headerFormatOffset] & 0x0F)
+	if format == nil {
+		return nil, Capabilities{}, errors.Errorf("[ DeserializePacket ] unknown format %d", header[headerFormatOffset]&0x0F)
 	}
 
 	log.Debugf("[ DeserializePacket ] packet length %d", length)
-	buf := make([]byte, length)
-	if _, err := io.ReadFull(conn, buf); err != nil {
+	buf := pool.GetBuffer()
+	defer pool.PutBuffer(buf)
+	buf.Grow(int(length))
+	if _, err := io.CopyN(buf, conn, int64(length)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
 		log.Error("[ DeserializePacket ] couldn't read packet: ", err)
-		return nil, err
+		return nil, Capabilities{}, err
 	}
 	log.Debugf("[ DeserializePacket ] read packet")
 
-	msg := &Packet{}
-	dec := gob.NewDecoder(bytes.NewReader(buf))
+	var body io.Reader = buf
+	var signature *Signature
+	if header[headerCodecOffset]&headerSignedFlag != 0 {
+		var err error
+		signature, err = parseSignature(buf.Bytes())
+		if err != nil {
+			return nil, Capabilities{}, errors.Wrap(err, "[ DeserializePacket ] couldn't parse packet signature")
+		}
+		body = bytes.NewReader(signature.Data)
+	}
+	if id := header[headerCodecOffset] &^ headerSignedFlag; id != 0 {
+		codec := CodecByID(id)
+		if codec == nil {
+			return nil, Capabilities{}, errors.Errorf("[ DeserializePacket ] unknown codec %d", id)
+		}
+		compressed, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, Capabilities{}, errors.Wrap(err, "[ DeserializePacket ] couldn't read packet")
+		}
+		data, err := codec.Decompress(compressed)
+		if err != nil {
+			return nil, Capabilities{}, errors.Wrap(err, "[ DeserializePacket ] couldn't decompress packet")
+		}
+		body = bytes.NewReader(data)
+	}
 
-	err = dec.Decode(msg)
+	msg := &Packet{signature: signature}
+	err := format.Unmarshal(body, msg)
 	if err != nil {
 		log.Error("[ DeserializePacket ] couldn't decode packet: ", err)
-		return nil, err
+		return nil, Capabilities{}, err
+	}
+	if err := Payloads.decode(msg); err != nil {
+		return nil, Capabilities{}, errors.Wrap(err, "[ DeserializePacket ] couldn't decode packet payload")
 	}
 
 	log.Debugf("[ DeserializePacket ] decoded packet to %#v", msg)
 
-	return msg, nil
+	msg.accepted = Capabilities{
+		Codecs:  codecIDs(header[headerAcceptOffset]),
+		Formats: formatIDs(header[headerFormatOffset]),
+	}
+	return msg, msg.accepted, nil
 }
 
 func init() {
@@ -106,4 +246,6 @@ func init() {
 
 	gob.Register(&ResponsePulse{})
 	gob.Register(&ResponseGetRandomHosts{})
+
+	gob.Register(&RegisteredPayload{})
 }
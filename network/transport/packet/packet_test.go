@@ -20,6 +20,7 @@ package packet
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/gob"
 	"testing"
 
@@ -82,3 +83,187 @@ func TestDeserializeBigPacket(t *testing.T) {
 	deserializedData := deserializedMsg.Data.(*RequestTest).Data
 	require.Equal(t, data, deserializedData)
 }
+
+func TestSerializePacketCompressed(t *testing.T) {
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	receiver, _ := host.NewHostN("127.0.0.2:31338", testutils.RandomRef())
+	builder := NewBuilder(sender).Receiver(receiver).Type(TestPacket)
+	codec := Codecs["flate"]
+
+	big := builder.Request(&RequestTest{bytes.Repeat([]byte("insolar"), 1024)}).Build()
+	plain, err := SerializePacket(big)
+	require.NoError(t, err)
+	compressed, err := SerializePacketCompressed(big, codec, []byte{codec.ID()})
+	require.NoError(t, err)
+	require.True(t, len(compressed) < len(plain))
+
+	deserialized, accepted, err := DeserializePacketCompressed(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	expected := *big
+	expected.accepted = Capabilities{Codecs: []byte{codec.ID()}}
+	require.Equal(t, &expected, deserialized)
+	require.Equal(t, []byte{codec.ID()}, accepted)
+
+	// packets smaller than threshold are left as is
+	small := builder.Request(&RequestTest{[]byte{0, 1, 2, 3}}).Build()
+	serialized, err := SerializePacketCompressed(small, codec, nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), serialized[headerCodecOffset])
+
+	deserialized, accepted, err = DeserializePacketCompressed(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Equal(t, small, deserialized)
+	require.Empty(t, accepted)
+}
+
+func TestCodecs(t *testing.T) {
+	data := bytes.Repeat([]byte("insolar"), 1024)
+	for name, codec := range Codecs {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, codec, CodecByID(codec.ID()))
+
+			compressed, err := codec.Compress(data)
+			require.NoError(t, err)
+			require.True(t, len(compressed) < len(data))
+
+			decompressed, err := codec.Decompress(compressed)
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+		})
+	}
+
+	// snappy block declaring length larger than max packet size
+	var header [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(header[:], MaxPacketSize+1)
+	_, err := Codecs["snappy"].Decompress(header[:n])
+	require.Equal(t, ErrTooLarge, err)
+}
+
+type testSigner struct{}
+
+func (testSigner) PublicKey() []byte {
+	return []byte("key")
+}
+
+func (testSigner) Sign(data []byte) ([]byte, error) {
+	return append([]byte("signed "), data[:4]...), nil
+}
+
+func TestSerializePacketSigned(t *testing.T) {
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	receiver, _ := host.NewHostN("127.0.0.2:31338", testutils.RandomRef())
+	msg := NewBuilder(sender).Receiver(receiver).Type(TestPacket).
+		Request(&RequestTest{bytes.Repeat([]byte("insolar"), 1024)}).Build()
+	codec := Codecs["flate"]
+
+	serialized, err := SerializePacketNegotiated(msg, SerializeOptions{Codec: codec, Signer: testSigner{}})
+	require.NoError(t, err)
+	require.Equal(t, codec.ID()|headerSignedFlag, serialized[headerCodecOffset])
+
+	deserialized, _, err := DeserializePacketNegotiated(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	signature := deserialized.Signature()
+	require.NotNil(t, signature)
+	require.Equal(t, []byte("key"), signature.PublicKey)
+	require.Equal(t, append([]byte("signed "), signature.Data[:4]...), signature.Signature)
+	require.True(t, bytes.HasSuffix(serialized, signature.Data))
+	require.Equal(t, msg.Data, deserialized.Data)
+
+	// malformed signature is rejected before body is decoded
+	copy(serialized[headerSize:], []byte{0xFF, 0xFF, 0x7F})
+	_, _, err = DeserializePacketNegotiated(bytes.NewReader(serialized))
+	require.Error(t, err)
+}
+
+func TestDeserializePacketUnknownCodec(t *testing.T) {
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	msg := NewBuilder(sender).Receiver(sender).Type(TestPacket).Request(&RequestTest{[]byte{0, 1, 2, 3}}).Build()
+
+	serialized, err := SerializePacket(msg)
+	require.NoError(t, err)
+	serialized[headerCodecOffset] = 8
+
+	_, err = DeserializePacket(bytes.NewReader(serialized))
+	require.Error(t, err)
+}
+
+func TestSerializePacketProtobuf(t *testing.T) {
+	sender, _ := host.NewHostNS("127.0.0.1:31337", testutils.RandomRef(), 42)
+	receiver, _ := host.NewHostN("[::1]:31338", testutils.RandomRef())
+	msg := NewBuilder(sender).Receiver(receiver).Type(TestPacket).Request(&RequestTest{[]byte{0, 1, 2, 3}}).Build()
+	msg.TraceID = "trace"
+	format := Formats["protobuf"]
+
+	serialized, err := SerializePacketNegotiated(msg, SerializeOptions{
+		Format:   format,
+		Accepted: Capabilities{Formats: []byte{format.ID()}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, format.ID(), serialized[headerFormatOffset]&0x0F)
+
+	deserialized, accepted, err := DeserializePacketNegotiated(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	expected := *msg
+	expected.accepted = accepted
+	require.Equal(t, &expected, deserialized)
+	require.Equal(t, []byte{format.ID()}, accepted.Formats)
+
+	// registered payloads are sent in envelope
+	reg := NewPayloadRegistry()
+	require.NoError(t, reg.Register(PayloadKey{Type: TestPacket, Version: 1}, &RequestTest{}, 1024))
+	encoded, err := reg.encode(msg)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, format.Marshal(&buf, encoded))
+	decoded := &Packet{}
+	require.NoError(t, format.Unmarshal(&buf, decoded))
+	require.NoError(t, reg.decode(decoded))
+	require.Equal(t, msg, decoded)
+}
+
+func TestDeserializePacketUnknownFormat(t *testing.T) {
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	msg := NewBuilder(sender).Receiver(sender).Type(TestPacket).Request(&RequestTest{[]byte{0, 1, 2, 3}}).Build()
+
+	serialized, err := SerializePacket(msg)
+	require.NoError(t, err)
+	serialized[headerFormatOffset] = 4
+
+	_, err = DeserializePacket(bytes.NewReader(serialized))
+	require.Error(t, err)
+}
+
+func benchmarkPacket() *Packet {
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	receiver, _ := host.NewHostN("127.0.0.2:31338", testutils.RandomRef())
+	data := make([]byte, 1024)
+	rand.Read(data)
+	return NewBuilder(sender).Receiver(receiver).Type(TestPacket).Request(&RequestTest{data}).Build()
+}
+
+func BenchmarkSerializePacket(b *testing.B) {
+	msg := benchmarkPacket()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := SerializePacket(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeserializePacket(b *testing.B) {
+	serialized, err := SerializePacket(benchmarkPacket())
+	require.NoError(b, err)
+	reader := bytes.NewReader(serialized)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Reset(serialized)
+		_, err := DeserializePacket(reader)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package packet
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownPayloadVersion is returned when packet carries payload of version that is not registered, e.g. it is
+// sent by node of newer release. Such packets are well-formed and should not be treated as malformed.
+var ErrUnknownPayloadVersion = errors.New("unknown payload version")
+
+// PayloadKey identifies layout of packet payload.
+type PayloadKey struct {
+	Type     types.PacketType
+	Response bool
+	Version  uint16
+}
+
+func (k PayloadKey) String() string {
+	direction := "request"
+	if k.Response {
+		direction = "response"
+	}
+	return fmt.Sprintf("%s %s v%d", k.Type, direction, k.Version)
+}
+
+// RegisteredPayload is envelope of payload registered in PayloadRegistry. Payload is encoded separately from packet,
+// so its Go type name is not sent over network and receiver decodes it only if its layout version is known.
+type RegisteredPayload struct {
+	Version uint16
+	Body    []byte
+}
+
+type payloadEntry struct {
+	key   PayloadKey
+	typ   reflect.Type
+	limit int
+}
+
+// PayloadRegistry maps payload types to packet type, direction and version of payload layout.
+type PayloadRegistry struct {
+	lock   sync.RWMutex
+	byKey  map[PayloadKey]*payloadEntry
+	byType map[reflect.Type]*payloadEntry
+}
+
+// NewPayloadRegistry creates new empty payload registry.
+func NewPayloadRegistry() *PayloadRegistry {
+	return &PayloadRegistry{
+		byKey:  make(map[PayloadKey]*payloadEntry),
+		byType: make(map[reflect.Type]*payloadEntry),
+	}
+}
+
+// Payloads is registry used by packet serialization.
+var Payloads = NewPayloadRegistry()
+
+// Register binds payload type of prototype to key. Limit is max size of encoded payload, larger payloads are
+// rejected on both sides. Registering the same type for the same key again does nothing.
+func (r *PayloadRegistry) Register(key PayloadKey, prototype interface{}, limit int) error {
+	typ := reflect.TypeOf(prototype)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return errors.Errorf("payload prototype for %s should be a pointer", key)
+	}
+	if limit <= 0 {
+		return errors.Errorf("payload limit for %s should be positive", key)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if e, ok := r.byKey[key]; ok {
+		if e.typ == typ && e.limit == limit {
+			return nil
+		}
+		return errors.Errorf("%s is already registered for %s", key, e.typ)
+	}
+	if e, ok := r.byType[typ]; ok {
+		return errors.Errorf("%s is already registered for %s", typ, e.key)
+	}
+	e := &payloadEntry{key: key, typ: typ, limit: limit}
+	r.byKey[key] = e
+	r.byType[typ] = e
+	return nil
+}
+
+// encode returns copy of packet with registered payload wrapped in envelope, packets with payloads that are not
+// registered are returned as is.
+func (r *PayloadRegistry) encode(q *Packet) (*Packet, error) {
+	if q.Data == nil {
+		return q, nil
+	}
+	r.lock.RLock()
+	e, ok := r.byType[reflect.TypeOf(q.Data)]
+	r.lock.RUnlock()
+	if !ok {
+		return q, nil
+	}
+	if e.key.Type != q.Type || e.key.Response != q.IsResponse {
+		key := PayloadKey{Type: q.Type, Response: q.IsResponse, Version: e.key.Version}
+		return nil, errors.Errorf("payload %s is registered for %s, not for %s", e.typ, e.key, key)
+	}
+
+	var body bytes.Buffer
+	if err := gob.NewEncoder(&body).Encode(q.Data); err != nil {
+		return nil, errors.Wrapf(err, "failed to encode %s payload", e.key)
+	}
+	if body.Len() > e.limit {
+		return nil, errors.Errorf("%s payload is too big: %d bytes, limit is %d", e.key, body.Len(), e.limit)
+	}
+
+	result := *q
+	result.Data = &RegisteredPayload{Version: e.key.Version, Body: body.Bytes()}
+	return &result, nil
+}
+
+// decode replaces envelope of registered payload with decoded payload.
+func (r *PayloadRegistry) decode(q *Packet) error {
+	envelope, ok := q.Data.(*RegisteredPayload)
+	if !ok {
+		return nil
+	}
+	key := PayloadKey{Type: q.Type, Response: q.IsResponse, Version: envelope.Version}
+	r.lock.RLock()
+	e, ok := r.byKey[key]
+	r.lock.RUnlock()
+	if !ok {
+		return errors.Wrapf(ErrUnknownPayloadVersion, "failed to decode %s payload", key)
+	}
+	if len(envelope.Body) > e.limit {
+		return errors.Errorf("%s payload is too big: %d bytes, limit is %d", key, len(envelope.Body), e.limit)
+	}
+
+	data := reflect.New(e.typ.Elem()).Interface()
+	if err := gob.NewDecoder(bytes.NewReader(envelope.Body)).Decode(data); err != nil {
+		return errors.Wrapf(err, "failed to decode %s payload", key)
+	}
+	q.Data = data
+	return nil
+}
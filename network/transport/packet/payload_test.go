@@ -0,0 +1,116 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const TestPayloadPacket = TestPacket + 1
+
+type payloadTestV1 struct {
+	Text string
+}
+
+type payloadTestV2 struct {
+	Text  string
+	Count int
+}
+
+func TestPayloadRegistry_Register(t *testing.T) {
+	r := NewPayloadRegistry()
+	key := PayloadKey{Type: TestPayloadPacket, Version: 1}
+
+	require.Error(t, r.Register(key, payloadTestV1{}, 1024))
+	require.Error(t, r.Register(key, &payloadTestV1{}, 0))
+	require.NoError(t, r.Register(key, &payloadTestV1{}, 1024))
+	// registration is idempotent
+	require.NoError(t, r.Register(key, &payloadTestV1{}, 1024))
+	// key is taken by other type
+	require.Error(t, r.Register(key, &payloadTestV2{}, 1024))
+	// type is registered for other key
+	require.Error(t, r.Register(PayloadKey{Type: TestPayloadPacket, Version: 2}, &payloadTestV1{}, 1024))
+	require.NoError(t, r.Register(PayloadKey{Type: TestPayloadPacket, Version: 2}, &payloadTestV2{}, 1024))
+}
+
+func TestPayloadRegistry_EncodeDecode(t *testing.T) {
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	builder := NewBuilder(sender).Receiver(sender).Type(TestPayloadPacket)
+
+	old := NewPayloadRegistry()
+	require.NoError(t, old.Register(PayloadKey{Type: TestPayloadPacket, Version: 1}, &payloadTestV1{}, 128))
+	current := NewPayloadRegistry()
+	require.NoError(t, current.Register(PayloadKey{Type: TestPayloadPacket, Version: 1}, &payloadTestV1{}, 128))
+	require.NoError(t, current.Register(PayloadKey{Type: TestPayloadPacket, Version: 2}, &payloadTestV2{}, 128))
+
+	msg := builder.Request(&payloadTestV1{Text: "insolar"}).Build()
+	encoded, err := old.encode(msg)
+	require.NoError(t, err)
+	require.IsType(t, &RegisteredPayload{}, encoded.Data)
+	// original packet is not changed
+	require.Equal(t, &payloadTestV1{Text: "insolar"}, msg.Data)
+
+	require.NoError(t, current.decode(encoded))
+	require.Equal(t, msg, encoded)
+
+	// newer version is unknown to old release
+	encoded, err = current.encode(builder.Request(&payloadTestV2{Text: "insolar", Count: 1}).Build())
+	require.NoError(t, err)
+	err = old.decode(encoded)
+	require.Equal(t, ErrUnknownPayloadVersion, errors.Cause(err))
+
+	// payload doesn't match packet type or direction
+	_, err = current.encode(builder.Type(TestPacket).Request(&payloadTestV1{}).Build())
+	require.Error(t, err)
+	_, err = current.encode(builder.Response(&payloadTestV1{}).Build())
+	require.Error(t, err)
+
+	// limits are checked on both sides
+	big := builder.Request(&payloadTestV1{Text: string(bytes.Repeat([]byte("insolar"), 32))}).Build()
+	_, err = current.encode(big)
+	require.Error(t, err)
+	loose := NewPayloadRegistry()
+	require.NoError(t, loose.Register(PayloadKey{Type: TestPayloadPacket, Version: 1}, &payloadTestV1{}, 1024))
+	encoded, err = loose.encode(big)
+	require.NoError(t, err)
+	require.Error(t, current.decode(encoded))
+
+	// packets with payloads that are not registered are left as is
+	plain := builder.Request(&RequestTest{[]byte{0, 1, 2, 3}}).Build()
+	encoded, err = current.encode(plain)
+	require.NoError(t, err)
+	require.Equal(t, plain, encoded)
+	require.NoError(t, current.decode(encoded))
+}
+
+func TestSerializePacketRegisteredPayload(t *testing.T) {
+	require.NoError(t, Payloads.Register(PayloadKey{Type: TestPayloadPacket, Version: 1}, &payloadTestV1{}, 128))
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	msg := NewBuilder(sender).Receiver(sender).Type(TestPayloadPacket).Request(&payloadTestV1{Text: "insolar"}).Build()
+
+	serialized, err := SerializePacket(msg)
+	require.NoError(t, err)
+	deserialized, err := DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Equal(t, msg, deserialized)
+}
@@ -0,0 +1,62 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// Messages of packet.proto, field tags must match the schema.
+
+type pbPacket struct {
+	Sender        *pbHost    `protobuf:"bytes,1,opt,name=sender,proto3"`
+	Receiver      *pbHost    `protobuf:"bytes,2,opt,name=receiver,proto3"`
+	Type          int32      `protobuf:"varint,3,opt,name=type,proto3"`
+	RequestId     uint64     `protobuf:"varint,4,opt,name=request_id,json=requestId,proto3"`
+	RemoteAddress string     `protobuf:"bytes,5,opt,name=remote_address,json=remoteAddress,proto3"`
+	TraceId       string     `protobuf:"bytes,6,opt,name=trace_id,json=traceId,proto3"`
+	Payload       *pbPayload `protobuf:"bytes,7,opt,name=payload,proto3"`
+	Error         string     `protobuf:"bytes,8,opt,name=error,proto3"`
+	IsResponse    bool       `protobuf:"varint,9,opt,name=is_response,json=isResponse,proto3"`
+}
+
+func (m *pbPacket) Reset()         { *m = pbPacket{} }
+func (m *pbPacket) String() string { return proto.CompactTextString(m) }
+func (*pbPacket) ProtoMessage()    {}
+
+type pbHost struct {
+	NodeId  []byte `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3"`
+	ShortId uint32 `protobuf:"varint,2,opt,name=short_id,json=shortId,proto3"`
+	Ip      []byte `protobuf:"bytes,3,opt,name=ip,proto3"`
+	Port    uint32 `protobuf:"varint,4,opt,name=port,proto3"`
+	Zone    string `protobuf:"bytes,5,opt,name=zone,proto3"`
+}
+
+func (m *pbHost) Reset()         { *m = pbHost{} }
+func (m *pbHost) String() string { return proto.CompactTextString(m) }
+func (*pbHost) ProtoMessage()    {}
+
+type pbPayload struct {
+	Registered bool   `protobuf:"varint,1,opt,name=registered,proto3"`
+	Version    uint32 `protobuf:"varint,2,opt,name=version,proto3"`
+	Body       []byte `protobuf:"bytes,3,opt,name=body,proto3"`
+}
+
+func (m *pbPayload) Reset()         { *m = pbPayload{} }
+func (m *pbPayload) String() string { return proto.CompactTextString(m) }
+func (*pbPayload) ProtoMessage()    {}
@@ -0,0 +1,99 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// headerSignedFlag is set in codec byte of header if body is signed. Signed body starts with uvarint length of
+// sender public key, the key, uvarint length of signature and the signature, the rest of body is signed data.
+const headerSignedFlag = 0x80
+
+// maxSignaturePartLength is max length of public key and signature in signed body.
+const maxSignaturePartLength = 1 << 10
+
+// Signer signs serialized packets with node key.
+type Signer interface {
+	// PublicKey returns binary encoded public key receivers verify signatures with.
+	PublicKey() []byte
+	// Sign signs serialized packet body.
+	Sign(data []byte) ([]byte, error)
+}
+
+// Signature is signature of received packet.
+type Signature struct {
+	// PublicKey is binary encoded public key of sender
+	PublicKey []byte
+	// Signature is signature of Data made with sender key
+	Signature []byte
+	// Data is serialized packet body as it was signed by sender
+	Data []byte
+}
+
+// Signature returns signature of received packet or nil if packet is not signed.
+func (q *Packet) Signature() *Signature {
+	return q.signature
+}
+
+func signBody(signer Signer, body []byte) ([]byte, error) {
+	signature, err := signer.Sign(body)
+	if err != nil {
+		return nil, err
+	}
+	key := signer.PublicKey()
+	result := make([]byte, 0, 2*binary.MaxVarintLen64+len(key)+len(signature)+len(body))
+	result = appendSignaturePart(result, key)
+	result = appendSignaturePart(result, signature)
+	return append(result, body...), nil
+}
+
+func appendSignaturePart(buf []byte, part []byte) []byte {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(part)))
+	buf = append(buf, length[:n]...)
+	return append(buf, part...)
+}
+
+// parseSignature splits signed body to public key, signature and signed data. Data is copied, so body may be reused.
+func parseSignature(body []byte) (*Signature, error) {
+	key, rest, err := readSignaturePart(body)
+	if err != nil {
+		return nil, err
+	}
+	signature, rest, err := readSignaturePart(rest)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{
+		PublicKey: key,
+		Signature: signature,
+		Data:      append([]byte(nil), rest...),
+	}, nil
+}
+
+func readSignaturePart(buf []byte) ([]byte, []byte, error) {
+	length, n := binary.Uvarint(buf)
+	if n <= 0 || length > maxSignaturePartLength || uint64(len(buf)-n) < length {
+		return nil, nil, errors.New("malformed signature")
+	}
+	end := n + int(length)
+	return append([]byte(nil), buf[n:end]...), buf[end:], nil
+}
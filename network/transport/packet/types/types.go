@@ -52,4 +52,15 @@ const (
 	Phase2
 	// Phase3Pulse is packet type for phase 3 ( pulse )
 	Phase3
+
+	// PeerExchange is packet type to share a sample of known active nodes with random peer
+	PeerExchange
+	// StandbySync is packet type for standby discovery node to replicate state from its primary
+	StandbySync
+	// Gossip is packet type to exchange signed digests of active node list with random peers
+	Gossip
+	// AntiEntropy is packet type to reconcile active node list with random peer
+	AntiEntropy
+	// Replication is packet type to execute RPC carrying ledger replication message on a remote node.
+	Replication
 )
@@ -21,6 +21,8 @@ import (
 	"context"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
@@ -33,9 +35,18 @@ import (
 type lockableConnection struct {
 	net.Conn
 	sync.Locker
+
+	address net.Addr
+	// writers is count of goroutines writing or waiting to write to connection
+	writers int32
+	// lastUsed is time of last write in unix nanoseconds
+	lastUsed int64
 }
 
 func (lc *lockableConnection) Write(data []byte) (int, error) {
+	atomic.AddInt32(&lc.writers, 1)
+	defer atomic.AddInt32(&lc.writers, -1)
+
 	lc.Lock()
 	defer lc.Unlock()
 
@@ -50,19 +61,38 @@ func (lc *lockableConnection) Write(data []byte) (int, error) {
 	// }
 	// return written, nil
 
+	atomic.StoreInt64(&lc.lastUsed, time.Now().UnixNano())
 	return lc.Conn.Write(data)
 }
 
+func (lc *lockableConnection) busy() int32 {
+	return atomic.LoadInt32(&lc.writers)
+}
+
+func (lc *lockableConnection) idleSince() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&lc.lastUsed))
+}
+
 type connectionPool struct {
 	connectionFactory connectionFactory
+	options           Options
+	now               func() time.Time
 
 	unsafeConnectionsHolder unsafeConnectionHolder
 	mutex                   sync.RWMutex
+
+	// lastEviction is time of last idle connections eviction in unix nanoseconds
+	lastEviction int64
 }
 
-func newConnectionPool(connectionFactory connectionFactory) *connectionPool {
+func newConnectionPool(connectionFactory connectionFactory, options Options) *connectionPool {
+	if options.MaxConnectionsPerPeer <= 0 {
+		options.MaxConnectionsPerPeer = 1
+	}
 	return &connectionPool{
 		connectionFactory: connectionFactory,
+		options:           options,
+		now:               time.Now,
 
 		unsafeConnectionsHolder: newUnsafeConnectionHolder(),
 	}
@@ -71,6 +101,8 @@ func newConnectionPool(connectionFactory connectionFactory) *connectionPool {
 func (cp *connectionPool) GetConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 
+	cp.evictIdleConnections(ctx)
+
 	conn, ok := cp.getConnection(address)
 
 	logger.Debugf("[ GetConnection ] Finding connection to %s in pool: %t", address, ok)
@@ -79,7 +111,7 @@ func (cp *connectionPool) GetConnection(ctx context.Context, address net.Addr) (
 		return conn, nil
 	}
 
-	logger.Debugf("[ GetConnection ] Missing open connection to %s in pool ", address)
+	logger.Debugf("[ GetConnection ] Missing free connection to %s in pool ", address)
 
 	return cp.getOrCreateConnection(ctx, address)
 }
@@ -90,23 +122,60 @@ func (cp *connectionPool) CloseConnection(ctx context.Context, address net.Addr)
 
 	logger := inslogger.FromContext(ctx)
 
-	conn, ok := cp.unsafeConnectionsHolder.Get(address)
-	logger.Debugf("[ CloseConnection ] Finding connection to %s in pool: %s", address, ok)
+	conns := cp.unsafeConnectionsHolder.Get(address)
+	logger.Debugf("[ CloseConnection ] Found %d connections to %s in pool", len(conns), address)
 
-	if ok {
-		utils.CloseVerbose(conn)
+	if len(conns) > 0 {
+		for _, conn := range conns {
+			utils.CloseVerbose(conn)
+		}
 
-		logger.Debugf("[ CloseConnection ] Delete connection to %s from pool: %s", address)
+		logger.Debugf("[ CloseConnection ] Delete connections to %s from pool", address)
 		cp.unsafeConnectionsHolder.Delete(address)
 		metrics.NetworkConnections.Set(float64(cp.unsafeConnectionsHolder.Size()))
 	}
 }
 
+// closeConnection closes single connection of pool, e.g. one closed by remote host.
+func (cp *connectionPool) closeConnection(ctx context.Context, conn *lockableConnection) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	if cp.unsafeConnectionsHolder.Remove(conn) {
+		utils.CloseVerbose(conn)
+		inslogger.FromContext(ctx).Debugf("[ closeConnection ] Delete connection to %s from pool", conn.address)
+		metrics.NetworkConnections.Set(float64(cp.unsafeConnectionsHolder.Size()))
+	}
+}
+
+// pickConnection returns connection nobody writes to. If all connections are busy, the least busy one is returned
+// if no more connections can be opened.
+func (cp *connectionPool) pickConnection(address net.Addr) (*lockableConnection, bool) {
+	conns := cp.unsafeConnectionsHolder.Get(address)
+	var least *lockableConnection
+	for _, conn := range conns {
+		if conn.busy() == 0 {
+			return conn, true
+		}
+		if least == nil || conn.busy() < least.busy() {
+			least = conn
+		}
+	}
+	if least != nil && len(conns) >= cp.options.MaxConnectionsPerPeer {
+		return least, true
+	}
+	return nil, false
+}
+
 func (cp *connectionPool) getConnection(address net.Addr) (net.Conn, bool) {
 	cp.mutex.RLock()
 	defer cp.mutex.RUnlock()
 
-	return cp.unsafeConnectionsHolder.Get(address)
+	conn, ok := cp.pickConnection(address)
+	if !ok {
+		return nil, false
+	}
+	return conn, true
 }
 
 func (cp *connectionPool) getOrCreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
@@ -115,8 +184,8 @@ func (cp *connectionPool) getOrCreateConnection(ctx context.Context, address net
 	cp.mutex.Lock()
 	defer cp.mutex.Unlock()
 
-	conn, ok := cp.unsafeConnectionsHolder.Get(address)
-	logger.Debugf("[ getOrCreateConnection ] Finding connection to %s in pool: %s", address, ok)
+	conn, ok := cp.pickConnection(address)
+	logger.Debugf("[ getOrCreateConnection ] Finding connection to %s in pool: %t", address, ok)
 
 	if ok {
 		return conn, nil
@@ -128,46 +197,82 @@ func (cp *connectionPool) getOrCreateConnection(ctx context.Context, address net
 	span.AddAttributes(
 		trace.StringAttribute("create connect to", address.String()),
 	)
-	conn, err := cp.connectionFactory.CreateConnection(ctx, address)
+	created, err := cp.connectionFactory.CreateConnection(ctx, address)
 	defer span.End()
 	if err != nil {
 		return nil, errors.Wrap(err, "[ send ] Failed to create TCP connection")
 	}
 
+	lc := &lockableConnection{
+		Conn:     created,
+		Locker:   &sync.Mutex{},
+		address:  address,
+		lastUsed: cp.now().UnixNano(),
+	}
+
 	go func() {
 		b := make([]byte, 1)
-		_, err := conn.Read(b)
+		_, err := created.Read(b)
 		if err != nil {
 			logger.Infof("remote host 'closed' connection to %s: %s", address, err)
-			cp.CloseConnection(ctx, address)
+			cp.closeConnection(ctx, lc)
 			return
 		}
 
 		logger.Errorf("unexpected data on connection to %s", address)
 	}()
 
-	lc := &lockableConnection{
-		Conn:   conn,
-		Locker: &sync.Mutex{},
-	}
-
-	cp.unsafeConnectionsHolder.Add(address, lc)
+	cp.unsafeConnectionsHolder.Add(lc)
 	size := cp.unsafeConnectionsHolder.Size()
 	logger.Debugf(
 		"[ getOrCreateConnection ] Added connection to %s. Current pool size: %d",
-		conn.RemoteAddr(),
+		created.RemoteAddr(),
 		size,
 	)
 	metrics.NetworkConnections.Set(float64(size))
 
-	return conn, nil
+	return lc, nil
+}
+
+// evictIdleConnections closes connections not used for IdleTimeout. Pool is checked at most twice per IdleTimeout.
+func (cp *connectionPool) evictIdleConnections(ctx context.Context) {
+	if cp.options.IdleTimeout <= 0 {
+		return
+	}
+	now := cp.now()
+	last := atomic.LoadInt64(&cp.lastEviction)
+	if now.Sub(time.Unix(0, last)) < cp.options.IdleTimeout/2 {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&cp.lastEviction, last, now.UnixNano()) {
+		return
+	}
+
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	var idle []*lockableConnection
+	cp.unsafeConnectionsHolder.Iterate(func(conn *lockableConnection) {
+		if conn.busy() == 0 && now.Sub(conn.idleSince()) >= cp.options.IdleTimeout {
+			idle = append(idle, conn)
+		}
+	})
+	if len(idle) == 0 {
+		return
+	}
+	for _, conn := range idle {
+		cp.unsafeConnectionsHolder.Remove(conn)
+		utils.CloseVerbose(conn)
+	}
+	inslogger.FromContext(ctx).Debugf("[ evictIdleConnections ] Closed %d idle connections", len(idle))
+	metrics.NetworkConnections.Set(float64(cp.unsafeConnectionsHolder.Size()))
 }
 
 func (cp *connectionPool) Reset() {
 	cp.mutex.Lock()
 	defer cp.mutex.Unlock()
 
-	cp.unsafeConnectionsHolder.Iterate(func(conn net.Conn) {
+	cp.unsafeConnectionsHolder.Iterate(func(conn *lockableConnection) {
 		utils.CloseVerbose(conn)
 	})
 	cp.unsafeConnectionsHolder.Clear()
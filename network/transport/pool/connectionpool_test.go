@@ -0,0 +1,131 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package pool
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pipeFactory struct {
+	created int
+	remotes []net.Conn
+}
+
+func (f *pipeFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+	f.created++
+	local, remote := net.Pipe()
+	f.remotes = append(f.remotes, remote)
+	return local, nil
+}
+
+func newTestAddr(t *testing.T, address string) net.Addr {
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	require.NoError(t, err)
+	return addr
+}
+
+func TestConnectionPool_MaxConnectionsPerPeer(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeFactory{}
+	cp := newConnectionPool(factory, Options{MaxConnectionsPerPeer: 2})
+	defer cp.Reset()
+	addr := newTestAddr(t, "127.0.0.1:5000")
+
+	first, err := cp.GetConnection(ctx, addr)
+	require.NoError(t, err)
+	// free connection is reused
+	conn, err := cp.GetConnection(ctx, addr)
+	require.NoError(t, err)
+	require.True(t, first == conn)
+	require.Equal(t, 1, factory.created)
+
+	// busy connection makes pool open another one
+	atomic.AddInt32(&first.(*lockableConnection).writers, 1)
+	second, err := cp.GetConnection(ctx, addr)
+	require.NoError(t, err)
+	require.False(t, first == second)
+	require.Equal(t, 2, factory.created)
+
+	// limit is reached, the least busy connection is shared
+	atomic.AddInt32(&second.(*lockableConnection).writers, 2)
+	conn, err = cp.GetConnection(ctx, addr)
+	require.NoError(t, err)
+	require.True(t, first == conn)
+	require.Equal(t, 2, factory.created)
+	require.Equal(t, 2, cp.unsafeConnectionsHolder.Size())
+
+	cp.CloseConnection(ctx, addr)
+	require.Equal(t, 0, cp.unsafeConnectionsHolder.Size())
+}
+
+func TestConnectionPool_EvictIdleConnections(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeFactory{}
+	cp := newConnectionPool(factory, Options{IdleTimeout: time.Minute})
+	defer cp.Reset()
+	now := time.Now()
+	cp.now = func() time.Time { return now }
+
+	idle := newTestAddr(t, "127.0.0.1:5000")
+	active := newTestAddr(t, "127.0.0.1:5001")
+	_, err := cp.GetConnection(ctx, idle)
+	require.NoError(t, err)
+	now = now.Add(time.Minute / 2)
+	_, err = cp.GetConnection(ctx, active)
+	require.NoError(t, err)
+	require.Equal(t, 2, cp.unsafeConnectionsHolder.Size())
+
+	now = now.Add(time.Minute / 2)
+	_, err = cp.GetConnection(ctx, active)
+	require.NoError(t, err)
+	require.Equal(t, 1, cp.unsafeConnectionsHolder.Size())
+	require.Empty(t, cp.unsafeConnectionsHolder.Get(idle))
+	require.Equal(t, 2, factory.created)
+}
+
+func TestConnectionPool_ClosedByRemote(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeFactory{}
+	cp := newConnectionPool(factory, Options{MaxConnectionsPerPeer: 2})
+	defer cp.Reset()
+	addr := newTestAddr(t, "127.0.0.1:5000")
+
+	first, err := cp.GetConnection(ctx, addr)
+	require.NoError(t, err)
+	atomic.AddInt32(&first.(*lockableConnection).writers, 1)
+	_, err = cp.GetConnection(ctx, addr)
+	require.NoError(t, err)
+
+	require.NoError(t, factory.remotes[1].Close())
+	size := func() int {
+		cp.mutex.RLock()
+		defer cp.mutex.RUnlock()
+		return cp.unsafeConnectionsHolder.Size()
+	}
+	for i := 0; i < 100 && size() != 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, 1, size())
+	require.True(t, first == cp.unsafeConnectionsHolder.Get(addr)[0])
+}
@@ -22,12 +22,13 @@ import (
 )
 
 type unsafeConnectionsHolderImpl struct {
-	connections map[string]net.Conn
+	connections map[string][]*lockableConnection
+	size        int
 }
 
 func newUnsafeConnectionHolderImpl() unsafeConnectionHolder {
 	return &unsafeConnectionsHolderImpl{
-		connections: make(map[string]net.Conn),
+		connections: make(map[string][]*lockableConnection),
 	}
 }
 
@@ -35,32 +36,56 @@ func (uch *unsafeConnectionsHolderImpl) key(address net.Addr) string {
 	return address.String()
 }
 
-func (uch *unsafeConnectionsHolderImpl) Get(address net.Addr) (net.Conn, bool) {
-	conn, ok := uch.connections[uch.key(address)]
-
-	return conn, ok
+func (uch *unsafeConnectionsHolderImpl) Get(address net.Addr) []*lockableConnection {
+	return uch.connections[uch.key(address)]
 }
 
 func (uch *unsafeConnectionsHolderImpl) Delete(address net.Addr) {
-	delete(uch.connections, uch.key(address))
+	key := uch.key(address)
+	uch.size -= len(uch.connections[key])
+	delete(uch.connections, key)
+}
+
+func (uch *unsafeConnectionsHolderImpl) Remove(conn *lockableConnection) bool {
+	key := uch.key(conn.address)
+	conns := uch.connections[key]
+	for i, c := range conns {
+		if c != conn {
+			continue
+		}
+		conns = append(conns[:i], conns[i+1:]...)
+		if len(conns) == 0 {
+			delete(uch.connections, key)
+		} else {
+			uch.connections[key] = conns
+		}
+		uch.size--
+		return true
+	}
+	return false
 }
 
-func (uch *unsafeConnectionsHolderImpl) Add(address net.Addr, conn net.Conn) {
-	uch.connections[uch.key(address)] = conn
+func (uch *unsafeConnectionsHolderImpl) Add(conn *lockableConnection) {
+	key := uch.key(conn.address)
+	uch.connections[key] = append(uch.connections[key], conn)
+	uch.size++
 }
 
 func (uch *unsafeConnectionsHolderImpl) Clear() {
 	for key := range uch.connections {
 		delete(uch.connections, key)
 	}
+	uch.size = 0
 }
 
 func (uch *unsafeConnectionsHolderImpl) Iterate(iterateFunc iterateFunc) {
-	for _, conn := range uch.connections {
-		iterateFunc(conn)
+	for _, conns := range uch.connections {
+		for _, conn := range conns {
+			iterateFunc(conn)
+		}
 	}
 }
 
 func (uch *unsafeConnectionsHolderImpl) Size() int {
-	return len(uch.connections)
+	return uch.size
 }
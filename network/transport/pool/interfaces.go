@@ -20,6 +20,7 @@ package pool
 import (
 	"context"
 	"net"
+	"time"
 )
 
 type ConnectionPool interface {
@@ -32,12 +33,13 @@ type connectionFactory interface {
 	CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error)
 }
 
-type iterateFunc func(conn net.Conn)
+type iterateFunc func(conn *lockableConnection)
 
 type unsafeConnectionHolder interface {
-	Get(address net.Addr) (net.Conn, bool)
+	Get(address net.Addr) []*lockableConnection
 	Delete(address net.Addr)
-	Add(address net.Addr, conn net.Conn)
+	Remove(conn *lockableConnection) bool
+	Add(conn *lockableConnection)
 	Size() int
 	Clear()
 	Iterate(iterateFunc iterateFunc)
@@ -47,6 +49,15 @@ func newUnsafeConnectionHolder() unsafeConnectionHolder {
 	return newUnsafeConnectionHolderImpl()
 }
 
-func NewConnectionPool(connectionFactory connectionFactory) ConnectionPool {
-	return newConnectionPool(connectionFactory)
+// Options configures connection pool.
+type Options struct {
+	// MaxConnectionsPerPeer is max count of connections opened to one address, new connection is opened only when
+	// all existing ones are busy writing. One connection is used if it is not positive.
+	MaxConnectionsPerPeer int
+	// IdleTimeout is duration after which unused connection is closed, zero disables eviction.
+	IdleTimeout time.Duration
+}
+
+func NewConnectionPool(connectionFactory connectionFactory, options Options) ConnectionPool {
+	return newConnectionPool(connectionFactory, options)
 }
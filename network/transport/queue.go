@@ -0,0 +1,96 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// packetPriority returns priority class of packet type.
+func packetPriority(t types.PacketType) host.Priority {
+	switch t {
+	case types.Phase1, types.Phase2, types.Phase3:
+		return host.PriorityConsensus
+	case types.Pulse:
+		return host.PriorityPulse
+	case types.Replication, types.Cascade, types.PeerExchange, types.StandbySync, types.Gossip, types.AntiEntropy:
+		return host.PriorityReplication
+	default:
+		return host.PriorityRPC
+	}
+}
+
+// newSendQueueFromConfig creates send queue configured by transport configuration, nil if queue is disabled.
+func newSendQueueFromConfig(cfg configuration.Transport) (*host.SendQueue, error) {
+	if cfg.SendWorkers <= 0 {
+		return nil, nil
+	}
+	weights := host.DefaultPriorityWeights
+	for _, pair := range splitList(cfg.SendQueueWeights) {
+		priority, value, err := parsePriorityPair(pair)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse send queue weights")
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight <= 0 {
+			return nil, errors.Errorf("invalid weight %s of %s, it must be positive integer", value, priority)
+		}
+		weights[priority] = weight
+	}
+	policies := host.DefaultDropPolicies
+	for _, pair := range splitList(cfg.SendQueueDropPolicies) {
+		priority, value, err := parsePriorityPair(pair)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse send queue drop policies")
+		}
+		switch value {
+		case "newest":
+			policies[priority] = host.DropNewest
+		case "oldest":
+			policies[priority] = host.DropOldest
+		default:
+			return nil, errors.Errorf("unknown drop policy %s of %s", value, priority)
+		}
+	}
+	limit := cfg.SendQueueSize
+	if limit <= 0 {
+		return nil, errors.New("send queue size must be positive")
+	}
+	return host.NewSendQueue(cfg.SendWorkers, limit, weights, policies), nil
+}
+
+// parsePriorityPair parses class=value pair of configuration.
+func parsePriorityPair(pair string) (host.Priority, string, error) {
+	parts := strings.SplitN(pair, "=", 2)
+	if len(parts) != 2 {
+		return 0, "", errors.Errorf("%s is not class=value pair", pair)
+	}
+	name := strings.TrimSpace(parts[0])
+	for priority := host.Priority(0); priority < host.PriorityCount; priority++ {
+		if priority.String() == name {
+			return priority, strings.TrimSpace(parts[1]), nil
+		}
+	}
+	return 0, "", errors.Errorf("unknown priority class %s", name)
+}
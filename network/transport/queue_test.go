@@ -0,0 +1,61 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSendQueueFromConfig(t *testing.T) {
+	cfg := configuration.NewHostNetwork().Transport
+	q, err := newSendQueueFromConfig(cfg)
+	require.NoError(t, err)
+	require.Nil(t, q)
+
+	cfg.SendWorkers = 2
+	cfg.SendQueueWeights = "consensus=16, replication=2"
+	cfg.SendQueueDropPolicies = "rpc=oldest"
+	q, err = newSendQueueFromConfig(cfg)
+	require.NoError(t, err)
+	weights := [host.PriorityCount]int{16, 4, 1, 2}
+	policies := [host.PriorityCount]host.DropPolicy{host.DropOldest, host.DropOldest, host.DropOldest, host.DropNewest}
+	require.Equal(t, host.NewSendQueue(2, cfg.SendQueueSize, weights, policies), q)
+
+	for _, weights := range []string{"unknown=1", "consensus", "consensus=0", "consensus=a"} {
+		cfg.SendQueueWeights = weights
+		_, err = newSendQueueFromConfig(cfg)
+		require.Error(t, err, weights)
+	}
+	cfg.SendQueueWeights = ""
+	cfg.SendQueueDropPolicies = "rpc=random"
+	_, err = newSendQueueFromConfig(cfg)
+	require.Error(t, err)
+}
+
+func TestPacketPriority(t *testing.T) {
+	require.Equal(t, host.PriorityConsensus, packetPriority(types.Phase2))
+	require.Equal(t, host.PriorityPulse, packetPriority(types.Pulse))
+	require.Equal(t, host.PriorityRPC, packetPriority(types.RPC))
+	require.Equal(t, host.PriorityReplication, packetPriority(types.Replication))
+	require.Equal(t, host.PriorityReplication, packetPriority(types.Cascade))
+}
@@ -19,90 +19,157 @@ package transport
 
 import (
 	"context"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"io/ioutil"
 	"math/big"
 	"net"
+	"sync"
+	"time"
 
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
 	quic "github.com/lucas-clemente/quic-go"
 	"github.com/pkg/errors"
 )
 
-type quicConnection struct {
-	session quic.Session
-	stream  quic.Stream
-}
+const (
+	// quicIdleTimeout is a time after which session without streams and keep alive pings is closed
+	quicIdleTimeout = time.Minute
+	// quicMaxIncomingStreams is a limit of concurrently opened streams (packets in flight) per session
+	quicMaxIncomingStreams = 1024
+)
 
+// quicTransport sends every packet in its own stream of QUIC session opened to peer. Streams are
+// multiplexed, so a big packet doesn't hold back others as on TCP connection. QUIC provides congestion
+// control and identifies sessions by connection ID, so a session survives change of peer address.
 type quicTransport struct {
 	baseTransport
-	l           quic.Listener
-	conn        net.PacketConn
-	connections map[string]quicConnection
+	l          quic.Listener
+	conn       net.PacketConn
+	tlsConfig  *tls.Config
+	quicConfig *quic.Config
+
+	sessions     map[string]quic.Session
+	sessionsLock sync.Mutex
 }
 
-func newQuicTransport(conn net.PacketConn, proxy relay.Proxy, publicAddress string) (*quicTransport, error) {
-	listener, err := quic.Listen(conn, generateTLSConfig(), nil)
+func newQuicTransport(conn net.PacketConn, proxy relay.Proxy, publicAddress string, privateKey crypto.PrivateKey) (*quicTransport, error) {
+	tlsConfig, err := newQuicTLSConfig(privateKey)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "[ newQuicTransport ] failed to create TLS config")
+	}
+	quicConfig := &quic.Config{
+		HandshakeTimeout:   handshakeTimeout,
+		IdleTimeout:        quicIdleTimeout,
+		MaxIncomingStreams: quicMaxIncomingStreams,
+		KeepAlive:          true,
+	}
+
+	listener, err := quic.Listen(conn, tlsConfig, quicConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newQuicTransport ] failed to listen")
 	}
 
 	transport := &quicTransport{
 		baseTransport: newBaseTransport(proxy, publicAddress),
 		l:             listener,
 		conn:          conn,
-		connections:   make(map[string]quicConnection),
+		tlsConfig:     tlsConfig,
+		quicConfig:    quicConfig,
+		sessions:      make(map[string]quic.Session),
 	}
 
 	transport.sendFunc = transport.send
 	return transport, nil
 }
 
-func (t *quicTransport) send(recvAddress string, data []byte) error {
-	conn, ok := t.connections[recvAddress]
-	var stream quic.Stream
-	var err error
-	if !ok {
-		var session quic.Session
-		session, stream, err = createConnection(recvAddress)
+func (t *quicTransport) send(address string, _ types.PacketType, data []byte) error {
+	session, err := t.getSession(address)
+	if err != nil {
+		return errors.Wrap(err, "[ send ] failed to get a session")
+	}
+
+	err = writeStream(session, data)
+	if err != nil {
+		// session could be closed by peer on idle timeout or restart, retry once with a new one
+		t.closeSession(address, session)
+		session, err = t.getSession(address)
 		if err != nil {
-			return errors.Wrap(err, "[ send ] failed to create a connection")
+			return errors.Wrap(err, "[ send ] failed to get a session")
 		}
-		t.connections[recvAddress] = quicConnection{session, stream}
-	} else {
-		stream = conn.stream
+		err = writeStream(session, data)
 	}
 
-	n, err := stream.Write(data)
+	return errors.Wrap(err, "[ send ] failed to write data")
+}
+
+func (t *quicTransport) getSession(address string) (quic.Session, error) {
+	t.sessionsLock.Lock()
+	defer t.sessionsLock.Unlock()
+
+	if session, ok := t.sessions[address]; ok {
+		return session, nil
+	}
+	session, err := quic.DialAddr(address, t.tlsConfig, t.quicConfig)
 	if err != nil {
-		return errors.Wrap(err, "[ send ] failed to write to a stream")
+		return nil, errors.Wrap(err, "[ getSession ] failed to create a session")
 	}
+	log.Debugf("[ getSession ] connected to: %s", session.RemoteAddr())
+	t.sessions[address] = session
+	return session, nil
+}
 
-	if n != len(data) {
-		return errors.New("[ send ] sent a part of data")
+func (t *quicTransport) closeSession(address string, session quic.Session) {
+	t.sessionsLock.Lock()
+	defer t.sessionsLock.Unlock()
+
+	if t.sessions[address] == session {
+		delete(t.sessions, address)
 	}
+	utils.CloseVerbose(session)
+}
 
+func writeStream(session quic.Session, data []byte) error {
+	stream, err := session.OpenStreamSync()
+	if err != nil {
+		return errors.Wrap(err, "[ writeStream ] failed to open a stream")
+	}
+	defer utils.CloseVerbose(stream)
+
+	n, err := stream.Write(data)
+	if err != nil {
+		return errors.Wrap(err, "[ writeStream ] failed to write to a stream")
+	}
+	if n != len(data) {
+		return errors.New("[ writeStream ] sent a part of data")
+	}
 	return nil
 }
 
 // Start starts networking.
 func (t *quicTransport) Listen(ctx context.Context, started chan struct{}) error {
-	log.Debug("Start QUIC transport")
+	logger := inslogger.FromContext(ctx)
+	logger.Info("[ Listen ] Start QUIC transport")
 	started <- struct{}{}
 	for {
 		session, err := t.l.Accept()
 		if err != nil {
 			<-t.disconnectFinished
-			return err
+			return errors.Wrap(err, "[ Listen ] failed to accept a session")
 		}
 
-		log.Debugf("accept from: %s", session.RemoteAddr().String())
-		go t.handleAcceptedConnection(session)
+		logger.Debugf("[ Listen ] accept from: %s", session.RemoteAddr())
+		go t.handleAcceptedSession(session)
 	}
 }
 
@@ -111,47 +178,75 @@ func (t *quicTransport) Stop() {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	log.Debug("[ Stop ] Stop QUIC transport")
+	log.Info("[ Stop ] Stop QUIC transport")
 	t.prepareDisconnect()
 
 	utils.CloseVerbose(t.l)
 
-	for _, conn := range t.connections {
-		utils.CloseVerbose(conn.stream)
-		utils.CloseVerbose(conn.session)
+	t.sessionsLock.Lock()
+	for address, session := range t.sessions {
+		utils.CloseVerbose(session)
+		delete(t.sessions, address)
 	}
+	t.sessionsLock.Unlock()
 
 	utils.CloseVerbose(t.conn)
 }
 
-func (t *quicTransport) handleAcceptedConnection(session quic.Session) {
-	stream, err := session.AcceptStream()
-	if err != nil {
-		log.Error(err, "[ handleAcceptedConnection ] failed to get a stream")
+func (t *quicTransport) handleAcceptedSession(session quic.Session) {
+	if t.isBanned(session.RemoteAddr()) {
+		log.Debugf("[ handleAcceptedSession ] Dropped session from banned peer %s", session.RemoteAddr())
+		utils.CloseVerbose(session)
+		return
+	}
+	if t.isDenied(session.RemoteAddr()) {
+		log.Debugf("[ handleAcceptedSession ] Dropped session from denied peer %s", session.RemoteAddr())
+		utils.CloseVerbose(session)
+		return
 	}
+	for {
+		stream, err := session.AcceptStream()
+		if err != nil {
+			log.Debugf("[ handleAcceptedSession ] session with %s closed: %s", session.RemoteAddr(), err)
+			return
+		}
+		go t.handleStream(session.RemoteAddr(), stream)
+	}
+}
 
+func (t *quicTransport) handleStream(remoteAddr net.Addr, stream quic.Stream) {
+	defer utils.CloseVerbose(stream)
+
+	// access list may be updated while session is open
+	if t.isDenied(remoteAddr) {
+		log.Debugf("[ handleStream ] Dropped packet from denied peer %s", remoteAddr)
+		return
+	}
 	msg, err := t.serializer.DeserializePacket(stream)
+	if errors.Cause(err) == packet.ErrUnknownPayloadVersion {
+		log.Warn("[ handleStream ] skipping packet of newer release: ", err.Error())
+		return
+	}
 	if err != nil {
-		log.Error(err, "[ handleAcceptedConnection ] failed to deserialize a packet")
+		log.Error("[ handleStream ] failed to deserialize a packet: ", err.Error())
+		t.reputation.Penalize(remoteAddr.String(), host.OffenceMalformedPacket)
+		return
 	}
+	// drain the rest of stream to receive FIN from peer
+	_, _ = ioutil.ReadAll(stream)
 
-	go t.packetHandler.Handle(context.TODO(), msg)
+	ctx, logger := inslogger.WithTraceField(context.Background(), msg.TraceID)
+	logger.Debug("[ handleStream ] Handling packet: ", msg.RequestID)
 
-	utils.CloseVerbose(stream)
+	go t.handlePacket(ctx, msg, remoteAddr)
 }
 
-func createConnection(addr string) (quic.Session, quic.Stream, error) {
-	// TODO: NETD18-78
-	session, err := quic.DialAddr(addr, &tls.Config{InsecureSkipVerify: true}, nil)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "[ createConnection ] failed to create a session")
-	}
-	stream, err := session.OpenStreamSync()
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "[ createConnection ] failed to open a stream")
+// newQuicTLSConfig creates TLS config with certificate made of node key, or generated one if key isn't set.
+func newQuicTLSConfig(privateKey crypto.PrivateKey) (*tls.Config, error) {
+	if privateKey == nil {
+		return generateTLSConfig(), nil
 	}
-	log.Debug("connected to: %s", session.RemoteAddr().String())
-	return session, stream, nil
+	return newTLSConfig(privateKey)
 }
 
 // Setup a bare-bones TLS config for the server
@@ -172,5 +267,5 @@ func generateTLSConfig() *tls.Config {
 	if err != nil {
 		panic(err)
 	}
-	return &tls.Config{Certificates: []tls.Certificate{tlsCert}}
+	return &tls.Config{Certificates: []tls.Certificate{tlsCert}, InsecureSkipVerify: true} // nolint: gosec
 }
@@ -23,6 +23,8 @@ Currently there are two default implementation of resolvers:
  - Using STUN server
  - No-op resolver which returns socket listen address
 
+Resolvers can be chained with fallback resolver which tries them in order.
+
 Usage:
 
 	var conn net.PacketConn
@@ -34,5 +36,12 @@ Usage:
 
 	fmt.Println(publicAddr)
 
+	// try STUN servers one by one, use listen address if none of them responds
+	r = resolver.NewFallbackResolver(
+		resolver.NewStunResolver("stun1.example.com:3478"),
+		resolver.NewStunResolver("stun2.example.com:3478"),
+		resolver.NewExactResolver(),
+	)
+
 */
 package resolver
@@ -0,0 +1,54 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package resolver
+
+import (
+	"net"
+
+	"github.com/insolar/insolar/log"
+	"github.com/pkg/errors"
+)
+
+type fallbackResolver struct {
+	resolvers []PublicAddressResolver
+}
+
+// NewFallbackResolver returns resolver which tries given resolvers in order until one of them succeeds.
+func NewFallbackResolver(resolvers ...PublicAddressResolver) PublicAddressResolver {
+	return newFallbackResolver(resolvers...)
+}
+
+func newFallbackResolver(resolvers ...PublicAddressResolver) *fallbackResolver {
+	return &fallbackResolver{
+		resolvers: resolvers,
+	}
+}
+
+// Resolve returns address resolved by first successful resolver.
+func (fr *fallbackResolver) Resolve(conn net.PacketConn) (string, error) {
+	var errs []string
+	for _, r := range fr.resolvers {
+		address, err := r.Resolve(conn)
+		if err == nil {
+			return address, nil
+		}
+		log.Warnf("Failed to resolve public address, trying next resolver: %s", err)
+		errs = append(errs, err.Error())
+	}
+	return "", errors.Errorf("All resolvers failed: %v", errs)
+}
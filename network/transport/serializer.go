@@ -0,0 +1,143 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"io"
+	"sync"
+
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/pkg/errors"
+)
+
+// negotiatingSerializer compresses packet bodies and encodes them with formats accepted by peers. Formats accepted
+// by peer are learned from headers of packets received from it. Codecs are chosen on connection handshake by TCP
+// transport, other transports learn them from headers of packets received from connection address of peer. So the
+// first packet to a peer may be sent uncompressed and in gob format, but large responses are compressed if request
+// was sent by a peer with compression enabled.
+type negotiatingSerializer struct {
+	compressor *compressor
+	// formats are enabled formats in order of preference
+	formats  []packet.Format
+	accepted packet.Capabilities
+	// signer signs packets if it is not nil
+	signer packet.Signer
+	// handshake is set if codecs are chosen on connection handshake and are not learned from packet headers
+	handshake bool
+
+	// peerFormats holds formats chosen for peers by their addresses
+	peerFormats map[string]packet.Format
+	// peerCodecs holds codecs chosen for peers by connection addresses, nil codec means no compression
+	peerCodecs map[string]compressionCodec
+	peersLock  sync.RWMutex
+}
+
+func newNegotiatingSerializer(c *compressor, formats []packet.Format) *negotiatingSerializer {
+	s := &negotiatingSerializer{
+		compressor:  c,
+		formats:     formats,
+		peerFormats: make(map[string]packet.Format),
+		peerCodecs:  make(map[string]compressionCodec),
+	}
+	if c != nil {
+		for _, codec := range c.codecs {
+			s.accepted.Codecs = append(s.accepted.Codecs, codec.ID())
+		}
+	}
+	for _, format := range formats {
+		s.accepted.Formats = append(s.accepted.Formats, format.ID())
+	}
+	return s
+}
+
+// newFormats returns formats from list of names in order of preference.
+func newFormats(names []string) ([]packet.Format, error) {
+	var formats []packet.Format
+	for _, name := range names {
+		format, ok := packet.Formats[name]
+		if !ok {
+			return nil, errors.Errorf("unknown packet format %s", name)
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// chooseFormat returns first enabled format that is gob or one of offered ids, or nil that means gob.
+func (s *negotiatingSerializer) chooseFormat(offered []byte) packet.Format {
+	for _, format := range s.formats {
+		if format.ID() == 0 {
+			return format
+		}
+		for _, id := range offered {
+			if format.ID() == id {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// setCodec sets codec chosen on handshake of connection to address.
+func (s *negotiatingSerializer) setCodec(address string, codec compressionCodec) {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+	s.peerCodecs[address] = codec
+}
+
+// learnCodecs chooses codec for peer from codecs it accepts. Remote is address of connection packet came from,
+// address reported by sender is not trusted, otherwise a peer could make node send compressed packets to a node that
+// can't read them.
+func (s *negotiatingSerializer) learnCodecs(remote string, accepted []byte) {
+	if s.handshake || remote == "" {
+		return
+	}
+	s.setCodec(remote, s.compressor.choose(accepted))
+}
+
+func (s *negotiatingSerializer) SerializePacket(q *packet.Packet) ([]byte, error) {
+	var codec compressionCodec
+	var format packet.Format
+	if q.Receiver != nil && q.Receiver.Address != nil {
+		address := q.Receiver.Address.String()
+		s.peersLock.RLock()
+		codec = s.peerCodecs[address]
+		format = s.peerFormats[address]
+		s.peersLock.RUnlock()
+	}
+	return packet.SerializePacketNegotiated(q, packet.SerializeOptions{
+		Codec:    codec,
+		Format:   format,
+		Accepted: s.accepted,
+		Signer:   s.signer,
+	})
+}
+
+func (s *negotiatingSerializer) DeserializePacket(conn io.Reader) (*packet.Packet, error) {
+	msg, accepted, err := packet.DeserializePacketNegotiated(conn)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Sender != nil && msg.Sender.Address != nil {
+		format := s.chooseFormat(accepted.Formats)
+		s.peersLock.Lock()
+		s.peerFormats[msg.Sender.Address.String()] = format
+		s.peersLock.Unlock()
+	}
+	return msg, nil
+}
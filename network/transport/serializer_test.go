@@ -0,0 +1,87 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// formatOf returns id of format of serialized packet from its header.
+func formatOf(serialized []byte) byte {
+	return serialized[5] & 0x0F
+}
+
+func TestNegotiatingSerializerFormats(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	sender, _ := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	receiver, _ := host.NewHostN("127.0.0.2:31338", testutils.RandomRef())
+	oldSender, _ := host.NewHostN("127.0.0.3:31339", testutils.RandomRef())
+
+	_, err := newFormats([]string{"unknown"})
+	require.Error(t, err)
+	formats, err := newFormats([]string{"protobuf"})
+	require.NoError(t, err)
+	serializerA := newNegotiatingSerializer(nil, formats)
+	serializerB := newNegotiatingSerializer(nil, formats)
+
+	// B doesn't know formats of A yet
+	response := packet.NewBuilder(receiver).Receiver(sender).Type(packet.TestPacket).
+		Response(&packet.RequestTest{Data: []byte{0, 1, 2, 3}}).Build()
+	serialized, err := serializerB.SerializePacket(response)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), formatOf(serialized))
+
+	// A tells B about its formats in request header
+	request := packet.NewBuilder(sender).Receiver(receiver).Type(packet.TestPacket).
+		Request(&packet.RequestTest{}).Build()
+	serialized, err = serializerA.SerializePacket(request)
+	require.NoError(t, err)
+	_, err = serializerB.DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+
+	serialized, err = serializerB.SerializePacket(response)
+	require.NoError(t, err)
+	require.Equal(t, packet.Formats["protobuf"].ID(), formatOf(serialized))
+	msg, err := serializerA.DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Equal(t, response.Sender, msg.Sender)
+	require.Equal(t, response.Data, msg.Data)
+	require.Equal(t, []byte{packet.Formats["protobuf"].ID()}, msg.Accepted().Formats)
+
+	// node without formats gets gob
+	request = packet.NewBuilder(oldSender).Receiver(receiver).Type(packet.TestPacket).
+		Request(&packet.RequestTest{}).Build()
+	serialized, err = (&baseSerializer{}).SerializePacket(request)
+	require.NoError(t, err)
+	_, err = serializerB.DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+
+	response.Receiver = oldSender
+	serialized, err = serializerB.SerializePacket(response)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), formatOf(serialized))
+	_, err = (&baseSerializer{}).DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+}
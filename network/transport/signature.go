@@ -0,0 +1,149 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bytes"
+	"crypto"
+	"net"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// KeyResolver returns public key of authenticated node or nil if node is not authenticated.
+type KeyResolver func(ref core.RecordRef) crypto.PublicKey
+
+// packetSigner signs outgoing packets with node key.
+type packetSigner struct {
+	signer    core.Signer
+	publicKey []byte
+}
+
+func newPacketSigner(privateKey crypto.PrivateKey) (*packetSigner, error) {
+	kp := platformpolicy.NewKeyProcessor()
+	publicKey, err := kp.ExportPublicKeyBinary(kp.ExtractPublicKey(privateKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export node public key")
+	}
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	return &packetSigner{signer: scheme.Signer(privateKey), publicKey: publicKey}, nil
+}
+
+// PublicKey returns binary encoded public key of node.
+func (s *packetSigner) PublicKey() []byte {
+	return s.publicKey
+}
+
+// Sign signs serialized packet body.
+func (s *packetSigner) Sign(data []byte) ([]byte, error) {
+	signature, err := s.signer.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
+
+// packetVerifier checks signatures of incoming packets. Packets are verified with key of authenticated
+// sender or, if sender is not authenticated yet, with key sent in packet. Unsigned packets are rejected from every
+// sender, sender reference in packet is not authenticated by itself.
+type packetVerifier struct {
+	scheme  core.PlatformCryptographyScheme
+	kp      core.KeyProcessor
+	resolve KeyResolver
+}
+
+func newPacketVerifier() *packetVerifier {
+	return &packetVerifier{
+		scheme: platformpolicy.NewPlatformCryptographyScheme(),
+		kp:     platformpolicy.NewKeyProcessor(),
+	}
+}
+
+// authenticatedKey returns key of authenticated sender, nil if sender is unknown.
+func (v *packetVerifier) authenticatedKey(msg *packet.Packet) crypto.PublicKey {
+	if v.resolve == nil || msg.Sender == nil || msg.Sender.NodeID.IsEmpty() {
+		return nil
+	}
+	return v.resolve(msg.Sender.NodeID)
+}
+
+// verify checks packet signature, returns true if packet is signed by authenticated sender.
+func (v *packetVerifier) verify(msg *packet.Packet) (bool, error) {
+	signature := msg.Signature()
+	if signature == nil {
+		return false, errors.New("unsigned packet")
+	}
+
+	key := v.authenticatedKey(msg)
+	authenticated := key != nil
+	if authenticated {
+		// key sent in packet must be the one node is authenticated with, so it can't be swapped
+		exported, err := v.kp.ExportPublicKeyBinary(key)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to export sender public key")
+		}
+		if !bytes.Equal(exported, signature.PublicKey) {
+			return false, errors.New("packet is signed with key sender is not authenticated with")
+		}
+	} else {
+		var err error
+		key, err = v.kp.ImportPublicKeyBinary(signature.PublicKey)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to import sender public key")
+		}
+	}
+	if !v.scheme.Verifier(key).Verify(core.SignatureFromBytes(signature.Signature), signature.Data) {
+		return false, errors.New("invalid packet signature")
+	}
+	return authenticated, nil
+}
+
+// SetKeyResolver enables signature verification of incoming packets with keys of authenticated nodes.
+func (t *baseTransport) SetKeyResolver(resolve KeyResolver) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.verifier == nil {
+		t.verifier = newPacketVerifier()
+	}
+	t.verifier.resolve = resolve
+}
+
+// isForged checks signature of incoming packet if verification is enabled, remote address of connection forged
+// packet came from is penalized. Authenticated is true if packet is signed by authenticated sender.
+func (t *baseTransport) isForged(msg *packet.Packet, remote net.Addr) (forged bool, authenticated bool) {
+	t.mutex.RLock()
+	verifier := t.verifier
+	t.mutex.RUnlock()
+
+	if verifier == nil {
+		return false, false
+	}
+	authenticated, err := verifier.verify(msg)
+	if err == nil {
+		return false, authenticated
+	}
+	t.reputation.Penalize(remote.String(), host.OffenceFailedAuth)
+	metrics.NetworkPacketDroppedSignatureTotal.Inc()
+	return true, false
+}
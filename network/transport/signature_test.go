@@ -0,0 +1,114 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/gob"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func signedPacket(t *testing.T, signer packet.Signer, sender *host.Host) *packet.Packet {
+	msg := packet.NewBuilder(sender).Receiver(sender).Type(types.Ping).
+		Request(&packet.RequestTest{Data: []byte("insolar")}).Build()
+	serializer := &baseSerializer{signer: signer}
+	data, err := serializer.SerializePacket(msg)
+	require.NoError(t, err)
+	result, err := serializer.DeserializePacket(bytes.NewReader(data))
+	require.NoError(t, err)
+	return result
+}
+
+func TestPacketVerifier(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	signer, err := newPacketSigner(key)
+	require.NoError(t, err)
+
+	sender, err := host.NewHostN("127.0.0.1:31337", testutils.RandomRef())
+	require.NoError(t, err)
+	authenticated := map[core.RecordRef]crypto.PublicKey{}
+	verifier := newPacketVerifier()
+	verifier.resolve = func(ref core.RecordRef) crypto.PublicKey {
+		return authenticated[ref]
+	}
+
+	signed := signedPacket(t, signer, sender)
+	unsigned := signedPacket(t, nil, sender)
+
+	// peer which is not authenticated yet must sign packets with key sent in packet
+	ok, err := verifier.verify(signed)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, err = verifier.verify(unsigned)
+	require.Error(t, err)
+
+	// unsigned packet is rejected even if it claims to be sent by authenticated peer
+	authenticated[sender.NodeID] = kp.ExtractPublicKey(key)
+	ok, err = verifier.verify(signed)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = verifier.verify(unsigned)
+	require.Error(t, err)
+
+	// authenticated peer can't sign packets with another key
+	authenticated[sender.NodeID] = kp.ExtractPublicKey(otherKey)
+	_, err = verifier.verify(signed)
+	require.Error(t, err)
+
+	// signed data can't be changed
+	delete(authenticated, sender.NodeID)
+	signed.Signature().Data[0] ^= 0xFF
+	_, err = verifier.verify(signed)
+	require.Error(t, err)
+}
+
+func TestBaseTransport_IsForged(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	transport := newBaseTransport(nil, "")
+	transport.reputation = host.NewReputation(-25, time.Minute)
+	transport.SetKeyResolver(func(ref core.RecordRef) crypto.PublicKey {
+		return nil
+	})
+
+	sender, err := host.NewHostN("127.0.0.2:31337", testutils.RandomRef())
+	require.NoError(t, err)
+	remote := &net.UDPAddr{IP: net.ParseIP("127.0.0.3"), Port: 31337}
+
+	// connection address is penalized, address reported in packet may belong to another peer
+	forged, authenticated := transport.isForged(signedPacket(t, nil, sender), remote)
+	require.True(t, forged)
+	require.False(t, authenticated)
+	require.True(t, transport.reputation.IsBanned(remote.String()))
+	require.False(t, transport.reputation.IsBanned(sender.Address.String()))
+}
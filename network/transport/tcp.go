@@ -19,11 +19,16 @@ package transport
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
 	"net"
+	"time"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/pool"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
@@ -36,21 +41,47 @@ type tcpTransport struct {
 	pool     pool.ConnectionPool
 	listener net.Listener
 	addr     string
+
+	// compressor negotiates codecs of outgoing connections, packet bodies are compressed by serializer
+	compressor *compressor
+	// tlsConfig is not nil if connections are encrypted
+	tlsConfig *tls.Config
+	// keepAlivePeriod is period of TCP keep-alive probes of outgoing connections, system default is used if zero
+	keepAlivePeriod time.Duration
 }
 
-func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string) (*tcpTransport, error) {
+func newTCPTransport(
+	addr string,
+	proxy relay.Proxy,
+	publicAddress string,
+	compressor *compressor,
+	tlsConfig *tls.Config,
+	poolOptions pool.Options,
+	keepAlivePeriod time.Duration,
+) (*tcpTransport, error) {
 	transport := &tcpTransport{
 		baseTransport: newBaseTransport(proxy, publicAddress),
 		addr:          addr,
-		pool:          pool.NewConnectionPool(&tcpConnectionFactory{}),
+		compressor:    compressor,
+		tlsConfig:     tlsConfig,
+
+		keepAlivePeriod: keepAlivePeriod,
 	}
+	transport.pool = pool.NewConnectionPool(&tcpConnectionFactory{transport: transport}, poolOptions)
 
 	transport.sendFunc = transport.send
 
 	return transport, nil
 }
 
-func (t *tcpTransport) send(address string, data []byte) error {
+// setCodec passes codec negotiated for connection to address to serializer.
+func (t *tcpTransport) setCodec(address string, codec compressionCodec) {
+	if s, ok := t.serializer.(*negotiatingSerializer); ok {
+		s.setCodec(address, codec)
+	}
+}
+
+func (t *tcpTransport) send(address string, packetType types.PacketType, data []byte) error {
 	ctx := context.Background()
 	logger := inslogger.FromContext(ctx)
 
@@ -98,6 +129,9 @@ func (t *tcpTransport) prepareListen() error {
 	if err != nil {
 		return err
 	}
+	if t.tlsConfig != nil {
+		listener = tls.NewListener(listener, t.tlsConfig)
+	}
 
 	t.listener = listener
 
@@ -144,28 +178,65 @@ func (t *tcpTransport) Stop() {
 func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 	defer utils.CloseVerbose(conn)
 
+	if t.isBanned(conn.RemoteAddr()) {
+		log.Debugf("[ handleAcceptedConnection ] Dropped connection from banned peer %s", conn.RemoteAddr())
+		return
+	}
+	if t.isDenied(conn.RemoteAddr()) {
+		log.Debugf("[ handleAcceptedConnection ] Dropped connection from denied peer %s", conn.RemoteAddr())
+		return
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			log.Warn("[ handleAcceptedConnection ] TLS handshake failed: ", err.Error())
+			t.reputation.Penalize(conn.RemoteAddr().String(), host.OffenceFailedAuth)
+			return
+		}
+	}
+
+	reader, err := t.compressor.acceptHandshake(conn)
+	if err != nil {
+		log.Warn("[ handleAcceptedConnection ] Failed to read connection preface: ", err.Error())
+		return
+	}
+
 	for {
-		msg, err := t.serializer.DeserializePacket(conn)
+		// access list may be updated while connection is open
+		if t.isDenied(conn.RemoteAddr()) {
+			log.Debugf("[ handleAcceptedConnection ] Closing connection from denied peer %s", conn.RemoteAddr())
+			return
+		}
+		msg, err := t.serializer.DeserializePacket(reader)
 
 		if err != nil {
 			if err == io.EOF || err == io.ErrUnexpectedEOF {
 				log.Warn("[ handleAcceptedConnection ] Connection closed by peer")
 				return
 			}
+			if errors.Cause(err) == packet.ErrUnknownPayloadVersion {
+				log.Warn("[ handleAcceptedConnection ] Skipping packet of newer release: ", err.Error())
+				continue
+			}
 
 			log.Error("[ handleAcceptedConnection ] Failed to deserialize packet: ", err.Error())
+			if t.reputation.Penalize(conn.RemoteAddr().String(), host.OffenceMalformedPacket) {
+				log.Warnf("[ handleAcceptedConnection ] Peer %s is banned, closing connection", conn.RemoteAddr())
+				return
+			}
 		} else {
 			ctx, logger := inslogger.WithTraceField(context.Background(), msg.TraceID)
 			logger.Debug("[ handleAcceptedConnection ] Handling packet: ", msg.RequestID)
 
-			go t.packetHandler.Handle(ctx, msg)
+			go t.handlePacket(ctx, msg, conn.RemoteAddr())
 		}
 	}
 }
 
-type tcpConnectionFactory struct{}
+type tcpConnectionFactory struct {
+	transport *tcpTransport
+}
 
-func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+func (f *tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 	tcpAddress, ok := address.(*net.TCPAddr)
 	if !ok {
@@ -182,11 +253,41 @@ func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.A
 	if err != nil {
 		logger.Error("[ createConnection ] Failed to set keep alive")
 	}
+	if f.transport != nil && f.transport.keepAlivePeriod > 0 {
+		err = conn.SetKeepAlivePeriod(f.transport.keepAlivePeriod)
+		if err != nil {
+			logger.Error("[ createConnection ] Failed to set keep alive period")
+		}
+	}
 
 	err = conn.SetNoDelay(true)
 	if err != nil {
 		logger.Errorln("[ createConnection ] Failed to set connection no delay: ", err.Error())
 	}
 
-	return conn, nil
+	var result net.Conn = conn
+	if f.transport != nil && f.transport.tlsConfig != nil {
+		tlsConn := tls.Client(conn, f.transport.tlsConfig)
+		handshakeCtx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+		err := tlsConn.HandshakeContext(handshakeCtx)
+		cancel()
+		if err != nil {
+			utils.CloseVerbose(conn)
+			return nil, errors.Wrap(err, "[ createConnection ] Failed to perform TLS handshake")
+		}
+		logger.Debugf("[ createConnection ] TLS connection to %s established, resumed: %t", address, tlsConn.ConnectionState().DidResume)
+		result = tlsConn
+	}
+
+	if f.transport != nil && f.transport.compressor != nil {
+		codec, err := f.transport.compressor.handshake(result)
+		if err != nil {
+			utils.CloseVerbose(result)
+			return nil, errors.Wrap(err, "[ createConnection ] Failed to negotiate compression")
+		}
+		logger.Debugf("[ createConnection ] Negotiated compression with %s: %s", address, codecName(codec))
+		f.transport.setCodec(address.String(), codec)
+	}
+
+	return result, nil
 }
@@ -0,0 +1,96 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfig_SessionResumption(t *testing.T) {
+	kp := platformpolicy.NewKeyProcessor()
+	serverKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	clientKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	serverConfig, err := newTLSConfig(serverKey)
+	require.NoError(t, err)
+	clientConfig, err := newTLSConfig(clientKey)
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				buf := make([]byte, 1)
+				for {
+					if _, err := conn.Read(buf); err != nil {
+						return
+					}
+					if _, err := conn.Write(buf); err != nil {
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	connect := func() bool {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+		require.NoError(t, err)
+		defer conn.Close()
+		// read echo to receive session ticket sent after handshake
+		_, err = conn.Write([]byte{1})
+		require.NoError(t, err)
+		_, err = conn.Read(make([]byte, 1))
+		require.NoError(t, err)
+		state := conn.ConnectionState()
+		require.Equal(t, uint16(tls.VersionTLS13), state.Version)
+		return state.DidResume
+	}
+
+	require.False(t, connect())
+	require.True(t, connect())
+}
+
+func TestVerifySelfSigned(t *testing.T) {
+	key, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	require.NoError(t, err)
+	config, err := newTLSConfig(key)
+	require.NoError(t, err)
+
+	raw := config.Certificates[0].Certificate
+	require.NoError(t, verifySelfSigned(raw, nil))
+
+	broken := append([]byte{}, raw[0]...)
+	broken[len(broken)-1] ^= 0xff
+	require.Error(t, verifySelfSigned([][]byte{broken}, nil))
+	require.Error(t, verifySelfSigned(nil, nil))
+}
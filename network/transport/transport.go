@@ -19,13 +19,21 @@ package transport
 
 import (
 	"context"
+	"crypto"
+	"crypto/tls"
 	"net"
+	"strings"
+	"time"
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/transport/connection"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/nat"
 	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/pool"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/transport/resolver"
 	"github.com/insolar/insolar/network/utils"
@@ -60,51 +68,327 @@ type Transport interface {
 
 	// PublicAddress returns PublicAddress
 	PublicAddress() string
+
+	// Reputation returns tracker of peer scores, packets from banned peers are dropped.
+	Reputation() *host.Reputation
+
+	// Faults returns fault injection layer, packets to and from isolated peers are dropped.
+	Faults() *host.Faults
+
+	// AccessList returns allow and deny lists, packets from denied peers are dropped.
+	AccessList() *host.AccessList
+
+	// Capture returns packet capture, the last packets of each peer are kept while capture is enabled.
+	Capture() *host.Capture
+
+	// SetKeyResolver enables verification of packet signatures, packets with invalid signatures and unsigned
+	// packets from not authenticated peers are dropped.
+	SetKeyResolver(resolve KeyResolver)
+
+	// SendQueue returns priority queue of outgoing packets, nil if packets are sent without queue.
+	SendQueue() *host.SendQueue
+
+	// SetSendQueue makes transport send packets through queue shared with another transport.
+	SetSendQueue(queue *host.SendQueue)
 }
 
 // NewTransport creates new Transport with particular configuration
 func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, error) {
+	return NewTransportWithKey(cfg, proxy, nil)
+}
+
+// NewTransportWithKey creates new Transport with particular configuration, privateKey is node key
+// which is required if TLS or packet signing is enabled in configuration.
+func NewTransportWithKey(cfg configuration.Transport, proxy relay.Proxy, privateKey crypto.PrivateKey) (Transport, error) {
+	if cfg.TLS && cfg.Protocol != "TCP" && cfg.Protocol != "QUIC" {
+		return nil, errors.New("[ NewTransport ] TLS is supported only for TCP and QUIC transports")
+	}
+	if cfg.TLS && privateKey == nil {
+		return nil, errors.New("[ NewTransport ] TLS requires node private key")
+	}
+	if cfg.SignPackets && privateKey == nil {
+		return nil, errors.New("[ NewTransport ] Packet signing requires node private key")
+	}
+
+	var signer packet.Signer
+	if cfg.SignPackets {
+		packetSigner, err := newPacketSigner(privateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewTransport ] Failed to create packet signer")
+		}
+		signer = packetSigner
+	}
+
+	if cfg.Protocol == "MEMORY" || cfg.Protocol == "MEMORY_UDP" {
+		return createMemoryTransport(cfg, proxy, signer)
+	}
+
 	// TODO: let each transport creates connection in their constructor
-	conn, publicAddress, err := NewConnection(cfg)
+	conn, publicAddress, mapping, err := newConnection(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ NewTransport ] Failed to create connection.")
 	}
 
+	t, err := createTransport(cfg, conn, proxy, publicAddress, privateKey, signer)
+	if mapping == nil {
+		return t, err
+	}
+	if err != nil {
+		utils.CloseVerbose(mapping)
+		return nil, err
+	}
+	return &mappedTransport{Transport: t, mapping: mapping}, nil
+}
+
+func createTransport(
+	cfg configuration.Transport,
+	conn net.PacketConn,
+	proxy relay.Proxy,
+	publicAddress string,
+	privateKey crypto.PrivateKey,
+	signer packet.Signer,
+) (Transport, error) {
+	accessList, err := newAccessList(cfg)
+	if err != nil {
+		utils.CloseVerbose(conn)
+		return nil, errors.Wrap(err, "[ NewTransport ] Failed to create access list")
+	}
+	queue, err := newSendQueueFromConfig(cfg)
+	if err != nil {
+		utils.CloseVerbose(conn)
+		return nil, errors.Wrap(err, "[ NewTransport ] Failed to create send queue")
+	}
+
 	switch cfg.Protocol {
 	case "TCP":
 		// TODO: little hack: It's better to change interface for NewConnection
 		utils.CloseVerbose(conn)
 
-		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress)
+		compressor, err := newCompressor(splitList(cfg.Compression))
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewTransport ] Failed to create compressor")
+		}
+		var tlsConfig *tls.Config
+		if cfg.TLS {
+			tlsConfig, err = newTLSConfig(privateKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "[ NewTransport ] Failed to create TLS config")
+			}
+		}
+		serializer, err := newSerializer(cfg, signer)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewTransport ] Failed to create serializer")
+		}
+		transport, err := newTCPTransport(
+			conn.LocalAddr().String(), proxy, publicAddress, compressor, tlsConfig,
+			newPoolOptions(cfg), time.Duration(cfg.KeepAlivePeriod)*time.Millisecond,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transport.serializer = serializer
+		transport.reputation = newReputation(cfg)
+		transport.accessList = accessList
+		transport.replayCache = newReplayCache(cfg)
+		transport.sendQueue = queue
+		return transport, nil
 	case "PURE_UDP":
-		return newUDPTransport(conn, proxy, publicAddress)
+		transport, err := newUDPTransport(conn, proxy, publicAddress)
+		if err != nil {
+			return nil, err
+		}
+		transport.reputation = newReputation(cfg)
+		transport.accessList = accessList
+		transport.replayCache = newReplayCache(cfg)
+		transport.sendQueue = queue
+		return transport, nil
 	case "QUIC":
-		return newQuicTransport(conn, proxy, publicAddress)
+		serializer, err := newSerializer(cfg, signer)
+		if err != nil {
+			utils.CloseVerbose(conn)
+			return nil, errors.Wrap(err, "[ NewTransport ] Failed to create serializer")
+		}
+		transport, err := newQuicTransport(conn, proxy, publicAddress, privateKey)
+		if err != nil {
+			return nil, err
+		}
+		transport.serializer = serializer
+		transport.reputation = newReputation(cfg)
+		transport.accessList = accessList
+		transport.replayCache = newReplayCache(cfg)
+		transport.sendQueue = queue
+		return transport, nil
 	default:
 		utils.CloseVerbose(conn)
 		return nil, errors.New("invalid transport configuration")
 	}
 }
 
+// createMemoryTransport creates transport of in-memory network registered with name from configuration. MEMORY_UDP
+// transport serializes consensus packets like PURE_UDP.
+func createMemoryTransport(cfg configuration.Transport, proxy relay.Proxy, signer packet.Signer) (Transport, error) {
+	memoryNetwork, err := getMemoryNetwork(cfg.MemoryNetwork)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewTransport ] Failed to get memory network")
+	}
+	accessList, err := newAccessList(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewTransport ] Failed to create access list")
+	}
+	queue, err := newSendQueueFromConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewTransport ] Failed to create send queue")
+	}
+	datagram := cfg.Protocol == "MEMORY_UDP"
+	transport, err := newMemoryTransport(memoryNetwork, cfg.Address, proxy, datagram)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewTransport ] Failed to create memory transport")
+	}
+	if datagram {
+		transport.serializer = &udpSerializer{}
+	} else {
+		transport.serializer, err = newSerializer(cfg, signer)
+		if err != nil {
+			transport.network.close(transport.address)
+			return nil, errors.Wrap(err, "[ NewTransport ] Failed to create serializer")
+		}
+	}
+	transport.reputation = newReputation(cfg)
+	transport.accessList = accessList
+	transport.replayCache = newReplayCache(cfg)
+	transport.sendQueue = queue
+	return transport, nil
+}
+
+// newSerializer creates packet serializer which compresses packets and encodes them with formats other than gob
+// if it is enabled in configuration. Packets are signed if signer is not nil.
+func newSerializer(cfg configuration.Transport, signer packet.Signer) (transportSerializer, error) {
+	compressor, err := newCompressor(splitList(cfg.Compression))
+	if err != nil {
+		return nil, err
+	}
+	formats, err := newFormats(splitList(cfg.PacketFormats))
+	if err != nil {
+		return nil, err
+	}
+	if compressor == nil && len(formats) == 0 {
+		return &baseSerializer{signer: signer}, nil
+	}
+	serializer := newNegotiatingSerializer(compressor, formats)
+	serializer.signer = signer
+	// TCP transport chooses codecs on connection handshake
+	serializer.handshake = cfg.Protocol == "TCP"
+	return serializer, nil
+}
+
+// newReputation creates tracker of peer scores from configuration.
+func newReputation(cfg configuration.Transport) *host.Reputation {
+	return host.NewReputation(cfg.BanThreshold, time.Duration(cfg.BanDuration)*time.Millisecond)
+}
+
+// newPoolOptions creates options of TCP connection pool from configuration.
+func newPoolOptions(cfg configuration.Transport) pool.Options {
+	return pool.Options{
+		MaxConnectionsPerPeer: cfg.MaxConnectionsPerPeer,
+		IdleTimeout:           time.Duration(cfg.ConnectionIdleTimeout) * time.Millisecond,
+	}
+}
+
+// newReplayCache creates cache of recently received requests from configuration.
+func newReplayCache(cfg configuration.Transport) *host.ReplayCache {
+	return host.NewReplayCache(time.Duration(cfg.ReplayWindow)*time.Millisecond, host.DefaultReplayLimit)
+}
+
+// newAccessList creates allow and deny lists of peers from configuration.
+func newAccessList(cfg configuration.Transport) (*host.AccessList, error) {
+	return host.NewAccessList(splitList(cfg.AllowList), splitList(cfg.DenyList))
+}
+
 // NewConnection creates new Connection from configuration and returns connection and public address
 func NewConnection(cfg configuration.Transport) (net.PacketConn, string, error) {
-	conn, err := connection.NewConnectionFactory().Create(cfg.Address)
+	conn, publicAddress, _, err := newConnection(cfg)
+	return conn, publicAddress, err
+}
+
+func newConnection(cfg configuration.Transport) (net.PacketConn, string, *nat.Mapping, error) {
+	conn, err := connection.NewConnectionFactory().Create(listenAddress(cfg))
 	if err != nil {
-		return nil, "", errors.Wrap(err, "[ NewConnection ] Failed to create connection")
+		return nil, "", nil, errors.Wrap(err, "[ NewConnection ] Failed to create connection")
 	}
-	publicAddress, err := createResolver(cfg.BehindNAT).Resolve(conn)
+	if cfg.PortMapping {
+		mapping, err := nat.Map(mappingProtocol(cfg.Protocol), conn.LocalAddr().(*net.UDPAddr).Port)
+		if err == nil {
+			return conn, mapping.ExternalAddress(), mapping, nil
+		}
+		log.Warnf("[ NewConnection ] Failed to map port on NAT gateway, fallback to resolver: %s", err)
+	}
+	publicAddress, err := createResolver(cfg).Resolve(conn)
 	if err != nil {
 		utils.CloseVerbose(conn)
-		return nil, "", errors.Wrap(err, "[ NewConnection ] Failed to create resolver")
+		return nil, "", nil, errors.Wrap(err, "[ NewConnection ] Failed to create resolver")
+	}
+	return conn, publicAddress, nil, nil
+}
+
+// listenAddress returns address to listen, unspecified IPv4 address is replaced with IPv6 one for dual-stack listening.
+func listenAddress(cfg configuration.Transport) string {
+	if !cfg.DualStack {
+		return cfg.Address
+	}
+	host, port, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		return cfg.Address
+	}
+	if host == "" || host == net.IPv4zero.String() {
+		return net.JoinHostPort(net.IPv6unspecified.String(), port)
+	}
+	return cfg.Address
+}
+
+// mappingProtocol returns protocol of port mapping on NAT gateway for transport protocol
+func mappingProtocol(protocol string) string {
+	if protocol == "TCP" {
+		return "TCP"
+	}
+	return "UDP"
+}
+
+// mappedTransport removes port mapping from NAT gateway when transport is closed
+type mappedTransport struct {
+	Transport
+	mapping *nat.Mapping
+}
+
+func (t *mappedTransport) Close() {
+	t.Transport.Close()
+	utils.CloseVerbose(t.mapping)
+}
+
+func splitList(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
 	}
-	return conn, publicAddress, nil
+	return names
 }
 
-func createResolver(stun bool) resolver.PublicAddressResolver {
-	if stun {
-		return resolver.NewStunResolver("")
+func createResolver(cfg configuration.Transport) resolver.PublicAddressResolver {
+	if !cfg.BehindNAT {
+		return resolver.NewExactResolver()
+	}
+	servers := splitList(cfg.STUNServers)
+	if len(servers) == 0 {
+		servers = []string{""}
+	}
+	resolvers := make([]resolver.PublicAddressResolver, 0, len(servers)+1)
+	for _, server := range servers {
+		resolvers = append(resolvers, resolver.NewStunResolver(server))
 	}
-	return resolver.NewExactResolver()
+	resolvers = append(resolvers, resolver.NewExactResolver())
+	return resolver.NewFallbackResolver(resolvers...)
 }
 
 func ListenAndWaitUntilReady(ctx context.Context, transport Transport) {
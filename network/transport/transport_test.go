@@ -19,8 +19,10 @@ package transport
 
 import (
 	"context"
+	"crypto"
 	"crypto/rand"
 	"encoding/gob"
+	"net"
 	"testing"
 
 	"github.com/insolar/insolar/configuration"
@@ -29,11 +31,14 @@ import (
 	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/relay"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
 type node struct {
 	config    configuration.Transport
+	key       crypto.PrivateKey
 	transport Transport
 	host      *host.Host
 }
@@ -67,7 +72,12 @@ func setupNode(t *transportSuite, n *node) {
 	n.host, err = host.NewHost(n.config.Address)
 	t.Assert().NoError(err)
 
-	n.transport, err = NewTransport(n.config, relay.NewProxy())
+	if n.config.TLS {
+		n.key, err = platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+		t.Require().NoError(err)
+	}
+
+	n.transport, err = NewTransportWithKey(n.config, relay.NewProxy(), n.key)
 	t.Require().NoError(err)
 	t.Require().NotNil(n.transport)
 	t.Require().Implements((*Transport)(nil), n.transport)
@@ -101,6 +111,11 @@ func (t *transportSuite) AfterTest(suiteName, testName string) {
 	t.node2.transport.Close()
 }
 
+// isDatagram checks if transport sends only consensus packets.
+func (t *transportSuite) isDatagram() bool {
+	return t.node1.config.Protocol == "PURE_UDP" || t.node1.config.Protocol == "MEMORY_UDP"
+}
+
 func generateRandomBytes(n int) ([]byte, error) {
 	b := make([]byte, n)
 	_, err := rand.Read(b)
@@ -111,8 +126,8 @@ func generateRandomBytes(n int) ([]byte, error) {
 }
 
 func (t *transportSuite) TestPingPong() {
-	if t.node1.config.Protocol == "PURE_UDP" {
-		t.T().Skip("Skipping TestPingPong for PURE_UDP")
+	if t.isDatagram() {
+		t.T().Skip("Skipping TestPingPong for datagram transport")
 	}
 	ctx := context.Background()
 	p := packet.NewBuilder(t.node1.host).Type(types.Ping).Receiver(t.node2.host).Build()
@@ -137,8 +152,8 @@ func (t *transportSuite) TestSendBigPacket() {
 	if testing.Short() {
 		t.T().Skip("Skipping TestSendBigPacket in short mode")
 	}
-	if t.node1.config.Protocol == "PURE_UDP" {
-		t.T().Skip("Skipping TestSendBigPacket for PURE_UDP")
+	if t.isDatagram() {
+		t.T().Skip("Skipping TestSendBigPacket for datagram transport")
 	}
 	ctx := context.Background()
 	data, _ := generateRandomBytes(1024 * 1024 * 2)
@@ -154,6 +169,34 @@ func (t *transportSuite) TestSendBigPacket() {
 	t.Assert().Equal(data, receivedData)
 }
 
+func (t *transportSuite) TestSendMultiplePackets() {
+	if t.isDatagram() {
+		t.T().Skip("Skipping TestSendMultiplePackets for datagram transport")
+	}
+	ctx := context.Background()
+	const count = 20
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		go func(i byte) {
+			builder := packet.NewBuilder(t.node1.host).Receiver(t.node2.host).Type(packet.TestPacket)
+			p := builder.Request(&packet.RequestTest{Data: []byte{i}}).Build()
+			_, err := t.node1.transport.SendRequest(ctx, p)
+			errs <- err
+		}(byte(i))
+	}
+	for i := 0; i < count; i++ {
+		t.Require().NoError(<-errs)
+	}
+
+	received := make(map[byte]bool)
+	for i := 0; i < count; i++ {
+		msg := <-t.node2.transport.Packets()
+		t.Require().Equal(packet.TestPacket, msg.Type)
+		received[msg.Data.(*packet.RequestTest).Data[0]] = true
+	}
+	t.Assert().Len(received, count)
+}
+
 func (t *consensusSuite) TestSendPacketConsensus() {
 	ctx := context.Background()
 	builder := packet.NewBuilder(t.node1.host).Receiver(t.node2.host).Type(types.Phase1)
@@ -179,11 +222,86 @@ func TestTCPTransport(t *testing.T) {
 	suite.Run(t, NewSuite(cfg1, cfg2))
 }
 
-func TestQuicTransport(t *testing.T) {
-	t.Skip("QUIC internals racing atm. Skip until we want to use it in production")
+func TestTCPTransportCompression(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17020", Compression: "flate"}
+	cfg2 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17021", Compression: "flate"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestTCPTransportCompressionOneSide(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17022", Compression: "flate"}
+	cfg2 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17023"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestTCPTransportTLS(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17024", TLS: true}
+	cfg2 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17025", TLS: true, Compression: "flate"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestNewTransportTLSRequiresKey(t *testing.T) {
+	_, err := NewTransport(configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:0", TLS: true}, relay.NewProxy())
+	require.Error(t, err)
+
+	_, err = NewTransport(configuration.Transport{Protocol: "PURE_UDP", Address: "127.0.0.1:0", TLS: true}, relay.NewProxy())
+	require.Error(t, err)
+}
 
+func TestQuicTransport(t *testing.T) {
 	cfg1 := configuration.Transport{Protocol: "QUIC", Address: "127.0.0.1:17018", BehindNAT: false}
 	cfg2 := configuration.Transport{Protocol: "QUIC", Address: "127.0.0.1:17019", BehindNAT: false}
 
 	suite.Run(t, NewSuite(cfg1, cfg2))
 }
+
+func TestQuicTransportTLS(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "QUIC", Address: "127.0.0.1:17026", TLS: true}
+	cfg2 := configuration.Transport{Protocol: "QUIC", Address: "127.0.0.1:17027", TLS: true}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestTCPTransportCompressionZstd(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17028", Compression: "zstd,flate"}
+	cfg2 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17029", Compression: "snappy,zstd"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestQuicTransportCompression(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "QUIC", Address: "127.0.0.1:17030", Compression: "flate"}
+	cfg2 := configuration.Transport{Protocol: "QUIC", Address: "127.0.0.1:17031"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestTCPTransportIPv6(t *testing.T) {
+	l, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 loopback is not available")
+	}
+	l.Close()
+
+	cfg1 := configuration.Transport{Protocol: "TCP", Address: "[::1]:17032"}
+	cfg2 := configuration.Transport{Protocol: "TCP", Address: "[::1]:17033"}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+func TestListenAddress(t *testing.T) {
+	cfg := configuration.Transport{Address: "0.0.0.0:17034"}
+	require.Equal(t, "0.0.0.0:17034", listenAddress(cfg))
+
+	cfg.DualStack = true
+	require.Equal(t, "[::]:17034", listenAddress(cfg))
+
+	cfg.Address = ":17034"
+	require.Equal(t, "[::]:17034", listenAddress(cfg))
+
+	cfg.Address = "127.0.0.1:17034"
+	require.Equal(t, "127.0.0.1:17034", listenAddress(cfg))
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
@@ -86,7 +87,7 @@ func newUDPTransport(conn net.PacketConn, proxy relay.Proxy, publicAddress strin
 	return transport, nil
 }
 
-func (t *udpTransport) send(recvAddress string, data []byte) error {
+func (t *udpTransport) send(recvAddress string, _ types.PacketType, data []byte) error {
 	log.Debug("Sending PURE_UDP request")
 	if len(data) > udpMaxPacketSize {
 		return errors.New(fmt.Sprintf("udpTransport.send: too big input data. Maximum: %d. Current: %d",
@@ -139,13 +140,22 @@ func (t *udpTransport) Stop() {
 }
 
 func (t *udpTransport) handleAcceptedConnection(data []byte, addr net.Addr) {
+	if t.isBanned(addr) {
+		log.Debug("[ handleAcceptedConnection ] Dropped packet from banned peer ", addr)
+		return
+	}
+	if t.isDenied(addr) {
+		log.Debug("[ handleAcceptedConnection ] Dropped packet from denied peer ", addr)
+		return
+	}
 	r := bytes.NewReader(data)
 	msg, err := t.serializer.DeserializePacket(r)
 	if err != nil {
 		log.Error("[ handleAcceptedConnection ] ", err)
+		t.reputation.Penalize(addr.String(), host.OffenceMalformedPacket)
 		return
 	}
 	log.Debug("[ handleAcceptedConnection ] Packet processed. size: ", len(data), ". Address: ", addr)
 
-	go t.packetHandler.Handle(context.TODO(), msg)
+	go t.handlePacket(context.TODO(), msg, addr)
 }
@@ -19,8 +19,12 @@ package networkcoordinator
 
 import (
 	"context"
+	"sync"
 
+	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
 )
 
 // NetworkCoordinator encapsulates logic of network configuration
@@ -31,10 +35,14 @@ type NetworkCoordinator struct {
 	MessageBus         core.MessageBus          `inject:""`
 	CS                 core.CryptographyService `inject:""`
 	PS                 core.PulseStorage        `inject:""`
+	NodeNetwork        core.NodeNetwork         `inject:""`
 
 	realCoordinator Coordinator
 	zeroCoordinator Coordinator
 	isStarted       bool
+
+	upgrade     *rollingUpgrade
+	upgradeLock sync.Mutex
 }
 
 // New creates new NetworkCoordinator
@@ -88,3 +96,80 @@ func (nc *NetworkCoordinator) signCertHandler(ctx context.Context, p core.Parcel
 func (nc *NetworkCoordinator) SetPulse(ctx context.Context, pulse core.Pulse) error {
 	return nc.getCoordinator().SetPulse(ctx, pulse)
 }
+
+// StartUpgrade starts rolling upgrade of nodes. Nodes are switched to draining state one by one (or by parallel
+// nodes at once) while the rest of network satisfies majority rule and min roles from certificate.
+// Progress is updated on every GetUpgradeStatus call, so operator should poll it. If version is empty node
+// is considered upgraded when it rejoins after it was seen offline.
+func (nc *NetworkCoordinator) StartUpgrade(ctx context.Context, nodes []core.RecordRef, version string, parallel int) (*core.UpgradeStatus, error) {
+	nc.upgradeLock.Lock()
+	defer nc.upgradeLock.Unlock()
+
+	if nc.upgrade != nil && !nc.upgrade.done() {
+		return nil, errors.New("[ StartUpgrade ] upgrade is already in progress")
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("[ StartUpgrade ] no nodes to upgrade")
+	}
+
+	cert, ok := nc.CertificateManager.GetCertificate().(*certificate.Certificate)
+	if !ok {
+		return nil, errors.New("[ StartUpgrade ] certificate has no network rules")
+	}
+	minRoles := map[core.StaticRole]int{
+		core.StaticRoleVirtual:       int(cert.MinRoles.Virtual),
+		core.StaticRoleHeavyMaterial: int(cert.MinRoles.HeavyMaterial),
+		core.StaticRoleLightMaterial: int(cert.MinRoles.LightMaterial),
+	}
+
+	statuses := make([]core.UpgradeNodeStatus, 0, len(nodes))
+	seen := make(map[core.RecordRef]bool, len(nodes))
+	for _, ref := range nodes {
+		if seen[ref] {
+			return nil, errors.Errorf("[ StartUpgrade ] node %s is listed twice", ref)
+		}
+		seen[ref] = true
+		node := nc.NodeNetwork.GetActiveNode(ref)
+		if node == nil {
+			return nil, errors.Errorf("[ StartUpgrade ] node %s is not active", ref)
+		}
+		statuses = append(statuses, core.UpgradeNodeStatus{Node: ref, Role: node.Role(), State: core.UpgradePending})
+	}
+
+	nc.upgrade = newRollingUpgrade(statuses, version, parallel, cert.MajorityRule, minRoles)
+	inslogger.FromContext(ctx).Infof("[ StartUpgrade ] rolling upgrade of %d nodes to version %q started", len(nodes), version)
+	return nc.stepUpgrade(ctx)
+}
+
+// GetUpgradeStatus returns progress of current rolling upgrade.
+func (nc *NetworkCoordinator) GetUpgradeStatus(ctx context.Context) (*core.UpgradeStatus, error) {
+	nc.upgradeLock.Lock()
+	defer nc.upgradeLock.Unlock()
+
+	if nc.upgrade == nil {
+		return nil, errors.New("[ GetUpgradeStatus ] no upgrade was started")
+	}
+	return nc.stepUpgrade(ctx)
+}
+
+// CancelUpgrade stops current rolling upgrade.
+func (nc *NetworkCoordinator) CancelUpgrade(ctx context.Context) error {
+	nc.upgradeLock.Lock()
+	defer nc.upgradeLock.Unlock()
+
+	if nc.upgrade == nil {
+		return errors.New("[ CancelUpgrade ] no upgrade was started")
+	}
+	nc.upgrade = nil
+	inslogger.FromContext(ctx).Info("[ CancelUpgrade ] rolling upgrade canceled")
+	return nil
+}
+
+func (nc *NetworkCoordinator) stepUpgrade(ctx context.Context) (*core.UpgradeStatus, error) {
+	pulse, err := nc.PS.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ stepUpgrade ] failed to get current pulse")
+	}
+	nc.upgrade.step(nc.NodeNetwork.GetActiveNodes(), pulse.PulseNumber)
+	return nc.upgrade.status(), nil
+}
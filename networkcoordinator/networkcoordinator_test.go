@@ -27,6 +27,7 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -38,19 +39,21 @@ func TestNewNetworkCoordinator(t *testing.T) {
 	messageBus := testutils.NewMessageBusMock(t)
 	cs := testutils.NewCryptographyServiceMock(t)
 	ps := testutils.NewPulseStorageMock(t)
+	nn := network.NewNodeNetworkMock(t)
 
 	nc, err := New()
 	require.NoError(t, err)
 	require.Equal(t, &NetworkCoordinator{}, nc)
 
 	cm := &component.Manager{}
-	cm.Inject(certificateManager, networkSwitcher, contractRequester, messageBus, cs, ps, nc)
+	cm.Inject(certificateManager, networkSwitcher, contractRequester, messageBus, cs, ps, nn, nc)
 	require.Equal(t, certificateManager, nc.CertificateManager)
 	require.Equal(t, networkSwitcher, nc.NetworkSwitcher)
 	require.Equal(t, contractRequester, nc.ContractRequester)
 	require.Equal(t, messageBus, nc.MessageBus)
 	require.Equal(t, cs, nc.CS)
 	require.Equal(t, ps, nc.PS)
+	require.Equal(t, nn, nc.NodeNetwork)
 }
 
 func TestNetworkCoordinator_Start(t *testing.T) {
@@ -0,0 +1,151 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package networkcoordinator
+
+import (
+	"github.com/insolar/insolar/core"
+)
+
+// rollingUpgrade sequences nodes for drain-upgrade-rejoin. Operator stops node when it's draining, upgrades it
+// and starts it again. Nodes are switched to draining only when the rest of network still satisfies majority rule
+// and min roles rule.
+type rollingUpgrade struct {
+	version      string
+	parallel     int
+	majorityRule int
+	minRoles     map[core.StaticRole]int
+	nodes        []core.UpgradeNodeStatus
+}
+
+func newRollingUpgrade(
+	nodes []core.UpgradeNodeStatus,
+	version string,
+	parallel int,
+	majorityRule int,
+	minRoles map[core.StaticRole]int,
+) *rollingUpgrade {
+	if parallel < 1 {
+		parallel = 1
+	}
+	return &rollingUpgrade{
+		version:      version,
+		parallel:     parallel,
+		majorityRule: majorityRule,
+		minRoles:     minRoles,
+		nodes:        nodes,
+	}
+}
+
+// step updates states of nodes from active list and switches pending nodes to draining if it's safe.
+func (ru *rollingUpgrade) step(active []core.Node, pulse core.PulseNumber) {
+	activeNodes := make(map[core.RecordRef]core.Node, len(active))
+	for _, node := range active {
+		activeNodes[node.ID()] = node
+	}
+
+	set := func(n *core.UpgradeNodeStatus, state core.UpgradeNodeState) {
+		n.State = state
+		n.Pulse = pulse
+	}
+
+	for i := range ru.nodes {
+		n := &ru.nodes[i]
+		node, isActive := activeNodes[n.Node]
+		switch n.State {
+		case core.UpgradePending:
+			if !isActive {
+				set(n, core.UpgradeOffline)
+			} else if ru.version != "" && node.Version() == ru.version {
+				set(n, core.UpgradeDone)
+			}
+		case core.UpgradeDraining:
+			if !isActive {
+				set(n, core.UpgradeOffline)
+			} else if ru.version != "" && node.Version() == ru.version {
+				// node restarted between two steps
+				set(n, core.UpgradeDone)
+			}
+		case core.UpgradeOffline:
+			if isActive && (ru.version == "" || node.Version() == ru.version) {
+				set(n, core.UpgradeDone)
+			}
+		}
+	}
+
+	available := 0
+	roles := make(map[core.StaticRole]int)
+	inProgress := 0
+	for _, n := range ru.nodes {
+		if n.State == core.UpgradeDraining || n.State == core.UpgradeOffline {
+			inProgress++
+		}
+	}
+	for _, node := range active {
+		if ru.isLeaving(node.ID()) {
+			continue
+		}
+		available++
+		roles[node.Role()]++
+	}
+
+	for i := range ru.nodes {
+		if inProgress >= ru.parallel {
+			return
+		}
+		n := &ru.nodes[i]
+		if n.State != core.UpgradePending {
+			continue
+		}
+		if available-1 < ru.majorityRule || roles[n.Role]-1 < ru.minRoles[n.Role] {
+			continue
+		}
+		set(n, core.UpgradeDraining)
+		available--
+		roles[n.Role]--
+		inProgress++
+	}
+}
+
+func (ru *rollingUpgrade) isLeaving(ref core.RecordRef) bool {
+	for _, n := range ru.nodes {
+		if n.Node == ref {
+			return n.State == core.UpgradeDraining || n.State == core.UpgradeOffline
+		}
+	}
+	return false
+}
+
+func (ru *rollingUpgrade) done() bool {
+	for _, n := range ru.nodes {
+		if n.State != core.UpgradeDone {
+			return false
+		}
+	}
+	return true
+}
+
+func (ru *rollingUpgrade) status() *core.UpgradeStatus {
+	nodes := make([]core.UpgradeNodeStatus, len(ru.nodes))
+	copy(nodes, ru.nodes)
+	return &core.UpgradeStatus{
+		Version:  ru.version,
+		Parallel: ru.parallel,
+		Nodes:    nodes,
+		Done:     ru.done(),
+	}
+}
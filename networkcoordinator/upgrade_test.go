@@ -0,0 +1,108 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package networkcoordinator
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func upgradeTestNodes(roles ...core.StaticRole) []core.Node {
+	nodes := make([]core.Node, len(roles))
+	for i, role := range roles {
+		nodes[i] = nodenetwork.NewNode(testutils.RandomRef(), role, nil, "127.0.0.1:0", "v1")
+	}
+	return nodes
+}
+
+func upgradeStatuses(nodes ...core.Node) []core.UpgradeNodeStatus {
+	statuses := make([]core.UpgradeNodeStatus, len(nodes))
+	for i, n := range nodes {
+		statuses[i] = core.UpgradeNodeStatus{Node: n.ID(), Role: n.Role(), State: core.UpgradePending}
+	}
+	return statuses
+}
+
+func upgradeStates(ru *rollingUpgrade) []core.UpgradeNodeState {
+	states := make([]core.UpgradeNodeState, len(ru.nodes))
+	for i, n := range ru.nodes {
+		states[i] = n.State
+	}
+	return states
+}
+
+func without(nodes []core.Node, node core.Node) []core.Node {
+	var result []core.Node
+	for _, n := range nodes {
+		if n != node {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func TestRollingUpgrade_Sequence(t *testing.T) {
+	active := upgradeTestNodes(core.StaticRoleVirtual, core.StaticRoleVirtual, core.StaticRoleLightMaterial)
+	ru := newRollingUpgrade(upgradeStatuses(active[0], active[1]), "v2", 0, 2, nil)
+
+	ru.step(active, 1)
+	assert.Equal(t, []core.UpgradeNodeState{core.UpgradeDraining, core.UpgradePending}, upgradeStates(ru))
+
+	// node is stopped
+	ru.step(without(active, active[0]), 2)
+	assert.Equal(t, []core.UpgradeNodeState{core.UpgradeOffline, core.UpgradePending}, upgradeStates(ru))
+	assert.Equal(t, core.PulseNumber(2), ru.nodes[0].Pulse)
+
+	// node rejoined with new version
+	active[0] = nodenetwork.NewNode(active[0].ID(), active[0].Role(), nil, "127.0.0.1:0", "v2")
+	ru.step(active, 3)
+	assert.Equal(t, []core.UpgradeNodeState{core.UpgradeDone, core.UpgradeDraining}, upgradeStates(ru))
+	assert.False(t, ru.done())
+
+	// node restarted between steps
+	active[1] = nodenetwork.NewNode(active[1].ID(), active[1].Role(), nil, "127.0.0.1:0", "v2")
+	ru.step(active, 4)
+	assert.True(t, ru.done())
+	assert.True(t, ru.status().Done)
+}
+
+func TestRollingUpgrade_MajorityRule(t *testing.T) {
+	active := upgradeTestNodes(core.StaticRoleVirtual, core.StaticRoleVirtual, core.StaticRoleVirtual)
+	ru := newRollingUpgrade(upgradeStatuses(active...), "", 3, 2, nil)
+
+	ru.step(active, 1)
+	assert.Equal(t, []core.UpgradeNodeState{core.UpgradeDraining, core.UpgradePending, core.UpgradePending}, upgradeStates(ru))
+}
+
+func TestRollingUpgrade_MinRoles(t *testing.T) {
+	active := upgradeTestNodes(core.StaticRoleVirtual, core.StaticRoleHeavyMaterial, core.StaticRoleVirtual)
+	minRoles := map[core.StaticRole]int{core.StaticRoleVirtual: 1, core.StaticRoleHeavyMaterial: 1}
+	ru := newRollingUpgrade(upgradeStatuses(active...), "", 2, 0, minRoles)
+
+	ru.step(active, 1)
+	assert.Equal(t, []core.UpgradeNodeState{core.UpgradeDraining, core.UpgradePending, core.UpgradePending}, upgradeStates(ru))
+
+	// without version node is done after it was seen offline
+	ru.step(without(active, active[0]), 2)
+	ru.step(active, 3)
+	assert.Equal(t, []core.UpgradeNodeState{core.UpgradeDone, core.UpgradePending, core.UpgradeDraining}, upgradeStates(ru))
+}
@@ -183,6 +183,18 @@ func (pp *PulsePayload) Hash(hashProvider core.Hasher) ([]byte, error) {
 		return nil, err
 	}
 
+	if pp.Pulse.ContractLimits != nil {
+		var limits bytes.Buffer
+		err = codec.NewEncoder(&limits, cborH).Encode(pp.Pulse.ContractLimits)
+		if err != nil {
+			return nil, err
+		}
+		_, err = hashProvider.Write(limits.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return hashProvider.Sum(nil), nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
@@ -267,6 +268,7 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 		EpochPulseNumber: 1,
 		OriginID:         [16]byte{206, 41, 229, 190, 7, 240, 162, 155, 121, 245, 207, 56, 161, 67, 189, 0},
 		PulseTimestamp:   time.Now().Unix(),
+		ContractLimits:   currentPulsar.contractLimits(),
 	}
 	currentPulsar.currentSlotSenderConfirmationsLock.RUnlock()
 
@@ -292,3 +294,18 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 
 	currentPulsar.StateSwitcher.SwitchToState(ctx, WaitingForStart, nil)
 }
+
+// contractLimits returns limits from config, nil means that nodes use default limits
+func (currentPulsar *Pulsar) contractLimits() *core.ContractLimits {
+	cfg := currentPulsar.Config.ContractLimits
+	if cfg == (configuration.ContractLimits{}) {
+		return nil
+	}
+	return &core.ContractLimits{
+		Version:            core.ContractLimitsVersion,
+		MaxStateSize:       cfg.MaxStateSize,
+		MaxChildrenPerCall: cfg.MaxChildrenPerCall,
+		MaxEventPayload:    cfg.MaxEventPayload,
+		MeteringBudget:     cfg.MeteringBudget,
+	}
+}
@@ -0,0 +1,441 @@
+package testutils
+
+/*
+DO NOT EDIT!
+This code was generated automatically using github.com/gojuno/minimock v1.9
+The original interface "AdminLog" can be found in github.com/insolar/insolar/core
+*/
+import (
+	context "context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gojuno/minimock"
+	core "github.com/insolar/insolar/core"
+
+	testify_assert "github.com/stretchr/testify/assert"
+)
+
+//AdminLogMock implements github.com/insolar/insolar/core.AdminLog
+type AdminLogMock struct {
+	t minimock.Tester
+
+	AppendAdminActionFunc       func(p context.Context, p1 core.AdminLogEntry) (r *core.AdminLogEntry, r1 error)
+	AppendAdminActionCounter    uint64
+	AppendAdminActionPreCounter uint64
+	AppendAdminActionMock       mAdminLogMockAppendAdminAction
+
+	GetAdminActionsFunc       func(p context.Context, p1 uint64, p2 int) (r []core.AdminLogEntry, r1 error)
+	GetAdminActionsCounter    uint64
+	GetAdminActionsPreCounter uint64
+	GetAdminActionsMock       mAdminLogMockGetAdminActions
+}
+
+//NewAdminLogMock returns a mock for github.com/insolar/insolar/core.AdminLog
+func NewAdminLogMock(t minimock.Tester) *AdminLogMock {
+	m := &AdminLogMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.AppendAdminActionMock = mAdminLogMockAppendAdminAction{mock: m}
+	m.GetAdminActionsMock = mAdminLogMockGetAdminActions{mock: m}
+
+	return m
+}
+
+type mAdminLogMockAppendAdminAction struct {
+	mock              *AdminLogMock
+	mainExpectation   *AdminLogMockAppendAdminActionExpectation
+	expectationSeries []*AdminLogMockAppendAdminActionExpectation
+}
+
+type AdminLogMockAppendAdminActionExpectation struct {
+	input  *AdminLogMockAppendAdminActionInput
+	result *AdminLogMockAppendAdminActionResult
+}
+
+type AdminLogMockAppendAdminActionInput struct {
+	p  context.Context
+	p1 core.AdminLogEntry
+}
+
+type AdminLogMockAppendAdminActionResult struct {
+	r  *core.AdminLogEntry
+	r1 error
+}
+
+//Expect specifies that invocation of AdminLog.AppendAdminAction is expected from 1 to Infinity times
+func (m *mAdminLogMockAppendAdminAction) Expect(p context.Context, p1 core.AdminLogEntry) *mAdminLogMockAppendAdminAction {
+	m.mock.AppendAdminActionFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &AdminLogMockAppendAdminActionExpectation{}
+	}
+	m.mainExpectation.input = &AdminLogMockAppendAdminActionInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of AdminLog.AppendAdminAction
+func (m *mAdminLogMockAppendAdminAction) Return(r *core.AdminLogEntry, r1 error) *AdminLogMock {
+	m.mock.AppendAdminActionFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &AdminLogMockAppendAdminActionExpectation{}
+	}
+	m.mainExpectation.result = &AdminLogMockAppendAdminActionResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of AdminLog.AppendAdminAction is expected once
+func (m *mAdminLogMockAppendAdminAction) ExpectOnce(p context.Context, p1 core.AdminLogEntry) *AdminLogMockAppendAdminActionExpectation {
+	m.mock.AppendAdminActionFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &AdminLogMockAppendAdminActionExpectation{}
+	expectation.input = &AdminLogMockAppendAdminActionInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *AdminLogMockAppendAdminActionExpectation) Return(r *core.AdminLogEntry, r1 error) {
+	e.result = &AdminLogMockAppendAdminActionResult{r, r1}
+}
+
+//Set uses given function f as a mock of AdminLog.AppendAdminAction method
+func (m *mAdminLogMockAppendAdminAction) Set(f func(p context.Context, p1 core.AdminLogEntry) (r *core.AdminLogEntry, r1 error)) *AdminLogMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.AppendAdminActionFunc = f
+	return m.mock
+}
+
+//AppendAdminAction implements github.com/insolar/insolar/core.AdminLog interface
+func (m *AdminLogMock) AppendAdminAction(p context.Context, p1 core.AdminLogEntry) (r *core.AdminLogEntry, r1 error) {
+	counter := atomic.AddUint64(&m.AppendAdminActionPreCounter, 1)
+	defer atomic.AddUint64(&m.AppendAdminActionCounter, 1)
+
+	if len(m.AppendAdminActionMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.AppendAdminActionMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to AdminLogMock.AppendAdminAction. %v %v", p, p1)
+			return
+		}
+
+		input := m.AppendAdminActionMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, AdminLogMockAppendAdminActionInput{p, p1}, "AdminLog.AppendAdminAction got unexpected parameters")
+
+		result := m.AppendAdminActionMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the AdminLogMock.AppendAdminAction")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.AppendAdminActionMock.mainExpectation != nil {
+
+		input := m.AppendAdminActionMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, AdminLogMockAppendAdminActionInput{p, p1}, "AdminLog.AppendAdminAction got unexpected parameters")
+		}
+
+		result := m.AppendAdminActionMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the AdminLogMock.AppendAdminAction")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.AppendAdminActionFunc == nil {
+		m.t.Fatalf("Unexpected call to AdminLogMock.AppendAdminAction. %v %v", p, p1)
+		return
+	}
+
+	return m.AppendAdminActionFunc(p, p1)
+}
+
+//AppendAdminActionMinimockCounter returns a count of AdminLogMock.AppendAdminActionFunc invocations
+func (m *AdminLogMock) AppendAdminActionMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.AppendAdminActionCounter)
+}
+
+//AppendAdminActionMinimockPreCounter returns the value of AdminLogMock.AppendAdminAction invocations
+func (m *AdminLogMock) AppendAdminActionMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.AppendAdminActionPreCounter)
+}
+
+//AppendAdminActionFinished returns true if mock invocations count is ok
+func (m *AdminLogMock) AppendAdminActionFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.AppendAdminActionMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.AppendAdminActionCounter) == uint64(len(m.AppendAdminActionMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.AppendAdminActionMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.AppendAdminActionCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.AppendAdminActionFunc != nil {
+		return atomic.LoadUint64(&m.AppendAdminActionCounter) > 0
+	}
+
+	return true
+}
+
+type mAdminLogMockGetAdminActions struct {
+	mock              *AdminLogMock
+	mainExpectation   *AdminLogMockGetAdminActionsExpectation
+	expectationSeries []*AdminLogMockGetAdminActionsExpectation
+}
+
+type AdminLogMockGetAdminActionsExpectation struct {
+	input  *AdminLogMockGetAdminActionsInput
+	result *AdminLogMockGetAdminActionsResult
+}
+
+type AdminLogMockGetAdminActionsInput struct {
+	p  context.Context
+	p1 uint64
+	p2 int
+}
+
+type AdminLogMockGetAdminActionsResult struct {
+	r  []core.AdminLogEntry
+	r1 error
+}
+
+//Expect specifies that invocation of AdminLog.GetAdminActions is expected from 1 to Infinity times
+func (m *mAdminLogMockGetAdminActions) Expect(p context.Context, p1 uint64, p2 int) *mAdminLogMockGetAdminActions {
+	m.mock.GetAdminActionsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &AdminLogMockGetAdminActionsExpectation{}
+	}
+	m.mainExpectation.input = &AdminLogMockGetAdminActionsInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of AdminLog.GetAdminActions
+func (m *mAdminLogMockGetAdminActions) Return(r []core.AdminLogEntry, r1 error) *AdminLogMock {
+	m.mock.GetAdminActionsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &AdminLogMockGetAdminActionsExpectation{}
+	}
+	m.mainExpectation.result = &AdminLogMockGetAdminActionsResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of AdminLog.GetAdminActions is expected once
+func (m *mAdminLogMockGetAdminActions) ExpectOnce(p context.Context, p1 uint64, p2 int) *AdminLogMockGetAdminActionsExpectation {
+	m.mock.GetAdminActionsFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &AdminLogMockGetAdminActionsExpectation{}
+	expectation.input = &AdminLogMockGetAdminActionsInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *AdminLogMockGetAdminActionsExpectation) Return(r []core.AdminLogEntry, r1 error) {
+	e.result = &AdminLogMockGetAdminActionsResult{r, r1}
+}
+
+//Set uses given function f as a mock of AdminLog.GetAdminActions method
+func (m *mAdminLogMockGetAdminActions) Set(f func(p context.Context, p1 uint64, p2 int) (r []core.AdminLogEntry, r1 error)) *AdminLogMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetAdminActionsFunc = f
+	return m.mock
+}
+
+//GetAdminActions implements github.com/insolar/insolar/core.AdminLog interface
+func (m *AdminLogMock) GetAdminActions(p context.Context, p1 uint64, p2 int) (r []core.AdminLogEntry, r1 error) {
+	counter := atomic.AddUint64(&m.GetAdminActionsPreCounter, 1)
+	defer atomic.AddUint64(&m.GetAdminActionsCounter, 1)
+
+	if len(m.GetAdminActionsMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetAdminActionsMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to AdminLogMock.GetAdminActions. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.GetAdminActionsMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, AdminLogMockGetAdminActionsInput{p, p1, p2}, "AdminLog.GetAdminActions got unexpected parameters")
+
+		result := m.GetAdminActionsMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the AdminLogMock.GetAdminActions")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetAdminActionsMock.mainExpectation != nil {
+
+		input := m.GetAdminActionsMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, AdminLogMockGetAdminActionsInput{p, p1, p2}, "AdminLog.GetAdminActions got unexpected parameters")
+		}
+
+		result := m.GetAdminActionsMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the AdminLogMock.GetAdminActions")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetAdminActionsFunc == nil {
+		m.t.Fatalf("Unexpected call to AdminLogMock.GetAdminActions. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.GetAdminActionsFunc(p, p1, p2)
+}
+
+//GetAdminActionsMinimockCounter returns a count of AdminLogMock.GetAdminActionsFunc invocations
+func (m *AdminLogMock) GetAdminActionsMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetAdminActionsCounter)
+}
+
+//GetAdminActionsMinimockPreCounter returns the value of AdminLogMock.GetAdminActions invocations
+func (m *AdminLogMock) GetAdminActionsMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetAdminActionsPreCounter)
+}
+
+//GetAdminActionsFinished returns true if mock invocations count is ok
+func (m *AdminLogMock) GetAdminActionsFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetAdminActionsMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetAdminActionsCounter) == uint64(len(m.GetAdminActionsMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetAdminActionsMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetAdminActionsCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetAdminActionsFunc != nil {
+		return atomic.LoadUint64(&m.GetAdminActionsCounter) > 0
+	}
+
+	return true
+}
+
+//ValidateCallCounters checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *AdminLogMock) ValidateCallCounters() {
+
+	if !m.AppendAdminActionFinished() {
+		m.t.Fatal("Expected call to AdminLogMock.AppendAdminAction")
+	}
+
+	if !m.GetAdminActionsFinished() {
+		m.t.Fatal("Expected call to AdminLogMock.GetAdminActions")
+	}
+
+}
+
+//CheckMocksCalled checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *AdminLogMock) CheckMocksCalled() {
+	m.Finish()
+}
+
+//Finish checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish or use Finish method of minimock.Controller
+func (m *AdminLogMock) Finish() {
+	m.MinimockFinish()
+}
+
+//MinimockFinish checks that all mocked methods of the interface have been called at least once
+func (m *AdminLogMock) MinimockFinish() {
+
+	if !m.AppendAdminActionFinished() {
+		m.t.Fatal("Expected call to AdminLogMock.AppendAdminAction")
+	}
+
+	if !m.GetAdminActionsFinished() {
+		m.t.Fatal("Expected call to AdminLogMock.GetAdminActions")
+	}
+
+}
+
+//Wait waits for all mocked methods to be called at least once
+//Deprecated: please use MinimockWait or use Wait method of minimock.Controller
+func (m *AdminLogMock) Wait(timeout time.Duration) {
+	m.MinimockWait(timeout)
+}
+
+//MinimockWait waits for all mocked methods to be called at least once
+//this method is called by minimock.Controller
+func (m *AdminLogMock) MinimockWait(timeout time.Duration) {
+	timeoutCh := time.After(timeout)
+	for {
+		ok := true
+		ok = ok && m.AppendAdminActionFinished()
+		ok = ok && m.GetAdminActionsFinished()
+
+		if ok {
+			return
+		}
+
+		select {
+		case <-timeoutCh:
+
+			if !m.AppendAdminActionFinished() {
+				m.t.Error("Expected call to AdminLogMock.AppendAdminAction")
+			}
+
+			if !m.GetAdminActionsFinished() {
+				m.t.Error("Expected call to AdminLogMock.GetAdminActions")
+			}
+
+			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
+			return
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+//AllMocksCalled returns true if all mocked methods were called before the execution of AllMocksCalled,
+//it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
+func (m *AdminLogMock) AllMocksCalled() bool {
+
+	if !m.AppendAdminActionFinished() {
+		return false
+	}
+
+	if !m.GetAdminActionsFinished() {
+		return false
+	}
+
+	return true
+}
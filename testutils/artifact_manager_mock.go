@@ -55,6 +55,11 @@ type ArtifactManagerMock struct {
 	GetChildrenPreCounter uint64
 	GetChildrenMock       mArtifactManagerMockGetChildren
 
+	GetChildrenPageFunc       func(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) (r []core.RecordRef, r1 *core.RecordID, r2 error)
+	GetChildrenPageCounter    uint64
+	GetChildrenPagePreCounter uint64
+	GetChildrenPageMock       mArtifactManagerMockGetChildrenPage
+
 	GetCodeFunc       func(p context.Context, p1 core.RecordRef) (r core.CodeDescriptor, r1 error)
 	GetCodeCounter    uint64
 	GetCodePreCounter uint64
@@ -70,6 +75,11 @@ type ArtifactManagerMock struct {
 	GetObjectPreCounter uint64
 	GetObjectMock       mArtifactManagerMockGetObject
 
+	GetObjectsByPrototypeFunc       func(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) (r []core.RecordRef, r1 *core.RecordRef, r2 error)
+	GetObjectsByPrototypeCounter    uint64
+	GetObjectsByPrototypePreCounter uint64
+	GetObjectsByPrototypeMock       mArtifactManagerMockGetObjectsByPrototype
+
 	HasPendingRequestsFunc       func(p context.Context, p1 core.RecordRef) (r bool, r1 error)
 	HasPendingRequestsCounter    uint64
 	HasPendingRequestsPreCounter uint64
@@ -121,9 +131,11 @@ func NewArtifactManagerMock(t minimock.Tester) *ArtifactManagerMock {
 	m.DeployCodeMock = mArtifactManagerMockDeployCode{mock: m}
 	m.GenesisRefMock = mArtifactManagerMockGenesisRef{mock: m}
 	m.GetChildrenMock = mArtifactManagerMockGetChildren{mock: m}
+	m.GetChildrenPageMock = mArtifactManagerMockGetChildrenPage{mock: m}
 	m.GetCodeMock = mArtifactManagerMockGetCode{mock: m}
 	m.GetDelegateMock = mArtifactManagerMockGetDelegate{mock: m}
 	m.GetObjectMock = mArtifactManagerMockGetObject{mock: m}
+	m.GetObjectsByPrototypeMock = mArtifactManagerMockGetObjectsByPrototype{mock: m}
 	m.HasPendingRequestsMock = mArtifactManagerMockHasPendingRequests{mock: m}
 	m.RegisterRequestMock = mArtifactManagerMockRegisterRequest{mock: m}
 	m.RegisterResultMock = mArtifactManagerMockRegisterResult{mock: m}
@@ -1192,6 +1204,162 @@ func (m *ArtifactManagerMock) GetChildrenFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockGetChildrenPage struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockGetChildrenPageExpectation
+	expectationSeries []*ArtifactManagerMockGetChildrenPageExpectation
+}
+
+type ArtifactManagerMockGetChildrenPageExpectation struct {
+	input  *ArtifactManagerMockGetChildrenPageInput
+	result *ArtifactManagerMockGetChildrenPageResult
+}
+
+type ArtifactManagerMockGetChildrenPageInput struct {
+	p  context.Context
+	p1 core.RecordRef
+	p2 *core.RecordID
+	p3 int
+}
+
+type ArtifactManagerMockGetChildrenPageResult struct {
+	r  []core.RecordRef
+	r1 *core.RecordID
+	r2 error
+}
+
+//Expect specifies that invocation of ArtifactManager.GetChildrenPage is expected from 1 to Infinity times
+func (m *mArtifactManagerMockGetChildrenPage) Expect(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) *mArtifactManagerMockGetChildrenPage {
+	m.mock.GetChildrenPageFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetChildrenPageExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockGetChildrenPageInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.GetChildrenPage
+func (m *mArtifactManagerMockGetChildrenPage) Return(r []core.RecordRef, r1 *core.RecordID, r2 error) *ArtifactManagerMock {
+	m.mock.GetChildrenPageFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetChildrenPageExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockGetChildrenPageResult{r, r1, r2}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.GetChildrenPage is expected once
+func (m *mArtifactManagerMockGetChildrenPage) ExpectOnce(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) *ArtifactManagerMockGetChildrenPageExpectation {
+	m.mock.GetChildrenPageFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockGetChildrenPageExpectation{}
+	expectation.input = &ArtifactManagerMockGetChildrenPageInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockGetChildrenPageExpectation) Return(r []core.RecordRef, r1 *core.RecordID, r2 error) {
+	e.result = &ArtifactManagerMockGetChildrenPageResult{r, r1, r2}
+}
+
+//Set uses given function f as a mock of ArtifactManager.GetChildrenPage method
+func (m *mArtifactManagerMockGetChildrenPage) Set(f func(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) (r []core.RecordRef, r1 *core.RecordID, r2 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetChildrenPageFunc = f
+	return m.mock
+}
+
+//GetChildrenPage implements github.com/insolar/insolar/core.ArtifactManager interface
+func (m *ArtifactManagerMock) GetChildrenPage(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) (r []core.RecordRef, r1 *core.RecordID, r2 error) {
+	counter := atomic.AddUint64(&m.GetChildrenPagePreCounter, 1)
+	defer atomic.AddUint64(&m.GetChildrenPageCounter, 1)
+
+	if len(m.GetChildrenPageMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetChildrenPageMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetChildrenPage. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetChildrenPageMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockGetChildrenPageInput{p, p1, p2, p3}, "ArtifactManager.GetChildrenPage got unexpected parameters")
+
+		result := m.GetChildrenPageMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetChildrenPage")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+		r2 = result.r2
+
+		return
+	}
+
+	if m.GetChildrenPageMock.mainExpectation != nil {
+
+		input := m.GetChildrenPageMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockGetChildrenPageInput{p, p1, p2, p3}, "ArtifactManager.GetChildrenPage got unexpected parameters")
+		}
+
+		result := m.GetChildrenPageMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetChildrenPage")
+		}
+
+		r = result.r
+		r1 = result.r1
+		r2 = result.r2
+
+		return
+	}
+
+	if m.GetChildrenPageFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetChildrenPage. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetChildrenPageFunc(p, p1, p2, p3)
+}
+
+//GetChildrenPageMinimockCounter returns a count of ArtifactManagerMock.GetChildrenPageFunc invocations
+func (m *ArtifactManagerMock) GetChildrenPageMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetChildrenPageCounter)
+}
+
+//GetChildrenPageMinimockPreCounter returns the value of ArtifactManagerMock.GetChildrenPage invocations
+func (m *ArtifactManagerMock) GetChildrenPageMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetChildrenPagePreCounter)
+}
+
+//GetChildrenPageFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) GetChildrenPageFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetChildrenPageMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetChildrenPageCounter) == uint64(len(m.GetChildrenPageMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetChildrenPageMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetChildrenPageCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetChildrenPageFunc != nil {
+		return atomic.LoadUint64(&m.GetChildrenPageCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockGetCode struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockGetCodeExpectation
@@ -1648,6 +1816,162 @@ func (m *ArtifactManagerMock) GetObjectFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockGetObjectsByPrototype struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockGetObjectsByPrototypeExpectation
+	expectationSeries []*ArtifactManagerMockGetObjectsByPrototypeExpectation
+}
+
+type ArtifactManagerMockGetObjectsByPrototypeExpectation struct {
+	input  *ArtifactManagerMockGetObjectsByPrototypeInput
+	result *ArtifactManagerMockGetObjectsByPrototypeResult
+}
+
+type ArtifactManagerMockGetObjectsByPrototypeInput struct {
+	p  context.Context
+	p1 core.RecordRef
+	p2 *core.RecordRef
+	p3 int
+}
+
+type ArtifactManagerMockGetObjectsByPrototypeResult struct {
+	r  []core.RecordRef
+	r1 *core.RecordRef
+	r2 error
+}
+
+//Expect specifies that invocation of ArtifactManager.GetObjectsByPrototype is expected from 1 to Infinity times
+func (m *mArtifactManagerMockGetObjectsByPrototype) Expect(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) *mArtifactManagerMockGetObjectsByPrototype {
+	m.mock.GetObjectsByPrototypeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetObjectsByPrototypeExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockGetObjectsByPrototypeInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.GetObjectsByPrototype
+func (m *mArtifactManagerMockGetObjectsByPrototype) Return(r []core.RecordRef, r1 *core.RecordRef, r2 error) *ArtifactManagerMock {
+	m.mock.GetObjectsByPrototypeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetObjectsByPrototypeExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockGetObjectsByPrototypeResult{r, r1, r2}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.GetObjectsByPrototype is expected once
+func (m *mArtifactManagerMockGetObjectsByPrototype) ExpectOnce(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) *ArtifactManagerMockGetObjectsByPrototypeExpectation {
+	m.mock.GetObjectsByPrototypeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockGetObjectsByPrototypeExpectation{}
+	expectation.input = &ArtifactManagerMockGetObjectsByPrototypeInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockGetObjectsByPrototypeExpectation) Return(r []core.RecordRef, r1 *core.RecordRef, r2 error) {
+	e.result = &ArtifactManagerMockGetObjectsByPrototypeResult{r, r1, r2}
+}
+
+//Set uses given function f as a mock of ArtifactManager.GetObjectsByPrototype method
+func (m *mArtifactManagerMockGetObjectsByPrototype) Set(f func(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) (r []core.RecordRef, r1 *core.RecordRef, r2 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetObjectsByPrototypeFunc = f
+	return m.mock
+}
+
+//GetObjectsByPrototype implements github.com/insolar/insolar/core.ArtifactManager interface
+func (m *ArtifactManagerMock) GetObjectsByPrototype(p context.Context, p1 core.RecordRef, p2 *core.RecordRef, p3 int) (r []core.RecordRef, r1 *core.RecordRef, r2 error) {
+	counter := atomic.AddUint64(&m.GetObjectsByPrototypePreCounter, 1)
+	defer atomic.AddUint64(&m.GetObjectsByPrototypeCounter, 1)
+
+	if len(m.GetObjectsByPrototypeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetObjectsByPrototypeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetObjectsByPrototype. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetObjectsByPrototypeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockGetObjectsByPrototypeInput{p, p1, p2, p3}, "ArtifactManager.GetObjectsByPrototype got unexpected parameters")
+
+		result := m.GetObjectsByPrototypeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetObjectsByPrototype")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+		r2 = result.r2
+
+		return
+	}
+
+	if m.GetObjectsByPrototypeMock.mainExpectation != nil {
+
+		input := m.GetObjectsByPrototypeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockGetObjectsByPrototypeInput{p, p1, p2, p3}, "ArtifactManager.GetObjectsByPrototype got unexpected parameters")
+		}
+
+		result := m.GetObjectsByPrototypeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetObjectsByPrototype")
+		}
+
+		r = result.r
+		r1 = result.r1
+		r2 = result.r2
+
+		return
+	}
+
+	if m.GetObjectsByPrototypeFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetObjectsByPrototype. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetObjectsByPrototypeFunc(p, p1, p2, p3)
+}
+
+//GetObjectsByPrototypeMinimockCounter returns a count of ArtifactManagerMock.GetObjectsByPrototypeFunc invocations
+func (m *ArtifactManagerMock) GetObjectsByPrototypeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter)
+}
+
+//GetObjectsByPrototypeMinimockPreCounter returns the value of ArtifactManagerMock.GetObjectsByPrototype invocations
+func (m *ArtifactManagerMock) GetObjectsByPrototypeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectsByPrototypePreCounter)
+}
+
+//GetObjectsByPrototypeFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) GetObjectsByPrototypeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetObjectsByPrototypeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter) == uint64(len(m.GetObjectsByPrototypeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetObjectsByPrototypeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetObjectsByPrototypeFunc != nil {
+		return atomic.LoadUint64(&m.GetObjectsByPrototypeCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockHasPendingRequests struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockHasPendingRequestsExpectation
@@ -2733,6 +3057,10 @@ func (m *ArtifactManagerMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetChildren")
 	}
 
+	if !m.GetChildrenPageFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetChildrenPage")
+	}
+
 	if !m.GetCodeFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetCode")
 	}
@@ -2745,6 +3073,10 @@ func (m *ArtifactManagerMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetObject")
 	}
 
+	if !m.GetObjectsByPrototypeFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetObjectsByPrototype")
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.HasPendingRequests")
 	}
@@ -2818,6 +3150,10 @@ func (m *ArtifactManagerMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetChildren")
 	}
 
+	if !m.GetChildrenPageFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetChildrenPage")
+	}
+
 	if !m.GetCodeFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetCode")
 	}
@@ -2830,6 +3166,10 @@ func (m *ArtifactManagerMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetObject")
 	}
 
+	if !m.GetObjectsByPrototypeFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetObjectsByPrototype")
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.HasPendingRequests")
 	}
@@ -2879,9 +3219,11 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.DeployCodeFinished()
 		ok = ok && m.GenesisRefFinished()
 		ok = ok && m.GetChildrenFinished()
+		ok = ok && m.GetChildrenPageFinished()
 		ok = ok && m.GetCodeFinished()
 		ok = ok && m.GetDelegateFinished()
 		ok = ok && m.GetObjectFinished()
+		ok = ok && m.GetObjectsByPrototypeFinished()
 		ok = ok && m.HasPendingRequestsFinished()
 		ok = ok && m.RegisterRequestFinished()
 		ok = ok && m.RegisterResultFinished()
@@ -2925,6 +3267,10 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ArtifactManagerMock.GetChildren")
 			}
 
+			if !m.GetChildrenPageFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.GetChildrenPage")
+			}
+
 			if !m.GetCodeFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.GetCode")
 			}
@@ -2937,6 +3283,10 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ArtifactManagerMock.GetObject")
 			}
 
+			if !m.GetObjectsByPrototypeFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.GetObjectsByPrototype")
+			}
+
 			if !m.HasPendingRequestsFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.HasPendingRequests")
 			}
@@ -3005,6 +3355,10 @@ func (m *ArtifactManagerMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetChildrenPageFinished() {
+		return false
+	}
+
 	if !m.GetCodeFinished() {
 		return false
 	}
@@ -3017,6 +3371,10 @@ func (m *ArtifactManagerMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetObjectsByPrototypeFinished() {
+		return false
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		return false
 	}
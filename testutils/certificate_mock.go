@@ -33,6 +33,11 @@ type CertificateMock struct {
 	GetNodeRefPreCounter uint64
 	GetNodeRefMock       mCertificateMockGetNodeRef
 
+	GetOperatorPublicKeysFunc       func() (r []crypto.PublicKey)
+	GetOperatorPublicKeysCounter    uint64
+	GetOperatorPublicKeysPreCounter uint64
+	GetOperatorPublicKeysMock       mCertificateMockGetOperatorPublicKeys
+
 	GetPublicKeyFunc       func() (r crypto.PublicKey)
 	GetPublicKeyCounter    uint64
 	GetPublicKeyPreCounter uint64
@@ -65,6 +70,7 @@ func NewCertificateMock(t minimock.Tester) *CertificateMock {
 	m.GetDiscoveryNodesMock = mCertificateMockGetDiscoveryNodes{mock: m}
 	m.GetDiscoverySignsMock = mCertificateMockGetDiscoverySigns{mock: m}
 	m.GetNodeRefMock = mCertificateMockGetNodeRef{mock: m}
+	m.GetOperatorPublicKeysMock = mCertificateMockGetOperatorPublicKeys{mock: m}
 	m.GetPublicKeyMock = mCertificateMockGetPublicKey{mock: m}
 	m.GetRoleMock = mCertificateMockGetRole{mock: m}
 	m.GetRootDomainReferenceMock = mCertificateMockGetRootDomainReference{mock: m}
@@ -475,6 +481,140 @@ func (m *CertificateMock) GetNodeRefFinished() bool {
 	return true
 }
 
+type mCertificateMockGetOperatorPublicKeys struct {
+	mock              *CertificateMock
+	mainExpectation   *CertificateMockGetOperatorPublicKeysExpectation
+	expectationSeries []*CertificateMockGetOperatorPublicKeysExpectation
+}
+
+type CertificateMockGetOperatorPublicKeysExpectation struct {
+	result *CertificateMockGetOperatorPublicKeysResult
+}
+
+type CertificateMockGetOperatorPublicKeysResult struct {
+	r []crypto.PublicKey
+}
+
+//Expect specifies that invocation of Certificate.GetOperatorPublicKeys is expected from 1 to Infinity times
+func (m *mCertificateMockGetOperatorPublicKeys) Expect() *mCertificateMockGetOperatorPublicKeys {
+	m.mock.GetOperatorPublicKeysFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CertificateMockGetOperatorPublicKeysExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of Certificate.GetOperatorPublicKeys
+func (m *mCertificateMockGetOperatorPublicKeys) Return(r []crypto.PublicKey) *CertificateMock {
+	m.mock.GetOperatorPublicKeysFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CertificateMockGetOperatorPublicKeysExpectation{}
+	}
+	m.mainExpectation.result = &CertificateMockGetOperatorPublicKeysResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Certificate.GetOperatorPublicKeys is expected once
+func (m *mCertificateMockGetOperatorPublicKeys) ExpectOnce() *CertificateMockGetOperatorPublicKeysExpectation {
+	m.mock.GetOperatorPublicKeysFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CertificateMockGetOperatorPublicKeysExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CertificateMockGetOperatorPublicKeysExpectation) Return(r []crypto.PublicKey) {
+	e.result = &CertificateMockGetOperatorPublicKeysResult{r}
+}
+
+//Set uses given function f as a mock of Certificate.GetOperatorPublicKeys method
+func (m *mCertificateMockGetOperatorPublicKeys) Set(f func() (r []crypto.PublicKey)) *CertificateMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetOperatorPublicKeysFunc = f
+	return m.mock
+}
+
+//GetOperatorPublicKeys implements github.com/insolar/insolar/core.Certificate interface
+func (m *CertificateMock) GetOperatorPublicKeys() (r []crypto.PublicKey) {
+	counter := atomic.AddUint64(&m.GetOperatorPublicKeysPreCounter, 1)
+	defer atomic.AddUint64(&m.GetOperatorPublicKeysCounter, 1)
+
+	if len(m.GetOperatorPublicKeysMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetOperatorPublicKeysMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CertificateMock.GetOperatorPublicKeys.")
+			return
+		}
+
+		result := m.GetOperatorPublicKeysMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CertificateMock.GetOperatorPublicKeys")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetOperatorPublicKeysMock.mainExpectation != nil {
+
+		result := m.GetOperatorPublicKeysMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CertificateMock.GetOperatorPublicKeys")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetOperatorPublicKeysFunc == nil {
+		m.t.Fatalf("Unexpected call to CertificateMock.GetOperatorPublicKeys.")
+		return
+	}
+
+	return m.GetOperatorPublicKeysFunc()
+}
+
+//GetOperatorPublicKeysMinimockCounter returns a count of CertificateMock.GetOperatorPublicKeysFunc invocations
+func (m *CertificateMock) GetOperatorPublicKeysMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetOperatorPublicKeysCounter)
+}
+
+//GetOperatorPublicKeysMinimockPreCounter returns the value of CertificateMock.GetOperatorPublicKeys invocations
+func (m *CertificateMock) GetOperatorPublicKeysMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetOperatorPublicKeysPreCounter)
+}
+
+//GetOperatorPublicKeysFinished returns true if mock invocations count is ok
+func (m *CertificateMock) GetOperatorPublicKeysFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetOperatorPublicKeysMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetOperatorPublicKeysCounter) == uint64(len(m.GetOperatorPublicKeysMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetOperatorPublicKeysMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetOperatorPublicKeysCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetOperatorPublicKeysFunc != nil {
+		return atomic.LoadUint64(&m.GetOperatorPublicKeysCounter) > 0
+	}
+
+	return true
+}
+
 type mCertificateMockGetPublicKey struct {
 	mock              *CertificateMock
 	mainExpectation   *CertificateMockGetPublicKeyExpectation
@@ -1027,6 +1167,10 @@ func (m *CertificateMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to CertificateMock.GetNodeRef")
 	}
 
+	if !m.GetOperatorPublicKeysFinished() {
+		m.t.Fatal("Expected call to CertificateMock.GetOperatorPublicKeys")
+	}
+
 	if !m.GetPublicKeyFinished() {
 		m.t.Fatal("Expected call to CertificateMock.GetPublicKey")
 	}
@@ -1072,6 +1216,10 @@ func (m *CertificateMock) MinimockFinish() {
 		m.t.Fatal("Expected call to CertificateMock.GetNodeRef")
 	}
 
+	if !m.GetOperatorPublicKeysFinished() {
+		m.t.Fatal("Expected call to CertificateMock.GetOperatorPublicKeys")
+	}
+
 	if !m.GetPublicKeyFinished() {
 		m.t.Fatal("Expected call to CertificateMock.GetPublicKey")
 	}
@@ -1105,6 +1253,7 @@ func (m *CertificateMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetDiscoveryNodesFinished()
 		ok = ok && m.GetDiscoverySignsFinished()
 		ok = ok && m.GetNodeRefFinished()
+		ok = ok && m.GetOperatorPublicKeysFinished()
 		ok = ok && m.GetPublicKeyFinished()
 		ok = ok && m.GetRoleFinished()
 		ok = ok && m.GetRootDomainReferenceFinished()
@@ -1129,6 +1278,10 @@ func (m *CertificateMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to CertificateMock.GetNodeRef")
 			}
 
+			if !m.GetOperatorPublicKeysFinished() {
+				m.t.Error("Expected call to CertificateMock.GetOperatorPublicKeys")
+			}
+
 			if !m.GetPublicKeyFinished() {
 				m.t.Error("Expected call to CertificateMock.GetPublicKey")
 			}
@@ -1169,6 +1322,10 @@ func (m *CertificateMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetOperatorPublicKeysFinished() {
+		return false
+	}
+
 	if !m.GetPublicKeyFinished() {
 		return false
 	}
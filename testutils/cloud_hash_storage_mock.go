@@ -0,0 +1,613 @@
+package testutils
+
+/*
+DO NOT EDIT!
+This code was generated automatically using github.com/gojuno/minimock v1.9
+The original interface "CloudHashStorage" can be found in github.com/insolar/insolar/core
+*/
+import (
+	context "context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gojuno/minimock"
+	core "github.com/insolar/insolar/core"
+
+	testify_assert "github.com/stretchr/testify/assert"
+)
+
+//CloudHashStorageMock implements github.com/insolar/insolar/core.CloudHashStorage
+type CloudHashStorageMock struct {
+	t minimock.Tester
+
+	GetCloudHashFunc       func(p context.Context, p1 core.PulseNumber) (r *core.CloudHashRecord, r1 error)
+	GetCloudHashCounter    uint64
+	GetCloudHashPreCounter uint64
+	GetCloudHashMock       mCloudHashStorageMockGetCloudHash
+
+	GetCloudHashesFunc       func(p context.Context, p1 core.PulseNumber, p2 core.PulseNumber, p3 int) (r []core.CloudHashRecord, r1 error)
+	GetCloudHashesCounter    uint64
+	GetCloudHashesPreCounter uint64
+	GetCloudHashesMock       mCloudHashStorageMockGetCloudHashes
+
+	SaveCloudHashFunc       func(p context.Context, p1 core.CloudHashRecord) (r error)
+	SaveCloudHashCounter    uint64
+	SaveCloudHashPreCounter uint64
+	SaveCloudHashMock       mCloudHashStorageMockSaveCloudHash
+}
+
+//NewCloudHashStorageMock returns a mock for github.com/insolar/insolar/core.CloudHashStorage
+func NewCloudHashStorageMock(t minimock.Tester) *CloudHashStorageMock {
+	m := &CloudHashStorageMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.GetCloudHashMock = mCloudHashStorageMockGetCloudHash{mock: m}
+	m.GetCloudHashesMock = mCloudHashStorageMockGetCloudHashes{mock: m}
+	m.SaveCloudHashMock = mCloudHashStorageMockSaveCloudHash{mock: m}
+
+	return m
+}
+
+type mCloudHashStorageMockGetCloudHash struct {
+	mock              *CloudHashStorageMock
+	mainExpectation   *CloudHashStorageMockGetCloudHashExpectation
+	expectationSeries []*CloudHashStorageMockGetCloudHashExpectation
+}
+
+type CloudHashStorageMockGetCloudHashExpectation struct {
+	input  *CloudHashStorageMockGetCloudHashInput
+	result *CloudHashStorageMockGetCloudHashResult
+}
+
+type CloudHashStorageMockGetCloudHashInput struct {
+	p  context.Context
+	p1 core.PulseNumber
+}
+
+type CloudHashStorageMockGetCloudHashResult struct {
+	r  *core.CloudHashRecord
+	r1 error
+}
+
+//Expect specifies that invocation of CloudHashStorage.GetCloudHash is expected from 1 to Infinity times
+func (m *mCloudHashStorageMockGetCloudHash) Expect(p context.Context, p1 core.PulseNumber) *mCloudHashStorageMockGetCloudHash {
+	m.mock.GetCloudHashFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CloudHashStorageMockGetCloudHashExpectation{}
+	}
+	m.mainExpectation.input = &CloudHashStorageMockGetCloudHashInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of CloudHashStorage.GetCloudHash
+func (m *mCloudHashStorageMockGetCloudHash) Return(r *core.CloudHashRecord, r1 error) *CloudHashStorageMock {
+	m.mock.GetCloudHashFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CloudHashStorageMockGetCloudHashExpectation{}
+	}
+	m.mainExpectation.result = &CloudHashStorageMockGetCloudHashResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of CloudHashStorage.GetCloudHash is expected once
+func (m *mCloudHashStorageMockGetCloudHash) ExpectOnce(p context.Context, p1 core.PulseNumber) *CloudHashStorageMockGetCloudHashExpectation {
+	m.mock.GetCloudHashFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CloudHashStorageMockGetCloudHashExpectation{}
+	expectation.input = &CloudHashStorageMockGetCloudHashInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CloudHashStorageMockGetCloudHashExpectation) Return(r *core.CloudHashRecord, r1 error) {
+	e.result = &CloudHashStorageMockGetCloudHashResult{r, r1}
+}
+
+//Set uses given function f as a mock of CloudHashStorage.GetCloudHash method
+func (m *mCloudHashStorageMockGetCloudHash) Set(f func(p context.Context, p1 core.PulseNumber) (r *core.CloudHashRecord, r1 error)) *CloudHashStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetCloudHashFunc = f
+	return m.mock
+}
+
+//GetCloudHash implements github.com/insolar/insolar/core.CloudHashStorage interface
+func (m *CloudHashStorageMock) GetCloudHash(p context.Context, p1 core.PulseNumber) (r *core.CloudHashRecord, r1 error) {
+	counter := atomic.AddUint64(&m.GetCloudHashPreCounter, 1)
+	defer atomic.AddUint64(&m.GetCloudHashCounter, 1)
+
+	if len(m.GetCloudHashMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetCloudHashMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CloudHashStorageMock.GetCloudHash. %v %v", p, p1)
+			return
+		}
+
+		input := m.GetCloudHashMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, CloudHashStorageMockGetCloudHashInput{p, p1}, "CloudHashStorage.GetCloudHash got unexpected parameters")
+
+		result := m.GetCloudHashMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CloudHashStorageMock.GetCloudHash")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetCloudHashMock.mainExpectation != nil {
+
+		input := m.GetCloudHashMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, CloudHashStorageMockGetCloudHashInput{p, p1}, "CloudHashStorage.GetCloudHash got unexpected parameters")
+		}
+
+		result := m.GetCloudHashMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CloudHashStorageMock.GetCloudHash")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetCloudHashFunc == nil {
+		m.t.Fatalf("Unexpected call to CloudHashStorageMock.GetCloudHash. %v %v", p, p1)
+		return
+	}
+
+	return m.GetCloudHashFunc(p, p1)
+}
+
+//GetCloudHashMinimockCounter returns a count of CloudHashStorageMock.GetCloudHashFunc invocations
+func (m *CloudHashStorageMock) GetCloudHashMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetCloudHashCounter)
+}
+
+//GetCloudHashMinimockPreCounter returns the value of CloudHashStorageMock.GetCloudHash invocations
+func (m *CloudHashStorageMock) GetCloudHashMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetCloudHashPreCounter)
+}
+
+//GetCloudHashFinished returns true if mock invocations count is ok
+func (m *CloudHashStorageMock) GetCloudHashFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetCloudHashMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetCloudHashCounter) == uint64(len(m.GetCloudHashMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetCloudHashMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetCloudHashCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetCloudHashFunc != nil {
+		return atomic.LoadUint64(&m.GetCloudHashCounter) > 0
+	}
+
+	return true
+}
+
+type mCloudHashStorageMockGetCloudHashes struct {
+	mock              *CloudHashStorageMock
+	mainExpectation   *CloudHashStorageMockGetCloudHashesExpectation
+	expectationSeries []*CloudHashStorageMockGetCloudHashesExpectation
+}
+
+type CloudHashStorageMockGetCloudHashesExpectation struct {
+	input  *CloudHashStorageMockGetCloudHashesInput
+	result *CloudHashStorageMockGetCloudHashesResult
+}
+
+type CloudHashStorageMockGetCloudHashesInput struct {
+	p  context.Context
+	p1 core.PulseNumber
+	p2 core.PulseNumber
+	p3 int
+}
+
+type CloudHashStorageMockGetCloudHashesResult struct {
+	r  []core.CloudHashRecord
+	r1 error
+}
+
+//Expect specifies that invocation of CloudHashStorage.GetCloudHashes is expected from 1 to Infinity times
+func (m *mCloudHashStorageMockGetCloudHashes) Expect(p context.Context, p1 core.PulseNumber, p2 core.PulseNumber, p3 int) *mCloudHashStorageMockGetCloudHashes {
+	m.mock.GetCloudHashesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CloudHashStorageMockGetCloudHashesExpectation{}
+	}
+	m.mainExpectation.input = &CloudHashStorageMockGetCloudHashesInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of CloudHashStorage.GetCloudHashes
+func (m *mCloudHashStorageMockGetCloudHashes) Return(r []core.CloudHashRecord, r1 error) *CloudHashStorageMock {
+	m.mock.GetCloudHashesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CloudHashStorageMockGetCloudHashesExpectation{}
+	}
+	m.mainExpectation.result = &CloudHashStorageMockGetCloudHashesResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of CloudHashStorage.GetCloudHashes is expected once
+func (m *mCloudHashStorageMockGetCloudHashes) ExpectOnce(p context.Context, p1 core.PulseNumber, p2 core.PulseNumber, p3 int) *CloudHashStorageMockGetCloudHashesExpectation {
+	m.mock.GetCloudHashesFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CloudHashStorageMockGetCloudHashesExpectation{}
+	expectation.input = &CloudHashStorageMockGetCloudHashesInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CloudHashStorageMockGetCloudHashesExpectation) Return(r []core.CloudHashRecord, r1 error) {
+	e.result = &CloudHashStorageMockGetCloudHashesResult{r, r1}
+}
+
+//Set uses given function f as a mock of CloudHashStorage.GetCloudHashes method
+func (m *mCloudHashStorageMockGetCloudHashes) Set(f func(p context.Context, p1 core.PulseNumber, p2 core.PulseNumber, p3 int) (r []core.CloudHashRecord, r1 error)) *CloudHashStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetCloudHashesFunc = f
+	return m.mock
+}
+
+//GetCloudHashes implements github.com/insolar/insolar/core.CloudHashStorage interface
+func (m *CloudHashStorageMock) GetCloudHashes(p context.Context, p1 core.PulseNumber, p2 core.PulseNumber, p3 int) (r []core.CloudHashRecord, r1 error) {
+	counter := atomic.AddUint64(&m.GetCloudHashesPreCounter, 1)
+	defer atomic.AddUint64(&m.GetCloudHashesCounter, 1)
+
+	if len(m.GetCloudHashesMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetCloudHashesMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CloudHashStorageMock.GetCloudHashes. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetCloudHashesMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, CloudHashStorageMockGetCloudHashesInput{p, p1, p2, p3}, "CloudHashStorage.GetCloudHashes got unexpected parameters")
+
+		result := m.GetCloudHashesMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CloudHashStorageMock.GetCloudHashes")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetCloudHashesMock.mainExpectation != nil {
+
+		input := m.GetCloudHashesMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, CloudHashStorageMockGetCloudHashesInput{p, p1, p2, p3}, "CloudHashStorage.GetCloudHashes got unexpected parameters")
+		}
+
+		result := m.GetCloudHashesMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CloudHashStorageMock.GetCloudHashes")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetCloudHashesFunc == nil {
+		m.t.Fatalf("Unexpected call to CloudHashStorageMock.GetCloudHashes. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetCloudHashesFunc(p, p1, p2, p3)
+}
+
+//GetCloudHashesMinimockCounter returns a count of CloudHashStorageMock.GetCloudHashesFunc invocations
+func (m *CloudHashStorageMock) GetCloudHashesMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetCloudHashesCounter)
+}
+
+//GetCloudHashesMinimockPreCounter returns the value of CloudHashStorageMock.GetCloudHashes invocations
+func (m *CloudHashStorageMock) GetCloudHashesMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetCloudHashesPreCounter)
+}
+
+//GetCloudHashesFinished returns true if mock invocations count is ok
+func (m *CloudHashStorageMock) GetCloudHashesFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetCloudHashesMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetCloudHashesCounter) == uint64(len(m.GetCloudHashesMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetCloudHashesMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetCloudHashesCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetCloudHashesFunc != nil {
+		return atomic.LoadUint64(&m.GetCloudHashesCounter) > 0
+	}
+
+	return true
+}
+
+type mCloudHashStorageMockSaveCloudHash struct {
+	mock              *CloudHashStorageMock
+	mainExpectation   *CloudHashStorageMockSaveCloudHashExpectation
+	expectationSeries []*CloudHashStorageMockSaveCloudHashExpectation
+}
+
+type CloudHashStorageMockSaveCloudHashExpectation struct {
+	input  *CloudHashStorageMockSaveCloudHashInput
+	result *CloudHashStorageMockSaveCloudHashResult
+}
+
+type CloudHashStorageMockSaveCloudHashInput struct {
+	p  context.Context
+	p1 core.CloudHashRecord
+}
+
+type CloudHashStorageMockSaveCloudHashResult struct {
+	r error
+}
+
+//Expect specifies that invocation of CloudHashStorage.SaveCloudHash is expected from 1 to Infinity times
+func (m *mCloudHashStorageMockSaveCloudHash) Expect(p context.Context, p1 core.CloudHashRecord) *mCloudHashStorageMockSaveCloudHash {
+	m.mock.SaveCloudHashFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CloudHashStorageMockSaveCloudHashExpectation{}
+	}
+	m.mainExpectation.input = &CloudHashStorageMockSaveCloudHashInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of CloudHashStorage.SaveCloudHash
+func (m *mCloudHashStorageMockSaveCloudHash) Return(r error) *CloudHashStorageMock {
+	m.mock.SaveCloudHashFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CloudHashStorageMockSaveCloudHashExpectation{}
+	}
+	m.mainExpectation.result = &CloudHashStorageMockSaveCloudHashResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of CloudHashStorage.SaveCloudHash is expected once
+func (m *mCloudHashStorageMockSaveCloudHash) ExpectOnce(p context.Context, p1 core.CloudHashRecord) *CloudHashStorageMockSaveCloudHashExpectation {
+	m.mock.SaveCloudHashFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CloudHashStorageMockSaveCloudHashExpectation{}
+	expectation.input = &CloudHashStorageMockSaveCloudHashInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CloudHashStorageMockSaveCloudHashExpectation) Return(r error) {
+	e.result = &CloudHashStorageMockSaveCloudHashResult{r}
+}
+
+//Set uses given function f as a mock of CloudHashStorage.SaveCloudHash method
+func (m *mCloudHashStorageMockSaveCloudHash) Set(f func(p context.Context, p1 core.CloudHashRecord) (r error)) *CloudHashStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SaveCloudHashFunc = f
+	return m.mock
+}
+
+//SaveCloudHash implements github.com/insolar/insolar/core.CloudHashStorage interface
+func (m *CloudHashStorageMock) SaveCloudHash(p context.Context, p1 core.CloudHashRecord) (r error) {
+	counter := atomic.AddUint64(&m.SaveCloudHashPreCounter, 1)
+	defer atomic.AddUint64(&m.SaveCloudHashCounter, 1)
+
+	if len(m.SaveCloudHashMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SaveCloudHashMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CloudHashStorageMock.SaveCloudHash. %v %v", p, p1)
+			return
+		}
+
+		input := m.SaveCloudHashMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, CloudHashStorageMockSaveCloudHashInput{p, p1}, "CloudHashStorage.SaveCloudHash got unexpected parameters")
+
+		result := m.SaveCloudHashMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CloudHashStorageMock.SaveCloudHash")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SaveCloudHashMock.mainExpectation != nil {
+
+		input := m.SaveCloudHashMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, CloudHashStorageMockSaveCloudHashInput{p, p1}, "CloudHashStorage.SaveCloudHash got unexpected parameters")
+		}
+
+		result := m.SaveCloudHashMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CloudHashStorageMock.SaveCloudHash")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SaveCloudHashFunc == nil {
+		m.t.Fatalf("Unexpected call to CloudHashStorageMock.SaveCloudHash. %v %v", p, p1)
+		return
+	}
+
+	return m.SaveCloudHashFunc(p, p1)
+}
+
+//SaveCloudHashMinimockCounter returns a count of CloudHashStorageMock.SaveCloudHashFunc invocations
+func (m *CloudHashStorageMock) SaveCloudHashMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SaveCloudHashCounter)
+}
+
+//SaveCloudHashMinimockPreCounter returns the value of CloudHashStorageMock.SaveCloudHash invocations
+func (m *CloudHashStorageMock) SaveCloudHashMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SaveCloudHashPreCounter)
+}
+
+//SaveCloudHashFinished returns true if mock invocations count is ok
+func (m *CloudHashStorageMock) SaveCloudHashFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SaveCloudHashMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SaveCloudHashCounter) == uint64(len(m.SaveCloudHashMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SaveCloudHashMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SaveCloudHashCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SaveCloudHashFunc != nil {
+		return atomic.LoadUint64(&m.SaveCloudHashCounter) > 0
+	}
+
+	return true
+}
+
+//ValidateCallCounters checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *CloudHashStorageMock) ValidateCallCounters() {
+
+	if !m.GetCloudHashFinished() {
+		m.t.Fatal("Expected call to CloudHashStorageMock.GetCloudHash")
+	}
+
+	if !m.GetCloudHashesFinished() {
+		m.t.Fatal("Expected call to CloudHashStorageMock.GetCloudHashes")
+	}
+
+	if !m.SaveCloudHashFinished() {
+		m.t.Fatal("Expected call to CloudHashStorageMock.SaveCloudHash")
+	}
+
+}
+
+//CheckMocksCalled checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *CloudHashStorageMock) CheckMocksCalled() {
+	m.Finish()
+}
+
+//Finish checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish or use Finish method of minimock.Controller
+func (m *CloudHashStorageMock) Finish() {
+	m.MinimockFinish()
+}
+
+//MinimockFinish checks that all mocked methods of the interface have been called at least once
+func (m *CloudHashStorageMock) MinimockFinish() {
+
+	if !m.GetCloudHashFinished() {
+		m.t.Fatal("Expected call to CloudHashStorageMock.GetCloudHash")
+	}
+
+	if !m.GetCloudHashesFinished() {
+		m.t.Fatal("Expected call to CloudHashStorageMock.GetCloudHashes")
+	}
+
+	if !m.SaveCloudHashFinished() {
+		m.t.Fatal("Expected call to CloudHashStorageMock.SaveCloudHash")
+	}
+
+}
+
+//Wait waits for all mocked methods to be called at least once
+//Deprecated: please use MinimockWait or use Wait method of minimock.Controller
+func (m *CloudHashStorageMock) Wait(timeout time.Duration) {
+	m.MinimockWait(timeout)
+}
+
+//MinimockWait waits for all mocked methods to be called at least once
+//this method is called by minimock.Controller
+func (m *CloudHashStorageMock) MinimockWait(timeout time.Duration) {
+	timeoutCh := time.After(timeout)
+	for {
+		ok := true
+		ok = ok && m.GetCloudHashFinished()
+		ok = ok && m.GetCloudHashesFinished()
+		ok = ok && m.SaveCloudHashFinished()
+
+		if ok {
+			return
+		}
+
+		select {
+		case <-timeoutCh:
+
+			if !m.GetCloudHashFinished() {
+				m.t.Error("Expected call to CloudHashStorageMock.GetCloudHash")
+			}
+
+			if !m.GetCloudHashesFinished() {
+				m.t.Error("Expected call to CloudHashStorageMock.GetCloudHashes")
+			}
+
+			if !m.SaveCloudHashFinished() {
+				m.t.Error("Expected call to CloudHashStorageMock.SaveCloudHash")
+			}
+
+			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
+			return
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+//AllMocksCalled returns true if all mocked methods were called before the execution of AllMocksCalled,
+//it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
+func (m *CloudHashStorageMock) AllMocksCalled() bool {
+
+	if !m.GetCloudHashFinished() {
+		return false
+	}
+
+	if !m.GetCloudHashesFinished() {
+		return false
+	}
+
+	if !m.SaveCloudHashFinished() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,437 @@
+package testutils
+
+/*
+DO NOT EDIT!
+This code was generated automatically using github.com/gojuno/minimock v1.9
+The original interface "FaultEvidenceStorage" can be found in github.com/insolar/insolar/core
+*/
+import (
+	context "context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gojuno/minimock"
+	core "github.com/insolar/insolar/core"
+
+	testify_assert "github.com/stretchr/testify/assert"
+)
+
+//FaultEvidenceStorageMock implements github.com/insolar/insolar/core.FaultEvidenceStorage
+type FaultEvidenceStorageMock struct {
+	t minimock.Tester
+
+	AddFaultEvidenceFunc       func(p context.Context, p1 core.FaultEvidence) (r error)
+	AddFaultEvidenceCounter    uint64
+	AddFaultEvidencePreCounter uint64
+	AddFaultEvidenceMock       mFaultEvidenceStorageMockAddFaultEvidence
+
+	GetFaultEvidenceFunc       func(p context.Context, p1 core.RecordRef) (r []core.FaultEvidence, r1 error)
+	GetFaultEvidenceCounter    uint64
+	GetFaultEvidencePreCounter uint64
+	GetFaultEvidenceMock       mFaultEvidenceStorageMockGetFaultEvidence
+}
+
+//NewFaultEvidenceStorageMock returns a mock for github.com/insolar/insolar/core.FaultEvidenceStorage
+func NewFaultEvidenceStorageMock(t minimock.Tester) *FaultEvidenceStorageMock {
+	m := &FaultEvidenceStorageMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.AddFaultEvidenceMock = mFaultEvidenceStorageMockAddFaultEvidence{mock: m}
+	m.GetFaultEvidenceMock = mFaultEvidenceStorageMockGetFaultEvidence{mock: m}
+
+	return m
+}
+
+type mFaultEvidenceStorageMockAddFaultEvidence struct {
+	mock              *FaultEvidenceStorageMock
+	mainExpectation   *FaultEvidenceStorageMockAddFaultEvidenceExpectation
+	expectationSeries []*FaultEvidenceStorageMockAddFaultEvidenceExpectation
+}
+
+type FaultEvidenceStorageMockAddFaultEvidenceExpectation struct {
+	input  *FaultEvidenceStorageMockAddFaultEvidenceInput
+	result *FaultEvidenceStorageMockAddFaultEvidenceResult
+}
+
+type FaultEvidenceStorageMockAddFaultEvidenceInput struct {
+	p  context.Context
+	p1 core.FaultEvidence
+}
+
+type FaultEvidenceStorageMockAddFaultEvidenceResult struct {
+	r error
+}
+
+//Expect specifies that invocation of FaultEvidenceStorage.AddFaultEvidence is expected from 1 to Infinity times
+func (m *mFaultEvidenceStorageMockAddFaultEvidence) Expect(p context.Context, p1 core.FaultEvidence) *mFaultEvidenceStorageMockAddFaultEvidence {
+	m.mock.AddFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &FaultEvidenceStorageMockAddFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.input = &FaultEvidenceStorageMockAddFaultEvidenceInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of FaultEvidenceStorage.AddFaultEvidence
+func (m *mFaultEvidenceStorageMockAddFaultEvidence) Return(r error) *FaultEvidenceStorageMock {
+	m.mock.AddFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &FaultEvidenceStorageMockAddFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.result = &FaultEvidenceStorageMockAddFaultEvidenceResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of FaultEvidenceStorage.AddFaultEvidence is expected once
+func (m *mFaultEvidenceStorageMockAddFaultEvidence) ExpectOnce(p context.Context, p1 core.FaultEvidence) *FaultEvidenceStorageMockAddFaultEvidenceExpectation {
+	m.mock.AddFaultEvidenceFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &FaultEvidenceStorageMockAddFaultEvidenceExpectation{}
+	expectation.input = &FaultEvidenceStorageMockAddFaultEvidenceInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *FaultEvidenceStorageMockAddFaultEvidenceExpectation) Return(r error) {
+	e.result = &FaultEvidenceStorageMockAddFaultEvidenceResult{r}
+}
+
+//Set uses given function f as a mock of FaultEvidenceStorage.AddFaultEvidence method
+func (m *mFaultEvidenceStorageMockAddFaultEvidence) Set(f func(p context.Context, p1 core.FaultEvidence) (r error)) *FaultEvidenceStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.AddFaultEvidenceFunc = f
+	return m.mock
+}
+
+//AddFaultEvidence implements github.com/insolar/insolar/core.FaultEvidenceStorage interface
+func (m *FaultEvidenceStorageMock) AddFaultEvidence(p context.Context, p1 core.FaultEvidence) (r error) {
+	counter := atomic.AddUint64(&m.AddFaultEvidencePreCounter, 1)
+	defer atomic.AddUint64(&m.AddFaultEvidenceCounter, 1)
+
+	if len(m.AddFaultEvidenceMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.AddFaultEvidenceMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to FaultEvidenceStorageMock.AddFaultEvidence. %v %v", p, p1)
+			return
+		}
+
+		input := m.AddFaultEvidenceMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, FaultEvidenceStorageMockAddFaultEvidenceInput{p, p1}, "FaultEvidenceStorage.AddFaultEvidence got unexpected parameters")
+
+		result := m.AddFaultEvidenceMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the FaultEvidenceStorageMock.AddFaultEvidence")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.AddFaultEvidenceMock.mainExpectation != nil {
+
+		input := m.AddFaultEvidenceMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, FaultEvidenceStorageMockAddFaultEvidenceInput{p, p1}, "FaultEvidenceStorage.AddFaultEvidence got unexpected parameters")
+		}
+
+		result := m.AddFaultEvidenceMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the FaultEvidenceStorageMock.AddFaultEvidence")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.AddFaultEvidenceFunc == nil {
+		m.t.Fatalf("Unexpected call to FaultEvidenceStorageMock.AddFaultEvidence. %v %v", p, p1)
+		return
+	}
+
+	return m.AddFaultEvidenceFunc(p, p1)
+}
+
+//AddFaultEvidenceMinimockCounter returns a count of FaultEvidenceStorageMock.AddFaultEvidenceFunc invocations
+func (m *FaultEvidenceStorageMock) AddFaultEvidenceMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.AddFaultEvidenceCounter)
+}
+
+//AddFaultEvidenceMinimockPreCounter returns the value of FaultEvidenceStorageMock.AddFaultEvidence invocations
+func (m *FaultEvidenceStorageMock) AddFaultEvidenceMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.AddFaultEvidencePreCounter)
+}
+
+//AddFaultEvidenceFinished returns true if mock invocations count is ok
+func (m *FaultEvidenceStorageMock) AddFaultEvidenceFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.AddFaultEvidenceMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.AddFaultEvidenceCounter) == uint64(len(m.AddFaultEvidenceMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.AddFaultEvidenceMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.AddFaultEvidenceCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.AddFaultEvidenceFunc != nil {
+		return atomic.LoadUint64(&m.AddFaultEvidenceCounter) > 0
+	}
+
+	return true
+}
+
+type mFaultEvidenceStorageMockGetFaultEvidence struct {
+	mock              *FaultEvidenceStorageMock
+	mainExpectation   *FaultEvidenceStorageMockGetFaultEvidenceExpectation
+	expectationSeries []*FaultEvidenceStorageMockGetFaultEvidenceExpectation
+}
+
+type FaultEvidenceStorageMockGetFaultEvidenceExpectation struct {
+	input  *FaultEvidenceStorageMockGetFaultEvidenceInput
+	result *FaultEvidenceStorageMockGetFaultEvidenceResult
+}
+
+type FaultEvidenceStorageMockGetFaultEvidenceInput struct {
+	p  context.Context
+	p1 core.RecordRef
+}
+
+type FaultEvidenceStorageMockGetFaultEvidenceResult struct {
+	r  []core.FaultEvidence
+	r1 error
+}
+
+//Expect specifies that invocation of FaultEvidenceStorage.GetFaultEvidence is expected from 1 to Infinity times
+func (m *mFaultEvidenceStorageMockGetFaultEvidence) Expect(p context.Context, p1 core.RecordRef) *mFaultEvidenceStorageMockGetFaultEvidence {
+	m.mock.GetFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &FaultEvidenceStorageMockGetFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.input = &FaultEvidenceStorageMockGetFaultEvidenceInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of FaultEvidenceStorage.GetFaultEvidence
+func (m *mFaultEvidenceStorageMockGetFaultEvidence) Return(r []core.FaultEvidence, r1 error) *FaultEvidenceStorageMock {
+	m.mock.GetFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &FaultEvidenceStorageMockGetFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.result = &FaultEvidenceStorageMockGetFaultEvidenceResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of FaultEvidenceStorage.GetFaultEvidence is expected once
+func (m *mFaultEvidenceStorageMockGetFaultEvidence) ExpectOnce(p context.Context, p1 core.RecordRef) *FaultEvidenceStorageMockGetFaultEvidenceExpectation {
+	m.mock.GetFaultEvidenceFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &FaultEvidenceStorageMockGetFaultEvidenceExpectation{}
+	expectation.input = &FaultEvidenceStorageMockGetFaultEvidenceInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *FaultEvidenceStorageMockGetFaultEvidenceExpectation) Return(r []core.FaultEvidence, r1 error) {
+	e.result = &FaultEvidenceStorageMockGetFaultEvidenceResult{r, r1}
+}
+
+//Set uses given function f as a mock of FaultEvidenceStorage.GetFaultEvidence method
+func (m *mFaultEvidenceStorageMockGetFaultEvidence) Set(f func(p context.Context, p1 core.RecordRef) (r []core.FaultEvidence, r1 error)) *FaultEvidenceStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetFaultEvidenceFunc = f
+	return m.mock
+}
+
+//GetFaultEvidence implements github.com/insolar/insolar/core.FaultEvidenceStorage interface
+func (m *FaultEvidenceStorageMock) GetFaultEvidence(p context.Context, p1 core.RecordRef) (r []core.FaultEvidence, r1 error) {
+	counter := atomic.AddUint64(&m.GetFaultEvidencePreCounter, 1)
+	defer atomic.AddUint64(&m.GetFaultEvidenceCounter, 1)
+
+	if len(m.GetFaultEvidenceMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetFaultEvidenceMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to FaultEvidenceStorageMock.GetFaultEvidence. %v %v", p, p1)
+			return
+		}
+
+		input := m.GetFaultEvidenceMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, FaultEvidenceStorageMockGetFaultEvidenceInput{p, p1}, "FaultEvidenceStorage.GetFaultEvidence got unexpected parameters")
+
+		result := m.GetFaultEvidenceMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the FaultEvidenceStorageMock.GetFaultEvidence")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetFaultEvidenceMock.mainExpectation != nil {
+
+		input := m.GetFaultEvidenceMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, FaultEvidenceStorageMockGetFaultEvidenceInput{p, p1}, "FaultEvidenceStorage.GetFaultEvidence got unexpected parameters")
+		}
+
+		result := m.GetFaultEvidenceMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the FaultEvidenceStorageMock.GetFaultEvidence")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetFaultEvidenceFunc == nil {
+		m.t.Fatalf("Unexpected call to FaultEvidenceStorageMock.GetFaultEvidence. %v %v", p, p1)
+		return
+	}
+
+	return m.GetFaultEvidenceFunc(p, p1)
+}
+
+//GetFaultEvidenceMinimockCounter returns a count of FaultEvidenceStorageMock.GetFaultEvidenceFunc invocations
+func (m *FaultEvidenceStorageMock) GetFaultEvidenceMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetFaultEvidenceCounter)
+}
+
+//GetFaultEvidenceMinimockPreCounter returns the value of FaultEvidenceStorageMock.GetFaultEvidence invocations
+func (m *FaultEvidenceStorageMock) GetFaultEvidenceMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetFaultEvidencePreCounter)
+}
+
+//GetFaultEvidenceFinished returns true if mock invocations count is ok
+func (m *FaultEvidenceStorageMock) GetFaultEvidenceFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetFaultEvidenceMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetFaultEvidenceCounter) == uint64(len(m.GetFaultEvidenceMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetFaultEvidenceMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetFaultEvidenceCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetFaultEvidenceFunc != nil {
+		return atomic.LoadUint64(&m.GetFaultEvidenceCounter) > 0
+	}
+
+	return true
+}
+
+//ValidateCallCounters checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *FaultEvidenceStorageMock) ValidateCallCounters() {
+
+	if !m.AddFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to FaultEvidenceStorageMock.AddFaultEvidence")
+	}
+
+	if !m.GetFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to FaultEvidenceStorageMock.GetFaultEvidence")
+	}
+
+}
+
+//CheckMocksCalled checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
+func (m *FaultEvidenceStorageMock) CheckMocksCalled() {
+	m.Finish()
+}
+
+//Finish checks that all mocked methods of the interface have been called at least once
+//Deprecated: please use MinimockFinish or use Finish method of minimock.Controller
+func (m *FaultEvidenceStorageMock) Finish() {
+	m.MinimockFinish()
+}
+
+//MinimockFinish checks that all mocked methods of the interface have been called at least once
+func (m *FaultEvidenceStorageMock) MinimockFinish() {
+
+	if !m.AddFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to FaultEvidenceStorageMock.AddFaultEvidence")
+	}
+
+	if !m.GetFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to FaultEvidenceStorageMock.GetFaultEvidence")
+	}
+
+}
+
+//Wait waits for all mocked methods to be called at least once
+//Deprecated: please use MinimockWait or use Wait method of minimock.Controller
+func (m *FaultEvidenceStorageMock) Wait(timeout time.Duration) {
+	m.MinimockWait(timeout)
+}
+
+//MinimockWait waits for all mocked methods to be called at least once
+//this method is called by minimock.Controller
+func (m *FaultEvidenceStorageMock) MinimockWait(timeout time.Duration) {
+	timeoutCh := time.After(timeout)
+	for {
+		ok := true
+		ok = ok && m.AddFaultEvidenceFinished()
+		ok = ok && m.GetFaultEvidenceFinished()
+
+		if ok {
+			return
+		}
+
+		select {
+		case <-timeoutCh:
+
+			if !m.AddFaultEvidenceFinished() {
+				m.t.Error("Expected call to FaultEvidenceStorageMock.AddFaultEvidence")
+			}
+
+			if !m.GetFaultEvidenceFinished() {
+				m.t.Error("Expected call to FaultEvidenceStorageMock.GetFaultEvidence")
+			}
+
+			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
+			return
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+//AllMocksCalled returns true if all mocked methods were called before the execution of AllMocksCalled,
+//it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
+func (m *FaultEvidenceStorageMock) AllMocksCalled() bool {
+
+	if !m.AddFaultEvidenceFinished() {
+		return false
+	}
+
+	if !m.GetFaultEvidenceFinished() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,178 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package fixtures generates ledger test data: pulses, jet trees, object lifelines and parcels. Data is generated
+// from seed, so scenarios are reproducible and failed property-based tests can be replayed by logging the seed.
+package fixtures
+
+import (
+	"encoding/hex"
+	"math/rand"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/testutils"
+)
+
+// maxPulseDelta is max difference of numbers of sequential generated pulses.
+const maxPulseDelta = 10
+
+// Generator generates test data. Generator is not safe for concurrent use.
+type Generator struct {
+	seed   int64
+	rand   *rand.Rand
+	scheme core.PlatformCryptographyScheme
+
+	pulse core.Pulse
+}
+
+// New creates generator of data from seed. The first pulse is core.FirstPulseNumber.
+func New(seed int64) *Generator {
+	g := &Generator{
+		seed:   seed,
+		rand:   rand.New(rand.NewSource(seed)),
+		scheme: testutils.NewPlatformCryptographyScheme(),
+	}
+	g.pulse = core.Pulse{
+		PulseNumber:      core.FirstPulseNumber,
+		PrevPulseNumber:  core.FirstPulseNumber,
+		NextPulseNumber:  core.FirstPulseNumber + core.PulseNumber(g.pulseDelta()),
+		PulseTimestamp:   core.GenesisPulse.PulseTimestamp,
+		EpochPulseNumber: core.GenesisPulse.EpochPulseNumber,
+		Entropy:          g.entropy(),
+	}
+	return g
+}
+
+// Seed returns seed generator was created with.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// Rand returns source of random numbers of generator.
+func (g *Generator) Rand() *rand.Rand {
+	return g.rand
+}
+
+// Scheme returns cryptography scheme record ids are calculated with.
+func (g *Generator) Scheme() core.PlatformCryptographyScheme {
+	return g.scheme
+}
+
+// Bytes returns n random bytes.
+func (g *Generator) Bytes(n int) []byte {
+	buf := make([]byte, n)
+	g.rand.Read(buf) // nolint
+	return buf
+}
+
+// ID returns random record id in current pulse.
+func (g *Generator) ID() core.RecordID {
+	return *core.NewRecordID(g.pulse.PulseNumber, g.Bytes(core.RecordHashSize))
+}
+
+// Ref returns random reference to record in current pulse.
+func (g *Generator) Ref() core.RecordRef {
+	return *core.NewRecordRef(g.ID(), g.ID())
+}
+
+// Pulse returns current pulse.
+func (g *Generator) Pulse() core.Pulse {
+	return g.pulse
+}
+
+// NextPulse makes next pulse current and returns it.
+func (g *Generator) NextPulse() core.Pulse {
+	prev := g.pulse
+	g.pulse = core.Pulse{
+		PulseNumber:      prev.NextPulseNumber,
+		PrevPulseNumber:  prev.PulseNumber,
+		NextPulseNumber:  prev.NextPulseNumber + core.PulseNumber(g.pulseDelta()),
+		PulseTimestamp:   prev.PulseTimestamp + int64(prev.NextPulseNumber-prev.PulseNumber),
+		EpochPulseNumber: prev.EpochPulseNumber,
+		Entropy:          g.entropy(),
+	}
+	return g.pulse
+}
+
+// Pulses makes n next pulses current one by one and returns them.
+func (g *Generator) Pulses(n int) []core.Pulse {
+	pulses := make([]core.Pulse, n)
+	for i := range pulses {
+		pulses[i] = g.NextPulse()
+	}
+	return pulses
+}
+
+// JetTree returns actual jet tree with given count of leaves that are split from random jets, no deeper than
+// maxDepth. Returns tree and its leaf jets.
+func (g *Generator) JetTree(leaves int, maxDepth uint8) (*jet.Tree, []core.RecordID) {
+	tree := jet.NewTree(true)
+	ids := []core.RecordID{jet.ZeroJetID}
+	for len(ids) < leaves {
+		var splittable []int
+		for i, id := range ids {
+			if depth, _ := jet.Jet(id); depth < maxDepth {
+				splittable = append(splittable, i)
+			}
+		}
+		if len(splittable) == 0 {
+			break
+		}
+		i := splittable[g.rand.Intn(len(splittable))]
+		left, right, err := tree.Split(ids[i])
+		if err != nil {
+			panic(err)
+		}
+		ids[i] = *left
+		ids = append(ids, *right)
+	}
+	for _, id := range ids {
+		tree.Update(id, true)
+	}
+	return tree, tree.LeafIDs()
+}
+
+// Parcel returns parcel of message sent by random node in current pulse.
+func (g *Generator) Parcel(msg core.Message) *message.Parcel {
+	return &message.Parcel{
+		Sender:      g.Ref(),
+		Msg:         msg,
+		Signature:   g.Bytes(64),
+		LogTraceID:  hex.EncodeToString(g.Bytes(16)),
+		PulseNumber: g.pulse.PulseNumber,
+	}
+}
+
+// Parcels returns parcels of n random ledger messages about objects of lifelines.
+func (g *Generator) Parcels(n int, lifelines []*Lifeline) []*message.Parcel {
+	parcels := make([]*message.Parcel, n)
+	for i := range parcels {
+		parcels[i] = g.Parcel(g.LedgerMessage(lifelines[g.rand.Intn(len(lifelines))]))
+	}
+	return parcels
+}
+
+func (g *Generator) pulseDelta() int {
+	return 1 + g.rand.Intn(maxPulseDelta)
+}
+
+func (g *Generator) entropy() core.Entropy {
+	var entropy core.Entropy
+	g.rand.Read(entropy[:]) // nolint
+	return entropy
+}
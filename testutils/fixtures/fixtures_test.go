@@ -0,0 +1,101 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/record"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Deterministic(t *testing.T) {
+	a, b := New(42), New(42)
+	require.Equal(t, a.Pulses(3), b.Pulses(3))
+	require.Equal(t, a.Lifelines(3, 5), b.Lifelines(3, 5))
+	treeA, leavesA := a.JetTree(8, 5)
+	treeB, leavesB := b.JetTree(8, 5)
+	require.Equal(t, treeA.String(), treeB.String())
+	require.Equal(t, leavesA, leavesB)
+
+	require.NotEqual(t, New(1).Ref(), New(2).Ref())
+}
+
+func TestGenerator_Pulses(t *testing.T) {
+	g := New(1)
+	prev := g.Pulse()
+	for _, pulse := range g.Pulses(10) {
+		assert.Equal(t, prev.NextPulseNumber, pulse.PulseNumber)
+		assert.Equal(t, prev.PulseNumber, pulse.PrevPulseNumber)
+		assert.True(t, pulse.NextPulseNumber > pulse.PulseNumber)
+		prev = pulse
+	}
+}
+
+func TestGenerator_JetTree(t *testing.T) {
+	g := New(2)
+	tree, leaves := g.JetTree(10, 4)
+	require.Len(t, leaves, 10)
+	for _, leaf := range leaves {
+		depth, _ := jet.Jet(leaf)
+		assert.True(t, depth <= 4)
+		found, actual := tree.Find(*core.NewRecordID(core.FirstPulseNumber, leaf[core.PulseNumberSize+1:]))
+		assert.Equal(t, leaf, *found)
+		assert.True(t, actual)
+	}
+
+	_, leaves = g.JetTree(100, 2)
+	require.Len(t, leaves, 4)
+}
+
+func TestGenerator_Lifeline(t *testing.T) {
+	g := New(3)
+	l := g.Lifeline(3, true)
+	require.Len(t, l.States, 5)
+	assert.Equal(t, l.Request.ID, *l.Head.Record())
+	assert.Equal(t, record.StateDeactivation, l.Index.State)
+	assert.Equal(t, l.LatestState().ID, *l.Index.LatestState)
+	assert.Len(t, l.Memory, 4)
+
+	for i, state := range l.States {
+		objectState := state.Record.(record.ObjectState)
+		if i == 0 {
+			assert.Nil(t, objectState.PrevStateID())
+		} else {
+			assert.Equal(t, l.States[i-1].ID, *objectState.PrevStateID())
+			assert.True(t, state.ID.Pulse() > l.States[i-1].ID.Pulse())
+		}
+		if memory := objectState.GetMemory(); memory != nil {
+			assert.Contains(t, l.Memory, *memory)
+		}
+		assert.Equal(t, state.ID, *record.NewRecordIDFromRecord(
+			g.Scheme(), state.ID.Pulse(), record.DeserializeRecord(record.SerializeRecord(state.Record)),
+		))
+	}
+}
+
+func TestGenerator_Parcels(t *testing.T) {
+	g := New(4)
+	lifelines := g.Lifelines(5, 2)
+	for _, parcel := range g.Parcels(20, lifelines) {
+		assert.Equal(t, g.Pulse().PulseNumber, parcel.Pulse())
+		assert.NotNil(t, parcel.DefaultTarget())
+	}
+}
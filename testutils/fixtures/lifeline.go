@@ -0,0 +1,144 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package fixtures
+
+import (
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/ledger/storage/index"
+	"github.com/insolar/insolar/ledger/storage/record"
+)
+
+// maxMemorySize is max size of generated object memory.
+const maxMemorySize = 512
+
+// Record is generated record with its id.
+type Record struct {
+	ID     core.RecordID
+	Record record.Record
+}
+
+// Lifeline is generated object: request it is created by, state records and index. Every record is written in
+// its own pulse, so generator pulse is moved forward.
+type Lifeline struct {
+	Head    core.RecordRef
+	Request Record
+	// States are activation, amends and deactivation records in order they are written
+	States []Record
+	// Memory holds blobs of object states by their ids
+	Memory map[core.RecordID][]byte
+	Index  *index.ObjectLifeline
+}
+
+// LatestState returns the latest state record of object.
+func (l *Lifeline) LatestState() Record {
+	return l.States[len(l.States)-1]
+}
+
+// Lifeline returns object activated in current pulse and amended given count of times. If deactivated is true,
+// object is deactivated after amends.
+func (g *Generator) Lifeline(amends int, deactivated bool) *Lifeline {
+	domain := g.Ref()
+	parent := g.Ref()
+	request := g.record(&record.RequestRecord{Payload: g.Bytes(1 + g.rand.Intn(maxMemorySize)), Object: g.ID()})
+	l := &Lifeline{
+		Head:    *core.NewRecordRef(*domain.Record(), request.ID),
+		Request: request,
+		Memory:  make(map[core.RecordID][]byte),
+	}
+	sideEffect := record.SideEffectRecord{Domain: domain, Request: l.Head}
+	image := g.Ref()
+
+	state := g.record(&record.ObjectActivateRecord{
+		SideEffectRecord:  sideEffect,
+		ObjectStateRecord: record.ObjectStateRecord{Memory: g.memory(l), Image: image},
+		Parent:            parent,
+	})
+	l.States = append(l.States, state)
+	for i := 0; i < amends; i++ {
+		g.NextPulse()
+		state = g.record(&record.ObjectAmendRecord{
+			SideEffectRecord:  sideEffect,
+			ObjectStateRecord: record.ObjectStateRecord{Memory: g.memory(l), Image: image},
+			PrevState:         state.ID,
+		})
+		l.States = append(l.States, state)
+	}
+	if deactivated {
+		g.NextPulse()
+		state = g.record(&record.DeactivationRecord{SideEffectRecord: sideEffect, PrevState: state.ID})
+		l.States = append(l.States, state)
+	}
+
+	latest := state.ID
+	l.Index = &index.ObjectLifeline{
+		LatestState:         &latest,
+		LatestStateApproved: &latest,
+		Parent:              parent,
+		Delegates:           map[core.RecordRef]core.RecordRef{},
+		State:               state.Record.(record.ObjectState).State(),
+		LatestUpdate:        g.pulse.PulseNumber,
+	}
+	return l
+}
+
+// Lifelines returns n objects with random count of amends up to maxAmends, some of them are deactivated.
+func (g *Generator) Lifelines(n, maxAmends int) []*Lifeline {
+	lifelines := make([]*Lifeline, n)
+	for i := range lifelines {
+		lifelines[i] = g.Lifeline(g.rand.Intn(maxAmends+1), g.rand.Intn(4) == 0)
+	}
+	return lifelines
+}
+
+// LedgerMessage returns random ledger message about object of lifeline.
+func (g *Generator) LedgerMessage(l *Lifeline) core.Message {
+	latest := l.LatestState()
+	switch g.rand.Intn(5) {
+	case 0:
+		return &message.GetObject{Head: l.Head, State: &latest.ID, Approved: g.rand.Intn(2) == 0}
+	case 1:
+		return &message.GetObjectIndex{Object: l.Head}
+	case 2:
+		return &message.GetChildren{Parent: l.Head, Amount: 1 + g.rand.Intn(10)}
+	case 3:
+		amend := &record.ObjectAmendRecord{
+			SideEffectRecord: record.SideEffectRecord{Domain: g.Ref(), Request: l.Head},
+			PrevState:        latest.ID,
+		}
+		return &message.UpdateObject{
+			Record: record.SerializeRecord(amend),
+			Object: l.Head,
+			Memory: g.Bytes(1 + g.rand.Intn(maxMemorySize)),
+		}
+	default:
+		return &message.SetBlob{TargetRef: l.Head, Memory: g.Bytes(1 + g.rand.Intn(maxMemorySize))}
+	}
+}
+
+// record returns record with its id in current pulse.
+func (g *Generator) record(rec record.Record) Record {
+	return Record{ID: *record.NewRecordIDFromRecord(g.scheme, g.pulse.PulseNumber, rec), Record: rec}
+}
+
+// memory adds random blob to lifeline and returns its id.
+func (g *Generator) memory(l *Lifeline) *core.RecordID {
+	blob := g.Bytes(1 + g.rand.Intn(maxMemorySize))
+	id := record.CalculateIDForBlob(g.scheme, g.pulse.PulseNumber, blob)
+	l.Memory[*id] = blob
+	return id
+}
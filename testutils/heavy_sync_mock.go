@@ -20,11 +20,21 @@ import (
 type HeavySyncMock struct {
 	t minimock.Tester
 
+	CheckpointFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 []byte) (r error)
+	CheckpointCounter    uint64
+	CheckpointPreCounter uint64
+	CheckpointMock       mHeavySyncMockCheckpoint
+
 	ResetFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)
 	ResetCounter    uint64
 	ResetPreCounter uint64
 	ResetMock       mHeavySyncMockReset
 
+	ResumeFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r []byte, r1 error)
+	ResumeCounter    uint64
+	ResumePreCounter uint64
+	ResumeMock       mHeavySyncMockResume
+
 	StartFunc       func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r error)
 	StartCounter    uint64
 	StartPreCounter uint64
@@ -49,7 +59,9 @@ func NewHeavySyncMock(t minimock.Tester) *HeavySyncMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.CheckpointMock = mHeavySyncMockCheckpoint{mock: m}
 	m.ResetMock = mHeavySyncMockReset{mock: m}
+	m.ResumeMock = mHeavySyncMockResume{mock: m}
 	m.StartMock = mHeavySyncMockStart{mock: m}
 	m.StopMock = mHeavySyncMockStop{mock: m}
 	m.StoreMock = mHeavySyncMockStore{mock: m}
@@ -57,6 +69,156 @@ func NewHeavySyncMock(t minimock.Tester) *HeavySyncMock {
 	return m
 }
 
+type mHeavySyncMockCheckpoint struct {
+	mock              *HeavySyncMock
+	mainExpectation   *HeavySyncMockCheckpointExpectation
+	expectationSeries []*HeavySyncMockCheckpointExpectation
+}
+
+type HeavySyncMockCheckpointExpectation struct {
+	input  *HeavySyncMockCheckpointInput
+	result *HeavySyncMockCheckpointResult
+}
+
+type HeavySyncMockCheckpointInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.PulseNumber
+	p3 []byte
+}
+
+type HeavySyncMockCheckpointResult struct {
+	r error
+}
+
+//Expect specifies that invocation of HeavySync.Checkpoint is expected from 1 to Infinity times
+func (m *mHeavySyncMockCheckpoint) Expect(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 []byte) *mHeavySyncMockCheckpoint {
+	m.mock.CheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockCheckpointExpectation{}
+	}
+	m.mainExpectation.input = &HeavySyncMockCheckpointInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of HeavySync.Checkpoint
+func (m *mHeavySyncMockCheckpoint) Return(r error) *HeavySyncMock {
+	m.mock.CheckpointFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockCheckpointExpectation{}
+	}
+	m.mainExpectation.result = &HeavySyncMockCheckpointResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of HeavySync.Checkpoint is expected once
+func (m *mHeavySyncMockCheckpoint) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 []byte) *HeavySyncMockCheckpointExpectation {
+	m.mock.CheckpointFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &HeavySyncMockCheckpointExpectation{}
+	expectation.input = &HeavySyncMockCheckpointInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *HeavySyncMockCheckpointExpectation) Return(r error) {
+	e.result = &HeavySyncMockCheckpointResult{r}
+}
+
+//Set uses given function f as a mock of HeavySync.Checkpoint method
+func (m *mHeavySyncMockCheckpoint) Set(f func(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 []byte) (r error)) *HeavySyncMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.CheckpointFunc = f
+	return m.mock
+}
+
+//Checkpoint implements github.com/insolar/insolar/core.HeavySync interface
+func (m *HeavySyncMock) Checkpoint(p context.Context, p1 core.RecordID, p2 core.PulseNumber, p3 []byte) (r error) {
+	counter := atomic.AddUint64(&m.CheckpointPreCounter, 1)
+	defer atomic.AddUint64(&m.CheckpointCounter, 1)
+
+	if len(m.CheckpointMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.CheckpointMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to HeavySyncMock.Checkpoint. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.CheckpointMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, HeavySyncMockCheckpointInput{p, p1, p2, p3}, "HeavySync.Checkpoint got unexpected parameters")
+
+		result := m.CheckpointMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Checkpoint")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CheckpointMock.mainExpectation != nil {
+
+		input := m.CheckpointMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, HeavySyncMockCheckpointInput{p, p1, p2, p3}, "HeavySync.Checkpoint got unexpected parameters")
+		}
+
+		result := m.CheckpointMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Checkpoint")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CheckpointFunc == nil {
+		m.t.Fatalf("Unexpected call to HeavySyncMock.Checkpoint. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.CheckpointFunc(p, p1, p2, p3)
+}
+
+//CheckpointMinimockCounter returns a count of HeavySyncMock.CheckpointFunc invocations
+func (m *HeavySyncMock) CheckpointMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.CheckpointCounter)
+}
+
+//CheckpointMinimockPreCounter returns the value of HeavySyncMock.Checkpoint invocations
+func (m *HeavySyncMock) CheckpointMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.CheckpointPreCounter)
+}
+
+//CheckpointFinished returns true if mock invocations count is ok
+func (m *HeavySyncMock) CheckpointFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.CheckpointMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.CheckpointCounter) == uint64(len(m.CheckpointMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.CheckpointMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.CheckpointCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.CheckpointFunc != nil {
+		return atomic.LoadUint64(&m.CheckpointCounter) > 0
+	}
+
+	return true
+}
+
 type mHeavySyncMockReset struct {
 	mock              *HeavySyncMock
 	mainExpectation   *HeavySyncMockResetExpectation
@@ -206,6 +368,158 @@ func (m *HeavySyncMock) ResetFinished() bool {
 	return true
 }
 
+type mHeavySyncMockResume struct {
+	mock              *HeavySyncMock
+	mainExpectation   *HeavySyncMockResumeExpectation
+	expectationSeries []*HeavySyncMockResumeExpectation
+}
+
+type HeavySyncMockResumeExpectation struct {
+	input  *HeavySyncMockResumeInput
+	result *HeavySyncMockResumeResult
+}
+
+type HeavySyncMockResumeInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.PulseNumber
+}
+
+type HeavySyncMockResumeResult struct {
+	r  []byte
+	r1 error
+}
+
+//Expect specifies that invocation of HeavySync.Resume is expected from 1 to Infinity times
+func (m *mHeavySyncMockResume) Expect(p context.Context, p1 core.RecordID, p2 core.PulseNumber) *mHeavySyncMockResume {
+	m.mock.ResumeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockResumeExpectation{}
+	}
+	m.mainExpectation.input = &HeavySyncMockResumeInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of HeavySync.Resume
+func (m *mHeavySyncMockResume) Return(r []byte, r1 error) *HeavySyncMock {
+	m.mock.ResumeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &HeavySyncMockResumeExpectation{}
+	}
+	m.mainExpectation.result = &HeavySyncMockResumeResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of HeavySync.Resume is expected once
+func (m *mHeavySyncMockResume) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.PulseNumber) *HeavySyncMockResumeExpectation {
+	m.mock.ResumeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &HeavySyncMockResumeExpectation{}
+	expectation.input = &HeavySyncMockResumeInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *HeavySyncMockResumeExpectation) Return(r []byte, r1 error) {
+	e.result = &HeavySyncMockResumeResult{r, r1}
+}
+
+//Set uses given function f as a mock of HeavySync.Resume method
+func (m *mHeavySyncMockResume) Set(f func(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r []byte, r1 error)) *HeavySyncMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.ResumeFunc = f
+	return m.mock
+}
+
+//Resume implements github.com/insolar/insolar/core.HeavySync interface
+func (m *HeavySyncMock) Resume(p context.Context, p1 core.RecordID, p2 core.PulseNumber) (r []byte, r1 error) {
+	counter := atomic.AddUint64(&m.ResumePreCounter, 1)
+	defer atomic.AddUint64(&m.ResumeCounter, 1)
+
+	if len(m.ResumeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.ResumeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to HeavySyncMock.Resume. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.ResumeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, HeavySyncMockResumeInput{p, p1, p2}, "HeavySync.Resume got unexpected parameters")
+
+		result := m.ResumeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Resume")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.ResumeMock.mainExpectation != nil {
+
+		input := m.ResumeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, HeavySyncMockResumeInput{p, p1, p2}, "HeavySync.Resume got unexpected parameters")
+		}
+
+		result := m.ResumeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the HeavySyncMock.Resume")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.ResumeFunc == nil {
+		m.t.Fatalf("Unexpected call to HeavySyncMock.Resume. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.ResumeFunc(p, p1, p2)
+}
+
+//ResumeMinimockCounter returns a count of HeavySyncMock.ResumeFunc invocations
+func (m *HeavySyncMock) ResumeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.ResumeCounter)
+}
+
+//ResumeMinimockPreCounter returns the value of HeavySyncMock.Resume invocations
+func (m *HeavySyncMock) ResumeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.ResumePreCounter)
+}
+
+//ResumeFinished returns true if mock invocations count is ok
+func (m *HeavySyncMock) ResumeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.ResumeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.ResumeCounter) == uint64(len(m.ResumeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.ResumeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.ResumeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.ResumeFunc != nil {
+		return atomic.LoadUint64(&m.ResumeCounter) > 0
+	}
+
+	return true
+}
+
 type mHeavySyncMockStart struct {
 	mock              *HeavySyncMock
 	mainExpectation   *HeavySyncMockStartExpectation
@@ -658,10 +972,18 @@ func (m *HeavySyncMock) StoreFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *HeavySyncMock) ValidateCallCounters() {
 
+	if !m.CheckpointFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Checkpoint")
+	}
+
 	if !m.ResetFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Reset")
 	}
 
+	if !m.ResumeFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Resume")
+	}
+
 	if !m.StartFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Start")
 	}
@@ -691,10 +1013,18 @@ func (m *HeavySyncMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *HeavySyncMock) MinimockFinish() {
 
+	if !m.CheckpointFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Checkpoint")
+	}
+
 	if !m.ResetFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Reset")
 	}
 
+	if !m.ResumeFinished() {
+		m.t.Fatal("Expected call to HeavySyncMock.Resume")
+	}
+
 	if !m.StartFinished() {
 		m.t.Fatal("Expected call to HeavySyncMock.Start")
 	}
@@ -721,7 +1051,9 @@ func (m *HeavySyncMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.CheckpointFinished()
 		ok = ok && m.ResetFinished()
+		ok = ok && m.ResumeFinished()
 		ok = ok && m.StartFinished()
 		ok = ok && m.StopFinished()
 		ok = ok && m.StoreFinished()
@@ -733,10 +1065,18 @@ func (m *HeavySyncMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.CheckpointFinished() {
+				m.t.Error("Expected call to HeavySyncMock.Checkpoint")
+			}
+
 			if !m.ResetFinished() {
 				m.t.Error("Expected call to HeavySyncMock.Reset")
 			}
 
+			if !m.ResumeFinished() {
+				m.t.Error("Expected call to HeavySyncMock.Resume")
+			}
+
 			if !m.StartFinished() {
 				m.t.Error("Expected call to HeavySyncMock.Start")
 			}
@@ -761,10 +1101,18 @@ func (m *HeavySyncMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *HeavySyncMock) AllMocksCalled() bool {
 
+	if !m.CheckpointFinished() {
+		return false
+	}
+
 	if !m.ResetFinished() {
 		return false
 	}
 
+	if !m.ResumeFinished() {
+		return false
+	}
+
 	if !m.StartFinished() {
 		return false
 	}
@@ -31,6 +31,11 @@ type ClaimQueueMock struct {
 	PopCounter    uint64
 	PopPreCounter uint64
 	PopMock       mClaimQueueMockPop
+
+	SnapshotFunc       func() (r []packets.ReferendumClaim)
+	SnapshotCounter    uint64
+	SnapshotPreCounter uint64
+	SnapshotMock       mClaimQueueMockSnapshot
 }
 
 //NewClaimQueueMock returns a mock for github.com/insolar/insolar/network.ClaimQueue
@@ -44,6 +49,7 @@ func NewClaimQueueMock(t minimock.Tester) *ClaimQueueMock {
 	m.FrontMock = mClaimQueueMockFront{mock: m}
 	m.LengthMock = mClaimQueueMockLength{mock: m}
 	m.PopMock = mClaimQueueMockPop{mock: m}
+	m.SnapshotMock = mClaimQueueMockSnapshot{mock: m}
 
 	return m
 }
@@ -450,6 +456,140 @@ func (m *ClaimQueueMock) PopFinished() bool {
 	return true
 }
 
+type mClaimQueueMockSnapshot struct {
+	mock              *ClaimQueueMock
+	mainExpectation   *ClaimQueueMockSnapshotExpectation
+	expectationSeries []*ClaimQueueMockSnapshotExpectation
+}
+
+type ClaimQueueMockSnapshotExpectation struct {
+	result *ClaimQueueMockSnapshotResult
+}
+
+type ClaimQueueMockSnapshotResult struct {
+	r []packets.ReferendumClaim
+}
+
+//Expect specifies that invocation of ClaimQueue.Snapshot is expected from 1 to Infinity times
+func (m *mClaimQueueMockSnapshot) Expect() *mClaimQueueMockSnapshot {
+	m.mock.SnapshotFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ClaimQueueMockSnapshotExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of ClaimQueue.Snapshot
+func (m *mClaimQueueMockSnapshot) Return(r []packets.ReferendumClaim) *ClaimQueueMock {
+	m.mock.SnapshotFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ClaimQueueMockSnapshotExpectation{}
+	}
+	m.mainExpectation.result = &ClaimQueueMockSnapshotResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ClaimQueue.Snapshot is expected once
+func (m *mClaimQueueMockSnapshot) ExpectOnce() *ClaimQueueMockSnapshotExpectation {
+	m.mock.SnapshotFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ClaimQueueMockSnapshotExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ClaimQueueMockSnapshotExpectation) Return(r []packets.ReferendumClaim) {
+	e.result = &ClaimQueueMockSnapshotResult{r}
+}
+
+//Set uses given function f as a mock of ClaimQueue.Snapshot method
+func (m *mClaimQueueMockSnapshot) Set(f func() (r []packets.ReferendumClaim)) *ClaimQueueMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SnapshotFunc = f
+	return m.mock
+}
+
+//Snapshot implements github.com/insolar/insolar/network.ClaimQueue interface
+func (m *ClaimQueueMock) Snapshot() (r []packets.ReferendumClaim) {
+	counter := atomic.AddUint64(&m.SnapshotPreCounter, 1)
+	defer atomic.AddUint64(&m.SnapshotCounter, 1)
+
+	if len(m.SnapshotMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SnapshotMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ClaimQueueMock.Snapshot.")
+			return
+		}
+
+		result := m.SnapshotMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ClaimQueueMock.Snapshot")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SnapshotMock.mainExpectation != nil {
+
+		result := m.SnapshotMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ClaimQueueMock.Snapshot")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SnapshotFunc == nil {
+		m.t.Fatalf("Unexpected call to ClaimQueueMock.Snapshot.")
+		return
+	}
+
+	return m.SnapshotFunc()
+}
+
+//SnapshotMinimockCounter returns a count of ClaimQueueMock.SnapshotFunc invocations
+func (m *ClaimQueueMock) SnapshotMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SnapshotCounter)
+}
+
+//SnapshotMinimockPreCounter returns the value of ClaimQueueMock.Snapshot invocations
+func (m *ClaimQueueMock) SnapshotMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SnapshotPreCounter)
+}
+
+//SnapshotFinished returns true if mock invocations count is ok
+func (m *ClaimQueueMock) SnapshotFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SnapshotMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SnapshotCounter) == uint64(len(m.SnapshotMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SnapshotMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SnapshotCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SnapshotFunc != nil {
+		return atomic.LoadUint64(&m.SnapshotCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *ClaimQueueMock) ValidateCallCounters() {
@@ -466,6 +606,10 @@ func (m *ClaimQueueMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ClaimQueueMock.Pop")
 	}
 
+	if !m.SnapshotFinished() {
+		m.t.Fatal("Expected call to ClaimQueueMock.Snapshot")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -495,6 +639,10 @@ func (m *ClaimQueueMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ClaimQueueMock.Pop")
 	}
 
+	if !m.SnapshotFinished() {
+		m.t.Fatal("Expected call to ClaimQueueMock.Snapshot")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -512,6 +660,7 @@ func (m *ClaimQueueMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.FrontFinished()
 		ok = ok && m.LengthFinished()
 		ok = ok && m.PopFinished()
+		ok = ok && m.SnapshotFinished()
 
 		if ok {
 			return
@@ -532,6 +681,10 @@ func (m *ClaimQueueMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ClaimQueueMock.Pop")
 			}
 
+			if !m.SnapshotFinished() {
+				m.t.Error("Expected call to ClaimQueueMock.Snapshot")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -556,5 +709,9 @@ func (m *ClaimQueueMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.SnapshotFinished() {
+		return false
+	}
+
 	return true
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/gojuno/minimock"
 	core "github.com/insolar/insolar/core"
 	network "github.com/insolar/insolar/network"
+	host "github.com/insolar/insolar/network/transport/host"
 	types "github.com/insolar/insolar/network/transport/packet/types"
 
 	testify_assert "github.com/stretchr/testify/assert"
@@ -22,6 +23,21 @@ import (
 type ConsensusNetworkMock struct {
 	t minimock.Tester
 
+	AccessListFunc       func() (r *host.AccessList)
+	AccessListCounter    uint64
+	AccessListPreCounter uint64
+	AccessListMock       mConsensusNetworkMockAccessList
+
+	CaptureFunc       func() (r *host.Capture)
+	CaptureCounter    uint64
+	CapturePreCounter uint64
+	CaptureMock       mConsensusNetworkMockCapture
+
+	FaultsFunc       func() (r *host.Faults)
+	FaultsCounter    uint64
+	FaultsPreCounter uint64
+	FaultsMock       mConsensusNetworkMockFaults
+
 	GetNodeIDFunc       func() (r core.RecordRef)
 	GetNodeIDCounter    uint64
 	GetNodeIDPreCounter uint64
@@ -47,6 +63,11 @@ type ConsensusNetworkMock struct {
 	SendRequestPreCounter uint64
 	SendRequestMock       mConsensusNetworkMockSendRequest
 
+	SetSendQueueFunc       func(p *host.SendQueue)
+	SetSendQueueCounter    uint64
+	SetSendQueuePreCounter uint64
+	SetSendQueueMock       mConsensusNetworkMockSetSendQueue
+
 	StartFunc       func(p context.Context)
 	StartCounter    uint64
 	StartPreCounter uint64
@@ -66,17 +87,423 @@ func NewConsensusNetworkMock(t minimock.Tester) *ConsensusNetworkMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.AccessListMock = mConsensusNetworkMockAccessList{mock: m}
+	m.CaptureMock = mConsensusNetworkMockCapture{mock: m}
+	m.FaultsMock = mConsensusNetworkMockFaults{mock: m}
 	m.GetNodeIDMock = mConsensusNetworkMockGetNodeID{mock: m}
 	m.NewRequestBuilderMock = mConsensusNetworkMockNewRequestBuilder{mock: m}
 	m.PublicAddressMock = mConsensusNetworkMockPublicAddress{mock: m}
 	m.RegisterRequestHandlerMock = mConsensusNetworkMockRegisterRequestHandler{mock: m}
 	m.SendRequestMock = mConsensusNetworkMockSendRequest{mock: m}
+	m.SetSendQueueMock = mConsensusNetworkMockSetSendQueue{mock: m}
 	m.StartMock = mConsensusNetworkMockStart{mock: m}
 	m.StopMock = mConsensusNetworkMockStop{mock: m}
 
 	return m
 }
 
+type mConsensusNetworkMockAccessList struct {
+	mock              *ConsensusNetworkMock
+	mainExpectation   *ConsensusNetworkMockAccessListExpectation
+	expectationSeries []*ConsensusNetworkMockAccessListExpectation
+}
+
+type ConsensusNetworkMockAccessListExpectation struct {
+	result *ConsensusNetworkMockAccessListResult
+}
+
+type ConsensusNetworkMockAccessListResult struct {
+	r *host.AccessList
+}
+
+//Expect specifies that invocation of ConsensusNetwork.AccessList is expected from 1 to Infinity times
+func (m *mConsensusNetworkMockAccessList) Expect() *mConsensusNetworkMockAccessList {
+	m.mock.AccessListFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockAccessListExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of ConsensusNetwork.AccessList
+func (m *mConsensusNetworkMockAccessList) Return(r *host.AccessList) *ConsensusNetworkMock {
+	m.mock.AccessListFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockAccessListExpectation{}
+	}
+	m.mainExpectation.result = &ConsensusNetworkMockAccessListResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ConsensusNetwork.AccessList is expected once
+func (m *mConsensusNetworkMockAccessList) ExpectOnce() *ConsensusNetworkMockAccessListExpectation {
+	m.mock.AccessListFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ConsensusNetworkMockAccessListExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ConsensusNetworkMockAccessListExpectation) Return(r *host.AccessList) {
+	e.result = &ConsensusNetworkMockAccessListResult{r}
+}
+
+//Set uses given function f as a mock of ConsensusNetwork.AccessList method
+func (m *mConsensusNetworkMockAccessList) Set(f func() (r *host.AccessList)) *ConsensusNetworkMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.AccessListFunc = f
+	return m.mock
+}
+
+//AccessList implements github.com/insolar/insolar/network.ConsensusNetwork interface
+func (m *ConsensusNetworkMock) AccessList() (r *host.AccessList) {
+	counter := atomic.AddUint64(&m.AccessListPreCounter, 1)
+	defer atomic.AddUint64(&m.AccessListCounter, 1)
+
+	if len(m.AccessListMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.AccessListMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ConsensusNetworkMock.AccessList.")
+			return
+		}
+
+		result := m.AccessListMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ConsensusNetworkMock.AccessList")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.AccessListMock.mainExpectation != nil {
+
+		result := m.AccessListMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ConsensusNetworkMock.AccessList")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.AccessListFunc == nil {
+		m.t.Fatalf("Unexpected call to ConsensusNetworkMock.AccessList.")
+		return
+	}
+
+	return m.AccessListFunc()
+}
+
+//AccessListMinimockCounter returns a count of ConsensusNetworkMock.AccessListFunc invocations
+func (m *ConsensusNetworkMock) AccessListMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.AccessListCounter)
+}
+
+//AccessListMinimockPreCounter returns the value of ConsensusNetworkMock.AccessList invocations
+func (m *ConsensusNetworkMock) AccessListMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.AccessListPreCounter)
+}
+
+//AccessListFinished returns true if mock invocations count is ok
+func (m *ConsensusNetworkMock) AccessListFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.AccessListMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.AccessListCounter) == uint64(len(m.AccessListMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.AccessListMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.AccessListCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.AccessListFunc != nil {
+		return atomic.LoadUint64(&m.AccessListCounter) > 0
+	}
+
+	return true
+}
+
+type mConsensusNetworkMockCapture struct {
+	mock              *ConsensusNetworkMock
+	mainExpectation   *ConsensusNetworkMockCaptureExpectation
+	expectationSeries []*ConsensusNetworkMockCaptureExpectation
+}
+
+type ConsensusNetworkMockCaptureExpectation struct {
+	result *ConsensusNetworkMockCaptureResult
+}
+
+type ConsensusNetworkMockCaptureResult struct {
+	r *host.Capture
+}
+
+//Expect specifies that invocation of ConsensusNetwork.Capture is expected from 1 to Infinity times
+func (m *mConsensusNetworkMockCapture) Expect() *mConsensusNetworkMockCapture {
+	m.mock.CaptureFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockCaptureExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of ConsensusNetwork.Capture
+func (m *mConsensusNetworkMockCapture) Return(r *host.Capture) *ConsensusNetworkMock {
+	m.mock.CaptureFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockCaptureExpectation{}
+	}
+	m.mainExpectation.result = &ConsensusNetworkMockCaptureResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ConsensusNetwork.Capture is expected once
+func (m *mConsensusNetworkMockCapture) ExpectOnce() *ConsensusNetworkMockCaptureExpectation {
+	m.mock.CaptureFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ConsensusNetworkMockCaptureExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ConsensusNetworkMockCaptureExpectation) Return(r *host.Capture) {
+	e.result = &ConsensusNetworkMockCaptureResult{r}
+}
+
+//Set uses given function f as a mock of ConsensusNetwork.Capture method
+func (m *mConsensusNetworkMockCapture) Set(f func() (r *host.Capture)) *ConsensusNetworkMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.CaptureFunc = f
+	return m.mock
+}
+
+//Capture implements github.com/insolar/insolar/network.ConsensusNetwork interface
+func (m *ConsensusNetworkMock) Capture() (r *host.Capture) {
+	counter := atomic.AddUint64(&m.CapturePreCounter, 1)
+	defer atomic.AddUint64(&m.CaptureCounter, 1)
+
+	if len(m.CaptureMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.CaptureMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ConsensusNetworkMock.Capture.")
+			return
+		}
+
+		result := m.CaptureMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ConsensusNetworkMock.Capture")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CaptureMock.mainExpectation != nil {
+
+		result := m.CaptureMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ConsensusNetworkMock.Capture")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CaptureFunc == nil {
+		m.t.Fatalf("Unexpected call to ConsensusNetworkMock.Capture.")
+		return
+	}
+
+	return m.CaptureFunc()
+}
+
+//CaptureMinimockCounter returns a count of ConsensusNetworkMock.CaptureFunc invocations
+func (m *ConsensusNetworkMock) CaptureMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.CaptureCounter)
+}
+
+//CaptureMinimockPreCounter returns the value of ConsensusNetworkMock.Capture invocations
+func (m *ConsensusNetworkMock) CaptureMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.CapturePreCounter)
+}
+
+//CaptureFinished returns true if mock invocations count is ok
+func (m *ConsensusNetworkMock) CaptureFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.CaptureMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.CaptureCounter) == uint64(len(m.CaptureMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.CaptureMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.CaptureCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.CaptureFunc != nil {
+		return atomic.LoadUint64(&m.CaptureCounter) > 0
+	}
+
+	return true
+}
+
+type mConsensusNetworkMockFaults struct {
+	mock              *ConsensusNetworkMock
+	mainExpectation   *ConsensusNetworkMockFaultsExpectation
+	expectationSeries []*ConsensusNetworkMockFaultsExpectation
+}
+
+type ConsensusNetworkMockFaultsExpectation struct {
+	result *ConsensusNetworkMockFaultsResult
+}
+
+type ConsensusNetworkMockFaultsResult struct {
+	r *host.Faults
+}
+
+//Expect specifies that invocation of ConsensusNetwork.Faults is expected from 1 to Infinity times
+func (m *mConsensusNetworkMockFaults) Expect() *mConsensusNetworkMockFaults {
+	m.mock.FaultsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockFaultsExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of ConsensusNetwork.Faults
+func (m *mConsensusNetworkMockFaults) Return(r *host.Faults) *ConsensusNetworkMock {
+	m.mock.FaultsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockFaultsExpectation{}
+	}
+	m.mainExpectation.result = &ConsensusNetworkMockFaultsResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ConsensusNetwork.Faults is expected once
+func (m *mConsensusNetworkMockFaults) ExpectOnce() *ConsensusNetworkMockFaultsExpectation {
+	m.mock.FaultsFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ConsensusNetworkMockFaultsExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ConsensusNetworkMockFaultsExpectation) Return(r *host.Faults) {
+	e.result = &ConsensusNetworkMockFaultsResult{r}
+}
+
+//Set uses given function f as a mock of ConsensusNetwork.Faults method
+func (m *mConsensusNetworkMockFaults) Set(f func() (r *host.Faults)) *ConsensusNetworkMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.FaultsFunc = f
+	return m.mock
+}
+
+//Faults implements github.com/insolar/insolar/network.ConsensusNetwork interface
+func (m *ConsensusNetworkMock) Faults() (r *host.Faults) {
+	counter := atomic.AddUint64(&m.FaultsPreCounter, 1)
+	defer atomic.AddUint64(&m.FaultsCounter, 1)
+
+	if len(m.FaultsMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.FaultsMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ConsensusNetworkMock.Faults.")
+			return
+		}
+
+		result := m.FaultsMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ConsensusNetworkMock.Faults")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.FaultsMock.mainExpectation != nil {
+
+		result := m.FaultsMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ConsensusNetworkMock.Faults")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.FaultsFunc == nil {
+		m.t.Fatalf("Unexpected call to ConsensusNetworkMock.Faults.")
+		return
+	}
+
+	return m.FaultsFunc()
+}
+
+//FaultsMinimockCounter returns a count of ConsensusNetworkMock.FaultsFunc invocations
+func (m *ConsensusNetworkMock) FaultsMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.FaultsCounter)
+}
+
+//FaultsMinimockPreCounter returns the value of ConsensusNetworkMock.Faults invocations
+func (m *ConsensusNetworkMock) FaultsMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.FaultsPreCounter)
+}
+
+//FaultsFinished returns true if mock invocations count is ok
+func (m *ConsensusNetworkMock) FaultsFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.FaultsMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.FaultsCounter) == uint64(len(m.FaultsMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.FaultsMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.FaultsCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.FaultsFunc != nil {
+		return atomic.LoadUint64(&m.FaultsCounter) > 0
+	}
+
+	return true
+}
+
 type mConsensusNetworkMockGetNodeID struct {
 	mock              *ConsensusNetworkMock
 	mainExpectation   *ConsensusNetworkMockGetNodeIDExpectation
@@ -751,6 +1178,129 @@ func (m *ConsensusNetworkMock) SendRequestFinished() bool {
 	return true
 }
 
+type mConsensusNetworkMockSetSendQueue struct {
+	mock              *ConsensusNetworkMock
+	mainExpectation   *ConsensusNetworkMockSetSendQueueExpectation
+	expectationSeries []*ConsensusNetworkMockSetSendQueueExpectation
+}
+
+type ConsensusNetworkMockSetSendQueueExpectation struct {
+	input *ConsensusNetworkMockSetSendQueueInput
+}
+
+type ConsensusNetworkMockSetSendQueueInput struct {
+	p *host.SendQueue
+}
+
+//Expect specifies that invocation of ConsensusNetwork.SetSendQueue is expected from 1 to Infinity times
+func (m *mConsensusNetworkMockSetSendQueue) Expect(p *host.SendQueue) *mConsensusNetworkMockSetSendQueue {
+	m.mock.SetSendQueueFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockSetSendQueueExpectation{}
+	}
+	m.mainExpectation.input = &ConsensusNetworkMockSetSendQueueInput{p}
+	return m
+}
+
+//Return specifies results of invocation of ConsensusNetwork.SetSendQueue
+func (m *mConsensusNetworkMockSetSendQueue) Return() *ConsensusNetworkMock {
+	m.mock.SetSendQueueFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockSetSendQueueExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ConsensusNetwork.SetSendQueue is expected once
+func (m *mConsensusNetworkMockSetSendQueue) ExpectOnce(p *host.SendQueue) *ConsensusNetworkMockSetSendQueueExpectation {
+	m.mock.SetSendQueueFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ConsensusNetworkMockSetSendQueueExpectation{}
+	expectation.input = &ConsensusNetworkMockSetSendQueueInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of ConsensusNetwork.SetSendQueue method
+func (m *mConsensusNetworkMockSetSendQueue) Set(f func(p *host.SendQueue)) *ConsensusNetworkMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SetSendQueueFunc = f
+	return m.mock
+}
+
+//SetSendQueue implements github.com/insolar/insolar/network.ConsensusNetwork interface
+func (m *ConsensusNetworkMock) SetSendQueue(p *host.SendQueue) {
+	counter := atomic.AddUint64(&m.SetSendQueuePreCounter, 1)
+	defer atomic.AddUint64(&m.SetSendQueueCounter, 1)
+
+	if len(m.SetSendQueueMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SetSendQueueMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ConsensusNetworkMock.SetSendQueue. %v", p)
+			return
+		}
+
+		input := m.SetSendQueueMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ConsensusNetworkMockSetSendQueueInput{p}, "ConsensusNetwork.SetSendQueue got unexpected parameters")
+
+		return
+	}
+
+	if m.SetSendQueueMock.mainExpectation != nil {
+
+		input := m.SetSendQueueMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ConsensusNetworkMockSetSendQueueInput{p}, "ConsensusNetwork.SetSendQueue got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.SetSendQueueFunc == nil {
+		m.t.Fatalf("Unexpected call to ConsensusNetworkMock.SetSendQueue. %v", p)
+		return
+	}
+
+	m.SetSendQueueFunc(p)
+}
+
+//SetSendQueueMinimockCounter returns a count of ConsensusNetworkMock.SetSendQueueFunc invocations
+func (m *ConsensusNetworkMock) SetSendQueueMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetSendQueueCounter)
+}
+
+//SetSendQueueMinimockPreCounter returns the value of ConsensusNetworkMock.SetSendQueue invocations
+func (m *ConsensusNetworkMock) SetSendQueueMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetSendQueuePreCounter)
+}
+
+//SetSendQueueFinished returns true if mock invocations count is ok
+func (m *ConsensusNetworkMock) SetSendQueueFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SetSendQueueMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SetSendQueueCounter) == uint64(len(m.SetSendQueueMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SetSendQueueMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SetSendQueueCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SetSendQueueFunc != nil {
+		return atomic.LoadUint64(&m.SetSendQueueCounter) > 0
+	}
+
+	return true
+}
+
 type mConsensusNetworkMockStart struct {
 	mock              *ConsensusNetworkMock
 	mainExpectation   *ConsensusNetworkMockStartExpectation
@@ -988,6 +1538,18 @@ func (m *ConsensusNetworkMock) StopFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *ConsensusNetworkMock) ValidateCallCounters() {
 
+	if !m.AccessListFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.AccessList")
+	}
+
+	if !m.CaptureFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.Capture")
+	}
+
+	if !m.FaultsFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.Faults")
+	}
+
 	if !m.GetNodeIDFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.GetNodeID")
 	}
@@ -1008,6 +1570,10 @@ func (m *ConsensusNetworkMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.SendRequest")
 	}
 
+	if !m.SetSendQueueFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.SetSendQueue")
+	}
+
 	if !m.StartFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.Start")
 	}
@@ -1033,6 +1599,18 @@ func (m *ConsensusNetworkMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *ConsensusNetworkMock) MinimockFinish() {
 
+	if !m.AccessListFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.AccessList")
+	}
+
+	if !m.CaptureFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.Capture")
+	}
+
+	if !m.FaultsFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.Faults")
+	}
+
 	if !m.GetNodeIDFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.GetNodeID")
 	}
@@ -1053,6 +1631,10 @@ func (m *ConsensusNetworkMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.SendRequest")
 	}
 
+	if !m.SetSendQueueFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.SetSendQueue")
+	}
+
 	if !m.StartFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.Start")
 	}
@@ -1075,11 +1657,15 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.AccessListFinished()
+		ok = ok && m.CaptureFinished()
+		ok = ok && m.FaultsFinished()
 		ok = ok && m.GetNodeIDFinished()
 		ok = ok && m.NewRequestBuilderFinished()
 		ok = ok && m.PublicAddressFinished()
 		ok = ok && m.RegisterRequestHandlerFinished()
 		ok = ok && m.SendRequestFinished()
+		ok = ok && m.SetSendQueueFinished()
 		ok = ok && m.StartFinished()
 		ok = ok && m.StopFinished()
 
@@ -1090,6 +1676,18 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.AccessListFinished() {
+				m.t.Error("Expected call to ConsensusNetworkMock.AccessList")
+			}
+
+			if !m.CaptureFinished() {
+				m.t.Error("Expected call to ConsensusNetworkMock.Capture")
+			}
+
+			if !m.FaultsFinished() {
+				m.t.Error("Expected call to ConsensusNetworkMock.Faults")
+			}
+
 			if !m.GetNodeIDFinished() {
 				m.t.Error("Expected call to ConsensusNetworkMock.GetNodeID")
 			}
@@ -1110,6 +1708,10 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ConsensusNetworkMock.SendRequest")
 			}
 
+			if !m.SetSendQueueFinished() {
+				m.t.Error("Expected call to ConsensusNetworkMock.SetSendQueue")
+			}
+
 			if !m.StartFinished() {
 				m.t.Error("Expected call to ConsensusNetworkMock.Start")
 			}
@@ -1130,6 +1732,18 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *ConsensusNetworkMock) AllMocksCalled() bool {
 
+	if !m.AccessListFinished() {
+		return false
+	}
+
+	if !m.CaptureFinished() {
+		return false
+	}
+
+	if !m.FaultsFinished() {
+		return false
+	}
+
 	if !m.GetNodeIDFinished() {
 		return false
 	}
@@ -1150,6 +1764,10 @@ func (m *ConsensusNetworkMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.SetSendQueueFinished() {
+		return false
+	}
+
 	if !m.StartFinished() {
 		return false
 	}
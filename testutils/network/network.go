@@ -17,13 +17,15 @@
 package network
 
 import (
+	"context"
+
 	"github.com/insolar/insolar/core"
 )
 
 type testNetwork struct {
 }
 
-func (n *testNetwork) SendMessage(nodeID core.RecordRef, method string, msg core.Parcel) ([]byte, error) {
+func (n *testNetwork) SendMessage(ctx context.Context, nodeID core.RecordRef, method string, msg core.Parcel) ([]byte, error) {
 	return make([]byte, 0), nil
 }
 func (n *testNetwork) SendCascadeMessage(data core.Cascade, method string, msg core.Parcel) error {
@@ -6,6 +6,7 @@ This code was generated automatically using github.com/gojuno/minimock v1.9
 The original interface "NodeKeeper" can be found in github.com/insolar/insolar/network
 */
 import (
+	context "context"
 	"sync/atomic"
 	"time"
 
@@ -26,6 +27,11 @@ type NodeKeeperMock struct {
 	AddActiveNodesPreCounter uint64
 	AddActiveNodesMock       mNodeKeeperMockAddActiveNodes
 
+	AddFaultEvidenceFunc       func(p context.Context, p1 core.FaultEvidence) (r error)
+	AddFaultEvidenceCounter    uint64
+	AddFaultEvidencePreCounter uint64
+	AddFaultEvidenceMock       mNodeKeeperMockAddFaultEvidence
+
 	AddPendingClaimFunc       func(p packets.ReferendumClaim) (r bool)
 	AddPendingClaimCounter    uint64
 	AddPendingClaimPreCounter uint64
@@ -61,6 +67,11 @@ type NodeKeeperMock struct {
 	GetCloudHashPreCounter uint64
 	GetCloudHashMock       mNodeKeeperMockGetCloudHash
 
+	GetFaultEvidenceFunc       func(p context.Context, p1 core.RecordRef) (r []core.FaultEvidence, r1 error)
+	GetFaultEvidenceCounter    uint64
+	GetFaultEvidencePreCounter uint64
+	GetFaultEvidenceMock       mNodeKeeperMockGetFaultEvidence
+
 	GetOriginFunc       func() (r core.Node)
 	GetOriginCounter    uint64
 	GetOriginPreCounter uint64
@@ -101,6 +112,16 @@ type NodeKeeperMock struct {
 	NodesJoinedDuringPreviousPulsePreCounter uint64
 	NodesJoinedDuringPreviousPulseMock       mNodeKeeperMockNodesJoinedDuringPreviousPulse
 
+	RemoveActiveNodesFunc       func(p []core.RecordRef)
+	RemoveActiveNodesCounter    uint64
+	RemoveActiveNodesPreCounter uint64
+	RemoveActiveNodesMock       mNodeKeeperMockRemoveActiveNodes
+
+	ResetFunc       func()
+	ResetCounter    uint64
+	ResetPreCounter uint64
+	ResetMock       mNodeKeeperMockReset
+
 	SetCloudHashFunc       func(p []byte)
 	SetCloudHashCounter    uint64
 	SetCloudHashPreCounter uint64
@@ -131,6 +152,7 @@ func NewNodeKeeperMock(t minimock.Tester) *NodeKeeperMock {
 	}
 
 	m.AddActiveNodesMock = mNodeKeeperMockAddActiveNodes{mock: m}
+	m.AddFaultEvidenceMock = mNodeKeeperMockAddFaultEvidence{mock: m}
 	m.AddPendingClaimMock = mNodeKeeperMockAddPendingClaim{mock: m}
 	m.GetActiveNodeMock = mNodeKeeperMockGetActiveNode{mock: m}
 	m.GetActiveNodeByShortIDMock = mNodeKeeperMockGetActiveNodeByShortID{mock: m}
@@ -138,6 +160,7 @@ func NewNodeKeeperMock(t minimock.Tester) *NodeKeeperMock {
 	m.GetActiveNodesByRoleMock = mNodeKeeperMockGetActiveNodesByRole{mock: m}
 	m.GetClaimQueueMock = mNodeKeeperMockGetClaimQueue{mock: m}
 	m.GetCloudHashMock = mNodeKeeperMockGetCloudHash{mock: m}
+	m.GetFaultEvidenceMock = mNodeKeeperMockGetFaultEvidence{mock: m}
 	m.GetOriginMock = mNodeKeeperMockGetOrigin{mock: m}
 	m.GetOriginClaimMock = mNodeKeeperMockGetOriginClaim{mock: m}
 	m.GetSparseUnsyncListMock = mNodeKeeperMockGetSparseUnsyncList{mock: m}
@@ -146,6 +169,8 @@ func NewNodeKeeperMock(t minimock.Tester) *NodeKeeperMock {
 	m.IsBootstrappedMock = mNodeKeeperMockIsBootstrapped{mock: m}
 	m.MoveSyncToActiveMock = mNodeKeeperMockMoveSyncToActive{mock: m}
 	m.NodesJoinedDuringPreviousPulseMock = mNodeKeeperMockNodesJoinedDuringPreviousPulse{mock: m}
+	m.RemoveActiveNodesMock = mNodeKeeperMockRemoveActiveNodes{mock: m}
+	m.ResetMock = mNodeKeeperMockReset{mock: m}
 	m.SetCloudHashMock = mNodeKeeperMockSetCloudHash{mock: m}
 	m.SetIsBootstrappedMock = mNodeKeeperMockSetIsBootstrapped{mock: m}
 	m.SetStateMock = mNodeKeeperMockSetState{mock: m}
@@ -277,6 +302,154 @@ func (m *NodeKeeperMock) AddActiveNodesFinished() bool {
 	return true
 }
 
+type mNodeKeeperMockAddFaultEvidence struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockAddFaultEvidenceExpectation
+	expectationSeries []*NodeKeeperMockAddFaultEvidenceExpectation
+}
+
+type NodeKeeperMockAddFaultEvidenceExpectation struct {
+	input  *NodeKeeperMockAddFaultEvidenceInput
+	result *NodeKeeperMockAddFaultEvidenceResult
+}
+
+type NodeKeeperMockAddFaultEvidenceInput struct {
+	p  context.Context
+	p1 core.FaultEvidence
+}
+
+type NodeKeeperMockAddFaultEvidenceResult struct {
+	r error
+}
+
+//Expect specifies that invocation of NodeKeeper.AddFaultEvidence is expected from 1 to Infinity times
+func (m *mNodeKeeperMockAddFaultEvidence) Expect(p context.Context, p1 core.FaultEvidence) *mNodeKeeperMockAddFaultEvidence {
+	m.mock.AddFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockAddFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.input = &NodeKeeperMockAddFaultEvidenceInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.AddFaultEvidence
+func (m *mNodeKeeperMockAddFaultEvidence) Return(r error) *NodeKeeperMock {
+	m.mock.AddFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockAddFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.result = &NodeKeeperMockAddFaultEvidenceResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.AddFaultEvidence is expected once
+func (m *mNodeKeeperMockAddFaultEvidence) ExpectOnce(p context.Context, p1 core.FaultEvidence) *NodeKeeperMockAddFaultEvidenceExpectation {
+	m.mock.AddFaultEvidenceFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockAddFaultEvidenceExpectation{}
+	expectation.input = &NodeKeeperMockAddFaultEvidenceInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NodeKeeperMockAddFaultEvidenceExpectation) Return(r error) {
+	e.result = &NodeKeeperMockAddFaultEvidenceResult{r}
+}
+
+//Set uses given function f as a mock of NodeKeeper.AddFaultEvidence method
+func (m *mNodeKeeperMockAddFaultEvidence) Set(f func(p context.Context, p1 core.FaultEvidence) (r error)) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.AddFaultEvidenceFunc = f
+	return m.mock
+}
+
+//AddFaultEvidence implements github.com/insolar/insolar/network.NodeKeeper interface
+func (m *NodeKeeperMock) AddFaultEvidence(p context.Context, p1 core.FaultEvidence) (r error) {
+	counter := atomic.AddUint64(&m.AddFaultEvidencePreCounter, 1)
+	defer atomic.AddUint64(&m.AddFaultEvidenceCounter, 1)
+
+	if len(m.AddFaultEvidenceMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.AddFaultEvidenceMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.AddFaultEvidence. %v %v", p, p1)
+			return
+		}
+
+		input := m.AddFaultEvidenceMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NodeKeeperMockAddFaultEvidenceInput{p, p1}, "NodeKeeper.AddFaultEvidence got unexpected parameters")
+
+		result := m.AddFaultEvidenceMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.AddFaultEvidence")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.AddFaultEvidenceMock.mainExpectation != nil {
+
+		input := m.AddFaultEvidenceMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NodeKeeperMockAddFaultEvidenceInput{p, p1}, "NodeKeeper.AddFaultEvidence got unexpected parameters")
+		}
+
+		result := m.AddFaultEvidenceMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.AddFaultEvidence")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.AddFaultEvidenceFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.AddFaultEvidence. %v %v", p, p1)
+		return
+	}
+
+	return m.AddFaultEvidenceFunc(p, p1)
+}
+
+//AddFaultEvidenceMinimockCounter returns a count of NodeKeeperMock.AddFaultEvidenceFunc invocations
+func (m *NodeKeeperMock) AddFaultEvidenceMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.AddFaultEvidenceCounter)
+}
+
+//AddFaultEvidenceMinimockPreCounter returns the value of NodeKeeperMock.AddFaultEvidence invocations
+func (m *NodeKeeperMock) AddFaultEvidenceMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.AddFaultEvidencePreCounter)
+}
+
+//AddFaultEvidenceFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) AddFaultEvidenceFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.AddFaultEvidenceMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.AddFaultEvidenceCounter) == uint64(len(m.AddFaultEvidenceMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.AddFaultEvidenceMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.AddFaultEvidenceCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.AddFaultEvidenceFunc != nil {
+		return atomic.LoadUint64(&m.AddFaultEvidenceCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeKeeperMockAddPendingClaim struct {
 	mock              *NodeKeeperMock
 	mainExpectation   *NodeKeeperMockAddPendingClaimExpectation
@@ -1267,6 +1440,157 @@ func (m *NodeKeeperMock) GetCloudHashFinished() bool {
 	return true
 }
 
+type mNodeKeeperMockGetFaultEvidence struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockGetFaultEvidenceExpectation
+	expectationSeries []*NodeKeeperMockGetFaultEvidenceExpectation
+}
+
+type NodeKeeperMockGetFaultEvidenceExpectation struct {
+	input  *NodeKeeperMockGetFaultEvidenceInput
+	result *NodeKeeperMockGetFaultEvidenceResult
+}
+
+type NodeKeeperMockGetFaultEvidenceInput struct {
+	p  context.Context
+	p1 core.RecordRef
+}
+
+type NodeKeeperMockGetFaultEvidenceResult struct {
+	r  []core.FaultEvidence
+	r1 error
+}
+
+//Expect specifies that invocation of NodeKeeper.GetFaultEvidence is expected from 1 to Infinity times
+func (m *mNodeKeeperMockGetFaultEvidence) Expect(p context.Context, p1 core.RecordRef) *mNodeKeeperMockGetFaultEvidence {
+	m.mock.GetFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockGetFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.input = &NodeKeeperMockGetFaultEvidenceInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.GetFaultEvidence
+func (m *mNodeKeeperMockGetFaultEvidence) Return(r []core.FaultEvidence, r1 error) *NodeKeeperMock {
+	m.mock.GetFaultEvidenceFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockGetFaultEvidenceExpectation{}
+	}
+	m.mainExpectation.result = &NodeKeeperMockGetFaultEvidenceResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.GetFaultEvidence is expected once
+func (m *mNodeKeeperMockGetFaultEvidence) ExpectOnce(p context.Context, p1 core.RecordRef) *NodeKeeperMockGetFaultEvidenceExpectation {
+	m.mock.GetFaultEvidenceFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockGetFaultEvidenceExpectation{}
+	expectation.input = &NodeKeeperMockGetFaultEvidenceInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NodeKeeperMockGetFaultEvidenceExpectation) Return(r []core.FaultEvidence, r1 error) {
+	e.result = &NodeKeeperMockGetFaultEvidenceResult{r, r1}
+}
+
+//Set uses given function f as a mock of NodeKeeper.GetFaultEvidence method
+func (m *mNodeKeeperMockGetFaultEvidence) Set(f func(p context.Context, p1 core.RecordRef) (r []core.FaultEvidence, r1 error)) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetFaultEvidenceFunc = f
+	return m.mock
+}
+
+//GetFaultEvidence implements github.com/insolar/insolar/network.NodeKeeper interface
+func (m *NodeKeeperMock) GetFaultEvidence(p context.Context, p1 core.RecordRef) (r []core.FaultEvidence, r1 error) {
+	counter := atomic.AddUint64(&m.GetFaultEvidencePreCounter, 1)
+	defer atomic.AddUint64(&m.GetFaultEvidenceCounter, 1)
+
+	if len(m.GetFaultEvidenceMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetFaultEvidenceMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.GetFaultEvidence. %v %v", p, p1)
+			return
+		}
+
+		input := m.GetFaultEvidenceMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NodeKeeperMockGetFaultEvidenceInput{p, p1}, "NodeKeeper.GetFaultEvidence got unexpected parameters")
+
+		result := m.GetFaultEvidenceMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.GetFaultEvidence")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetFaultEvidenceMock.mainExpectation != nil {
+
+		input := m.GetFaultEvidenceMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NodeKeeperMockGetFaultEvidenceInput{p, p1}, "NodeKeeper.GetFaultEvidence got unexpected parameters")
+		}
+
+		result := m.GetFaultEvidenceMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.GetFaultEvidence")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetFaultEvidenceFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.GetFaultEvidence. %v %v", p, p1)
+		return
+	}
+
+	return m.GetFaultEvidenceFunc(p, p1)
+}
+
+//GetFaultEvidenceMinimockCounter returns a count of NodeKeeperMock.GetFaultEvidenceFunc invocations
+func (m *NodeKeeperMock) GetFaultEvidenceMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetFaultEvidenceCounter)
+}
+
+//GetFaultEvidenceMinimockPreCounter returns the value of NodeKeeperMock.GetFaultEvidence invocations
+func (m *NodeKeeperMock) GetFaultEvidenceMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetFaultEvidencePreCounter)
+}
+
+//GetFaultEvidenceFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) GetFaultEvidenceFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetFaultEvidenceMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetFaultEvidenceCounter) == uint64(len(m.GetFaultEvidenceMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetFaultEvidenceMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetFaultEvidenceCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetFaultEvidenceFunc != nil {
+		return atomic.LoadUint64(&m.GetFaultEvidenceCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeKeeperMockGetOrigin struct {
 	mock              *NodeKeeperMock
 	mainExpectation   *NodeKeeperMockGetOriginExpectation
@@ -2331,6 +2655,239 @@ func (m *NodeKeeperMock) NodesJoinedDuringPreviousPulseFinished() bool {
 	return true
 }
 
+type mNodeKeeperMockRemoveActiveNodes struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockRemoveActiveNodesExpectation
+	expectationSeries []*NodeKeeperMockRemoveActiveNodesExpectation
+}
+
+type NodeKeeperMockRemoveActiveNodesExpectation struct {
+	input *NodeKeeperMockRemoveActiveNodesInput
+}
+
+type NodeKeeperMockRemoveActiveNodesInput struct {
+	p []core.RecordRef
+}
+
+//Expect specifies that invocation of NodeKeeper.RemoveActiveNodes is expected from 1 to Infinity times
+func (m *mNodeKeeperMockRemoveActiveNodes) Expect(p []core.RecordRef) *mNodeKeeperMockRemoveActiveNodes {
+	m.mock.RemoveActiveNodesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockRemoveActiveNodesExpectation{}
+	}
+	m.mainExpectation.input = &NodeKeeperMockRemoveActiveNodesInput{p}
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.RemoveActiveNodes
+func (m *mNodeKeeperMockRemoveActiveNodes) Return() *NodeKeeperMock {
+	m.mock.RemoveActiveNodesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockRemoveActiveNodesExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.RemoveActiveNodes is expected once
+func (m *mNodeKeeperMockRemoveActiveNodes) ExpectOnce(p []core.RecordRef) *NodeKeeperMockRemoveActiveNodesExpectation {
+	m.mock.RemoveActiveNodesFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockRemoveActiveNodesExpectation{}
+	expectation.input = &NodeKeeperMockRemoveActiveNodesInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of NodeKeeper.RemoveActiveNodes method
+func (m *mNodeKeeperMockRemoveActiveNodes) Set(f func(p []core.RecordRef)) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.RemoveActiveNodesFunc = f
+	return m.mock
+}
+
+//RemoveActiveNodes implements github.com/insolar/insolar/network.NodeKeeper interface
+func (m *NodeKeeperMock) RemoveActiveNodes(p []core.RecordRef) {
+	counter := atomic.AddUint64(&m.RemoveActiveNodesPreCounter, 1)
+	defer atomic.AddUint64(&m.RemoveActiveNodesCounter, 1)
+
+	if len(m.RemoveActiveNodesMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.RemoveActiveNodesMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.RemoveActiveNodes. %v", p)
+			return
+		}
+
+		input := m.RemoveActiveNodesMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NodeKeeperMockRemoveActiveNodesInput{p}, "NodeKeeper.RemoveActiveNodes got unexpected parameters")
+
+		return
+	}
+
+	if m.RemoveActiveNodesMock.mainExpectation != nil {
+
+		input := m.RemoveActiveNodesMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NodeKeeperMockRemoveActiveNodesInput{p}, "NodeKeeper.RemoveActiveNodes got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.RemoveActiveNodesFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.RemoveActiveNodes. %v", p)
+		return
+	}
+
+	m.RemoveActiveNodesFunc(p)
+}
+
+//RemoveActiveNodesMinimockCounter returns a count of NodeKeeperMock.RemoveActiveNodesFunc invocations
+func (m *NodeKeeperMock) RemoveActiveNodesMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.RemoveActiveNodesCounter)
+}
+
+//RemoveActiveNodesMinimockPreCounter returns the value of NodeKeeperMock.RemoveActiveNodes invocations
+func (m *NodeKeeperMock) RemoveActiveNodesMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.RemoveActiveNodesPreCounter)
+}
+
+//RemoveActiveNodesFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) RemoveActiveNodesFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.RemoveActiveNodesMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.RemoveActiveNodesCounter) == uint64(len(m.RemoveActiveNodesMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.RemoveActiveNodesMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.RemoveActiveNodesCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.RemoveActiveNodesFunc != nil {
+		return atomic.LoadUint64(&m.RemoveActiveNodesCounter) > 0
+	}
+
+	return true
+}
+
+type mNodeKeeperMockReset struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockResetExpectation
+	expectationSeries []*NodeKeeperMockResetExpectation
+}
+
+type NodeKeeperMockResetExpectation struct {
+}
+
+//Expect specifies that invocation of NodeKeeper.Reset is expected from 1 to Infinity times
+func (m *mNodeKeeperMockReset) Expect() *mNodeKeeperMockReset {
+	m.mock.ResetFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockResetExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.Reset
+func (m *mNodeKeeperMockReset) Return() *NodeKeeperMock {
+	m.mock.ResetFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockResetExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.Reset is expected once
+func (m *mNodeKeeperMockReset) ExpectOnce() *NodeKeeperMockResetExpectation {
+	m.mock.ResetFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockResetExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of NodeKeeper.Reset method
+func (m *mNodeKeeperMockReset) Set(f func()) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.ResetFunc = f
+	return m.mock
+}
+
+//Reset implements github.com/insolar/insolar/network.NodeKeeper interface
+func (m *NodeKeeperMock) Reset() {
+	counter := atomic.AddUint64(&m.ResetPreCounter, 1)
+	defer atomic.AddUint64(&m.ResetCounter, 1)
+
+	if len(m.ResetMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.ResetMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.Reset.")
+			return
+		}
+
+		return
+	}
+
+	if m.ResetMock.mainExpectation != nil {
+
+		return
+	}
+
+	if m.ResetFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.Reset.")
+		return
+	}
+
+	m.ResetFunc()
+}
+
+//ResetMinimockCounter returns a count of NodeKeeperMock.ResetFunc invocations
+func (m *NodeKeeperMock) ResetMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.ResetCounter)
+}
+
+//ResetMinimockPreCounter returns the value of NodeKeeperMock.Reset invocations
+func (m *NodeKeeperMock) ResetMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.ResetPreCounter)
+}
+
+//ResetFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) ResetFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.ResetMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.ResetCounter) == uint64(len(m.ResetMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.ResetMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.ResetCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.ResetFunc != nil {
+		return atomic.LoadUint64(&m.ResetCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeKeeperMockSetCloudHash struct {
 	mock              *NodeKeeperMock
 	mainExpectation   *NodeKeeperMockSetCloudHashExpectation
@@ -2831,6 +3388,10 @@ func (m *NodeKeeperMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeKeeperMock.AddActiveNodes")
 	}
 
+	if !m.AddFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.AddFaultEvidence")
+	}
+
 	if !m.AddPendingClaimFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.AddPendingClaim")
 	}
@@ -2859,6 +3420,10 @@ func (m *NodeKeeperMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetCloudHash")
 	}
 
+	if !m.GetFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.GetFaultEvidence")
+	}
+
 	if !m.GetOriginFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetOrigin")
 	}
@@ -2891,6 +3456,14 @@ func (m *NodeKeeperMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeKeeperMock.NodesJoinedDuringPreviousPulse")
 	}
 
+	if !m.RemoveActiveNodesFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.RemoveActiveNodes")
+	}
+
+	if !m.ResetFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.Reset")
+	}
+
 	if !m.SetCloudHashFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.SetCloudHash")
 	}
@@ -2928,6 +3501,10 @@ func (m *NodeKeeperMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeKeeperMock.AddActiveNodes")
 	}
 
+	if !m.AddFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.AddFaultEvidence")
+	}
+
 	if !m.AddPendingClaimFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.AddPendingClaim")
 	}
@@ -2956,6 +3533,10 @@ func (m *NodeKeeperMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetCloudHash")
 	}
 
+	if !m.GetFaultEvidenceFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.GetFaultEvidence")
+	}
+
 	if !m.GetOriginFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetOrigin")
 	}
@@ -2988,6 +3569,14 @@ func (m *NodeKeeperMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeKeeperMock.NodesJoinedDuringPreviousPulse")
 	}
 
+	if !m.RemoveActiveNodesFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.RemoveActiveNodes")
+	}
+
+	if !m.ResetFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.Reset")
+	}
+
 	if !m.SetCloudHashFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.SetCloudHash")
 	}
@@ -3019,6 +3608,7 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && m.AddActiveNodesFinished()
+		ok = ok && m.AddFaultEvidenceFinished()
 		ok = ok && m.AddPendingClaimFinished()
 		ok = ok && m.GetActiveNodeFinished()
 		ok = ok && m.GetActiveNodeByShortIDFinished()
@@ -3026,6 +3616,7 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetActiveNodesByRoleFinished()
 		ok = ok && m.GetClaimQueueFinished()
 		ok = ok && m.GetCloudHashFinished()
+		ok = ok && m.GetFaultEvidenceFinished()
 		ok = ok && m.GetOriginFinished()
 		ok = ok && m.GetOriginClaimFinished()
 		ok = ok && m.GetSparseUnsyncListFinished()
@@ -3034,6 +3625,8 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.IsBootstrappedFinished()
 		ok = ok && m.MoveSyncToActiveFinished()
 		ok = ok && m.NodesJoinedDuringPreviousPulseFinished()
+		ok = ok && m.RemoveActiveNodesFinished()
+		ok = ok && m.ResetFinished()
 		ok = ok && m.SetCloudHashFinished()
 		ok = ok && m.SetIsBootstrappedFinished()
 		ok = ok && m.SetStateFinished()
@@ -3050,6 +3643,10 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeKeeperMock.AddActiveNodes")
 			}
 
+			if !m.AddFaultEvidenceFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.AddFaultEvidence")
+			}
+
 			if !m.AddPendingClaimFinished() {
 				m.t.Error("Expected call to NodeKeeperMock.AddPendingClaim")
 			}
@@ -3078,6 +3675,10 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeKeeperMock.GetCloudHash")
 			}
 
+			if !m.GetFaultEvidenceFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.GetFaultEvidence")
+			}
+
 			if !m.GetOriginFinished() {
 				m.t.Error("Expected call to NodeKeeperMock.GetOrigin")
 			}
@@ -3110,6 +3711,14 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeKeeperMock.NodesJoinedDuringPreviousPulse")
 			}
 
+			if !m.RemoveActiveNodesFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.RemoveActiveNodes")
+			}
+
+			if !m.ResetFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.Reset")
+			}
+
 			if !m.SetCloudHashFinished() {
 				m.t.Error("Expected call to NodeKeeperMock.SetCloudHash")
 			}
@@ -3142,6 +3751,10 @@ func (m *NodeKeeperMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.AddFaultEvidenceFinished() {
+		return false
+	}
+
 	if !m.AddPendingClaimFinished() {
 		return false
 	}
@@ -3170,6 +3783,10 @@ func (m *NodeKeeperMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetFaultEvidenceFinished() {
+		return false
+	}
+
 	if !m.GetOriginFinished() {
 		return false
 	}
@@ -3202,6 +3819,14 @@ func (m *NodeKeeperMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.RemoveActiveNodesFinished() {
+		return false
+	}
+
+	if !m.ResetFinished() {
+		return false
+	}
+
 	if !m.SetCloudHashFinished() {
 		return false
 	}
@@ -33,6 +33,11 @@ type NodeMock struct {
 	PhysicalAddressPreCounter uint64
 	PhysicalAddressMock       mNodeMockPhysicalAddress
 
+	PhysicalAddressesFunc       func() (r []string)
+	PhysicalAddressesCounter    uint64
+	PhysicalAddressesPreCounter uint64
+	PhysicalAddressesMock       mNodeMockPhysicalAddresses
+
 	PublicKeyFunc       func() (r crypto.PublicKey)
 	PublicKeyCounter    uint64
 	PublicKeyPreCounter uint64
@@ -65,6 +70,7 @@ func NewNodeMock(t minimock.Tester) *NodeMock {
 	m.GetGlobuleIDMock = mNodeMockGetGlobuleID{mock: m}
 	m.IDMock = mNodeMockID{mock: m}
 	m.PhysicalAddressMock = mNodeMockPhysicalAddress{mock: m}
+	m.PhysicalAddressesMock = mNodeMockPhysicalAddresses{mock: m}
 	m.PublicKeyMock = mNodeMockPublicKey{mock: m}
 	m.RoleMock = mNodeMockRole{mock: m}
 	m.ShortIDMock = mNodeMockShortID{mock: m}
@@ -475,6 +481,140 @@ func (m *NodeMock) PhysicalAddressFinished() bool {
 	return true
 }
 
+type mNodeMockPhysicalAddresses struct {
+	mock              *NodeMock
+	mainExpectation   *NodeMockPhysicalAddressesExpectation
+	expectationSeries []*NodeMockPhysicalAddressesExpectation
+}
+
+type NodeMockPhysicalAddressesExpectation struct {
+	result *NodeMockPhysicalAddressesResult
+}
+
+type NodeMockPhysicalAddressesResult struct {
+	r []string
+}
+
+//Expect specifies that invocation of Node.PhysicalAddresses is expected from 1 to Infinity times
+func (m *mNodeMockPhysicalAddresses) Expect() *mNodeMockPhysicalAddresses {
+	m.mock.PhysicalAddressesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeMockPhysicalAddressesExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of Node.PhysicalAddresses
+func (m *mNodeMockPhysicalAddresses) Return(r []string) *NodeMock {
+	m.mock.PhysicalAddressesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeMockPhysicalAddressesExpectation{}
+	}
+	m.mainExpectation.result = &NodeMockPhysicalAddressesResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Node.PhysicalAddresses is expected once
+func (m *mNodeMockPhysicalAddresses) ExpectOnce() *NodeMockPhysicalAddressesExpectation {
+	m.mock.PhysicalAddressesFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeMockPhysicalAddressesExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NodeMockPhysicalAddressesExpectation) Return(r []string) {
+	e.result = &NodeMockPhysicalAddressesResult{r}
+}
+
+//Set uses given function f as a mock of Node.PhysicalAddresses method
+func (m *mNodeMockPhysicalAddresses) Set(f func() (r []string)) *NodeMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.PhysicalAddressesFunc = f
+	return m.mock
+}
+
+//PhysicalAddresses implements github.com/insolar/insolar/core.Node interface
+func (m *NodeMock) PhysicalAddresses() (r []string) {
+	counter := atomic.AddUint64(&m.PhysicalAddressesPreCounter, 1)
+	defer atomic.AddUint64(&m.PhysicalAddressesCounter, 1)
+
+	if len(m.PhysicalAddressesMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.PhysicalAddressesMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeMock.PhysicalAddresses.")
+			return
+		}
+
+		result := m.PhysicalAddressesMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeMock.PhysicalAddresses")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.PhysicalAddressesMock.mainExpectation != nil {
+
+		result := m.PhysicalAddressesMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeMock.PhysicalAddresses")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.PhysicalAddressesFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeMock.PhysicalAddresses.")
+		return
+	}
+
+	return m.PhysicalAddressesFunc()
+}
+
+//PhysicalAddressesMinimockCounter returns a count of NodeMock.PhysicalAddressesFunc invocations
+func (m *NodeMock) PhysicalAddressesMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.PhysicalAddressesCounter)
+}
+
+//PhysicalAddressesMinimockPreCounter returns the value of NodeMock.PhysicalAddresses invocations
+func (m *NodeMock) PhysicalAddressesMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.PhysicalAddressesPreCounter)
+}
+
+//PhysicalAddressesFinished returns true if mock invocations count is ok
+func (m *NodeMock) PhysicalAddressesFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.PhysicalAddressesMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.PhysicalAddressesCounter) == uint64(len(m.PhysicalAddressesMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.PhysicalAddressesMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.PhysicalAddressesCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.PhysicalAddressesFunc != nil {
+		return atomic.LoadUint64(&m.PhysicalAddressesCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeMockPublicKey struct {
 	mock              *NodeMock
 	mainExpectation   *NodeMockPublicKeyExpectation
@@ -1027,6 +1167,10 @@ func (m *NodeMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeMock.PhysicalAddress")
 	}
 
+	if !m.PhysicalAddressesFinished() {
+		m.t.Fatal("Expected call to NodeMock.PhysicalAddresses")
+	}
+
 	if !m.PublicKeyFinished() {
 		m.t.Fatal("Expected call to NodeMock.PublicKey")
 	}
@@ -1072,6 +1216,10 @@ func (m *NodeMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeMock.PhysicalAddress")
 	}
 
+	if !m.PhysicalAddressesFinished() {
+		m.t.Fatal("Expected call to NodeMock.PhysicalAddresses")
+	}
+
 	if !m.PublicKeyFinished() {
 		m.t.Fatal("Expected call to NodeMock.PublicKey")
 	}
@@ -1105,6 +1253,7 @@ func (m *NodeMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetGlobuleIDFinished()
 		ok = ok && m.IDFinished()
 		ok = ok && m.PhysicalAddressFinished()
+		ok = ok && m.PhysicalAddressesFinished()
 		ok = ok && m.PublicKeyFinished()
 		ok = ok && m.RoleFinished()
 		ok = ok && m.ShortIDFinished()
@@ -1129,6 +1278,10 @@ func (m *NodeMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeMock.PhysicalAddress")
 			}
 
+			if !m.PhysicalAddressesFinished() {
+				m.t.Error("Expected call to NodeMock.PhysicalAddresses")
+			}
+
 			if !m.PublicKeyFinished() {
 				m.t.Error("Expected call to NodeMock.PublicKey")
 			}
@@ -1169,6 +1322,10 @@ func (m *NodeMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.PhysicalAddressesFinished() {
+		return false
+	}
+
 	if !m.PublicKeyFinished() {
 		return false
 	}
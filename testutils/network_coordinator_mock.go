@@ -20,11 +20,21 @@ import (
 type NetworkCoordinatorMock struct {
 	t minimock.Tester
 
+	CancelUpgradeFunc       func(p context.Context) (r error)
+	CancelUpgradeCounter    uint64
+	CancelUpgradePreCounter uint64
+	CancelUpgradeMock       mNetworkCoordinatorMockCancelUpgrade
+
 	GetCertFunc       func(p context.Context, p1 *core.RecordRef) (r core.Certificate, r1 error)
 	GetCertCounter    uint64
 	GetCertPreCounter uint64
 	GetCertMock       mNetworkCoordinatorMockGetCert
 
+	GetUpgradeStatusFunc       func(p context.Context) (r *core.UpgradeStatus, r1 error)
+	GetUpgradeStatusCounter    uint64
+	GetUpgradeStatusPreCounter uint64
+	GetUpgradeStatusMock       mNetworkCoordinatorMockGetUpgradeStatus
+
 	IsStartedFunc       func() (r bool)
 	IsStartedCounter    uint64
 	IsStartedPreCounter uint64
@@ -35,6 +45,11 @@ type NetworkCoordinatorMock struct {
 	SetPulsePreCounter uint64
 	SetPulseMock       mNetworkCoordinatorMockSetPulse
 
+	StartUpgradeFunc       func(p context.Context, p1 []core.RecordRef, p2 string, p3 int) (r *core.UpgradeStatus, r1 error)
+	StartUpgradeCounter    uint64
+	StartUpgradePreCounter uint64
+	StartUpgradeMock       mNetworkCoordinatorMockStartUpgrade
+
 	ValidateCertFunc       func(p context.Context, p1 core.AuthorizationCertificate) (r bool, r1 error)
 	ValidateCertCounter    uint64
 	ValidateCertPreCounter uint64
@@ -49,14 +64,164 @@ func NewNetworkCoordinatorMock(t minimock.Tester) *NetworkCoordinatorMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.CancelUpgradeMock = mNetworkCoordinatorMockCancelUpgrade{mock: m}
 	m.GetCertMock = mNetworkCoordinatorMockGetCert{mock: m}
+	m.GetUpgradeStatusMock = mNetworkCoordinatorMockGetUpgradeStatus{mock: m}
 	m.IsStartedMock = mNetworkCoordinatorMockIsStarted{mock: m}
 	m.SetPulseMock = mNetworkCoordinatorMockSetPulse{mock: m}
+	m.StartUpgradeMock = mNetworkCoordinatorMockStartUpgrade{mock: m}
 	m.ValidateCertMock = mNetworkCoordinatorMockValidateCert{mock: m}
 
 	return m
 }
 
+type mNetworkCoordinatorMockCancelUpgrade struct {
+	mock              *NetworkCoordinatorMock
+	mainExpectation   *NetworkCoordinatorMockCancelUpgradeExpectation
+	expectationSeries []*NetworkCoordinatorMockCancelUpgradeExpectation
+}
+
+type NetworkCoordinatorMockCancelUpgradeExpectation struct {
+	input  *NetworkCoordinatorMockCancelUpgradeInput
+	result *NetworkCoordinatorMockCancelUpgradeResult
+}
+
+type NetworkCoordinatorMockCancelUpgradeInput struct {
+	p context.Context
+}
+
+type NetworkCoordinatorMockCancelUpgradeResult struct {
+	r error
+}
+
+//Expect specifies that invocation of NetworkCoordinator.CancelUpgrade is expected from 1 to Infinity times
+func (m *mNetworkCoordinatorMockCancelUpgrade) Expect(p context.Context) *mNetworkCoordinatorMockCancelUpgrade {
+	m.mock.CancelUpgradeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkCoordinatorMockCancelUpgradeExpectation{}
+	}
+	m.mainExpectation.input = &NetworkCoordinatorMockCancelUpgradeInput{p}
+	return m
+}
+
+//Return specifies results of invocation of NetworkCoordinator.CancelUpgrade
+func (m *mNetworkCoordinatorMockCancelUpgrade) Return(r error) *NetworkCoordinatorMock {
+	m.mock.CancelUpgradeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkCoordinatorMockCancelUpgradeExpectation{}
+	}
+	m.mainExpectation.result = &NetworkCoordinatorMockCancelUpgradeResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NetworkCoordinator.CancelUpgrade is expected once
+func (m *mNetworkCoordinatorMockCancelUpgrade) ExpectOnce(p context.Context) *NetworkCoordinatorMockCancelUpgradeExpectation {
+	m.mock.CancelUpgradeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NetworkCoordinatorMockCancelUpgradeExpectation{}
+	expectation.input = &NetworkCoordinatorMockCancelUpgradeInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NetworkCoordinatorMockCancelUpgradeExpectation) Return(r error) {
+	e.result = &NetworkCoordinatorMockCancelUpgradeResult{r}
+}
+
+//Set uses given function f as a mock of NetworkCoordinator.CancelUpgrade method
+func (m *mNetworkCoordinatorMockCancelUpgrade) Set(f func(p context.Context) (r error)) *NetworkCoordinatorMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.CancelUpgradeFunc = f
+	return m.mock
+}
+
+//CancelUpgrade implements github.com/insolar/insolar/core.NetworkCoordinator interface
+func (m *NetworkCoordinatorMock) CancelUpgrade(p context.Context) (r error) {
+	counter := atomic.AddUint64(&m.CancelUpgradePreCounter, 1)
+	defer atomic.AddUint64(&m.CancelUpgradeCounter, 1)
+
+	if len(m.CancelUpgradeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.CancelUpgradeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NetworkCoordinatorMock.CancelUpgrade. %v", p)
+			return
+		}
+
+		input := m.CancelUpgradeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NetworkCoordinatorMockCancelUpgradeInput{p}, "NetworkCoordinator.CancelUpgrade got unexpected parameters")
+
+		result := m.CancelUpgradeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkCoordinatorMock.CancelUpgrade")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CancelUpgradeMock.mainExpectation != nil {
+
+		input := m.CancelUpgradeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NetworkCoordinatorMockCancelUpgradeInput{p}, "NetworkCoordinator.CancelUpgrade got unexpected parameters")
+		}
+
+		result := m.CancelUpgradeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkCoordinatorMock.CancelUpgrade")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.CancelUpgradeFunc == nil {
+		m.t.Fatalf("Unexpected call to NetworkCoordinatorMock.CancelUpgrade. %v", p)
+		return
+	}
+
+	return m.CancelUpgradeFunc(p)
+}
+
+//CancelUpgradeMinimockCounter returns a count of NetworkCoordinatorMock.CancelUpgradeFunc invocations
+func (m *NetworkCoordinatorMock) CancelUpgradeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.CancelUpgradeCounter)
+}
+
+//CancelUpgradeMinimockPreCounter returns the value of NetworkCoordinatorMock.CancelUpgrade invocations
+func (m *NetworkCoordinatorMock) CancelUpgradeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.CancelUpgradePreCounter)
+}
+
+//CancelUpgradeFinished returns true if mock invocations count is ok
+func (m *NetworkCoordinatorMock) CancelUpgradeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.CancelUpgradeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.CancelUpgradeCounter) == uint64(len(m.CancelUpgradeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.CancelUpgradeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.CancelUpgradeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.CancelUpgradeFunc != nil {
+		return atomic.LoadUint64(&m.CancelUpgradeCounter) > 0
+	}
+
+	return true
+}
+
 type mNetworkCoordinatorMockGetCert struct {
 	mock              *NetworkCoordinatorMock
 	mainExpectation   *NetworkCoordinatorMockGetCertExpectation
@@ -208,6 +373,156 @@ func (m *NetworkCoordinatorMock) GetCertFinished() bool {
 	return true
 }
 
+type mNetworkCoordinatorMockGetUpgradeStatus struct {
+	mock              *NetworkCoordinatorMock
+	mainExpectation   *NetworkCoordinatorMockGetUpgradeStatusExpectation
+	expectationSeries []*NetworkCoordinatorMockGetUpgradeStatusExpectation
+}
+
+type NetworkCoordinatorMockGetUpgradeStatusExpectation struct {
+	input  *NetworkCoordinatorMockGetUpgradeStatusInput
+	result *NetworkCoordinatorMockGetUpgradeStatusResult
+}
+
+type NetworkCoordinatorMockGetUpgradeStatusInput struct {
+	p context.Context
+}
+
+type NetworkCoordinatorMockGetUpgradeStatusResult struct {
+	r  *core.UpgradeStatus
+	r1 error
+}
+
+//Expect specifies that invocation of NetworkCoordinator.GetUpgradeStatus is expected from 1 to Infinity times
+func (m *mNetworkCoordinatorMockGetUpgradeStatus) Expect(p context.Context) *mNetworkCoordinatorMockGetUpgradeStatus {
+	m.mock.GetUpgradeStatusFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkCoordinatorMockGetUpgradeStatusExpectation{}
+	}
+	m.mainExpectation.input = &NetworkCoordinatorMockGetUpgradeStatusInput{p}
+	return m
+}
+
+//Return specifies results of invocation of NetworkCoordinator.GetUpgradeStatus
+func (m *mNetworkCoordinatorMockGetUpgradeStatus) Return(r *core.UpgradeStatus, r1 error) *NetworkCoordinatorMock {
+	m.mock.GetUpgradeStatusFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkCoordinatorMockGetUpgradeStatusExpectation{}
+	}
+	m.mainExpectation.result = &NetworkCoordinatorMockGetUpgradeStatusResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NetworkCoordinator.GetUpgradeStatus is expected once
+func (m *mNetworkCoordinatorMockGetUpgradeStatus) ExpectOnce(p context.Context) *NetworkCoordinatorMockGetUpgradeStatusExpectation {
+	m.mock.GetUpgradeStatusFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NetworkCoordinatorMockGetUpgradeStatusExpectation{}
+	expectation.input = &NetworkCoordinatorMockGetUpgradeStatusInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NetworkCoordinatorMockGetUpgradeStatusExpectation) Return(r *core.UpgradeStatus, r1 error) {
+	e.result = &NetworkCoordinatorMockGetUpgradeStatusResult{r, r1}
+}
+
+//Set uses given function f as a mock of NetworkCoordinator.GetUpgradeStatus method
+func (m *mNetworkCoordinatorMockGetUpgradeStatus) Set(f func(p context.Context) (r *core.UpgradeStatus, r1 error)) *NetworkCoordinatorMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetUpgradeStatusFunc = f
+	return m.mock
+}
+
+//GetUpgradeStatus implements github.com/insolar/insolar/core.NetworkCoordinator interface
+func (m *NetworkCoordinatorMock) GetUpgradeStatus(p context.Context) (r *core.UpgradeStatus, r1 error) {
+	counter := atomic.AddUint64(&m.GetUpgradeStatusPreCounter, 1)
+	defer atomic.AddUint64(&m.GetUpgradeStatusCounter, 1)
+
+	if len(m.GetUpgradeStatusMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetUpgradeStatusMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NetworkCoordinatorMock.GetUpgradeStatus. %v", p)
+			return
+		}
+
+		input := m.GetUpgradeStatusMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NetworkCoordinatorMockGetUpgradeStatusInput{p}, "NetworkCoordinator.GetUpgradeStatus got unexpected parameters")
+
+		result := m.GetUpgradeStatusMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkCoordinatorMock.GetUpgradeStatus")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetUpgradeStatusMock.mainExpectation != nil {
+
+		input := m.GetUpgradeStatusMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NetworkCoordinatorMockGetUpgradeStatusInput{p}, "NetworkCoordinator.GetUpgradeStatus got unexpected parameters")
+		}
+
+		result := m.GetUpgradeStatusMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkCoordinatorMock.GetUpgradeStatus")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetUpgradeStatusFunc == nil {
+		m.t.Fatalf("Unexpected call to NetworkCoordinatorMock.GetUpgradeStatus. %v", p)
+		return
+	}
+
+	return m.GetUpgradeStatusFunc(p)
+}
+
+//GetUpgradeStatusMinimockCounter returns a count of NetworkCoordinatorMock.GetUpgradeStatusFunc invocations
+func (m *NetworkCoordinatorMock) GetUpgradeStatusMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetUpgradeStatusCounter)
+}
+
+//GetUpgradeStatusMinimockPreCounter returns the value of NetworkCoordinatorMock.GetUpgradeStatus invocations
+func (m *NetworkCoordinatorMock) GetUpgradeStatusMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetUpgradeStatusPreCounter)
+}
+
+//GetUpgradeStatusFinished returns true if mock invocations count is ok
+func (m *NetworkCoordinatorMock) GetUpgradeStatusFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetUpgradeStatusMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetUpgradeStatusCounter) == uint64(len(m.GetUpgradeStatusMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetUpgradeStatusMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetUpgradeStatusCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetUpgradeStatusFunc != nil {
+		return atomic.LoadUint64(&m.GetUpgradeStatusCounter) > 0
+	}
+
+	return true
+}
+
 type mNetworkCoordinatorMockIsStarted struct {
 	mock              *NetworkCoordinatorMock
 	mainExpectation   *NetworkCoordinatorMockIsStartedExpectation
@@ -490,6 +805,159 @@ func (m *NetworkCoordinatorMock) SetPulseFinished() bool {
 	return true
 }
 
+type mNetworkCoordinatorMockStartUpgrade struct {
+	mock              *NetworkCoordinatorMock
+	mainExpectation   *NetworkCoordinatorMockStartUpgradeExpectation
+	expectationSeries []*NetworkCoordinatorMockStartUpgradeExpectation
+}
+
+type NetworkCoordinatorMockStartUpgradeExpectation struct {
+	input  *NetworkCoordinatorMockStartUpgradeInput
+	result *NetworkCoordinatorMockStartUpgradeResult
+}
+
+type NetworkCoordinatorMockStartUpgradeInput struct {
+	p  context.Context
+	p1 []core.RecordRef
+	p2 string
+	p3 int
+}
+
+type NetworkCoordinatorMockStartUpgradeResult struct {
+	r  *core.UpgradeStatus
+	r1 error
+}
+
+//Expect specifies that invocation of NetworkCoordinator.StartUpgrade is expected from 1 to Infinity times
+func (m *mNetworkCoordinatorMockStartUpgrade) Expect(p context.Context, p1 []core.RecordRef, p2 string, p3 int) *mNetworkCoordinatorMockStartUpgrade {
+	m.mock.StartUpgradeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkCoordinatorMockStartUpgradeExpectation{}
+	}
+	m.mainExpectation.input = &NetworkCoordinatorMockStartUpgradeInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of NetworkCoordinator.StartUpgrade
+func (m *mNetworkCoordinatorMockStartUpgrade) Return(r *core.UpgradeStatus, r1 error) *NetworkCoordinatorMock {
+	m.mock.StartUpgradeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkCoordinatorMockStartUpgradeExpectation{}
+	}
+	m.mainExpectation.result = &NetworkCoordinatorMockStartUpgradeResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NetworkCoordinator.StartUpgrade is expected once
+func (m *mNetworkCoordinatorMockStartUpgrade) ExpectOnce(p context.Context, p1 []core.RecordRef, p2 string, p3 int) *NetworkCoordinatorMockStartUpgradeExpectation {
+	m.mock.StartUpgradeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NetworkCoordinatorMockStartUpgradeExpectation{}
+	expectation.input = &NetworkCoordinatorMockStartUpgradeInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NetworkCoordinatorMockStartUpgradeExpectation) Return(r *core.UpgradeStatus, r1 error) {
+	e.result = &NetworkCoordinatorMockStartUpgradeResult{r, r1}
+}
+
+//Set uses given function f as a mock of NetworkCoordinator.StartUpgrade method
+func (m *mNetworkCoordinatorMockStartUpgrade) Set(f func(p context.Context, p1 []core.RecordRef, p2 string, p3 int) (r *core.UpgradeStatus, r1 error)) *NetworkCoordinatorMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.StartUpgradeFunc = f
+	return m.mock
+}
+
+//StartUpgrade implements github.com/insolar/insolar/core.NetworkCoordinator interface
+func (m *NetworkCoordinatorMock) StartUpgrade(p context.Context, p1 []core.RecordRef, p2 string, p3 int) (r *core.UpgradeStatus, r1 error) {
+	counter := atomic.AddUint64(&m.StartUpgradePreCounter, 1)
+	defer atomic.AddUint64(&m.StartUpgradeCounter, 1)
+
+	if len(m.StartUpgradeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.StartUpgradeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NetworkCoordinatorMock.StartUpgrade. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.StartUpgradeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NetworkCoordinatorMockStartUpgradeInput{p, p1, p2, p3}, "NetworkCoordinator.StartUpgrade got unexpected parameters")
+
+		result := m.StartUpgradeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkCoordinatorMock.StartUpgrade")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.StartUpgradeMock.mainExpectation != nil {
+
+		input := m.StartUpgradeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NetworkCoordinatorMockStartUpgradeInput{p, p1, p2, p3}, "NetworkCoordinator.StartUpgrade got unexpected parameters")
+		}
+
+		result := m.StartUpgradeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkCoordinatorMock.StartUpgrade")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.StartUpgradeFunc == nil {
+		m.t.Fatalf("Unexpected call to NetworkCoordinatorMock.StartUpgrade. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.StartUpgradeFunc(p, p1, p2, p3)
+}
+
+//StartUpgradeMinimockCounter returns a count of NetworkCoordinatorMock.StartUpgradeFunc invocations
+func (m *NetworkCoordinatorMock) StartUpgradeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.StartUpgradeCounter)
+}
+
+//StartUpgradeMinimockPreCounter returns the value of NetworkCoordinatorMock.StartUpgrade invocations
+func (m *NetworkCoordinatorMock) StartUpgradeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.StartUpgradePreCounter)
+}
+
+//StartUpgradeFinished returns true if mock invocations count is ok
+func (m *NetworkCoordinatorMock) StartUpgradeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.StartUpgradeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.StartUpgradeCounter) == uint64(len(m.StartUpgradeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.StartUpgradeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.StartUpgradeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.StartUpgradeFunc != nil {
+		return atomic.LoadUint64(&m.StartUpgradeCounter) > 0
+	}
+
+	return true
+}
+
 type mNetworkCoordinatorMockValidateCert struct {
 	mock              *NetworkCoordinatorMock
 	mainExpectation   *NetworkCoordinatorMockValidateCertExpectation
@@ -645,10 +1113,18 @@ func (m *NetworkCoordinatorMock) ValidateCertFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *NetworkCoordinatorMock) ValidateCallCounters() {
 
+	if !m.CancelUpgradeFinished() {
+		m.t.Fatal("Expected call to NetworkCoordinatorMock.CancelUpgrade")
+	}
+
 	if !m.GetCertFinished() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.GetCert")
 	}
 
+	if !m.GetUpgradeStatusFinished() {
+		m.t.Fatal("Expected call to NetworkCoordinatorMock.GetUpgradeStatus")
+	}
+
 	if !m.IsStartedFinished() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.IsStarted")
 	}
@@ -657,6 +1133,10 @@ func (m *NetworkCoordinatorMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.SetPulse")
 	}
 
+	if !m.StartUpgradeFinished() {
+		m.t.Fatal("Expected call to NetworkCoordinatorMock.StartUpgrade")
+	}
+
 	if !m.ValidateCertFinished() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.ValidateCert")
 	}
@@ -678,10 +1158,18 @@ func (m *NetworkCoordinatorMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *NetworkCoordinatorMock) MinimockFinish() {
 
+	if !m.CancelUpgradeFinished() {
+		m.t.Fatal("Expected call to NetworkCoordinatorMock.CancelUpgrade")
+	}
+
 	if !m.GetCertFinished() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.GetCert")
 	}
 
+	if !m.GetUpgradeStatusFinished() {
+		m.t.Fatal("Expected call to NetworkCoordinatorMock.GetUpgradeStatus")
+	}
+
 	if !m.IsStartedFinished() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.IsStarted")
 	}
@@ -690,6 +1178,10 @@ func (m *NetworkCoordinatorMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.SetPulse")
 	}
 
+	if !m.StartUpgradeFinished() {
+		m.t.Fatal("Expected call to NetworkCoordinatorMock.StartUpgrade")
+	}
+
 	if !m.ValidateCertFinished() {
 		m.t.Fatal("Expected call to NetworkCoordinatorMock.ValidateCert")
 	}
@@ -708,9 +1200,12 @@ func (m *NetworkCoordinatorMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.CancelUpgradeFinished()
 		ok = ok && m.GetCertFinished()
+		ok = ok && m.GetUpgradeStatusFinished()
 		ok = ok && m.IsStartedFinished()
 		ok = ok && m.SetPulseFinished()
+		ok = ok && m.StartUpgradeFinished()
 		ok = ok && m.ValidateCertFinished()
 
 		if ok {
@@ -720,10 +1215,18 @@ func (m *NetworkCoordinatorMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.CancelUpgradeFinished() {
+				m.t.Error("Expected call to NetworkCoordinatorMock.CancelUpgrade")
+			}
+
 			if !m.GetCertFinished() {
 				m.t.Error("Expected call to NetworkCoordinatorMock.GetCert")
 			}
 
+			if !m.GetUpgradeStatusFinished() {
+				m.t.Error("Expected call to NetworkCoordinatorMock.GetUpgradeStatus")
+			}
+
 			if !m.IsStartedFinished() {
 				m.t.Error("Expected call to NetworkCoordinatorMock.IsStarted")
 			}
@@ -732,6 +1235,10 @@ func (m *NetworkCoordinatorMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NetworkCoordinatorMock.SetPulse")
 			}
 
+			if !m.StartUpgradeFinished() {
+				m.t.Error("Expected call to NetworkCoordinatorMock.StartUpgrade")
+			}
+
 			if !m.ValidateCertFinished() {
 				m.t.Error("Expected call to NetworkCoordinatorMock.ValidateCert")
 			}
@@ -748,10 +1255,18 @@ func (m *NetworkCoordinatorMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *NetworkCoordinatorMock) AllMocksCalled() bool {
 
+	if !m.CancelUpgradeFinished() {
+		return false
+	}
+
 	if !m.GetCertFinished() {
 		return false
 	}
 
+	if !m.GetUpgradeStatusFinished() {
+		return false
+	}
+
 	if !m.IsStartedFinished() {
 		return false
 	}
@@ -760,6 +1275,10 @@ func (m *NetworkCoordinatorMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.StartUpgradeFinished() {
+		return false
+	}
+
 	if !m.ValidateCertFinished() {
 		return false
 	}
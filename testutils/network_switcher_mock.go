@@ -20,6 +20,11 @@ import (
 type NetworkSwitcherMock struct {
 	t minimock.Tester
 
+	GetQuorumFunc       func() (r core.QuorumResult)
+	GetQuorumCounter    uint64
+	GetQuorumPreCounter uint64
+	GetQuorumMock       mNetworkSwitcherMockGetQuorum
+
 	GetStateFunc       func() (r core.NetworkState)
 	GetStateCounter    uint64
 	GetStatePreCounter uint64
@@ -39,12 +44,147 @@ func NewNetworkSwitcherMock(t minimock.Tester) *NetworkSwitcherMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.GetQuorumMock = mNetworkSwitcherMockGetQuorum{mock: m}
 	m.GetStateMock = mNetworkSwitcherMockGetState{mock: m}
 	m.OnPulseMock = mNetworkSwitcherMockOnPulse{mock: m}
 
 	return m
 }
 
+type mNetworkSwitcherMockGetQuorum struct {
+	mock              *NetworkSwitcherMock
+	mainExpectation   *NetworkSwitcherMockGetQuorumExpectation
+	expectationSeries []*NetworkSwitcherMockGetQuorumExpectation
+}
+
+type NetworkSwitcherMockGetQuorumExpectation struct {
+	result *NetworkSwitcherMockGetQuorumResult
+}
+
+type NetworkSwitcherMockGetQuorumResult struct {
+	r core.QuorumResult
+}
+
+//Expect specifies that invocation of NetworkSwitcher.GetQuorum is expected from 1 to Infinity times
+func (m *mNetworkSwitcherMockGetQuorum) Expect() *mNetworkSwitcherMockGetQuorum {
+	m.mock.GetQuorumFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkSwitcherMockGetQuorumExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of NetworkSwitcher.GetQuorum
+func (m *mNetworkSwitcherMockGetQuorum) Return(r core.QuorumResult) *NetworkSwitcherMock {
+	m.mock.GetQuorumFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NetworkSwitcherMockGetQuorumExpectation{}
+	}
+	m.mainExpectation.result = &NetworkSwitcherMockGetQuorumResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NetworkSwitcher.GetQuorum is expected once
+func (m *mNetworkSwitcherMockGetQuorum) ExpectOnce() *NetworkSwitcherMockGetQuorumExpectation {
+	m.mock.GetQuorumFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NetworkSwitcherMockGetQuorumExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NetworkSwitcherMockGetQuorumExpectation) Return(r core.QuorumResult) {
+	e.result = &NetworkSwitcherMockGetQuorumResult{r}
+}
+
+//Set uses given function f as a mock of NetworkSwitcher.GetQuorum method
+func (m *mNetworkSwitcherMockGetQuorum) Set(f func() (r core.QuorumResult)) *NetworkSwitcherMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetQuorumFunc = f
+	return m.mock
+}
+
+//GetQuorum implements github.com/insolar/insolar/core.NetworkSwitcher interface
+func (m *NetworkSwitcherMock) GetQuorum() (r core.QuorumResult) {
+	counter := atomic.AddUint64(&m.GetQuorumPreCounter, 1)
+	defer atomic.AddUint64(&m.GetQuorumCounter, 1)
+
+	if len(m.GetQuorumMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetQuorumMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NetworkSwitcherMock.GetQuorum.")
+			return
+		}
+
+		result := m.GetQuorumMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkSwitcherMock.GetQuorum")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetQuorumMock.mainExpectation != nil {
+
+		result := m.GetQuorumMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NetworkSwitcherMock.GetQuorum")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetQuorumFunc == nil {
+		m.t.Fatalf("Unexpected call to NetworkSwitcherMock.GetQuorum.")
+		return
+	}
+
+	return m.GetQuorumFunc()
+}
+
+//GetQuorumMinimockCounter returns a count of NetworkSwitcherMock.GetQuorumFunc invocations
+func (m *NetworkSwitcherMock) GetQuorumMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetQuorumCounter)
+}
+
+//GetQuorumMinimockPreCounter returns the value of NetworkSwitcherMock.GetQuorum invocations
+func (m *NetworkSwitcherMock) GetQuorumMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetQuorumPreCounter)
+}
+
+//GetQuorumFinished returns true if mock invocations count is ok
+func (m *NetworkSwitcherMock) GetQuorumFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetQuorumMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetQuorumCounter) == uint64(len(m.GetQuorumMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetQuorumMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetQuorumCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetQuorumFunc != nil {
+		return atomic.LoadUint64(&m.GetQuorumCounter) > 0
+	}
+
+	return true
+}
+
 type mNetworkSwitcherMockGetState struct {
 	mock              *NetworkSwitcherMock
 	mainExpectation   *NetworkSwitcherMockGetStateExpectation
@@ -331,6 +471,10 @@ func (m *NetworkSwitcherMock) OnPulseFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *NetworkSwitcherMock) ValidateCallCounters() {
 
+	if !m.GetQuorumFinished() {
+		m.t.Fatal("Expected call to NetworkSwitcherMock.GetQuorum")
+	}
+
 	if !m.GetStateFinished() {
 		m.t.Fatal("Expected call to NetworkSwitcherMock.GetState")
 	}
@@ -356,6 +500,10 @@ func (m *NetworkSwitcherMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *NetworkSwitcherMock) MinimockFinish() {
 
+	if !m.GetQuorumFinished() {
+		m.t.Fatal("Expected call to NetworkSwitcherMock.GetQuorum")
+	}
+
 	if !m.GetStateFinished() {
 		m.t.Fatal("Expected call to NetworkSwitcherMock.GetState")
 	}
@@ -378,6 +526,7 @@ func (m *NetworkSwitcherMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.GetQuorumFinished()
 		ok = ok && m.GetStateFinished()
 		ok = ok && m.OnPulseFinished()
 
@@ -388,6 +537,10 @@ func (m *NetworkSwitcherMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.GetQuorumFinished() {
+				m.t.Error("Expected call to NetworkSwitcherMock.GetQuorum")
+			}
+
 			if !m.GetStateFinished() {
 				m.t.Error("Expected call to NetworkSwitcherMock.GetState")
 			}
@@ -408,6 +561,10 @@ func (m *NetworkSwitcherMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *NetworkSwitcherMock) AllMocksCalled() bool {
 
+	if !m.GetQuorumFinished() {
+		return false
+	}
+
 	if !m.GetStateFinished() {
 		return false
 	}
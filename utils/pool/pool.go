@@ -0,0 +1,97 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package pool provides reusable buffers and CBOR encoders/decoders for hot serialization paths.
+package pool
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+)
+
+// maxPooledSize limits capacity of buffers returned to pool, so single huge message doesn't stay in memory.
+const maxPooledSize = 1 << 20
+
+var buffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// GetBuffer returns empty buffer from pool.
+func GetBuffer() *bytes.Buffer {
+	return buffers.Get().(*bytes.Buffer)
+}
+
+// PutBuffer returns buffer to pool. Buffer content must not be used after this call.
+func PutBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledSize {
+		return
+	}
+	buf.Reset()
+	buffers.Put(buf)
+}
+
+// cborHandle is shared between all pooled encoders and decoders, it caches type info after first use
+// and is safe for concurrent use.
+var cborHandle = &codec.CborHandle{}
+
+type encoder struct {
+	enc *codec.Encoder
+	out []byte
+}
+
+var encoders = sync.Pool{
+	New: func() interface{} {
+		e := &encoder{}
+		e.enc = codec.NewEncoderBytes(&e.out, cborHandle)
+		return e
+	},
+}
+
+var decoders = sync.Pool{
+	New: func() interface{} {
+		return codec.NewDecoderBytes(nil, cborHandle)
+	},
+}
+
+// MarshalCBOR encodes value with CBOR appending result to dst.
+func MarshalCBOR(dst []byte, what interface{}) ([]byte, error) {
+	e := encoders.Get().(*encoder)
+	defer func() {
+		if cap(e.out) <= maxPooledSize {
+			encoders.Put(e)
+		}
+	}()
+
+	e.out = e.out[:0]
+	e.enc.ResetBytes(&e.out)
+	if err := e.enc.Encode(what); err != nil {
+		return dst, err
+	}
+	return append(dst, e.out...), nil
+}
+
+// UnmarshalCBOR decodes CBOR data into value.
+func UnmarshalCBOR(data []byte, into interface{}) error {
+	dec := decoders.Get().(*codec.Decoder)
+	defer decoders.Put(dec)
+
+	dec.ResetBytes(data)
+	return dec.Decode(into)
+}
@@ -0,0 +1,71 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package backoff provides an exponential-backoff implementation.
+package pool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testStruct struct {
+	Name  string
+	Value []byte
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	prefix := []byte{1, 2}
+	data, err := MarshalCBOR(prefix, &testStruct{Name: "name", Value: []byte{3, 4}})
+	require.NoError(t, err)
+	require.Equal(t, prefix, data[:2])
+
+	// pooled encoder must not share output between calls
+	other, err := MarshalCBOR(nil, &testStruct{Name: "other"})
+	require.NoError(t, err)
+
+	var decoded testStruct
+	require.NoError(t, UnmarshalCBOR(data[2:], &decoded))
+	require.Equal(t, testStruct{Name: "name", Value: []byte{3, 4}}, decoded)
+
+	decoded = testStruct{}
+	require.NoError(t, UnmarshalCBOR(other, &decoded))
+	require.Equal(t, "other", decoded.Name)
+
+	require.Error(t, UnmarshalCBOR([]byte{0xff}, &decoded))
+}
+
+func TestBuffer(t *testing.T) {
+	buf := GetBuffer()
+	buf.WriteString("data")
+	PutBuffer(buf)
+	require.Equal(t, 0, GetBuffer().Len())
+
+	big := GetBuffer()
+	big.Grow(maxPooledSize + 1)
+	PutBuffer(big)
+}
+
+func BenchmarkMarshalCBOR(b *testing.B) {
+	v := &testStruct{Name: "name", Value: make([]byte, 256)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := MarshalCBOR(nil, v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}